@@ -0,0 +1,321 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/enginefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Abort Builds API", func() {
+	var (
+		fakeaccess *accessorfakes.FakeAccess
+
+		build1 *dbfakes.FakeBuild
+		build2 *dbfakes.FakeBuild
+
+		engineBuild1 *enginefakes.FakeBuild
+		engineBuild2 *enginefakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+
+		build1 = new(dbfakes.FakeBuild)
+		build1.IDReturns(1)
+		build1.NameReturns("1")
+		build1.TeamNameReturns("a-team")
+		build1.StatusReturns(db.BuildStatusAborted)
+
+		build2 = new(dbfakes.FakeBuild)
+		build2.IDReturns(2)
+		build2.NameReturns("2")
+		build2.TeamNameReturns("a-team")
+		build2.StatusReturns(db.BuildStatusAborted)
+
+		engineBuild1 = new(enginefakes.FakeBuild)
+		engineBuild2 = new(enginefakes.FakeBuild)
+		engineBuild2.AbortReturns(errors.New("nope"))
+
+		fakeEngine.LookupBuildStub = func(_ lager.Logger, build db.Build) (engine.Build, error) {
+			if build.ID() == 1 {
+				return engineBuild1, nil
+			}
+
+			return engineBuild2, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("POST /api/v1/teams/:team_name/builds/abort", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"pipeline_name":"a-pipeline","statuses":["pending"]}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/builds/abort", "application/json", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not abort any builds", func() {
+				Expect(dbTeam.AbortBuildsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				dbTeam.AbortBuildsReturns([]db.Build{build1, build2}, nil)
+			})
+
+			It("marks the matching builds as aborted", func() {
+				Expect(dbTeam.AbortBuildsCallCount()).To(Equal(1))
+				filter, _, _ := dbTeam.AbortBuildsArgsForCall(0)
+				Expect(filter).To(Equal(db.AbortBuildsFilter{
+					PipelineName: "a-pipeline",
+					Statuses:     []db.BuildStatus{db.BuildStatusPending},
+				}))
+			})
+
+			It("does not save the abort reason separately from marking the builds as aborted", func() {
+				Expect(build1.SaveAbortReasonCallCount()).To(BeZero())
+				Expect(build2.SaveAbortReasonCallCount()).To(BeZero())
+			})
+
+			It("aborts each of the builds", func() {
+				Expect(engineBuild1.AbortCallCount()).To(Equal(1))
+				Expect(engineBuild2.AbortCallCount()).To(Equal(1))
+			})
+
+			Context("when the request is made by a user", func() {
+				BeforeEach(func() {
+					fakeaccess.UserNameReturns("some-user")
+				})
+
+				It("records who aborted the builds along with marking them as aborted", func() {
+					_, reason, abortedBy := dbTeam.AbortBuildsArgsForCall(0)
+					Expect(reason).To(BeEmpty())
+					Expect(abortedBy).To(Equal("some-user"))
+				})
+
+				Context("when a reason is given", func() {
+					BeforeEach(func() {
+						requestBody = `{"pipeline_name":"a-pipeline","statuses":["pending"],"reason":"  wrong branch "}`
+					})
+
+					It("records the reason along with marking the builds as aborted", func() {
+						_, reason, _ := dbTeam.AbortBuildsArgsForCall(0)
+						Expect(reason).To(Equal("wrong branch"))
+					})
+				})
+			})
+
+			Context("when looking up a build fails", func() {
+				BeforeEach(func() {
+					fakeEngine.LookupBuildStub = func(_ lager.Logger, build db.Build) (engine.Build, error) {
+						if build.ID() == 1 {
+							return nil, errors.New("disaster")
+						}
+
+						return engineBuild2, nil
+					}
+				})
+
+				It("reports the error for the build and aborts the rest", func() {
+					Expect(engineBuild2.AbortCallCount()).To(Equal(1))
+
+					var aborted []atc.AbortedBuild
+					Expect(json.NewDecoder(response.Body).Decode(&aborted)).To(Succeed())
+					Expect(aborted).To(HaveLen(2))
+					Expect(aborted[0].Error).To(Equal("disaster"))
+				})
+			})
+
+			It("returns 200 OK with the outcome for each build", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"build": {
+							"id": 1,
+							"name": "1",
+							"team_name": "a-team",
+							"status": "aborted",
+							"api_url": "/api/v1/builds/1"
+						}
+					},
+					{
+						"build": {
+							"id": 2,
+							"name": "2",
+							"team_name": "a-team",
+							"status": "aborted",
+							"api_url": "/api/v1/builds/2"
+						},
+						"error": "nope"
+					}
+				]`))
+			})
+
+			Context("when the request body is empty", func() {
+				BeforeEach(func() {
+					requestBody = ``
+				})
+
+				It("aborts all running and pending builds", func() {
+					Expect(dbTeam.AbortBuildsCallCount()).To(Equal(1))
+					filter, _, _ := dbTeam.AbortBuildsArgsForCall(0)
+					Expect(filter).To(Equal(db.AbortBuildsFilter{}))
+				})
+			})
+
+			Context("when a status cannot be aborted", func() {
+				BeforeEach(func() {
+					requestBody = `{"statuses":["succeeded"]}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.AbortBuildsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when marking the builds as aborted fails", func() {
+				BeforeEach(func() {
+					dbTeam.AbortBuildsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/abort", func() {
+		var (
+			fakeJob  *dbfakes.FakeJob
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.AbortBuildsReturns([]db.Build{build1}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/jobs/a-job/builds/abort", "application/json", bytes.NewBufferString(`{"statuses":["started"]}`))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.TeamNamesReturns([]string{"a-team"})
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("aborts the job's matching builds", func() {
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("a-job"))
+
+					Expect(fakeJob.AbortBuildsCallCount()).To(Equal(1))
+					filter, _, _ := fakeJob.AbortBuildsArgsForCall(0)
+					Expect(filter).To(Equal(db.AbortBuildsFilter{
+						Statuses: []db.BuildStatus{db.BuildStatusStarted},
+					}))
+
+					Expect(engineBuild1.AbortCallCount()).To(Equal(1))
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("records who aborted the builds", func() {
+					_, _, abortedBy := fakeJob.AbortBuildsArgsForCall(0)
+					Expect(abortedBy).To(Equal("a-team"))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -12,6 +12,7 @@ type Access interface {
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
+	UserName() string
 	CSRFToken() string
 }
 
@@ -69,6 +70,17 @@ func (a *access) TeamNames() []string {
 	return []string{}
 }
 
+func (a *access) UserName() string {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if userNameClaim, ok := claims["user_name"]; ok {
+			if userName, ok := userNameClaim.(string); ok {
+				return userName
+			}
+		}
+	}
+	return ""
+}
+
 func (a *access) CSRFToken() string {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if csrfTokenClaim, ok := claims["csrf"]; ok {
@@ -17,12 +17,22 @@ type AccessFactory interface {
 }
 
 type accessFactory struct {
-	publicKey *rsa.PublicKey
+	publicKeys      []*rsa.PublicKey
+	issuer          string
+	requireAudience bool
 }
 
-func NewAccessFactory(key *rsa.PublicKey) AccessFactory {
+// NewAccessFactory validates tokens signed by any of the given keys, so that
+// tokens signed by an old key remain valid while the signing key is rotated.
+// Tokens with an issuer or audience claim must name the given issuer, i.e.
+// the external URL, so that tokens issued by another installation sharing a
+// key are rejected. If requireAudience is set, tokens without both claims are
+// rejected too.
+func NewAccessFactory(keys []*rsa.PublicKey, issuer string, requireAudience bool) AccessFactory {
 	return &accessFactory{
-		publicKey: key,
+		publicKeys:      keys,
+		issuer:          issuer,
+		requireAudience: requireAudience,
 	}
 }
 
@@ -39,19 +49,104 @@ func (a *accessFactory) Create(r *http.Request) Access {
 }
 
 func (a *accessFactory) parseToken(r *http.Request) (*jwt.Token, error) {
-	fun := func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return a.publicKey, nil
-	}
-
 	if ah := r.Header.Get("Authorization"); ah != "" {
 		// Should be a bearer token
 		if len(ah) > 6 && strings.ToUpper(ah[0:6]) == "BEARER" {
-			return jwt.Parse(ah[7:], fun)
+			token, err := a.parseWithAnyKey(ah[7:])
+			if err != nil {
+				return nil, err
+			}
+
+			err = a.verifyIssuerAndAudience(token)
+			if err != nil {
+				return nil, err
+			}
+
+			return token, nil
 		}
 	}
 
 	return nil, errors.New("unable to parse authorization header")
 }
+
+// parseWithAnyKey returns the error of the key the token's signature was
+// verified with, e.g. that the token has expired, rather than that of
+// whichever key happened to be tried last.
+func (a *accessFactory) parseWithAnyKey(tokenString string) (*jwt.Token, error) {
+	var firstErr error
+
+	for _, key := range a.publicKeys {
+		publicKey := key
+
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+			}
+			return publicKey, nil
+		})
+		if err == nil {
+			return token, nil
+		}
+
+		if validationErr, ok := err.(*jwt.ValidationError); ok && validationErr.Errors&jwt.ValidationErrorSignatureInvalid == 0 {
+			return nil, err
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil {
+		return nil, errors.New("no keys to verify token with")
+	}
+
+	return nil, firstErr
+}
+
+// verifyIssuerAndAudience only checks the claims which are present unless
+// the audience is required, as tokens issued before the ATC named itself in
+// them carry neither.
+func (a *accessFactory) verifyIssuerAndAudience(token *jwt.Token) error {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		if a.requireAudience {
+			return errors.New("token has no issuer or audience")
+		}
+
+		return nil
+	}
+
+	iss, found := claims["iss"]
+	if !found && a.requireAudience {
+		return errors.New("token has no issuer")
+	}
+
+	if found && iss != a.issuer {
+		return fmt.Errorf("unexpected issuer: %v", iss)
+	}
+
+	aud, found := claims["aud"]
+	if !found {
+		if a.requireAudience {
+			return errors.New("token has no audience")
+		}
+
+		return nil
+	}
+
+	switch audience := aud.(type) {
+	case string:
+		if audience == a.issuer {
+			return nil
+		}
+	case []interface{}:
+		for _, audienceObj := range audience {
+			if audienceObj == a.issuer {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("unexpected audience: %v", aud)
+}
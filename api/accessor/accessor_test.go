@@ -33,7 +33,7 @@ var _ = Describe("Accessor", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		publicKey := &key.PublicKey
-		accessorFactory = accessor.NewAccessFactory(publicKey)
+		accessorFactory = accessor.NewAccessFactory([]*rsa.PublicKey{publicKey}, "https://ci.example.com", false)
 
 	})
 	Describe("Is Admin", func() {
@@ -143,6 +143,41 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("User Name", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has user name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"user_name": "some-user"}
+			})
+			It("returns the user name", func() {
+				Expect(access.UserName()).To(Equal("some-user"))
+			})
+		})
+		Context("when request has user name claim set to something other than a string", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"user_name": true}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+		Context("when request does not have user name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Is authenticated", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -152,10 +187,111 @@ var _ = Describe("Accessor", func() {
 			access = accessorFactory.Create(req)
 		})
 		Context("when valid token is set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
 			It("returns true", func() {
 				Expect(access.IsAuthenticated()).To(BeTrue())
 			})
 		})
+		Context("when the token was issued for this installation", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"iss": "https://ci.example.com",
+					"aud": []string{"https://ci.example.com"},
+				}
+			})
+			It("returns true", func() {
+				Expect(access.IsAuthenticated()).To(BeTrue())
+			})
+		})
+		Context("when the token was issued by another installation", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"iss": "https://other-ci.example.com"}
+			})
+			It("returns false", func() {
+				Expect(access.IsAuthenticated()).To(BeFalse())
+			})
+		})
+		Context("when the audience is required", func() {
+			BeforeEach(func() {
+				accessorFactory = accessor.NewAccessFactory([]*rsa.PublicKey{&key.PublicKey}, "https://ci.example.com", true)
+			})
+
+			Context("when the token has no issuer or audience", func() {
+				BeforeEach(func() {
+					claims = &jwt.MapClaims{}
+				})
+				It("returns false", func() {
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+
+			Context("when the token has an issuer but no audience", func() {
+				BeforeEach(func() {
+					claims = &jwt.MapClaims{"iss": "https://ci.example.com"}
+				})
+				It("returns false", func() {
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+
+			Context("when the token was issued for this installation", func() {
+				BeforeEach(func() {
+					claims = &jwt.MapClaims{
+						"iss": "https://ci.example.com",
+						"aud": "https://ci.example.com",
+					}
+				})
+				It("returns true", func() {
+					Expect(access.IsAuthenticated()).To(BeTrue())
+				})
+			})
+		})
+		Context("when the token was issued for another installation", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"iss": "https://ci.example.com",
+					"aud": "https://other-ci.example.com",
+				}
+			})
+			It("returns false", func() {
+				Expect(access.IsAuthenticated()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Key rotation", func() {
+		var oldKey *rsa.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			oldKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwt.MapClaims{})
+			tokenString, err := token.SignedString(oldKey)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+		})
+
+		Context("when the old key is still accepted", func() {
+			BeforeEach(func() {
+				accessorFactory = accessor.NewAccessFactory([]*rsa.PublicKey{&key.PublicKey, &oldKey.PublicKey}, "https://ci.example.com", false)
+			})
+
+			It("accepts tokens signed by the old key", func() {
+				access = accessorFactory.Create(req)
+				Expect(access.IsAuthenticated()).To(BeTrue())
+			})
+		})
+
+		Context("when the old key is no longer accepted", func() {
+			It("rejects tokens signed by the old key", func() {
+				access = accessorFactory.Create(req)
+				Expect(access.IsAuthenticated()).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("Is Authorized", func() {
@@ -64,6 +64,15 @@ type FakeAccess struct {
 	cSRFTokenReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UserNameStub        func() string
+	userNameMutex       sync.RWMutex
+	userNameArgsForCall []struct{}
+	userNameReturns     struct {
+		result1 string
+	}
+	userNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -316,6 +325,46 @@ func (fake *FakeAccess) CSRFTokenReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeAccess) UserName() string {
+	fake.userNameMutex.Lock()
+	ret, specificReturn := fake.userNameReturnsOnCall[len(fake.userNameArgsForCall)]
+	fake.userNameArgsForCall = append(fake.userNameArgsForCall, struct{}{})
+	fake.recordInvocation("UserName", []interface{}{})
+	fake.userNameMutex.Unlock()
+	if fake.UserNameStub != nil {
+		return fake.UserNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.userNameReturns.result1
+}
+
+func (fake *FakeAccess) UserNameCallCount() int {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return len(fake.userNameArgsForCall)
+}
+
+func (fake *FakeAccess) UserNameReturns(result1 string) {
+	fake.UserNameStub = nil
+	fake.userNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAccess) UserNameReturnsOnCall(i int, result1 string) {
+	fake.UserNameStub = nil
+	if fake.userNameReturnsOnCall == nil {
+		fake.userNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.userNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -331,6 +380,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.teamNamesMutex.RUnlock()
 	fake.cSRFTokenMutex.RLock()
 	defer fake.cSRFTokenMutex.RUnlock()
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
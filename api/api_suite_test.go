@@ -1,7 +1,10 @@
 package api_test
 
 import (
+	"crypto/rsa"
 	"io/ioutil"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -13,6 +16,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/auth"
@@ -27,6 +31,7 @@ import (
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
 	"github.com/concourse/atc/api/resourceserver/resourceserverfakes"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/webhooks"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/atc/wrappa"
 )
@@ -37,38 +42,42 @@ var (
 	externalURL  = "https://example.com"
 	oAuthBaseURL = "https://oauth.example.com"
 
-	fakeEngine              *enginefakes.FakeEngine
-	fakeWorkerClient        *workerfakes.FakeClient
-	fakeWorkerProvider      *workerfakes.FakeWorkerProvider
-	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
-	fakeContainerRepository *dbfakes.FakeContainerRepository
-	fakeDestroyer           *gcfakes.FakeDestroyer
-	dbTeamFactory           *dbfakes.FakeTeamFactory
-	dbPipelineFactory       *dbfakes.FakePipelineFactory
-	dbJobFactory            *dbfakes.FakeJobFactory
-	dbResourceFactory       *dbfakes.FakeResourceFactory
-	fakePipeline            *dbfakes.FakePipeline
-	fakeAccessor            *accessorfakes.FakeAccessFactory
-	dbWorkerFactory         *dbfakes.FakeWorkerFactory
-	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
-	build                   *dbfakes.FakeBuild
-	dbBuildFactory          *dbfakes.FakeBuildFactory
-	dbTeam                  *dbfakes.FakeTeam
-	fakeSchedulerFactory    *jobserverfakes.FakeSchedulerFactory
-	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
-	fakeVariablesFactory    *credsfakes.FakeVariablesFactory
-	credsManagers           creds.Managers
-	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
-	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
-	peerURL                 string
-	drain                   chan struct{}
-	expire                  time.Duration
-	isTLSEnabled            bool
-	cliDownloadsDir         string
-	logger                  *lagertest.TestLogger
+	fakeEngine                *enginefakes.FakeEngine
+	fakeWorkerClient          *workerfakes.FakeClient
+	fakeWorkerProvider        *workerfakes.FakeWorkerProvider
+	fakeVolumeRepository      *dbfakes.FakeVolumeRepository
+	fakeContainerRepository   *dbfakes.FakeContainerRepository
+	fakeDestroyer             *gcfakes.FakeDestroyer
+	dbTeamFactory             *dbfakes.FakeTeamFactory
+	dbPipelineFactory         *dbfakes.FakePipelineFactory
+	dbJobFactory              *dbfakes.FakeJobFactory
+	dbResourceFactory         *dbfakes.FakeResourceFactory
+	fakePipeline              *dbfakes.FakePipeline
+	fakeAccessor              *accessorfakes.FakeAccessFactory
+	dbWorkerFactory           *dbfakes.FakeWorkerFactory
+	dbWorkerLifecycle         *dbfakes.FakeWorkerLifecycle
+	build                     *dbfakes.FakeBuild
+	dbBuildFactory            *dbfakes.FakeBuildFactory
+	dbBuildInputUploadFactory *dbfakes.FakeBuildInputUploadFactory
+	dbTeam                    *dbfakes.FakeTeam
+	fakeSchedulerFactory      *jobserverfakes.FakeSchedulerFactory
+	fakeScannerFactory        *resourceserverfakes.FakeScannerFactory
+	fakeVariablesFactory      *credsfakes.FakeVariablesFactory
+	credsManagers             creds.Managers
+	signingKey                *rsa.PublicKey
+	interceptTimeoutFactory   *containerserverfakes.FakeInterceptTimeoutFactory
+	interceptTimeout          *containerserverfakes.FakeInterceptTimeout
+	peerURL                   string
+	drain                     chan struct{}
+	expire                    time.Duration
+	isTLSEnabled              bool
+	cliDownloadsDir           string
+	logger                    *lagertest.TestLogger
 
 	constructedEventHandler *fakeEventHandlerFactory
 
+	fakeBuildLogRetentionCalculator *gcfakes.FakeBuildLogRetentionCalculator
+
 	server *httptest.Server
 	client *http.Client
 )
@@ -99,6 +108,7 @@ var _ = BeforeEach(func() {
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbResourceFactory = new(dbfakes.FakeResourceFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
+	dbBuildInputUploadFactory = new(dbfakes.FakeBuildInputUploadFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -131,8 +141,14 @@ var _ = BeforeEach(func() {
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
 	fakeDestroyer = new(gcfakes.FakeDestroyer)
 
+	fakeBuildLogRetentionCalculator = new(gcfakes.FakeBuildLogRetentionCalculator)
+	fakeBuildLogRetentionCalculator.BuildLogsToRetainStub = func(job db.Job) int {
+		return job.Config().BuildLogsToRetain
+	}
+
 	fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
 	credsManagers = make(creds.Managers)
+	signingKey = &rsa.PublicKey{N: big.NewInt(0xc0ffee), E: 65537}
 	var err error
 
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
@@ -179,10 +195,12 @@ var _ = BeforeEach(func() {
 		fakeContainerRepository,
 		fakeDestroyer,
 		dbBuildFactory,
+		dbBuildInputUploadFactory,
 
 		peerURL,
 		constructedEventHandler.Construct,
 		drain,
+		12,
 
 		fakeEngine,
 		fakeWorkerClient,
@@ -201,6 +219,25 @@ var _ = BeforeEach(func() {
 		fakeVariablesFactory,
 		credsManagers,
 		interceptTimeoutFactory,
+		db.ContainerRetention{
+			Success: time.Hour,
+			Failure: 24 * time.Hour,
+		},
+		fakeBuildLogRetentionCalculator,
+		atc.TeamUsageQuota{
+			BuildMinutes:   600,
+			ContainerHours: 100,
+		},
+		[]*rsa.PublicKey{signingKey},
+		webhooks.TargetPolicy{
+			LookupIP: func(host string) ([]net.IP, error) {
+				if host == "internal.example.com" {
+					return []net.IP{net.ParseIP("10.1.2.3")}, nil
+				}
+
+				return []net.IP{net.ParseIP("203.0.113.10")}, nil
+			},
+		},
 	)
 
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// TokenClaimsHandler names the issuer, i.e. the external URL, as both the
+// issuer and audience of the session tokens handed out by the auth server, so
+// that they are not accepted by another installation sharing the signing key.
+//
+// Tokens are found in the auth cookie, in redirects (e.g. to fly) and in JSON
+// responses. Only tokens signed by the signing key are re-signed; anything
+// else is passed through untouched.
+type TokenClaimsHandler struct {
+	Issuer     string
+	SigningKey *rsa.PrivateKey
+	Handler    http.Handler
+}
+
+func (handler TokenClaimsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writer := &tokenClaimsResponseWriter{
+		ResponseWriter: w,
+		handler:        handler,
+	}
+
+	handler.Handler.ServeHTTP(writer, r)
+
+	writer.flush()
+}
+
+func (handler TokenClaimsHandler) claimTokens(s string) string {
+	return tokenPattern.ReplaceAllStringFunc(s, handler.claimToken)
+}
+
+func (handler TokenClaimsHandler) claimToken(tokenString string) string {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return &handler.SigningKey.PublicKey, nil
+	})
+	if err != nil {
+		return tokenString
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return tokenString
+	}
+
+	claims["iss"] = handler.Issuer
+	claims["aud"] = handler.Issuer
+
+	signed, err := token.SignedString(handler.SigningKey)
+	if err != nil {
+		return tokenString
+	}
+
+	return signed
+}
+
+// tokenClaimsResponseWriter rewrites the tokens in the headers as they are
+// written. JSON bodies are held back until the handler returns, as a token
+// may span several writes and re-signing it changes the body's length.
+type tokenClaimsResponseWriter struct {
+	http.ResponseWriter
+
+	handler TokenClaimsHandler
+
+	wroteHeader bool
+	buffering   bool
+	status      int
+	body        bytes.Buffer
+}
+
+func (w *tokenClaimsResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+
+	header := w.Header()
+
+	cookies := header["Set-Cookie"]
+	for i, cookie := range cookies {
+		cookies[i] = w.handler.claimTokens(cookie)
+	}
+
+	if location := header.Get("Location"); location != "" {
+		header.Set("Location", w.handler.claimTokens(location))
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		w.buffering = true
+		w.status = status
+		header.Del("Content-Length")
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *tokenClaimsResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffering {
+		return w.body.Write(p)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *tokenClaimsResponseWriter) flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.buffering {
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write([]byte(w.handler.claimTokens(w.body.String())))
+}
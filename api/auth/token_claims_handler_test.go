@@ -0,0 +1,196 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/api/auth"
+)
+
+var _ = Describe("TokenClaimsHandler", func() {
+	var (
+		key         *rsa.PrivateKey
+		tokenString string
+		authHandler http.HandlerFunc
+		server      *httptest.Server
+		response    *http.Response
+	)
+
+	parseClaims := func(tokenString string) jwt.MapClaims {
+		token, err := jwt.Parse(tokenString, func(*jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return token.Claims.(jwt.MapClaims)
+	}
+
+	signToken := func(signingKey *rsa.PrivateKey) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":      "some-user",
+			"is_admin": true,
+		})
+
+		tokenString, err := token.SignedString(signingKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		return tokenString
+	}
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenString = signToken(key)
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(auth.TokenClaimsHandler{
+			Issuer:     "https://ci.example.com",
+			SigningKey: key,
+			Handler:    authHandler,
+		})
+
+		client := &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		var err error
+		response, err = client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the auth cookie is set", func() {
+		BeforeEach(func() {
+			authHandler = func(w http.ResponseWriter, r *http.Request) {
+				http.SetCookie(w, &http.Cookie{
+					Name:     auth.AuthCookieName,
+					Value:    "Bearer " + tokenString,
+					Path:     "/",
+					HttpOnly: true,
+				})
+
+				http.Redirect(w, r, "/", http.StatusFound)
+			}
+		})
+
+		It("names the installation as the token's issuer and audience", func() {
+			cookies := response.Cookies()
+			Expect(cookies).To(HaveLen(1))
+			Expect(cookies[0].Name).To(Equal(auth.AuthCookieName))
+			Expect(cookies[0].HttpOnly).To(BeTrue())
+
+			claims := parseClaims(strings.TrimPrefix(cookies[0].Value, "Bearer "))
+			Expect(claims["iss"]).To(Equal("https://ci.example.com"))
+			Expect(claims["aud"]).To(Equal("https://ci.example.com"))
+			Expect(claims["sub"]).To(Equal("some-user"))
+			Expect(claims["is_admin"]).To(BeTrue())
+		})
+	})
+
+	Context("when the token is passed in a redirect", func() {
+		BeforeEach(func() {
+			authHandler = func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://127.0.0.1:1234/auth/callback?token=Bearer+"+tokenString, http.StatusFound)
+			}
+		})
+
+		It("names the installation as the token's issuer and audience", func() {
+			location, err := url.Parse(response.Header.Get("Location"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location.Host).To(Equal("127.0.0.1:1234"))
+
+			claims := parseClaims(strings.TrimPrefix(location.Query().Get("token"), "Bearer "))
+			Expect(claims["iss"]).To(Equal("https://ci.example.com"))
+			Expect(claims["aud"]).To(Equal("https://ci.example.com"))
+		})
+	})
+
+	Context("when the token is returned as JSON", func() {
+		BeforeEach(func() {
+			authHandler = func(w http.ResponseWriter, r *http.Request) {
+				body := `{"access_token":"` + tokenString + `","token_type":"Bearer"}`
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Length", "1")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(body[:10]))
+				w.Write([]byte(body[10:]))
+			}
+		})
+
+		It("names the installation as the token's issuer and audience", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+			var body struct {
+				AccessToken string `json:"access_token"`
+				TokenType   string `json:"token_type"`
+			}
+
+			err := json.NewDecoder(response.Body).Decode(&body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body.TokenType).To(Equal("Bearer"))
+
+			claims := parseClaims(body.AccessToken)
+			Expect(claims["iss"]).To(Equal("https://ci.example.com"))
+			Expect(claims["aud"]).To(Equal("https://ci.example.com"))
+		})
+	})
+
+	Context("when the token was signed by another key", func() {
+		var otherToken string
+
+		BeforeEach(func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherToken = signToken(otherKey)
+
+			authHandler = func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id_token":"` + otherToken + `"}`))
+			}
+		})
+
+		It("leaves it alone", func() {
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal(`{"id_token":"` + otherToken + `"}`))
+		})
+	})
+
+	Context("when the response has no token", func() {
+		BeforeEach(func() {
+			authHandler = func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusTeapot)
+				w.Write([]byte("<p>ci.example.com</p>"))
+			}
+		})
+
+		It("passes it through", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("<p>ci.example.com</p>"))
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package api_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +20,9 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
@@ -241,7 +247,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					teamName, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Since: 0,
 						Until: 0,
@@ -259,7 +265,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Since: 2,
 						Until: 3,
@@ -354,7 +360,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Since: 0,
 						Until: 0,
@@ -371,7 +377,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Since: 2,
 						Until: 3,
@@ -427,11 +433,96 @@ var _ = Describe("Builds API", func() {
 
 				It("returns builds for teams from the token", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
-					teamName, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, _, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(teamName).To(ConsistOf("some-team"))
 				})
 			})
 
+			Context("when filtering by labels", func() {
+				BeforeEach(func() {
+					queryParams = "?label=team:payments&label=tier:critical"
+
+					dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{
+						Next: &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("passes the labels through", func() {
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
+
+					_, filter, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					Expect(filter.Labels).To(Equal(map[string]string{
+						"team": "payments",
+						"tier": "critical",
+					}))
+				})
+
+				It("keeps the labels in the Link headers", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/builds?since=3&limit=2&label=team%%3Apayments&label=tier%%3Acritical>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when filtering by team, status and start time", func() {
+				BeforeEach(func() {
+					queryParams = "?team=some-team&status=failed&status=errored&started_since=2018-09-01T00:00:00Z&started_until=1538352000"
+
+					dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{
+						Next: &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("passes the filter through", func() {
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
+
+					_, filter, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					Expect(filter.TeamNames).To(Equal([]string{"some-team"}))
+					Expect(filter.Statuses).To(Equal([]db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored}))
+					Expect(filter.StartedSince).To(BeTemporally("==", time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)))
+					Expect(filter.StartedUntil).To(BeTemporally("==", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)))
+				})
+
+				It("keeps the filter in the Link headers", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/builds?since=3&limit=2&started_since=2018-09-01T00%%3A00%%3A00Z&started_until=1538352000&status=failed&status=errored&team=some-team>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when a status is unknown", func() {
+				BeforeEach(func() {
+					queryParams = "?status=broken"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a start time is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?started_since=yesterday"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a label selector is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?label=payments"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when next/previous pages are available", func() {
 				BeforeEach(func() {
 					dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{
@@ -608,6 +699,40 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the build errored", func() {
+							BeforeEach(func() {
+								build.StatusReturns(db.BuildStatusErrored)
+								build.ErrorReturns(&atc.BuildError{
+									Code:    atc.BuildErrorCodeNoWorkers,
+									Message: "no workers",
+									Step:    "some-task",
+								})
+							})
+
+							It("includes the error in the build", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"name": "1",
+						"status": "errored",
+						"job_name": "job1",
+						"pipeline_name": "pipeline1",
+						"team_name": "some-team",
+						"api_url": "/api/v1/builds/1",
+						"start_time": 1,
+						"end_time": 100,
+						"reap_time": 200,
+						"error": {
+							"code": "no_workers",
+							"message": "no workers",
+							"step": "some-task"
+						}
+					}`))
+							})
+						})
 					})
 				})
 			})
@@ -1037,12 +1162,17 @@ var _ = Describe("Builds API", func() {
 	Describe("PUT /api/v1/builds/:build_id/abort", func() {
 		var (
 			response *http.Response
+			query    string
 		)
 
+		BeforeEach(func() {
+			query = ""
+		})
+
 		JustBeforeEach(func() {
 			var err error
 
-			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort", nil)
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(req)
@@ -1077,6 +1207,59 @@ var _ = Describe("Builds API", func() {
 							Expect(engineBuild.AbortCallCount()).To(Equal(1))
 						})
 
+						It("saves no reason before aborting", func() {
+							Expect(build.SaveAbortReasonCallCount()).To(Equal(1))
+							reason, _ := build.SaveAbortReasonArgsForCall(0)
+							Expect(reason).To(BeEmpty())
+						})
+
+						Context("when a reason is given", func() {
+							BeforeEach(func() {
+								query = "?reason=wrong+branch"
+							})
+
+							It("saves the reason", func() {
+								reason, _ := build.SaveAbortReasonArgsForCall(0)
+								Expect(reason).To(Equal("wrong branch"))
+							})
+						})
+
+						Context("when the token names a user", func() {
+							BeforeEach(func() {
+								fakeaccess.UserNameReturns("some-user")
+							})
+
+							It("records the user as having aborted the build", func() {
+								_, abortedBy := build.SaveAbortReasonArgsForCall(0)
+								Expect(abortedBy).To(Equal("some-user"))
+							})
+						})
+
+						Context("when the token does not name a user", func() {
+							BeforeEach(func() {
+								fakeaccess.TeamNamesReturns([]string{"some-team", "other-team"})
+							})
+
+							It("records the token's teams as having aborted the build", func() {
+								_, abortedBy := build.SaveAbortReasonArgsForCall(0)
+								Expect(abortedBy).To(Equal("some-team,other-team"))
+							})
+						})
+
+						Context("when saving the reason fails", func() {
+							BeforeEach(func() {
+								build.SaveAbortReasonReturns(errors.New("oh no!"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not abort the build", func() {
+								Expect(engineBuild.AbortCallCount()).To(BeZero())
+							})
+						})
+
 						Context("when aborting succeeds", func() {
 							BeforeEach(func() {
 								engineBuild.AbortReturns(nil)
@@ -1176,6 +1359,12 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					SkippedVersions: db.SkippedVersions{
+						"some-input": {
+							Version:   db.ResourceVersion{"version": "some-version"},
+							NotPassed: []string{"some-upstream-job"},
+						},
+					},
 				}
 				dbBuildFactory.BuildReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1317,6 +1506,12 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
+					},
+					"skipped_versions": {
+						"some-input": {
+							"version": {"version": "some-version"},
+							"not_passed": ["some-upstream-job"]
+						}
 					}
 				}`))
 				})
@@ -1499,6 +1694,331 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/bundle", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/bundle")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PipelineReturns(fakePipeline, true, nil)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(false)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build is still running", func() {
+					BeforeEach(func() {
+						build.IsRunningReturns(true)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when getting the build resources fails", func() {
+					BeforeEach(func() {
+						build.ResourcesReturns(nil, nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the build has finished", func() {
+					BeforeEach(func() {
+						data := []byte(`{"some":"plan"}`)
+						build.PublicPlanReturns((*json.RawMessage)(&data))
+						build.EngineReturns("some-schema")
+						build.StatusReturns(db.BuildStatusSucceeded)
+
+						fakeEventSource := new(dbfakes.FakeEventSource)
+						envelopes := []event.Envelope{}
+						for _, ev := range []atc.Event{
+							event.Log{Time: 1, Origin: event.Origin{ID: "some-task"}, Payload: "hello "},
+							event.Log{Time: 2, Origin: event.Origin{ID: "some-task"}, Payload: "world\n"},
+							event.FinishTask{Time: 3, Origin: event.Origin{ID: "some-task"}, ExitStatus: 0},
+						} {
+							payload, err := json.Marshal(ev)
+							Expect(err).NotTo(HaveOccurred())
+							data := json.RawMessage(payload)
+							envelopes = append(envelopes, event.Envelope{
+								Data:    &data,
+								Event:   ev.EventType(),
+								Version: ev.Version(),
+							})
+						}
+
+						fakeEventSource.NextStub = func() (event.Envelope, error) {
+							if len(envelopes) == 0 {
+								return event.Envelope{}, db.ErrEndOfBuildEventStream
+							}
+
+							envelope := envelopes[0]
+							envelopes = envelopes[1:]
+							return envelope, nil
+						}
+
+						build.EventsReturns(fakeEventSource, nil)
+					})
+
+					It("returns a zip of the plan, logs, and timings", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/zip"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+						Expect(err).NotTo(HaveOccurred())
+
+						files := map[string]string{}
+						for _, file := range archive.File {
+							reader, err := file.Open()
+							Expect(err).NotTo(HaveOccurred())
+
+							content, err := ioutil.ReadAll(reader)
+							Expect(err).NotTo(HaveOccurred())
+							files[file.Name] = string(content)
+						}
+
+						Expect(files).To(HaveKey("resources.json"))
+						Expect(files["plan.json"]).To(MatchJSON(`{"schema":"some-schema","plan":{"some":"plan"}}`))
+						Expect(files["logs/some-task.log"]).To(Equal("hello world\n"))
+						Expect(files["timings.json"]).To(MatchJSON(`{
+							"build_id": 42,
+							"build_name": "",
+							"status": "succeeded",
+							"steps": [
+								{"id": "some-task", "first_event": 1, "last_event": 3, "finished": 3, "exit_status": 0}
+							]
+						}`))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_name", func() {
+		var (
+			response     *http.Response
+			artifactName string
+		)
+
+		BeforeEach(func() {
+			artifactName = "some-output"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/artifacts/" + artifactName)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(128)
+				build.TeamIDReturns(734)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has a container which produced the artifact", func() {
+				var (
+					fakeContainer *workerfakes.FakeContainer
+					fakeVolume    *workerfakes.FakeVolume
+				)
+
+				BeforeEach(func() {
+					getContainer := new(dbfakes.FakeCreatedContainer)
+					getContainer.IDReturns(1)
+					getContainer.HandleReturns("get-handle")
+					getContainer.MetadataReturns(db.ContainerMetadata{
+						Type:             db.ContainerTypeGet,
+						StepName:         "some-input",
+						WorkingDirectory: "/tmp/build/get",
+					})
+
+					taskContainer := new(dbfakes.FakeCreatedContainer)
+					taskContainer.IDReturns(2)
+					taskContainer.HandleReturns("task-handle")
+					taskContainer.MetadataReturns(db.ContainerMetadata{
+						Type:             db.ContainerTypeTask,
+						StepName:         "some-task",
+						WorkingDirectory: "/tmp/build/some-guid",
+						Outputs: db.ContainerOutputs{
+							"some-output": "/tmp/build/some-guid/some/path/",
+						},
+					})
+
+					dbTeam.FindContainersByMetadataReturns([]db.Container{getContainer, taskContainer}, nil)
+
+					fakeVolume = new(workerfakes.FakeVolume)
+					fakeVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar")), nil)
+
+					fakeContainer = new(workerfakes.FakeContainer)
+					fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+						{Volume: new(workerfakes.FakeVolume), MountPath: "/tmp/build/some-guid/some/path"},
+						{Volume: fakeVolume, MountPath: "/tmp/build/some-guid/some/path/"},
+					})
+
+					fakeWorkerClient.FindContainerByHandleReturns(fakeContainer, true, nil)
+				})
+
+				It("looks up the build's containers", func() {
+					Expect(dbTeamFactory.GetByIDArgsForCall(0)).To(Equal(734))
+					Expect(dbTeam.FindContainersByMetadataArgsForCall(0)).To(Equal(db.ContainerMetadata{
+						BuildID: 128,
+					}))
+				})
+
+				It("streams out the volume mounted at the output's path", func() {
+					_, teamID, handle := fakeWorkerClient.FindContainerByHandleArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(handle).To(Equal("task-handle"))
+
+					Expect(fakeVolume.StreamOutArgsForCall(0)).To(Equal("."))
+				})
+
+				It("responds with the tarball", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/x-tar"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("some-tar"))
+				})
+
+				Context("when no output was registered as the artifact", func() {
+					BeforeEach(func() {
+						artifactName = "path"
+					})
+
+					It("returns 404", func() {
+						Expect(fakeWorkerClient.FindContainerByHandleCallCount()).To(BeZero())
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the artifact was fetched by a get step", func() {
+					BeforeEach(func() {
+						artifactName = "some-input"
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{Volume: fakeVolume, MountPath: "/tmp/build/get"},
+						})
+					})
+
+					It("streams out the get step's volume", func() {
+						Expect(fakeWorkerClient.FindContainerByHandleCallCount()).To(Equal(2))
+
+						_, _, handle := fakeWorkerClient.FindContainerByHandleArgsForCall(1)
+						Expect(handle).To(Equal("get-handle"))
+
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when the container no longer exists", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.FindContainerByHandleReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when finding the container fails", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.FindContainerByHandleReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when streaming out the volume fails", func() {
+					BeforeEach(func() {
+						fakeVolume.StreamOutReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the build has no containers", func() {
+				BeforeEach(func() {
+					dbTeam.FindContainersByMetadataReturns(nil, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the containers fails", func() {
+				BeforeEach(func() {
+					dbTeam.FindContainersByMetadataReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/builds/:build_id/plan/:plan_id/input", func() {
 		var (
 			otherTracker *ghttp.Server
@@ -1693,6 +2213,289 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/plan/:plan_id/input in chunks", func() {
+		var (
+			engineBuild  *enginefakes.FakeBuild
+			streamedBody string
+
+			fakeWorker *workerfakes.FakeWorker
+			fakeVolume *workerfakes.FakeVolume
+
+			storedUpload *db.BuildInputUpload
+			volumeFiles  map[string]string
+		)
+
+		sendChunk := func(chunk string, contentRange string) *http.Response {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/plan/some-plan/input", bytes.NewBufferString(chunk))
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("Content-Range", contentRange)
+
+			response, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+
+			return response
+		}
+
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+
+			build.IDReturns(128)
+			build.TeamIDReturns(3)
+			build.TeamNameReturns("some-team")
+			build.TrackerReturns("http://127.0.0.1:1234")
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			engineBuild = new(enginefakes.FakeBuild)
+			fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+			engineBuild.ReceiveInputStub = func(logger lager.Logger, id atc.PlanID, stream io.ReadCloser) {
+				p, err := ioutil.ReadAll(stream)
+				Expect(err).ToNot(HaveOccurred())
+
+				streamedBody = string(p)
+
+				Expect(stream.Close()).To(Succeed())
+			}
+
+			storedUpload = nil
+
+			dbBuildInputUploadFactory.FindStub = func(buildID int, planID atc.PlanID) (*db.BuildInputUpload, bool, error) {
+				if storedUpload == nil {
+					return nil, false, nil
+				}
+
+				upload := *storedUpload
+				return &upload, true, nil
+			}
+
+			dbBuildInputUploadFactory.FindOrCreateStub = func(buildID int, planID atc.PlanID, workerName string) (*db.BuildInputUpload, error) {
+				storedUpload = &db.BuildInputUpload{
+					ID:         1,
+					BuildID:    buildID,
+					PlanID:     planID,
+					WorkerName: workerName,
+				}
+
+				upload := *storedUpload
+				return &upload, nil
+			}
+
+			dbBuildInputUploadFactory.RecordChunkStub = func(upload *db.BuildInputUpload, size int64) (bool, error) {
+				upload.Received += size
+				upload.Chunks++
+
+				recorded := *upload
+				storedUpload = &recorded
+
+				return true, nil
+			}
+
+			dbBuildInputUploadFactory.RemoveStub = func(*db.BuildInputUpload) error {
+				storedUpload = nil
+				return nil
+			}
+
+			volumeFiles = map[string]string{}
+
+			fakeVolume = new(workerfakes.FakeVolume)
+			fakeVolume.StreamInStub = func(path string, tarStream io.Reader) error {
+				gzReader, err := gzip.NewReader(tarStream)
+				if err != nil {
+					return err
+				}
+
+				tarReader := tar.NewReader(gzReader)
+
+				for {
+					header, err := tarReader.Next()
+					if err == io.EOF {
+						return nil
+					}
+
+					if err != nil {
+						return err
+					}
+
+					content, err := ioutil.ReadAll(tarReader)
+					if err != nil {
+						return err
+					}
+
+					volumeFiles[header.Name] = string(content)
+				}
+			}
+
+			fakeVolume.StreamOutStub = func(path string) (io.ReadCloser, error) {
+				buf := new(bytes.Buffer)
+
+				gzWriter := gzip.NewWriter(buf)
+				tarWriter := tar.NewWriter(gzWriter)
+
+				content := volumeFiles[path]
+				Expect(tarWriter.WriteHeader(&tar.Header{
+					Name: path,
+					Mode: 0644,
+					Size: int64(len(content)),
+				})).To(Succeed())
+
+				_, err := tarWriter.Write([]byte(content))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tarWriter.Close()).To(Succeed())
+				Expect(gzWriter.Close()).To(Succeed())
+
+				return ioutil.NopCloser(buf), nil
+			}
+
+			fakeWorker = new(workerfakes.FakeWorker)
+			fakeWorker.NameReturns("some-worker")
+			fakeWorker.FindOrCreateVolumeForBuildInputUploadReturns(fakeVolume, nil)
+
+			fakeWorkerClient.SatisfyingReturns(fakeWorker, nil)
+			fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+		})
+
+		It("accepts each chunk until the input is complete, then sends it to the plan", func() {
+			response := sendChunk("some-", "bytes 0-4/*")
+			Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+			Expect(response.Header.Get("Range")).To(Equal("bytes=0-4"))
+			Expect(engineBuild.ReceiveInputCallCount()).To(Equal(0))
+
+			response = sendChunk("payload", "bytes 5-11/12")
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(engineBuild.ReceiveInputCallCount()).To(Equal(1))
+			_, id, _ := engineBuild.ReceiveInputArgsForCall(0)
+			Expect(id).To(Equal(atc.PlanID("some-plan")))
+			Expect(streamedBody).To(Equal("some-payload"))
+		})
+
+		It("stores the chunks in a volume on a worker for the build's team", func() {
+			sendChunk("some-", "bytes 0-4/*")
+
+			Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
+			_, spec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+			Expect(spec.TeamID).To(Equal(3))
+
+			Expect(dbBuildInputUploadFactory.FindOrCreateCallCount()).To(Equal(1))
+			buildID, planID, workerName := dbBuildInputUploadFactory.FindOrCreateArgsForCall(0)
+			Expect(buildID).To(Equal(128))
+			Expect(planID).To(Equal(atc.PlanID("some-plan")))
+			Expect(workerName).To(Equal("some-worker"))
+
+			Expect(fakeWorker.FindOrCreateVolumeForBuildInputUploadCallCount()).To(Equal(1))
+			_, _, teamID, upload := fakeWorker.FindOrCreateVolumeForBuildInputUploadArgsForCall(0)
+			Expect(teamID).To(Equal(3))
+			Expect(upload.ID).To(Equal(1))
+
+			Expect(volumeFiles).To(HaveLen(1))
+			Expect(volumeFiles).To(ContainElement("some-"))
+		})
+
+		It("removes the upload once the input has been sent to the plan", func() {
+			sendChunk("some-", "bytes 0-4/*")
+			sendChunk("payload", "bytes 5-11/12")
+
+			Expect(dbBuildInputUploadFactory.RemoveCallCount()).To(Equal(1))
+			Expect(storedUpload).To(BeNil())
+		})
+
+		It("rejects a chunk which does not resume from the received offset", func() {
+			response := sendChunk("some-", "bytes 0-4/12")
+			Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+
+			response = sendChunk("load", "bytes 8-11/12")
+			Expect(response.StatusCode).To(Equal(http.StatusRequestedRangeNotSatisfiable))
+			Expect(response.Header.Get("Range")).To(Equal("bytes=0-4"))
+			Expect(engineBuild.ReceiveInputCallCount()).To(Equal(0))
+		})
+
+		It("rejects a chunk which would take the input past the maximum size", func() {
+			response := sendChunk("some-payload!", "bytes 0-12/*")
+			Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+			Expect(dbBuildInputUploadFactory.FindOrCreateCallCount()).To(Equal(0))
+		})
+
+		It("rejects an input whose total size exceeds the maximum size", func() {
+			response := sendChunk("some-", "bytes 0-4/100")
+			Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+
+		Context("when storing the chunk fails", func() {
+			BeforeEach(func() {
+				fakeVolume.StreamInStub = nil
+				fakeVolume.StreamInReturns(errors.New("nope"))
+			})
+
+			It("does not record the chunk", func() {
+				response := sendChunk("some-", "bytes 0-4/*")
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(dbBuildInputUploadFactory.RecordChunkCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the upload's worker has gone away", func() {
+			It("removes the upload so that the client starts over", func() {
+				response := sendChunk("some-", "bytes 0-4/*")
+				Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+
+				fakeWorkerClient.RunningWorkersReturns([]worker.Worker{}, nil)
+
+				response = sendChunk("payload", "bytes 5-11/12")
+				Expect(response.StatusCode).To(Equal(http.StatusRequestedRangeNotSatisfiable))
+				Expect(response.Header.Get("Range")).To(BeEmpty())
+
+				Expect(dbBuildInputUploadFactory.RemoveCallCount()).To(Equal(1))
+				Expect(engineBuild.ReceiveInputCallCount()).To(Equal(0))
+			})
+		})
+
+		It("returns Bad Request for a malformed Content-Range", func() {
+			response := sendChunk("some-payload", "bytes 0-")
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		Describe("HEAD /api/v1/builds/:build_id/plan/:plan_id/input", func() {
+			getProgress := func() *http.Response {
+				req, err := http.NewRequest("HEAD", server.URL+"/api/v1/builds/128/plan/some-plan/input", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				return response
+			}
+
+			It("returns no Range before any chunk has been received", func() {
+				response := getProgress()
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(response.Header.Get("Range")).To(BeEmpty())
+			})
+
+			It("returns the range received so far", func() {
+				sendChunk("some-", "bytes 0-4/12")
+
+				response := getProgress()
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(response.Header.Get("Range")).To(Equal("bytes=0-4"))
+			})
+
+			Context("when looking up the upload fails", func() {
+				BeforeEach(func() {
+					dbBuildInputUploadFactory.FindStub = nil
+					dbBuildInputUploadFactory.FindReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns Internal Server Error", func() {
+					response := getProgress()
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan/:plan_id/output", func() {
 		var (
 			otherTracker *ghttp.Server
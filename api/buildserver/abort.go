@@ -2,7 +2,9 @@ package buildserver
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
 
 	"code.cloudfoundry.org/lager"
@@ -21,6 +23,15 @@ func (s *Server) AbortBuild(build db.Build) http.Handler {
 			return
 		}
 
+		reason := strings.TrimSpace(r.FormValue("reason"))
+
+		err = build.SaveAbortReason(reason, abortedBy(accessor.GetAccessor(r)))
+		if err != nil {
+			aLog.Error("failed-to-save-abort-reason", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		err = engineBuild.Abort(aLog)
 		if err != nil {
 			aLog.Error("failed-to-abort-build", err)
@@ -31,3 +42,17 @@ func (s *Server) AbortBuild(build db.Build) http.Handler {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+// abortedBy identifies who requested the abort: the user if the token names
+// one, otherwise the teams it grants access to.
+func abortedBy(acc accessor.Access) string {
+	if userName := acc.UserName(); userName != "" {
+		return userName
+	}
+
+	if acc.IsSystem() {
+		return "system"
+	}
+
+	return strings.Join(acc.TeamNames(), ",")
+}
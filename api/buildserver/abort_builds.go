@@ -0,0 +1,135 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+// AbortTeamBuilds aborts every running and pending build of the team matching
+// the filter given in the request body.
+func (s *Server) AbortTeamBuilds(team db.Team) http.Handler {
+	logger := s.logger.Session("abort-team-builds")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, reason, ok := decodeAbortBuildsRequest(logger, w, r)
+		if !ok {
+			return
+		}
+
+		builds, err := team.AbortBuilds(filter, reason, abortedBy(accessor.GetAccessor(r)))
+		s.abortBuilds(logger, w, builds, err)
+	})
+}
+
+// AbortJobBuilds aborts every running and pending build of the job matching
+// the filter given in the request body.
+func (s *Server) AbortJobBuilds(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("abort-job-builds")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		filter, reason, ok := decodeAbortBuildsRequest(logger, w, r)
+		if !ok {
+			return
+		}
+
+		builds, err := job.AbortBuilds(filter, reason, abortedBy(accessor.GetAccessor(r)))
+		s.abortBuilds(logger, w, builds, err)
+	})
+}
+
+func decodeAbortBuildsRequest(logger lager.Logger, w http.ResponseWriter, r *http.Request) (db.AbortBuildsFilter, string, bool) {
+	var request atc.AbortBuildsRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return db.AbortBuildsFilter{}, "", false
+	}
+
+	filter := db.AbortBuildsFilter{
+		PipelineName: request.PipelineName,
+		JobName:      request.JobName,
+	}
+
+	for _, status := range request.Statuses {
+		filter.Statuses = append(filter.Statuses, db.BuildStatus(status))
+	}
+
+	err = filter.Validate()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return db.AbortBuildsFilter{}, "", false
+	}
+
+	return filter, strings.TrimSpace(request.Reason), true
+}
+
+// abortBuilds aborts the builds which were marked as aborted in the database,
+// along with why and by whom, and reports the outcome for each of them.
+func (s *Server) abortBuilds(logger lager.Logger, w http.ResponseWriter, builds []db.Build, err error) {
+	if err != nil {
+		logger.Error("failed-to-mark-builds-as-aborted", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	aborted := []atc.AbortedBuild{}
+	for _, build := range builds {
+		result := atc.AbortedBuild{Build: present.Build(build)}
+
+		err := s.abortBuild(logger.Session("abort", lager.Data{"build": build.ID()}), build)
+		if err != nil {
+			result.Error = err.Error()
+		}
+
+		aborted = append(aborted, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(aborted)
+	if err != nil {
+		logger.Error("failed-to-encode-aborted-builds", err)
+	}
+}
+
+func (s *Server) abortBuild(logger lager.Logger, build db.Build) error {
+	engineBuild, err := s.engine.LookupBuild(logger, build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build", err)
+		return err
+	}
+
+	err = engineBuild.Abort(logger)
+	if err != nil {
+		logger.Error("failed-to-abort-build", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package buildserver
+
+import (
+	"io"
+	"net/http"
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+// GetBuildArtifact responds with a tarball of the named artifact, streamed
+// from the volume of the step which produced it. Get steps are found by their
+// name and task outputs by the name they were registered as, taking the
+// task's output mapping into account. This only works for as long as the
+// step's container is still around.
+func (s *Server) GetBuildArtifact(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artifactName := r.FormValue(":artifact_name")
+
+		logger := s.logger.Session("get-build-artifact", lager.Data{
+			"build":    build.ID(),
+			"artifact": artifactName,
+		})
+
+		team := s.teamFactory.GetByID(build.TeamID())
+
+		containers, err := team.FindContainersByMetadata(db.ContainerMetadata{
+			BuildID: build.ID(),
+		})
+		if err != nil {
+			logger.Error("failed-to-find-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// a later step registering the same name replaces the artifact, so
+		// prefer the most recent containers
+		sort.Slice(containers, func(i, j int) bool {
+			return containers[i].ID() > containers[j].ID()
+		})
+
+		for _, container := range containers {
+			mountPath, ok := artifactMountPath(container.Metadata(), artifactName)
+			if !ok {
+				continue
+			}
+
+			volume, found, err := s.findArtifactVolume(logger, build.TeamID(), container.Handle(), mountPath)
+			if err != nil {
+				logger.Error("failed-to-find-volume", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				continue
+			}
+
+			out, err := volume.StreamOut(".")
+			if err != nil {
+				logger.Error("failed-to-stream-out-volume", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			defer out.Close()
+
+			w.Header().Set("Content-Type", "application/x-tar")
+			w.WriteHeader(http.StatusOK)
+
+			_, err = io.Copy(w, out)
+			if err != nil {
+				logger.Error("failed-to-stream-artifact", err)
+			}
+
+			return
+		}
+
+		logger.Info("artifact-not-found")
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func (s *Server) findArtifactVolume(logger lager.Logger, teamID int, handle string, mountPath string) (worker.Volume, bool, error) {
+	container, found, err := s.workerClient.FindContainerByHandle(logger, teamID, handle)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	// inputs may be mounted at the same path once cleaned, but are never
+	// given the trailing slash output mounts are
+	for _, mount := range container.VolumeMounts() {
+		if mount.MountPath == mountPath {
+			return mount.Volume, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+func artifactMountPath(metadata db.ContainerMetadata, artifactName string) (string, bool) {
+	switch metadata.Type {
+	case db.ContainerTypeGet:
+		if metadata.StepName != artifactName {
+			return "", false
+		}
+
+		return metadata.WorkingDirectory, true
+
+	case db.ContainerTypeTask:
+		mountPath, found := metadata.Outputs[artifactName]
+		return mountPath, found
+
+	default:
+		return "", false
+	}
+}
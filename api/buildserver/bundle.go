@@ -0,0 +1,254 @@
+package buildserver
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+type bundleTimings struct {
+	BuildID   int                 `json:"build_id"`
+	BuildName string              `json:"build_name"`
+	Status    string              `json:"status"`
+	StartTime int64               `json:"start_time,omitempty"`
+	EndTime   int64               `json:"end_time,omitempty"`
+	Steps     []*bundleStepTiming `json:"steps"`
+}
+
+type bundleStepTiming struct {
+	ID          event.OriginID `json:"id"`
+	FirstEvent  int64          `json:"first_event,omitempty"`
+	LastEvent   int64          `json:"last_event,omitempty"`
+	Initialized int64          `json:"initialized,omitempty"`
+	Started     int64          `json:"started,omitempty"`
+	Finished    int64          `json:"finished,omitempty"`
+	ExitStatus  *int           `json:"exit_status,omitempty"`
+}
+
+func (timing *bundleStepTiming) saw(t int64) {
+	if t == 0 {
+		return
+	}
+
+	if timing.FirstEvent == 0 || t < timing.FirstEvent {
+		timing.FirstEvent = t
+	}
+
+	if t > timing.LastEvent {
+		timing.LastEvent = t
+	}
+}
+
+// GetBuildBundle responds with a zip of everything about a finished build:
+// its plan, the logs of each step, the resource versions it used and
+// produced, and the timings of each step.
+func (s *Server) GetBuildBundle(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-bundle")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, "build is still running")
+			return
+		}
+
+		inputs, outputs, err := build.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-build-resources", err, lager.Data{"build-id": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resources := atc.BuildInputsOutputs{
+			Inputs:  make([]atc.PublicBuildInput, 0, len(inputs)),
+			Outputs: []atc.VersionedResource{},
+		}
+
+		for _, input := range inputs {
+			resources.Inputs = append(resources.Inputs, present.PublicBuildInput(input, build.PipelineID()))
+		}
+
+		for _, output := range outputs {
+			resources.Outputs = append(resources.Outputs, present.VersionedResource(output.VersionedResource))
+		}
+
+		logs, timings, err := s.collectBundleEvents(build)
+		if err != nil {
+			logger.Error("failed-to-collect-build-events", err, lager.Data{"build-id": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		files := []bundleFile{
+			{name: "plan.json", json: atc.PublicBuildPlan{Schema: build.Engine(), Plan: build.PublicPlan()}},
+			{name: "resources.json", json: resources},
+			{name: "timings.json", json: timings},
+		}
+
+		for _, step := range timings.Steps {
+			files = append(files, bundleFile{
+				name:    fmt.Sprintf("logs/%s.log", step.ID),
+				content: logs[step.ID].Bytes(),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="build-%d.zip"`, build.ID()))
+		w.WriteHeader(http.StatusOK)
+
+		err = writeBundle(w, files, build.EndTime())
+		if err != nil {
+			logger.Error("failed-to-write-bundle", err)
+		}
+	})
+}
+
+func (s *Server) collectBundleEvents(build db.Build) (map[event.OriginID]*bytes.Buffer, bundleTimings, error) {
+	timings := bundleTimings{
+		BuildID:   build.ID(),
+		BuildName: build.Name(),
+		Status:    string(build.Status()),
+		Steps:     []*bundleStepTiming{},
+	}
+
+	if !build.StartTime().IsZero() {
+		timings.StartTime = build.StartTime().Unix()
+	}
+
+	if !build.EndTime().IsZero() {
+		timings.EndTime = build.EndTime().Unix()
+	}
+
+	events, err := build.Events(0)
+	if err != nil {
+		return nil, timings, err
+	}
+
+	defer db.Close(events)
+
+	logs := map[event.OriginID]*bytes.Buffer{}
+	steps := map[event.OriginID]*bundleStepTiming{}
+
+	step := func(id event.OriginID) *bundleStepTiming {
+		if id == "" {
+			id = "build"
+		}
+
+		timing, found := steps[id]
+		if !found {
+			timing = &bundleStepTiming{ID: id}
+			steps[id] = timing
+			timings.Steps = append(timings.Steps, timing)
+			logs[id] = new(bytes.Buffer)
+		}
+
+		return timing
+	}
+
+	for {
+		envelope, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return nil, timings, err
+		}
+
+		if envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			// events from older versions may no longer be parseable; they are
+			// still in the event stream, but not worth failing the bundle over
+			continue
+		}
+
+		switch e := ev.(type) {
+		case event.Log:
+			timing := step(e.Origin.ID)
+			timing.saw(e.Time)
+			logs[timing.ID].WriteString(e.Payload)
+		case event.Error:
+			timing := step(e.Origin.ID)
+			logs[timing.ID].WriteString(e.Message + "\n")
+		case event.InitializeTask:
+			timing := step(e.Origin.ID)
+			timing.saw(e.Time)
+			timing.Initialized = e.Time
+		case event.StartTask:
+			timing := step(e.Origin.ID)
+			timing.saw(e.Time)
+			timing.Started = e.Time
+		case event.FinishTask:
+			timing := step(e.Origin.ID)
+			timing.saw(e.Time)
+			timing.Finished = e.Time
+			exitStatus := e.ExitStatus
+			timing.ExitStatus = &exitStatus
+		case event.FinishGet:
+			exitStatus := e.ExitStatus
+			step(e.Origin.ID).ExitStatus = &exitStatus
+		case event.FinishPut:
+			exitStatus := e.ExitStatus
+			step(e.Origin.ID).ExitStatus = &exitStatus
+		}
+	}
+
+	return logs, timings, nil
+}
+
+type bundleFile struct {
+	name    string
+	json    interface{}
+	content []byte
+}
+
+func writeBundle(w io.Writer, files []bundleFile, modified time.Time) error {
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+
+	archive := zip.NewWriter(w)
+
+	for _, file := range files {
+		content := file.content
+		if file.json != nil {
+			var err error
+			content, err = json.MarshalIndent(file.json, "", "  ")
+			if err != nil {
+				return err
+			}
+		}
+
+		header := &zip.FileHeader{
+			Name:   file.name,
+			Method: zip.Deflate,
+		}
+		header.SetModTime(modified)
+
+		entry, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = entry.Write(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
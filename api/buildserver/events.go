@@ -22,3 +22,20 @@ func (s *Server) BuildEvents(build db.Build) http.Handler {
 		}
 	})
 }
+
+func (s *Server) BuildEventsWebSocket(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		streamDone := make(chan struct{})
+
+		go func() {
+			defer close(streamDone)
+
+			NewWebSocketEventHandler(s.logger, build).ServeHTTP(w, r)
+		}()
+
+		select {
+		case <-streamDone:
+		case <-s.drain:
+		}
+	})
+}
@@ -0,0 +1,367 @@
+package buildserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+var ErrInvalidContentRange = errors.New("invalid Content-Range header")
+
+// ErrUnexpectedChunk is returned when a chunk does not start where the
+// previous one left off.
+var ErrUnexpectedChunk = errors.New("chunk does not start at the received offset")
+
+// ErrInputTooLarge is returned when a chunk would take the input past the
+// maximum upload size.
+var ErrInputTooLarge = errors.New("input exceeds the maximum upload size")
+
+// inputUploads keeps input uploaded in chunks in a volume on a worker until
+// all of it has arrived, so that an interrupted upload can resume from the
+// last chunk received, even after the ATC restarts. Uploads to builds which
+// have finished are removed by the garbage collector along with their
+// volumes.
+type inputUploads struct {
+	uploadFactory db.BuildInputUploadFactory
+	workerClient  worker.Client
+	maxSize       int64
+}
+
+type contentRange struct {
+	start int64
+	end   int64
+
+	// -1 if the client does not know the size of the input yet
+	total int64
+}
+
+func newInputUploads(uploadFactory db.BuildInputUploadFactory, workerClient worker.Client, maxSize int64) *inputUploads {
+	return &inputUploads{
+		uploadFactory: uploadFactory,
+		workerClient:  workerClient,
+		maxSize:       maxSize,
+	}
+}
+
+// Received returns how many bytes of the input have been received.
+func (uploads *inputUploads) Received(build db.Build, planID atc.PlanID) (int64, error) {
+	upload, found, err := uploads.uploadFactory.Find(build.ID(), planID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, nil
+	}
+
+	return upload.Received, nil
+}
+
+// WriteChunk appends the chunk to the input. Once the chunk ending the input
+// has been written, the complete input is returned, which removes the upload
+// when closed.
+//
+// A chunk which is cut short is discarded, so that the client resumes from
+// the end of the previous one.
+func (uploads *inputUploads) WriteChunk(logger lager.Logger, build db.Build, planID atc.PlanID, chunkRange contentRange, chunk io.Reader) (int64, io.ReadCloser, error) {
+	upload, found, err := uploads.uploadFactory.Find(build.ID(), planID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var received int64
+	if found {
+		received = upload.Received
+	}
+
+	if chunkRange.start != received {
+		return received, nil, ErrUnexpectedChunk
+	}
+
+	if chunkRange.end >= uploads.maxSize || chunkRange.total > uploads.maxSize {
+		return received, nil, ErrInputTooLarge
+	}
+
+	if !found {
+		chosenWorker, err := uploads.workerClient.Satisfying(
+			logger,
+			worker.WorkerSpec{TeamID: build.TeamID()},
+			creds.VersionedResourceTypes{},
+		)
+		if err != nil {
+			return received, nil, err
+		}
+
+		upload, err = uploads.uploadFactory.FindOrCreate(build.ID(), planID, chosenWorker.Name())
+		if err != nil {
+			return received, nil, err
+		}
+	}
+
+	volume, found, err := uploads.volume(logger, build.TeamID(), upload)
+	if err != nil {
+		return received, nil, err
+	}
+
+	if !found {
+		logger.Info("upload-worker-disappeared", lager.Data{"worker": upload.WorkerName})
+
+		// the chunks received so far are lost along with the worker, so the
+		// client has to start over
+		err := uploads.uploadFactory.Remove(upload)
+		if err != nil {
+			return received, nil, err
+		}
+
+		return 0, nil, ErrUnexpectedChunk
+	}
+
+	size := chunkRange.end - chunkRange.start + 1
+
+	tarStream := chunkTarStream(chunkName(upload.Chunks), size, chunk)
+
+	err = volume.StreamIn(".", tarStream)
+	tarStream.Close()
+	if err != nil {
+		return received, nil, err
+	}
+
+	recorded, err := uploads.uploadFactory.RecordChunk(upload, size)
+	if err != nil {
+		return received, nil, err
+	}
+
+	if !recorded {
+		return received, nil, ErrUnexpectedChunk
+	}
+
+	if chunkRange.total == -1 || upload.Received < chunkRange.total {
+		return upload.Received, nil, nil
+	}
+
+	return upload.Received, &uploadedInput{
+		volume: volume,
+		chunks: upload.Chunks,
+		remove: func() error {
+			return uploads.uploadFactory.Remove(upload)
+		},
+	}, nil
+}
+
+// volume returns the upload's volume, or false if its worker is no longer
+// running.
+func (uploads *inputUploads) volume(logger lager.Logger, teamID int, upload *db.BuildInputUpload) (worker.Volume, bool, error) {
+	workers, err := uploads.workerClient.RunningWorkers(logger)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, w := range workers {
+		if w.Name() != upload.WorkerName {
+			continue
+		}
+
+		volume, err := w.FindOrCreateVolumeForBuildInputUpload(
+			logger,
+			worker.VolumeSpec{
+				Strategy: baggageclaim.EmptyStrategy{},
+			},
+			teamID,
+			upload,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return volume, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func chunkName(index int) string {
+	return fmt.Sprintf("chunk-%08d", index)
+}
+
+// chunkTarStream streams the chunk as a single file in the compressed tar
+// format volumes are streamed in. The stream fails if the chunk is cut short,
+// so that it is not stored.
+func chunkTarStream(name string, size int64, chunk io.Reader) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		gzWriter := gzip.NewWriter(writer)
+		tarWriter := tar.NewWriter(gzWriter)
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     size,
+			Typeflag: tar.TypeReg,
+		})
+		if err == nil {
+			_, err = io.CopyN(tarWriter, chunk, size)
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+		}
+
+		if err == nil {
+			err = tarWriter.Close()
+		}
+
+		if err == nil {
+			err = gzWriter.Close()
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader
+}
+
+// uploadedInput reads the chunks of a complete upload back out of its volume
+// in order.
+type uploadedInput struct {
+	volume worker.Volume
+	chunks int
+	remove func() error
+
+	next    int
+	current io.ReadCloser
+}
+
+func (input *uploadedInput) Read(p []byte) (int, error) {
+	for {
+		if input.current == nil {
+			if input.next == input.chunks {
+				return 0, io.EOF
+			}
+
+			chunk, err := streamChunkOut(input.volume, chunkName(input.next))
+			if err != nil {
+				return 0, err
+			}
+
+			input.current = chunk
+			input.next++
+		}
+
+		n, err := input.current.Read(p)
+		if err == io.EOF {
+			input.current.Close()
+			input.current = nil
+
+			if n == 0 {
+				continue
+			}
+
+			err = nil
+		}
+
+		return n, err
+	}
+}
+
+func (input *uploadedInput) Close() error {
+	if input.current != nil {
+		input.current.Close()
+		input.current = nil
+	}
+
+	return input.remove()
+}
+
+func streamChunkOut(volume worker.Volume, name string) (io.ReadCloser, error) {
+	out, err := volume.StreamOut(name)
+	if err != nil {
+		return nil, err
+	}
+
+	gzReader, err := gzip.NewReader(out)
+	if err != nil {
+		out.Close()
+		return nil, err
+	}
+
+	tarReader := tar.NewReader(gzReader)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		out.Close()
+		return nil, fmt.Errorf("could not read %s of input upload: %s", name, err)
+	}
+
+	return chunkReadCloser{
+		Reader: tarReader,
+		Closer: out,
+	}, nil
+}
+
+type chunkReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// parseContentRange parses a header of the form 'bytes 0-1023/4096'. The total
+// may be given as '*' for every chunk but the last.
+func parseContentRange(header string) (contentRange, error) {
+	if !strings.HasPrefix(header, "bytes ") {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	segments := strings.Split(strings.TrimPrefix(header, "bytes "), "/")
+	if len(segments) != 2 {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	bounds := strings.Split(segments[0], "-")
+	if len(bounds) != 2 {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	start, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	end, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	total := int64(-1)
+	if segments[1] != "*" {
+		total, err = strconv.ParseInt(segments[1], 10, 64)
+		if err != nil {
+			return contentRange{}, ErrInvalidContentRange
+		}
+	}
+
+	if start < 0 || end < start || (total != -1 && end >= total) {
+		return contentRange{}, ErrInvalidContentRange
+	}
+
+	return contentRange{start: start, end: end, total: total}, nil
+}
+
+// receivedRange is the Range header describing the bytes received so far, or
+// empty if nothing has been.
+func receivedRange(received int64) string {
+	if received == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("bytes=0-%d", received-1)
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
@@ -38,11 +40,18 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 
 	page := db.Page{Until: until, Since: since, Limit: limit}
 
+	filter, err := parseBuildFilter(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
 	var builds []db.Build
 	var pagination db.Pagination
 
 	acc := accessor.GetAccessor(r)
-	builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), page)
+	builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), filter, page)
 
 	if err != nil {
 		logger.Error("failed-to-get-all-builds", err)
@@ -51,11 +60,11 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if pagination.Next != nil {
-		s.addNextLink(w, *pagination.Next)
+		s.addNextLink(w, *pagination.Next, filterQuery(r.URL.Query()))
 	}
 
 	if pagination.Previous != nil {
-		s.addPreviousLink(w, *pagination.Previous)
+		s.addPreviousLink(w, *pagination.Previous, filterQuery(r.URL.Query()))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -74,26 +83,107 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
+func (s *Server) addNextLink(w http.ResponseWriter, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQuerySince,
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery,
 		atc.LinkRelNext,
 	))
 }
 
-func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
+func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQueryUntil,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery,
 		atc.LinkRelPrevious,
 	))
 }
+
+// parseBuildFilter parses the query params which narrow down the builds
+// listed, e.g. for reporting on failures across the deployment:
+//
+//	?status=failed&status=errored&team=main&started_since=2018-09-01T00:00:00Z
+//
+// Times may be given either in RFC3339 or as seconds since the epoch. They
+// are not named 'since' and 'until', as those are the pagination cursors.
+func parseBuildFilter(query url.Values) (db.BuildFilter, error) {
+	labels, err := db.ParseLabelSelectors(query["label"])
+	if err != nil {
+		return db.BuildFilter{}, err
+	}
+
+	filter := db.BuildFilter{
+		TeamNames: query["team"],
+		Labels:    labels,
+	}
+
+	for _, status := range query["status"] {
+		filter.Statuses = append(filter.Statuses, db.BuildStatus(status))
+	}
+
+	err = filter.Validate()
+	if err != nil {
+		return db.BuildFilter{}, err
+	}
+
+	filter.StartedSince, err = parseTime(query, queryStartedSince)
+	if err != nil {
+		return db.BuildFilter{}, err
+	}
+
+	filter.StartedUntil, err = parseTime(query, queryStartedUntil)
+	if err != nil {
+		return db.BuildFilter{}, err
+	}
+
+	return filter, nil
+}
+
+const (
+	queryStartedSince = "started_since"
+	queryStartedUntil = "started_until"
+)
+
+func parseTime(query url.Values, param string) (time.Time, error) {
+	value := query.Get(param)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s '%s': expected RFC3339 or seconds since the epoch", param, value)
+	}
+
+	return t, nil
+}
+
+// filterQuery carries the filters over to the pagination links.
+func filterQuery(query url.Values) string {
+	filters := url.Values{}
+	for _, param := range []string{"label", "status", "team", queryStartedSince, queryStartedUntil} {
+		if values, found := query[param]; found {
+			filters[param] = values
+		}
+	}
+
+	if len(filters) == 0 {
+		return ""
+	}
+
+	return "&" + filters.Encode()
+}
@@ -51,6 +51,11 @@ func (s *Server) SendInputToBuildPlan(build db.Build) http.Handler {
 		}
 
 		if build.Tracker() == s.peerURL {
+			if r.Header.Get("Content-Range") != "" {
+				s.receiveInputChunk(logger, w, r, build, planID)
+				return
+			}
+
 			engineBuild, err := s.engine.LookupBuild(logger, build)
 			if err != nil {
 				logger.Error("failed-to-lookup-build", err)
@@ -74,6 +79,102 @@ func (s *Server) SendInputToBuildPlan(build db.Build) http.Handler {
 	})
 }
 
+// GetBuildPlanInputProgress responds with how much of an input uploaded in
+// chunks has been received, so that an interrupted upload can be resumed.
+func (s *Server) GetBuildPlanInputProgress(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-input-progress", lager.Data{
+			"build": build.ID(),
+		})
+
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		if len(planID) == 0 {
+			logger.Info("no-plan-id-specified")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if build.Tracker() != "" && build.Tracker() != s.peerURL {
+			logger.Debug("forwarding", lager.Data{"to": build.Tracker()})
+
+			err := s.forwardRequest(w, r, build.Tracker(), atc.GetBuildPlanInputProgress)
+			if err != nil {
+				logger.Error("failed-to-forward-request", err)
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		received, err := s.inputUploads.Received(build, planID)
+		if err != nil {
+			logger.Error("failed-to-get-input-progress", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		rangeHeader := receivedRange(received)
+		if rangeHeader != "" {
+			w.Header().Set("Range", rangeHeader)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// receiveInputChunk stores a chunk of the input, only sending the input to
+// the plan once all of it has been received.
+func (s *Server) receiveInputChunk(logger lager.Logger, w http.ResponseWriter, r *http.Request, build db.Build, planID atc.PlanID) {
+	chunkRange, err := parseContentRange(r.Header.Get("Content-Range"))
+	if err != nil {
+		logger.Info("invalid-content-range", lager.Data{"content-range": r.Header.Get("Content-Range")})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	received, input, err := s.inputUploads.WriteChunk(logger, build, planID, chunkRange, r.Body)
+
+	rangeHeader := receivedRange(received)
+	if rangeHeader != "" {
+		w.Header().Set("Range", rangeHeader)
+	}
+
+	if err == ErrUnexpectedChunk {
+		logger.Info("unexpected-chunk", lager.Data{"start": chunkRange.start, "received": received})
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	if err == ErrInputTooLarge {
+		logger.Info("input-too-large", lager.Data{"end": chunkRange.end, "total": chunkRange.total})
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-write-chunk", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if input == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	engineBuild, err := s.engine.LookupBuild(logger, build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build", err)
+		input.Close()
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	engineBuild.ReceiveInput(logger, planID, input)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (s *Server) forwardRequest(w http.ResponseWriter, r *http.Request, host string, route string) error {
 	generator := rata.NewRequestGenerator(host, atc.Routes)
 
@@ -102,6 +203,10 @@ func (s *Server) forwardRequest(w http.ResponseWriter, r *http.Request, host str
 		return err
 	}
 
+	for key, values := range response.Header {
+		w.Header()[key] = values
+	}
+
 	w.WriteHeader(response.StatusCode)
 
 	_, err = io.Copy(w, response.Body)
@@ -25,6 +25,8 @@ type Server struct {
 	eventHandlerFactory EventHandlerFactory
 	drain               <-chan struct{}
 	rejector            auth.Rejector
+
+	inputUploads *inputUploads
 }
 
 func NewServer(
@@ -35,8 +37,10 @@ func NewServer(
 	workerClient worker.Client,
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
+	buildInputUploadFactory db.BuildInputUploadFactory,
 	eventHandlerFactory EventHandlerFactory,
 	drain <-chan struct{},
+	maxInputUploadSize int64,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -52,5 +56,7 @@ func NewServer(
 		drain:               drain,
 
 		rejector: auth.UnauthorizedRejector{},
+
+		inputUploads: newInputUploads(buildInputUploadFactory, workerClient, maxInputUploadSize),
 	}
 }
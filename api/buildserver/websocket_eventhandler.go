@@ -0,0 +1,137 @@
+package buildserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/gorilla/websocket"
+)
+
+// a client which cannot take an event within this long is assumed to be gone,
+// rather than holding on to the build's event stream indefinitely
+const webSocketWriteTimeout = 30 * time.Second
+
+var eventsUpgrader = websocket.Upgrader{
+	HandshakeTimeout: 5 * time.Second,
+}
+
+// WebSocketEvent is sent as a JSON text message for each of the build's
+// events, followed by one with End set once the build has finished.
+//
+// Clients resume a stream by reconnecting with the 'from' query param set to
+// the ID after the last one they received.
+type WebSocketEvent struct {
+	ID    uint            `json:"id"`
+	Event *event.Envelope `json:"event,omitempty"`
+	End   bool            `json:"end,omitempty"`
+}
+
+// NewWebSocketEventHandler streams the build's events over a WebSocket, for
+// clients behind proxies which buffer event streams.
+//
+// Events are read from the database only as fast as the client receives
+// them, so a slow client holds back its own stream rather than events
+// piling up in memory.
+func NewWebSocketEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var eventID uint = 0
+		if from := r.URL.Query().Get("from"); from != "" {
+			_, err := fmt.Sscanf(from, "%d", &eventID)
+			if err != nil {
+				logger.Info("failed-to-parse-from", lager.Data{"from": from})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		conn, err := eventsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Error("unable-to-upgrade-connection-for-websockets", err)
+			return
+		}
+
+		defer db.Close(conn)
+
+		events, err := build.Events(eventID)
+		if err != nil {
+			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
+			closeWebSocket(logger, conn, websocket.CloseInternalServerErr, "failed to get build events")
+			return
+		}
+
+		defer db.Close(events)
+
+		// nothing is expected from the client, but reading is what handles its
+		// control messages and notices it closing the connection
+		clientGone := make(chan struct{})
+		go func() {
+			defer close(clientGone)
+
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			logger = logger.WithData(lager.Data{"id": eventID})
+
+			ev, err := events.Next()
+			if err != nil {
+				if err != db.ErrEndOfBuildEventStream {
+					logger.Error("failed-to-get-next-build-event", err)
+					closeWebSocket(logger, conn, websocket.CloseInternalServerErr, "failed to get next build event")
+					return
+				}
+
+				err := writeWebSocketEvent(conn, WebSocketEvent{ID: eventID, End: true})
+				if err != nil {
+					logger.Info("failed-to-write-end", lager.Data{"error": err.Error()})
+					return
+				}
+
+				closeWebSocket(logger, conn, websocket.CloseNormalClosure, "")
+
+				select {
+				case <-clientGone:
+				case <-time.After(webSocketWriteTimeout):
+				}
+
+				return
+			}
+
+			err = writeWebSocketEvent(conn, WebSocketEvent{ID: eventID, Event: &ev})
+			if err != nil {
+				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+				return
+			}
+
+			eventID++
+		}
+	})
+}
+
+func writeWebSocketEvent(conn *websocket.Conn, message WebSocketEvent) error {
+	err := conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteJSON(message)
+}
+
+func closeWebSocket(logger lager.Logger, conn *websocket.Conn, code int, reason string) {
+	err := conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(webSocketWriteTimeout),
+	)
+	if err != nil {
+		logger.Info("failed-to-close-websocket-connection", lager.Data{"error": err.Error()})
+	}
+}
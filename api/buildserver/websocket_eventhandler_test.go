@@ -0,0 +1,163 @@
+package buildserver_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/gorilla/websocket"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebSocket Handler", func() {
+	var (
+		build *dbfakes.FakeBuild
+
+		server *httptest.Server
+		url    string
+	)
+
+	BeforeEach(func() {
+		build = new(dbfakes.FakeBuild)
+
+		server = httptest.NewServer(NewWebSocketEventHandler(lagertest.NewTestLogger("test"), build))
+		url = "ws" + strings.TrimPrefix(server.URL, "http")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when subscribing to the build succeeds", func() {
+		var fakeEventSource *dbfakes.FakeEventSource
+		var returnedEvents []event.Envelope
+
+		BeforeEach(func() {
+			returnedEvents = []event.Envelope{
+				fakeEvent(`{"event":1}`),
+				fakeEvent(`{"event":2}`),
+				fakeEvent(`{"event":3}`),
+			}
+
+			fakeEventSource = new(dbfakes.FakeEventSource)
+
+			build.EventsStub = func(from uint) (db.EventSource, error) {
+				fakeEventSource.NextStub = func() (event.Envelope, error) {
+					if from >= uint(len(returnedEvents)) {
+						return event.Envelope{}, db.ErrEndOfBuildEventStream
+					}
+
+					from++
+
+					return returnedEvents[from-1], nil
+				}
+
+				return fakeEventSource, nil
+			}
+		})
+
+		It("emits them, followed by an end message, and closes the stream", func() {
+			conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(conn)
+
+			for i, ev := range returnedEvents {
+				var message WebSocketEvent
+				Expect(conn.ReadJSON(&message)).To(Succeed())
+				Expect(message.ID).To(Equal(uint(i)))
+				Expect(message.Event.Event).To(Equal(ev.Event))
+				Expect(*message.Event.Data).To(MatchJSON(*ev.Data))
+			}
+
+			var end WebSocketEvent
+			Expect(conn.ReadJSON(&end)).To(Succeed())
+			Expect(end).To(Equal(WebSocketEvent{ID: 3, End: true}))
+
+			_, _, err = conn.ReadMessage()
+			Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+
+			Eventually(fakeEventSource.CloseCallCount).Should(Equal(1))
+		})
+
+		It("gets the events from the start of the build", func() {
+			conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(conn)
+
+			Eventually(build.EventsCallCount).Should(Equal(1))
+			Expect(build.EventsArgsForCall(0)).To(BeZero())
+		})
+
+		Context("when resuming from an event", func() {
+			It("gets the events from that event on", func() {
+				conn, _, err := websocket.DefaultDialer.Dial(url+"?from=2", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(conn)
+
+				var message WebSocketEvent
+				Expect(conn.ReadJSON(&message)).To(Succeed())
+				Expect(message.ID).To(Equal(uint(2)))
+
+				Expect(build.EventsArgsForCall(0)).To(Equal(uint(2)))
+			})
+		})
+
+		Context("when the offset is malformed", func() {
+			It("returns 400", func() {
+				_, response, err := websocket.DefaultDialer.Dial(url+"?from=bogus", nil)
+				Expect(err).To(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("when the event stream never ends", func() {
+		var fakeEventSource *dbfakes.FakeEventSource
+
+		BeforeEach(func() {
+			fakeEventSource = new(dbfakes.FakeEventSource)
+			fakeEventSource.NextReturns(fakeEvent(`{"event":1}`), nil)
+			build.EventsReturns(fakeEventSource, nil)
+		})
+
+		It("closes the event stream when the client goes away", func() {
+			conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			var message WebSocketEvent
+			Expect(conn.ReadJSON(&message)).To(Succeed())
+
+			Expect(conn.Close()).To(Succeed())
+
+			Eventually(fakeEventSource.CloseCallCount, 30*time.Second).Should(Equal(1))
+		})
+	})
+
+	Context("when subscribing to the build fails", func() {
+		BeforeEach(func() {
+			build.EventsReturns(nil, errors.New("nope"))
+		})
+
+		It("closes the connection with an error", func() {
+			conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(conn)
+
+			_, _, err = conn.ReadMessage()
+			Expect(websocket.IsCloseError(err, websocket.CloseInternalServerErr)).To(BeTrue())
+		})
+	})
+})
@@ -2,6 +2,8 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -275,6 +277,14 @@ var _ = Describe("Config API", func() {
 									Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("1"))
 								})
 
+								It("returns the checksum of the config as X-Concourse-Config-Checksum", func() {
+									rawConfig, err := json.Marshal(pipelineConfig)
+									Expect(err).NotTo(HaveOccurred())
+
+									checksum := sha256.Sum256(rawConfig)
+									Expect(response.Header.Get(atc.ConfigChecksumHeader)).To(Equal(hex.EncodeToString(checksum[:])))
+								})
+
 								It("returns the config", func() {
 									var actualConfigResponse atc.ConfigResponse
 									err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
@@ -376,6 +386,261 @@ var _ = Describe("Config API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/checksum", func() {
+		var (
+			response *http.Response
+		)
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigChecksum, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "something-else",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team is found", func() {
+				var fakeTeam *dbfakes.FakeTeam
+				BeforeEach(func() {
+					fakeTeam = new(dbfakes.FakeTeam)
+					fakeTeam.NameReturns("a-team")
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the pipeline is found", func() {
+					var fakePipeline *dbfakes.FakePipeline
+					BeforeEach(func() {
+						fakePipeline = new(dbfakes.FakePipeline)
+						fakePipeline.NameReturns("something-else")
+						fakePipeline.ConfigVersionReturns(3)
+						fakePipeline.GroupsReturns(atc.GroupConfigs{
+							{
+								Name: "some-group",
+								Jobs: []string{"some-job"},
+							},
+						})
+
+						fakeJob := new(dbfakes.FakeJob)
+						fakeJob.ConfigReturns(atc.JobConfig{
+							Name: "some-job",
+							Plan: atc.PlanSequence{{Task: "some-task", TaskConfigPath: "some/config.yml"}},
+						})
+						fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+
+						fakeTeam.PipelineReturns(fakePipeline, true, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the checksum of the stored config and its version", func() {
+						rawConfig, err := json.Marshal(atc.Config{
+							Groups: atc.GroupConfigs{
+								{
+									Name: "some-group",
+									Jobs: []string{"some-job"},
+								},
+							},
+							Jobs: atc.JobConfigs{
+								{
+									Name: "some-job",
+									Plan: atc.PlanSequence{{Task: "some-task", TaskConfigPath: "some/config.yml"}},
+								},
+							},
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						checksum := sha256.Sum256(rawConfig)
+
+						Expect(response.Header.Get(atc.ConfigChecksumHeader)).To(Equal(hex.EncodeToString(checksum[:])))
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("3"))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"checksum": "` + hex.EncodeToString(checksum[:]) + `",
+							"config_version": 3
+						}`))
+					})
+
+					Context("when finding the jobs fails", func() {
+						BeforeEach(func() {
+							fakePipeline.JobsReturns(nil, errors.New("failed"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the pipeline is not found", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/diff", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{
+				"groups": [{"name": "some-group", "jobs": ["some-job", "some-other-job"]}],
+				"jobs": [
+					{"name": "some-job", "serial": true, "plan": [{"task": "some-task", "file": "some/other-config.yml"}]},
+					{"name": "some-other-job", "plan": [{"task": "some-task", "file": "some/config.yml"}]}
+				]
+			}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.DiffConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the pipeline is found", func() {
+				var fakePipeline *dbfakes.FakePipeline
+
+				BeforeEach(func() {
+					fakePipeline = new(dbfakes.FakePipeline)
+					fakePipeline.GroupsReturns(atc.GroupConfigs{
+						{
+							Name: "some-group",
+							Jobs: []string{"some-job"},
+						},
+					})
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name: "some-job",
+						Plan: atc.PlanSequence{{Task: "some-task", TaskConfigPath: "some/config.yml"}},
+					})
+
+					fakeRemovedJob := new(dbfakes.FakeJob)
+					fakeRemovedJob.ConfigReturns(atc.JobConfig{
+						Name: "some-removed-job",
+						Plan: atc.PlanSequence{{Task: "some-task", TaskConfigPath: "some/config.yml"}},
+					})
+
+					fakePipeline.JobsReturns(db.Jobs{fakeJob, fakeRemovedJob}, nil)
+
+					dbTeam.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns the diff against the stored config", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"groups": {
+							"changed": [{"name": "some-group", "fields": ["jobs"]}]
+						},
+						"resources": {},
+						"resource_types": {},
+						"jobs": {
+							"added": ["some-other-job"],
+							"removed": ["some-removed-job"],
+							"changed": [{"name": "some-job", "fields": ["plan", "serial"]}]
+						}
+					}`))
+				})
+
+				It("does not save the config", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline does not exist yet", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns everything as added", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var diff atc.ConfigDiff
+					Expect(json.NewDecoder(response.Body).Decode(&diff)).To(Succeed())
+					Expect(diff.Groups.Added).To(Equal([]string{"some-group"}))
+					Expect(diff.Jobs.Added).To(Equal([]string{"some-job", "some-other-job"}))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					requestBody = `{"jobs": [{"name": "some-job"}, {"name": "some-job"}]}`
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
 			request  *http.Request
@@ -398,9 +663,16 @@ var _ = Describe("Config API", func() {
 		})
 
 		Context("when authorized", func() {
+			var savedPipeline *dbfakes.FakePipeline
+
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				dbTeam.AllowPrivilegedReturns(true)
+
+				savedPipeline = new(dbfakes.FakePipeline)
+				savedPipeline.GroupsReturns(atc.GroupConfigs{{Name: "saved-group"}})
+				dbTeam.SavePipelineReturns(savedPipeline, false, nil)
 			})
 
 			Context("when a config version is specified", func() {
@@ -495,6 +767,30 @@ var _ = Describe("Config API", func() {
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						It("returns the checksum of the saved config as X-Concourse-Config-Checksum", func() {
+							rawConfig, err := json.Marshal(atc.Config{
+								Groups: atc.GroupConfigs{{Name: "saved-group"}},
+							})
+							Expect(err).NotTo(HaveOccurred())
+
+							checksum := sha256.Sum256(rawConfig)
+							Expect(response.Header.Get(atc.ConfigChecksumHeader)).To(Equal(hex.EncodeToString(checksum[:])))
+						})
+
+						Context("and getting the saved config fails", func() {
+							BeforeEach(func() {
+								savedPipeline.JobsReturns(nil, errors.New("oh no!"))
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+
+							It("leaves out the checksum", func() {
+								Expect(response.Header).NotTo(HaveKey(atc.ConfigChecksumHeader))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
 								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
@@ -549,6 +845,29 @@ var _ = Describe("Config API", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
 							})
 						})
+
+						Context("when the team is not allowed to run privileged containers", func() {
+							BeforeEach(func() {
+								dbTeam.AllowPrivilegedReturns(false)
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("returns error JSON", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+								{
+									"errors": [
+										"task 'some-task' in job 'some-job' is privileged, but the team is not allowed to run privileged containers"
+									]
+								}`))
+							})
+
+							It("does not save it", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							})
+						})
 					})
 
 					Context("YAML", func() {
@@ -671,6 +990,30 @@ jobs:
 							})
 						})
 
+						Context("when a step's tags are given by a var", func() {
+							BeforeEach(func() {
+								payload := `---
+resources:
+- name: some-resource
+  type: some-type
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    tags: ((deploy_zone))`
+
+								request.Header.Set("Content-Type", "application/x-yaml")
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+							})
+
+							It("saves the var as the step's tags", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedConfig.Jobs[0].Plan[0].Tags).To(Equal(atc.Tags{"((deploy_zone))"}))
+							})
+						})
+
 						Context("when it contains credentials to be interpolated", func() {
 							var (
 								payloadAsConfig atc.Config
@@ -0,0 +1,127 @@
+package configserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) GetConfigChecksum(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-checksum")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	checksum, err := savedConfigChecksum(logger, pipeline)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
+	w.Header().Set(atc.ConfigChecksumHeader, checksum)
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(atc.ConfigChecksumResponse{
+		Checksum:      checksum,
+		ConfigVersion: int(pipeline.ConfigVersion()),
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-checksum", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// savedConfigChecksum returns the checksum of the pipeline's config as it is
+// stored.
+func savedConfigChecksum(logger lager.Logger, pipeline db.Pipeline) (string, error) {
+	savedConfig, err := pipelineConfig(logger, pipeline)
+	if err != nil {
+		return "", err
+	}
+
+	checksum, err := configChecksum(savedConfig)
+	if err != nil {
+		logger.Error("failed-to-checksum-config", err)
+		return "", err
+	}
+
+	return checksum, nil
+}
+
+// pipelineConfig assembles the pipeline's config as it is stored, which is
+// what its checksum is computed over regardless of how the config was
+// formatted when it was set.
+func pipelineConfig(logger lager.Logger, pipeline db.Pipeline) (atc.Config, error) {
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		logger.Error("failed-to-get-jobs", err)
+		return atc.Config{}, err
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resourceTypes", err)
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        pipeline.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+		Network:       pipeline.Network(),
+		Labels:        pipeline.Labels(),
+		MaxInFlight:   pipeline.MaxInFlight(),
+	}, nil
+}
+
+func configChecksum(config atc.Config) (string, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,97 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+// DiffConfig compares the submitted config with the pipeline's current one,
+// without saving it. The config is submitted the same way as to SaveConfig;
+// if the pipeline doesn't exist yet, everything in it is added.
+func (s *Server) DiffConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("diff-config")
+
+	config, _, err := saveConfigRequestUnmarshaler(r)
+	switch err {
+	case nil:
+	case ErrStatusUnsupportedMediaType:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	case ErrMalformedRequestPayload:
+		s.handleBadRequest(w, []string{"malformed config"}, session)
+		return
+	case ErrCouldNotDecode:
+		s.handleBadRequest(w, []string{"failed to decode config"}, session)
+		return
+	case ErrInvalidPausedValue:
+		s.handleBadRequest(w, []string{"invalid paused value"}, session)
+		return
+	default:
+		if eke, ok := err.(ExtraKeysError); ok {
+			s.handleBadRequest(w, []string{eke.Error()}, session)
+		} else {
+			session.Error("unexpected-error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	_, errorMessages := config.Validate()
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var currentConfig atc.Config
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		session.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		currentConfig, err = pipelineConfig(session, pipeline)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	diff, err := atc.DiffConfigs(currentConfig, config)
+	if err != nil {
+		session.Error("failed-to-diff-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(diff)
+	if err != nil {
+		session.Error("failed-to-encode-config-diff", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
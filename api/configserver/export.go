@@ -0,0 +1,61 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// ExportTeam responds with the team's auth settings and its pipelines, in
+// order, in a form which ImportTeam accepts.
+func (s *Server) ExportTeam(team db.Team) http.Handler {
+	logger := s.logger.Session("export-team")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logger.WithData(lager.Data{"team": team.Name()})
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		allowPrivileged := team.AllowPrivileged()
+
+		export := atc.TeamExport{
+			Team: atc.Team{
+				Name:            team.Name(),
+				Auth:            team.Auth(),
+				AllowPrivileged: &allowPrivileged,
+			},
+			Pipelines: []atc.PipelineExport{},
+		}
+
+		for _, pipeline := range pipelines {
+			config, err := pipelineConfig(logger, pipeline)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			export.Pipelines = append(export.Pipelines, atc.PipelineExport{
+				Name:   pipeline.Name(),
+				Paused: pipeline.Paused(),
+				Public: pipeline.Public(),
+				Config: config,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(export)
+		if err != nil {
+			logger.Error("failed-to-encode-team-export", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
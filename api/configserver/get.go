@@ -42,42 +42,28 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobs, err := pipeline.Jobs()
+	config, err := pipelineConfig(logger, pipeline)
 	if err != nil {
-		logger.Error("failed-to-get-jobs", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	resources, err := pipeline.Resources()
-	if err != nil {
-		logger.Error("failed-to-get-resources", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	resourceTypes, err := pipeline.ResourceTypes()
+	rawConfig, err := json.Marshal(config)
 	if err != nil {
-		logger.Error("failed-to-get-resourceTypes", err)
+		logger.Error("failed-to-marshal-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	config := atc.Config{
-		Groups:        pipeline.Groups(),
-		Resources:     resources.Configs(),
-		ResourceTypes: resourceTypes.Configs(),
-		Jobs:          jobs.Configs(),
-	}
-
-	rawConfig, err := json.Marshal(config)
+	checksum, err := configChecksum(config)
 	if err != nil {
-		logger.Error("failed-to-marshal-config", err)
+		logger.Error("failed-to-checksum-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
+	w.Header().Set(atc.ConfigChecksumHeader, checksum)
 	w.Header().Set("Content-Type", "application/json")
 
 	err = json.NewEncoder(w).Encode(atc.ConfigResponse{
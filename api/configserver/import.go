@@ -0,0 +1,227 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/db"
+)
+
+// ImportTeam recreates a team from a bundle produced by ExportTeam. The team
+// is created if it doesn't exist yet, which only admins may do, and its auth
+// settings are replaced. Each pipeline in the bundle is saved and the
+// pipelines are ordered as in the bundle; other pipelines of the team are left
+// alone. Everything is validated before anything is saved, and the auth of an
+// existing team is replaced last. A team created by a failed import is
+// deleted again.
+func (s *Server) ImportTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	session := s.logger.Session("import-team", lager.Data{"team": teamName})
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsAdmin() && !acc.IsAuthorized(teamName) {
+		session.Debug("not-allowed")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var export atc.TeamExport
+	err := json.NewDecoder(r.Body).Decode(&export)
+	if err != nil {
+		session.Info("malformed-request", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, []string{"malformed team export"}, session)
+		return
+	}
+
+	warnings, errorMessages := validateTeamExport(export)
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	allowPrivileged := found && team.AllowPrivileged()
+	if export.Team.AllowPrivileged != nil {
+		allowPrivileged = *export.Team.AllowPrivileged
+	}
+
+	if found && !acc.IsAdmin() {
+		if allowPrivileged && !team.AllowPrivileged() {
+			session.Debug("not-allowed-to-allow-privileged")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		allowPrivileged = team.AllowPrivileged()
+	} else if !found && !acc.IsAdmin() {
+		session.Debug("not-allowed-to-create-team")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if !allowPrivileged {
+		for _, pipeline := range export.Pipelines {
+			for _, message := range validateUnprivileged(pipeline.Config) {
+				errorMessages = append(errorMessages, fmt.Sprintf("pipeline '%s': %s", pipeline.Name, message))
+			}
+		}
+
+		if len(errorMessages) > 0 {
+			session.Info("privileged-not-allowed")
+			s.handleBadRequest(w, errorMessages, session)
+			return
+		}
+	}
+
+	if !found {
+		team, err = s.teamFactory.CreateTeam(atc.Team{
+			Name:            teamName,
+			Auth:            export.Team.Auth,
+			AllowPrivileged: &allowPrivileged,
+		})
+		if err != nil {
+			session.Error("failed-to-create-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	pipelineNames := []string{}
+	for _, pipeline := range export.Pipelines {
+		err = importPipeline(team, pipeline)
+		if err != nil {
+			session.Error("failed-to-import-pipeline", err, lager.Data{"pipeline": pipeline.Name})
+
+			if !found {
+				s.deleteImportedTeam(session, team)
+			}
+
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to import pipeline '%s': %s", pipeline.Name, err)
+			return
+		}
+
+		pipelineNames = append(pipelineNames, pipeline.Name)
+	}
+
+	if len(pipelineNames) > 0 {
+		err = team.OrderPipelines(pipelineNames)
+		if err != nil {
+			session.Error("failed-to-order-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// the auth of an existing team is only replaced once all of its pipelines
+	// have been saved, so that a failed import leaves it as it was
+	if found {
+		err = team.UpdateProviderAuth(export.Team.Auth)
+		if err != nil {
+			session.Error("failed-to-update-team-auth", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if acc.IsAdmin() && export.Team.AllowPrivileged != nil {
+			err = team.UpdateAllowPrivileged(allowPrivileged)
+			if err != nil {
+				session.Error("failed-to-update-team-allow-privileged", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	session.Info("imported", lager.Data{"pipelines": pipelineNames})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if found {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
+
+func (s *Server) deleteImportedTeam(logger lager.Logger, team db.Team) {
+	err := team.Delete()
+	if err != nil {
+		logger.Error("failed-to-delete-imported-team", err)
+	}
+}
+
+func validateTeamExport(export atc.TeamExport) ([]atc.Warning, []string) {
+	warnings := []atc.Warning{}
+	errorMessages := []string{}
+
+	seen := map[string]bool{}
+	for i, pipeline := range export.Pipelines {
+		if pipeline.Name == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("pipeline at index %d has no name", i))
+			continue
+		}
+
+		if seen[pipeline.Name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("pipeline '%s' appears more than once", pipeline.Name))
+			continue
+		}
+
+		seen[pipeline.Name] = true
+
+		configWarnings, configErrors := pipeline.Config.Validate()
+
+		for _, warning := range configWarnings {
+			warning.Message = fmt.Sprintf("pipeline '%s': %s", pipeline.Name, warning.Message)
+			warnings = append(warnings, warning)
+		}
+
+		for _, message := range configErrors {
+			errorMessages = append(errorMessages, fmt.Sprintf("pipeline '%s': %s", pipeline.Name, message))
+		}
+	}
+
+	return warnings, errorMessages
+}
+
+func importPipeline(team db.Team, export atc.PipelineExport) error {
+	var version db.ConfigVersion
+
+	existing, found, err := team.Pipeline(export.Name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		version = existing.ConfigVersion()
+	}
+
+	pausedState := db.PipelineUnpaused
+	if export.Paused {
+		pausedState = db.PipelinePaused
+	}
+
+	pipeline, _, err := team.SavePipeline(export.Name, export.Config, version, pausedState)
+	if err != nil {
+		return err
+	}
+
+	if export.Public {
+		return pipeline.Expose()
+	}
+
+	return pipeline.Hide()
+}
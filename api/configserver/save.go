@@ -143,7 +143,16 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	if !team.AllowPrivileged() {
+		errorMessages := validateUnprivileged(config)
+		if len(errorMessages) > 0 {
+			session.Info("privileged-not-allowed")
+			s.handleBadRequest(w, errorMessages, session)
+			return
+		}
+	}
+
+	pipeline, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -153,6 +162,15 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saved")
 
+	// the checksum is taken from the config as it was stored rather than as
+	// it was submitted, so that it matches the one returned when getting it.
+	// the config has been saved by now, so failing to take it only leaves the
+	// header out rather than failing the request
+	checksum, err := savedConfigChecksum(session, pipeline)
+	if err == nil {
+		w.Header().Set(atc.ConfigChecksumHeader, checksum)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	if created {
@@ -164,6 +182,28 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// Validate that the config does not use privileged tasks or resource types,
+// for teams which are not allowed to run privileged containers
+func validateUnprivileged(config atc.Config) []string {
+	errorMessages := []string{}
+
+	for _, resourceType := range config.ResourceTypes {
+		if resourceType.Privileged {
+			errorMessages = append(errorMessages, fmt.Sprintf("resource type '%s' is privileged, but the team is not allowed to run privileged containers", resourceType.Name))
+		}
+	}
+
+	for _, job := range config.Jobs {
+		for _, plan := range job.Plans() {
+			if plan.Task != "" && plan.Privileged {
+				errorMessages = append(errorMessages, fmt.Sprintf("task '%s' in job '%s' is privileged, but the team is not allowed to run privileged containers", plan.Task, job.Name))
+			}
+		}
+	}
+
+	return errorMessages
+}
+
 // Simply validate that the credentials exist; don't do anything with the actual secrets
 func validateCredParams(vars creds.Variables, config atc.Config, session lager.Logger) error {
 	var errs error
@@ -326,6 +366,7 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 			atc.SanitizeDecodeHook,
 			atc.VersionConfigDecodeHook,
 			atc.ContainerLimitsDecodeHook,
+			atc.TagsDecodeHook,
 		),
 	}
 
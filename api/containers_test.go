@@ -3,6 +3,7 @@ package api_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -109,6 +110,37 @@ var _ = Describe("Containers API", func() {
 				fakeaccess.IsAuthorizedReturns(true)
 			})
 
+			Context("with pagination params", func() {
+				BeforeEach(func() {
+					var err error
+					req, err = http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/containers?type=task&limit=1", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeContainer1.IDReturns(1)
+					fakeContainer2.IDReturns(2)
+					dbTeam.FindContainersByMetadataReturns([]db.Container{fakeContainer1, fakeContainer2}, nil)
+				})
+
+				It("returns the first page of containers, newest first", func() {
+					response, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					var containers []atc.Container
+					Expect(json.NewDecoder(response.Body).Decode(&containers)).To(Succeed())
+					Expect(containers).To(HaveLen(1))
+					Expect(containers[0].ID).To(Equal("some-other-handle"))
+				})
+
+				It("links to the next page, keeping the other params", func() {
+					response, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(response.Header["Link"]).To(Equal([]string{
+						fmt.Sprintf(`<%s/api/v1/teams/a-team/containers?limit=1&since=2&type=task>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
 			Context("with no params", func() {
 				Context("when no errors are returned", func() {
 					BeforeEach(func() {
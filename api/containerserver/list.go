@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"code.cloudfoundry.org/lager"
@@ -13,6 +14,9 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+// ListContainers lists the team's containers matching the query. If any of
+// the since, until or limit params are given, they are paged through by ID,
+// newest first.
 func (s *Server) ListContainers(team db.Team) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		params := r.URL.RawQuery
@@ -40,6 +44,22 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 
 		hLog.Debug("listed", lager.Data{"container-count": len(containers)})
 
+		if page, paginated := parsePage(r); paginated {
+			sort.Slice(containers, func(i, j int) bool {
+				return containers[i].ID() > containers[j].ID()
+			})
+
+			ids := make([]int, len(containers))
+			for i, container := range containers {
+				ids[i] = container.ID()
+			}
+
+			start, end, pagination := db.PageIDs(ids, page)
+			containers = containers[start:end]
+
+			s.addPaginationLinks(w, r, pagination)
+		}
+
 		presentedContainers := make([]atc.Container, len(containers))
 		for i := 0; i < len(containers); i++ {
 			container := containers[i]
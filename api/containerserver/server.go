@@ -9,7 +9,8 @@ import (
 )
 
 type Server struct {
-	logger lager.Logger
+	logger      lager.Logger
+	externalURL string
 
 	workerClient            worker.Client
 	variablesFactory        creds.VariablesFactory
@@ -20,6 +21,7 @@ type Server struct {
 
 func NewServer(
 	logger lager.Logger,
+	externalURL string,
 	workerClient worker.Client,
 	variablesFactory creds.VariablesFactory,
 	interceptTimeoutFactory InterceptTimeoutFactory,
@@ -28,6 +30,7 @@ func NewServer(
 ) *Server {
 	return &Server{
 		logger:                  logger,
+		externalURL:             externalURL,
 		workerClient:            workerClient,
 		variablesFactory:        variablesFactory,
 		interceptTimeoutFactory: interceptTimeoutFactory,
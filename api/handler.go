@@ -1,6 +1,7 @@
 package api
 
 import (
+	"crypto/rsa"
 	"net/http"
 	"path/filepath"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/mainredirect"
+	"github.com/concourse/atc/webhooks"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/wrappa"
 )
@@ -46,10 +48,12 @@ func NewHandler(
 	containerRepository db.ContainerRepository,
 	destroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
+	dbBuildInputUploadFactory db.BuildInputUploadFactory,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
 	drain <-chan struct{},
+	maxInputUploadSize int64,
 
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -68,6 +72,11 @@ func NewHandler(
 	variablesFactory creds.VariablesFactory,
 	credsManagers creds.Managers,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	maxContainerRetention db.ContainerRetention,
+	buildLogRetentionCalculator gc.BuildLogRetentionCalculator,
+	usageQuota atc.TeamUsageQuota,
+	signingKeys []*rsa.PublicKey,
+	webhookTargetPolicy webhooks.TargetPolicy,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -79,8 +88,8 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, peerURL, engine, workerClient, dbTeamFactory, dbBuildFactory, eventHandlerFactory, drain)
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory, dbJobFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, peerURL, engine, workerClient, dbTeamFactory, dbBuildFactory, dbBuildInputUploadFactory, eventHandlerFactory, drain, maxInputUploadSize)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory, dbJobFactory, buildLogRetentionCalculator)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory, variablesFactory, dbResourceFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL, engine)
@@ -88,36 +97,46 @@ func NewHandler(
 	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, workerProvider)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
-	containerServer := containerserver.NewServer(logger, workerClient, variablesFactory, interceptTimeoutFactory, containerRepository, destroyer)
-	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
-	infoServer := infoserver.NewServer(logger, version, workerVersion, credsManagers)
+	containerServer := containerserver.NewServer(logger, externalURL, workerClient, variablesFactory, interceptTimeoutFactory, containerRepository, destroyer)
+	volumesServer := volumeserver.NewServer(logger, externalURL, volumeRepository, destroyer)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL, maxContainerRetention, usageQuota, webhookTargetPolicy)
+	infoServer := infoserver.NewServer(logger, version, workerVersion, credsManagers, signingKeys)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
-
-		atc.ListBuilds:              http.HandlerFunc(buildServer.ListBuilds),
-		atc.CreateBuild:             teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
-		atc.GetBuild:                buildHandlerFactory.HandlerFor(buildServer.GetBuild),
-		atc.BuildResources:          buildHandlerFactory.HandlerFor(buildServer.BuildResources),
-		atc.AbortBuild:              buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
-		atc.GetBuildPlan:            buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
-		atc.GetBuildPreparation:     buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
-		atc.BuildEvents:             buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-		atc.SendInputToBuildPlan:    buildHandlerFactory.HandlerFor(buildServer.SendInputToBuildPlan),
-		atc.ReadOutputFromBuildPlan: buildHandlerFactory.HandlerFor(buildServer.ReadOutputFromBuildPlan),
-
-		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.GetConfig:         http.HandlerFunc(configServer.GetConfig),
+		atc.GetConfigChecksum: http.HandlerFunc(configServer.GetConfigChecksum),
+		atc.SaveConfig:        http.HandlerFunc(configServer.SaveConfig),
+		atc.DiffConfig:        http.HandlerFunc(configServer.DiffConfig),
+
+		atc.ListBuilds:                http.HandlerFunc(buildServer.ListBuilds),
+		atc.CreateBuild:               teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
+		atc.GetBuild:                  buildHandlerFactory.HandlerFor(buildServer.GetBuild),
+		atc.BuildResources:            buildHandlerFactory.HandlerFor(buildServer.BuildResources),
+		atc.AbortBuild:                buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.AbortJobBuilds:            pipelineHandlerFactory.HandlerFor(buildServer.AbortJobBuilds),
+		atc.AbortTeamBuilds:           teamHandlerFactory.HandlerFor(buildServer.AbortTeamBuilds),
+		atc.GetBuildPlan:              buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPreparation:       buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.BuildEvents:               buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+		atc.BuildEventsWS:             buildHandlerFactory.HandlerFor(buildServer.BuildEventsWebSocket),
+		atc.GetBuildBundle:            buildHandlerFactory.HandlerFor(buildServer.GetBuildBundle),
+		atc.GetBuildArtifact:          buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifact),
+		atc.SendInputToBuildPlan:      buildHandlerFactory.HandlerFor(buildServer.SendInputToBuildPlan),
+		atc.GetBuildPlanInputProgress: buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanInputProgress),
+		atc.ReadOutputFromBuildPlan:   buildHandlerFactory.HandlerFor(buildServer.ReadOutputFromBuildPlan),
+
+		atc.ListAllJobs:      http.HandlerFunc(jobServer.ListAllJobs),
+		atc.ListJobs:         pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:           pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:    pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:    pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.ExplainJobInputs: pipelineHandlerFactory.HandlerFor(jobServer.ExplainJobInputs),
+		atc.LatestJobOutputs: pipelineHandlerFactory.HandlerFor(jobServer.LatestJobOutputs),
+		atc.GetJobBuild:      pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:   pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:         pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:         pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge: mainredirect.Handler{
 			Routes: atc.Routes,
 			Route:  atc.JobBadge,
@@ -129,6 +148,7 @@ func NewHandler(
 		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:         pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
 		atc.DeletePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
+		atc.UndeletePipeline:    http.HandlerFunc(pipelineServer.UndeletePipeline),
 		atc.OrderPipelines:      http.HandlerFunc(pipelineServer.OrderPipelines),
 		atc.PausePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
 		atc.UnpausePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
@@ -139,6 +159,7 @@ func NewHandler(
 		atc.ListPipelineBuilds:  pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
 		atc.CreatePipelineBuild: pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.PipelineGraph:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineGraph),
 
 		atc.ListAllResources:     http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
@@ -146,6 +167,8 @@ func NewHandler(
 		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
 		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.PinResource:          pipelineHandlerFactory.HandlerFor(resourceServer.PinResource),
+		atc.UnpinResource:        pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 		atc.CheckResourceType:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
@@ -154,6 +177,7 @@ func NewHandler(
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.SaveResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.SaveResourceVersions),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
@@ -172,6 +196,9 @@ func NewHandler(
 		atc.DownloadCLI:  http.HandlerFunc(cliServer.Download),
 		atc.GetInfo:      http.HandlerFunc(infoServer.Info),
 		atc.GetInfoCreds: http.HandlerFunc(infoServer.Creds),
+		atc.GetInfoKeys:  http.HandlerFunc(infoServer.Keys),
+
+		atc.GetPipelineConfigSchema: http.HandlerFunc(infoServer.PipelineConfigSchema),
 
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
@@ -183,11 +210,24 @@ func NewHandler(
 		atc.ListDestroyingVolumes: http.HandlerFunc(volumesServer.ListDestroyingVolumes),
 		atc.ReportWorkerVolumes:   http.HandlerFunc(volumesServer.ReportWorkerVolumes),
 
+		atc.ListCaches:   http.HandlerFunc(volumesServer.ListCaches),
+		atc.DestroyCache: http.HandlerFunc(volumesServer.DestroyCache),
+
 		atc.ListTeams:      http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:        http.HandlerFunc(teamServer.SetTeam),
 		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
 		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
+
+		atc.GetTeamSettings: teamHandlerFactory.HandlerFor(teamServer.GetTeamSettings),
+		atc.SetTeamSettings: teamHandlerFactory.HandlerFor(teamServer.SetTeamSettings),
+		atc.GetTeamUsage:    teamHandlerFactory.HandlerFor(teamServer.GetTeamUsage),
+		atc.ExportTeam:      teamHandlerFactory.HandlerFor(configServer.ExportTeam),
+		atc.ImportTeam:      http.HandlerFunc(configServer.ImportTeam),
+
+		atc.ListWebhooks:  teamHandlerFactory.HandlerFor(teamServer.ListWebhooks),
+		atc.CreateWebhook: teamHandlerFactory.HandlerFor(teamServer.CreateWebhook),
+		atc.DeleteWebhook: teamHandlerFactory.HandlerFor(teamServer.DeleteWebhook),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
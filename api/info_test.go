@@ -12,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	awsssm "github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/creds/credhub"
 	"github.com/concourse/atc/creds/secretsmanager"
@@ -69,6 +70,67 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/schemas/pipeline", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/schemas/pipeline")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("returns Content-Type 'application/schema+json'", func() {
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/schema+json"))
+		})
+
+		It("contains the pipeline config schema", func() {
+			expected, err := json.Marshal(atc.ConfigSchema())
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(expected))
+		})
+	})
+
+	Describe("GET /api/v1/info/keys", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/info/keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+		})
+
+		It("lists the signing keys as a JSON Web Key Set", func() {
+			var keySet atc.JSONWebKeySet
+			err := json.NewDecoder(response.Body).Decode(&keySet)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(keySet.Keys).To(HaveLen(1))
+
+			key := keySet.Keys[0]
+			Expect(key.KeyType).To(Equal("RSA"))
+			Expect(key.Algorithm).To(Equal("RS256"))
+			Expect(key.Use).To(Equal("sig"))
+			Expect(key.KeyID).NotTo(BeEmpty())
+			Expect(key.Modulus).To(Equal("wP_u"))
+			Expect(key.Exponent).To(Equal("AQAB"))
+		})
+	})
+
 	Describe("GET /api/v1/info/creds", func() {
 		var (
 			response   *http.Response
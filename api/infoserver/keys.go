@@ -0,0 +1,60 @@
+package infoserver
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+// Keys lists the public keys which tokens are accepted as signed with, so that
+// external services can verify them. During a key rotation this includes the
+// old key as well as the current one.
+func (s *Server) Keys(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("keys")
+
+	keySet := atc.JSONWebKeySet{
+		Keys: []atc.JSONWebKey{},
+	}
+
+	for _, key := range s.signingKeys {
+		webKey, err := jsonWebKey(key)
+		if err != nil {
+			logger.Error("failed-to-marshal-key", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		keySet.Keys = append(keySet.Keys, webKey)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(keySet)
+	if err != nil {
+		logger.Error("failed-to-encode-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func jsonWebKey(key *rsa.PublicKey) (atc.JSONWebKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return atc.JSONWebKey{}, err
+	}
+
+	fingerprint := sha256.Sum256(der)
+
+	return atc.JSONWebKey{
+		KeyType:   "RSA",
+		Algorithm: "RS256",
+		Use:       "sig",
+		KeyID:     base64.RawURLEncoding.EncodeToString(fingerprint[:]),
+		Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package infoserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+// PipelineConfigSchema serves the JSON Schema for pipeline configs.
+func (s *Server) PipelineConfigSchema(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("pipeline-config-schema")
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	err := json.NewEncoder(w).Encode(atc.ConfigSchema())
+	if err != nil {
+		logger.Error("failed-to-encode-schema", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
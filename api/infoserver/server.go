@@ -1,6 +1,8 @@
 package infoserver
 
 import (
+	"crypto/rsa"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/creds"
 )
@@ -10,6 +12,7 @@ type Server struct {
 	version       string
 	workerVersion string
 	credsManagers creds.Managers
+	signingKeys   []*rsa.PublicKey
 }
 
 func NewServer(
@@ -17,11 +20,13 @@ func NewServer(
 	version string,
 	workerVersion string,
 	credsManagers creds.Managers,
+	signingKeys []*rsa.PublicKey,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		version:       version,
 		workerVersion: workerVersion,
 		credsManagers: credsManagers,
+		signingKeys:   signingKeys,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -16,7 +17,9 @@ import (
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
 
@@ -79,9 +82,14 @@ var _ = Describe("Jobs API", func() {
 
 	Describe("GET /api/v1/jobs", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/jobs", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/jobs"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -235,7 +243,51 @@ var _ = Describe("Jobs API", func() {
 		Context("when not authenticated", func() {
 			It("populates job factory with no team names", func() {
 				Expect(dbJobFactory.VisibleJobsCallCount()).To(Equal(1))
-				Expect(dbJobFactory.VisibleJobsArgsForCall(0)).To(BeEmpty())
+				teamNames, _ := dbJobFactory.VisibleJobsArgsForCall(0)
+				Expect(teamNames).To(BeEmpty())
+			})
+		})
+
+		It("does not filter the jobs", func() {
+			_, filter := dbJobFactory.VisibleJobsArgsForCall(0)
+			Expect(filter).To(Equal(db.DashboardFilter{}))
+		})
+
+		Context("when filtering", func() {
+			BeforeEach(func() {
+				query = "?team=some-team&status=failing&search=some-search&label=team:payments"
+			})
+
+			It("filters the jobs", func() {
+				_, filter := dbJobFactory.VisibleJobsArgsForCall(0)
+				Expect(filter).To(Equal(db.DashboardFilter{
+					TeamName: "some-team",
+					Status:   db.DashboardStatusFailing,
+					Search:   "some-search",
+					Labels:   map[string]string{"team": "payments"},
+				}))
+			})
+		})
+
+		Context("when filtering by a malformed label", func() {
+			BeforeEach(func() {
+				query = "?label=payments"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbJobFactory.VisibleJobsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when filtering by an unknown status", func() {
+			BeforeEach(func() {
+				query = "?status=bogus"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbJobFactory.VisibleJobsCallCount()).To(BeZero())
 			})
 		})
 
@@ -246,7 +298,8 @@ var _ = Describe("Jobs API", func() {
 
 			It("constructs job factory with provided team names", func() {
 				Expect(dbJobFactory.VisibleJobsCallCount()).To(Equal(1))
-				Expect(dbJobFactory.VisibleJobsArgsForCall(0)).To(ContainElement("some-team"))
+				teamNames, _ := dbJobFactory.VisibleJobsArgsForCall(0)
+				Expect(teamNames).To(ContainElement("some-team"))
 			})
 		})
 	})
@@ -397,6 +450,40 @@ var _ = Describe("Jobs API", func() {
 						Expect(fakeJob.FinishedAndNextBuildCallCount()).To(Equal(1))
 					})
 
+					Context("when the job retains a limited number of build logs", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:              "some-job",
+								BuildLogsToRetain: 10,
+							})
+						})
+
+						It("returns the retention", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.BuildLogsToRetain).To(Equal(10))
+						})
+
+						Context("when the retention is limited to fewer build logs", func() {
+							BeforeEach(func() {
+								fakeBuildLogRetentionCalculator.BuildLogsToRetainReturns(5)
+							})
+
+							It("returns the effective retention", func() {
+								var job atc.Job
+								err := json.NewDecoder(response.Body).Decode(&job)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(job.BuildLogsToRetain).To(Equal(5))
+
+								Expect(fakeBuildLogRetentionCalculator.BuildLogsToRetainCallCount()).To(Equal(1))
+								Expect(fakeBuildLogRetentionCalculator.BuildLogsToRetainArgsForCall(0)).To(Equal(fakeJob))
+							})
+						})
+					})
+
 					It("returns 200 OK", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
@@ -833,11 +920,16 @@ var _ = Describe("Jobs API", func() {
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", func() {
 		var response *http.Response
 		var dashboardResponse db.Dashboard
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1067,6 +1159,38 @@ var _ = Describe("Jobs API", func() {
 						]`))
 				})
 
+				Context("when filtering by label", func() {
+					BeforeEach(func() {
+						query = "?label=team:payments&label=tier:prod"
+
+						fakePipeline.LabelsReturns(map[string]string{"team": "search", "tier": "prod"})
+						job1.ConfigReturns(atc.JobConfig{
+							Name:   "job-1",
+							Plan:   atc.PlanSequence{{Get: "input-1"}, {Put: "output-1"}},
+							Labels: map[string]string{"team": "payments"},
+						})
+					})
+
+					It("returns only the jobs with the labels, set on either the job or its pipeline", func() {
+						var jobs []atc.Job
+						err := json.NewDecoder(response.Body).Decode(&jobs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(jobs).To(HaveLen(1))
+						Expect(jobs[0].Name).To(Equal("job-1"))
+					})
+				})
+
+				Context("when filtering by a malformed label", func() {
+					BeforeEach(func() {
+						query = "?label=payments"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
 				Context("when manual triggering of a job is disabled", func() {
 					BeforeEach(func() {
 						job1.ConfigReturns(atc.JobConfig{
@@ -1162,6 +1286,30 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when a job has an icon and description", func() {
+					BeforeEach(func() {
+						job1.ConfigReturns(atc.JobConfig{
+							Name:        "job-1",
+							Plan:        atc.PlanSequence{{Get: "input-1"}, {Put: "output-1"}},
+							Icon:        "rocket",
+							Description: "deploys to production",
+						})
+						fakePipeline.DashboardReturns(dashboardResponse, nil)
+					})
+
+					It("includes them", func() {
+						var jobs []atc.Job
+						err := json.NewDecoder(response.Body).Decode(&jobs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(jobs).To(HaveLen(3))
+						Expect(jobs[0].Icon).To(Equal("rocket"))
+						Expect(jobs[0].Description).To(Equal("deploys to production"))
+						Expect(jobs[1].Icon).To(BeEmpty())
+						Expect(jobs[1].Description).To(BeEmpty())
+					})
+				})
+
 				Context("when getting the dashboard fails", func() {
 					Context("with an unknown error", func() {
 						BeforeEach(func() {
@@ -1438,6 +1586,49 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when the job requires a reason to trigger it", func() {
+					BeforeEach(func() {
+						fakeJob.ConfigReturns(atc.JobConfig{
+							Name:                       "some-job",
+							RequireManualTriggerReason: true,
+							Plan: atc.PlanSequence{
+								{
+									Get: "some-input",
+								},
+							},
+						})
+
+						fakeScheduler.TriggerImmediatelyReturns(new(dbfakes.FakeBuild), nil, nil)
+					})
+
+					Context("when no reason is given", func() {
+						BeforeEach(func() {
+							request.URL.RawQuery = "reason=" + url.QueryEscape("  ")
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when a reason is given", func() {
+						BeforeEach(func() {
+							request.URL.RawQuery = "reason=" + url.QueryEscape("rolling back")
+						})
+
+						It("triggers the build with the reason", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+							_, _, _, _, reason := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							Expect(reason).To(Equal("rolling back"))
+						})
+					})
+				})
+
 				Context("when getting the job config succeeds", func() {
 					BeforeEach(func() {
 						fakeJob.ConfigReturns(atc.JobConfig{
@@ -1477,10 +1668,24 @@ var _ = Describe("Jobs API", func() {
 						It("triggers using the current config", func() {
 							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-							_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							_, job, resources, resourceTypes, reason := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 							Expect(job).To(Equal(fakeJob))
 							Expect(resources).To(Equal(db.Resources{fakeResource, fakeResource2}))
 							Expect(resourceTypes).To(Equal(versionedResourceTypes))
+							Expect(reason).To(BeEmpty())
+						})
+
+						Context("when a reason is given", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "reason=" + url.QueryEscape(" hotfix for the outage ")
+							})
+
+							It("triggers with the reason", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+								_, _, _, _, reason := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+								Expect(reason).To(Equal("hotfix for the outage"))
+							})
 						})
 
 						It("returns 200 OK", func() {
@@ -1754,6 +1959,147 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs/explain", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/inputs/explain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the job is found", func() {
+				var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+				BeforeEach(func() {
+					fakeJob = new(dbfakes.FakeJob)
+					fakeJob.NameReturns("some-job")
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+					resource := new(dbfakes.FakeResource)
+					resource.NameReturns("some-resource")
+					fakePipeline.ResourcesReturns([]db.Resource{resource}, nil)
+				})
+
+				Context("when the inputs can be satisfied", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainNextInputMappingReturns([]inputmapper.InputExplanation{
+							{Name: "some-input", Resource: "some-resource", VersionID: 42},
+						}, nil)
+
+						fakePipeline.VersionedResourceReturns(db.SavedVersionedResource{
+							ID: 42,
+							VersionedResource: db.VersionedResource{
+								Resource: "some-resource",
+								Version:  db.ResourceVersion{"some": "version"},
+							},
+						}, true, nil)
+					})
+
+					It("explains the inputs of the job without saving them", func() {
+						_, receivedJob, receivedResources := fakeScheduler.ExplainNextInputMappingArgsForCall(0)
+						Expect(receivedJob.Name()).To(Equal("some-job"))
+						Expect(receivedResources).To(HaveLen(1))
+
+						Expect(fakeScheduler.SaveNextInputMappingCallCount()).To(BeZero())
+					})
+
+					It("returns the versions which would be used", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakePipeline.VersionedResourceArgsForCall(0)).To(Equal(42))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"satisfiable": true,
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"version": {"some": "version"}
+								}
+							]
+						}`))
+					})
+				})
+
+				Context("when an input is blocked", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainNextInputMappingReturns([]inputmapper.InputExplanation{
+							{
+								Name:         "some-input",
+								Resource:     "some-resource",
+								BlockedBy:    algorithm.BlockerPassed,
+								BlockingJobs: []string{"some-upstream-job"},
+							},
+						}, nil)
+					})
+
+					It("returns what is blocking it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"satisfiable": false,
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"blocked_by": "passed",
+									"blocking_jobs": ["some-upstream-job"]
+								}
+							]
+						}`))
+					})
+				})
+
+				Context("when explaining the inputs fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainNextInputMappingReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1915,6 +2261,204 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/last-successful-outputs", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/last-successful-outputs")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when getting the job succeeds", func() {
+				BeforeEach(func() {
+					fakeJob.NameReturns("some-job")
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("looks up the right job", func() {
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+				})
+
+				Context("when the job has succeeded", func() {
+					var dbBuild *dbfakes.FakeBuild
+
+					BeforeEach(func() {
+						dbBuild = new(dbfakes.FakeBuild)
+						dbBuild.IDReturns(1)
+						dbBuild.NameReturns("1")
+						dbBuild.JobNameReturns("some-job")
+						dbBuild.PipelineIDReturns(42)
+						dbBuild.PipelineNameReturns("some-pipeline")
+						dbBuild.TeamNameReturns("some-team")
+						dbBuild.StatusReturns(db.BuildStatusSucceeded)
+						dbBuild.StartTimeReturns(time.Unix(1, 0))
+						dbBuild.EndTimeReturns(time.Unix(100, 0))
+						fakeJob.LatestSuccessfulBuildReturns(dbBuild, true, nil)
+					})
+
+					Context("when getting the build's resources succeeds", func() {
+						BeforeEach(func() {
+							dbBuild.ResourcesReturns([]db.BuildInput{
+								{
+									Name: "some-input",
+									VersionedResource: db.VersionedResource{
+										Resource: "some-resource",
+										Type:     "some-type",
+										Version:  db.ResourceVersion{"some": "version"},
+									},
+									FirstOccurrence: true,
+								},
+							}, []db.BuildOutput{
+								{
+									VersionedResource: db.VersionedResource{
+										Resource: "some-output",
+										Type:     "some-type",
+										Version:  db.ResourceVersion{"some": "output-version"},
+									},
+								},
+							}, nil)
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns Content-Type 'application/json'", func() {
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+						})
+
+						It("returns the build with its inputs and outputs", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"build": {
+									"id": 1,
+									"name": "1",
+									"job_name": "some-job",
+									"status": "succeeded",
+									"api_url": "/api/v1/builds/1",
+									"pipeline_name": "some-pipeline",
+									"team_name": "some-team",
+									"start_time": 1,
+									"end_time": 100
+								},
+								"inputs": [
+									{
+										"name": "some-input",
+										"resource": "some-resource",
+										"type": "some-type",
+										"version": {"some": "version"},
+										"metadata": [],
+										"pipeline_id": 42,
+										"first_occurrence": true
+									}
+								],
+								"outputs": [
+									{
+										"id": 0,
+										"type": "",
+										"metadata": null,
+										"resource": "some-output",
+										"version": {"some": "output-version"},
+										"enabled": false
+									}
+								]
+							}`))
+						})
+					})
+
+					Context("when getting the build's resources fails", func() {
+						BeforeEach(func() {
+							dbBuild.ResourcesReturns(nil, nil, errors.New("oh no!"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the job has never succeeded", func() {
+					BeforeEach(func() {
+						fakeJob.LatestSuccessfulBuildReturns(nil, false, nil)
+					})
+
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.LatestSuccessfulBuildReturns(nil, false, errors.New("oh no!"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("oh no!"))
+				})
+
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+					fakeaccess.IsAuthenticatedReturns(true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+					fakeJob.LatestSuccessfulBuildReturns(new(dbfakes.FakeBuild), true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", func() {
 		var response *http.Response
 
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
@@ -33,6 +36,14 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		reason := strings.TrimSpace(r.FormValue("reason"))
+		if reason == "" && job.Config().RequireManualTriggerReason {
+			logger.Info("missing-trigger-reason", lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "job '%s' requires a reason to trigger it", jobName)
+			return
+		}
+
 		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name()))
 
 		resourceTypes, err := pipeline.ResourceTypes()
@@ -51,7 +62,7 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		build, _, err := scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes, reason)
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -59,6 +70,12 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		logger.Info("triggered", lager.Data{
+			"job":    jobName,
+			"build":  build.Name(),
+			"reason": reason,
+		})
+
 		err = json.NewEncoder(w).Encode(present.Build(build))
 		if err != nil {
 			logger.Error("failed-to-encode-build", err)
@@ -0,0 +1,87 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// ExplainJobInputs resolves the inputs for the job's next build without
+// creating it, responding with the version each input would use or, if they
+// cannot be satisfied, the constraint blocking each input.
+func (s *Server) ExplainJobInputs(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("explain-job-inputs")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		variables := s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, variables)
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		inputExplanations, err := scheduler.ExplainNextInputMapping(logger, job, resources)
+		if err != nil {
+			logger.Error("failed-to-explain-next-input-mapping", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		explanation := atc.JobInputsExplanation{
+			Satisfiable: true,
+			Inputs:      []atc.InputExplanation{},
+		}
+
+		for _, inputExplanation := range inputExplanations {
+			input := atc.InputExplanation{
+				Name:         inputExplanation.Name,
+				Resource:     inputExplanation.Resource,
+				BlockedBy:    string(inputExplanation.BlockedBy),
+				BlockingJobs: inputExplanation.BlockingJobs,
+			}
+
+			if inputExplanation.BlockedBy != "" {
+				explanation.Satisfiable = false
+			} else if inputExplanation.VersionID != 0 {
+				versionedResource, found, err := pipeline.VersionedResource(inputExplanation.VersionID)
+				if err != nil {
+					logger.Error("failed-to-get-versioned-resource", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				if found {
+					input.Version = atc.Version(versionedResource.Version)
+				}
+			}
+
+			explanation.Inputs = append(explanation.Inputs, input)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(explanation)
+		if err != nil {
+			logger.Error("failed-to-encode-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+// LatestJobOutputs returns the versions used and produced by the job's most
+// recent successful build.
+func (s *Server) LatestJobOutputs(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("latest-job-outputs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		build, found, err := job.LatestSuccessfulBuild()
+		if err != nil {
+			logger.Error("failed-to-get-latest-successful-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		inputs, outputs, err := build.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-build-resources", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobOutputs := atc.JobOutputs{
+			Build:   present.Build(build),
+			Inputs:  make([]atc.PublicBuildInput, 0, len(inputs)),
+			Outputs: make([]atc.VersionedResource, 0, len(outputs)),
+		}
+
+		for _, input := range inputs {
+			jobOutputs.Inputs = append(jobOutputs.Inputs, present.PublicBuildInput(input, build.PipelineID()))
+		}
+
+		for _, output := range outputs {
+			jobOutputs.Outputs = append(jobOutputs.Outputs, present.VersionedResource(output.VersionedResource))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(jobOutputs)
+		if err != nil {
+			logger.Error("failed-to-encode-job-outputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package jobserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc"
@@ -15,6 +16,13 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var jobs []atc.Job
 
+		labels, err := db.ParseLabelSelectors(r.URL.Query()["label"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
 		dashboard, err := pipeline.Dashboard()
 
 		if err != nil {
@@ -26,6 +34,10 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 		teamName := r.FormValue(":team_name")
 
 		for _, job := range dashboard {
+			if len(labels) > 0 && !db.MatchesLabels(jobLabels(pipeline, job.Job), labels) {
+				continue
+			}
+
 			jobs = append(
 				jobs,
 				present.Job(
@@ -34,6 +46,7 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 					job.FinishedBuild,
 					job.NextBuild,
 					job.TransitionBuild,
+					s.buildLogRetentionCalculator.BuildLogsToRetain(job.Job),
 				),
 			)
 		}
@@ -48,3 +61,19 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+// jobLabels are the labels set on the job or its pipeline, with the job's
+// taking precedence, as matched by the label filter when listing all jobs.
+func jobLabels(pipeline db.Pipeline, job db.Job) map[string]string {
+	labels := map[string]string{}
+
+	for key, value := range pipeline.Labels() {
+		labels[key] = value
+	}
+
+	for key, value := range job.Config().Labels {
+		labels[key] = value
+	}
+
+	return labels
+}
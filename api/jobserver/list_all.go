@@ -2,11 +2,13 @@ package jobserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
 )
 
 func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
@@ -14,7 +16,28 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 
 	acc := accessor.GetAccessor(r)
 
-	dashboard, err := s.jobFactory.VisibleJobs(acc.TeamNames())
+	labels, err := db.ParseLabelSelectors(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	filter := db.DashboardFilter{
+		TeamName: r.URL.Query().Get("team"),
+		Status:   r.URL.Query().Get("status"),
+		Search:   r.URL.Query().Get("search"),
+		Labels:   labels,
+	}
+
+	err = filter.Validate()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	dashboard, err := s.jobFactory.VisibleJobs(acc.TeamNames(), filter)
 	if err != nil {
 		logger.Error("failed-to-get-all-visible-jobs", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -32,6 +55,7 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 				job.FinishedBuild,
 				job.NextBuild,
 				job.TransitionBuild,
+				s.buildLogRetentionCalculator.BuildLogsToRetain(job.Job),
 			),
 		)
 	}
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/scheduler"
 )
 
@@ -22,6 +23,8 @@ type Server struct {
 	rejector         auth.Rejector
 	variablesFactory creds.VariablesFactory
 	jobFactory       db.JobFactory
+
+	buildLogRetentionCalculator gc.BuildLogRetentionCalculator
 }
 
 func NewServer(
@@ -30,6 +33,7 @@ func NewServer(
 	externalURL string,
 	variablesFactory creds.VariablesFactory,
 	jobFactory db.JobFactory,
+	buildLogRetentionCalculator gc.BuildLogRetentionCalculator,
 ) *Server {
 	return &Server{
 		logger:           logger,
@@ -38,5 +42,7 @@ func NewServer(
 		rejector:         auth.UnauthorizedRejector{},
 		variablesFactory: variablesFactory,
 		jobFactory:       jobFactory,
+
+		buildLogRetentionCalculator: buildLogRetentionCalculator,
 	}
 }
@@ -94,9 +94,14 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/pipelines", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -120,7 +125,51 @@ var _ = Describe("Pipelines API", func() {
 
 			It("constructs pipeline factory with provided team names", func() {
 				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(Equal(1))
-				Expect(dbPipelineFactory.VisiblePipelinesArgsForCall(0)).To(ContainElement("some-team"))
+				teamNames, _ := dbPipelineFactory.VisiblePipelinesArgsForCall(0)
+				Expect(teamNames).To(ContainElement("some-team"))
+			})
+		})
+
+		It("does not filter the pipelines", func() {
+			_, filter := dbPipelineFactory.VisiblePipelinesArgsForCall(0)
+			Expect(filter).To(Equal(db.DashboardFilter{}))
+		})
+
+		Context("when filtering", func() {
+			BeforeEach(func() {
+				query = "?team=some-team&status=paused&search=some-search&label=team:payments"
+			})
+
+			It("filters the pipelines", func() {
+				_, filter := dbPipelineFactory.VisiblePipelinesArgsForCall(0)
+				Expect(filter).To(Equal(db.DashboardFilter{
+					TeamName: "some-team",
+					Status:   db.DashboardStatusPaused,
+					Search:   "some-search",
+					Labels:   map[string]string{"team": "payments"},
+				}))
+			})
+		})
+
+		Context("when filtering by a malformed label", func() {
+			BeforeEach(func() {
+				query = "?label=payments"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when filtering by an unknown status", func() {
+			BeforeEach(func() {
+				query = "?status=bogus"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(BeZero())
 			})
 		})
 
@@ -154,7 +203,8 @@ var _ = Describe("Pipelines API", func() {
 			})
 			It("populates pipeline factory with no team names", func() {
 				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(Equal(1))
-				Expect(dbPipelineFactory.VisiblePipelinesArgsForCall(0)).To(BeEmpty())
+				teamNames, _ := dbPipelineFactory.VisiblePipelinesArgsForCall(0)
+				Expect(teamNames).To(BeEmpty())
 			})
 		})
 
@@ -220,9 +270,14 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/teams/:team_name/pipelines", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -285,6 +340,38 @@ var _ = Describe("Pipelines API", func() {
 					}]`))
 			})
 
+			Context("when filtering by label", func() {
+				BeforeEach(func() {
+					query = "?label=team:payments"
+
+					privatePipeline.LabelsReturns(map[string]string{"team": "payments", "tier": "prod"})
+					publicPipeline.LabelsReturns(map[string]string{"team": "search"})
+				})
+
+				It("returns only the pipelines with the label", func() {
+					var pipelines []atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipelines)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pipelines).To(HaveLen(1))
+					Expect(pipelines[0].Name).To(Equal("private-pipeline"))
+				})
+			})
+
+			Context("when filtering by a malformed label", func() {
+				BeforeEach(func() {
+					query = "?label=payments"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not look up the team", func() {
+					Expect(dbTeamFactory.FindTeamCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the call to get active pipelines fails", func() {
 				BeforeEach(func() {
 					fakeTeam.PipelinesReturns(nil, errors.New("disaster"))
@@ -438,6 +525,22 @@ var _ = Describe("Pipelines API", func() {
 						]
 					}`))
 			})
+
+			Context("when the pipeline was paused for being idle", func() {
+				BeforeEach(func() {
+					fakePipeline.PausedReturns(true)
+					fakePipeline.PausedByInactivityReturns(true)
+				})
+
+				It("says so, so that it can be unpaused", func() {
+					var pipeline atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipeline)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pipeline.Paused).To(BeTrue())
+					Expect(pipeline.PausedByInactivity).To(BeTrue())
+				})
+			})
 		})
 
 		Context("when authenticated as another team", func() {
@@ -762,6 +865,123 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			dbPipeline.NameReturns("some-pipeline")
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+
+			job := new(dbfakes.FakeJob)
+			job.ConfigReturns(atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{{Get: "some-resource", Trigger: true}},
+			})
+			dbPipeline.JobsReturns([]db.Job{job}, nil)
+
+			resource := new(dbfakes.FakeResource)
+			resource.NameReturns("some-resource")
+			dbPipeline.ResourcesReturns([]db.Resource{resource}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/graph" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+				dbPipeline.PublicReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authorized and the pipeline is public", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+				dbPipeline.PublicReturns(true)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the graph as JSON", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"nodes": [
+						{"id": "resource:some-resource", "type": "resource", "name": "some-resource"},
+						{"id": "job:some-job", "type": "job", "name": "some-job"}
+					],
+					"edges": [
+						{"source": "resource:some-resource", "target": "job:some-job", "type": "input", "trigger": true}
+					]
+				}`))
+			})
+
+			Context("when DOT is requested", func() {
+				BeforeEach(func() {
+					query = "?format=dot"
+				})
+
+				It("returns the graph in DOT", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(ContainSubstring(`"resource:some-resource" -> "job:some-job";`))
+				})
+			})
+
+			Context("when an unknown format is requested", func() {
+				BeforeEach(func() {
+					query = "?format=svg"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the jobs fails", func() {
+				BeforeEach(func() {
+					dbPipeline.JobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
 		var response *http.Response
 
@@ -804,15 +1024,16 @@ var _ = Describe("Pipelines API", func() {
 					Expect(pipelineName).To(Equal("a-pipeline-name"))
 				})
 
-				It("deletes the named pipeline from the database", func() {
-					Expect(dbPipeline.DestroyCallCount()).To(Equal(1))
+				It("soft-deletes the named pipeline", func() {
+					Expect(dbPipeline.SoftDeleteCallCount()).To(Equal(1))
+					Expect(dbPipeline.DestroyCallCount()).To(BeZero())
 				})
 
-				Context("when an error occurs destroying the pipeline", func() {
+				Context("when an error occurs deleting the pipeline", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
 						err := errors.New("disaster!")
-						dbPipeline.DestroyReturns(err)
+						dbPipeline.SoftDeleteReturns(err)
 					})
 
 					It("returns a 500 Internal Server Error", func() {
@@ -1160,6 +1381,94 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/undelete", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/undelete", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when a deleted pipeline is found", func() {
+				BeforeEach(func() {
+					fakeTeam.UndeletePipelineReturns(true, nil)
+				})
+
+				It("undeletes the pipeline", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+					Expect(fakeTeam.UndeletePipelineCallCount()).To(Equal(1))
+					Expect(fakeTeam.UndeletePipelineArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when no deleted pipeline is found", func() {
+				BeforeEach(func() {
+					fakeTeam.UndeletePipelineReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when another pipeline has taken its name", func() {
+				BeforeEach(func() {
+					fakeTeam.UndeletePipelineReturns(false, db.ErrPipelineNameTaken)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when undeleting the pipeline fails", func() {
+				BeforeEach(func() {
+					fakeTeam.UndeletePipelineReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when requester does not belong to the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.UndeletePipelineCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/ordering", func() {
 		var response *http.Response
 		var body io.Reader
@@ -0,0 +1,55 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// PipelineGraph responds with the graph of the pipeline's jobs and
+// resources, as JSON or, with ?format=dot, in Graphviz's DOT language.
+func (s *Server) PipelineGraph(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("pipeline-graph")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown format: %s", format)
+			return
+		}
+
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph := atc.NewPipelineGraph(jobs.Configs(), resources.Configs())
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			fmt.Fprint(w, graph.DOT())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(graph)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package pipelineserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/api/accessor"
@@ -12,6 +13,14 @@ import (
 func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-pipelines")
 	requestTeamName := r.FormValue(":team_name")
+
+	labels, err := db.ParseLabelSelectors(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
 	team, found, err := s.teamFactory.FindTeam(requestTeamName)
 	if err != nil {
 		logger.Error("failed-to-get-team", err)
@@ -40,6 +49,17 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(labels) > 0 {
+		matching := []db.Pipeline{}
+		for _, pipeline := range pipelines {
+			if db.MatchesLabels(pipeline.Labels(), labels) {
+				matching = append(matching, pipeline)
+			}
+		}
+
+		pipelines = matching
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	err = json.NewEncoder(w).Encode(present.Pipelines(pipelines))
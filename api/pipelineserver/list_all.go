@@ -2,19 +2,44 @@ package pipelineserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
 )
 
-// show all public pipelines and team private pipelines if authorized
+// show all public pipelines and team private pipelines if authorized,
+// optionally filtered by team, status, labels, and a search for the pipeline
+// name
 func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-all-pipelines")
 
 	acc := accessor.GetAccessor(r)
 
-	pipelines, err := s.pipelineFactory.VisiblePipelines(acc.TeamNames())
+	labels, err := db.ParseLabelSelectors(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	filter := db.DashboardFilter{
+		TeamName: r.URL.Query().Get("team"),
+		Status:   r.URL.Query().Get("status"),
+		Search:   r.URL.Query().Get("search"),
+		Labels:   labels,
+	}
+
+	err = filter.Validate()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	pipelines, err := s.pipelineFactory.VisiblePipelines(acc.TeamNames(), filter)
 	if err != nil {
 		logger.Error("failed-to-get-all-visible-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,54 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// UndeletePipeline restores a pipeline which was deleted within the purge
+// grace period. The restored pipeline is paused only if it was paused before
+// it was deleted.
+func (s *Server) UndeletePipeline(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	pipelineName := r.FormValue(":pipeline_name")
+
+	logger := s.logger.Session("undelete-pipeline", lager.Data{
+		"team":     teamName,
+		"pipeline": pipelineName,
+	})
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	found, err = team.UndeletePipeline(pipelineName)
+	if err != nil {
+		if err == db.ErrPipelineNameTaken {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		logger.Error("failed-to-undelete-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("deleted-pipeline-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -26,6 +26,11 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		Status:       string(build.Status()),
 		APIURL:       apiURL,
+		Error:        build.Error(),
+
+		TriggerReason: build.TriggerReason(),
+		AbortReason:   build.AbortReason(),
+		AbortedBy:     build.AbortedBy(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -12,6 +12,18 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		inputs[k] = atc.BuildPreparationStatus(v)
 	}
 
+	var skippedVersions map[string]atc.SkippedVersion
+	if len(preparation.SkippedVersions) > 0 {
+		skippedVersions = make(map[string]atc.SkippedVersion)
+
+		for k, v := range preparation.SkippedVersions {
+			skippedVersions[k] = atc.SkippedVersion{
+				Version:   atc.Version(v.Version),
+				NotPassed: v.NotPassed,
+			}
+		}
+	}
+
 	return atc.BuildPreparation{
 		BuildID:             preparation.BuildID,
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
@@ -20,5 +32,6 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		SkippedVersions:     skippedVersions,
 	}
 }
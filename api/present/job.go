@@ -11,6 +11,7 @@ func Job(
 	finishedBuild db.Build,
 	nextBuild db.Build,
 	transitionBuild db.Build,
+	buildLogsToRetain int,
 ) atc.Job {
 	var presentedNextBuild, presentedFinishedBuild, presentedTransitionBuild *atc.Build
 
@@ -50,19 +51,25 @@ func Job(
 	return atc.Job{
 		ID: job.ID(),
 
-		Name:                 job.Name(),
-		PipelineName:         job.PipelineName(),
-		TeamName:             teamName,
-		DisableManualTrigger: job.Config().DisableManualTrigger,
-		Paused:               job.Paused(),
-		FirstLoggedBuildID:   job.FirstLoggedBuildID(),
-		FinishedBuild:        presentedFinishedBuild,
-		NextBuild:            presentedNextBuild,
-		TransitionBuild:      presentedTransitionBuild,
+		Name:                       job.Name(),
+		PipelineName:               job.PipelineName(),
+		TeamName:                   teamName,
+		DisableManualTrigger:       job.Config().DisableManualTrigger,
+		RequireManualTriggerReason: job.Config().RequireManualTriggerReason,
+		BuildLogsToRetain:          buildLogsToRetain,
+		Paused:                     job.Paused(),
+		FirstLoggedBuildID:         job.FirstLoggedBuildID(),
+		FinishedBuild:              presentedFinishedBuild,
+		NextBuild:                  presentedNextBuild,
+		TransitionBuild:            presentedTransitionBuild,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
 
 		Groups: job.Tags(),
+		Labels: job.Config().Labels,
+
+		Icon:        job.Config().Icon,
+		Description: job.Config().Description,
 	}
 }
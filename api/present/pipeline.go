@@ -13,5 +13,8 @@ func Pipeline(savedPipeline db.Pipeline) atc.Pipeline {
 		Paused:   savedPipeline.Paused(),
 		Public:   savedPipeline.Public(),
 		Groups:   savedPipeline.Groups(),
+		Labels:   savedPipeline.Labels(),
+
+		PausedByInactivity: savedPipeline.PausedByInactivity(),
 	}
 }
@@ -17,8 +17,14 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 		TeamName:     teamName,
 		Type:         resource.Type(),
 
+		Icon:        resource.Icon(),
+		Description: resource.Description(),
+
 		Paused: resource.Paused(),
 
+		PinnedVersion:  resource.PinnedVersion(),
+		PinnedInConfig: resource.ConfigPinnedVersion() != nil,
+
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
@@ -6,9 +6,16 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presented := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if team.AllowPrivileged() {
+		allowPrivileged := true
+		presented.AllowPrivileged = &allowPrivileged
+	}
+
+	return presented
 }
@@ -35,5 +35,9 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		StartTime:        workerInfo.StartTime(),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Runtime:          workerInfo.Runtime(),
+		Load:             workerInfo.Load(),
+
+		DeniesNetworkByDefault: workerInfo.DeniesNetworkByDefault(),
 	}
 }
@@ -62,6 +62,8 @@ var _ = Describe("Resources API", func() {
 				resource1.NameReturns("resource-1")
 				resource1.TypeReturns("type-1")
 				resource1.LastCheckedReturns(time.Unix(1513364881, 0))
+				resource1.IconReturns("github")
+				resource1.DescriptionReturns("the app's source code")
 
 				resource2 := new(dbfakes.FakeResource)
 				resource2.IDReturns(2)
@@ -105,6 +107,8 @@ var _ = Describe("Resources API", func() {
 							"pipeline_name": "a-pipeline",
 							"team_name": "some-team",
 							"type": "type-1",
+							"icon": "github",
+							"description": "the app's source code",
 							"paused": true,
 							"last_checked": 1513364881
 						},
@@ -732,6 +736,34 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when the resource is pinned", func() {
+				BeforeEach(func() {
+					resource1 := new(dbfakes.FakeResource)
+					resource1.PipelineNameReturns("a-pipeline")
+					resource1.NameReturns("resource-1")
+					resource1.TypeReturns("type-1")
+					resource1.PinnedVersionReturns(atc.Version{"ref": "abc"})
+					resource1.ConfigPinnedVersionReturns(atc.Version{"ref": "abc"})
+
+					fakePipeline.ResourceReturns(resource1, true, nil)
+				})
+
+				It("returns the pinned version and whether it's pinned in the config", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"pinned_version": {"ref": "abc"},
+								"pinned_in_config": true
+							}`))
+				})
+			})
 		})
 	})
 
@@ -897,6 +929,209 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+			reqBody      string
+		)
+
+		BeforeEach(func() {
+			reqBody = `{"ref":"abc"}`
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/pin", bytes.NewBufferString(reqBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when pinning the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("pins the given version", func() {
+						Expect(fakeResource.PinVersionCallCount()).To(Equal(1))
+						Expect(fakeResource.PinVersionArgsForCall(0)).To(Equal(atc.Version{"ref": "abc"}))
+					})
+				})
+
+				Context("when the version is empty", func() {
+					BeforeEach(func() {
+						reqBody = `{}`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the request is malformed", func() {
+					BeforeEach(func() {
+						reqBody = `{`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the resource is pinned in the pipeline config", func() {
+					BeforeEach(func() {
+						fakeResource.ConfigPinnedVersionReturns(atc.Version{"ref": "def"})
+					})
+
+					It("returns 409 without pinning it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when resource can not be found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when pinning the resource fails", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Status Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when unpinning the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeResource.UnpinVersionCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when resource can not be found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when unpinning the resource fails", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
@@ -1242,6 +1477,71 @@ var _ = Describe("Resources API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 				})
 			})
+
+			Context("when the resource has no webhook token configured", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokenReturns("")
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the webhook token is a credential manager var", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokenReturns("((webhook-token))")
+				})
+
+				Context("when the var resolves to the given token", func() {
+					BeforeEach(func() {
+						fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+							"webhook-token": "fake-token",
+						})
+					})
+
+					It("looks up the var for the pipeline", func() {
+						Expect(fakeVariablesFactory.NewVariablesCallCount()).To(Equal(1))
+						teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+						Expect(teamName).To(Equal(fakePipeline.TeamName()))
+						Expect(pipelineName).To(Equal(fakePipeline.Name()))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when the var resolves to a different token", func() {
+					BeforeEach(func() {
+						fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+							"webhook-token": "rotated-token",
+						})
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("when the var cannot be resolved", func() {
+					BeforeEach(func() {
+						fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{})
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not scan", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 	})
 })
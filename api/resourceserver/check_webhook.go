@@ -1,6 +1,7 @@
 package resourceserver
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 
@@ -38,13 +39,28 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if pipelineResource.WebhookToken() == "" {
+			logger.Info("no-webhook-token-configured", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// the token is resolved on every request so that it can be kept in the
+		// credential manager, e.g. as ((webhook-token)), and rotated there
 		variables := s.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name())
 		token, err := creds.NewString(variables, pipelineResource.WebhookToken()).Evaluate()
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
+		if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-token", lager.Data{"resource": resourceName})
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
+
 		var fromVersion atc.Version
 		latestVersion, found, err := dbPipeline.GetLatestVersionedResource(resourceName)
 		if err != nil {
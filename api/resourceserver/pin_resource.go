@@ -0,0 +1,65 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// PinResource pins the resource to the version in the request body, so that
+// jobs only use that version of it. A version pinned in the pipeline config
+// can't be overridden.
+func (s *Server) PinResource(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("pin-resource")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		var version atc.Version
+		err := json.NewDecoder(r.Body).Decode(&version)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(version) == 0 {
+			logger.Info("empty-version")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if dbResource.ConfigPinnedVersion() != nil {
+			logger.Info("resource-pinned-in-config", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "resource '%s' is pinned in the pipeline config", resourceName)
+			return
+		}
+
+		err = dbResource.PinVersion(version)
+		if err != nil {
+			logger.Error("failed-to-pin-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,78 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/resource"
+	"github.com/tedsuo/rata"
+)
+
+// SaveResourceVersions saves the versions in the request body, oldest first,
+// for resources configured with 'check: false' which are never checked.
+func (s *Server) SaveResourceVersions(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("save-resource-versions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		var versions []atc.Version
+		err := json.NewDecoder(r.Body).Decode(&versions)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for _, version := range versions {
+			if len(version) == 0 {
+				logger.Info("empty-version")
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			err = resource.ValidateVersion(version)
+			if err != nil {
+				logger.Info("invalid-version", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, err.Error())
+				return
+			}
+		}
+
+		dbResource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !dbResource.CheckDisabled() {
+			logger.Info("resource-is-checked", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "resource '%s' must be configured with 'check: false' to save versions through the API", resourceName)
+			return
+		}
+
+		err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+			Name: dbResource.Name(),
+			Type: dbResource.Type(),
+		}, versions)
+		if err != nil {
+			logger.Error("failed-to-save-resource-versions", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,386 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Export API", func() {
+	var (
+		fakeaccess      *accessorfakes.FakeAccess
+		allowPrivileged = true
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/export", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/export")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				dbTeam.NameReturns("a-team")
+				dbTeam.AuthReturns(map[string][]string{"users": {"local:some-user"}})
+				dbTeam.AllowPrivilegedReturns(true)
+			})
+
+			Context("when the team has pipelines", func() {
+				BeforeEach(func() {
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job"})
+
+					pipeline1 := new(dbfakes.FakePipeline)
+					pipeline1.NameReturns("pipeline-1")
+					pipeline1.PausedReturns(true)
+					pipeline1.JobsReturns(db.Jobs{fakeJob}, nil)
+
+					pipeline2 := new(dbfakes.FakePipeline)
+					pipeline2.NameReturns("pipeline-2")
+					pipeline2.PublicReturns(true)
+
+					dbTeam.PipelinesReturns([]db.Pipeline{pipeline1, pipeline2}, nil)
+				})
+
+				It("returns 200 OK with the team and its pipelines in order", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					var export atc.TeamExport
+					err := json.NewDecoder(response.Body).Decode(&export)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(export).To(Equal(atc.TeamExport{
+						Team: atc.Team{
+							Name:            "a-team",
+							Auth:            map[string][]string{"users": {"local:some-user"}},
+							AllowPrivileged: &allowPrivileged,
+						},
+						Pipelines: []atc.PipelineExport{
+							{
+								Name:   "pipeline-1",
+								Paused: true,
+								Config: atc.Config{
+									Jobs: atc.JobConfigs{{Name: "some-job"}},
+								},
+							},
+							{
+								Name:   "pipeline-2",
+								Public: true,
+							},
+						},
+					}))
+				})
+			})
+
+			Context("when getting the pipelines fails", func() {
+				BeforeEach(func() {
+					dbTeam.PipelinesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/import", func() {
+		var (
+			export   atc.TeamExport
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			export = atc.TeamExport{
+				Team: atc.Team{
+					Auth:            map[string][]string{"users": {"local:some-user"}},
+					AllowPrivileged: &allowPrivileged,
+				},
+				Pipelines: []atc.PipelineExport{
+					{
+						Name:   "pipeline-1",
+						Paused: true,
+						Config: atc.Config{
+							Resources: atc.ResourceConfigs{{Name: "some-resource", Type: "git"}},
+						},
+					},
+					{
+						Name:   "pipeline-2",
+						Public: true,
+					},
+				},
+			}
+
+			fakeaccess.IsAuthenticatedReturns(true)
+
+			fakePipeline.ConfigVersionReturns(42)
+			dbTeam.SavePipelineReturns(fakePipeline, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(export)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/import", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not save anything", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					dbTeam.AllowPrivilegedReturns(true)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("updates the team's auth", func() {
+					Expect(dbTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(dbTeam.UpdateProviderAuthArgsForCall(0)).To(Equal(map[string][]string{"users": {"local:some-user"}}))
+				})
+
+				It("does not update whether the team may run privileged containers", func() {
+					Expect(dbTeam.UpdateAllowPrivilegedCallCount()).To(BeZero())
+				})
+
+				It("saves each pipeline over the existing one", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(2))
+
+					name, config, version, pausedState := dbTeam.SavePipelineArgsForCall(0)
+					Expect(name).To(Equal("pipeline-1"))
+					Expect(config).To(Equal(export.Pipelines[0].Config))
+					Expect(version).To(Equal(db.ConfigVersion(42)))
+					Expect(pausedState).To(Equal(db.PipelinePaused))
+
+					name, _, _, pausedState = dbTeam.SavePipelineArgsForCall(1)
+					Expect(name).To(Equal("pipeline-2"))
+					Expect(pausedState).To(Equal(db.PipelineUnpaused))
+				})
+
+				It("exposes or hides each pipeline", func() {
+					Expect(fakePipeline.HideCallCount()).To(Equal(1))
+					Expect(fakePipeline.ExposeCallCount()).To(Equal(1))
+				})
+
+				It("orders the pipelines", func() {
+					Expect(dbTeam.OrderPipelinesCallCount()).To(Equal(1))
+					Expect(dbTeam.OrderPipelinesArgsForCall(0)).To(Equal([]string{"pipeline-1", "pipeline-2"}))
+				})
+
+				Context("when the bundle allows privileged containers but the team doesn't", func() {
+					BeforeEach(func() {
+						dbTeam.AllowPrivilegedReturns(false)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not save anything", func() {
+						Expect(dbTeam.UpdateProviderAuthCallCount()).To(BeZero())
+						Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when saving a pipeline fails", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("leaves the team's auth alone", func() {
+						Expect(dbTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					})
+
+					It("does not delete the team", func() {
+						Expect(dbTeam.DeleteCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when updating the team's auth fails", func() {
+					BeforeEach(func() {
+						dbTeam.UpdateProviderAuthReturns(errors.New("nope"))
+					})
+
+					It("returns 500 after saving the pipelines", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(dbTeam.SavePipelineCallCount()).To(Equal(2))
+					})
+				})
+			})
+
+			Context("when a pipeline's config is invalid", func() {
+				BeforeEach(func() {
+					export.Pipelines[1].Config = atc.Config{
+						Resources: atc.ResourceConfigs{{Name: "some-resource"}},
+					}
+				})
+
+				It("returns 400 Bad Request with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("pipeline 'pipeline-2': "))
+				})
+
+				It("does not save anything", func() {
+					Expect(dbTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a pipeline appears more than once", func() {
+				BeforeEach(func() {
+					export.Pipelines[1].Name = "pipeline-1"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not create the team", func() {
+					Expect(dbTeamFactory.CreateTeamCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAdminReturns(true)
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+					dbTeamFactory.CreateTeamReturns(dbTeam, nil)
+				})
+
+				It("returns 201 Created", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("creates the team", func() {
+					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.CreateTeamArgsForCall(0)).To(Equal(atc.Team{
+						Name:            "a-team",
+						Auth:            map[string][]string{"users": {"local:some-user"}},
+						AllowPrivileged: &allowPrivileged,
+					}))
+				})
+
+				It("saves the pipelines into the new team", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(2))
+					Expect(dbTeam.OrderPipelinesCallCount()).To(Equal(1))
+				})
+
+				Context("when saving a pipeline fails", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("deletes the new team again", func() {
+						Expect(dbTeam.DeleteCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the team exists", func() {
+				It("updates whether the team may run privileged containers", func() {
+					Expect(dbTeam.UpdateAllowPrivilegedCallCount()).To(Equal(1))
+					Expect(dbTeam.UpdateAllowPrivilegedArgsForCall(0)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the request is malformed", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 400 Bad Request", func() {
+				request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/import", bytes.NewBufferString("{"))
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err := client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
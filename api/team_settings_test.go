@@ -0,0 +1,193 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Settings API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/settings", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/settings")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				dbTeam.ContainerRetentionReturns(db.ContainerRetention{
+					Success: 30 * time.Minute,
+				})
+			})
+
+			It("returns 200 OK with the team's settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"container_retention": {
+						"success": "30m0s"
+					}
+				}`))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/settings", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"container_retention":{"success":"30m","failure":"12h"}}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/settings", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not update the team", func() {
+				Expect(dbTeam.UpdateContainerRetentionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("updates the team's container retention", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(dbTeam.UpdateContainerRetentionCallCount()).To(Equal(1))
+				Expect(dbTeam.UpdateContainerRetentionArgsForCall(0)).To(Equal(db.ContainerRetention{
+					Success: 30 * time.Minute,
+					Failure: 12 * time.Hour,
+				}))
+			})
+
+			Context("when the durations are omitted", func() {
+				BeforeEach(func() {
+					requestBody = `{}`
+				})
+
+				It("resets the team to the defaults", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbTeam.UpdateContainerRetentionArgsForCall(0)).To(Equal(db.ContainerRetention{}))
+				})
+			})
+
+			Context("when a duration exceeds its bound", func() {
+				BeforeEach(func() {
+					requestBody = `{"container_retention":{"success":"2h"}}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("success container retention must not exceed 1h0m0s"))
+
+					Expect(dbTeam.UpdateContainerRetentionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a duration is negative", func() {
+				BeforeEach(func() {
+					requestBody = `{"container_retention":{"failure":"-1h"}}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.UpdateContainerRetentionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a duration is invalid", func() {
+				BeforeEach(func() {
+					requestBody = `{"container_retention":{"failure":"forever"}}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.UpdateContainerRetentionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when updating the team fails", func() {
+				BeforeEach(func() {
+					dbTeam.UpdateContainerRetentionReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
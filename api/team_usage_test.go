@@ -0,0 +1,138 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Usage API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/usage", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/usage" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the usage succeeds", func() {
+				BeforeEach(func() {
+					dbTeam.UsageReturns([]db.TeamUsage{
+						{
+							Day:           time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
+							BuildTime:     90 * time.Minute,
+							ContainerTime: 30 * time.Hour,
+						},
+						{
+							Day:           time.Date(2018, 9, 2, 0, 0, 0, 0, time.UTC),
+							BuildTime:     12 * time.Hour,
+							ContainerTime: 150 * time.Hour,
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK with the usage of each day, the quota, and warnings for days over it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"days": [
+							{"date": "2018-09-01", "build_minutes": 90, "container_hours": 30},
+							{"date": "2018-09-02", "build_minutes": 720, "container_hours": 150}
+						],
+						"quota": {
+							"build_minutes": 600,
+							"container_hours": 100
+						},
+						"warnings": [
+							"2018-09-02: used 720 build minutes, exceeding the quota of 600",
+							"2018-09-02: used 150.0 container hours, exceeding the quota of 100.0"
+						]
+					}`))
+				})
+
+				It("gets the usage of the last 30 days", func() {
+					Expect(dbTeam.UsageCallCount()).To(Equal(1))
+					Expect(dbTeam.UsageArgsForCall(0)).To(BeTemporally("~", time.Now().AddDate(0, 0, -29), time.Minute))
+				})
+
+				Context("when a since date is given", func() {
+					BeforeEach(func() {
+						query = "?since=2018-09-01"
+					})
+
+					It("gets the usage from that day on", func() {
+						Expect(dbTeam.UsageCallCount()).To(Equal(1))
+						Expect(dbTeam.UsageArgsForCall(0)).To(Equal(time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)))
+					})
+				})
+
+				Context("when the since date is malformed", func() {
+					BeforeEach(func() {
+						query = "?since=yesterday"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbTeam.UsageCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when getting the usage fails", func() {
+				BeforeEach(func() {
+					dbTeam.UsageReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
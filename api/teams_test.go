@@ -224,6 +224,47 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team exists and is allowed to run privileged containers", func() {
+				BeforeEach(func() {
+					allowPrivileged := true
+					atcTeam = atc.Team{AllowPrivileged: &allowPrivileged}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates whether the team may run privileged containers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateAllowPrivilegedCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateAllowPrivilegedArgsForCall(0)).To(BeTrue())
+				})
+
+				Context("when updating fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateAllowPrivilegedReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team exists and the request omits whether it may run privileged containers", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{Auth: map[string][]string{"users": {"local:some-user"}}}
+					fakeTeam.AllowPrivilegedReturns(true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the team's auth", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+				})
+
+				It("leaves whether the team may run privileged containers alone", func() {
+					Expect(fakeTeam.UpdateAllowPrivilegedCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -260,6 +301,40 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("does not update whether the team may run privileged containers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateAllowPrivilegedCallCount()).To(BeZero())
+				})
+
+				Context("when requesting to allow privileged containers", func() {
+					BeforeEach(func() {
+						allowPrivileged := true
+						atcTeam = atc.Team{AllowPrivileged: &allowPrivileged}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					})
+
+					Context("when the team is already allowed", func() {
+						BeforeEach(func() {
+							fakeTeam.AllowPrivilegedReturns(true)
+						})
+
+						It("updates the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateAllowPrivilegedCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
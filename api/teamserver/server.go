@@ -2,23 +2,36 @@ package teamserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/webhooks"
 )
 
 type Server struct {
 	logger      lager.Logger
 	teamFactory db.TeamFactory
 	externalURL string
+
+	maxContainerRetention db.ContainerRetention
+	usageQuota            atc.TeamUsageQuota
+	webhookTargetPolicy   webhooks.TargetPolicy
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	externalURL string,
+	maxContainerRetention db.ContainerRetention,
+	usageQuota atc.TeamUsageQuota,
+	webhookTargetPolicy webhooks.TargetPolicy,
 ) *Server {
 	return &Server{
 		logger:      logger,
 		teamFactory: teamFactory,
 		externalURL: externalURL,
+
+		maxContainerRetention: maxContainerRetention,
+		usageQuota:            usageQuota,
+		webhookTargetPolicy:   webhookTargetPolicy,
 	}
 }
@@ -42,6 +42,13 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if found {
+		requestsPrivileged := atcTeam.AllowPrivileged != nil && *atcTeam.AllowPrivileged
+		if !acc.IsAdmin() && requestsPrivileged && !team.AllowPrivileged() {
+			hLog.Debug("not-allowed-to-allow-privileged")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("updating-credentials")
 		err = team.UpdateProviderAuth(atcTeam.Auth)
 		if err != nil {
@@ -50,6 +57,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if acc.IsAdmin() && atcTeam.AllowPrivileged != nil {
+			err = team.UpdateAllowPrivileged(*atcTeam.AllowPrivileged)
+			if err != nil {
+				hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
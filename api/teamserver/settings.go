@@ -0,0 +1,119 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetTeamSettings(team db.Team) http.Handler {
+	logger := s.logger.Session("get-team-settings")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(presentTeamSettings(team))
+		if err != nil {
+			logger.Error("failed-to-encode-team-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetTeamSettings updates the team's settings. Container retention overrides
+// may not exceed the bounds configured by the operator.
+func (s *Server) SetTeamSettings(team db.Team) http.Handler {
+	logger := s.logger.Session("set-team-settings")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logger.WithData(lager.Data{"team": team.Name()})
+
+		var settings atc.TeamSettings
+		err := json.NewDecoder(r.Body).Decode(&settings)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		retention, err := s.parseContainerRetention(settings.ContainerRetention)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		err = team.UpdateContainerRetention(retention)
+		if err != nil {
+			logger.Error("failed-to-update-container-retention", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentTeamSettings(team))
+		if err != nil {
+			logger.Error("failed-to-encode-team-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) parseContainerRetention(retention atc.ContainerRetention) (db.ContainerRetention, error) {
+	success, err := parseRetention("success", retention.Success, s.maxContainerRetention.Success)
+	if err != nil {
+		return db.ContainerRetention{}, err
+	}
+
+	failure, err := parseRetention("failure", retention.Failure, s.maxContainerRetention.Failure)
+	if err != nil {
+		return db.ContainerRetention{}, err
+	}
+
+	return db.ContainerRetention{
+		Success: success,
+		Failure: failure,
+	}, nil
+}
+
+func parseRetention(name string, value string, max time.Duration) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s container retention: %s", name, err)
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("%s container retention must not be negative", name)
+	}
+
+	if duration > max {
+		return 0, fmt.Errorf("%s container retention must not exceed %s", name, max)
+	}
+
+	return duration, nil
+}
+
+func presentTeamSettings(team db.Team) atc.TeamSettings {
+	retention := team.ContainerRetention()
+
+	var settings atc.TeamSettings
+	if retention.Success != 0 {
+		settings.ContainerRetention.Success = retention.Success.String()
+	}
+
+	if retention.Failure != 0 {
+		settings.ContainerRetention.Failure = retention.Failure.String()
+	}
+
+	return settings
+}
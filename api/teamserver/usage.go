@@ -0,0 +1,96 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+const usageDateFormat = "2006-01-02"
+
+// by default, usage is shown for the last 30 days
+const defaultUsageDays = 30
+
+// GetTeamUsage shows the team's daily usage since the 'since' query param, a
+// date such as 2018-09-01. Days on which the team exceeded the quota are
+// called out in the warnings.
+func (s *Server) GetTeamUsage(team db.Team) http.Handler {
+	logger := s.logger.Session("get-team-usage")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logger.WithData(lager.Data{"team": team.Name()})
+
+		since := time.Now().UTC().AddDate(0, 0, -defaultUsageDays+1)
+		if param := r.URL.Query().Get("since"); param != "" {
+			var err error
+			since, err = time.Parse(usageDateFormat, param)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "malformed since date: %s", param)
+				return
+			}
+		}
+
+		usage, err := team.Usage(since)
+		if err != nil {
+			logger.Error("failed-to-get-team-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := s.presentTeamUsage(usage)
+		if len(presented.Warnings) > 0 {
+			logger.Info("team-exceeded-usage-quota", lager.Data{"warnings": presented.Warnings})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-team-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) presentTeamUsage(usage []db.TeamUsage) atc.TeamUsage {
+	presented := atc.TeamUsage{
+		Days:  []atc.TeamDailyUsage{},
+		Quota: s.usageQuota,
+	}
+
+	for _, day := range usage {
+		daily := atc.TeamDailyUsage{
+			Date:           day.Day.Format(usageDateFormat),
+			BuildMinutes:   day.BuildTime.Minutes(),
+			ContainerHours: day.ContainerTime.Hours(),
+		}
+
+		if s.usageQuota.BuildMinutes != 0 && daily.BuildMinutes > s.usageQuota.BuildMinutes {
+			presented.Warnings = append(presented.Warnings, fmt.Sprintf(
+				"%s: used %.0f build minutes, exceeding the quota of %.0f",
+				daily.Date,
+				daily.BuildMinutes,
+				s.usageQuota.BuildMinutes,
+			))
+		}
+
+		if s.usageQuota.ContainerHours != 0 && daily.ContainerHours > s.usageQuota.ContainerHours {
+			presented.Warnings = append(presented.Warnings, fmt.Sprintf(
+				"%s: used %.1f container hours, exceeding the quota of %.1f",
+				daily.Date,
+				daily.ContainerHours,
+				s.usageQuota.ContainerHours,
+			))
+		}
+
+		presented.Days = append(presented.Days, daily)
+	}
+
+	return presented
+}
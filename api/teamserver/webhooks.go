@@ -0,0 +1,121 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListWebhooks(team db.Team) http.Handler {
+	hLog := s.logger.Session("list-webhooks", lager.Data{"team": team.Name()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhooks, err := team.Webhooks()
+		if err != nil {
+			hLog.Error("failed-to-get-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedWebhooks := []atc.Webhook{}
+		for _, webhook := range webhooks {
+			presentedWebhooks = append(presentedWebhooks, presentWebhook(webhook))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presentedWebhooks)
+		if err != nil {
+			hLog.Error("failed-to-encode-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) CreateWebhook(team db.Team) http.Handler {
+	hLog := s.logger.Session("create-webhook", lager.Data{"team": team.Name()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var webhook atc.Webhook
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		if err != nil {
+			hLog.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhookURL, err := url.Parse(webhook.URL)
+		if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid url '%s'", webhook.URL)
+			return
+		}
+
+		err = s.webhookTargetPolicy.CheckURL(webhookURL)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		if webhook.Secret == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "secret must be specified")
+			return
+		}
+
+		created, err := team.CreateWebhook(webhook.URL, webhook.Secret, webhook.Events)
+		if err != nil {
+			hLog.Error("failed-to-create-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(presentWebhook(created))
+		if err != nil {
+			hLog.Error("failed-to-encode-webhook", err)
+		}
+	})
+}
+
+func (s *Server) DeleteWebhook(team db.Team) http.Handler {
+	hLog := s.logger.Session("delete-webhook", lager.Data{"team": team.Name()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.FormValue(":webhook_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		found, err := team.DeleteWebhook(id)
+		if err != nil {
+			hLog.Error("failed-to-delete-webhook", err, lager.Data{"id": id})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func presentWebhook(webhook db.Webhook) atc.Webhook {
+	return atc.Webhook{
+		ID:     webhook.ID,
+		URL:    webhook.URL,
+		Events: webhook.Events,
+	}
+}
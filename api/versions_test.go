@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/resource"
 )
 
 var _ = Describe("Versions API", func() {
@@ -260,6 +263,184 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
+		var body string
+		var response *http.Response
+
+		BeforeEach(func() {
+			body = `[{"ref":"v1"},{"ref":"v2"}]`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the resource exists", func() {
+					var fakeResource *dbfakes.FakeResource
+
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.NameReturns("resource-name")
+						fakeResource.TypeReturns("some-type")
+						fakeResource.CheckDisabledReturns(true)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("looks up the right resource", func() {
+						Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					})
+
+					It("saves the versions in order", func() {
+						Expect(fakePipeline.SaveResourceVersionsCallCount()).To(Equal(1))
+
+						config, versions := fakePipeline.SaveResourceVersionsArgsForCall(0)
+						Expect(config).To(Equal(atc.ResourceConfig{
+							Name: "resource-name",
+							Type: "some-type",
+						}))
+						Expect(versions).To(Equal([]atc.Version{
+							{"ref": "v1"},
+							{"ref": "v2"},
+						}))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					Context("when saving the versions fails", func() {
+						BeforeEach(func() {
+							fakePipeline.SaveResourceVersionsReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the body is malformed", func() {
+						BeforeEach(func() {
+							body = `{"ref":"v1"}`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not save anything", func() {
+							Expect(fakePipeline.SaveResourceVersionsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the resource is checked", func() {
+						BeforeEach(func() {
+							fakeResource.CheckDisabledReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("check: false"))
+						})
+
+						It("does not save anything", func() {
+							Expect(fakePipeline.SaveResourceVersionsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when a version is over the size limit", func() {
+						BeforeEach(func() {
+							resource.MaxVersionSize = 20
+							body = `[{"ref":"v1"},{"ref":"some-very-long-version"}]`
+						})
+
+						AfterEach(func() {
+							resource.MaxVersionSize = 0
+						})
+
+						It("returns 400 with the error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("over the limit of 20 bytes"))
+						})
+
+						It("does not save anything", func() {
+							Expect(fakePipeline.SaveResourceVersionsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when a version is empty", func() {
+						BeforeEach(func() {
+							body = `[{"ref":"v1"},{}]`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not save anything", func() {
+							Expect(fakePipeline.SaveResourceVersionsCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the resource does not exist", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the resource fails", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", func() {
 		var response *http.Response
 
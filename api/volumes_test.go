@@ -2,7 +2,9 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -29,13 +31,20 @@ var _ = Describe("Volumes API", func() {
 	})
 
 	Describe("GET /api/v1//teams/a-team/volumes", func() {
-		var response *http.Response
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			fakeAccessor.CreateReturns(fakeaccess)
 
 			var err error
-			response, err = client.Get(server.URL + "/api/v1/teams/a-team/volumes")
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/volumes" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -287,6 +296,49 @@ var _ = Describe("Volumes API", func() {
 						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
 					})
 				})
+
+				Context("when paginating", func() {
+					BeforeEach(func() {
+						query = "?since=3&limit=1"
+
+						fakeVolumeRepository.GetTeamVolumesStub = func(teamID int) ([]db.CreatedVolume, error) {
+							volumes := []db.CreatedVolume{}
+							for _, id := range []int{2, 3, 1} {
+								volume := new(dbfakes.FakeCreatedVolume)
+								volume.IDReturns(id)
+								volume.HandleReturns(fmt.Sprintf("handle-%d", id))
+								volume.WorkerNameReturns(fakeWorker.Name())
+								volume.TypeReturns(db.VolumeTypeContainer)
+								volumes = append(volumes, volume)
+							}
+
+							return volumes, nil
+						}
+					})
+
+					It("returns the page of volumes, newest first", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						var volumes []atc.Volume
+						Expect(json.Unmarshal(body, &volumes)).To(Succeed())
+						Expect(volumes).To(HaveLen(1))
+						Expect(volumes[0].ID).To(Equal("handle-2"))
+					})
+
+					It("links to the next and previous pages", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/volumes?limit=1&since=2>; rel="next"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/volumes?limit=1&until=2>; rel="previous"`, externalURL),
+						}))
+					})
+				})
+
+				Context("when not paginating", func() {
+					It("does not add links", func() {
+						Expect(response.Header["Link"]).To(BeEmpty())
+					})
+				})
 			})
 		})
 	})
@@ -506,4 +558,180 @@ var _ = Describe("Volumes API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/caches?resource_hash=some-hash")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+			})
+
+			Context("when getting the caches succeeds", func() {
+				BeforeEach(func() {
+					fakeVolumeRepository.GetResourceCacheVolumesReturns([]db.ResourceCacheVolume{
+						{
+							ID:              42,
+							Handle:          "some-handle",
+							WorkerName:      "some-worker",
+							ResourceCacheID: 7,
+							ResourceHash:    "some-hash",
+							Version:         atc.Version{"some": "version"},
+							ParamsHash:      "some-params-hash",
+						},
+					}, nil)
+				})
+
+				It("filters by the given resource hash", func() {
+					Expect(fakeVolumeRepository.GetResourceCacheVolumesCallCount()).To(Equal(1))
+					Expect(fakeVolumeRepository.GetResourceCacheVolumesArgsForCall(0)).To(Equal("some-hash"))
+				})
+
+				It("returns 200 OK with the caches", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 42,
+							"handle": "some-handle",
+							"worker_name": "some-worker",
+							"resource_cache_id": 7,
+							"resource_hash": "some-hash",
+							"version": {"some": "version"},
+							"params_hash": "some-params-hash"
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the caches fails", func() {
+				BeforeEach(func() {
+					fakeVolumeRepository.GetResourceCacheVolumesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/caches/:id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/caches/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not look up the cache", func() {
+				Expect(fakeVolumeRepository.FindResourceCacheVolumeByIDCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			var fakeVolume *dbfakes.FakeCreatedVolume
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				fakeVolume = new(dbfakes.FakeCreatedVolume)
+			})
+
+			Context("when the cache exists", func() {
+				BeforeEach(func() {
+					fakeVolumeRepository.FindResourceCacheVolumeByIDReturns(fakeVolume, true, nil)
+				})
+
+				It("looks up the cache volume by id", func() {
+					Expect(fakeVolumeRepository.FindResourceCacheVolumeByIDArgsForCall(0)).To(Equal(42))
+				})
+
+				It("marks the volume as destroying and returns 204 No Content", func() {
+					Expect(fakeVolume.DestroyingCallCount()).To(Equal(1))
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				Context("when the cache is in use", func() {
+					BeforeEach(func() {
+						fakeVolume.DestroyingReturns(nil, db.ErrVolumeCannotBeDestroyedWithChildrenPresent)
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when marking the volume as destroying fails", func() {
+					BeforeEach(func() {
+						fakeVolume.DestroyingReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the cache does not exist", func() {
+				BeforeEach(func() {
+					fakeVolumeRepository.FindResourceCacheVolumeByIDReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the cache fails", func() {
+				BeforeEach(func() {
+					fakeVolumeRepository.FindResourceCacheVolumeByIDReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
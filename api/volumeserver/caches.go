@@ -0,0 +1,88 @@
+package volumeserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListCaches(w http.ResponseWriter, r *http.Request) {
+	resourceHash := r.URL.Query().Get("resource_hash")
+
+	logger := s.logger.Session("list-caches", lager.Data{"resource-hash": resourceHash})
+
+	volumes, err := s.repository.GetResourceCacheVolumes(resourceHash)
+	if err != nil {
+		logger.Error("failed-to-get-resource-cache-volumes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Debug("listed", lager.Data{"cache-count": len(volumes)})
+
+	caches := []atc.Cache{}
+	for _, volume := range volumes {
+		caches = append(caches, atc.Cache{
+			ID:              volume.ID,
+			Handle:          volume.Handle,
+			WorkerName:      volume.WorkerName,
+			ResourceCacheID: volume.ResourceCacheID,
+			ResourceHash:    volume.ResourceHash,
+			Version:         volume.Version,
+			ParamsHash:      volume.ParamsHash,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(caches)
+	if err != nil {
+		logger.Error("failed-to-encode-caches", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// DestroyCache marks a resource cache volume as destroying, so that it is
+// removed from its worker by the garbage collector and fetched again the next
+// time it is needed.
+func (s *Server) DestroyCache(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("destroy-cache")
+
+	id, err := strconv.Atoi(r.FormValue(":id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	logger = logger.WithData(lager.Data{"id": id})
+
+	volume, found, err := s.repository.FindResourceCacheVolumeByID(id)
+	if err != nil {
+		logger.Error("failed-to-find-resource-cache-volume", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_, err = volume.Destroying()
+	if err != nil {
+		if err == db.ErrVolumeCannotBeDestroyedWithChildrenPresent {
+			logger.Info("cache-in-use")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		logger.Error("failed-to-mark-volume-as-destroying", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
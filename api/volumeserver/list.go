@@ -3,6 +3,7 @@ package volumeserver
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -10,6 +11,8 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+// ListVolumes lists the team's volumes. If any of the since, until or limit
+// params are given, they are paged through by ID, newest first.
 func (s *Server) ListVolumes(team db.Team) http.Handler {
 	hLog := s.logger.Session("list-volumes")
 
@@ -25,6 +28,22 @@ func (s *Server) ListVolumes(team db.Team) http.Handler {
 
 		hLog.Debug("listed", lager.Data{"volume-count": len(volumes)})
 
+		if page, paginated := parsePage(r); paginated {
+			sort.Slice(volumes, func(i, j int) bool {
+				return volumes[i].ID() > volumes[j].ID()
+			})
+
+			ids := make([]int, len(volumes))
+			for i, volume := range volumes {
+				ids[i] = volume.ID()
+			}
+
+			start, end, pagination := db.PageIDs(ids, page)
+			volumes = volumes[start:end]
+
+			s.addPaginationLinks(w, r, pagination)
+		}
+
 		presentedVolumes := []atc.Volume{}
 		for i := 0; i < len(volumes); i++ {
 			volume := volumes[i]
@@ -0,0 +1,59 @@
+package volumeserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// parsePage returns the requested page, and false if no pagination params
+// were given, in which case everything is listed.
+func parsePage(r *http.Request) (db.Page, bool) {
+	query := r.URL.Query()
+	if query.Get(atc.PaginationQuerySince) == "" &&
+		query.Get(atc.PaginationQueryUntil) == "" &&
+		query.Get(atc.PaginationQueryLimit) == "" {
+		return db.Page{}, false
+	}
+
+	since, _ := strconv.Atoi(query.Get(atc.PaginationQuerySince))
+	until, _ := strconv.Atoi(query.Get(atc.PaginationQueryUntil))
+
+	limit, _ := strconv.Atoi(query.Get(atc.PaginationQueryLimit))
+	if limit <= 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	return db.Page{Since: since, Until: until, Limit: limit}, true
+}
+
+// addPaginationLinks links to the pages around the current one, keeping the
+// request's other query params.
+func (s *Server) addPaginationLinks(w http.ResponseWriter, r *http.Request, pagination db.Pagination) {
+	if pagination.Next != nil {
+		s.addLink(w, r, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, r, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+}
+
+func (s *Server) addLink(w http.ResponseWriter, r *http.Request, cursorParam string, cursor int, limit int, rel string) {
+	query := r.URL.Query()
+	query.Del(atc.PaginationQuerySince)
+	query.Del(atc.PaginationQueryUntil)
+	query.Set(cursorParam, strconv.Itoa(cursor))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s%s?%s>; rel="%s"`,
+		s.externalURL,
+		r.URL.Path,
+		query.Encode(),
+		rel,
+	))
+}
@@ -7,19 +7,22 @@ import (
 )
 
 type Server struct {
-	logger     lager.Logger
-	repository db.VolumeRepository
-	destroyer  gc.Destroyer
+	logger      lager.Logger
+	externalURL string
+	repository  db.VolumeRepository
+	destroyer   gc.Destroyer
 }
 
 func NewServer(
 	logger lager.Logger,
+	externalURL string,
 	volumeRepository db.VolumeRepository,
 	destroyer gc.Destroyer,
 ) *Server {
 	return &Server{
-		logger:     logger,
-		repository: volumeRepository,
-		destroyer:  destroyer,
+		logger:      logger,
+		externalURL: externalURL,
+		repository:  volumeRepository,
+		destroyer:   destroyer,
 	}
 }
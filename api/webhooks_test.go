@@ -0,0 +1,300 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/webhooks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the webhooks succeeds", func() {
+				BeforeEach(func() {
+					dbTeam.WebhooksReturns([]db.Webhook{
+						{
+							ID:     1,
+							TeamID: 734,
+							URL:    "https://example.com/hook",
+							Secret: "some-secret",
+							Events: []string{"status"},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK with the webhooks, without their secrets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 1,
+							"url": "https://example.com/hook",
+							"events": ["status"]
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the webhooks fails", func() {
+				BeforeEach(func() {
+					dbTeam.WebhooksReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/webhooks", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"url":"https://example.com/hook","secret":"some-secret","events":["status","finish-task"]}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/webhooks", "application/json", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not create the webhook", func() {
+				Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				dbTeam.CreateWebhookReturns(db.Webhook{
+					ID:     42,
+					TeamID: 734,
+					URL:    "https://example.com/hook",
+					Secret: "some-secret",
+					Events: []string{"status", "finish-task"},
+				}, nil)
+			})
+
+			It("creates the webhook", func() {
+				Expect(dbTeam.CreateWebhookCallCount()).To(Equal(1))
+
+				url, secret, events := dbTeam.CreateWebhookArgsForCall(0)
+				Expect(url).To(Equal("https://example.com/hook"))
+				Expect(secret).To(Equal("some-secret"))
+				Expect(events).To(Equal([]string{"status", "finish-task"}))
+			})
+
+			It("returns 201 Created with the webhook, without its secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"id": 42,
+					"url": "https://example.com/hook",
+					"events": ["status", "finish-task"]
+				}`))
+			})
+
+			Context("when the url is invalid", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"ftp://example.com/hook","secret":"some-secret"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the url points at a link-local address", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"http://169.254.169.254/latest/meta-data","secret":"some-secret"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the url points at a loopback address", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"http://127.0.0.1:8080/api/v1/teams","secret":"some-secret"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the url's host resolves to a private address", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"https://internal.example.com/hook","secret":"some-secret"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the secret is missing", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"https://example.com/hook"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when creating the webhook fails", func() {
+				BeforeEach(func() {
+					dbTeam.CreateWebhookReturns(db.Webhook{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/webhooks/:webhook_id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/webhooks/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(true, nil)
+				})
+
+				It("deletes it and returns 204 No Content", func() {
+					Expect(dbTeam.DeleteWebhookCallCount()).To(Equal(1))
+					Expect(dbTeam.DeleteWebhookArgsForCall(0)).To(Equal(42))
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the webhook fails", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -244,6 +244,45 @@ var _ = Describe("Workers API", func() {
 						Expect(foundTeam.SaveWorkerCallCount()).To(Equal(1))
 					})
 
+					Context("when request is not from tsa", func() {
+						BeforeEach(func() {
+							fakeaccess.IsSystemReturns(false)
+						})
+
+						Context("when the requester is a member of the team", func() {
+							BeforeEach(func() {
+								fakeaccess.IsAuthorizedReturns(true)
+							})
+
+							It("checks membership of the worker's team", func() {
+								Expect(fakeaccess.IsAuthorizedArgsForCall(0)).To(Equal("some-team"))
+							})
+
+							It("saves the worker to the team", func() {
+								Expect(foundTeam.SaveWorkerCallCount()).To(Equal(1))
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+						})
+
+						Context("when the requester is not a member of the team", func() {
+							BeforeEach(func() {
+								fakeaccess.IsAuthorizedReturns(false)
+							})
+
+							It("does not save the worker", func() {
+								Expect(foundTeam.SaveWorkerCallCount()).To(BeZero())
+								Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
+							})
+
+							It("returns 403", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							})
+						})
+					})
+
 					Context("when saving the worker succeeds", func() {
 						BeforeEach(func() {
 							foundTeam.SaveWorkerReturns(new(dbfakes.FakeWorker), nil)
@@ -426,6 +465,41 @@ var _ = Describe("Workers API", func() {
 				})
 			})
 
+			Context("when the worker's runtime has not been registered", func() {
+				BeforeEach(func() {
+					worker.Runtime = "some-unknown-runtime"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("returns the unknown runtime in the response body", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("unknown runtime: some-unknown-runtime")))
+				})
+
+				It("does not save it", func() {
+					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the worker's runtime is garden", func() {
+				BeforeEach(func() {
+					worker.Runtime = "garden"
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the runtime", func() {
+					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(Equal(1))
+
+					savedInfo, _ := dbWorkerFactory.SaveWorkerArgsForCall(0)
+					Expect(savedInfo.Runtime).To(Equal("garden"))
+				})
+			})
+
 			Context("when worker version is invalid", func() {
 				BeforeEach(func() {
 					worker.Version = "invalid"
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/worker"
 )
 
 type IntMetric int
@@ -24,18 +25,21 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("register-worker")
 	var registration atc.Worker
 
-	acc := accessor.GetAccessor(r)
-	if !acc.IsSystem() {
-		w.WriteHeader(http.StatusForbidden)
-		return
-	}
-
 	err := json.NewDecoder(r.Body).Decode(&registration)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	// workers are normally registered through the TSA, but members of a team
+	// may also register workers of their own which only run the team's work
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() && (registration.Team == "" || !acc.IsAuthorized(registration.Team)) {
+		logger.Info("not-allowed-to-register-worker", lager.Data{"team-name": registration.Team})
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	err = registration.Validate()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -43,6 +47,15 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if registration.Runtime != "" {
+		if _, found := worker.RuntimeFactories()[registration.Runtime]; !found {
+			logger.Info("unknown-runtime", lager.Data{"runtime": registration.Runtime})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown runtime: %s", registration.Runtime)
+			return
+		}
+	}
+
 	var ttl time.Duration
 
 	ttlStr := r.URL.Query().Get("ttl")
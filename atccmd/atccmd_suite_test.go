@@ -0,0 +1,13 @@
+package atccmd
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAtccmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Atccmd Suite")
+}
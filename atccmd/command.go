@@ -1,15 +1,24 @@
 package atccmd
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -28,16 +37,23 @@ import (
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/eventstream"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc"
+	"github.com/concourse/atc/health"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/sentry"
+	"github.com/concourse/atc/teammapping"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/webhooks"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
+	"github.com/concourse/atc/worker/taskcache"
 	"github.com/concourse/atc/wrappa"
 	"github.com/concourse/flag"
 	"github.com/concourse/retryhttp"
@@ -49,8 +65,9 @@ import (
 	"github.com/jessevdk/go-flags"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
-	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/atc/metric/emitter"
@@ -72,7 +89,7 @@ type ATCCommand struct {
 }
 
 type RunCommand struct {
-	Logger flag.Lager
+	Logger LoggerConfig `group:"Logging"`
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -81,14 +98,21 @@ type RunCommand struct {
 	TLSCert     flag.File `long:"tls-cert"      description:"File containing an SSL certificate."`
 	TLSKey      flag.File `long:"tls-key"       description:"File containing an RSA private key, used to encrypt HTTPS traffic."`
 
+	LetsEncrypt struct {
+		Enable  bool     `long:"enable-lets-encrypt"   description:"Automatically obtain and renew the certificate for the external URL through Let's Encrypt, rather than using --tls-cert and --tls-key."`
+		ACMEURL flag.URL `long:"lets-encrypt-acme-url" default:"https://acme-v02.api.letsencrypt.org/directory" description:"URL of the ACME CA directory endpoint."`
+	} `group:"Let's Encrypt Configuration"`
+
 	ExternalURL flag.URL `long:"external-url" description:"URL used to reach any ATC from the outside world."`
 	PeerURL     flag.URL `long:"peer-url"     description:"URL used to reach this ATC from other ATCs in the cluster."`
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
-	CredentialManagement struct{} `group:"Credential Management"`
+	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
+	LogSanitization engine.LogSanitizerConfig `group:"Build Log Sanitization"`
+
 	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
 	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
 
@@ -100,14 +124,31 @@ type RunCommand struct {
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
+	MaxChecksPerSecond           float64       `long:"max-checks-per-second" default:"0" description:"Maximum number of resource checks to start per second, across all pipelines. 0 means no limit."`
+
+	MaxResourceVersionSize  int `long:"max-resource-version-size"  default:"65536"  description:"Fail checks, gets, and puts whose resource emits a version larger than this many bytes. 0 means no limit."`
+	MaxResourceMetadataSize int `long:"max-resource-metadata-size" default:"262144" description:"Fail gets and puts whose resource emits metadata larger than this many bytes. 0 means no limit."`
 
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" description:"Method by which a worker is selected during container placement."`
+	MaxInputUploadSize int64 `long:"max-input-upload-size" default:"10737418240" description:"Reject inputs uploaded to builds in chunks which are larger than this many bytes."`
+
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"least-loaded" description:"Method by which a worker is selected during container placement. 'least-loaded' favors the workers reporting the least CPU load."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
+	MinVolumeStreamThroughput         uint64        `long:"min-volume-stream-throughput" default:"0" description:"Warn when streaming a volume from one worker to another runs slower than this many bytes per second. 0 disables the warning."`
+
+	BaseResourceTypeDefaults flag.File         `long:"base-resource-type-defaults" description:"YAML file mapping base resource types to source fields used whenever a resource leaves them unset."`
+	ResourceTypeVersions     map[string]string `long:"resource-type-version"       description:"Only run a base resource type on workers advertising this version of it. Can be specified multiple times." value-name:"TYPE:VERSION"`
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
+
+		FaultInjection struct {
+			Enabled              bool          `long:"dev-fault-injection"                        description:"Randomly inject faults to test resilience. Never enable this in production."`
+			MaxQueryDelay        time.Duration `long:"dev-fault-injection-max-query-delay"        default:"100ms" description:"Maximum random delay to add to each database query."`
+			ContainerFailureRate float64       `long:"dev-fault-injection-container-failure-rate" default:"0.1"   description:"Fraction of container creations to fail."`
+			HeartbeatDropRate    float64       `long:"dev-fault-injection-heartbeat-drop-rate"    default:"0.1"   description:"Fraction of worker heartbeats to drop."`
+		}
 	} `group:"Developer Options"`
 
 	Worker struct {
@@ -121,31 +162,93 @@ type RunCommand struct {
 		Attributes map[string]string `long:"metrics-attribute"   description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 	} `group:"Metrics & Diagnostics"`
 
+	Sentry struct {
+		DSN         string `long:"dsn"         description:"Sentry DSN to report logged errors to."`
+		Environment string `long:"environment" description:"Environment to tag errors reported to Sentry with."`
+	} `group:"Error Reporting (Sentry)" namespace:"sentry"`
+
+	Tracing struct {
+		JaegerEndpoint string `long:"jaeger-endpoint" description:"URL of a Jaeger collector to send traces of builds and checks to, e.g. http://jaeger:14268/api/traces."`
+		ServiceName    string `long:"service-name" default:"atc" description:"Service name to report traces under."`
+	} `group:"Tracing" namespace:"tracing"`
+
 	Server struct {
-		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
+		XFrameOptions           string   `long:"x-frame-options"             description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
+		StrictTransportSecurity string   `long:"strict-transport-security"   description:"The value to set for Strict-Transport-Security when the external URL is HTTPS, e.g. 'max-age=31536000'. If omitted, the header is not set."`
+		ContentSecurityPolicy   string   `long:"content-security-policy"     description:"The value to set for Content-Security-Policy. If omitted, the header is not set."`
+		SecurityExemptPaths     []string `long:"security-header-exempt-path" description:"Path prefix served without X-Frame-Options or Content-Security-Policy, e.g. to embed the dashboard. Can be specified multiple times."`
+
+		AllowCORSOrigins []string `long:"allow-cors-origin" description:"Origin allowed to make cross-origin requests to the API, or '*' to allow any origin without credentials. Can be specified multiple times."`
+
+		ReadTimeout     time.Duration `long:"web-read-timeout"     default:"0"   description:"Maximum duration for reading an entire request, including the body. Zero means no timeout."`
+		WriteTimeout    time.Duration `long:"web-write-timeout"    default:"0"   description:"Maximum duration before timing out writes of a response. Zero means no timeout; a non-zero value also bounds build event streams."`
+		IdleTimeout     time.Duration `long:"web-idle-timeout"     default:"2m"  description:"Maximum duration to keep an idle keep-alive connection open."`
+		ShutdownTimeout time.Duration `long:"web-shutdown-timeout" default:"30s" description:"Maximum duration to wait for in-flight requests to finish when shutting down."`
 	} `group:"Web Server"`
 
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
 
+	MigrationLockTimeout time.Duration `long:"migration-lock-timeout" default:"0" description:"Maximum duration to wait for another ATC to finish migrating the database before giving up. Zero waits forever."`
+
 	GC struct {
-		Interval               time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
-		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Grace period before reaping one-off task containers"`
-		WorkerConcurrency      int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+		Interval                 time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
+		OneOffBuildGracePeriod   time.Duration `long:"one-off-grace-period" default:"5m" description:"Grace period before reaping one-off task containers"`
+		WorkerConcurrency        int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+		ContainerReaperBatchSize int           `long:"container-reaper-batch-size" default:"100" description:"Maximum number of destroying containers to destroy on each worker per interval."`
+		ContainerReaperAttempts  int           `long:"container-reaper-attempts" default:"3" description:"Number of attempts to make at destroying a container before leaving it for the next interval."`
+		TaskCacheIdleTTL         time.Duration `long:"task-cache-idle-ttl" description:"Remove task caches which have not been used by a build for this long, e.g. 168h for a week. 0 means never."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	ContainerRetention struct {
+		SuccessDuration    time.Duration `long:"success-duration" description:"Duration for which to keep the containers of succeeded builds around for hijacking."`
+		FailureDuration    time.Duration `long:"failure-duration" description:"Duration for which to keep the containers of failed builds around for hijacking, in addition to those of each job's latest build."`
+		MaxSuccessDuration time.Duration `long:"max-success-duration" default:"24h" description:"Maximum duration to which teams may set their success duration."`
+		MaxFailureDuration time.Duration `long:"max-failure-duration" default:"24h" description:"Maximum duration to which teams may set their failure duration."`
+	} `group:"Container Retention" namespace:"container-retention"`
+
+	TeamUsage struct {
+		RecordInterval      time.Duration `long:"record-interval" default:"1m" description:"Interval on which to record the time used by each team's running builds and containers. 0 means never."`
+		DailyBuildMinutes   float64       `long:"daily-build-minutes" description:"Soft limit on the build minutes each team may use per day. Teams exceeding it are only warned."`
+		DailyContainerHours float64       `long:"daily-container-hours" description:"Soft limit on the container hours each team may use per day. Teams exceeding it are only warned."`
+	} `group:"Team Usage" namespace:"team-usage"`
+
+	Webhooks struct {
+		DeliveryInterval time.Duration `long:"delivery-interval" default:"5s" description:"Interval on which to deliver queued build events to webhooks."`
+		Timeout          time.Duration `long:"timeout" default:"10s" description:"Timeout for each webhook delivery."`
+		MaxAttempts      int           `long:"max-attempts" default:"10" description:"Number of attempts to make at delivering an event before giving up on it."`
+		AllowedNetworks  []string      `long:"allowed-network" description:"CIDR of a loopback, link-local or private network which webhooks may be delivered to. Can be specified multiple times."`
+	} `group:"Webhooks" namespace:"webhook"`
+
+	TaskCacheSnapshots taskcache.S3Config `group:"Task Cache Snapshots" namespace:"task-cache-snapshot"`
+
+	BuildEventStream eventstream.Config `group:"Build Event Streaming" namespace:"build-event-stream"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	HookTimeout time.Duration `long:"hook-timeout" description:"Maximum time an ensure, on_success or on_failure hook may run for before it's interrupted, regardless of its own timeout. 0 means no limit."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
 	DefaultBuildLogsToRetain uint64 `long:"default-build-logs-to-retain" description:"Default build logs to retain, 0 means all"`
 	MaxBuildLogsToRetain     uint64 `long:"max-build-logs-to-retain" description:"Maximum build logs to retain, 0 means not specified. Will override values configured in jobs"`
 
+	ResourceVersionHistoryLimit int `long:"resource-version-history-limit" description:"Default number of versions to keep for each resource, 0 means all. Versions used by builds are always kept. Overridden by version_history_limit on the resource."`
+
+	PipelinePurgeGracePeriod time.Duration `long:"pipeline-purge-grace-period" default:"24h" description:"Period for which deleted pipelines are kept around, during which they can be undeleted."`
+	PausePipelinesIdleAfter  time.Duration `long:"pause-pipelines-idle-after" description:"Pause pipelines whose config has not changed, and none of whose jobs have run, for this long, e.g. 720h for 30 days. 0 means never."`
+
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
+
+		AllowTokensWithoutAudience bool `long:"allow-tokens-without-audience" description:"Accept tokens which don't name an issuer or audience, e.g. those issued before upgrading. Tokens naming another installation are always rejected."`
+
+		OldSigningKey *flag.PrivateKey `long:"old-session-signing-key" description:"File containing the RSA private key previously used to sign session tokens. Tokens signed by it remain valid while rotating to a new --session-signing-key."`
+
+		GitHubTeamMappings []teammapping.GitHubTeamMapping `long:"github-team-mapping" value-name:"ORG[:TEAM]=CONCOURSE-TEAM" description:"Make members of a GitHub organization or team members of a Concourse team when they log in with GitHub. Can be specified multiple times."`
 	} `group:"Authentication"`
 }
 
@@ -315,10 +418,36 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, bool,
 	}
 
 	radar.GlobalResourceCheckTimeout = cmd.GlobalResourceCheckTimeout
+	resource.MaxVersionSize = cmd.MaxResourceVersionSize
+	resource.MaxMetadataSize = cmd.MaxResourceMetadataSize
+
+	if cmd.BaseResourceTypeDefaults != "" {
+		payload, err := ioutil.ReadFile(string(cmd.BaseResourceTypeDefaults))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read base resource type defaults: %s", err)
+		}
+
+		defaults, err := atc.ParseBaseResourceTypeDefaults(payload)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to parse base resource type defaults: %s", err)
+		}
+
+		atc.LoadBaseResourceTypeDefaults(defaults)
+	}
+
 	//FIXME: These only need to run once for the entire binary. At the moment,
 	//they rely on state of the command.
 	db.SetupConnectionRetryingDriver("postgres", cmd.Postgres.ConnectionString(), retryingDriverName)
-	logger, reconfigurableSink := cmd.Logger.Logger("atc")
+	logger, reconfigurableSink := cmd.constructLogger()
+
+	if cmd.Sentry.DSN != "" {
+		sentrySink, err := sentry.NewSink(cmd.Sentry.DSN, cmd.Sentry.Environment)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to configure sentry: %s", err)
+		}
+
+		logger.RegisterSink(sentrySink)
+	}
 
 	http.HandleFunc("/debug/connections", func(w http.ResponseWriter, r *http.Request) {
 		for _, stack := range db.GlobalConnectionTracker.Current() {
@@ -326,17 +455,42 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, bool,
 		}
 	})
 
+	http.HandleFunc("/debug/migrations", func(w http.ResponseWriter, r *http.Request) {
+		status, err := migration.NewOpenHelper(
+			defaultDriverName,
+			cmd.Postgres.ConnectionString(),
+			nil,
+			encryption.NewNoEncryption(),
+		).Status()
+		if err != nil {
+			logger.Error("failed-to-get-migration-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+
 	if err := cmd.configureMetrics(logger); err != nil {
 		return nil, false, err
 	}
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
+	tracer, err := tracing.ConfigureJaeger(cmd.Tracing.ServiceName, cmd.Tracing.JaegerEndpoint)
+	if err != nil {
+		return nil, false, err
+	}
+
 	members, err := cmd.constructMembers(positionalArguments, logger, reconfigurableSink)
 	if err != nil {
+		tracer.Close()
 		return nil, false, err
 	}
 
-	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
+	// the tracer is only closed once every member has exited, so that the
+	// spans of builds and checks interrupted by shutdown are still sent
+	return closeOnExit(onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
 			"debug": cmd.debugBindAddr(),
@@ -347,7 +501,21 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, bool,
 		}
 
 		logger.Info("listening", logData)
-	}), false, nil
+	}), tracer), false, nil
+}
+
+func (cmd *RunCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
+	logger := lager.NewLogger("atc")
+
+	reconfigurableSink := lager.NewReconfigurableSink(&logSink{
+		writer:          os.Stdout,
+		format:          cmd.Logger.LogFormat,
+		timestampFormat: cmd.Logger.TimestampFormat,
+	}, cmd.Logger.minLogLevel())
+
+	logger.RegisterSink(reconfigurableSink)
+
+	return logger, reconfigurableSink
 }
 
 func (cmd *RunCommand) constructMembers(
@@ -375,22 +543,41 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink)
+	// both the API and the backend run builds, so they share the streamer
+	streamer := cmd.BuildEventStream.NewStreamer(logger.Session("build-event-stream"))
+
+	var buildEventStreamer engine.BuildEventStreamer
+	if streamer != nil {
+		buildEventStreamer = streamer
+	}
+
+	// the budget of checks is for the whole ATC, so the API's and the
+	// backend's scanners share it
+	checkRateLimiter := radar.NewCheckRateLimiter(clock.NewClock(), cmd.MaxChecksPerSecond)
+
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, buildEventStreamer, checkRateLimiter)
 	if err != nil {
 		return nil, err
 	}
 
-	backendMembers, err := cmd.constructBackendMembers(logger)
+	backendMembers, err := cmd.constructBackendMembers(logger, buildEventStreamer, checkRateLimiter)
 	if err != nil {
 		return nil, err
 	}
 
-	return append(apiMembers, backendMembers...), nil
+	members := append(apiMembers, backendMembers...)
+	if streamer != nil {
+		members = append(members, grouper.Member{Name: "build-event-stream", Runner: streamer})
+	}
+
+	return members, nil
 }
 
 func (cmd *RunCommand) constructAPIMembers(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
+	buildEventStreamer engine.BuildEventStreamer,
+	checkRateLimiter *radar.CheckRateLimiter,
 ) ([]grouper.Member, error) {
 	connectionName := "api"
 	maxConns := 32
@@ -418,9 +605,20 @@ func (cmd *RunCommand) constructAPIMembers(
 	if err != nil {
 		return nil, err
 	}
-	authHandler, err := skymarshal.NewServer(&skymarshal.Config{
+	// the auth server would otherwise generate its own key, leaving none to
+	// re-sign its tokens with
+	if cmd.Auth.AuthFlags.SigningKey == nil {
+		signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Auth.AuthFlags.SigningKey = &flag.PrivateKey{PrivateKey: signingKey}
+	}
+
+	authServer, err := skymarshal.NewServer(&skymarshal.Config{
 		Logger:      logger,
-		TeamFactory: teamFactory,
+		TeamFactory: teammapping.NewTeamFactory(teamFactory, cmd.Auth.GitHubTeamMappings),
 		Flags:       cmd.Auth.AuthFlags,
 		ServerURL:   cmd.ExternalURL.String(),
 		HttpClient:  httpClient,
@@ -430,6 +628,12 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	authHandler := auth.TokenClaimsHandler{
+		Issuer:     cmd.ExternalURL.String(),
+		SigningKey: cmd.Auth.AuthFlags.SigningKey.PrivateKey,
+		Handler:    authServer,
+	}
+
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
@@ -444,6 +648,9 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbWorkerTaskCacheFactory := db.NewWorkerTaskCacheFactory(dbConn)
 	dbVolumeRepository := db.NewVolumeRepository(dbConn)
 	dbWorkerFactory := db.NewWorkerFactory(dbConn)
+	if cmd.Developer.FaultInjection.Enabled {
+		dbWorkerFactory = db.DropHeartbeats(logger.Session("fault-injection"), dbWorkerFactory, cmd.Developer.FaultInjection.HeartbeatDropRate)
+	}
 	workerVersion, err := workerVersion()
 	if err != nil {
 		return nil, err
@@ -461,6 +668,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.ResourceTypeVersions,
+		cmd.MinVolumeStreamThroughput,
 	)
 
 	workerClient := cmd.constructWorkerPool(
@@ -475,11 +684,17 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
-	variablesFactory, err := cmd.variablesFactory(logger)
+	variablesFactory, buildTokenIssuer, err := cmd.variablesFactory(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	taskCacheStore, err := cmd.TaskCacheSnapshots.NewStore(logger.Session("task-cache-snapshots"))
 	if err != nil {
 		return nil, err
 	}
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, defaultLimits)
+
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, buildTokenIssuer, defaultLimits, taskCacheStore, buildEventStreamer)
 
 	dbResourceConfigCheckSessionFactory := db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
@@ -488,6 +703,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
 		engine,
+		dbWorkerFactory,
+		checkRateLimiter,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
@@ -507,6 +724,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
+	dbBuildInputUploadFactory := db.NewBuildInputUploadFactory(dbConn)
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
 		reconfigurableSink,
@@ -519,6 +737,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbContainerRepository,
 		gcContainerDestroyer,
 		dbBuildFactory,
+		dbBuildInputUploadFactory,
 		engine,
 		workerClient,
 		workerProvider,
@@ -527,19 +746,22 @@ func (cmd *RunCommand) constructAPIMembers(
 		radarScannerFactory,
 		variablesFactory,
 		credsManagers,
+		cmd.signingKeys(authServer.PublicKey()),
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
+	accessFactory := accessor.NewAccessFactory(cmd.signingKeys(authServer.PublicKey()), cmd.ExternalURL.String(), !cmd.Auth.AllowTokensWithoutAudience)
 	apiHandler = accessor.NewHandler(apiHandler, accessFactory)
 	webHandler, err := webHandler(logger)
 	if err != nil {
 		return nil, err
 	}
 
+	readinessChecks := cmd.constructReadinessChecks(dbConn, authServer.PublicKey())
+
 	var httpHandler, httpsHandler http.Handler
 	if cmd.isTLSEnabled() {
 		httpHandler = cmd.constructHTTPHandler(
@@ -563,6 +785,8 @@ func (cmd *RunCommand) constructAPIMembers(
 				externalHost: cmd.ExternalURL.URL.Host,
 				baseHandler:  authHandler,
 			},
+
+			readinessChecks,
 		)
 
 		httpsHandler = cmd.constructHTTPHandler(
@@ -570,6 +794,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			webHandler,
 			apiHandler,
 			authHandler,
+			readinessChecks,
 		)
 	} else {
 		httpHandler = cmd.constructHTTPHandler(
@@ -577,29 +802,52 @@ func (cmd *RunCommand) constructAPIMembers(
 			webHandler,
 			apiHandler,
 			authHandler,
+			readinessChecks,
 		)
 	}
 
+	var certManager *autocert.Manager
+	if cmd.LetsEncrypt.Enable {
+		certManager = cmd.letsEncryptManager(dbConn)
+	}
+
+	httpHandler = cmd.nonTLSHandler(httpHandler, certManager)
+
+	// both the http and https servers drain event streams upon shutdown, but
+	// the channel may only be closed once
+	var drainOnce sync.Once
+	drainEventStreams := func() {
+		drainOnce.Do(func() { close(drain) })
+	}
+
 	members := []grouper.Member{
-		{Name: "debug", Runner: http_server.New(
+		{Name: "debug", Runner: cmd.httpServer(
+			logger.Session("debug-server"),
 			cmd.debugBindAddr(),
 			http.DefaultServeMux,
+			nil,
+			nil,
 		)},
-		{Name: "web", Runner: http_server.New(
+		{Name: "web", Runner: cmd.httpServer(
+			logger.Session("web-server"),
 			cmd.nonTLSBindAddr(),
 			httpHandler,
+			nil,
+			drainEventStreams,
 		)},
 	}
 
 	if httpsHandler != nil {
-		tlsConfig, err := cmd.tlsConfig()
+		tlsConfig, err := cmd.tlsConfig(certManager)
 		if err != nil {
 			return nil, err
 		}
-		members = append(members, grouper.Member{Name: "web-tls", Runner: http_server.NewTLSServer(
+		members = append(members, grouper.Member{Name: "web-tls", Runner: cmd.httpServer(
+			logger.Session("web-tls-server"),
 			cmd.tlsBindAddr(),
 			httpsHandler,
 			tlsConfig,
+			drainEventStreams,
 		)})
 	}
 
@@ -608,6 +856,8 @@ func (cmd *RunCommand) constructAPIMembers(
 
 func (cmd *RunCommand) constructBackendMembers(
 	logger lager.Logger,
+	buildEventStreamer engine.BuildEventStreamer,
+	checkRateLimiter *radar.CheckRateLimiter,
 ) ([]grouper.Member, error) {
 	connectionName := "backend"
 	maxConns := 32
@@ -653,6 +903,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.ResourceTypeVersions,
+		cmd.MinVolumeStreamThroughput,
 	)
 	workerClient := cmd.constructWorkerPool(
 		logger,
@@ -666,11 +918,17 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
-	variablesFactory, err := cmd.variablesFactory(logger)
+	variablesFactory, buildTokenIssuer, err := cmd.variablesFactory(logger)
 	if err != nil {
 		return nil, err
 	}
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, defaultLimits)
+
+	taskCacheStore, err := cmd.TaskCacheSnapshots.NewStore(logger.Session("task-cache-snapshots"))
+	if err != nil {
+		return nil, err
+	}
+
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, buildTokenIssuer, defaultLimits, taskCacheStore, buildEventStreamer)
 
 	dbResourceConfigCheckSessionFactory := db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
@@ -679,6 +937,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
 		engine,
+		dbWorkerFactory,
+		checkRateLimiter,
 	)
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
@@ -723,7 +983,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		{Name: "collector", Runner: lockrunner.NewRunner(
 			logger.Session("collector"),
 			gc.NewCollector(
-				gc.NewBuildCollector(dbBuildFactory),
+				gc.NewBuildCollector(dbBuildFactory, cmd.containerRetentionPolicy()),
+				gc.NewPipelineCollector(dbPipelineFactory, cmd.PipelinePurgeGracePeriod),
 				gc.NewWorkerCollector(dbWorkerLifecycle),
 				gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
 				gc.NewResourceConfigCollector(dbResourceConfigFactory),
@@ -754,22 +1015,113 @@ func (cmd *RunCommand) constructBackendMembers(
 			clock.NewClock(),
 			cmd.GC.Interval,
 		)},
+		{Name: "input-upload-collector", Runner: lockrunner.NewRunner(
+			logger.Session("input-upload-collector"),
+			gc.NewInputUploadCollector(db.NewBuildInputUploadFactory(dbConn)),
+			"input-upload-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
 		// run separately so as to not preempt critical GC
 		{Name: "build-log-collector", Runner: lockrunner.NewRunner(
 			logger.Session("build-log-collector"),
 			gc.NewBuildLogCollector(
 				dbPipelineFactory,
 				500,
-				gc.NewBuildLogRetentionCalculator(
-					cmd.DefaultBuildLogsToRetain,
-					cmd.MaxBuildLogsToRetain,
-				),
+				cmd.buildLogRetentionCalculator(),
 			),
 			"build-reaper",
 			lockFactory,
 			clock.NewClock(),
 			30*time.Second,
 		)},
+		{Name: "container-reaper", Runner: lockrunner.NewRunner(
+			logger.Session("container-reaper"),
+			gc.NewContainerReaper(
+				dbContainerRepository,
+				gc.NewWorkerJobRunner(
+					logger.Session("container-reaper-worker-job-runner"),
+					workerClient,
+					time.Minute,
+					cmd.GC.WorkerConcurrency,
+					func(logger lager.Logger, workerName string) {
+						metric.GarbageCollectionContainerReaperJobDropped{
+							WorkerName: workerName,
+						}.Emit(logger)
+					},
+				),
+				cmd.GC.ContainerReaperBatchSize,
+				cmd.GC.ContainerReaperAttempts,
+			),
+			"container-reaper",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "resource-version-collector", Runner: lockrunner.NewRunner(
+			logger.Session("resource-version-collector"),
+			gc.NewResourceVersionCollector(
+				dbPipelineFactory,
+				cmd.ResourceVersionHistoryLimit,
+			),
+			"resource-version-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "webhook-deliverer", Runner: lockrunner.NewRunner(
+			logger.Session("webhook-deliverer"),
+			webhooks.NewDeliverer(
+				db.NewWebhookDeliveryQueue(dbConn),
+				cmd.webhookTargetPolicy().Client(cmd.Webhooks.Timeout),
+				clock.NewClock(),
+				cmd.Webhooks.MaxAttempts,
+			),
+			"webhook-deliverer",
+			lockFactory,
+			clock.NewClock(),
+			cmd.Webhooks.DeliveryInterval,
+		)},
+	}
+	if cmd.PausePipelinesIdleAfter != 0 {
+		members = append(members, grouper.Member{Name: "idle-pipeline-pauser", Runner: lockrunner.NewRunner(
+			logger.Session("idle-pipeline-pauser"),
+			gc.NewIdlePipelinePauser(
+				dbPipelineFactory,
+				cmd.PausePipelinesIdleAfter,
+			),
+			"idle-pipeline-pauser",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+	if cmd.GC.TaskCacheIdleTTL != 0 {
+		members = append(members, grouper.Member{Name: "task-cache-collector", Runner: lockrunner.NewRunner(
+			logger.Session("task-cache-collector"),
+			gc.NewTaskCacheCollector(
+				dbWorkerTaskCacheFactory,
+				cmd.GC.TaskCacheIdleTTL,
+			),
+			"task-cache-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+	if cmd.TeamUsage.RecordInterval != 0 {
+		members = append(members, grouper.Member{Name: "team-usage-recorder", Runner: lockrunner.NewRunner(
+			logger.Session("team-usage-recorder"),
+			gc.NewTeamUsageRecorder(
+				db.NewTeamUsageRecorder(dbConn),
+				cmd.TeamUsage.RecordInterval,
+			),
+			"team-usage-recorder",
+			lockFactory,
+			clock.NewClock(),
+			cmd.TeamUsage.RecordInterval,
+		)})
 	}
 	if cmd.Worker.GardenURL.URL != nil {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
@@ -790,8 +1142,9 @@ func workerVersion() (*version.Version, error) {
 	return workerVersion, nil
 }
 
-func (cmd *RunCommand) variablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+func (cmd *RunCommand) variablesFactory(logger lager.Logger) (creds.VariablesFactory, creds.BuildTokenIssuer, error) {
 	var variablesFactory creds.VariablesFactory = noop.NewNoopFactory()
+	var buildTokenIssuer creds.BuildTokenIssuer
 	for name, manager := range cmd.CredentialManagers {
 		if !manager.IsConfigured() {
 			continue
@@ -805,22 +1158,41 @@ func (cmd *RunCommand) variablesFactory(logger lager.Logger) (creds.VariablesFac
 
 		err := manager.Init(credsLogger)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		err = manager.Validate()
 		if err != nil {
-			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+			return nil, nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
 		}
 
 		variablesFactory, err = manager.NewVariablesFactory(credsLogger)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		if tokenManager, ok := manager.(creds.BuildTokenManager); ok {
+			issuer, enabled, err := tokenManager.NewBuildTokenIssuer(credsLogger)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if enabled {
+				credsLogger.Info("build-tokens-enabled")
+				buildTokenIssuer = issuer
+			}
+		}
+
+		if cmd.CredentialManagement.SecretCache.Enabled {
+			credsLogger.Info("secret-cache-enabled")
+
+			secretCache := creds.NewSecretCache(cmd.CredentialManagement.SecretCache, clock.NewClock())
+			variablesFactory = creds.NewCachedVariablesFactory(variablesFactory, secretCache)
 		}
 
 		break
 	}
-	return variablesFactory, nil
+	return variablesFactory, buildTokenIssuer, nil
 }
 
 func (cmd *RunCommand) lockFactory() (lock.LockFactory, error) {
@@ -858,7 +1230,7 @@ func webHandler(logger lager.Logger) (http.Handler, error) {
 
 func (cmd *RunCommand) httpClient() (*http.Client, error) {
 	httpClient := http.DefaultClient
-	if cmd.isTLSEnabled() {
+	if cmd.isTLSEnabled() && !cmd.LetsEncrypt.Enable {
 		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
 		if err != nil {
 			return nil, err
@@ -885,17 +1257,54 @@ func (cmd *RunCommand) httpClient() (*http.Client, error) {
 	return httpClient, nil
 }
 
-func (cmd *RunCommand) tlsConfig() (*tls.Config, error) {
+func (cmd *RunCommand) httpServer(
+	logger lager.Logger,
+	addr string,
+	handler http.Handler,
+	tlsConfig *tls.Config,
+	drain func(),
+) httpServer {
+	return httpServer{
+		logger:    logger,
+		addr:      addr,
+		handler:   handler,
+		tlsConfig: tlsConfig,
+
+		readTimeout:     cmd.Server.ReadTimeout,
+		writeTimeout:    cmd.Server.WriteTimeout,
+		idleTimeout:     cmd.Server.IdleTimeout,
+		shutdownTimeout: cmd.Server.ShutdownTimeout,
+
+		drain: drain,
+	}
+}
+
+func (cmd *RunCommand) letsEncryptManager(dbConn db.Conn) *autocert.Manager {
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      db.NewCertCache(dbConn),
+		HostPolicy: autocert.HostWhitelist(cmd.ExternalURL.URL.Hostname()),
+		Client: &acme.Client{
+			DirectoryURL: cmd.LetsEncrypt.ACMEURL.String(),
+		},
+	}
+}
+
+// nonTLSHandler answers the ACME http-01 challenges when using Let's Encrypt,
+// serving everything else as usual.
+func (cmd *RunCommand) nonTLSHandler(httpHandler http.Handler, certManager *autocert.Manager) http.Handler {
+	if certManager == nil {
+		return httpHandler
+	}
+
+	return certManager.HTTPHandler(httpHandler)
+}
+
+func (cmd *RunCommand) tlsConfig(certManager *autocert.Manager) (*tls.Config, error) {
 	var tlsConfig *tls.Config
 
 	if cmd.isTLSEnabled() {
-		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
-		if err != nil {
-			return nil, err
-		}
-
 		tlsConfig = &tls.Config{
-			Certificates:     []tls.Certificate{cert},
 			MinVersion:       tls.VersionTLS12,
 			CurvePreferences: []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
 			CipherSuites: []uint16{
@@ -906,7 +1315,21 @@ func (cmd *RunCommand) tlsConfig() (*tls.Config, error) {
 				tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
 			},
 			PreferServerCipherSuites: true,
-			NextProtos:               []string{"h2"},
+			NextProtos:               []string{"h2", "http/1.1"},
+		}
+
+		if certManager != nil {
+			tlsConfig.GetCertificate = certManager.GetCertificate
+
+			// answer the ACME tls-alpn-01 challenges
+			tlsConfig.NextProtos = append(tlsConfig.NextProtos, acme.ALPNProto)
+		} else {
+			cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
+			if err != nil {
+				return nil, err
+			}
+
+			tlsConfig.Certificates = []tls.Certificate{cert}
 		}
 	}
 	return tlsConfig, nil
@@ -932,6 +1355,13 @@ func (cmd *RunCommand) defaultURL() flag.URL {
 	}
 }
 
+func closeOnExit(runner ifrit.Runner, closer io.Closer) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		defer closer.Close()
+		return runner.Run(signals, ready)
+	})
+}
+
 func onReady(runner ifrit.Runner, cb func()) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
 		process := ifrit.Background(runner)
@@ -968,7 +1398,21 @@ func (cmd *RunCommand) validate() error {
 		tlsFlagCount++
 	}
 
-	if tlsFlagCount == 3 {
+	if cmd.LetsEncrypt.Enable {
+		if cmd.TLSBindPort == 0 || cmd.TLSCert != "" || cmd.TLSKey != "" {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --tls-bind-port, but not --tls-cert or --tls-key, to use Let's Encrypt"),
+			)
+		}
+
+		if cmd.ExternalURL.URL.Scheme != "https" {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify HTTPS external-url to use Let's Encrypt"),
+			)
+		}
+	} else if tlsFlagCount == 3 {
 		if cmd.ExternalURL.URL.Scheme != "https" {
 			errs = multierror.Append(
 				errs,
@@ -982,9 +1426,57 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	for _, cidr := range cmd.Webhooks.AllowedNetworks {
+		_, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("invalid --webhook-allowed-network '%s': %s", cidr, err),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
+// webhookTargetPolicy returns the policy for which addresses webhooks may be
+// delivered to. The allowed networks have already been validated.
+func (cmd *RunCommand) webhookTargetPolicy() webhooks.TargetPolicy {
+	policy := webhooks.TargetPolicy{}
+	for _, cidr := range cmd.Webhooks.AllowedNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil {
+			policy.AllowedNetworks = append(policy.AllowedNetworks, network)
+		}
+	}
+
+	return policy
+}
+
+// containerRetentionPolicy returns the default container retention and the
+// maximum to which teams may override it.
+func (cmd *RunCommand) containerRetentionPolicy() db.ContainerRetentionPolicy {
+	return db.ContainerRetentionPolicy{
+		Default: db.ContainerRetention{
+			Success: cmd.ContainerRetention.SuccessDuration,
+			Failure: cmd.ContainerRetention.FailureDuration,
+		},
+		Max: db.ContainerRetention{
+			Success: cmd.ContainerRetention.MaxSuccessDuration,
+			Failure: cmd.ContainerRetention.MaxFailureDuration,
+		},
+	}
+}
+
+// buildLogRetentionCalculator decides how many of each job's build logs are
+// kept, both when reaping them and when reporting it through the API.
+func (cmd *RunCommand) buildLogRetentionCalculator() gc.BuildLogRetentionCalculator {
+	return gc.NewBuildLogRetentionCalculator(
+		cmd.DefaultBuildLogsToRetain,
+		cmd.MaxBuildLogsToRetain,
+	)
+}
+
 func (cmd *RunCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -1013,7 +1505,7 @@ func (cmd *RunCommand) constructDBConn(
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory, cmd.MigrationLockTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -1022,11 +1514,20 @@ func (cmd *RunCommand) constructDBConn(
 	dbConn = metric.CountQueries(dbConn)
 	metric.Databases = append(metric.Databases, dbConn)
 
+	// Instrument with Tracing
+	if cmd.Tracing.JaegerEndpoint != "" {
+		dbConn = tracing.TraceQueries(dbConn)
+	}
+
 	// Instrument with Logging
 	if cmd.LogDBQueries {
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	if cmd.Developer.FaultInjection.Enabled {
+		dbConn = db.DelayQueries(dbConn, cmd.Developer.FaultInjection.MaxQueryDelay)
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConn)
 
@@ -1055,14 +1556,23 @@ func (cmd *RunCommand) constructWorkerPool(
 	switch cmd.ContainerPlacementStrategy {
 	case "random":
 		strategy = worker.NewRandomPlacementStrategy()
+	case "least-loaded":
+		strategy = worker.NewLeastLoadedPlacementStrategy()
 	default:
 		strategy = worker.NewVolumeLocalityPlacementStrategy()
 	}
 
-	return worker.NewPool(
+	client := worker.NewPool(
 		workerProvider,
 		strategy,
 	)
+
+	if cmd.Developer.FaultInjection.Enabled {
+		logger.Info("injecting-container-failures")
+		client = worker.FailContainerCreations(client, cmd.Developer.FaultInjection.ContainerFailureRate)
+	}
+
+	return client
 }
 
 func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
@@ -1094,26 +1604,35 @@ func (cmd *RunCommand) constructEngine(
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	variablesFactory creds.VariablesFactory,
+	buildTokenIssuer creds.BuildTokenIssuer,
 	defaultLimits atc.ContainerLimits,
+	taskCacheStore taskcache.Store,
+	buildEventStreamer engine.BuildEventStreamer,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
-		variablesFactory,
 		defaultLimits,
+		taskCacheStore,
 	)
 
+	var logSanitizer *engine.LogSanitizer
+	if cmd.LogSanitization.Enabled {
+		logSanitizer = engine.NewLogSanitizer(cmd.LogSanitization.AllowedSequences)
+	}
+
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(variablesFactory, buildTokenIssuer, logSanitizer, cmd.containerRetentionPolicy()),
 		cmd.ExternalURL.String(),
+		cmd.HookTimeout,
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
 
-	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine}, cmd.PeerURLOrDefault().String())
+	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine}, cmd.PeerURLOrDefault().String(), buildEventStreamer)
 }
 
 func (cmd *RunCommand) constructHTTPHandler(
@@ -1121,20 +1640,34 @@ func (cmd *RunCommand) constructHTTPHandler(
 	webHandler http.Handler,
 	apiHandler http.Handler,
 	authHandler http.Handler,
+	readinessChecks *health.Checks,
 ) http.Handler {
+	if len(cmd.Server.AllowCORSOrigins) > 0 {
+		apiHandler = wrappa.CORSHandler{
+			AllowedOrigins: cmd.Server.AllowCORSOrigins,
+			Handler:        apiHandler,
+		}
+	}
+
 	webMux := http.NewServeMux()
 	webMux.Handle("/api/v1/", apiHandler)
 	webMux.Handle("/sky/", authHandler)
 	webMux.Handle("/auth/", authHandler)
 	webMux.Handle("/login", authHandler)
 	webMux.Handle("/logout", authHandler)
+	webMux.Handle("/healthz", health.NewLivenessHandler())
+	webMux.Handle("/ready", health.NewReadinessHandler(logger.Session("readiness"), readinessChecks))
 	webMux.Handle("/", webHandler)
 
 	httpHandler := wrappa.LoggerHandler{
 		Logger: logger,
 
 		Handler: wrappa.SecurityHandler{
-			XFrameOptions: cmd.Server.XFrameOptions,
+			XFrameOptions:           cmd.Server.XFrameOptions,
+			StrictTransportSecurity: cmd.Server.StrictTransportSecurity,
+			ContentSecurityPolicy:   cmd.Server.ContentSecurityPolicy,
+			ExemptPaths:             cmd.Server.SecurityExemptPaths,
+			HTTPS:                   cmd.ExternalURL.URL.Scheme == "https",
 
 			// proxy Authorization header to/from auth cookie,
 			// to support auth from JS (EventSource) and custom JWT auth
@@ -1147,6 +1680,91 @@ func (cmd *RunCommand) constructHTTPHandler(
 	return httpHandler
 }
 
+// constructReadinessChecks registers the checks which must pass before the
+// ATC is ready to be put behind a load balancer.
+func (cmd *RunCommand) constructReadinessChecks(dbConn db.Conn, signingKey *rsa.PublicKey) *health.Checks {
+	checks := health.NewChecks(5 * time.Second)
+
+	checks.Register("database", dbConn.Ping)
+
+	// neither the schema nor the signing key change while running, so they
+	// are only checked once rather than on every request
+	migrationsErr := cmd.checkMigrations()
+	checks.Register("migrations", func() error {
+		return migrationsErr
+	})
+
+	signingKeyErr := cmd.checkSigningKey(signingKey)
+	checks.Register("signing-key", func() error {
+		return signingKeyErr
+	})
+
+	return checks
+}
+
+func (cmd *RunCommand) checkMigrations() error {
+	helper := migration.NewOpenHelper(
+		defaultDriverName,
+		cmd.Postgres.ConnectionString(),
+		nil,
+		encryption.NewNoEncryption(),
+	)
+
+	current, err := helper.CurrentVersion()
+	if err != nil {
+		return err
+	}
+
+	supported, err := helper.SupportedVersion()
+	if err != nil {
+		return err
+	}
+
+	if current != supported {
+		return fmt.Errorf("database is at version %d, expected %d", current, supported)
+	}
+
+	return nil
+}
+
+// checkSigningKey makes sure that session tokens can be verified, and that
+// tokens signed with the configured key verify against the key the API
+// checks them with.
+func (cmd *RunCommand) checkSigningKey(signingKey *rsa.PublicKey) error {
+	if signingKey == nil {
+		return errors.New("session signing key not loaded")
+	}
+
+	if signingKey.N == nil || signingKey.N.Sign() <= 0 || signingKey.E < 3 {
+		return errors.New("session signing key is not a valid RSA key")
+	}
+
+	if cmd.Auth.AuthFlags.SigningKey == nil {
+		return nil
+	}
+
+	privateKey := cmd.Auth.AuthFlags.SigningKey.PrivateKey
+
+	err := privateKey.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid session signing key: %s", err)
+	}
+
+	digest := sha256.Sum256([]byte("readiness"))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return fmt.Errorf("failed to sign with session signing key: %s", err)
+	}
+
+	err = rsa.VerifyPKCS1v15(signingKey, crypto.SHA256, digest[:], signature)
+	if err != nil {
+		return errors.New("session signing key does not match the key tokens are verified with")
+	}
+
+	return nil
+}
+
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
@@ -1159,6 +1777,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbContainerRepository db.ContainerRepository,
 	gcContainerDestroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
+	dbBuildInputUploadFactory db.BuildInputUploadFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
 	workerProvider worker.WorkerProvider,
@@ -1167,6 +1786,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	radarScannerFactory radar.ScannerFactory,
 	variablesFactory creds.VariablesFactory,
 	credsManagers creds.Managers,
+	signingKeys []*rsa.PublicKey,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1199,10 +1819,12 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbContainerRepository,
 		gcContainerDestroyer,
 		dbBuildFactory,
+		dbBuildInputUploadFactory,
 
 		cmd.PeerURLOrDefault().String(),
 		buildserver.NewEventHandler,
 		drain,
+		cmd.MaxInputUploadSize,
 
 		engine,
 		workerClient,
@@ -1220,9 +1842,28 @@ func (cmd *RunCommand) constructAPIHandler(
 		variablesFactory,
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		cmd.containerRetentionPolicy().Max,
+		cmd.buildLogRetentionCalculator(),
+		atc.TeamUsageQuota{
+			BuildMinutes:   cmd.TeamUsage.DailyBuildMinutes,
+			ContainerHours: cmd.TeamUsage.DailyContainerHours,
+		},
+		signingKeys,
+		cmd.webhookTargetPolicy(),
 	)
 }
 
+// signingKeys returns the current session signing key along with the old
+// one, if a key rotation is in progress.
+func (cmd *RunCommand) signingKeys(currentKey *rsa.PublicKey) []*rsa.PublicKey {
+	keys := []*rsa.PublicKey{currentKey}
+	if cmd.Auth.OldSigningKey != nil {
+		keys = append(keys, &cmd.Auth.OldSigningKey.PublicKey)
+	}
+
+	return keys
+}
+
 type tlsRedirectHandler struct {
 	externalHost string
 	baseHandler  http.Handler
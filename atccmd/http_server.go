@@ -0,0 +1,83 @@
+package atccmd
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// httpServer serves HTTP (or HTTP/2, when given a TLS config) until it is
+// signalled, at which point it drains any long-lived event streams and waits
+// for in-flight requests to finish before exiting.
+type httpServer struct {
+	logger    lager.Logger
+	addr      string
+	handler   http.Handler
+	tlsConfig *tls.Config
+
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	idleTimeout     time.Duration
+	shutdownTimeout time.Duration
+
+	// drain is called upon shutdown to end any streams which would otherwise
+	// keep their requests in flight indefinitely
+	drain func()
+}
+
+func (s httpServer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	server := &http.Server{
+		Handler:      s.handler,
+		TLSConfig:    s.tlsConfig,
+		ReadTimeout:  s.readTimeout,
+		WriteTimeout: s.writeTimeout,
+		IdleTimeout:  s.idleTimeout,
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if s.tlsConfig != nil {
+			// configures HTTP/2 as part of serving
+			serveErr <- server.ServeTLS(listener, "", "")
+		} else {
+			serveErr <- server.Serve(listener)
+		}
+	}()
+
+	close(ready)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-signals:
+	}
+
+	if s.drain != nil {
+		s.drain()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	s.logger.Info("shutting-down")
+
+	err = server.Shutdown(ctx)
+	if err != nil {
+		s.logger.Error("failed-to-shut-down-gracefully", err)
+		return server.Close()
+	}
+
+	s.logger.Info("shut-down")
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package atccmd
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("httpServer", func() {
+	var (
+		logger *lagertest.TestLogger
+		addr   string
+
+		requestStarted chan struct{}
+		finishRequest  chan struct{}
+		drained        chan struct{}
+
+		server  httpServer
+		process ifrit.Process
+
+		responses chan *http.Response
+		errs      chan error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr = listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		requestStarted = make(chan struct{})
+		finishRequest = make(chan struct{})
+		drained = make(chan struct{})
+
+		server = httpServer{
+			logger: logger,
+			addr:   addr,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(requestStarted)
+				<-finishRequest
+				w.Write([]byte("finished"))
+			}),
+
+			shutdownTimeout: time.Minute,
+
+			drain: func() { close(drained) },
+		}
+
+		responses = make(chan *http.Response, 1)
+		errs = make(chan error, 1)
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(server)
+
+		go func() {
+			response, err := http.Get("http://" + addr)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			responses <- response
+		}()
+
+		Eventually(requestStarted).Should(BeClosed())
+
+		process.Signal(os.Interrupt)
+	})
+
+	AfterEach(func() {
+		select {
+		case <-finishRequest:
+		default:
+			close(finishRequest)
+		}
+
+		process.Signal(os.Kill)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("drains the event streams", func() {
+		Eventually(drained).Should(BeClosed())
+	})
+
+	It("stops accepting new requests", func() {
+		Eventually(func() error {
+			_, err := net.Dial("tcp", addr)
+			return err
+		}).Should(HaveOccurred())
+	})
+
+	It("waits for in-flight requests to finish before exiting", func() {
+		Consistently(process.Wait()).ShouldNot(Receive())
+
+		close(finishRequest)
+
+		var response *http.Response
+		Eventually(responses).Should(Receive(&response))
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal("finished"))
+
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	Context("when in-flight requests outlast the shutdown timeout", func() {
+		BeforeEach(func() {
+			server.shutdownTimeout = 100 * time.Millisecond
+		})
+
+		It("closes their connections and exits", func() {
+			Eventually(process.Wait()).Should(Receive())
+			Eventually(errs).Should(Receive())
+
+			Expect(logger.LogMessages()).To(ContainElement("test.failed-to-shut-down-gracefully"))
+		})
+	})
+})
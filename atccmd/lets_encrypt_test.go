@@ -0,0 +1,139 @@
+package atccmd
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/flag"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Let's Encrypt", func() {
+	var cmd *RunCommand
+
+	BeforeEach(func() {
+		externalURL, err := url.Parse("https://ci.example.com")
+		Expect(err).NotTo(HaveOccurred())
+
+		acmeURL, err := url.Parse("https://acme.example.com/directory")
+		Expect(err).NotTo(HaveOccurred())
+
+		cmd = &RunCommand{
+			TLSBindPort: 443,
+			ExternalURL: flag.URL{URL: externalURL},
+		}
+		cmd.LetsEncrypt.Enable = true
+		cmd.LetsEncrypt.ACMEURL = flag.URL{URL: acmeURL}
+	})
+
+	It("defaults to the ACME v2 directory", func() {
+		field, found := reflect.TypeOf(cmd.LetsEncrypt).FieldByName("ACMEURL")
+		Expect(found).To(BeTrue())
+		Expect(field.Tag.Get("default")).To(Equal("https://acme-v02.api.letsencrypt.org/directory"))
+	})
+
+	Context("when Let's Encrypt is disabled", func() {
+		It("serves the ACME challenge path with the ATC's handler", func() {
+			handler := cmd.nonTLSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}), nil)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest("GET", "http://ci.example.com/.well-known/acme-challenge/some-token", nil))
+			Expect(recorder.Code).To(Equal(http.StatusTeapot))
+		})
+	})
+
+	Describe("the certificate manager", func() {
+		var certManager *autocert.Manager
+
+		BeforeEach(func() {
+			certManager = cmd.letsEncryptManager(new(dbfakes.FakeConn))
+		})
+
+		It("caches certificates in the database", func() {
+			Expect(certManager.Cache).NotTo(BeNil())
+		})
+
+		It("uses the configured directory", func() {
+			Expect(certManager.Client.DirectoryURL).To(Equal("https://acme.example.com/directory"))
+		})
+
+		It("only obtains certificates for the external URL's host", func() {
+			Expect(certManager.HostPolicy(context.Background(), "ci.example.com")).To(Succeed())
+			Expect(certManager.HostPolicy(context.Background(), "evil.example.com")).NotTo(Succeed())
+		})
+
+		Describe("the plain HTTP handler", func() {
+			var handler http.Handler
+
+			BeforeEach(func() {
+				// every lookup misses; the cache itself is covered by the db tests
+				certManager.Cache = nil
+
+				handler = cmd.nonTLSHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}), certManager)
+			})
+
+			serve := func(url string) *httptest.ResponseRecorder {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest("GET", url, nil))
+				return recorder
+			}
+
+			It("answers the http-01 challenges through the manager", func() {
+				recorder := serve("http://ci.example.com/.well-known/acme-challenge/some-token")
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+				Expect(recorder.Body.String()).To(ContainSubstring("acme/autocert"))
+			})
+
+			It("refuses challenges for other hosts", func() {
+				recorder := serve("http://evil.example.com/.well-known/acme-challenge/some-token")
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("serves everything else with the ATC's handler", func() {
+				recorder := serve("http://ci.example.com/api/v1/info")
+				Expect(recorder.Code).To(Equal(http.StatusTeapot))
+			})
+		})
+
+		Describe("the TLS config", func() {
+			var tlsConfig *tls.Config
+
+			BeforeEach(func() {
+				certManager.Cache = nil
+
+				var err error
+				tlsConfig, err = cmd.tlsConfig(certManager)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("gets certificates from the manager rather than from disk", func() {
+				Expect(tlsConfig.Certificates).To(BeEmpty())
+
+				_, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{ServerName: "evil.example.com"})
+				Expect(err).To(MatchError(ContainSubstring("acme/autocert")))
+			})
+
+			It("only speaks TLS 1.2 and up", func() {
+				Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+			})
+
+			It("answers the tls-alpn-01 challenges", func() {
+				Expect(tlsConfig.NextProtos).To(ContainElement(acme.ALPNProto))
+				Expect(tlsConfig.NextProtos).To(ContainElement("h2"))
+			})
+		})
+	})
+})
@@ -0,0 +1,141 @@
+package atccmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	logFormatJSON   = "json"
+	logFormatPretty = "pretty"
+
+	timestampFormatUnixEpoch = "unix-epoch"
+	timestampFormatRFC3339   = "rfc3339"
+)
+
+type LoggerConfig struct {
+	LogLevel        string `long:"log-level"            default:"info"       choice:"debug" choice:"info" choice:"error" choice:"fatal" description:"Minimum level of logs to see."`
+	LogFormat       string `long:"log-format"           default:"json"       choice:"json" choice:"pretty" description:"Format of the logs written to stdout. 'pretty' is easier to read when running locally."`
+	TimestampFormat string `long:"log-timestamp-format" default:"unix-epoch" choice:"unix-epoch" choice:"rfc3339" description:"Format of the timestamp of each log line."`
+}
+
+func (config LoggerConfig) minLogLevel() lager.LogLevel {
+	switch config.LogLevel {
+	case "debug":
+		return lager.DEBUG
+	case "error":
+		return lager.ERROR
+	case "fatal":
+		return lager.FATAL
+	default:
+		return lager.INFO
+	}
+}
+
+// logSink writes every log line to the writer, leaving filtering by level to
+// the reconfigurable sink wrapping it so that it can be changed at runtime.
+type logSink struct {
+	writer          io.Writer
+	format          string
+	timestampFormat string
+
+	writeL sync.Mutex
+}
+
+func (sink *logSink) Log(log lager.LogFormat) {
+	log.Timestamp = sink.timestamp(log.Timestamp)
+
+	var line []byte
+	if sink.format == logFormatPretty {
+		line = prettyLine(log)
+	} else {
+		line = log.ToJSON()
+	}
+
+	sink.writeL.Lock()
+	sink.writer.Write(line)
+	sink.writer.Write([]byte("\n"))
+	sink.writeL.Unlock()
+}
+
+// lager formats timestamps as seconds since the epoch; they're only converted
+// when another format is configured.
+func (sink *logSink) timestamp(epoch string) string {
+	if sink.timestampFormat != timestampFormatRFC3339 {
+		return epoch
+	}
+
+	seconds, err := strconv.ParseFloat(epoch, 64)
+	if err != nil {
+		return epoch
+	}
+
+	whole, fraction := math.Modf(seconds)
+
+	return time.Unix(int64(whole), int64(fraction*1e9)).UTC().Format(time.RFC3339Nano)
+}
+
+// prettyLine renders the log on one line as the timestamp, level and message
+// followed by its data as key=value pairs, sorted by key.
+func prettyLine(log lager.LogFormat) []byte {
+	fields := []string{
+		log.Timestamp,
+		fmt.Sprintf("%-5s", levelName(log.LogLevel)),
+		log.Message,
+	}
+
+	keys := []string{}
+	for key := range log.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fields = append(fields, key+"="+prettyValue(log.Data[key]))
+	}
+
+	return []byte(strings.Join(fields, " "))
+}
+
+func prettyValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if strings.ContainsAny(v, " \t\n\"=") {
+			return strconv.Quote(v)
+		}
+
+		return v
+	default:
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+
+		return string(payload)
+	}
+}
+
+func levelName(level lager.LogLevel) string {
+	switch level {
+	case lager.DEBUG:
+		return "DEBUG"
+	case lager.INFO:
+		return "INFO"
+	case lager.ERROR:
+		return "ERROR"
+	case lager.FATAL:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,96 @@
+package atccmd
+
+import (
+	"bytes"
+
+	"code.cloudfoundry.org/lager"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("logSink", func() {
+	var (
+		buffer *bytes.Buffer
+		sink   *logSink
+		log    lager.LogFormat
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		sink = &logSink{
+			writer:          buffer,
+			format:          logFormatJSON,
+			timestampFormat: timestampFormatUnixEpoch,
+		}
+
+		log = lager.LogFormat{
+			Timestamp: "1538470223.500000000",
+			Source:    "atc",
+			Message:   "atc.some-session.some-message",
+			LogLevel:  lager.INFO,
+			Data: lager.Data{
+				"zone":    "b",
+				"alpha":   "a",
+				"quoted":  "some value",
+				"number":  42,
+				"nested":  map[string]string{"key": "value"},
+				"session": "1.2",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		sink.Log(log)
+	})
+
+	It("writes JSON lines with epoch timestamps", func() {
+		Expect(buffer.String()).To(HavePrefix("{"))
+		Expect(buffer.String()).To(ContainSubstring(`"timestamp":"1538470223.500000000"`))
+		Expect(buffer.String()).To(HaveSuffix("}\n"))
+	})
+
+	Context("with RFC3339 timestamps", func() {
+		BeforeEach(func() {
+			sink.timestampFormat = timestampFormatRFC3339
+		})
+
+		It("converts the timestamp to UTC", func() {
+			Expect(buffer.String()).To(ContainSubstring(`"timestamp":"2018-10-02T08:50:23.5Z"`))
+		})
+
+		Context("when the timestamp is not a number", func() {
+			BeforeEach(func() {
+				log.Timestamp = "bogus"
+			})
+
+			It("leaves it as it is", func() {
+				Expect(buffer.String()).To(ContainSubstring(`"timestamp":"bogus"`))
+			})
+		})
+	})
+
+	Context("in the pretty format", func() {
+		BeforeEach(func() {
+			sink.format = logFormatPretty
+		})
+
+		It("writes the level, message and data sorted by key on one line", func() {
+			Expect(buffer.String()).To(Equal(
+				`1538470223.500000000 INFO  atc.some-session.some-message alpha=a nested={"key":"value"} number=42 quoted="some value" session=1.2 zone=b` + "\n",
+			))
+		})
+
+		Context("with RFC3339 timestamps", func() {
+			BeforeEach(func() {
+				sink.timestampFormat = timestampFormatRFC3339
+				log.LogLevel = lager.ERROR
+				log.Data = nil
+			})
+
+			It("starts the line with the converted timestamp", func() {
+				Expect(buffer.String()).To(Equal("2018-10-02T08:50:23.5Z ERROR atc.some-session.some-message\n"))
+			})
+		})
+	})
+})
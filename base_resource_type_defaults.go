@@ -0,0 +1,65 @@
+package atc
+
+import (
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var baseResourceTypeDefaults = map[string]Source{}
+
+// LoadBaseResourceTypeDefaults configures the sources which operators have
+// chosen to default to for each base resource type, e.g. a registry mirror for
+// docker-image.
+func LoadBaseResourceTypeDefaults(defaults map[string]Source) {
+	if defaults == nil {
+		defaults = map[string]Source{}
+	}
+
+	baseResourceTypeDefaults = defaults
+}
+
+// ParseBaseResourceTypeDefaults parses a YAML document mapping base resource
+// type names to the source to default to.
+func ParseBaseResourceTypeDefaults(payload []byte) (map[string]Source, error) {
+	var raw map[string]map[interface{}]interface{}
+	err := yaml.Unmarshal(payload, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := map[string]Source{}
+	for name, source := range raw {
+		sanitized, err := sanitize(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid defaults for resource type '%s': %s", name, err)
+		}
+
+		defaults[name] = Source(sanitized.(map[string]interface{}))
+	}
+
+	return defaults, nil
+}
+
+// FindBaseResourceTypeDefaults returns the configured defaults for the base
+// resource type, if any.
+func FindBaseResourceTypeDefaults(name string) (Source, bool) {
+	defaults, found := baseResourceTypeDefaults[name]
+	return defaults, found
+}
+
+// WithDefaults returns a copy of the source with any keys it does not set
+// taken from the defaults.
+func (source Source) WithDefaults(defaults Source) Source {
+	merged := Source{}
+
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range source {
+		merged[k] = v
+	}
+
+	return merged
+}
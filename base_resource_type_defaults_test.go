@@ -0,0 +1,58 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Base resource type defaults", func() {
+	Describe("ParseBaseResourceTypeDefaults", func() {
+		It("parses the source for each resource type", func() {
+			defaults, err := atc.ParseBaseResourceTypeDefaults([]byte(`
+docker-image:
+  registry_mirror:
+    host: mirror.example.com
+git:
+  depth: 1
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(Equal(map[string]atc.Source{
+				"docker-image": {
+					"registry_mirror": map[string]interface{}{
+						"host": "mirror.example.com",
+					},
+				},
+				"git": {
+					"depth": 1,
+				},
+			}))
+		})
+
+		It("fails on invalid YAML", func() {
+			_, err := atc.ParseBaseResourceTypeDefaults([]byte(`docker-image: [`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Source.WithDefaults", func() {
+		It("fills in the keys the source does not set", func() {
+			source := atc.Source{"repository": "some-repo", "tag": "some-tag"}
+			defaults := atc.Source{"tag": "latest", "registry_mirror": "mirror.example.com"}
+
+			Expect(source.WithDefaults(defaults)).To(Equal(atc.Source{
+				"repository":      "some-repo",
+				"tag":             "some-tag",
+				"registry_mirror": "mirror.example.com",
+			}))
+		})
+
+		It("does not modify the source", func() {
+			source := atc.Source{"repository": "some-repo"}
+			source.WithDefaults(atc.Source{"tag": "latest"})
+
+			Expect(source).To(Equal(atc.Source{"repository": "some-repo"}))
+		})
+	})
+})
@@ -22,6 +22,15 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	// given when the build was triggered manually
+	TriggerReason string `json:"trigger_reason,omitempty"`
+
+	// given when the build was aborted through the API
+	AbortReason string `json:"abort_reason,omitempty"`
+	AbortedBy   string `json:"aborted_by,omitempty"`
+
+	Error *BuildError `json:"error,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -41,6 +50,53 @@ func (b Build) OneOff() bool {
 	return b.JobName == ""
 }
 
+// AbortBuildsRequest selects the running and pending builds to abort in bulk.
+// Empty fields do not filter anything.
+type AbortBuildsRequest struct {
+	PipelineName string   `json:"pipeline_name,omitempty"`
+	JobName      string   `json:"job_name,omitempty"`
+	Statuses     []string `json:"statuses,omitempty"`
+	Reason       string   `json:"reason,omitempty"`
+}
+
+// AbortedBuild is the outcome of aborting one of the builds selected by an
+// AbortBuildsRequest.
+type AbortedBuild struct {
+	Build Build  `json:"build"`
+	Error string `json:"error,omitempty"`
+}
+
+type BuildErrorCode string
+
+const (
+	BuildErrorCodeUnknown           BuildErrorCode = "unknown"
+	BuildErrorCodeAborted           BuildErrorCode = "aborted"
+	BuildErrorCodeTimeout           BuildErrorCode = "timeout"
+	BuildErrorCodeMissingInputs     BuildErrorCode = "missing_inputs"
+	BuildErrorCodeInvalidConfig     BuildErrorCode = "invalid_config"
+	BuildErrorCodeNoWorkers         BuildErrorCode = "no_workers"
+	BuildErrorCodeWorkerUnavailable BuildErrorCode = "worker_unavailable"
+	BuildErrorCodeInternal          BuildErrorCode = "internal"
+)
+
+// Infrastructure returns true if the error was caused by Concourse or its
+// workers, rather than by the user's pipeline or task configuration.
+func (code BuildErrorCode) Infrastructure() bool {
+	switch code {
+	case BuildErrorCodeNoWorkers, BuildErrorCodeWorkerUnavailable, BuildErrorCodeInternal:
+		return true
+	default:
+		return false
+	}
+}
+
+type BuildError struct {
+	Code    BuildErrorCode `json:"code"`
+	Message string         `json:"message"`
+	Step    string         `json:"step,omitempty"`
+	Worker  string         `json:"worker,omitempty"`
+}
+
 type BuildPreparationStatus string
 
 const (
@@ -51,6 +107,13 @@ const (
 
 type MissingInputReasons map[string]string
 
+// SkippedVersion is the newest version of an input which the build is not
+// using because it has not yet passed the jobs listed in NotPassed.
+type SkippedVersion struct {
+	Version   Version  `json:"version"`
+	NotPassed []string `json:"not_passed"`
+}
+
 type BuildPreparation struct {
 	BuildID             int                               `json:"build_id"`
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
@@ -59,4 +122,5 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	SkippedVersions     map[string]SkippedVersion         `json:"skipped_versions,omitempty"`
 }
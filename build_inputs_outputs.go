@@ -5,6 +5,14 @@ type BuildInputsOutputs struct {
 	Outputs []VersionedResource `json:"outputs"`
 }
 
+// JobOutputs are the versions a job's build used and produced, for finding
+// out what last got through the job.
+type JobOutputs struct {
+	Build   Build               `json:"build"`
+	Inputs  []PublicBuildInput  `json:"inputs"`
+	Outputs []VersionedResource `json:"outputs"`
+}
+
 type PublicBuildInput struct {
 	Name            string          `json:"name"`
 	Resource        string          `json:"resource"`
@@ -88,4 +88,19 @@ var _ = Describe("Build", func() {
 			}
 		})
 	})
+
+	Describe("BuildErrorCode", func() {
+		It("is infrastructure for worker and internal errors", func() {
+			Expect(atc.BuildErrorCodeNoWorkers.Infrastructure()).To(BeTrue())
+			Expect(atc.BuildErrorCodeWorkerUnavailable.Infrastructure()).To(BeTrue())
+			Expect(atc.BuildErrorCodeInternal.Infrastructure()).To(BeTrue())
+		})
+
+		It("is not infrastructure for user errors", func() {
+			Expect(atc.BuildErrorCodeMissingInputs.Infrastructure()).To(BeFalse())
+			Expect(atc.BuildErrorCodeInvalidConfig.Infrastructure()).To(BeFalse())
+			Expect(atc.BuildErrorCodeTimeout.Infrastructure()).To(BeFalse())
+			Expect(atc.BuildErrorCodeUnknown.Infrastructure()).To(BeFalse())
+		})
+	})
 })
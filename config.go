@@ -8,6 +8,7 @@ import (
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
+const ConfigChecksumHeader = "X-Concourse-Config-Checksum"
 const DefaultPipelineName = "main"
 const DefaultTeamName = "main"
 
@@ -19,11 +20,26 @@ type ConfigResponse struct {
 	RawConfig RawConfig `json:"raw_config"`
 }
 
+// ConfigChecksumResponse identifies the stored config of a pipeline without
+// including it, so that it can be compared against a local copy cheaply.
+type ConfigChecksumResponse struct {
+	Checksum      string `json:"checksum"`
+	ConfigVersion int    `json:"config_version"`
+}
+
 type Config struct {
 	Groups        GroupConfigs    `yaml:"groups" json:"groups" mapstructure:"groups"`
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	Network *NetworkConfig `yaml:"network,omitempty" json:"network,omitempty" mapstructure:"network"`
+
+	// free-form labels which pipelines can be filtered by when listing them
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty" mapstructure:"labels"`
+
+	// limits the number of builds running across all of the pipeline's jobs
+	MaxInFlight int `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 }
 
 type RawConfig string
@@ -59,6 +75,27 @@ type ResourceConfig struct {
 	CheckTimeout string  `yaml:"check_timeout,omitempty" json:"check_timeout" mapstructure:"check_timeout"`
 	Tags         Tags    `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 	Version      Version `yaml:"version,omitempty" json:"version" mapstructure:"version"`
+
+	// the number of most recent versions to keep, overriding the default
+	// configured on the ATC; versions still in use by builds are always kept
+	VersionHistoryLimit int `yaml:"version_history_limit,omitempty" json:"version_history_limit,omitempty" mapstructure:"version_history_limit"`
+
+	// set to false for resources which are never checked, and whose versions
+	// are instead saved through the API as they are published
+	Check *bool `yaml:"check,omitempty" json:"check,omitempty" mapstructure:"check"`
+
+	// check on the resource's own interval even when the ATC's budget of
+	// checks per second has been spent
+	IgnoreCheckRateLimit bool `yaml:"ignore_check_rate_limit,omitempty" json:"ignore_check_rate_limit,omitempty" mapstructure:"ignore_check_rate_limit"`
+
+	// shown alongside the resource's name by the UI
+	Icon        string `yaml:"icon,omitempty" json:"icon,omitempty" mapstructure:"icon"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
+}
+
+// CheckDisabled returns whether the resource is configured with 'check: false'.
+func (config ResourceConfig) CheckDisabled() bool {
+	return config.Check != nil && !*config.Check
 }
 
 type ResourceType struct {
@@ -228,6 +265,8 @@ type PlanConfig struct {
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// whether to trigger when a passed job reruns with the same version
+	RerunOnUpstreamRerun bool `yaml:"rerun_on_upstream_rerun,omitempty" json:"rerun_on_upstream_rerun,omitempty" mapstructure:"rerun_on_upstream_rerun"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
@@ -235,6 +274,10 @@ type PlanConfig struct {
 	// corresponding resource config, e.g. aws-stemcell
 	Resource string `yaml:"resource,omitempty" json:"resource,omitempty" mapstructure:"resource"`
 
+	// used by Get and Put to mount the fetched artifact at a different path in
+	// subsequent tasks, e.g. src/github.com/concourse/atc
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+
 	// corresponds to a Task plan
 	// name of 'task', e.g. unit, go1.3, go1.4
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
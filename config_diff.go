@@ -0,0 +1,188 @@
+package atc
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// ConfigDiff is how a pipeline config differs from the one it would replace.
+type ConfigDiff struct {
+	Groups        ConfigDiffSection `json:"groups"`
+	Resources     ConfigDiffSection `json:"resources"`
+	ResourceTypes ConfigDiffSection `json:"resource_types"`
+	Jobs          ConfigDiffSection `json:"jobs"`
+
+	// the names of the pipeline-level settings which changed, e.g. 'labels'
+	Settings []string `json:"settings,omitempty"`
+}
+
+// ConfigDiffSection lists the names of the groups, resources, resource types
+// or jobs which were added or removed, in config order, along with those
+// which changed.
+type ConfigDiffSection struct {
+	Added   []string          `json:"added,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+	Changed []ConfigDiffEntry `json:"changed,omitempty"`
+}
+
+// ConfigDiffEntry is a group, resource, resource type or job which changed,
+// along with the names of its fields which changed, e.g. 'plan'.
+type ConfigDiffEntry struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+// DiffConfigs compares a config with the one it would replace. Fields are
+// compared as they would be stored, so reordering the keys of a step makes no
+// difference, but reordering steps does.
+func DiffConfigs(before Config, after Config) (ConfigDiff, error) {
+	var diff ConfigDiff
+	var err error
+
+	diff.Groups, err = diffSection(before.Groups, after.Groups)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	diff.Resources, err = diffSection(before.Resources, after.Resources)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	diff.ResourceTypes, err = diffSection(before.ResourceTypes, after.ResourceTypes)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	diff.Jobs, err = diffSection(before.Jobs, after.Jobs)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	before.Groups, before.Resources, before.ResourceTypes, before.Jobs = nil, nil, nil, nil
+	after.Groups, after.Resources, after.ResourceTypes, after.Jobs = nil, nil, nil, nil
+
+	diff.Settings, err = diffFields(before, after)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	return diff, nil
+}
+
+type namedFields struct {
+	name   string
+	fields map[string]interface{}
+}
+
+func diffSection(before interface{}, after interface{}) (ConfigDiffSection, error) {
+	beforeEntries, err := toNamedFields(before)
+	if err != nil {
+		return ConfigDiffSection{}, err
+	}
+
+	afterEntries, err := toNamedFields(after)
+	if err != nil {
+		return ConfigDiffSection{}, err
+	}
+
+	beforeByName := map[string]map[string]interface{}{}
+	for _, entry := range beforeEntries {
+		beforeByName[entry.name] = entry.fields
+	}
+
+	afterByName := map[string]bool{}
+
+	var section ConfigDiffSection
+	for _, entry := range afterEntries {
+		afterByName[entry.name] = true
+
+		beforeFields, found := beforeByName[entry.name]
+		if !found {
+			section.Added = append(section.Added, entry.name)
+			continue
+		}
+
+		changed := changedFields(beforeFields, entry.fields)
+		if len(changed) > 0 {
+			section.Changed = append(section.Changed, ConfigDiffEntry{
+				Name:   entry.name,
+				Fields: changed,
+			})
+		}
+	}
+
+	for _, entry := range beforeEntries {
+		if !afterByName[entry.name] {
+			section.Removed = append(section.Removed, entry.name)
+		}
+	}
+
+	return section, nil
+}
+
+func diffFields(before interface{}, after interface{}) ([]string, error) {
+	var beforeFields, afterFields map[string]interface{}
+
+	err := roundTrip(before, &beforeFields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = roundTrip(after, &afterFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return changedFields(beforeFields, afterFields), nil
+}
+
+func toNamedFields(entries interface{}) ([]namedFields, error) {
+	var fields []map[string]interface{}
+	err := roundTrip(entries, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	named := make([]namedFields, len(fields))
+	for i, f := range fields {
+		name, _ := f["name"].(string)
+		named[i] = namedFields{name: name, fields: f}
+	}
+
+	return named, nil
+}
+
+func changedFields(before map[string]interface{}, after map[string]interface{}) []string {
+	changed := []string{}
+
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			changed = append(changed, key)
+		}
+	}
+
+	for key := range before {
+		if _, found := after[key]; !found {
+			changed = append(changed, key)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+func roundTrip(from interface{}, to interface{}) error {
+	payload, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, to)
+}
@@ -0,0 +1,63 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var before Config
+
+	BeforeEach(func() {
+		before = Config{
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri", "branch": "master"}},
+				{Name: "some-other-resource", Type: "time"},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Plan: PlanSequence{{Get: "some-resource"}}},
+			},
+		}
+	})
+
+	It("finds nothing when the configs are the same", func() {
+		after := before
+		after.Resources = ResourceConfigs{
+			{Name: "some-resource", Type: "git", Source: Source{"branch": "master", "uri": "some-uri"}},
+			{Name: "some-other-resource", Type: "time"},
+		}
+
+		diff, err := DiffConfigs(before, after)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(Equal(ConfigDiff{}))
+	})
+
+	It("finds what was added, removed and changed", func() {
+		after := before
+		after.Resources = ResourceConfigs{
+			{Name: "some-resource", Type: "git", Source: Source{"uri": "some-other-uri", "branch": "master"}, CheckEvery: "1m"},
+			{Name: "some-new-resource", Type: "time"},
+		}
+
+		diff, err := DiffConfigs(before, after)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Resources).To(Equal(ConfigDiffSection{
+			Added:   []string{"some-new-resource"},
+			Removed: []string{"some-other-resource"},
+			Changed: []ConfigDiffEntry{{Name: "some-resource", Fields: []string{"check_every", "source"}}},
+		}))
+		Expect(diff.Jobs).To(Equal(ConfigDiffSection{}))
+	})
+
+	It("finds changes to the pipeline's settings", func() {
+		after := before
+		after.MaxInFlight = 2
+		after.Labels = map[string]string{"team": "some-team"}
+
+		diff, err := DiffConfigs(before, after)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Settings).To(Equal([]string{"labels", "max_in_flight"}))
+	})
+})
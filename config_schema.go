@@ -0,0 +1,178 @@
+package atc
+
+import (
+	"reflect"
+	"strings"
+)
+
+const configSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// ConfigSchema returns a JSON Schema describing pipeline configs, for editors
+// and linters to validate them before they are set.
+//
+// The schema is generated from Config so that it stays in sync as fields are
+// added. Each struct becomes a definition whose properties are named by their
+// YAML tags. String fields which are not omitempty are required, as these are
+// the names, types and paths without which the config makes no sense.
+func ConfigSchema() map[string]interface{} {
+	generator := &schemaGenerator{
+		definitions: map[string]map[string]interface{}{},
+	}
+
+	generator.schemaFor(reflect.TypeOf(Config{}))
+
+	// keys which are not part of the config are allowed at the top level, as
+	// they are commonly used to hold YAML anchors
+	generator.definitions["Config"]["additionalProperties"] = true
+
+	definitions := map[string]interface{}{}
+	for name, definition := range generator.definitions {
+		definitions[name] = definition
+	}
+
+	return map[string]interface{}{
+		"$schema":     configSchemaDraft,
+		"$ref":        "#/definitions/Config",
+		"definitions": definitions,
+	}
+}
+
+type schemaGenerator struct {
+	definitions map[string]map[string]interface{}
+}
+
+// schemaOverrides describe types which are decoded from more than one form,
+// e.g. a version which may be 'every', 'latest', or a version to pin to.
+var schemaOverrides = map[reflect.Type]map[string]interface{}{
+	reflect.TypeOf(VersionConfig{}): {
+		"oneOf": []interface{}{
+			map[string]interface{}{"enum": []string{VersionEvery, VersionLatest}},
+			map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+		},
+	},
+	reflect.TypeOf(ContainerLimits{}): {
+		"type": "object",
+		"properties": map[string]interface{}{
+			"cpu": map[string]interface{}{"type": "integer", "minimum": 0},
+			"memory": map[string]interface{}{
+				"oneOf": []interface{}{
+					map[string]interface{}{"type": "integer", "minimum": 0},
+					map[string]interface{}{"type": "string", "pattern": MemoryRegex},
+				},
+			},
+		},
+		"additionalProperties": false,
+	},
+}
+
+func (generator *schemaGenerator) schemaFor(t reflect.Type) map[string]interface{} {
+	if override, found := schemaOverrides[t]; found {
+		return override
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return generator.schemaFor(t.Elem())
+	case reflect.Struct:
+		return generator.definitionFor(t)
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": generator.schemaFor(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": generator.schemaFor(t.Elem()),
+		}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		// interface{} values, e.g. in a resource's source, may be anything
+		return map[string]interface{}{}
+	}
+}
+
+// definitionFor adds a definition for the struct and returns a reference to
+// it. The definition is reserved before its fields are visited so that
+// recursive types, e.g. steps nested in a 'do', refer back to it.
+func (generator *schemaGenerator) definitionFor(t reflect.Type) map[string]interface{} {
+	ref := map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+
+	if _, found := generator.definitions[t.Name()]; found {
+		return ref
+	}
+
+	definition := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+	}
+
+	generator.definitions[t.Name()] = definition
+
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, omitempty := schemaFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		properties[name] = generator.schemaFor(field.Type)
+
+		if !omitempty && field.Type.Kind() == reflect.String {
+			required = append(required, name)
+		}
+	}
+
+	definition["properties"] = properties
+
+	if len(required) > 0 {
+		definition["required"] = required
+	}
+
+	return ref
+}
+
+func schemaFieldName(field reflect.StructField) (string, bool) {
+	tag, found := field.Tag.Lookup("yaml")
+	if !found {
+		tag, found = field.Tag.Lookup("json")
+	}
+
+	if !found {
+		return field.Name, false
+	}
+
+	segments := strings.Split(tag, ",")
+	if segments[0] == "-" {
+		return "", false
+	}
+
+	name := segments[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	omitempty := false
+	for _, option := range segments[1:] {
+		if option == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty
+}
@@ -0,0 +1,89 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigSchema", func() {
+	var (
+		schema      map[string]interface{}
+		definitions map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		// round-trip through JSON to look at the schema as clients will
+		payload, err := json.Marshal(ConfigSchema())
+		Expect(err).NotTo(HaveOccurred())
+
+		err = json.Unmarshal(payload, &schema)
+		Expect(err).NotTo(HaveOccurred())
+
+		definitions = schema["definitions"].(map[string]interface{})
+	})
+
+	definition := func(name string) map[string]interface{} {
+		Expect(definitions).To(HaveKey(name))
+		return definitions[name].(map[string]interface{})
+	}
+
+	properties := func(name string) map[string]interface{} {
+		return definition(name)["properties"].(map[string]interface{})
+	}
+
+	It("refers to the config definition", func() {
+		Expect(schema["$schema"]).To(Equal("http://json-schema.org/draft-07/schema#"))
+		Expect(schema["$ref"]).To(Equal("#/definitions/Config"))
+	})
+
+	It("allows extra keys only at the top level", func() {
+		Expect(definition("Config")["additionalProperties"]).To(BeTrue())
+		Expect(definition("JobConfig")["additionalProperties"]).To(BeFalse())
+		Expect(definition("PlanConfig")["additionalProperties"]).To(BeFalse())
+	})
+
+	It("names properties by their YAML keys", func() {
+		Expect(properties("Config")).To(HaveKey("resource_types"))
+		Expect(properties("PlanConfig")).To(HaveKey("file"))
+		Expect(properties("PlanConfig")).To(HaveKey("on_failure"))
+		Expect(properties("PlanConfig")).NotTo(HaveKey("DependentGet"))
+		Expect(properties("TaskConfig")).To(HaveKey("container_limits"))
+	})
+
+	It("refers back to definitions for nested steps and hooks", func() {
+		Expect(properties("PlanConfig")["do"]).To(Equal(map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"$ref": "#/definitions/PlanConfig"},
+		}))
+
+		Expect(properties("PlanConfig")["ensure"]).To(Equal(map[string]interface{}{
+			"$ref": "#/definitions/PlanConfig",
+		}))
+
+		Expect(properties("JobConfig")["on_success"]).To(Equal(map[string]interface{}{
+			"$ref": "#/definitions/PlanConfig",
+		}))
+	})
+
+	It("requires the names and types of things", func() {
+		Expect(definition("JobConfig")["required"]).To(ConsistOf("name"))
+		Expect(definition("ResourceConfig")["required"]).To(ConsistOf("name", "type"))
+		Expect(definition("ResourceType")["required"]).To(ConsistOf("name", "type"))
+		Expect(definition("PlanConfig")).NotTo(HaveKey("required"))
+	})
+
+	It("describes the values which are decoded from more than one form", func() {
+		Expect(properties("PlanConfig")["version"]).To(HaveKey("oneOf"))
+	})
+
+	It("only allows tags to be given as a list", func() {
+		Expect(properties("PlanConfig")["tags"]).To(Equal(map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		}))
+	})
+})
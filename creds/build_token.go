@@ -0,0 +1,33 @@
+package creds
+
+import "code.cloudfoundry.org/lager"
+
+//go:generate counterfeiter . BuildTokenIssuer
+
+// A BuildTokenIssuer issues short-lived credentials scoped to a single build,
+// so that tasks can access the credential manager directly. Tokens should be
+// revoked once the build completes.
+type BuildTokenIssuer interface {
+	IssueBuildToken(logger lager.Logger, teamName string, pipelineName string, buildID int) (BuildToken, error)
+	RevokeBuildToken(logger lager.Logger, token BuildToken) error
+}
+
+// A BuildToken is exposed to task containers through its environment
+// variables.
+type BuildToken struct {
+	// Accessor identifies the token for revocation without revealing it.
+	Accessor string
+
+	// Secret is the token itself, which is redacted from build output.
+	Secret string
+
+	Env map[string]string
+}
+
+// A BuildTokenManager is a Manager which can optionally issue build tokens.
+type BuildTokenManager interface {
+	Manager
+
+	// NewBuildTokenIssuer returns false if build tokens are not configured.
+	NewBuildTokenIssuer(lager.Logger) (BuildTokenIssuer, bool, error)
+}
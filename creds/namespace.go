@@ -0,0 +1,61 @@
+package creds
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SecretNamespaceError is returned when a credential lookup would resolve to
+// a path outside of the namespace of the team that owns the pipeline.
+type SecretNamespaceError struct {
+	TeamName string
+	Path     string
+}
+
+func (err SecretNamespaceError) Error() string {
+	return fmt.Sprintf("secret path '%s' is outside of the namespace for team '%s'", err.Path, err.TeamName)
+}
+
+// VerifySecretPath returns a SecretNamespaceError if secretPath, once
+// normalized, is not nested under the given team namespace.
+func VerifySecretPath(teamName string, namespace string, secretPath string) error {
+	root := strings.TrimSuffix(path.Clean(namespace), "/") + "/"
+
+	if !strings.HasPrefix(path.Clean(secretPath), root) {
+		return SecretNamespaceError{
+			TeamName: teamName,
+			Path:     secretPath,
+		}
+	}
+
+	return nil
+}
+
+// ValidateTeamNamespace makes sure that a credential manager's secret
+// templates are namespaced by the team, so that pipelines can not read
+// secrets belonging to other teams. The templates are probed for a couple of
+// teams; resolve returns the team's namespace and the paths that would be
+// looked up for one of its pipeline's secrets.
+func ValidateTeamNamespace(resolve func(teamName string) (string, []string, error)) error {
+	for _, teamName := range []string{"team-a", "team-b"} {
+		namespace, secretPaths, err := resolve(teamName)
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(namespace, teamName) {
+			return errors.New("team secret template must be namespaced by team")
+		}
+
+		for _, secretPath := range secretPaths {
+			err := VerifySecretPath(teamName, namespace, secretPath)
+			if err != nil {
+				return fmt.Errorf("secret templates must be nested under the team namespace: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
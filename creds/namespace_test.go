@@ -0,0 +1,61 @@
+package creds_test
+
+import (
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VerifySecretPath", func() {
+	It("allows paths nested under the team namespace", func() {
+		Expect(creds.VerifySecretPath("alpha", "/concourse/alpha/", "/concourse/alpha/some-pipeline/foo")).To(Succeed())
+		Expect(creds.VerifySecretPath("alpha", "/concourse/alpha", "/concourse/alpha/foo")).To(Succeed())
+	})
+
+	It("allows paths that stay within the team namespace after normalizing", func() {
+		Expect(creds.VerifySecretPath("alpha", "/concourse/alpha/", "/concourse/alpha/some-pipeline/../foo")).To(Succeed())
+	})
+
+	It("denies paths that escape the team namespace", func() {
+		err := creds.VerifySecretPath("alpha", "/concourse/alpha/", "/concourse/alpha/some-pipeline/../../beta/foo")
+		Expect(err).To(Equal(creds.SecretNamespaceError{
+			TeamName: "alpha",
+			Path:     "/concourse/alpha/some-pipeline/../../beta/foo",
+		}))
+	})
+
+	It("denies paths belonging to a team with a common prefix", func() {
+		Expect(creds.VerifySecretPath("alpha", "/concourse/alpha/", "/concourse/alphabet/foo")).To(HaveOccurred())
+	})
+
+	It("denies the namespace itself", func() {
+		Expect(creds.VerifySecretPath("alpha", "/concourse/alpha/", "/concourse/alpha")).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ValidateTeamNamespace", func() {
+	It("allows templates nested under the team namespace", func() {
+		err := creds.ValidateTeamNamespace(func(teamName string) (string, []string, error) {
+			return "/concourse/" + teamName, []string{
+				"/concourse/" + teamName + "/pipeline/secret",
+				"/concourse/" + teamName + "/secret",
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("denies a namespace which isn't specific to the team", func() {
+		err := creds.ValidateTeamNamespace(func(teamName string) (string, []string, error) {
+			return "/concourse", []string{"/concourse/secret"}, nil
+		})
+		Expect(err).To(MatchError("team secret template must be namespaced by team"))
+	})
+
+	It("denies paths outside of the team namespace", func() {
+		err := creds.ValidateTeamNamespace(func(teamName string) (string, []string, error) {
+			return "/concourse/" + teamName, []string{"/pipeline/secret"}, nil
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
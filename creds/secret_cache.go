@@ -0,0 +1,189 @@
+package creds
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type SecretCacheConfig struct {
+	Enabled          bool          `long:"secret-cache-enabled"           description:"Enable in-memory cache for secrets."`
+	Duration         time.Duration `long:"secret-cache-duration"          default:"1m"   description:"If the cache is enabled, secret values will be cached for not longer than this duration."`
+	DurationNotFound time.Duration `long:"secret-cache-duration-notfound" default:"10s"  description:"If the cache is enabled, secrets that were not found will be cached for not longer than this duration."`
+	MaxSize          int           `long:"secret-cache-max-size"          default:"1000" description:"If the cache is enabled, the maximum number of secrets to keep in memory. 0 means unlimited."`
+}
+
+type CredentialManagementConfig struct {
+	SecretCache SecretCacheConfig
+}
+
+// An InvalidatingVariablesFactory is a VariablesFactory whose secrets should
+// be considered stale whenever the channel returned by Invalidated receives,
+// e.g. after re-authenticating with the credential manager.
+type InvalidatingVariablesFactory interface {
+	VariablesFactory
+
+	Invalidated() <-chan struct{}
+}
+
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	found     bool
+	expiresAt time.Time
+}
+
+// A SecretCache caches the result of looking up secrets, including secrets
+// which were not found. Once the cache reaches its max size the least
+// recently used entries are evicted.
+type SecretCache struct {
+	config SecretCacheConfig
+	clock  clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+func NewSecretCache(config SecretCacheConfig, clock clock.Clock) *SecretCache {
+	return &SecretCache{
+		config: config,
+		clock:  clock,
+
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+func (cache *SecretCache) Get(key string) (interface{}, bool, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	elem, ok := cache.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !cache.clock.Now().Before(entry.expiresAt) {
+		cache.remove(elem)
+		return nil, false, false
+	}
+
+	cache.lru.MoveToFront(elem)
+
+	return entry.value, entry.found, true
+}
+
+func (cache *SecretCache) Set(key string, value interface{}, found bool) {
+	duration := cache.config.Duration
+	if !found {
+		duration = cache.config.DurationNotFound
+	}
+
+	if duration <= 0 {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if elem, ok := cache.entries[key]; ok {
+		cache.remove(elem)
+	}
+
+	cache.entries[key] = cache.lru.PushFront(&cacheEntry{
+		key:       key,
+		value:     value,
+		found:     found,
+		expiresAt: cache.clock.Now().Add(duration),
+	})
+
+	for cache.config.MaxSize > 0 && cache.lru.Len() > cache.config.MaxSize {
+		cache.remove(cache.lru.Back())
+	}
+}
+
+// Purge removes all entries from the cache.
+func (cache *SecretCache) Purge() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.entries = map[string]*list.Element{}
+	cache.lru.Init()
+}
+
+func (cache *SecretCache) Len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return cache.lru.Len()
+}
+
+func (cache *SecretCache) remove(elem *list.Element) {
+	cache.lru.Remove(elem)
+	delete(cache.entries, elem.Value.(*cacheEntry).key)
+}
+
+type cachedVariablesFactory struct {
+	factory VariablesFactory
+	cache   *SecretCache
+}
+
+// NewCachedVariablesFactory wraps the given factory such that secrets are
+// looked up through the cache. If the factory is an
+// InvalidatingVariablesFactory the cache is purged whenever it is
+// invalidated.
+func NewCachedVariablesFactory(factory VariablesFactory, cache *SecretCache) VariablesFactory {
+	if invalidating, ok := factory.(InvalidatingVariablesFactory); ok {
+		go func() {
+			for range invalidating.Invalidated() {
+				cache.Purge()
+			}
+		}()
+	}
+
+	return &cachedVariablesFactory{
+		factory: factory,
+		cache:   cache,
+	}
+}
+
+func (factory *cachedVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	return &cachedVariables{
+		variables: factory.factory.NewVariables(teamName, pipelineName),
+		cache:     factory.cache,
+		keyPrefix: teamName + "/" + pipelineName + "/",
+	}
+}
+
+type cachedVariables struct {
+	variables Variables
+	cache     *SecretCache
+	keyPrefix string
+}
+
+func (variables *cachedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	key := variables.keyPrefix + varDef.Name
+
+	value, found, cached := variables.cache.Get(key)
+	if cached {
+		return value, found, nil
+	}
+
+	value, found, err := variables.variables.Get(varDef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	variables.cache.Set(key, value, found)
+
+	return value, found, nil
+}
+
+func (variables *cachedVariables) List() ([]template.VariableDefinition, error) {
+	return variables.variables.List()
+}
@@ -0,0 +1,169 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type invalidatingFactory struct {
+	*credsfakes.FakeVariablesFactory
+
+	invalidated chan struct{}
+}
+
+func (factory invalidatingFactory) Invalidated() <-chan struct{} {
+	return factory.invalidated
+}
+
+var _ = Describe("SecretCache", func() {
+	var (
+		fakeClock     *fakeclock.FakeClock
+		config        creds.SecretCacheConfig
+		cache         *creds.SecretCache
+		fakeFactory   *credsfakes.FakeVariablesFactory
+		fakeVariables *credsfakes.FakeVariables
+		variables     creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		config = creds.SecretCacheConfig{
+			Enabled:          true,
+			Duration:         time.Minute,
+			DurationNotFound: 10 * time.Second,
+			MaxSize:          2,
+		}
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeFactory.NewVariablesReturns(fakeVariables)
+	})
+
+	JustBeforeEach(func() {
+		cache = creds.NewSecretCache(config, fakeClock)
+		variables = creds.NewCachedVariablesFactory(fakeFactory, cache).NewVariables("some-team", "some-pipeline")
+	})
+
+	It("creates variables for the team and pipeline", func() {
+		Expect(fakeFactory.NewVariablesCallCount()).To(Equal(1))
+		teamName, pipelineName := fakeFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	Context("when the secret is found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		It("only looks it up once until it expires", func() {
+			for i := 0; i < 3; i++ {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-value"))
+			}
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Minute)
+
+			_, _, err := variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the secret is not found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, nil)
+		})
+
+		It("caches the negative result for the not found duration", func() {
+			_, found, err := variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeClock.Increment(10 * time.Second)
+
+			_, _, err = variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when looking up the secret fails", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, errors.New("nope"))
+		})
+
+		It("does not cache the error", func() {
+			_, _, err := variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).To(HaveOccurred())
+
+			_, _, err = variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the cache is full", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		It("evicts the least recently used secret", func() {
+			variables.Get(template.VariableDefinition{Name: "a"})
+			variables.Get(template.VariableDefinition{Name: "b"})
+			variables.Get(template.VariableDefinition{Name: "a"})
+			variables.Get(template.VariableDefinition{Name: "c"})
+			Expect(cache.Len()).To(Equal(2))
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(template.VariableDefinition{Name: "a"})
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(template.VariableDefinition{Name: "b"})
+			Expect(fakeVariables.GetCallCount()).To(Equal(4))
+		})
+	})
+
+	Context("when the factory invalidates its secrets", func() {
+		var invalidated chan struct{}
+
+		BeforeEach(func() {
+			invalidated = make(chan struct{})
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		JustBeforeEach(func() {
+			cache = creds.NewSecretCache(config, fakeClock)
+			variables = creds.NewCachedVariablesFactory(invalidatingFactory{
+				FakeVariablesFactory: fakeFactory,
+				invalidated:          invalidated,
+			}, cache).NewVariables("some-team", "some-pipeline")
+		})
+
+		It("purges the cache", func() {
+			variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(cache.Len()).To(Equal(1))
+
+			invalidated <- struct{}{}
+
+			Eventually(cache.Len).Should(BeZero())
+		})
+	})
+})
@@ -50,6 +50,33 @@ func buildSecretTemplate(name, tmpl string) (*template.Template, error) {
 	return t, nil
 }
 
+func validateTeamNamespace(pipelineSecretTemplate, teamSecretTemplate *template.Template) error {
+	return creds.ValidateTeamNamespace(func(teamName string) (string, []string, error) {
+		ssm := &Ssm{
+			TeamName:        teamName,
+			PipelineName:    "pipeline",
+			SecretTemplates: []*template.Template{pipelineSecretTemplate, teamSecretTemplate},
+		}
+
+		namespace, err := ssm.namespace()
+		if err != nil {
+			return "", nil, err
+		}
+
+		parameters := []string{}
+		for _, st := range ssm.SecretTemplates {
+			parameter, err := ssm.transformSecret(st, "secret")
+			if err != nil {
+				return "", nil, err
+			}
+
+			parameters = append(parameters, parameter)
+		}
+
+		return namespace, parameters, nil
+	})
+}
+
 func (manager *SsmManager) MarshalJSON() ([]byte, error) {
 	health, err := manager.Health()
 	if err != nil {
@@ -136,6 +163,11 @@ func (manager *SsmManager) Validate() error {
 	if err = teamSecretTemplate.Execute(ioutil.Discard, &dummy); err != nil {
 		return err
 	}
+	// Make sure that lookups can not escape the team's namespace, which is
+	// determined by the team template
+	if err = validateTeamNamespace(pipelineSecretTemplate, teamSecretTemplate); err != nil {
+		return err
+	}
 	// All of the AWS credential variables may be empty since credentials may be obtained via environemnt variables
 	// or other means. However, if one of them is provided, then all of them (except session token) must be provided.
 	if manager.AwsAccessKeyID == "" && manager.AwsSecretAccessKey == "" && manager.AwsSessionToken == "" {
@@ -69,14 +69,14 @@ var _ = Describe("SsmManager", func() {
 			Entry("only token", "", "", "token"),
 		)
 
-		It("passes on pipe secret template containing less specialization", func() {
+		It("fails on pipe secret template that is not namespaced by team", func() {
 			manager.PipelineSecretTemplate = "{{.Secret}}"
-			Expect(manager.Validate()).To(BeNil())
+			Expect(manager.Validate()).ToNot(BeNil())
 		})
 
-		It("passes on pipe secret template containing no specialization", func() {
+		It("fails on pipe secret template containing no specialization", func() {
 			manager.PipelineSecretTemplate = "var"
-			Expect(manager.Validate()).To(BeNil())
+			Expect(manager.Validate()).ToNot(BeNil())
 		})
 
 		It("fails on empty pipe secret template", func() {
@@ -89,16 +89,27 @@ var _ = Describe("SsmManager", func() {
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
 
-		It("passes on team secret template containing less specialization", func() {
+		It("fails on team secret template that is not namespaced by team", func() {
 			manager.TeamSecretTemplate = "{{.Secret}}"
-			Expect(manager.Validate()).To(BeNil())
+			Expect(manager.Validate()).ToNot(BeNil())
 		})
 
-		It("passes on team secret template containing no specialization", func() {
+		It("fails on team secret template containing no specialization", func() {
 			manager.TeamSecretTemplate = "var"
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
+
+		It("passes on custom secret templates nested under the team namespace", func() {
+			manager.PipelineSecretTemplate = "/shared/{{.Team}}/pipelines/{{.Pipeline}}/{{.Secret}}"
+			manager.TeamSecretTemplate = "/shared/{{.Team}}/{{.Secret}}"
 			Expect(manager.Validate()).To(BeNil())
 		})
 
+		It("fails on pipe secret template outside of the team namespace", func() {
+			manager.PipelineSecretTemplate = "/concourse/{{.Pipeline}}/{{.Team}}/{{.Secret}}"
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
+
 		It("fails on empty team secret template", func() {
 			manager.TeamSecretTemplate = ""
 			Expect(manager.Validate()).ToNot(BeNil())
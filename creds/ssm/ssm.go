@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	varTemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
 )
 
 type Ssm struct {
@@ -41,7 +42,23 @@ func (s *Ssm) transformSecret(nameTemplate *template.Template, secret string) (s
 	return buf.String(), err
 }
 
+// namespace returns the team-level namespace that all lookups must stay
+// within. The last secret template is the team template, so it is rendered
+// without a secret name to determine the namespace.
+func (s *Ssm) namespace() (string, error) {
+	if len(s.SecretTemplates) == 0 {
+		return "", nil
+	}
+
+	return s.transformSecret(s.SecretTemplates[len(s.SecretTemplates)-1], "")
+}
+
 func (s *Ssm) Get(varDef varTemplate.VariableDefinition) (interface{}, bool, error) {
+	namespace, err := s.namespace()
+	if err != nil {
+		return nil, false, err
+	}
+
 	for _, st := range s.SecretTemplates {
 		// Try to get the parameter as string value
 		parameter, err := s.transformSecret(st, varDef.Name)
@@ -56,6 +73,15 @@ func (s *Ssm) Get(varDef varTemplate.VariableDefinition) (interface{}, bool, err
 		if strings.Contains(parameter, "//") {
 			continue
 		}
+		err = creds.VerifySecretPath(s.TeamName, namespace, parameter)
+		if err != nil {
+			s.log.Error("ssm-parameter-outside-of-team-namespace", err, lager.Data{
+				"template":  st.Name(),
+				"secret":    varDef.Name,
+				"parameter": parameter,
+			})
+			return nil, false, err
+		}
 		value, found, err := s.getParameterByName(parameter)
 		if err != nil {
 			s.log.Error("failed-to-get-ssm-parameter-by-name", err, lager.Data{
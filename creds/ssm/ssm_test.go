@@ -168,6 +168,17 @@ var _ = Describe("Ssm", func() {
 			Expect(err).NotTo(BeNil())
 		})
 
+		It("should not allow secrets to escape the team namespace", func() {
+			mockService.stubGetParameter = func(input string) (string, error) {
+				Fail("should not look up " + input)
+				return "", nil
+			}
+			value, found, err := ssmAccess.Get(varTemplate.VariableDefinition{Name: "../../beta/cheery"})
+			Expect(value).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("should allow empty pipeline name", func() {
 			ssmAccess.PipelineName = ""
 			mockService.stubGetParameter = func(input string) (string, error) {
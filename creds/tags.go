@@ -0,0 +1,44 @@
+package creds
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+type Tags struct {
+	variablesResolver Variables
+	rawTags           atc.Tags
+}
+
+func NewTags(variables Variables, tags atc.Tags) Tags {
+	return Tags{
+		variablesResolver: variables,
+		rawTags:           tags,
+	}
+}
+
+// Evaluate resolves any vars in the tags. A var may resolve to either a single
+// tag or a list of tags, which are flattened into the result.
+func (t Tags) Evaluate() (atc.Tags, error) {
+	var untypedTags []interface{}
+
+	err := evaluate(t.variablesResolver, t.rawTags, &untypedTags)
+	if err != nil {
+		return t.rawTags, err
+	}
+
+	tags := atc.Tags{}
+	for _, untypedTag := range untypedTags {
+		switch tag := untypedTag.(type) {
+		case []interface{}:
+			for _, subTag := range tag {
+				tags = append(tags, fmt.Sprintf("%v", subTag))
+			}
+		default:
+			tags = append(tags, fmt.Sprintf("%v", tag))
+		}
+	}
+
+	return tags, nil
+}
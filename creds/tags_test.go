@@ -0,0 +1,40 @@
+package creds_test
+
+import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tags", func() {
+	var variables template.StaticVariables
+
+	BeforeEach(func() {
+		variables = template.StaticVariables{
+			"deploy-zone":  "us-east",
+			"deploy-zones": []interface{}{"us-east", "us-west"},
+		}
+	})
+
+	Describe("Evaluate", func() {
+		It("resolves vars to a single tag", func() {
+			tags, err := creds.NewTags(variables, atc.Tags{"((deploy-zone))", "linux"}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(Equal(atc.Tags{"us-east", "linux"}))
+		})
+
+		It("flattens vars which resolve to a list of tags", func() {
+			tags, err := creds.NewTags(variables, atc.Tags{"((deploy-zones))"}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(Equal(atc.Tags{"us-east", "us-west"}))
+		})
+
+		It("errors when a var is missing", func() {
+			_, err := creds.NewTags(variables, atc.Tags{"((missing))"}).Evaluate()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
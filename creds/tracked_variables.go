@@ -0,0 +1,146 @@
+package creds
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+const RedactedSecret = "((redacted))"
+
+// secrets shorter than this are not redacted, as they are likely to be
+// values such as '1' or 'yes' which would be redacted from all over the
+// output rather than hide anything
+const minRedactedSecretLength = 4
+
+// TrackedVariables remembers the values of every secret resolved through it,
+// so that they can be redacted from build output.
+type TrackedVariables struct {
+	Variables
+
+	lock    sync.RWMutex
+	secrets map[string]struct{}
+}
+
+func NewTrackedVariables(variables Variables) *TrackedVariables {
+	return &TrackedVariables{
+		Variables: variables,
+
+		secrets: map[string]struct{}{},
+	}
+}
+
+func (variables *TrackedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	val, found, err := variables.Variables.Get(varDef)
+	if err != nil || !found {
+		return val, found, err
+	}
+
+	variables.lock.Lock()
+	variables.track(val)
+	variables.lock.Unlock()
+
+	return val, found, nil
+}
+
+// Track redacts a secret which was not resolved through the variables, e.g. a
+// token issued to the build, from build output.
+func (variables *TrackedVariables) Track(secret string) {
+	variables.lock.Lock()
+	variables.track(secret)
+	variables.lock.Unlock()
+}
+
+func (variables *TrackedVariables) track(val interface{}) {
+	switch v := val.(type) {
+	case string:
+		if len(v) >= minRedactedSecretLength {
+			variables.secrets[v] = struct{}{}
+		}
+	case map[interface{}]interface{}:
+		for _, sub := range v {
+			variables.track(sub)
+		}
+	case map[string]interface{}:
+		for _, sub := range v {
+			variables.track(sub)
+		}
+	case []interface{}:
+		for _, sub := range v {
+			variables.track(sub)
+		}
+	}
+}
+
+// Redact replaces all occurrences of secrets resolved so far with
+// RedactedSecret. Longer secrets are replaced first so that secrets which
+// contain other secrets are fully redacted.
+func (variables *TrackedVariables) Redact(text string) string {
+	return redact(text, variables.sortedSecrets())
+}
+
+// RedactStream redacts a chunk of a stream of output, e.g. a step's logs, in
+// which a secret may be split across chunks. The end of the chunk is held
+// back if it could be the start of a secret; it should be prepended to the
+// next chunk, or passed to Redact once the stream ends.
+func (variables *TrackedVariables) RedactStream(chunk string) (string, string) {
+	secrets := variables.sortedSecrets()
+
+	held := ""
+	for _, secret := range secrets {
+		for length := len(secret) - 1; length > len(held); length-- {
+			cut := len(chunk) - length
+			if strings.HasSuffix(chunk, secret[:length]) && !splitsSecret(chunk, cut, secrets) {
+				held = chunk[cut:]
+				break
+			}
+		}
+	}
+
+	return redact(chunk[:len(chunk)-len(held)], secrets), held
+}
+
+// splitsSecret returns whether cutting the text at the given index would
+// split a secret within it, which would then not be redacted.
+func splitsSecret(text string, cut int, secrets []string) bool {
+	for _, secret := range secrets {
+		start := cut - len(secret) + 1
+		if start < 0 {
+			start = 0
+		}
+
+		for i := start; i < cut; i++ {
+			if strings.HasPrefix(text[i:], secret) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sortedSecrets returns the secrets resolved so far, longest first.
+func (variables *TrackedVariables) sortedSecrets() []string {
+	variables.lock.RLock()
+	secrets := make([]string, 0, len(variables.secrets))
+	for secret := range variables.secrets {
+		secrets = append(secrets, secret)
+	}
+	variables.lock.RUnlock()
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	return secrets
+}
+
+func redact(text string, secrets []string) string {
+	for _, secret := range secrets {
+		text = strings.Replace(text, secret, RedactedSecret, -1)
+	}
+
+	return text
+}
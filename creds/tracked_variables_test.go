@@ -0,0 +1,112 @@
+package creds_test
+
+import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrackedVariables", func() {
+	var variables *creds.TrackedVariables
+
+	BeforeEach(func() {
+		variables = creds.NewTrackedVariables(template.StaticVariables{
+			"password": "hunter2",
+			"short":    "yes",
+			"sauce":    "secret-sauce",
+			"long":     "hunter2-and-more",
+			"nested": map[interface{}]interface{}{
+				"key": "nested-secret",
+			},
+		})
+	})
+
+	It("does not redact secrets which have not been resolved", func() {
+		Expect(variables.Redact("my password is hunter2")).To(Equal("my password is hunter2"))
+	})
+
+	It("redacts resolved secrets", func() {
+		_, found, err := variables.Get(template.VariableDefinition{Name: "password"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		Expect(variables.Redact("my password is hunter2, hunter2!")).To(Equal("my password is ((redacted)), ((redacted))!"))
+	})
+
+	It("redacts longer secrets first", func() {
+		variables.Get(template.VariableDefinition{Name: "password"})
+		variables.Get(template.VariableDefinition{Name: "long"})
+
+		Expect(variables.Redact("hunter2-and-more")).To(Equal("((redacted))"))
+	})
+
+	It("redacts values nested within secrets", func() {
+		variables.Get(template.VariableDefinition{Name: "nested"})
+
+		Expect(variables.Redact("the nested-secret is here")).To(Equal("the ((redacted)) is here"))
+	})
+
+	It("redacts secrets tracked directly", func() {
+		variables.Track("some-token")
+
+		Expect(variables.Redact("VAULT_TOKEN=some-token")).To(Equal("VAULT_TOKEN=((redacted))"))
+	})
+
+	It("does not redact secrets which are too short to be meaningful", func() {
+		variables.Get(template.VariableDefinition{Name: "short"})
+
+		Expect(variables.Redact("yes, yes")).To(Equal("yes, yes"))
+	})
+
+	Describe("RedactStream", func() {
+		BeforeEach(func() {
+			variables.Get(template.VariableDefinition{Name: "password"})
+			variables.Get(template.VariableDefinition{Name: "long"})
+		})
+
+		It("holds back the end of the chunk if it may be the start of a secret", func() {
+			redacted, held := variables.RedactStream("hunter2 and hun")
+			Expect(redacted).To(Equal("((redacted)) and "))
+			Expect(held).To(Equal("hun"))
+
+			redacted, held = variables.RedactStream(held + "ter2!")
+			Expect(redacted).To(Equal("((redacted))!"))
+			Expect(held).To(BeEmpty())
+		})
+
+		It("holds back a secret which may be the start of a longer one", func() {
+			redacted, held := variables.RedactStream("it is hunter2")
+			Expect(redacted).To(Equal("it is "))
+			Expect(held).To(Equal("hunter2"))
+
+			redacted, held = variables.RedactStream(held + "-and-more")
+			Expect(redacted).To(Equal("((redacted))"))
+			Expect(held).To(BeEmpty())
+		})
+
+		It("does not hold back the end of a secret which it would split", func() {
+			variables.Get(template.VariableDefinition{Name: "nested"})
+			variables.Get(template.VariableDefinition{Name: "sauce"})
+
+			redacted, held := variables.RedactStream("the nested-secret")
+			Expect(redacted).To(Equal("the ((redacted))"))
+			Expect(held).To(BeEmpty())
+		})
+
+		It("holds back nothing when the chunk cannot continue into a secret", func() {
+			redacted, held := variables.RedactStream("nothing to see here")
+			Expect(redacted).To(Equal("nothing to see here"))
+			Expect(held).To(BeEmpty())
+		})
+	})
+
+	It("does not track secrets that are not found", func() {
+		_, found, err := variables.Get(template.VariableDefinition{Name: "missing"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		Expect(variables.Redact("missing")).To(Equal("missing"))
+	})
+})
@@ -0,0 +1,88 @@
+package vault
+
+import (
+	"bytes"
+	"strconv"
+	"text/template"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+type BuildTokenConfig struct {
+	Policies []string      `long:"build-token-policy" description:"Policy to attach to short-lived tokens issued to each build, exposed to tasks as VAULT_TOKEN. May reference {{.Team}} and {{.Pipeline}}. Can be specified multiple times."`
+	TTL      time.Duration `long:"build-token-ttl" default:"1h" description:"Time to live of tokens issued to builds. Tokens are revoked when the build completes."`
+}
+
+type buildPolicy struct {
+	Team     string
+	Pipeline string
+}
+
+// The buildTokenIssuer creates child tokens of the ATC's own token, limited
+// to the configured policies.
+type buildTokenIssuer struct {
+	client   *APIClient
+	url      string
+	policies []*template.Template
+	ttl      time.Duration
+}
+
+func (issuer *buildTokenIssuer) IssueBuildToken(logger lager.Logger, teamName string, pipelineName string, buildID int) (creds.BuildToken, error) {
+	policies := []string{}
+	for _, policyTemplate := range issuer.policies {
+		var buf bytes.Buffer
+		err := policyTemplate.Execute(&buf, buildPolicy{
+			Team:     teamName,
+			Pipeline: pipelineName,
+		})
+		if err != nil {
+			return creds.BuildToken{}, err
+		}
+
+		policies = append(policies, buf.String())
+	}
+
+	secret, err := issuer.client.client().Auth().Token().Create(&vaultapi.TokenCreateRequest{
+		Policies:    policies,
+		TTL:         issuer.ttl.String(),
+		DisplayName: "concourse-build-" + strconv.Itoa(buildID),
+		Metadata: map[string]string{
+			"team":     teamName,
+			"pipeline": pipelineName,
+			"build_id": strconv.Itoa(buildID),
+		},
+	})
+	if err != nil {
+		logger.Error("failed-to-create-build-token", err)
+		return creds.BuildToken{}, err
+	}
+
+	logger.Info("created-build-token", lager.Data{
+		"token-accessor": secret.Auth.Accessor,
+		"policies":       secret.Auth.Policies,
+	})
+
+	return creds.BuildToken{
+		Accessor: secret.Auth.Accessor,
+		Secret:   secret.Auth.ClientToken,
+		Env: map[string]string{
+			"VAULT_ADDR":  issuer.url,
+			"VAULT_TOKEN": secret.Auth.ClientToken,
+		},
+	}, nil
+}
+
+func (issuer *buildTokenIssuer) RevokeBuildToken(logger lager.Logger, token creds.BuildToken) error {
+	err := issuer.client.client().Auth().Token().RevokeAccessor(token.Accessor)
+	if err != nil {
+		logger.Error("failed-to-revoke-build-token", err, lager.Data{
+			"token-accessor": token.Accessor,
+		})
+		return err
+	}
+
+	return nil
+}
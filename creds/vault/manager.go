@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"text/template"
+	"text/template/parse"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -14,17 +16,24 @@ import (
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
+const DefaultPipelineSecretTemplate = "/{{.Team}}/{{.Pipeline}}/{{.Secret}}"
+const DefaultTeamSecretTemplate = "/{{.Team}}/{{.Secret}}"
+
 type VaultManager struct {
 	URL string `long:"url" description:"Vault server address used to access secrets."`
 
 	PathPrefix string `long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
 
+	PipelineSecretTemplate string `long:"pipeline-secret-template" default:"/{{.Team}}/{{.Pipeline}}/{{.Secret}}" description:"Path template, relative to the path prefix, used for pipeline specific secrets."`
+	TeamSecretTemplate     string `long:"team-secret-template"     default:"/{{.Team}}/{{.Secret}}"                description:"Path template, relative to the path prefix, used for team specific secrets. Pipeline lookups may not escape this namespace."`
+
 	Cache    bool          `long:"cache" description:"Cache returned secrets for their lease duration in memory"`
 	MaxLease time.Duration `long:"max-lease" description:"If the cache is enabled, and this is set, override secrets lease duration with a maximum value"`
 
-	TLS    TLS
-	Auth   AuthConfig
-	Client *APIClient
+	TLS        TLS
+	Auth       AuthConfig
+	BuildToken BuildTokenConfig
+	Client     *APIClient
 }
 
 type TLS struct {
@@ -77,6 +86,8 @@ func (manager *VaultManager) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&map[string]interface{}{
 		"url":                manager.URL,
 		"path_prefix":        manager.PathPrefix,
+		"pipeline_template":  manager.PipelineSecretTemplate,
+		"team_template":      manager.TeamSecretTemplate,
 		"cache":              manager.Cache,
 		"max_lease":          manager.MaxLease,
 		"ca_cert":            manager.TLS.CACert,
@@ -85,6 +96,7 @@ func (manager *VaultManager) MarshalJSON() ([]byte, error) {
 		"auth_max_ttl":       manager.Auth.BackendMaxTTL,
 		"auth_retry_max":     manager.Auth.RetryMax,
 		"auth_retry_initial": manager.Auth.RetryInitial,
+		"build_token_ttl":    manager.BuildToken.TTL,
 		"health":             health,
 	})
 }
@@ -99,6 +111,21 @@ func (manager VaultManager) Validate() error {
 		return fmt.Errorf("invalid URL: %s", err)
 	}
 
+	secretTemplates, err := manager.secretTemplates()
+	if err != nil {
+		return err
+	}
+
+	err = validateTeamNamespace(manager.PathPrefix, secretTemplates)
+	if err != nil {
+		return err
+	}
+
+	_, err = manager.buildTokenPolicies()
+	if err != nil {
+		return err
+	}
+
 	if manager.Auth.ClientToken != "" {
 		return nil
 	}
@@ -132,5 +159,98 @@ func (manager VaultManager) NewVariablesFactory(logger lager.Logger) (creds.Vari
 		sr = NewCache(manager.Client, manager.MaxLease)
 	}
 
-	return NewVaultFactory(sr, ra.LoggedIn(), manager.PathPrefix), nil
+	secretTemplates, err := manager.secretTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVaultFactory(sr, ra.LoggedIn(), ra.Renewed(), manager.PathPrefix, secretTemplates)
+}
+
+func (manager VaultManager) NewBuildTokenIssuer(logger lager.Logger) (creds.BuildTokenIssuer, bool, error) {
+	if len(manager.BuildToken.Policies) == 0 {
+		return nil, false, nil
+	}
+
+	policies, err := manager.buildTokenPolicies()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &buildTokenIssuer{
+		client:   manager.Client,
+		url:      manager.URL,
+		policies: policies,
+		ttl:      manager.BuildToken.TTL,
+	}, true, nil
+}
+
+func (manager VaultManager) buildTokenPolicies() ([]*template.Template, error) {
+	policies := []*template.Template{}
+	for _, policy := range manager.BuildToken.Policies {
+		t, err := buildSecretTemplate("build-token-policy", policy)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, t)
+	}
+
+	return policies, nil
+}
+
+func (manager VaultManager) secretTemplates() ([]*template.Template, error) {
+	pipelineSecretTemplate, err := buildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := buildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*template.Template{pipelineSecretTemplate, teamSecretTemplate}, nil
+}
+
+func buildSecretTemplate(name, tmpl string) (*template.Template, error) {
+	t, err := template.
+		New(name).
+		Option("missingkey=error").
+		Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if parse.IsEmptyTree(t.Root) {
+		return nil, errors.New("secret template should not be empty")
+	}
+	return t, nil
+}
+
+func validateTeamNamespace(prefix string, secretTemplates []*template.Template) error {
+	return creds.ValidateTeamNamespace(func(teamName string) (string, []string, error) {
+		v := Vault{
+			PathPrefix:      prefix,
+			TeamName:        teamName,
+			PipelineName:    "pipeline",
+			SecretTemplates: secretTemplates,
+		}
+
+		namespace, err := v.namespace()
+		if err != nil {
+			return "", nil, err
+		}
+
+		secretPaths := []string{}
+		for _, st := range secretTemplates {
+			secretPath, err := v.transformSecret(st, "secret")
+			if err != nil {
+				return "", nil, err
+			}
+
+			secretPaths = append(secretPaths, v.path(secretPath))
+		}
+
+		return namespace, secretPaths, nil
+	})
 }
@@ -25,6 +25,8 @@ type ReAuther struct {
 
 	loggedIn     chan struct{}
 	loggedInOnce *sync.Once
+
+	renewed chan struct{}
 }
 
 // NewReAuther with a retry time and a max retry time.
@@ -37,6 +39,8 @@ func NewReAuther(auther Auther, maxTTL, retry, max time.Duration) *ReAuther {
 
 		loggedIn:     make(chan struct{}, 1),
 		loggedInOnce: &sync.Once{},
+
+		renewed: make(chan struct{}, 1),
 	}
 
 	go ra.authLoop()
@@ -50,6 +54,20 @@ func (ra *ReAuther) LoggedIn() <-chan struct{} {
 	return ra.loggedIn
 }
 
+// Renewed will receive a signal after every login or renewal following the
+// initial login. Multiple renewals may result in a single signal as this
+// channel is not blocked.
+func (ra *ReAuther) Renewed() <-chan struct{} {
+	return ra.renewed
+}
+
+func (ra *ReAuther) notifyRenewed() {
+	select {
+	case ra.renewed <- struct{}{}:
+	default:
+	}
+}
+
 // we can't renew a secret that has exceeded it's maxTTL or it's lease
 func (ra *ReAuther) renewable(leaseEnd, tokenEOL time.Time) bool {
 	now := time.Now()
@@ -93,6 +111,12 @@ func (ra *ReAuther) authLoop() {
 
 			exp.Reset()
 
+			select {
+			case <-ra.loggedIn:
+				ra.notifyRenewed()
+			default:
+			}
+
 			ra.loggedInOnce.Do(func() {
 				close(ra.loggedIn)
 			})
@@ -118,6 +142,8 @@ func (ra *ReAuther) authLoop() {
 
 			exp.Reset()
 
+			ra.notifyRenewed()
+
 			leaseEnd = time.Now().Add(lease)
 			ra.sleep(leaseEnd, tokenEOL)
 		}
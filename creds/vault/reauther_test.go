@@ -75,3 +75,29 @@ func TestReAuther(t *testing.T) {
 	}
 
 }
+
+func TestReAutherRenewed(t *testing.T) {
+	ma := &MockAuther{
+		LoggedIn: make(chan bool, 1),
+		Renewed:  make(chan bool, 1),
+		Delay:    1 * time.Second,
+	}
+	ra := NewReAuther(ma, 10*time.Second, 1*time.Second, 64*time.Second)
+
+	<-ma.LoggedIn
+	<-ra.LoggedIn()
+
+	select {
+	case <-ra.Renewed():
+		t.Error("Should not signal renewal after the initial login")
+	default:
+	}
+
+	<-ma.Renewed
+
+	select {
+	case <-ra.Renewed():
+	case <-time.After(1 * time.Second):
+		t.Fatal("Didn't signal renewal within timeout")
+	}
+}
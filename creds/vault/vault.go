@@ -1,9 +1,14 @@
 package vault
 
 import (
+	"bytes"
+	"errors"
 	"path"
+	"strings"
+	"text/template"
 
-	"github.com/cloudfoundry/bosh-cli/director/template"
+	varTemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
@@ -21,25 +26,52 @@ type Vault struct {
 	PathPrefix   string
 	TeamName     string
 	PipelineName string
+
+	// SecretTemplates are evaluated in order to determine the paths to look
+	// up, relative to PathPrefix. The last template is the team template,
+	// which also determines the namespace that lookups may not escape.
+	SecretTemplates []*template.Template
+}
+
+type VaultSecret struct {
+	Team     string
+	Pipeline string
+	Secret   string
 }
 
-func (v Vault) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+func (v Vault) Get(varDef varTemplate.VariableDefinition) (interface{}, bool, error) {
 	var secret *vaultapi.Secret
 	var found bool
-	var err error
 
-	if v.PipelineName != "" {
-		secret, found, err = v.findSecret(v.path(v.TeamName, v.PipelineName, varDef.Name))
+	namespace, err := v.namespace()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, st := range v.SecretTemplates {
+		secretPath, err := v.transformSecret(st, varDef.Name)
 		if err != nil {
 			return nil, false, err
 		}
-	}
 
-	if !found {
-		secret, found, err = v.findSecret(v.path(v.TeamName, varDef.Name))
+		// If pipeline name is empty, double slashes may be present in the path
+		if strings.Contains(secretPath, "//") {
+			continue
+		}
+
+		err = creds.VerifySecretPath(v.TeamName, namespace, v.path(secretPath))
+		if err != nil {
+			return nil, false, err
+		}
+
+		secret, found, err = v.findSecret(v.path(secretPath))
 		if err != nil {
 			return nil, false, err
 		}
+
+		if found {
+			break
+		}
 	}
 
 	if !found {
@@ -76,7 +108,30 @@ func (v Vault) path(segments ...string) string {
 	return path.Join(append([]string{v.PathPrefix}, segments...)...)
 }
 
-func (v Vault) List() ([]template.VariableDefinition, error) {
+func (v Vault) transformSecret(nameTemplate *template.Template, secret string) (string, error) {
+	var buf bytes.Buffer
+	err := nameTemplate.Execute(&buf, &VaultSecret{
+		Team:     v.TeamName,
+		Pipeline: v.PipelineName,
+		Secret:   secret,
+	})
+	return buf.String(), err
+}
+
+func (v Vault) namespace() (string, error) {
+	if len(v.SecretTemplates) == 0 {
+		return "", errors.New("no secret templates configured")
+	}
+
+	teamPath, err := v.transformSecret(v.SecretTemplates[len(v.SecretTemplates)-1], "")
+	if err != nil {
+		return "", err
+	}
+
+	return v.path(teamPath), nil
+}
+
+func (v Vault) List() ([]varTemplate.VariableDefinition, error) {
 	// Don't think this works with vault.. if we need it to we'll figure it out
 	// var defs []template.VariableDefinition
 
@@ -92,5 +147,5 @@ func (v Vault) List() ([]template.VariableDefinition, error) {
 	// 	})
 	// }
 
-	return []template.VariableDefinition{}, nil
+	return []varTemplate.VariableDefinition{}, nil
 }
@@ -1,6 +1,8 @@
 package vault
 
 import (
+	"errors"
+	"text/template"
 	"time"
 
 	"github.com/concourse/atc/creds"
@@ -8,19 +10,29 @@ import (
 
 // The vaultFactory will return a vault implementation of creds.Variables.
 type vaultFactory struct {
-	sr       SecretReader
-	prefix   string
-	loggedIn <-chan struct{}
+	sr              SecretReader
+	prefix          string
+	secretTemplates []*template.Template
+	loggedIn        <-chan struct{}
+	renewed         <-chan struct{}
 }
 
-func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, prefix string) *vaultFactory {
+// NewVaultFactory returns an error if no secret templates are given, as every
+// lookup would silently find nothing.
+func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, renewed <-chan struct{}, prefix string, secretTemplates []*template.Template) (*vaultFactory, error) {
+	if len(secretTemplates) == 0 {
+		return nil, errors.New("no secret templates configured")
+	}
+
 	factory := &vaultFactory{
-		sr:       sr,
-		prefix:   prefix,
-		loggedIn: loggedIn,
+		sr:              sr,
+		prefix:          prefix,
+		secretTemplates: secretTemplates,
+		loggedIn:        loggedIn,
+		renewed:         renewed,
 	}
 
-	return factory
+	return factory, nil
 }
 
 // NewVariables will block until the loggedIn channel passed to the
@@ -36,5 +48,13 @@ func (factory *vaultFactory) NewVariables(teamName string, pipelineName string)
 		PathPrefix:   factory.prefix,
 		TeamName:     teamName,
 		PipelineName: pipelineName,
+
+		SecretTemplates: factory.secretTemplates,
 	}
 }
+
+// Invalidated signals whenever the vault token has been renewed, so that any
+// cached secrets are looked up again with the new token.
+func (factory *vaultFactory) Invalidated() <-chan struct{} {
+	return factory.renewed
+}
@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"testing"
+	"text/template"
+
+	varTemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+type MapSecretReader struct {
+	secrets map[string]*vaultapi.Secret
+	reads   []string
+}
+
+func (msr *MapSecretReader) Read(path string) (*vaultapi.Secret, error) {
+	msr.reads = append(msr.reads, path)
+	return msr.secrets[path], nil
+}
+
+func defaultSecretTemplates(t *testing.T) []*template.Template {
+	manager := VaultManager{
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+	}
+
+	templates, err := manager.secretTemplates()
+	if err != nil {
+		t.Fatal("failed to build secret templates", err)
+	}
+
+	return templates
+}
+
+func TestVaultFallsBackToTeam(t *testing.T) {
+	msr := &MapSecretReader{
+		secrets: map[string]*vaultapi.Secret{
+			"/concourse/alpha/foo": &vaultapi.Secret{
+				Data: map[string]interface{}{"value": "team-value"},
+			},
+		},
+	}
+
+	v := Vault{
+		SecretReader:    msr,
+		PathPrefix:      "/concourse",
+		TeamName:        "alpha",
+		PipelineName:    "some-pipeline",
+		SecretTemplates: defaultSecretTemplates(t),
+	}
+
+	val, found, err := v.Get(varTemplate.VariableDefinition{Name: "foo"})
+	if err != nil {
+		t.Fatal("got error reading secret", err)
+	}
+	if !found || val != "team-value" {
+		t.Errorf("got %v (found: %t), expected team-value", val, found)
+	}
+	if len(msr.reads) != 2 || msr.reads[0] != "/concourse/alpha/some-pipeline/foo" || msr.reads[1] != "/concourse/alpha/foo" {
+		t.Errorf("got reads %v, expected pipeline then team path", msr.reads)
+	}
+}
+
+func TestVaultDeniesEscapingTeamNamespace(t *testing.T) {
+	msr := &MapSecretReader{}
+
+	v := Vault{
+		SecretReader:    msr,
+		PathPrefix:      "/concourse",
+		TeamName:        "alpha",
+		PipelineName:    "some-pipeline",
+		SecretTemplates: defaultSecretTemplates(t),
+	}
+
+	_, found, err := v.Get(varTemplate.VariableDefinition{Name: "../../beta/foo"})
+	if err == nil {
+		t.Error("expected error reading secret outside of team namespace")
+	}
+	if found {
+		t.Error("expected secret not to be found")
+	}
+	if len(msr.reads) != 0 {
+		t.Errorf("got reads %v, expected none", msr.reads)
+	}
+}
+
+func TestValidateTeamNamespace(t *testing.T) {
+	manager := VaultManager{
+		URL:                    "https://vault.example.com",
+		PathPrefix:             "/concourse",
+		PipelineSecretTemplate: "/{{.Pipeline}}/{{.Secret}}",
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+		Auth:                   AuthConfig{ClientToken: "some-token"},
+	}
+
+	if err := manager.Validate(); err == nil {
+		t.Error("expected pipeline template outside of the team namespace to be invalid")
+	}
+
+	manager.PipelineSecretTemplate = DefaultPipelineSecretTemplate
+	if err := manager.Validate(); err != nil {
+		t.Error("expected default templates to be valid", err)
+	}
+}
+
+func TestVaultFactoryRequiresSecretTemplates(t *testing.T) {
+	_, err := NewVaultFactory(&MapSecretReader{}, nil, nil, "/concourse", nil)
+	if err == nil {
+		t.Error("expected error creating factory without secret templates")
+	}
+}
@@ -43,3 +43,18 @@ func (types VersionedResourceTypes) Without(name string) VersionedResourceTypes
 
 	return newTypes
 }
+
+// WithBaseResourceTypeDefaults fills in the operator's defaults for the
+// resource type into the source, unless the type is one of the custom types.
+func (types VersionedResourceTypes) WithBaseResourceTypeDefaults(resourceType string, source atc.Source) atc.Source {
+	if _, found := types.Lookup(resourceType); found {
+		return source
+	}
+
+	defaults, found := atc.FindBaseResourceTypeDefaults(resourceType)
+	if !found {
+		return source
+	}
+
+	return source.WithDefaults(defaults)
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/lock"
+)
+
+// AbortBuildsFilter narrows down the running and pending builds aborted in
+// bulk. Zero values do not filter anything.
+type AbortBuildsFilter struct {
+	PipelineName string
+	JobName      string
+	Statuses     []BuildStatus
+}
+
+type ErrNotAbortableStatus struct {
+	Status BuildStatus
+}
+
+func (err ErrNotAbortableStatus) Error() string {
+	return fmt.Sprintf("builds with status '%s' cannot be aborted", err.Status)
+}
+
+func (filter AbortBuildsFilter) Validate() error {
+	for _, status := range filter.Statuses {
+		switch status {
+		case BuildStatusPending, BuildStatusStarted:
+		default:
+			return ErrNotAbortableStatus{Status: status}
+		}
+	}
+
+	return nil
+}
+
+func (filter AbortBuildsFilter) filterBuilds(query sq.SelectBuilder) sq.SelectBuilder {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []BuildStatus{BuildStatusPending, BuildStatusStarted}
+	}
+
+	query = query.Where(sq.Eq{"b.status": statuses})
+
+	if filter.PipelineName != "" {
+		query = query.Where(sq.Eq{"p.name": filter.PipelineName})
+	}
+
+	if filter.JobName != "" {
+		query = query.Where(sq.Eq{"j.name": filter.JobName})
+	}
+
+	return query
+}
+
+func (t *team) AbortBuilds(filter AbortBuildsFilter, reason string, abortedBy string) ([]Build, error) {
+	return abortBuilds(t.conn, t.lockFactory, filter, reason, abortedBy, sq.Eq{"b.team_id": t.id})
+}
+
+func (j *job) AbortBuilds(filter AbortBuildsFilter, reason string, abortedBy string) ([]Build, error) {
+	filter.PipelineName = ""
+	filter.JobName = ""
+
+	return abortBuilds(j.conn, j.lockFactory, filter, reason, abortedBy, sq.Eq{"b.job_id": j.id})
+}
+
+// abortBuilds marks every matching build as aborted in one transaction, so
+// that none of them can be started in the meantime, and returns them so that
+// they can be aborted by the engine. The reason and who aborted the builds are
+// recorded in the same transaction, unless a build was already aborted by
+// someone else, so that they are included in each build's final status event.
+func abortBuilds(conn Conn, lockFactory lock.LockFactory, filter AbortBuildsFilter, reason string, abortedBy string, scope sq.Sqlizer) ([]Build, error) {
+	err := filter.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := filter.filterBuilds(buildsQuery.Where(scope)).
+		OrderBy("b.id ASC").
+		Suffix("FOR UPDATE OF b").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	builds := []Build{}
+	ids := []int{}
+	for rows.Next() {
+		build := &build{conn: conn, lockFactory: lockFactory}
+		err = scanBuild(build, rows, conn.EncryptionStrategy())
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		build.status = BuildStatusAborted
+
+		if build.abortedBy == "" {
+			build.abortReason = reason
+			build.abortedBy = abortedBy
+		}
+
+		builds = append(builds, build)
+		ids = append(ids, build.id)
+	}
+
+	Close(rows)
+
+	if len(ids) > 0 {
+		_, err = psql.Update("builds").
+			Set("status", string(BuildStatusAborted)).
+			Where(sq.Eq{"id": ids}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = psql.Update("builds").
+			Set("abort_reason", sql.NullString{String: reason, Valid: reason != ""}).
+			Set("aborted_by", sql.NullString{String: abortedBy, Valid: abortedBy != ""}).
+			Where(sq.Eq{
+				"id":         ids,
+				"aborted_by": nil,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return builds, nil
+}
@@ -0,0 +1,149 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AbortBuilds", func() {
+	var (
+		pendingBuild  db.Build
+		startedBuild  db.Build
+		finishedBuild db.Build
+		oneOffBuild   db.Build
+	)
+
+	BeforeEach(func() {
+		var err error
+		pendingBuild, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		startedBuild, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		started, err := startedBuild.Start("some-engine", "{}", atc.Plan{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		finishedBuild, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = finishedBuild.Finish(db.BuildStatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+
+		oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	buildIDs := func(builds []db.Build) []int {
+		ids := []int{}
+		for _, build := range builds {
+			ids = append(ids, build.ID())
+		}
+		return ids
+	}
+
+	status := func(build db.Build) db.BuildStatus {
+		found, err := build.Reload()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return build.Status()
+	}
+
+	Describe("Team.AbortBuilds", func() {
+		It("aborts every running and pending build of the team", func() {
+			builds, err := defaultTeam.AbortBuilds(db.AbortBuildsFilter{}, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs(builds)).To(Equal([]int{pendingBuild.ID(), startedBuild.ID(), oneOffBuild.ID()}))
+
+			Expect(status(pendingBuild)).To(Equal(db.BuildStatusAborted))
+			Expect(status(startedBuild)).To(Equal(db.BuildStatusAborted))
+			Expect(status(oneOffBuild)).To(Equal(db.BuildStatusAborted))
+			Expect(status(finishedBuild)).To(Equal(db.BuildStatusSucceeded))
+		})
+
+		It("only aborts builds matching the filter", func() {
+			builds, err := defaultTeam.AbortBuilds(db.AbortBuildsFilter{
+				PipelineName: defaultPipeline.Name(),
+				Statuses:     []db.BuildStatus{db.BuildStatusStarted},
+			}, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs(builds)).To(Equal([]int{startedBuild.ID()}))
+
+			Expect(status(pendingBuild)).To(Equal(db.BuildStatusPending))
+			Expect(status(oneOffBuild)).To(Equal(db.BuildStatusPending))
+		})
+
+		It("records why and by whom the builds were aborted", func() {
+			builds, err := defaultTeam.AbortBuilds(db.AbortBuildsFilter{}, "wrong branch", "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range builds {
+				Expect(build.AbortReason()).To(Equal("wrong branch"))
+				Expect(build.AbortedBy()).To(Equal("some-user"))
+			}
+
+			found, err := pendingBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pendingBuild.AbortReason()).To(Equal("wrong branch"))
+			Expect(pendingBuild.AbortedBy()).To(Equal("some-user"))
+
+			found, err = finishedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(finishedBuild.AbortedBy()).To(BeEmpty())
+		})
+
+		Context("when a build was already aborted by someone else", func() {
+			BeforeEach(func() {
+				err := startedBuild.SaveAbortReason("first", "first-user")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the first abort's reason", func() {
+				builds, err := defaultTeam.AbortBuilds(db.AbortBuildsFilter{}, "second", "second-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds[1].ID()).To(Equal(startedBuild.ID()))
+				Expect(builds[1].AbortedBy()).To(Equal("first-user"))
+
+				found, err := startedBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(startedBuild.AbortReason()).To(Equal("first"))
+				Expect(startedBuild.AbortedBy()).To(Equal("first-user"))
+			})
+		})
+
+		It("does not abort builds of other teams", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := otherTeam.AbortBuilds(db.AbortBuildsFilter{}, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+
+			Expect(status(pendingBuild)).To(Equal(db.BuildStatusPending))
+		})
+
+		It("refuses to abort builds which are not running or pending", func() {
+			_, err := defaultTeam.AbortBuilds(db.AbortBuildsFilter{
+				Statuses: []db.BuildStatus{db.BuildStatusSucceeded},
+			}, "", "")
+			Expect(err).To(Equal(db.ErrNotAbortableStatus{Status: db.BuildStatusSucceeded}))
+		})
+	})
+
+	Describe("Job.AbortBuilds", func() {
+		It("aborts the job's running and pending builds", func() {
+			builds, err := defaultJob.AbortBuilds(db.AbortBuildsFilter{}, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs(builds)).To(Equal([]int{pendingBuild.ID(), startedBuild.ID()}))
+
+			Expect(status(oneOffBuild)).To(Equal(db.BuildStatusPending))
+		})
+	})
+})
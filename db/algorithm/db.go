@@ -38,6 +38,31 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
+// IsVersionRerunUpstream returns true if any of the passed jobs has output the
+// version since the job last used it as the named input, i.e. an upstream job
+// ran again with the same version.
+func (db VersionsDB) IsVersionRerunUpstream(versionID int, jobID int, inputName string, passed JobSet) bool {
+	lastInputBuildID := 0
+	for _, buildInput := range db.BuildInputs {
+		if buildInput.VersionID == versionID &&
+			buildInput.JobID == jobID &&
+			buildInput.InputName == inputName &&
+			buildInput.BuildID > lastInputBuildID {
+			lastInputBuildID = buildInput.BuildID
+		}
+	}
+
+	for _, buildOutput := range db.BuildOutputs {
+		if buildOutput.VersionID == versionID &&
+			passed.Contains(buildOutput.JobID) &&
+			buildOutput.BuildID > lastInputBuildID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
@@ -0,0 +1,181 @@
+package algorithm
+
+import "sort"
+
+// A Blocker is the part of an input's configuration which prevents it from
+// being satisfied.
+type Blocker string
+
+const (
+	// BlockerVersions means the resource has no versions to use.
+	BlockerVersions Blocker = "versions"
+
+	// BlockerDisabled means every version of the resource is disabled.
+	BlockerDisabled Blocker = "disabled"
+
+	// BlockerPinned means the pinned version does not exist, is disabled, or
+	// has not passed the input's passed constraints.
+	BlockerPinned Blocker = "pinned"
+
+	// BlockerPassed means no version has passed all of the jobs the input
+	// requires, or none of those versions came through the same builds of the
+	// blocking jobs as a version of another input which requires them too.
+	BlockerPassed Blocker = "passed"
+
+	// BlockerAmbiguous means the inputs which require the blocking jobs cannot
+	// be satisfied together, although no two of them conflict on their own,
+	// so the conflict cannot be narrowed down to particular jobs.
+	BlockerAmbiguous Blocker = "ambiguous"
+)
+
+type InputExplanation struct {
+	Name string
+
+	// VersionID is the version which would be used, if the inputs can be
+	// satisfied.
+	VersionID int
+
+	BlockedBy Blocker
+
+	// BlockingJobIDs are the passed jobs which the input is waiting on.
+	BlockingJobIDs []int
+}
+
+// Explain resolves the inputs the same way as Resolve, but rather than giving
+// up on the first input which cannot be satisfied, it determines what is
+// blocking each of them.
+func (configs InputConfigs) Explain(db *VersionsDB) []InputExplanation {
+	explanations := make([]InputExplanation, len(configs))
+
+	blocked := false
+	for i, inputConfig := range configs {
+		explanations[i] = inputConfig.explain(db)
+
+		if explanations[i].BlockedBy != "" {
+			blocked = true
+		}
+	}
+
+	if blocked {
+		return explanations
+	}
+
+	mapping, ok := configs.Resolve(db)
+	if ok {
+		for i, inputConfig := range configs {
+			explanations[i].VersionID = mapping[inputConfig.Name].VersionID
+		}
+
+		return explanations
+	}
+
+	// every input can be satisfied on its own, but not together with the
+	// others, which can only be down to the builds of the jobs they have
+	// passed through in common
+	if configs.explainConflicts(db, explanations) {
+		return explanations
+	}
+
+	for i, inputConfig := range configs {
+		sharedJobs := JobSet{}
+		for j, otherConfig := range configs {
+			if i != j {
+				sharedJobs = sharedJobs.Union(inputConfig.Passed.Intersect(otherConfig.Passed))
+			}
+		}
+
+		if len(sharedJobs) > 0 {
+			explanations[i].BlockedBy = BlockerAmbiguous
+			explanations[i].BlockingJobIDs = sharedJobs.ids()
+		}
+	}
+
+	return explanations
+}
+
+// explainConflicts blocks each pair of inputs which cannot be satisfied
+// together by the jobs they have passed through in common. It returns false
+// if no such pair is found.
+func (configs InputConfigs) explainConflicts(db *VersionsDB, explanations []InputExplanation) bool {
+	blockingJobs := make([]JobSet, len(configs))
+
+	found := false
+	for i := range configs {
+		for j := i + 1; j < len(configs); j++ {
+			sharedJobs := configs[i].Passed.Intersect(configs[j].Passed)
+			if len(sharedJobs) == 0 {
+				continue
+			}
+
+			_, ok := InputConfigs{configs[i], configs[j]}.Resolve(db)
+			if ok {
+				continue
+			}
+
+			blockingJobs[i] = sharedJobs.Union(blockingJobs[i])
+			blockingJobs[j] = sharedJobs.Union(blockingJobs[j])
+			found = true
+		}
+	}
+
+	for i, jobs := range blockingJobs {
+		if len(jobs) > 0 {
+			explanations[i].BlockedBy = BlockerPassed
+			explanations[i].BlockingJobIDs = jobs.ids()
+		}
+	}
+
+	return found
+}
+
+func (inputConfig InputConfig) explain(db *VersionsDB) InputExplanation {
+	explanation := InputExplanation{Name: inputConfig.Name}
+
+	if len(inputConfig.Passed) == 0 {
+		if inputConfig.PinnedVersionID != 0 {
+			_, found := db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+			if !found {
+				explanation.BlockedBy = BlockerPinned
+			}
+		} else if db.AllVersionsOfResource(inputConfig.ResourceID).IsEmpty() {
+			explanation.BlockedBy = BlockerVersions
+		}
+
+		return explanation
+	}
+
+	for _, jobID := range inputConfig.Passed.ids() {
+		if db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, JobSet{jobID: struct{}{}}).IsEmpty() {
+			explanation.BlockingJobIDs = append(explanation.BlockingJobIDs, jobID)
+		}
+	}
+
+	if len(explanation.BlockingJobIDs) > 0 {
+		explanation.BlockedBy = BlockerPassed
+		return explanation
+	}
+
+	candidates := db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, inputConfig.Passed)
+	if candidates.IsEmpty() {
+		explanation.BlockedBy = BlockerPassed
+		explanation.BlockingJobIDs = inputConfig.Passed.ids()
+		return explanation
+	}
+
+	if inputConfig.PinnedVersionID != 0 && candidates.ForVersion(inputConfig.PinnedVersionID).IsEmpty() {
+		explanation.BlockedBy = BlockerPinned
+	}
+
+	return explanation
+}
+
+func (set JobSet) ids() []int {
+	ids := []int{}
+	for jobID := range set {
+		ids = append(ids, jobID)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
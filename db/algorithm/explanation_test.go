@@ -0,0 +1,171 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var versionsDB *algorithm.VersionsDB
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 22, CheckOrder: 1},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         100,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         101,
+					JobID:           12,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12, "j3": 13},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22, "r3": 23},
+		}
+	})
+
+	It("gives the versions which would be used when the inputs can be satisfied", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 21, JobID: 13},
+			{Name: "b", ResourceID: 21, JobID: 13, Passed: algorithm.JobSet{11: struct{}{}}},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", VersionID: 2},
+			{Name: "b", VersionID: 1},
+		}))
+	})
+
+	It("is blocked by versions when the resource has none", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 23, JobID: 13},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", BlockedBy: algorithm.BlockerVersions},
+		}))
+	})
+
+	It("is blocked by pinned when the pinned version is not available", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 21, JobID: 13, PinnedVersionID: 3},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", BlockedBy: algorithm.BlockerPinned},
+		}))
+	})
+
+	It("is blocked by pinned when the pinned version has not passed", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 21, JobID: 13, PinnedVersionID: 2, Passed: algorithm.JobSet{11: struct{}{}}},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", BlockedBy: algorithm.BlockerPinned},
+		}))
+	})
+
+	It("is blocked by the passed jobs which have not output any version", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 21, JobID: 13, Passed: algorithm.JobSet{11: struct{}{}, 13: struct{}{}}},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", BlockedBy: algorithm.BlockerPassed, BlockingJobIDs: []int{13}},
+		}))
+	})
+
+	It("is blocked by all of the passed jobs when they have no version in common", func() {
+		explanations := algorithm.InputConfigs{
+			{Name: "a", ResourceID: 21, JobID: 13, Passed: algorithm.JobSet{11: struct{}{}, 12: struct{}{}}},
+			{Name: "b", ResourceID: 22, JobID: 13},
+		}.Explain(versionsDB)
+
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{Name: "a", BlockedBy: algorithm.BlockerPassed, BlockingJobIDs: []int{11, 12}},
+			{Name: "b"},
+		}))
+	})
+
+	Context("when the inputs cannot be satisfied together", func() {
+		It("is blocked by the passed jobs of the inputs which conflict", func() {
+			versionsDB.ResourceVersions = append(versionsDB.ResourceVersions, algorithm.ResourceVersion{VersionID: 4, ResourceID: 22, CheckOrder: 2})
+			versionsDB.BuildOutputs = append(versionsDB.BuildOutputs, algorithm.BuildOutput{
+				ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 22, CheckOrder: 2},
+				BuildID:         102,
+				JobID:           11,
+			})
+
+			explanations := algorithm.InputConfigs{
+				{Name: "a", ResourceID: 21, JobID: 13, Passed: algorithm.JobSet{11: struct{}{}}},
+				{Name: "b", ResourceID: 22, JobID: 13, Passed: algorithm.JobSet{11: struct{}{}}},
+				{Name: "c", ResourceID: 21, JobID: 13, Passed: algorithm.JobSet{12: struct{}{}}},
+			}.Explain(versionsDB)
+
+			Expect(explanations).To(Equal([]algorithm.InputExplanation{
+				{Name: "a", BlockedBy: algorithm.BlockerPassed, BlockingJobIDs: []int{11}},
+				{Name: "b", BlockedBy: algorithm.BlockerPassed, BlockingJobIDs: []int{11}},
+				{Name: "c"},
+			}))
+		})
+
+		It("is ambiguous when no two inputs conflict on their own", func() {
+			version := func(versionID int, resourceID int) algorithm.ResourceVersion {
+				return algorithm.ResourceVersion{VersionID: versionID, ResourceID: resourceID, CheckOrder: versionID}
+			}
+
+			output := func(versionID int, resourceID int, buildID int, jobID int) algorithm.BuildOutput {
+				return algorithm.BuildOutput{
+					ResourceVersion: version(versionID, resourceID),
+					BuildID:         buildID,
+					JobID:           jobID,
+				}
+			}
+
+			// each pair of inputs shares one job, whose builds each agree on
+			// a pair of versions, but going round all three jobs always
+			// leads back to the other version of the first input
+			versionsDB = &algorithm.VersionsDB{
+				ResourceVersions: []algorithm.ResourceVersion{
+					version(1, 31), version(2, 31),
+					version(3, 32), version(4, 32),
+					version(5, 33), version(6, 33),
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					output(1, 31, 401, 41), output(5, 33, 401, 41),
+					output(2, 31, 402, 41), output(6, 33, 402, 41),
+					output(1, 31, 411, 42), output(3, 32, 411, 42),
+					output(2, 31, 412, 42), output(4, 32, 412, 42),
+					output(3, 32, 421, 43), output(6, 33, 421, 43),
+					output(4, 32, 422, 43), output(5, 33, 422, 43),
+				},
+				BuildInputs: []algorithm.BuildInput{},
+				JobIDs:      map[string]int{"x": 41, "y": 42, "z": 43, "j": 44},
+				ResourceIDs: map[string]int{"ra": 31, "rb": 32, "rc": 33},
+			}
+
+			explanations := algorithm.InputConfigs{
+				{Name: "a", ResourceID: 31, JobID: 44, Passed: algorithm.JobSet{41: struct{}{}, 42: struct{}{}}},
+				{Name: "b", ResourceID: 32, JobID: 44, Passed: algorithm.JobSet{42: struct{}{}, 43: struct{}{}}},
+				{Name: "c", ResourceID: 33, JobID: 44, Passed: algorithm.JobSet{41: struct{}{}, 43: struct{}{}}},
+			}.Explain(versionsDB)
+
+			Expect(explanations).To(Equal([]algorithm.InputExplanation{
+				{Name: "a", BlockedBy: algorithm.BlockerAmbiguous, BlockingJobIDs: []int{41, 42}},
+				{Name: "b", BlockedBy: algorithm.BlockerAmbiguous, BlockingJobIDs: []int{42, 43}},
+				{Name: "c", BlockedBy: algorithm.BlockerAmbiguous, BlockingJobIDs: []int{41, 43}},
+			}))
+		})
+	})
+})
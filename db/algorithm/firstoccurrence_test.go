@@ -130,6 +130,74 @@ var _ = Describe("Resolve", func() {
 		})
 	})
 
+	Context("when the input reruns on upstream reruns", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:                 "some-input",
+					JobName:              "j2",
+					Passed:               algorithm.JobSet{11: struct{}{}},
+					ResourceID:           21,
+					JobID:                12,
+					RerunOnUpstreamRerun: true,
+				},
+			}
+
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         31,
+					JobID:           11,
+				},
+			}
+
+			versionsDB.BuildInputs = []algorithm.BuildInput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         32,
+					JobID:           12,
+					InputName:       "some-input",
+				},
+			}
+		})
+
+		Context("when the upstream job has not run again since the version was used", func() {
+			It("sets FirstOccurrence to false", func() {
+				Expect(inputMapping).To(Equal(algorithm.InputMapping{
+					"some-input": algorithm.InputVersion{VersionID: 2, FirstOccurrence: false},
+				}))
+			})
+		})
+
+		Context("when the upstream job has output the same version again", func() {
+			BeforeEach(func() {
+				versionsDB.BuildOutputs = append(versionsDB.BuildOutputs, algorithm.BuildOutput{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				})
+			})
+
+			It("sets FirstOccurrence to true", func() {
+				Expect(inputMapping).To(Equal(algorithm.InputMapping{
+					"some-input": algorithm.InputVersion{VersionID: 2, FirstOccurrence: true},
+				}))
+			})
+
+			Context("when the input is not configured to rerun", func() {
+				BeforeEach(func() {
+					inputConfigs[0].RerunOnUpstreamRerun = false
+				})
+
+				It("sets FirstOccurrence to false", func() {
+					Expect(inputMapping).To(Equal(algorithm.InputMapping{
+						"some-input": algorithm.InputVersion{VersionID: 2, FirstOccurrence: false},
+					}))
+				})
+			})
+		})
+	})
+
 	Context("when a different version was an output of the same job", func() {
 		BeforeEach(func() {
 			versionsDB.BuildOutputs = []algorithm.BuildOutput{
@@ -3,13 +3,14 @@ package algorithm
 type InputConfigs []InputConfig
 
 type InputConfig struct {
-	Name            string
-	JobName         string
-	Passed          JobSet
-	UseEveryVersion bool
-	PinnedVersionID int
-	ResourceID      int
-	JobID           int
+	Name                 string
+	JobName              string
+	Passed               JobSet
+	UseEveryVersion      bool
+	PinnedVersionID      int
+	ResourceID           int
+	JobID                int
+	RerunOnUpstreamRerun bool
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
@@ -79,6 +80,10 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputName := inputConfig.Name
 		inputVersionID := basicMapping[inputName]
 		firstOccurrence := db.IsVersionFirstOccurrence(inputVersionID, inputConfig.JobID, inputName)
+		if !firstOccurrence && inputConfig.RerunOnUpstreamRerun {
+			firstOccurrence = db.IsVersionRerunUpstream(inputVersionID, inputConfig.JobID, inputName, inputConfig.Passed)
+		}
+
 		mapping[inputName] = InputVersion{
 			VersionID:       inputVersionID,
 			FirstOccurrence: firstOccurrence,
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -28,7 +29,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by, b.error, b.trigger_reason, b.abort_reason, b.aborted_by").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -54,8 +55,12 @@ type Build interface {
 	ReapTime() time.Time
 	Tracker() string
 	IsManuallyTriggered() bool
+	TriggerReason() string
+	AbortReason() string
+	AbortedBy() string
 	IsScheduled() bool
 	IsRunning() bool
+	Error() *atc.BuildError
 
 	Reload() (bool, error)
 
@@ -71,8 +76,21 @@ type Build interface {
 
 	SetInterceptible(bool) error
 
+	// RetainContainers records until when the containers of the finished
+	// build are kept around for hijacking, according to its team's container
+	// retention within the policy.
+	RetainContainers(ContainerRetentionPolicy) error
+
+	// SaveBuildTokenAccessor records a token issued to the build, so that it
+	// can be revoked once the build finishes, even if the build was resumed
+	// by another ATC.
+	SaveBuildTokenAccessor(accessor string) error
+	BuildTokenAccessors() ([]string, error)
+	DeleteBuildTokenAccessor(accessor string) error
+
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
+	SaveError(buildErr atc.BuildError, origin event.Origin) error
 
 	SaveInput(input BuildInput) error
 	SaveOutput(vr VersionedResource) error
@@ -86,6 +104,7 @@ type Build interface {
 
 	Delete() (bool, error)
 	MarkAsAborted() error
+	SaveAbortReason(reason string, abortedBy string) error
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
 }
@@ -105,6 +124,10 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	triggerReason       string
+
+	abortReason string
+	abortedBy   string
 
 	engine         string
 	engineMetadata string
@@ -116,6 +139,8 @@ type build struct {
 
 	trackedBy string
 
+	buildErr *atc.BuildError
+
 	conn        Conn
 	lockFactory lock.LockFactory
 }
@@ -131,6 +156,9 @@ func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
 func (b *build) TeamName() string             { return b.teamName }
 func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
+func (b *build) TriggerReason() string        { return b.triggerReason }
+func (b *build) AbortReason() string          { return b.abortReason }
+func (b *build) AbortedBy() string            { return b.abortedBy }
 func (b *build) Engine() string               { return b.engine }
 func (b *build) EngineMetadata() string       { return b.engineMetadata }
 func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
@@ -138,6 +166,7 @@ func (b *build) StartTime() time.Time         { return b.startTime }
 func (b *build) EndTime() time.Time           { return b.endTime }
 func (b *build) ReapTime() time.Time          { return b.reapTime }
 func (b *build) Status() BuildStatus          { return b.status }
+func (b *build) Error() *atc.BuildError       { return b.buildErr }
 func (b *build) Tracker() string              { return b.trackedBy }
 func (b *build) IsScheduled() bool            { return b.scheduled }
 
@@ -274,9 +303,10 @@ func (b *build) Start(engine, metadata string, plan atc.Plan) (bool, error) {
 }
 
 func (b *build) FinishWithError(cause error) error {
-	err := b.SaveEvent(event.Error{
+	err := b.SaveError(atc.BuildError{
+		Code:    atc.BuildErrorCodeInternal,
 		Message: cause.Error(),
-	})
+	}, event.Origin{})
 	if err != nil {
 		return err
 	}
@@ -292,7 +322,10 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
-	var endTime time.Time
+	var (
+		endTime                time.Time
+		abortReason, abortedBy sql.NullString
+	)
 
 	err = psql.Update("builds").
 		Set("status", status).
@@ -301,18 +334,25 @@ func (b *build) Finish(status BuildStatus) error {
 		Set("engine_metadata", nil).
 		Set("nonce", nil).
 		Where(sq.Eq{"id": b.id}).
-		Suffix("RETURNING end_time").
+		Suffix("RETURNING end_time, abort_reason, aborted_by").
 		RunWith(tx).
 		QueryRow().
-		Scan(&endTime)
+		Scan(&endTime, &abortReason, &abortedBy)
 	if err != nil {
 		return err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	statusEvent := event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
-	})
+	}
+
+	if status == BuildStatusAborted {
+		statusEvent.AbortReason = abortReason.String
+		statusEvent.AbortedBy = abortedBy.String
+	}
+
+	err = b.saveEvent(tx, statusEvent)
 	if err != nil {
 		return err
 	}
@@ -347,6 +387,11 @@ func (b *build) Finish(status BuildStatus) error {
 			return err
 		}
 
+		err = queueTransitionWebhookDeliveries(tx, b.teamID, b.id, b.jobID, statusEvent)
+		if err != nil {
+			return err
+		}
+
 		err = updateLatestCompletedBuildForJob(tx, b.jobID)
 		if err != nil {
 			return err
@@ -413,6 +458,102 @@ func (b *build) MarkAsAborted() error {
 	return b.conn.Bus().Notify(buildAbortChannel(b.id))
 }
 
+func (b *build) RetainContainers(policy ContainerRetentionPolicy) error {
+	retentionInterval, retentionArgs := policy.retentionInterval()
+
+	_, err := psql.Update("builds b").
+		Set("containers_expire_at", sq.Expr("b.end_time + "+retentionInterval, retentionArgs...)).
+		Where(sq.Eq{"b.id": b.id}).
+		Where(sq.NotEq{"b.end_time": nil}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) SaveBuildTokenAccessor(accessor string) error {
+	_, err := psql.Insert("build_token_accessors").
+		Columns("build_id", "accessor").
+		Values(b.id, accessor).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) BuildTokenAccessors() ([]string, error) {
+	rows, err := psql.Select("accessor").
+		From("build_token_accessors").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("accessor").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	accessors := []string{}
+	for rows.Next() {
+		var accessor string
+		err = rows.Scan(&accessor)
+		if err != nil {
+			return nil, err
+		}
+
+		accessors = append(accessors, accessor)
+	}
+
+	return accessors, nil
+}
+
+func (b *build) DeleteBuildTokenAccessor(accessor string) error {
+	_, err := psql.Delete("build_token_accessors").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"accessor": accessor,
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// SaveAbortReason records why the build is being aborted, and by whom. It
+// should be called before the build is aborted so that the reason can be
+// included in the build's final status event.
+//
+// Nothing is recorded if the build has already finished or was already
+// aborted by someone else, so that the first abort's reason stands.
+func (b *build) SaveAbortReason(reason string, abortedBy string) error {
+	result, err := psql.Update("builds").
+		Set("abort_reason", sql.NullString{String: reason, Valid: reason != ""}).
+		Set("aborted_by", sql.NullString{String: abortedBy, Valid: abortedBy != ""}).
+		Where(sq.Eq{
+			"id":         b.id,
+			"status":     []string{string(BuildStatusPending), string(BuildStatusStarted)},
+			"aborted_by": nil,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	b.abortReason = reason
+	b.abortedBy = abortedBy
+
+	return nil
+}
+
 // AbortNotifier returns a Notifier that can be watched for when the build
 // is marked as aborted. Once the build is marked as aborted it will send a
 // notification to finish the build to ATC that is tracking this build.
@@ -537,6 +678,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
+			SkippedVersions:     SkippedVersions{},
 		}, true, nil
 	}
 
@@ -605,7 +747,18 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
 	configInputs := job.Config().Inputs()
+	for i, configInput := range configInputs {
+		resource, found := resources.Lookup(configInput.Resource)
+		if found && len(resource.PinnedVersion()) != 0 {
+			configInputs[i].Version = &atc.VersionConfig{Pinned: resource.PinnedVersion()}
+		}
+	}
 
 	nextBuildInputs, found, err := job.GetNextBuildInputs()
 	if err != nil {
@@ -675,6 +828,26 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		}
 	}
 
+	skippedVersions := SkippedVersions{}
+	for _, configInput := range configInputs {
+		if len(configInput.Passed) == 0 {
+			continue
+		}
+
+		if configInput.Version != nil && configInput.Version.Pinned != nil {
+			continue
+		}
+
+		skippedVersion, found, err := newestSkippedVersion(pipeline, configInput, nextBuildInputs)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
+		if found {
+			skippedVersions[configInput.Name] = skippedVersion
+		}
+	}
+
 	buildPreparation := BuildPreparation{
 		BuildID:             b.id,
 		PausedPipeline:      pausedPipelineStatus,
@@ -683,11 +856,56 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+		SkippedVersions:     skippedVersions,
 	}
 
 	return buildPreparation, true, nil
 }
 
+// newestSkippedVersion finds the newest version of the input's resource, if
+// it is not the version the next build would use, along with which of the
+// input's passed jobs it has not yet succeeded in.
+func newestSkippedVersion(pipeline Pipeline, configInput atc.JobInput, nextBuildInputs []BuildInput) (SkippedVersion, bool, error) {
+	newest, found, err := pipeline.GetLatestVersionedResource(configInput.Resource)
+	if err != nil {
+		return SkippedVersion{}, false, err
+	}
+
+	if !found || !newest.Enabled {
+		return SkippedVersion{}, false, nil
+	}
+
+	for _, buildInput := range nextBuildInputs {
+		if buildInput.Name == configInput.Name && reflect.DeepEqual(buildInput.Version, newest.Version) {
+			return SkippedVersion{}, false, nil
+		}
+	}
+
+	builds, err := pipeline.GetBuildsWithVersionAsOutput(newest.ID)
+	if err != nil {
+		return SkippedVersion{}, false, err
+	}
+
+	passed := map[string]bool{}
+	for _, build := range builds {
+		if build.Status() == BuildStatusSucceeded {
+			passed[build.JobName()] = true
+		}
+	}
+
+	notPassed := []string{}
+	for _, jobName := range configInput.Passed {
+		if !passed[jobName] {
+			notPassed = append(notPassed, jobName)
+		}
+	}
+
+	return SkippedVersion{
+		Version:   newest.Version,
+		NotPassed: notPassed,
+	}, true, nil
+}
+
 func (b *build) Events(from uint) (EventSource, error) {
 	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
@@ -731,6 +949,54 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+// SaveError saves an error event for the build and records the error on the
+// build itself. Only the first error is recorded, as subsequent errors are
+// usually a consequence of it.
+func (b *build) SaveError(buildErr atc.BuildError, origin event.Origin) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = b.saveEvent(tx, event.Error{
+		Message: buildErr.Message,
+		Code:    buildErr.Code,
+		Step:    buildErr.Step,
+		Worker:  buildErr.Worker,
+		Origin:  origin,
+	})
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(buildErr)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("error", sq.Expr("COALESCE(error, ?)", string(payload))).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if b.buildErr == nil {
+		b.buildErr = &buildErr
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
 func (b *build) SaveInput(input BuildInput) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1003,12 +1269,12 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, pipelineID                                                    sql.NullInt64
 		engine, engineMetadata, jobName, pipelineName, publicPlan, trackedBy sql.NullString
 		startTime, endTime, reapTime                                         pq.NullTime
-		nonce                                                                sql.NullString
+		nonce, buildErr, triggerReason, abortReason, abortedBy               sql.NullString
 
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy, &buildErr, &triggerReason, &abortReason, &abortedBy)
 	if err != nil {
 		return err
 	}
@@ -1023,6 +1289,9 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 	b.trackedBy = trackedBy.String
+	b.triggerReason = triggerReason.String
+	b.abortReason = abortReason.String
+	b.abortedBy = abortedBy.String
 
 	var (
 		noncense                *string
@@ -1046,6 +1315,14 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	b.buildErr = nil
+	if buildErr.Valid {
+		err = json.Unmarshal([]byte(buildErr.String), &b.buildErr)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1064,7 +1341,11 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
 		RunWith(tx).
 		Exec()
-	return err
+	if err != nil {
+		return err
+	}
+
+	return queueWebhookDeliveries(tx, b.teamID, b.id, string(event.EventType()), string(event.Version()), payload)
 }
 
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
@@ -13,11 +13,11 @@ import (
 
 type BuildFactory interface {
 	Build(int) (Build, bool, error)
-	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
+	VisibleBuilds([]string, BuildFilter, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
-	MarkNonInterceptibleBuilds() error
+	MarkNonInterceptibleBuilds(ContainerRetentionPolicy) error
 }
 
 type buildFactory struct {
@@ -56,21 +56,30 @@ func (f *buildFactory) Build(buildID int) (Build, bool, error) {
 	return build, true, nil
 }
 
-func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pagination, error) {
+// VisibleBuilds returns the builds of the given teams and of public
+// pipelines which match the filter.
+func (f *buildFactory) VisibleBuilds(teamNames []string, filter BuildFilter, page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
 		})
 
-	return getBuildsWithPagination(newBuildsQuery, page, f.conn, f.lockFactory)
+	return getBuildsWithPagination(filter.filterBuilds(newBuildsQuery), page, f.conn, f.lockFactory)
 }
 
 func (f *buildFactory) PublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"p.public": true}), page, f.conn, f.lockFactory)
 }
 
-func (f *buildFactory) MarkNonInterceptibleBuilds() error {
+// MarkNonInterceptibleBuilds marks the finished builds whose containers are
+// no longer retained as non-interceptible, so that their containers can be
+// collected. Builds which recorded when their containers expire as they
+// finished keep them until then; for the others, the retention is worked out
+// from the policy.
+func (f *buildFactory) MarkNonInterceptibleBuilds(policy ContainerRetentionPolicy) error {
+	retentionInterval, retentionArgs := policy.retentionInterval()
+
 	_, err := psql.Update("builds b").
 		Set("interceptible", false).
 		Where(sq.Eq{
@@ -85,6 +94,11 @@ func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 			sq.Expr("NOT EXISTS (SELECT 1 FROM jobs j WHERE j.latest_completed_build_id = b.id)"),
 			sq.Eq{"status": string(BuildStatusSucceeded)},
 		}).
+		Where(sq.Or{
+			sq.Eq{"end_time": nil},
+			sq.Expr("now() >= containers_expire_at"),
+			sq.Expr("containers_expire_at IS NULL AND now() - end_time >= "+retentionInterval, retentionArgs...),
+		}).
 		RunWith(f.conn).
 		Exec()
 	return err
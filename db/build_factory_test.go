@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/atc/db"
 
 	"github.com/concourse/atc"
@@ -52,7 +54,7 @@ var _ = Describe("BuildFactory", func() {
 					err = b.Finish(status)
 					Expect(err).NotTo(HaveOccurred())
 
-					err = buildFactory.MarkNonInterceptibleBuilds()
+					err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 					Expect(err).NotTo(HaveOccurred())
 
 					i, err = b.Interceptible()
@@ -75,7 +77,7 @@ var _ = Describe("BuildFactory", func() {
 					err = b.Finish(status)
 					Expect(err).NotTo(HaveOccurred())
 
-					err = buildFactory.MarkNonInterceptibleBuilds()
+					err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 					Expect(err).NotTo(HaveOccurred())
 
 					i, err = b.Interceptible()
@@ -93,7 +95,7 @@ var _ = Describe("BuildFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
-				err = buildFactory.MarkNonInterceptibleBuilds()
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 				Expect(err).NotTo(HaveOccurred())
 				i, err = b.Interceptible()
 				Expect(err).NotTo(HaveOccurred())
@@ -139,7 +141,7 @@ var _ = Describe("BuildFactory", func() {
 				err = pb2.Finish(db.BuildStatusErrored)
 				Expect(err).NotTo(HaveOccurred())
 
-				err = buildFactory.MarkNonInterceptibleBuilds()
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
@@ -171,7 +173,7 @@ var _ = Describe("BuildFactory", func() {
 					err = b.Finish(status)
 					Expect(err).NotTo(HaveOccurred())
 
-					err = buildFactory.MarkNonInterceptibleBuilds()
+					err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 					Expect(err).NotTo(HaveOccurred())
 					i, err = b.Interceptible()
 					Expect(err).NotTo(HaveOccurred())
@@ -192,7 +194,7 @@ var _ = Describe("BuildFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(i).To(BeTrue())
 
-				err = buildFactory.MarkNonInterceptibleBuilds()
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 				Expect(err).NotTo(HaveOccurred())
 				i, err = b.Interceptible()
 				Expect(err).NotTo(HaveOccurred())
@@ -201,13 +203,120 @@ var _ = Describe("BuildFactory", func() {
 				_, err = b.Start("exec.v2", `{"so":"meta"}`, atc.Plan{})
 				Expect(err).NotTo(HaveOccurred())
 
-				err = buildFactory.MarkNonInterceptibleBuilds()
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
 				Expect(err).NotTo(HaveOccurred())
 				i, err = b.Interceptible()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(i).To(BeTrue())
 			})
 		})
+
+		Context("with container retention", func() {
+			var (
+				succeededBuild   db.Build
+				olderFailedBuild db.Build
+				failedBuild      db.Build
+			)
+
+			BeforeEach(func() {
+				var err error
+				succeededBuild, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(succeededBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+				olderFailedBuild, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(olderFailedBuild.Finish(db.BuildStatusFailed)).To(Succeed())
+
+				failedBuild, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedBuild.Finish(db.BuildStatusFailed)).To(Succeed())
+			})
+
+			interceptible := func(build db.Build) bool {
+				i, err := build.Interceptible()
+				Expect(err).NotTo(HaveOccurred())
+				return i
+			}
+
+			It("keeps builds within the default retention interceptible", func() {
+				err := buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{
+					Default: db.ContainerRetention{Success: time.Hour},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(interceptible(succeededBuild)).To(BeTrue())
+				Expect(interceptible(olderFailedBuild)).To(BeFalse())
+				Expect(interceptible(failedBuild)).To(BeTrue())
+			})
+
+			It("prefers the team's retention over the default", func() {
+				err := defaultTeam.UpdateContainerRetention(db.ContainerRetention{
+					Failure: time.Hour,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{
+					Default: db.ContainerRetention{Success: time.Hour},
+					Max:     db.ContainerRetention{Failure: time.Hour},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(interceptible(succeededBuild)).To(BeTrue())
+				Expect(interceptible(olderFailedBuild)).To(BeTrue())
+				Expect(interceptible(failedBuild)).To(BeTrue())
+			})
+
+			It("bounds the team's retention by the maximum", func() {
+				err := defaultTeam.UpdateContainerRetention(db.ContainerRetention{
+					Success: time.Hour,
+					Failure: time.Hour,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{
+					Max: db.ContainerRetention{Success: time.Hour},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(interceptible(succeededBuild)).To(BeTrue())
+				Expect(interceptible(olderFailedBuild)).To(BeFalse())
+				Expect(interceptible(failedBuild)).To(BeTrue())
+			})
+
+			Context("when the builds recorded the retention of their containers", func() {
+				BeforeEach(func() {
+					policy := db.ContainerRetentionPolicy{
+						Default: db.ContainerRetention{Success: time.Hour, Failure: time.Hour},
+					}
+
+					Expect(succeededBuild.RetainContainers(policy)).To(Succeed())
+					Expect(olderFailedBuild.RetainContainers(policy)).To(Succeed())
+				})
+
+				It("keeps their containers until then, regardless of later changes to the retention", func() {
+					err := buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(interceptible(succeededBuild)).To(BeTrue())
+					Expect(interceptible(olderFailedBuild)).To(BeTrue())
+				})
+			})
+
+			It("does not apply the team's retention to other teams", func() {
+				err := team.UpdateContainerRetention(db.ContainerRetention{
+					Success: time.Hour,
+					Failure: time.Hour,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = buildFactory.MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(interceptible(succeededBuild)).To(BeFalse())
+				Expect(interceptible(olderFailedBuild)).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("VisibleBuilds", func() {
@@ -222,7 +331,12 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+
+			privateConfig := atc.Config{
+				Labels: map[string]string{"team": "payments"},
+				Jobs:   atc.JobConfigs{{Name: "some-job", Labels: map[string]string{"tier": "critical"}}},
+			}
+			privatePipeline, _, err := team.SavePipeline("private-pipeline", privateConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -252,13 +366,56 @@ var _ = Describe("BuildFactory", func() {
 		})
 
 		It("returns visible builds for the given teams", func() {
-			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.Page{Limit: 10})
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.BuildFilter{}, db.Page{Limit: 10})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(builds).To(HaveLen(3))
 			Expect(builds).To(ConsistOf(build1, build2, build3))
 			Expect(builds).NotTo(ContainElement(build4))
 		})
+
+		It("returns only builds of jobs with the given pipeline and job labels", func() {
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.BuildFilter{Labels: map[string]string{"team": "payments", "tier": "critical"}}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(ConsistOf(build2))
+
+			builds, _, err = buildFactory.VisibleBuilds([]string{"some-team"}, db.BuildFilter{Labels: map[string]string{"team": "billing"}}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		It("returns only builds of the given teams", func() {
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team", "some-other-team"}, db.BuildFilter{TeamNames: []string{"some-other-team"}}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(ConsistOf(build4))
+		})
+
+		It("returns only builds with the given statuses", func() {
+			err := build2.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build3.Finish(db.BuildStatusErrored)
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.BuildFilter{Statuses: []db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored}}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(ConsistOf(build2, build3))
+		})
+
+		It("returns only builds started within the given time range", func() {
+			_, err := dbConn.Exec(`UPDATE builds SET start_time = now() - '2 hours'::interval WHERE id = $1`, build1.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE builds SET start_time = now() - '1 hour'::interval WHERE id = $1`, build2.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.BuildFilter{
+				StartedSince: time.Now().Add(-90 * time.Minute),
+				StartedUntil: time.Now(),
+			}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(ConsistOf(build2))
+		})
 	})
 
 	Describe("PublicBuilds", func() {
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// BuildFilter narrows down the builds listed across the deployment, e.g. for
+// reporting on failure rates. Zero values do not filter anything.
+//
+// Builds match a time range by their start time, so builds which have not
+// yet started never match one.
+type BuildFilter struct {
+	TeamNames []string
+	Statuses  []BuildStatus
+	Labels    map[string]string
+
+	StartedSince time.Time // inclusive
+	StartedUntil time.Time // exclusive
+}
+
+type ErrUnknownBuildStatus struct {
+	Status string
+}
+
+func (err ErrUnknownBuildStatus) Error() string {
+	return fmt.Sprintf("unknown build status '%s'", err.Status)
+}
+
+func (filter BuildFilter) Validate() error {
+	for _, status := range filter.Statuses {
+		switch status {
+		case BuildStatusPending, BuildStatusStarted, BuildStatusAborted,
+			BuildStatusSucceeded, BuildStatusFailed, BuildStatusErrored:
+		default:
+			return ErrUnknownBuildStatus{Status: string(status)}
+		}
+	}
+
+	return nil
+}
+
+// filterBuilds narrows down a query over builds b, jobs j, pipelines p and
+// teams t.
+func (filter BuildFilter) filterBuilds(query sq.SelectBuilder) sq.SelectBuilder {
+	if len(filter.TeamNames) > 0 {
+		query = query.Where(sq.Eq{"t.name": filter.TeamNames})
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+
+		query = query.Where(sq.Eq{"b.status": statuses})
+	}
+
+	if !filter.StartedSince.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": filter.StartedSince})
+	}
+
+	if !filter.StartedUntil.IsZero() {
+		query = query.Where(sq.Lt{"b.start_time": filter.StartedUntil})
+	}
+
+	if len(filter.Labels) > 0 {
+		query = query.Where(hasLabels("(p.labels || j.labels)", filter.Labels))
+	}
+
+	return query
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// BuildInputUpload tracks an input being uploaded to a build in chunks. The
+// chunks are kept in a volume on the upload's worker until all of them have
+// arrived.
+type BuildInputUpload struct {
+	ID         int
+	BuildID    int
+	PlanID     atc.PlanID
+	WorkerName string
+
+	// Received is how many bytes of the input have been stored, in as many
+	// chunks as Chunks.
+	Received int64
+	Chunks   int
+}
+
+//go:generate counterfeiter . BuildInputUploadFactory
+
+type BuildInputUploadFactory interface {
+	Find(buildID int, planID atc.PlanID) (*BuildInputUpload, bool, error)
+	FindOrCreate(buildID int, planID atc.PlanID, workerName string) (*BuildInputUpload, error)
+
+	// RecordChunk records a chunk of the given size as having been stored
+	// after those already received. It returns false if another chunk was
+	// recorded first.
+	RecordChunk(upload *BuildInputUpload, size int64) (bool, error)
+
+	Remove(upload *BuildInputUpload) error
+
+	// RemoveFinished removes the uploads to builds which have finished,
+	// returning how many were removed. Their volumes are then left to be
+	// collected.
+	RemoveFinished() (int, error)
+}
+
+type buildInputUploadFactory struct {
+	conn Conn
+}
+
+func NewBuildInputUploadFactory(conn Conn) BuildInputUploadFactory {
+	return &buildInputUploadFactory{
+		conn: conn,
+	}
+}
+
+var buildInputUploadColumns = []string{
+	"id",
+	"build_id",
+	"plan_id",
+	"worker_name",
+	"received",
+	"chunks",
+}
+
+func (f *buildInputUploadFactory) Find(buildID int, planID atc.PlanID) (*BuildInputUpload, bool, error) {
+	row := psql.Select(buildInputUploadColumns...).
+		From("build_input_uploads").
+		Where(sq.Eq{
+			"build_id": buildID,
+			"plan_id":  string(planID),
+		}).
+		RunWith(f.conn).
+		QueryRow()
+
+	upload, err := scanBuildInputUpload(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return upload, true, nil
+}
+
+func (f *buildInputUploadFactory) FindOrCreate(buildID int, planID atc.PlanID, workerName string) (*BuildInputUpload, error) {
+	row := psql.Insert("build_input_uploads").
+		Columns("build_id", "plan_id", "worker_name").
+		Values(buildID, string(planID), workerName).
+		Suffix(`
+			ON CONFLICT (build_id, plan_id) DO UPDATE SET
+				plan_id = EXCLUDED.plan_id
+			RETURNING id, build_id, plan_id, worker_name, received, chunks
+		`).
+		RunWith(f.conn).
+		QueryRow()
+
+	return scanBuildInputUpload(row)
+}
+
+func (f *buildInputUploadFactory) RecordChunk(upload *BuildInputUpload, size int64) (bool, error) {
+	result, err := psql.Update("build_input_uploads").
+		Set("received", sq.Expr("received + ?", size)).
+		Set("chunks", sq.Expr("chunks + 1")).
+		Where(sq.Eq{
+			"id":     upload.ID,
+			"chunks": upload.Chunks,
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	upload.Received += size
+	upload.Chunks++
+
+	return true, nil
+}
+
+func (f *buildInputUploadFactory) Remove(upload *BuildInputUpload) error {
+	_, err := psql.Delete("build_input_uploads").
+		Where(sq.Eq{"id": upload.ID}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *buildInputUploadFactory) RemoveFinished() (int, error) {
+	result, err := psql.Delete("build_input_uploads biu USING builds b").
+		Where(sq.Expr("b.id = biu.build_id")).
+		Where(sq.Eq{"b.completed": true}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
+func scanBuildInputUpload(row scannable) (*BuildInputUpload, error) {
+	var (
+		upload BuildInputUpload
+		planID string
+	)
+
+	err := row.Scan(
+		&upload.ID,
+		&upload.BuildID,
+		&planID,
+		&upload.WorkerName,
+		&upload.Received,
+		&upload.Chunks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	upload.PlanID = atc.PlanID(planID)
+
+	return &upload, nil
+}
@@ -0,0 +1,137 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildInputUploadFactory", func() {
+	var (
+		uploadFactory db.BuildInputUploadFactory
+		build         db.Build
+	)
+
+	BeforeEach(func() {
+		uploadFactory = db.NewBuildInputUploadFactory(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("FindOrCreate", func() {
+		It("creates the upload once", func() {
+			created, err := uploadFactory.FindOrCreate(build.ID(), "some-plan", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.BuildID).To(Equal(build.ID()))
+			Expect(created.PlanID).To(Equal(atc.PlanID("some-plan")))
+			Expect(created.WorkerName).To(Equal(defaultWorker.Name()))
+			Expect(created.Received).To(BeZero())
+			Expect(created.Chunks).To(BeZero())
+
+			found, err := uploadFactory.FindOrCreate(build.ID(), "some-plan", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.ID).To(Equal(created.ID))
+		})
+	})
+
+	Describe("RecordChunk", func() {
+		var upload *db.BuildInputUpload
+
+		BeforeEach(func() {
+			var err error
+			upload, err = uploadFactory.FindOrCreate(build.ID(), "some-plan", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds the chunk to the upload", func() {
+			recorded, err := uploadFactory.RecordChunk(upload, 1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+			Expect(upload.Received).To(Equal(int64(1024)))
+			Expect(upload.Chunks).To(Equal(1))
+
+			found, exists, err := uploadFactory.Find(build.ID(), "some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(found).To(Equal(upload))
+		})
+
+		Context("when another chunk was recorded first", func() {
+			BeforeEach(func() {
+				other := *upload
+
+				recorded, err := uploadFactory.RecordChunk(&other, 512)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recorded).To(BeTrue())
+			})
+
+			It("does not record the chunk", func() {
+				recorded, err := uploadFactory.RecordChunk(upload, 1024)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recorded).To(BeFalse())
+
+				found, _, err := uploadFactory.Find(build.ID(), "some-plan")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found.Received).To(Equal(int64(512)))
+				Expect(found.Chunks).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RemoveFinished", func() {
+		var upload *db.BuildInputUpload
+
+		BeforeEach(func() {
+			var err error
+			upload, err = uploadFactory.FindOrCreate(build.ID(), "some-plan", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the uploads of running builds", func() {
+			removed, err := uploadFactory.RemoveFinished()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(BeZero())
+
+			_, found, err := uploadFactory.Find(build.ID(), "some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the build has finished", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusAborted)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the upload, orphaning its volume", func() {
+				creatingVolume, err := volumeRepository.CreateBuildInputUploadVolume(defaultTeam.ID(), upload)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				removed, err := uploadFactory.RemoveFinished()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(removed).To(Equal(1))
+
+				_, found, err := uploadFactory.Find(build.ID(), "some-plan")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				orphaned, err := volumeRepository.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				handles := []string{}
+				for _, volume := range orphaned {
+					handles = append(handles, volume.Handle())
+				}
+
+				Expect(handles).To(ContainElement(creatingVolume.Handle()))
+			})
+		})
+	})
+})
@@ -30,6 +30,17 @@ func (mir MissingInputReasons) RegisterPinnedVersionUnavailable(inputName string
 	mir[inputName] = fmt.Sprintf(PinnedVersionUnavailable, version)
 }
 
+// SkippedVersion is the newest version of an input which was not used for the
+// build because of the input's passed constraints. NotPassed lists the jobs
+// it has not yet passed; it is empty when the version has passed each of them
+// but not alongside versions which satisfy the job's other inputs.
+type SkippedVersion struct {
+	Version   ResourceVersion
+	NotPassed []string
+}
+
+type SkippedVersions map[string]SkippedVersion
+
 type BuildPreparation struct {
 	BuildID             int
 	PausedPipeline      BuildPreparationStatus
@@ -38,4 +49,5 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+	SkippedVersions     SkippedVersions
 }
@@ -132,6 +132,39 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("build token accessors", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has none to begin with", func() {
+			Expect(build.BuildTokenAccessors()).To(BeEmpty())
+		})
+
+		It("remembers the saved accessors until they are deleted", func() {
+			Expect(build.SaveBuildTokenAccessor("some-accessor")).To(Succeed())
+			Expect(build.SaveBuildTokenAccessor("other-accessor")).To(Succeed())
+			Expect(build.SaveBuildTokenAccessor("some-accessor")).To(Succeed())
+
+			Expect(build.BuildTokenAccessors()).To(Equal([]string{"other-accessor", "some-accessor"}))
+
+			Expect(build.DeleteBuildTokenAccessor("some-accessor")).To(Succeed())
+			Expect(build.BuildTokenAccessors()).To(Equal([]string{"other-accessor"}))
+		})
+
+		It("keeps each build's accessors apart", func() {
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(otherBuild.SaveBuildTokenAccessor("other-accessor")).To(Succeed())
+			Expect(build.BuildTokenAccessors()).To(BeEmpty())
+		})
+	})
+
 	Describe("Finish", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -194,6 +227,106 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveAbortReason", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveAbortReason("wrong branch", "some-user")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the reason and who aborted the build", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.AbortReason()).To(Equal("wrong branch"))
+			Expect(build.AbortedBy()).To(Equal("some-user"))
+		})
+
+		Context("when the build is aborted again by someone else", func() {
+			It("keeps the first abort's reason", func() {
+				err := build.SaveAbortReason("another reason", "another-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.AbortReason()).To(Equal("wrong branch"))
+				Expect(build.AbortedBy()).To(Equal("some-user"))
+			})
+		})
+
+		Context("when the build has already finished", func() {
+			It("does not record an abort reason", func() {
+				finishedBuild, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = finishedBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = finishedBuild.SaveAbortReason("too late", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := finishedBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(finishedBuild.AbortReason()).To(BeEmpty())
+				Expect(finishedBuild.AbortedBy()).To(BeEmpty())
+			})
+		})
+
+		Context("when the build is finished as aborted", func() {
+			It("includes them in the status event", func() {
+				err := build.MarkAsAborted()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusAborted)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status:      atc.StatusAborted,
+					Time:        build.EndTime().Unix(),
+					AbortReason: "wrong branch",
+					AbortedBy:   "some-user",
+				})))
+			})
+		})
+
+		Context("when the build is finished with another status", func() {
+			It("does not include them in the status event", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				})))
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := team.CreateOneOffBuild()
@@ -606,6 +739,7 @@ var _ = Describe("Build", func() {
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
+				SkippedVersions:     db.SkippedVersions{},
 			}
 		})
 
@@ -864,6 +998,17 @@ var _ = Describe("Build", func() {
 					)
 					Expect(err).NotTo(HaveOccurred())
 
+					err = pipeline.SaveResourceVersions(
+						atc.ResourceConfig{
+							Name: "input3",
+							Type: "some-type",
+						},
+						[]atc.Version{
+							{"version": "v3"},
+						},
+					)
+					Expect(err).NotTo(HaveOccurred())
+
 					err = pipeline.SaveResourceVersions(
 						atc.ResourceConfig{
 							Name: "input6",
@@ -905,6 +1050,12 @@ var _ = Describe("Build", func() {
 						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
 						"input6": db.NoVerionsSatisfiedPassedConstraints,
 					}
+					expectedBuildPrep.SkippedVersions = db.SkippedVersions{
+						"input3": {
+							Version:   db.ResourceVersion{"version": "v3"},
+							NotPassed: []string{"some-upstream-job"},
+						},
+					}
 				})
 
 				It("returns blocking inputs satisfied", func() {
@@ -913,6 +1064,30 @@ var _ = Describe("Build", func() {
 					Expect(found).To(BeTrue())
 					Expect(buildPrep).To(Equal(expectedBuildPrep))
 				})
+
+				Context("when versions are pinned through the API", func() {
+					BeforeEach(func() {
+						for _, name := range []string{"input2", "input3"} {
+							resource, found, err := pipeline.Resource(name)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+
+							err = resource.PinVersion(atc.Version{"version": "api-pinned"})
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						expectedBuildPrep.MissingInputReasons["input2"] = fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"api-pinned"}`)
+						expectedBuildPrep.MissingInputReasons["input3"] = fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"api-pinned"}`)
+						expectedBuildPrep.SkippedVersions = db.SkippedVersions{}
+					})
+
+					It("reports the pinned versions as unavailable", func() {
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
 			})
 		})
 
@@ -1117,6 +1292,7 @@ var _ = Describe("Build", func() {
 
 			Expect(events.Next()).To(Equal(envelope(event.Error{
 				Message: "disaster",
+				Code:    atc.BuildErrorCodeInternal,
 			})))
 		})
 
@@ -1126,6 +1302,65 @@ var _ = Describe("Build", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.Status()).To(Equal(db.BuildStatusErrored))
 		})
+
+		It("records the error on the build", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Error()).To(Equal(&atc.BuildError{
+				Code:    atc.BuildErrorCodeInternal,
+				Message: "disaster",
+			}))
+		})
+	})
+
+	Describe("SaveError", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves an error event and only records the first error", func() {
+			err := build.SaveError(atc.BuildError{
+				Code:    atc.BuildErrorCodeWorkerUnavailable,
+				Message: "worker went away",
+				Step:    "some-task",
+				Worker:  "some-worker",
+			}, event.Origin{ID: "some-id"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveError(atc.BuildError{
+				Code:    atc.BuildErrorCodeUnknown,
+				Message: "something else",
+			}, event.Origin{})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Error{
+				Message: "worker went away",
+				Code:    atc.BuildErrorCodeWorkerUnavailable,
+				Step:    "some-task",
+				Worker:  "some-worker",
+				Origin:  event.Origin{ID: "some-id"},
+			})))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Error()).To(Equal(&atc.BuildError{
+				Code:    atc.BuildErrorCodeWorkerUnavailable,
+				Message: "worker went away",
+				Step:    "some-task",
+				Worker:  "some-worker",
+			}))
+		})
 	})
 })
 
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// NewCertCache returns a cache for the certificates and account keys obtained
+// through ACME. They are stored encrypted in the database, so that every ATC
+// serves the same certificates and they survive restarts.
+func NewCertCache(conn Conn) autocert.Cache {
+	return &certCache{
+		conn: conn,
+	}
+}
+
+type certCache struct {
+	conn Conn
+}
+
+func (c *certCache) Get(ctx context.Context, domain string) ([]byte, error) {
+	var (
+		cert  string
+		nonce sql.NullString
+	)
+
+	err := psql.Select("cert", "nonce").
+		From("cert_cache").
+		Where(sq.Eq{"domain": domain}).
+		RunWith(c.conn).
+		QueryRow().
+		Scan(&cert, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, autocert.ErrCacheMiss
+		}
+
+		return nil, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	return c.conn.EncryptionStrategy().Decrypt(cert, noncense)
+}
+
+func (c *certCache) Put(ctx context.Context, domain string, data []byte) error {
+	encryptedCert, nonce, err := c.conn.EncryptionStrategy().Encrypt(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("cert_cache").
+		Columns("domain", "cert", "nonce").
+		Values(domain, encryptedCert, nonce).
+		Suffix("ON CONFLICT (domain) DO UPDATE SET cert = EXCLUDED.cert, nonce = EXCLUDED.nonce").
+		RunWith(c.conn).
+		Exec()
+	return err
+}
+
+func (c *certCache) Delete(ctx context.Context, domain string) error {
+	_, err := psql.Delete("cert_cache").
+		Where(sq.Eq{"domain": domain}).
+		RunWith(c.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,56 @@
+package db_test
+
+import (
+	"context"
+
+	"github.com/concourse/atc/db"
+	"golang.org/x/crypto/acme/autocert"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CertCache", func() {
+	var certCache autocert.Cache
+
+	BeforeEach(func() {
+		certCache = db.NewCertCache(dbConn)
+	})
+
+	It("misses when nothing has been cached", func() {
+		_, err := certCache.Get(context.Background(), "ci.example.com")
+		Expect(err).To(Equal(autocert.ErrCacheMiss))
+	})
+
+	It("gets what was put", func() {
+		err := certCache.Put(context.Background(), "ci.example.com", []byte("some-cert"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cert, err := certCache.Get(context.Background(), "ci.example.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert).To(Equal([]byte("some-cert")))
+	})
+
+	It("replaces what was previously put", func() {
+		err := certCache.Put(context.Background(), "ci.example.com", []byte("some-cert"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = certCache.Put(context.Background(), "ci.example.com", []byte("some-renewed-cert"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cert, err := certCache.Get(context.Background(), "ci.example.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert).To(Equal([]byte("some-renewed-cert")))
+	})
+
+	It("misses once deleted", func() {
+		err := certCache.Put(context.Background(), "ci.example.com", []byte("some-cert"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = certCache.Delete(context.Background(), "ci.example.com")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = certCache.Get(context.Background(), "ci.example.com")
+		Expect(err).To(Equal(autocert.ErrCacheMiss))
+	})
+})
@@ -1,6 +1,10 @@
 package db
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
 
 type ContainerMetadata struct {
 	Type ContainerType
@@ -11,6 +15,10 @@ type ContainerMetadata struct {
 	WorkingDirectory string
 	User             string
 
+	// Outputs are the paths a task's outputs are mounted at, by the name of
+	// the artifact they are registered as.
+	Outputs ContainerOutputs
+
 	PipelineID int
 	JobID      int
 	BuildID    int
@@ -67,6 +75,10 @@ func (metadata ContainerMetadata) SQLMap() map[string]interface{} {
 		m["meta_process_user"] = metadata.User
 	}
 
+	if len(metadata.Outputs) > 0 {
+		m["meta_outputs"] = metadata.Outputs
+	}
+
 	if metadata.PipelineID != 0 {
 		m["meta_pipeline_id"] = metadata.PipelineID
 	}
@@ -100,6 +112,7 @@ var containerMetadataColumns = []string{
 	"meta_attempt",
 	"meta_working_directory",
 	"meta_process_user",
+	"meta_outputs",
 	"meta_pipeline_id",
 	"meta_job_id",
 	"meta_build_id",
@@ -115,6 +128,7 @@ func (metadata *ContainerMetadata) ScanTargets() []interface{} {
 		&metadata.Attempt,
 		&metadata.WorkingDirectory,
 		&metadata.User,
+		&metadata.Outputs,
 		&metadata.PipelineID,
 		&metadata.JobID,
 		&metadata.BuildID,
@@ -123,3 +137,46 @@ func (metadata *ContainerMetadata) ScanTargets() []interface{} {
 		&metadata.BuildName,
 	}
 }
+
+// ContainerOutputs is stored as a JSON object.
+type ContainerOutputs map[string]string
+
+func (outputs ContainerOutputs) Value() (driver.Value, error) {
+	payload, err := json.Marshal(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
+func (outputs *ContainerOutputs) Scan(src interface{}) error {
+	var payload []byte
+	switch src := src.(type) {
+	case []byte:
+		payload = src
+	case string:
+		payload = []byte(src)
+	case nil:
+		*outputs = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into container outputs", src)
+	}
+
+	var scanned map[string]string
+	err := json.Unmarshal(payload, &scanned)
+	if err != nil {
+		return err
+	}
+
+	// containers without outputs have an empty object, which is left as nil
+	// so that their metadata compares equal to what they were created with
+	if len(scanned) == 0 {
+		*outputs = nil
+	} else {
+		*outputs = scanned
+	}
+
+	return nil
+}
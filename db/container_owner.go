@@ -2,9 +2,11 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/nu7hatch/gouuid"
 )
 
 //go:generate counterfeiter . ContainerOwner
@@ -17,6 +19,13 @@ type ContainerOwner interface {
 	Create(tx Tx, workerName string) (map[string]interface{}, error)
 }
 
+// deterministicContainerOwner is implemented by owners whose containers
+// always have the same handle on a given worker, so that the container can be
+// found in Garden again after the ATC restarts.
+type deterministicContainerOwner interface {
+	containerHandle(workerName string) (string, error)
+}
+
 // NewImageCheckContainerOwner references a container whose image resource this
 // container is checking. When the referenced container transitions to another
 // state, or disappears, the container can be removed.
@@ -107,6 +116,17 @@ func (c buildStepContainerOwner) sqlMap() map[string]interface{} {
 	}
 }
 
+func (c buildStepContainerOwner) containerHandle(workerName string) (string, error) {
+	name := fmt.Sprintf("concourse:build-step:%d:%s:%s", c.BuildID, c.PlanID, workerName)
+
+	handle, err := uuid.NewV5(uuid.NamespaceURL, []byte(name))
+	if err != nil {
+		return "", err
+	}
+
+	return handle.String(), nil
+}
+
 // NewResourceConfigCheckSessionContainerOwner references a resource config and
 // worker base resource type, with an expiry. When the resource config or
 // worker base resource type disappear, or the expiry is reached, the container
@@ -10,6 +10,7 @@ type ContainerRepository interface {
 	FindOrphanedContainers() ([]CreatingContainer, []CreatedContainer, []DestroyingContainer, error)
 	DestroyFailedContainers() (int, error)
 	FindDestroyingContainers(workerName string) ([]string, error)
+	FindAllDestroyingContainers() ([]DestroyingContainer, error)
 	RemoveDestroyingContainers(workerName string, currentHandles []string) (int, error)
 }
 
@@ -59,6 +60,37 @@ func (repository *containerRepository) FindDestroyingContainers(workerName strin
 	return handles, nil
 }
 
+// FindAllDestroyingContainers returns the destroying containers of every
+// worker, leaving out hijacked containers which are being given time to
+// expire.
+func (repository *containerRepository) FindAllDestroyingContainers() ([]DestroyingContainer, error) {
+	rows, err := selectContainers().
+		Where(sq.Eq{
+			"state":        ContainerStateDestroying,
+			"discontinued": false,
+		}).
+		OrderBy("id").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	destroyingContainers := []DestroyingContainer{}
+	for rows.Next() {
+		_, _, destroyingContainer, _, err := scanContainer(rows, repository.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		destroyingContainers = append(destroyingContainers, destroyingContainer)
+	}
+
+	return destroyingContainers, rows.Err()
+}
+
 func (repository *containerRepository) RemoveDestroyingContainers(workerName string, handles []string) (int, error) {
 	rows, err := psql.Delete("containers").
 		Where(
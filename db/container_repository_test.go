@@ -611,6 +611,32 @@ var _ = Describe("ContainerRepository", func() {
 		})
 	})
 
+	Describe("FindAllDestroyingContainers", func() {
+		BeforeEach(func() {
+			for handle, discontinued := range map[string]bool{
+				"some-destroying-handle":   false,
+				"some-discontinued-handle": true,
+			} {
+				_, err := psql.Insert("containers").SetMap(map[string]interface{}{
+					"state":        "destroying",
+					"handle":       handle,
+					"worker_name":  defaultWorker.Name(),
+					"hijacked":     discontinued,
+					"discontinued": discontinued,
+				}).RunWith(dbConn).Exec()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns destroying containers which are not discontinued", func() {
+			destroyingContainers, err := containerRepository.FindAllDestroyingContainers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(destroyingContainers).To(HaveLen(1))
+			Expect(destroyingContainers[0].Handle()).To(Equal("some-destroying-handle"))
+			Expect(destroyingContainers[0].WorkerName()).To(Equal(defaultWorker.Name()))
+		})
+	})
+
 	Describe("RemoveDestroyingContainers", func() {
 		var failedErr error
 		var numDeleted int
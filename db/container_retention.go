@@ -0,0 +1,62 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ContainerRetention is how long the containers of finished builds are kept
+// around for hijacking, in addition to those of each job's latest failed
+// build. Zero durations fall back to the defaults.
+type ContainerRetention struct {
+	Success time.Duration
+	Failure time.Duration
+}
+
+func retentionSeconds(d time.Duration) sql.NullInt64 {
+	if d == 0 {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(d / time.Second), Valid: true}
+}
+
+func scannedRetention(success sql.NullInt64, failure sql.NullInt64) ContainerRetention {
+	return ContainerRetention{
+		Success: time.Duration(success.Int64) * time.Second,
+		Failure: time.Duration(failure.Int64) * time.Second,
+	}
+}
+
+// ContainerRetentionPolicy is the operator's container retention: the default
+// for teams which do not override it, and the maximum to which they may.
+type ContainerRetentionPolicy struct {
+	Default ContainerRetention
+	Max     ContainerRetention
+}
+
+// retentionInterval is the SQL for how long the containers of the build
+// aliased as b are retained: its team's override, bounded by the maximum, or
+// else the default.
+func (policy ContainerRetentionPolicy) retentionInterval() (string, []interface{}) {
+	return `(
+		SELECT CASE WHEN b.status = ?
+			THEN CASE WHEN t.container_retention_success IS NULL
+				THEN ?
+				ELSE LEAST(t.container_retention_success, ?)
+			END
+			ELSE CASE WHEN t.container_retention_failure IS NULL
+				THEN ?
+				ELSE LEAST(t.container_retention_failure, ?)
+			END
+		END * interval '1 second'
+		FROM teams t
+		WHERE t.id = b.team_id
+	)`, []interface{}{
+		string(BuildStatusSucceeded),
+		int(policy.Default.Success.Seconds()),
+		int(policy.Max.Success.Seconds()),
+		int(policy.Default.Failure.Seconds()),
+		int(policy.Max.Failure.Seconds()),
+	}
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	// DashboardStatusFailing matches jobs whose latest completed build either
+	// failed or errored.
+	DashboardStatusFailing = "failing"
+	DashboardStatusPaused  = "paused"
+)
+
+// DashboardFilter narrows down the jobs and pipelines listed on the
+// dashboard. Zero values do not filter anything.
+//
+// Pipelines match a status if any of their active jobs match it, and jobs
+// match labels set on either the job or its pipeline.
+type DashboardFilter struct {
+	TeamName string
+	Status   string
+	Search   string
+	Labels   map[string]string
+}
+
+type ErrUnknownDashboardStatus struct {
+	Status string
+}
+
+func (err ErrUnknownDashboardStatus) Error() string {
+	return fmt.Sprintf("unknown status '%s'", err.Status)
+}
+
+func (filter DashboardFilter) Validate() error {
+	if _, ok := filter.buildStatuses(); ok {
+		return nil
+	}
+
+	switch filter.Status {
+	case "", DashboardStatusPaused:
+		return nil
+	}
+
+	return ErrUnknownDashboardStatus{Status: filter.Status}
+}
+
+func (filter DashboardFilter) buildStatuses() ([]string, bool) {
+	switch filter.Status {
+	case DashboardStatusFailing:
+		return []string{string(BuildStatusFailed), string(BuildStatusErrored)}, true
+	case string(BuildStatusFailed), string(BuildStatusErrored), string(BuildStatusAborted):
+		return []string{filter.Status}, true
+	}
+
+	return nil, false
+}
+
+// filterJobs narrows down a query over jobs j, pipelines p and teams t.
+func (filter DashboardFilter) filterJobs(query sq.SelectBuilder) sq.SelectBuilder {
+	if filter.TeamName != "" {
+		query = query.Where(sq.Eq{"t.name": filter.TeamName})
+	}
+
+	if statuses, ok := filter.buildStatuses(); ok {
+		query = query.Where(exists(
+			sq.Select("1").
+				From("builds b").
+				Where(sq.Expr("b.id = j.latest_completed_build_id")).
+				Where(sq.Eq{"b.status": statuses}),
+		))
+	} else if filter.Status == DashboardStatusPaused {
+		query = query.Where(sq.Or{
+			sq.Eq{"j.paused": true},
+			sq.Eq{"p.paused": true},
+		})
+	}
+
+	if filter.Search != "" {
+		pattern := searchPattern(filter.Search)
+		query = query.Where(sq.Or{
+			sq.Expr("p.name ILIKE ?", pattern),
+			sq.Expr("j.name ILIKE ?", pattern),
+		})
+	}
+
+	if len(filter.Labels) > 0 {
+		query = query.Where(hasLabels("(p.labels || j.labels)", filter.Labels))
+	}
+
+	return query
+}
+
+// filterPipelines narrows down a query over pipelines p and teams t.
+func (filter DashboardFilter) filterPipelines(query sq.SelectBuilder) sq.SelectBuilder {
+	if filter.TeamName != "" {
+		query = query.Where(sq.Eq{"t.name": filter.TeamName})
+	}
+
+	if statuses, ok := filter.buildStatuses(); ok {
+		query = query.Where(exists(
+			sq.Select("1").
+				From("jobs j").
+				Join("builds b ON b.id = j.latest_completed_build_id").
+				Where(sq.Expr("j.pipeline_id = p.id")).
+				Where(sq.Eq{
+					"j.active": true,
+					"b.status": statuses,
+				}),
+		))
+	} else if filter.Status == DashboardStatusPaused {
+		query = query.Where(sq.Eq{"p.paused": true})
+	}
+
+	if filter.Search != "" {
+		query = query.Where(sq.Expr("p.name ILIKE ?", searchPattern(filter.Search)))
+	}
+
+	if len(filter.Labels) > 0 {
+		query = query.Where(hasLabels("p.labels", filter.Labels))
+	}
+
+	return query
+}
+
+// exists wraps a subquery built with '?' placeholders so that it can be
+// embedded in a query which is later given postgres placeholders.
+func exists(subquery sq.SelectBuilder) sq.Sqlizer {
+	return existsExpr{subquery}
+}
+
+type existsExpr struct {
+	subquery sq.SelectBuilder
+}
+
+func (expr existsExpr) ToSql() (string, []interface{}, error) {
+	sql, args, err := expr.subquery.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "EXISTS (" + sql + ")", args, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func searchPattern(search string) string {
+	return "%" + likeEscaper.Replace(search) + "%"
+}
@@ -70,6 +70,10 @@ var (
 
 		WorkingDirectory: "/some/work/dir",
 		User:             "some-user",
+
+		Outputs: db.ContainerOutputs{
+			"some-output": "/some/work/dir/some-output/",
+		},
 	}
 
 	psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 type FakeBuild struct {
@@ -441,6 +442,110 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	ErrorStub        func() *atc.BuildError
+	errorMutex       sync.RWMutex
+	errorArgsForCall []struct{}
+	errorReturns     struct {
+		result1 *atc.BuildError
+	}
+	errorReturnsOnCall map[int]struct {
+		result1 *atc.BuildError
+	}
+	SaveErrorStub        func(buildErr atc.BuildError, origin event.Origin) error
+	saveErrorMutex       sync.RWMutex
+	saveErrorArgsForCall []struct {
+		buildErr atc.BuildError
+		origin   event.Origin
+	}
+	saveErrorReturns struct {
+		result1 error
+	}
+	saveErrorReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TriggerReasonStub        func() string
+	triggerReasonMutex       sync.RWMutex
+	triggerReasonArgsForCall []struct{}
+	triggerReasonReturns     struct {
+		result1 string
+	}
+	triggerReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
+	AbortReasonStub        func() string
+	abortReasonMutex       sync.RWMutex
+	abortReasonArgsForCall []struct{}
+	abortReasonReturns     struct {
+		result1 string
+	}
+	abortReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
+	AbortedByStub        func() string
+	abortedByMutex       sync.RWMutex
+	abortedByArgsForCall []struct{}
+	abortedByReturns     struct {
+		result1 string
+	}
+	abortedByReturnsOnCall map[int]struct {
+		result1 string
+	}
+	SaveAbortReasonStub        func(reason string, abortedBy string) error
+	saveAbortReasonMutex       sync.RWMutex
+	saveAbortReasonArgsForCall []struct {
+		reason    string
+		abortedBy string
+	}
+	saveAbortReasonReturns struct {
+		result1 error
+	}
+	saveAbortReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RetainContainersStub        func(db.ContainerRetentionPolicy) error
+	retainContainersMutex       sync.RWMutex
+	retainContainersArgsForCall []struct {
+		arg1 db.ContainerRetentionPolicy
+	}
+	retainContainersReturns struct {
+		result1 error
+	}
+	retainContainersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveBuildTokenAccessorStub        func(accessor string) error
+	saveBuildTokenAccessorMutex       sync.RWMutex
+	saveBuildTokenAccessorArgsForCall []struct {
+		accessor string
+	}
+	saveBuildTokenAccessorReturns struct {
+		result1 error
+	}
+	saveBuildTokenAccessorReturnsOnCall map[int]struct {
+		result1 error
+	}
+	BuildTokenAccessorsStub        func() ([]string, error)
+	buildTokenAccessorsMutex       sync.RWMutex
+	buildTokenAccessorsArgsForCall []struct{}
+	buildTokenAccessorsReturns     struct {
+		result1 []string
+		result2 error
+	}
+	buildTokenAccessorsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteBuildTokenAccessorStub        func(accessor string) error
+	deleteBuildTokenAccessorMutex       sync.RWMutex
+	deleteBuildTokenAccessorArgsForCall []struct {
+		accessor string
+	}
+	deleteBuildTokenAccessorReturns struct {
+		result1 error
+	}
+	deleteBuildTokenAccessorReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2237,6 +2342,451 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Error() *atc.BuildError {
+	fake.errorMutex.Lock()
+	ret, specificReturn := fake.errorReturnsOnCall[len(fake.errorArgsForCall)]
+	fake.errorArgsForCall = append(fake.errorArgsForCall, struct{}{})
+	fake.recordInvocation("Error", []interface{}{})
+	fake.errorMutex.Unlock()
+	if fake.ErrorStub != nil {
+		return fake.ErrorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.errorReturns.result1
+}
+
+func (fake *FakeBuild) ErrorCallCount() int {
+	fake.errorMutex.RLock()
+	defer fake.errorMutex.RUnlock()
+	return len(fake.errorArgsForCall)
+}
+
+func (fake *FakeBuild) ErrorReturns(result1 *atc.BuildError) {
+	fake.ErrorStub = nil
+	fake.errorReturns = struct {
+		result1 *atc.BuildError
+	}{result1}
+}
+
+func (fake *FakeBuild) ErrorReturnsOnCall(i int, result1 *atc.BuildError) {
+	fake.ErrorStub = nil
+	if fake.errorReturnsOnCall == nil {
+		fake.errorReturnsOnCall = make(map[int]struct {
+			result1 *atc.BuildError
+		})
+	}
+	fake.errorReturnsOnCall[i] = struct {
+		result1 *atc.BuildError
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveError(buildErr atc.BuildError, origin event.Origin) error {
+	fake.saveErrorMutex.Lock()
+	ret, specificReturn := fake.saveErrorReturnsOnCall[len(fake.saveErrorArgsForCall)]
+	fake.saveErrorArgsForCall = append(fake.saveErrorArgsForCall, struct {
+		buildErr atc.BuildError
+		origin   event.Origin
+	}{buildErr, origin})
+	fake.recordInvocation("SaveError", []interface{}{buildErr, origin})
+	fake.saveErrorMutex.Unlock()
+	if fake.SaveErrorStub != nil {
+		return fake.SaveErrorStub(buildErr, origin)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveErrorReturns.result1
+}
+
+func (fake *FakeBuild) SaveErrorCallCount() int {
+	fake.saveErrorMutex.RLock()
+	defer fake.saveErrorMutex.RUnlock()
+	return len(fake.saveErrorArgsForCall)
+}
+
+func (fake *FakeBuild) SaveErrorArgsForCall(i int) (atc.BuildError, event.Origin) {
+	fake.saveErrorMutex.RLock()
+	defer fake.saveErrorMutex.RUnlock()
+	return fake.saveErrorArgsForCall[i].buildErr, fake.saveErrorArgsForCall[i].origin
+}
+
+func (fake *FakeBuild) SaveErrorReturns(result1 error) {
+	fake.SaveErrorStub = nil
+	fake.saveErrorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveErrorReturnsOnCall(i int, result1 error) {
+	fake.SaveErrorStub = nil
+	if fake.saveErrorReturnsOnCall == nil {
+		fake.saveErrorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveErrorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerReason() string {
+	fake.triggerReasonMutex.Lock()
+	ret, specificReturn := fake.triggerReasonReturnsOnCall[len(fake.triggerReasonArgsForCall)]
+	fake.triggerReasonArgsForCall = append(fake.triggerReasonArgsForCall, struct{}{})
+	fake.recordInvocation("TriggerReason", []interface{}{})
+	fake.triggerReasonMutex.Unlock()
+	if fake.TriggerReasonStub != nil {
+		return fake.TriggerReasonStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.triggerReasonReturns.result1
+}
+
+func (fake *FakeBuild) TriggerReasonCallCount() int {
+	fake.triggerReasonMutex.RLock()
+	defer fake.triggerReasonMutex.RUnlock()
+	return len(fake.triggerReasonArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerReasonReturns(result1 string) {
+	fake.TriggerReasonStub = nil
+	fake.triggerReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerReasonReturnsOnCall(i int, result1 string) {
+	fake.TriggerReasonStub = nil
+	if fake.triggerReasonReturnsOnCall == nil {
+		fake.triggerReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.triggerReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortReason() string {
+	fake.abortReasonMutex.Lock()
+	ret, specificReturn := fake.abortReasonReturnsOnCall[len(fake.abortReasonArgsForCall)]
+	fake.abortReasonArgsForCall = append(fake.abortReasonArgsForCall, struct{}{})
+	fake.recordInvocation("AbortReason", []interface{}{})
+	fake.abortReasonMutex.Unlock()
+	if fake.AbortReasonStub != nil {
+		return fake.AbortReasonStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.abortReasonReturns.result1
+}
+
+func (fake *FakeBuild) AbortReasonCallCount() int {
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	return len(fake.abortReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AbortReasonReturns(result1 string) {
+	fake.AbortReasonStub = nil
+	fake.abortReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortReasonReturnsOnCall(i int, result1 string) {
+	fake.AbortReasonStub = nil
+	if fake.abortReasonReturnsOnCall == nil {
+		fake.abortReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.abortReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortedBy() string {
+	fake.abortedByMutex.Lock()
+	ret, specificReturn := fake.abortedByReturnsOnCall[len(fake.abortedByArgsForCall)]
+	fake.abortedByArgsForCall = append(fake.abortedByArgsForCall, struct{}{})
+	fake.recordInvocation("AbortedBy", []interface{}{})
+	fake.abortedByMutex.Unlock()
+	if fake.AbortedByStub != nil {
+		return fake.AbortedByStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.abortedByReturns.result1
+}
+
+func (fake *FakeBuild) AbortedByCallCount() int {
+	fake.abortedByMutex.RLock()
+	defer fake.abortedByMutex.RUnlock()
+	return len(fake.abortedByArgsForCall)
+}
+
+func (fake *FakeBuild) AbortedByReturns(result1 string) {
+	fake.AbortedByStub = nil
+	fake.abortedByReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortedByReturnsOnCall(i int, result1 string) {
+	fake.AbortedByStub = nil
+	if fake.abortedByReturnsOnCall == nil {
+		fake.abortedByReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.abortedByReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveAbortReason(reason string, abortedBy string) error {
+	fake.saveAbortReasonMutex.Lock()
+	ret, specificReturn := fake.saveAbortReasonReturnsOnCall[len(fake.saveAbortReasonArgsForCall)]
+	fake.saveAbortReasonArgsForCall = append(fake.saveAbortReasonArgsForCall, struct {
+		reason    string
+		abortedBy string
+	}{reason, abortedBy})
+	fake.recordInvocation("SaveAbortReason", []interface{}{reason, abortedBy})
+	fake.saveAbortReasonMutex.Unlock()
+	if fake.SaveAbortReasonStub != nil {
+		return fake.SaveAbortReasonStub(reason, abortedBy)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveAbortReasonReturns.result1
+}
+
+func (fake *FakeBuild) SaveAbortReasonCallCount() int {
+	fake.saveAbortReasonMutex.RLock()
+	defer fake.saveAbortReasonMutex.RUnlock()
+	return len(fake.saveAbortReasonArgsForCall)
+}
+
+func (fake *FakeBuild) SaveAbortReasonArgsForCall(i int) (string, string) {
+	fake.saveAbortReasonMutex.RLock()
+	defer fake.saveAbortReasonMutex.RUnlock()
+	return fake.saveAbortReasonArgsForCall[i].reason, fake.saveAbortReasonArgsForCall[i].abortedBy
+}
+
+func (fake *FakeBuild) SaveAbortReasonReturns(result1 error) {
+	fake.SaveAbortReasonStub = nil
+	fake.saveAbortReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveAbortReasonReturnsOnCall(i int, result1 error) {
+	fake.SaveAbortReasonStub = nil
+	if fake.saveAbortReasonReturnsOnCall == nil {
+		fake.saveAbortReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveAbortReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RetainContainers(arg1 db.ContainerRetentionPolicy) error {
+	fake.retainContainersMutex.Lock()
+	ret, specificReturn := fake.retainContainersReturnsOnCall[len(fake.retainContainersArgsForCall)]
+	fake.retainContainersArgsForCall = append(fake.retainContainersArgsForCall, struct {
+		arg1 db.ContainerRetentionPolicy
+	}{arg1})
+	fake.recordInvocation("RetainContainers", []interface{}{arg1})
+	fake.retainContainersMutex.Unlock()
+	if fake.RetainContainersStub != nil {
+		return fake.RetainContainersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.retainContainersReturns.result1
+}
+
+func (fake *FakeBuild) RetainContainersCallCount() int {
+	fake.retainContainersMutex.RLock()
+	defer fake.retainContainersMutex.RUnlock()
+	return len(fake.retainContainersArgsForCall)
+}
+
+func (fake *FakeBuild) RetainContainersArgsForCall(i int) db.ContainerRetentionPolicy {
+	fake.retainContainersMutex.RLock()
+	defer fake.retainContainersMutex.RUnlock()
+	return fake.retainContainersArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) RetainContainersReturns(result1 error) {
+	fake.RetainContainersStub = nil
+	fake.retainContainersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RetainContainersReturnsOnCall(i int, result1 error) {
+	fake.RetainContainersStub = nil
+	if fake.retainContainersReturnsOnCall == nil {
+		fake.retainContainersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.retainContainersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveBuildTokenAccessor(accessor string) error {
+	fake.saveBuildTokenAccessorMutex.Lock()
+	ret, specificReturn := fake.saveBuildTokenAccessorReturnsOnCall[len(fake.saveBuildTokenAccessorArgsForCall)]
+	fake.saveBuildTokenAccessorArgsForCall = append(fake.saveBuildTokenAccessorArgsForCall, struct {
+		accessor string
+	}{accessor})
+	fake.recordInvocation("SaveBuildTokenAccessor", []interface{}{accessor})
+	fake.saveBuildTokenAccessorMutex.Unlock()
+	if fake.SaveBuildTokenAccessorStub != nil {
+		return fake.SaveBuildTokenAccessorStub(accessor)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveBuildTokenAccessorReturns.result1
+}
+
+func (fake *FakeBuild) SaveBuildTokenAccessorCallCount() int {
+	fake.saveBuildTokenAccessorMutex.RLock()
+	defer fake.saveBuildTokenAccessorMutex.RUnlock()
+	return len(fake.saveBuildTokenAccessorArgsForCall)
+}
+
+func (fake *FakeBuild) SaveBuildTokenAccessorArgsForCall(i int) string {
+	fake.saveBuildTokenAccessorMutex.RLock()
+	defer fake.saveBuildTokenAccessorMutex.RUnlock()
+	return fake.saveBuildTokenAccessorArgsForCall[i].accessor
+}
+
+func (fake *FakeBuild) SaveBuildTokenAccessorReturns(result1 error) {
+	fake.SaveBuildTokenAccessorStub = nil
+	fake.saveBuildTokenAccessorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveBuildTokenAccessorReturnsOnCall(i int, result1 error) {
+	fake.SaveBuildTokenAccessorStub = nil
+	if fake.saveBuildTokenAccessorReturnsOnCall == nil {
+		fake.saveBuildTokenAccessorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveBuildTokenAccessorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) BuildTokenAccessors() ([]string, error) {
+	fake.buildTokenAccessorsMutex.Lock()
+	ret, specificReturn := fake.buildTokenAccessorsReturnsOnCall[len(fake.buildTokenAccessorsArgsForCall)]
+	fake.buildTokenAccessorsArgsForCall = append(fake.buildTokenAccessorsArgsForCall, struct{}{})
+	fake.recordInvocation("BuildTokenAccessors", []interface{}{})
+	fake.buildTokenAccessorsMutex.Unlock()
+	if fake.BuildTokenAccessorsStub != nil {
+		return fake.BuildTokenAccessorsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.buildTokenAccessorsReturns.result1, fake.buildTokenAccessorsReturns.result2
+}
+
+func (fake *FakeBuild) BuildTokenAccessorsCallCount() int {
+	fake.buildTokenAccessorsMutex.RLock()
+	defer fake.buildTokenAccessorsMutex.RUnlock()
+	return len(fake.buildTokenAccessorsArgsForCall)
+}
+
+func (fake *FakeBuild) BuildTokenAccessorsReturns(result1 []string, result2 error) {
+	fake.BuildTokenAccessorsStub = nil
+	fake.buildTokenAccessorsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) BuildTokenAccessorsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.BuildTokenAccessorsStub = nil
+	if fake.buildTokenAccessorsReturnsOnCall == nil {
+		fake.buildTokenAccessorsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.buildTokenAccessorsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DeleteBuildTokenAccessor(accessor string) error {
+	fake.deleteBuildTokenAccessorMutex.Lock()
+	ret, specificReturn := fake.deleteBuildTokenAccessorReturnsOnCall[len(fake.deleteBuildTokenAccessorArgsForCall)]
+	fake.deleteBuildTokenAccessorArgsForCall = append(fake.deleteBuildTokenAccessorArgsForCall, struct {
+		accessor string
+	}{accessor})
+	fake.recordInvocation("DeleteBuildTokenAccessor", []interface{}{accessor})
+	fake.deleteBuildTokenAccessorMutex.Unlock()
+	if fake.DeleteBuildTokenAccessorStub != nil {
+		return fake.DeleteBuildTokenAccessorStub(accessor)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteBuildTokenAccessorReturns.result1
+}
+
+func (fake *FakeBuild) DeleteBuildTokenAccessorCallCount() int {
+	fake.deleteBuildTokenAccessorMutex.RLock()
+	defer fake.deleteBuildTokenAccessorMutex.RUnlock()
+	return len(fake.deleteBuildTokenAccessorArgsForCall)
+}
+
+func (fake *FakeBuild) DeleteBuildTokenAccessorArgsForCall(i int) string {
+	fake.deleteBuildTokenAccessorMutex.RLock()
+	defer fake.deleteBuildTokenAccessorMutex.RUnlock()
+	return fake.deleteBuildTokenAccessorArgsForCall[i].accessor
+}
+
+func (fake *FakeBuild) DeleteBuildTokenAccessorReturns(result1 error) {
+	fake.DeleteBuildTokenAccessorStub = nil
+	fake.deleteBuildTokenAccessorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) DeleteBuildTokenAccessorReturnsOnCall(i int, result1 error) {
+	fake.DeleteBuildTokenAccessorStub = nil
+	if fake.deleteBuildTokenAccessorReturnsOnCall == nil {
+		fake.deleteBuildTokenAccessorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBuildTokenAccessorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2322,6 +2872,26 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.errorMutex.RLock()
+	defer fake.errorMutex.RUnlock()
+	fake.saveErrorMutex.RLock()
+	defer fake.saveErrorMutex.RUnlock()
+	fake.triggerReasonMutex.RLock()
+	defer fake.triggerReasonMutex.RUnlock()
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	fake.abortedByMutex.RLock()
+	defer fake.abortedByMutex.RUnlock()
+	fake.saveAbortReasonMutex.RLock()
+	defer fake.saveAbortReasonMutex.RUnlock()
+	fake.retainContainersMutex.RLock()
+	defer fake.retainContainersMutex.RUnlock()
+	fake.saveBuildTokenAccessorMutex.RLock()
+	defer fake.saveBuildTokenAccessorMutex.RUnlock()
+	fake.buildTokenAccessorsMutex.RLock()
+	defer fake.buildTokenAccessorsMutex.RUnlock()
+	fake.deleteBuildTokenAccessorMutex.RLock()
+	defer fake.deleteBuildTokenAccessorMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -23,11 +23,12 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
-	VisibleBuildsStub        func([]string, db.Page) ([]db.Build, db.Pagination, error)
+	VisibleBuildsStub        func([]string, db.BuildFilter, db.Page) ([]db.Build, db.Pagination, error)
 	visibleBuildsMutex       sync.RWMutex
 	visibleBuildsArgsForCall []struct {
 		arg1 []string
-		arg2 db.Page
+		arg2 db.BuildFilter
+		arg3 db.Page
 	}
 	visibleBuildsReturns struct {
 		result1 []db.Build
@@ -65,10 +66,12 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
-	MarkNonInterceptibleBuildsStub        func() error
+	MarkNonInterceptibleBuildsStub        func(db.ContainerRetentionPolicy) error
 	markNonInterceptibleBuildsMutex       sync.RWMutex
-	markNonInterceptibleBuildsArgsForCall []struct{}
-	markNonInterceptibleBuildsReturns     struct {
+	markNonInterceptibleBuildsArgsForCall []struct {
+		arg1 db.ContainerRetentionPolicy
+	}
+	markNonInterceptibleBuildsReturns struct {
 		result1 error
 	}
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
@@ -132,7 +135,7 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.Build, db.Pagination, error) {
+func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.BuildFilter, arg3 db.Page) ([]db.Build, db.Pagination, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -142,12 +145,13 @@ func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.B
 	ret, specificReturn := fake.visibleBuildsReturnsOnCall[len(fake.visibleBuildsArgsForCall)]
 	fake.visibleBuildsArgsForCall = append(fake.visibleBuildsArgsForCall, struct {
 		arg1 []string
-		arg2 db.Page
-	}{arg1Copy, arg2})
-	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2})
+		arg2 db.BuildFilter
+		arg3 db.Page
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2, arg3})
 	fake.visibleBuildsMutex.Unlock()
 	if fake.VisibleBuildsStub != nil {
-		return fake.VisibleBuildsStub(arg1, arg2)
+		return fake.VisibleBuildsStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -161,10 +165,10 @@ func (fake *FakeBuildFactory) VisibleBuildsCallCount() int {
 	return len(fake.visibleBuildsArgsForCall)
 }
 
-func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.Page) {
+func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.BuildFilter, db.Page) {
 	fake.visibleBuildsMutex.RLock()
 	defer fake.visibleBuildsMutex.RUnlock()
-	return fake.visibleBuildsArgsForCall[i].arg1, fake.visibleBuildsArgsForCall[i].arg2
+	return fake.visibleBuildsArgsForCall[i].arg1, fake.visibleBuildsArgsForCall[i].arg2, fake.visibleBuildsArgsForCall[i].arg3
 }
 
 func (fake *FakeBuildFactory) VisibleBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
@@ -289,14 +293,16 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
-func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds() error {
+func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds(arg1 db.ContainerRetentionPolicy) error {
 	fake.markNonInterceptibleBuildsMutex.Lock()
 	ret, specificReturn := fake.markNonInterceptibleBuildsReturnsOnCall[len(fake.markNonInterceptibleBuildsArgsForCall)]
-	fake.markNonInterceptibleBuildsArgsForCall = append(fake.markNonInterceptibleBuildsArgsForCall, struct{}{})
-	fake.recordInvocation("MarkNonInterceptibleBuilds", []interface{}{})
+	fake.markNonInterceptibleBuildsArgsForCall = append(fake.markNonInterceptibleBuildsArgsForCall, struct {
+		arg1 db.ContainerRetentionPolicy
+	}{arg1})
+	fake.recordInvocation("MarkNonInterceptibleBuilds", []interface{}{arg1})
 	fake.markNonInterceptibleBuildsMutex.Unlock()
 	if fake.MarkNonInterceptibleBuildsStub != nil {
-		return fake.MarkNonInterceptibleBuildsStub()
+		return fake.MarkNonInterceptibleBuildsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -310,6 +316,12 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsCallCount() int {
 	return len(fake.markNonInterceptibleBuildsArgsForCall)
 }
 
+func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsArgsForCall(i int) db.ContainerRetentionPolicy {
+	fake.markNonInterceptibleBuildsMutex.RLock()
+	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	return fake.markNonInterceptibleBuildsArgsForCall[i].arg1
+}
+
 func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturns(result1 error) {
 	fake.MarkNonInterceptibleBuildsStub = nil
 	fake.markNonInterceptibleBuildsReturns = struct {
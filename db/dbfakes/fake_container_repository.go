@@ -61,6 +61,17 @@ type FakeContainerRepository struct {
 		result1 int
 		result2 error
 	}
+	FindAllDestroyingContainersStub        func() ([]db.DestroyingContainer, error)
+	findAllDestroyingContainersMutex       sync.RWMutex
+	findAllDestroyingContainersArgsForCall []struct{}
+	findAllDestroyingContainersReturns     struct {
+		result1 []db.DestroyingContainer
+		result2 error
+	}
+	findAllDestroyingContainersReturnsOnCall map[int]struct {
+		result1 []db.DestroyingContainer
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -265,6 +276,49 @@ func (fake *FakeContainerRepository) RemoveDestroyingContainersReturnsOnCall(i i
 	}{result1, result2}
 }
 
+func (fake *FakeContainerRepository) FindAllDestroyingContainers() ([]db.DestroyingContainer, error) {
+	fake.findAllDestroyingContainersMutex.Lock()
+	ret, specificReturn := fake.findAllDestroyingContainersReturnsOnCall[len(fake.findAllDestroyingContainersArgsForCall)]
+	fake.findAllDestroyingContainersArgsForCall = append(fake.findAllDestroyingContainersArgsForCall, struct{}{})
+	fake.recordInvocation("FindAllDestroyingContainers", []interface{}{})
+	fake.findAllDestroyingContainersMutex.Unlock()
+	if fake.FindAllDestroyingContainersStub != nil {
+		return fake.FindAllDestroyingContainersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findAllDestroyingContainersReturns.result1, fake.findAllDestroyingContainersReturns.result2
+}
+
+func (fake *FakeContainerRepository) FindAllDestroyingContainersCallCount() int {
+	fake.findAllDestroyingContainersMutex.RLock()
+	defer fake.findAllDestroyingContainersMutex.RUnlock()
+	return len(fake.findAllDestroyingContainersArgsForCall)
+}
+
+func (fake *FakeContainerRepository) FindAllDestroyingContainersReturns(result1 []db.DestroyingContainer, result2 error) {
+	fake.FindAllDestroyingContainersStub = nil
+	fake.findAllDestroyingContainersReturns = struct {
+		result1 []db.DestroyingContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerRepository) FindAllDestroyingContainersReturnsOnCall(i int, result1 []db.DestroyingContainer, result2 error) {
+	fake.FindAllDestroyingContainersStub = nil
+	if fake.findAllDestroyingContainersReturnsOnCall == nil {
+		fake.findAllDestroyingContainersReturnsOnCall = make(map[int]struct {
+			result1 []db.DestroyingContainer
+			result2 error
+		})
+	}
+	fake.findAllDestroyingContainersReturnsOnCall[i] = struct {
+		result1 []db.DestroyingContainer
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeContainerRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -276,6 +330,8 @@ func (fake *FakeContainerRepository) Invocations() map[string][][]interface{} {
 	defer fake.findDestroyingContainersMutex.RUnlock()
 	fake.removeDestroyingContainersMutex.RLock()
 	defer fake.removeDestroyingContainersMutex.RUnlock()
+	fake.findAllDestroyingContainersMutex.RLock()
+	defer fake.findAllDestroyingContainersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
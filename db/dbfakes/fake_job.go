@@ -129,17 +129,6 @@ type FakeJob struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateBuildStub        func() (db.Build, error)
-	createBuildMutex       sync.RWMutex
-	createBuildArgsForCall []struct{}
-	createBuildReturns     struct {
-		result1 db.Build
-		result2 error
-	}
-	createBuildReturnsOnCall map[int]struct {
-		result1 db.Build
-		result2 error
-	}
 	BuildsStub        func(page db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -322,6 +311,58 @@ type FakeJob struct {
 		result1 int64
 		result2 error
 	}
+	AbortBuildsStub        func(db.AbortBuildsFilter, string, string) ([]db.Build, error)
+	abortBuildsMutex       sync.RWMutex
+	abortBuildsArgsForCall []struct {
+		filter    db.AbortBuildsFilter
+		reason    string
+		abortedBy string
+	}
+	abortBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	abortBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	LatestSuccessfulBuildStub        func() (db.Build, bool, error)
+	latestSuccessfulBuildMutex       sync.RWMutex
+	latestSuccessfulBuildArgsForCall []struct{}
+	latestSuccessfulBuildReturns     struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	latestSuccessfulBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	CreateBuildStub        func() (db.Build, error)
+	createBuildMutex       sync.RWMutex
+	createBuildArgsForCall []struct{}
+	createBuildReturns     struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateBuildWithReasonStub        func(reason string) (db.Build, error)
+	createBuildWithReasonMutex       sync.RWMutex
+	createBuildWithReasonArgsForCall []struct {
+		reason string
+	}
+	createBuildWithReasonReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithReasonReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -849,49 +890,6 @@ func (fake *FakeJob) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) CreateBuild() (db.Build, error) {
-	fake.createBuildMutex.Lock()
-	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
-	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct{}{})
-	fake.recordInvocation("CreateBuild", []interface{}{})
-	fake.createBuildMutex.Unlock()
-	if fake.CreateBuildStub != nil {
-		return fake.CreateBuildStub()
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.createBuildReturns.result1, fake.createBuildReturns.result2
-}
-
-func (fake *FakeJob) CreateBuildCallCount() int {
-	fake.createBuildMutex.RLock()
-	defer fake.createBuildMutex.RUnlock()
-	return len(fake.createBuildArgsForCall)
-}
-
-func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
-	fake.CreateBuildStub = nil
-	fake.createBuildReturns = struct {
-		result1 db.Build
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
-	fake.CreateBuildStub = nil
-	if fake.createBuildReturnsOnCall == nil {
-		fake.createBuildReturnsOnCall = make(map[int]struct {
-			result1 db.Build
-			result2 error
-		})
-	}
-	fake.createBuildReturnsOnCall[i] = struct {
-		result1 db.Build
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeJob) Builds(page db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1617,6 +1615,199 @@ func (fake *FakeJob) ClearTaskCacheReturnsOnCall(i int, result1 int64, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) AbortBuilds(filter db.AbortBuildsFilter, reason string, abortedBy string) ([]db.Build, error) {
+	fake.abortBuildsMutex.Lock()
+	ret, specificReturn := fake.abortBuildsReturnsOnCall[len(fake.abortBuildsArgsForCall)]
+	fake.abortBuildsArgsForCall = append(fake.abortBuildsArgsForCall, struct {
+		filter    db.AbortBuildsFilter
+		reason    string
+		abortedBy string
+	}{filter, reason, abortedBy})
+	fake.recordInvocation("AbortBuilds", []interface{}{filter, reason, abortedBy})
+	fake.abortBuildsMutex.Unlock()
+	if fake.AbortBuildsStub != nil {
+		return fake.AbortBuildsStub(filter, reason, abortedBy)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.abortBuildsReturns.result1, fake.abortBuildsReturns.result2
+}
+
+func (fake *FakeJob) AbortBuildsCallCount() int {
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	return len(fake.abortBuildsArgsForCall)
+}
+
+func (fake *FakeJob) AbortBuildsArgsForCall(i int) (db.AbortBuildsFilter, string, string) {
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	return fake.abortBuildsArgsForCall[i].filter, fake.abortBuildsArgsForCall[i].reason, fake.abortBuildsArgsForCall[i].abortedBy
+}
+
+func (fake *FakeJob) AbortBuildsReturns(result1 []db.Build, result2 error) {
+	fake.AbortBuildsStub = nil
+	fake.abortBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) AbortBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.AbortBuildsStub = nil
+	if fake.abortBuildsReturnsOnCall == nil {
+		fake.abortBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.abortBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) LatestSuccessfulBuild() (db.Build, bool, error) {
+	fake.latestSuccessfulBuildMutex.Lock()
+	ret, specificReturn := fake.latestSuccessfulBuildReturnsOnCall[len(fake.latestSuccessfulBuildArgsForCall)]
+	fake.latestSuccessfulBuildArgsForCall = append(fake.latestSuccessfulBuildArgsForCall, struct{}{})
+	fake.recordInvocation("LatestSuccessfulBuild", []interface{}{})
+	fake.latestSuccessfulBuildMutex.Unlock()
+	if fake.LatestSuccessfulBuildStub != nil {
+		return fake.LatestSuccessfulBuildStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.latestSuccessfulBuildReturns.result1, fake.latestSuccessfulBuildReturns.result2, fake.latestSuccessfulBuildReturns.result3
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildCallCount() int {
+	fake.latestSuccessfulBuildMutex.RLock()
+	defer fake.latestSuccessfulBuildMutex.RUnlock()
+	return len(fake.latestSuccessfulBuildArgsForCall)
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.LatestSuccessfulBuildStub = nil
+	fake.latestSuccessfulBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.LatestSuccessfulBuildStub = nil
+	if fake.latestSuccessfulBuildReturnsOnCall == nil {
+		fake.latestSuccessfulBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.latestSuccessfulBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) CreateBuild() (db.Build, error) {
+	fake.createBuildMutex.Lock()
+	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
+	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct{}{})
+	fake.recordInvocation("CreateBuild", []interface{}{})
+	fake.createBuildMutex.Unlock()
+	if fake.CreateBuildStub != nil {
+		return fake.CreateBuildStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildReturns.result1, fake.createBuildReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildCallCount() int {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	return len(fake.createBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateBuildStub = nil
+	fake.createBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateBuildStub = nil
+	if fake.createBuildReturnsOnCall == nil {
+		fake.createBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithReason(reason string) (db.Build, error) {
+	fake.createBuildWithReasonMutex.Lock()
+	ret, specificReturn := fake.createBuildWithReasonReturnsOnCall[len(fake.createBuildWithReasonArgsForCall)]
+	fake.createBuildWithReasonArgsForCall = append(fake.createBuildWithReasonArgsForCall, struct {
+		reason string
+	}{reason})
+	fake.recordInvocation("CreateBuildWithReason", []interface{}{reason})
+	fake.createBuildWithReasonMutex.Unlock()
+	if fake.CreateBuildWithReasonStub != nil {
+		return fake.CreateBuildWithReasonStub(reason)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildWithReasonReturns.result1, fake.createBuildWithReasonReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithReasonCallCount() int {
+	fake.createBuildWithReasonMutex.RLock()
+	defer fake.createBuildWithReasonMutex.RUnlock()
+	return len(fake.createBuildWithReasonArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithReasonArgsForCall(i int) string {
+	fake.createBuildWithReasonMutex.RLock()
+	defer fake.createBuildWithReasonMutex.RUnlock()
+	return fake.createBuildWithReasonArgsForCall[i].reason
+}
+
+func (fake *FakeJob) CreateBuildWithReasonReturns(result1 db.Build, result2 error) {
+	fake.CreateBuildWithReasonStub = nil
+	fake.createBuildWithReasonReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithReasonReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateBuildWithReasonStub = nil
+	if fake.createBuildWithReasonReturnsOnCall == nil {
+		fake.createBuildWithReasonReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithReasonReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1646,8 +1837,6 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pauseMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
-	fake.createBuildMutex.RLock()
-	defer fake.createBuildMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildMutex.RLock()
@@ -1678,6 +1867,14 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	fake.latestSuccessfulBuildMutex.RLock()
+	defer fake.latestSuccessfulBuildMutex.RUnlock()
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithReasonMutex.RLock()
+	defer fake.createBuildWithReasonMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -8,10 +8,11 @@ import (
 )
 
 type FakeJobFactory struct {
-	VisibleJobsStub        func([]string) (db.Dashboard, error)
+	VisibleJobsStub        func([]string, db.DashboardFilter) (db.Dashboard, error)
 	visibleJobsMutex       sync.RWMutex
 	visibleJobsArgsForCall []struct {
 		arg1 []string
+		arg2 db.DashboardFilter
 	}
 	visibleJobsReturns struct {
 		result1 db.Dashboard
@@ -25,7 +26,7 @@ type FakeJobFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeJobFactory) VisibleJobs(arg1 []string) (db.Dashboard, error) {
+func (fake *FakeJobFactory) VisibleJobs(arg1 []string, arg2 db.DashboardFilter) (db.Dashboard, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -35,11 +36,12 @@ func (fake *FakeJobFactory) VisibleJobs(arg1 []string) (db.Dashboard, error) {
 	ret, specificReturn := fake.visibleJobsReturnsOnCall[len(fake.visibleJobsArgsForCall)]
 	fake.visibleJobsArgsForCall = append(fake.visibleJobsArgsForCall, struct {
 		arg1 []string
-	}{arg1Copy})
-	fake.recordInvocation("VisibleJobs", []interface{}{arg1Copy})
+		arg2 db.DashboardFilter
+	}{arg1Copy, arg2})
+	fake.recordInvocation("VisibleJobs", []interface{}{arg1Copy, arg2})
 	fake.visibleJobsMutex.Unlock()
 	if fake.VisibleJobsStub != nil {
-		return fake.VisibleJobsStub(arg1)
+		return fake.VisibleJobsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -53,10 +55,10 @@ func (fake *FakeJobFactory) VisibleJobsCallCount() int {
 	return len(fake.visibleJobsArgsForCall)
 }
 
-func (fake *FakeJobFactory) VisibleJobsArgsForCall(i int) []string {
+func (fake *FakeJobFactory) VisibleJobsArgsForCall(i int) ([]string, db.DashboardFilter) {
 	fake.visibleJobsMutex.RLock()
 	defer fake.visibleJobsMutex.RUnlock()
-	return fake.visibleJobsArgsForCall[i].arg1
+	return fake.visibleJobsArgsForCall[i].arg1, fake.visibleJobsArgsForCall[i].arg2
 }
 
 func (fake *FakeJobFactory) VisibleJobsReturns(result1 db.Dashboard, result2 error) {
@@ -525,6 +525,73 @@ type FakePipeline struct {
 		result1 db.Build
 		result2 error
 	}
+	NetworkStub        func() *atc.NetworkConfig
+	networkMutex       sync.RWMutex
+	networkArgsForCall []struct{}
+	networkReturns     struct {
+		result1 *atc.NetworkConfig
+	}
+	networkReturnsOnCall map[int]struct {
+		result1 *atc.NetworkConfig
+	}
+	MaxInFlightStub        func() int
+	maxInFlightMutex       sync.RWMutex
+	maxInFlightArgsForCall []struct{}
+	maxInFlightReturns     struct {
+		result1 int
+	}
+	maxInFlightReturnsOnCall map[int]struct {
+		result1 int
+	}
+	GetRunningBuildsStub        func() ([]db.Build, error)
+	getRunningBuildsMutex       sync.RWMutex
+	getRunningBuildsArgsForCall []struct{}
+	getRunningBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	getRunningBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	SoftDeleteStub        func() error
+	softDeleteMutex       sync.RWMutex
+	softDeleteArgsForCall []struct{}
+	softDeleteReturns     struct {
+		result1 error
+	}
+	softDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	LabelsStub        func() map[string]string
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct{}
+	labelsReturns     struct {
+		result1 map[string]string
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
+	PausedByInactivityStub        func() bool
+	pausedByInactivityMutex       sync.RWMutex
+	pausedByInactivityArgsForCall []struct{}
+	pausedByInactivityReturns     struct {
+		result1 bool
+	}
+	pausedByInactivityReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	GetNextPendingBuildsStub        func() ([]db.Build, error)
+	getNextPendingBuildsMutex       sync.RWMutex
+	getNextPendingBuildsArgsForCall []struct{}
+	getNextPendingBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	getNextPendingBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2545,6 +2612,292 @@ func (fake *FakePipeline) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Network() *atc.NetworkConfig {
+	fake.networkMutex.Lock()
+	ret, specificReturn := fake.networkReturnsOnCall[len(fake.networkArgsForCall)]
+	fake.networkArgsForCall = append(fake.networkArgsForCall, struct{}{})
+	fake.recordInvocation("Network", []interface{}{})
+	fake.networkMutex.Unlock()
+	if fake.NetworkStub != nil {
+		return fake.NetworkStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.networkReturns.result1
+}
+
+func (fake *FakePipeline) NetworkCallCount() int {
+	fake.networkMutex.RLock()
+	defer fake.networkMutex.RUnlock()
+	return len(fake.networkArgsForCall)
+}
+
+func (fake *FakePipeline) NetworkReturns(result1 *atc.NetworkConfig) {
+	fake.NetworkStub = nil
+	fake.networkReturns = struct {
+		result1 *atc.NetworkConfig
+	}{result1}
+}
+
+func (fake *FakePipeline) NetworkReturnsOnCall(i int, result1 *atc.NetworkConfig) {
+	fake.NetworkStub = nil
+	if fake.networkReturnsOnCall == nil {
+		fake.networkReturnsOnCall = make(map[int]struct {
+			result1 *atc.NetworkConfig
+		})
+	}
+	fake.networkReturnsOnCall[i] = struct {
+		result1 *atc.NetworkConfig
+	}{result1}
+}
+
+func (fake *FakePipeline) MaxInFlight() int {
+	fake.maxInFlightMutex.Lock()
+	ret, specificReturn := fake.maxInFlightReturnsOnCall[len(fake.maxInFlightArgsForCall)]
+	fake.maxInFlightArgsForCall = append(fake.maxInFlightArgsForCall, struct{}{})
+	fake.recordInvocation("MaxInFlight", []interface{}{})
+	fake.maxInFlightMutex.Unlock()
+	if fake.MaxInFlightStub != nil {
+		return fake.MaxInFlightStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxInFlightReturns.result1
+}
+
+func (fake *FakePipeline) MaxInFlightCallCount() int {
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	return len(fake.maxInFlightArgsForCall)
+}
+
+func (fake *FakePipeline) MaxInFlightReturns(result1 int) {
+	fake.MaxInFlightStub = nil
+	fake.maxInFlightReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakePipeline) MaxInFlightReturnsOnCall(i int, result1 int) {
+	fake.MaxInFlightStub = nil
+	if fake.maxInFlightReturnsOnCall == nil {
+		fake.maxInFlightReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxInFlightReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakePipeline) GetRunningBuilds() ([]db.Build, error) {
+	fake.getRunningBuildsMutex.Lock()
+	ret, specificReturn := fake.getRunningBuildsReturnsOnCall[len(fake.getRunningBuildsArgsForCall)]
+	fake.getRunningBuildsArgsForCall = append(fake.getRunningBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("GetRunningBuilds", []interface{}{})
+	fake.getRunningBuildsMutex.Unlock()
+	if fake.GetRunningBuildsStub != nil {
+		return fake.GetRunningBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getRunningBuildsReturns.result1, fake.getRunningBuildsReturns.result2
+}
+
+func (fake *FakePipeline) GetRunningBuildsCallCount() int {
+	fake.getRunningBuildsMutex.RLock()
+	defer fake.getRunningBuildsMutex.RUnlock()
+	return len(fake.getRunningBuildsArgsForCall)
+}
+
+func (fake *FakePipeline) GetRunningBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsStub = nil
+	fake.getRunningBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetRunningBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsStub = nil
+	if fake.getRunningBuildsReturnsOnCall == nil {
+		fake.getRunningBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getRunningBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SoftDelete() error {
+	fake.softDeleteMutex.Lock()
+	ret, specificReturn := fake.softDeleteReturnsOnCall[len(fake.softDeleteArgsForCall)]
+	fake.softDeleteArgsForCall = append(fake.softDeleteArgsForCall, struct{}{})
+	fake.recordInvocation("SoftDelete", []interface{}{})
+	fake.softDeleteMutex.Unlock()
+	if fake.SoftDeleteStub != nil {
+		return fake.SoftDeleteStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.softDeleteReturns.result1
+}
+
+func (fake *FakePipeline) SoftDeleteCallCount() int {
+	fake.softDeleteMutex.RLock()
+	defer fake.softDeleteMutex.RUnlock()
+	return len(fake.softDeleteArgsForCall)
+}
+
+func (fake *FakePipeline) SoftDeleteReturns(result1 error) {
+	fake.SoftDeleteStub = nil
+	fake.softDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SoftDeleteReturnsOnCall(i int, result1 error) {
+	fake.SoftDeleteStub = nil
+	if fake.softDeleteReturnsOnCall == nil {
+		fake.softDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.softDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Labels() map[string]string {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct{}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelsReturns.result1
+}
+
+func (fake *FakePipeline) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakePipeline) LabelsReturns(result1 map[string]string) {
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakePipeline) LabelsReturnsOnCall(i int, result1 map[string]string) {
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakePipeline) PausedByInactivity() bool {
+	fake.pausedByInactivityMutex.Lock()
+	ret, specificReturn := fake.pausedByInactivityReturnsOnCall[len(fake.pausedByInactivityArgsForCall)]
+	fake.pausedByInactivityArgsForCall = append(fake.pausedByInactivityArgsForCall, struct{}{})
+	fake.recordInvocation("PausedByInactivity", []interface{}{})
+	fake.pausedByInactivityMutex.Unlock()
+	if fake.PausedByInactivityStub != nil {
+		return fake.PausedByInactivityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pausedByInactivityReturns.result1
+}
+
+func (fake *FakePipeline) PausedByInactivityCallCount() int {
+	fake.pausedByInactivityMutex.RLock()
+	defer fake.pausedByInactivityMutex.RUnlock()
+	return len(fake.pausedByInactivityArgsForCall)
+}
+
+func (fake *FakePipeline) PausedByInactivityReturns(result1 bool) {
+	fake.PausedByInactivityStub = nil
+	fake.pausedByInactivityReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) PausedByInactivityReturnsOnCall(i int, result1 bool) {
+	fake.PausedByInactivityStub = nil
+	if fake.pausedByInactivityReturnsOnCall == nil {
+		fake.pausedByInactivityReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.pausedByInactivityReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) GetNextPendingBuilds() ([]db.Build, error) {
+	fake.getNextPendingBuildsMutex.Lock()
+	ret, specificReturn := fake.getNextPendingBuildsReturnsOnCall[len(fake.getNextPendingBuildsArgsForCall)]
+	fake.getNextPendingBuildsArgsForCall = append(fake.getNextPendingBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("GetNextPendingBuilds", []interface{}{})
+	fake.getNextPendingBuildsMutex.Unlock()
+	if fake.GetNextPendingBuildsStub != nil {
+		return fake.GetNextPendingBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getNextPendingBuildsReturns.result1, fake.getNextPendingBuildsReturns.result2
+}
+
+func (fake *FakePipeline) GetNextPendingBuildsCallCount() int {
+	fake.getNextPendingBuildsMutex.RLock()
+	defer fake.getNextPendingBuildsMutex.RUnlock()
+	return len(fake.getNextPendingBuildsArgsForCall)
+}
+
+func (fake *FakePipeline) GetNextPendingBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetNextPendingBuildsStub = nil
+	fake.getNextPendingBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetNextPendingBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.GetNextPendingBuildsStub = nil
+	if fake.getNextPendingBuildsReturnsOnCall == nil {
+		fake.getNextPendingBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getNextPendingBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2634,6 +2987,20 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.renameMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.networkMutex.RLock()
+	defer fake.networkMutex.RUnlock()
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	fake.getRunningBuildsMutex.RLock()
+	defer fake.getRunningBuildsMutex.RUnlock()
+	fake.softDeleteMutex.RLock()
+	defer fake.softDeleteMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	fake.pausedByInactivityMutex.RLock()
+	defer fake.pausedByInactivityMutex.RUnlock()
+	fake.getNextPendingBuildsMutex.RLock()
+	defer fake.getNextPendingBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,15 +3,17 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 )
 
 type FakePipelineFactory struct {
-	VisiblePipelinesStub        func([]string) ([]db.Pipeline, error)
+	VisiblePipelinesStub        func([]string, db.DashboardFilter) ([]db.Pipeline, error)
 	visiblePipelinesMutex       sync.RWMutex
 	visiblePipelinesArgsForCall []struct {
 		arg1 []string
+		arg2 db.DashboardFilter
 	}
 	visiblePipelinesReturns struct {
 		result1 []db.Pipeline
@@ -32,11 +34,35 @@ type FakePipelineFactory struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PurgeDeletedPipelinesStub        func(gracePeriod time.Duration) error
+	purgeDeletedPipelinesMutex       sync.RWMutex
+	purgeDeletedPipelinesArgsForCall []struct {
+		gracePeriod time.Duration
+	}
+	purgeDeletedPipelinesReturns struct {
+		result1 error
+	}
+	purgeDeletedPipelinesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PauseIdlePipelinesStub        func(idleFor time.Duration) ([]db.Pipeline, error)
+	pauseIdlePipelinesMutex       sync.RWMutex
+	pauseIdlePipelinesArgsForCall []struct {
+		idleFor time.Duration
+	}
+	pauseIdlePipelinesReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	pauseIdlePipelinesReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePipelineFactory) VisiblePipelines(arg1 []string) ([]db.Pipeline, error) {
+func (fake *FakePipelineFactory) VisiblePipelines(arg1 []string, arg2 db.DashboardFilter) ([]db.Pipeline, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -46,11 +72,12 @@ func (fake *FakePipelineFactory) VisiblePipelines(arg1 []string) ([]db.Pipeline,
 	ret, specificReturn := fake.visiblePipelinesReturnsOnCall[len(fake.visiblePipelinesArgsForCall)]
 	fake.visiblePipelinesArgsForCall = append(fake.visiblePipelinesArgsForCall, struct {
 		arg1 []string
-	}{arg1Copy})
-	fake.recordInvocation("VisiblePipelines", []interface{}{arg1Copy})
+		arg2 db.DashboardFilter
+	}{arg1Copy, arg2})
+	fake.recordInvocation("VisiblePipelines", []interface{}{arg1Copy, arg2})
 	fake.visiblePipelinesMutex.Unlock()
 	if fake.VisiblePipelinesStub != nil {
-		return fake.VisiblePipelinesStub(arg1)
+		return fake.VisiblePipelinesStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -64,10 +91,10 @@ func (fake *FakePipelineFactory) VisiblePipelinesCallCount() int {
 	return len(fake.visiblePipelinesArgsForCall)
 }
 
-func (fake *FakePipelineFactory) VisiblePipelinesArgsForCall(i int) []string {
+func (fake *FakePipelineFactory) VisiblePipelinesArgsForCall(i int) ([]string, db.DashboardFilter) {
 	fake.visiblePipelinesMutex.RLock()
 	defer fake.visiblePipelinesMutex.RUnlock()
-	return fake.visiblePipelinesArgsForCall[i].arg1
+	return fake.visiblePipelinesArgsForCall[i].arg1, fake.visiblePipelinesArgsForCall[i].arg2
 }
 
 func (fake *FakePipelineFactory) VisiblePipelinesReturns(result1 []db.Pipeline, result2 error) {
@@ -135,6 +162,105 @@ func (fake *FakePipelineFactory) AllPipelinesReturnsOnCall(i int, result1 []db.P
 	}{result1, result2}
 }
 
+func (fake *FakePipelineFactory) PurgeDeletedPipelines(gracePeriod time.Duration) error {
+	fake.purgeDeletedPipelinesMutex.Lock()
+	ret, specificReturn := fake.purgeDeletedPipelinesReturnsOnCall[len(fake.purgeDeletedPipelinesArgsForCall)]
+	fake.purgeDeletedPipelinesArgsForCall = append(fake.purgeDeletedPipelinesArgsForCall, struct {
+		gracePeriod time.Duration
+	}{gracePeriod})
+	fake.recordInvocation("PurgeDeletedPipelines", []interface{}{gracePeriod})
+	fake.purgeDeletedPipelinesMutex.Unlock()
+	if fake.PurgeDeletedPipelinesStub != nil {
+		return fake.PurgeDeletedPipelinesStub(gracePeriod)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.purgeDeletedPipelinesReturns.result1
+}
+
+func (fake *FakePipelineFactory) PurgeDeletedPipelinesCallCount() int {
+	fake.purgeDeletedPipelinesMutex.RLock()
+	defer fake.purgeDeletedPipelinesMutex.RUnlock()
+	return len(fake.purgeDeletedPipelinesArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PurgeDeletedPipelinesArgsForCall(i int) time.Duration {
+	fake.purgeDeletedPipelinesMutex.RLock()
+	defer fake.purgeDeletedPipelinesMutex.RUnlock()
+	return fake.purgeDeletedPipelinesArgsForCall[i].gracePeriod
+}
+
+func (fake *FakePipelineFactory) PurgeDeletedPipelinesReturns(result1 error) {
+	fake.PurgeDeletedPipelinesStub = nil
+	fake.purgeDeletedPipelinesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineFactory) PurgeDeletedPipelinesReturnsOnCall(i int, result1 error) {
+	fake.PurgeDeletedPipelinesStub = nil
+	if fake.purgeDeletedPipelinesReturnsOnCall == nil {
+		fake.purgeDeletedPipelinesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeDeletedPipelinesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineFactory) PauseIdlePipelines(idleFor time.Duration) ([]db.Pipeline, error) {
+	fake.pauseIdlePipelinesMutex.Lock()
+	ret, specificReturn := fake.pauseIdlePipelinesReturnsOnCall[len(fake.pauseIdlePipelinesArgsForCall)]
+	fake.pauseIdlePipelinesArgsForCall = append(fake.pauseIdlePipelinesArgsForCall, struct {
+		idleFor time.Duration
+	}{idleFor})
+	fake.recordInvocation("PauseIdlePipelines", []interface{}{idleFor})
+	fake.pauseIdlePipelinesMutex.Unlock()
+	if fake.PauseIdlePipelinesStub != nil {
+		return fake.PauseIdlePipelinesStub(idleFor)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pauseIdlePipelinesReturns.result1, fake.pauseIdlePipelinesReturns.result2
+}
+
+func (fake *FakePipelineFactory) PauseIdlePipelinesCallCount() int {
+	fake.pauseIdlePipelinesMutex.RLock()
+	defer fake.pauseIdlePipelinesMutex.RUnlock()
+	return len(fake.pauseIdlePipelinesArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PauseIdlePipelinesArgsForCall(i int) time.Duration {
+	fake.pauseIdlePipelinesMutex.RLock()
+	defer fake.pauseIdlePipelinesMutex.RUnlock()
+	return fake.pauseIdlePipelinesArgsForCall[i].idleFor
+}
+
+func (fake *FakePipelineFactory) PauseIdlePipelinesReturns(result1 []db.Pipeline, result2 error) {
+	fake.PauseIdlePipelinesStub = nil
+	fake.pauseIdlePipelinesReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) PauseIdlePipelinesReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.PauseIdlePipelinesStub = nil
+	if fake.pauseIdlePipelinesReturnsOnCall == nil {
+		fake.pauseIdlePipelinesReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.pauseIdlePipelinesReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -142,6 +268,10 @@ func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	defer fake.visiblePipelinesMutex.RUnlock()
 	fake.allPipelinesMutex.RLock()
 	defer fake.allPipelinesMutex.RUnlock()
+	fake.purgeDeletedPipelinesMutex.RLock()
+	defer fake.purgeDeletedPipelinesMutex.RUnlock()
+	fake.pauseIdlePipelinesMutex.RLock()
+	defer fake.pauseIdlePipelinesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
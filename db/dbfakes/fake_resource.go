@@ -185,6 +185,102 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	VersionHistoryLimitStub        func() int
+	versionHistoryLimitMutex       sync.RWMutex
+	versionHistoryLimitArgsForCall []struct{}
+	versionHistoryLimitReturns     struct {
+		result1 int
+	}
+	versionHistoryLimitReturnsOnCall map[int]struct {
+		result1 int
+	}
+	PruneVersionsStub        func(int) (int, error)
+	pruneVersionsMutex       sync.RWMutex
+	pruneVersionsArgsForCall []struct {
+		arg1 int
+	}
+	pruneVersionsReturns struct {
+		result1 int
+		result2 error
+	}
+	pruneVersionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	CheckDisabledStub        func() bool
+	checkDisabledMutex       sync.RWMutex
+	checkDisabledArgsForCall []struct{}
+	checkDisabledReturns     struct {
+		result1 bool
+	}
+	checkDisabledReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	IgnoreCheckRateLimitStub        func() bool
+	ignoreCheckRateLimitMutex       sync.RWMutex
+	ignoreCheckRateLimitArgsForCall []struct{}
+	ignoreCheckRateLimitReturns     struct {
+		result1 bool
+	}
+	ignoreCheckRateLimitReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	IconStub        func() string
+	iconMutex       sync.RWMutex
+	iconArgsForCall []struct{}
+	iconReturns     struct {
+		result1 string
+	}
+	iconReturnsOnCall map[int]struct {
+		result1 string
+	}
+	DescriptionStub        func() string
+	descriptionMutex       sync.RWMutex
+	descriptionArgsForCall []struct{}
+	descriptionReturns     struct {
+		result1 string
+	}
+	descriptionReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ConfigPinnedVersionStub        func() atc.Version
+	configPinnedVersionMutex       sync.RWMutex
+	configPinnedVersionArgsForCall []struct{}
+	configPinnedVersionReturns     struct {
+		result1 atc.Version
+	}
+	configPinnedVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+	}
+	APIPinnedVersionStub        func() atc.Version
+	aPIPinnedVersionMutex       sync.RWMutex
+	aPIPinnedVersionArgsForCall []struct{}
+	aPIPinnedVersionReturns     struct {
+		result1 atc.Version
+	}
+	aPIPinnedVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+	}
+	PinVersionStub        func(arg1 atc.Version) error
+	pinVersionMutex       sync.RWMutex
+	pinVersionArgsForCall []struct {
+		arg1 atc.Version
+	}
+	pinVersionReturns struct {
+		result1 error
+	}
+	pinVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnpinVersionStub        func() error
+	unpinVersionMutex       sync.RWMutex
+	unpinVersionArgsForCall []struct{}
+	unpinVersionReturns     struct {
+		result1 error
+	}
+	unpinVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -960,6 +1056,425 @@ func (fake *FakeResource) ReloadReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeResource) VersionHistoryLimit() int {
+	fake.versionHistoryLimitMutex.Lock()
+	ret, specificReturn := fake.versionHistoryLimitReturnsOnCall[len(fake.versionHistoryLimitArgsForCall)]
+	fake.versionHistoryLimitArgsForCall = append(fake.versionHistoryLimitArgsForCall, struct{}{})
+	fake.recordInvocation("VersionHistoryLimit", []interface{}{})
+	fake.versionHistoryLimitMutex.Unlock()
+	if fake.VersionHistoryLimitStub != nil {
+		return fake.VersionHistoryLimitStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.versionHistoryLimitReturns.result1
+}
+
+func (fake *FakeResource) VersionHistoryLimitCallCount() int {
+	fake.versionHistoryLimitMutex.RLock()
+	defer fake.versionHistoryLimitMutex.RUnlock()
+	return len(fake.versionHistoryLimitArgsForCall)
+}
+
+func (fake *FakeResource) VersionHistoryLimitReturns(result1 int) {
+	fake.VersionHistoryLimitStub = nil
+	fake.versionHistoryLimitReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) VersionHistoryLimitReturnsOnCall(i int, result1 int) {
+	fake.VersionHistoryLimitStub = nil
+	if fake.versionHistoryLimitReturnsOnCall == nil {
+		fake.versionHistoryLimitReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.versionHistoryLimitReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PruneVersions(arg1 int) (int, error) {
+	fake.pruneVersionsMutex.Lock()
+	ret, specificReturn := fake.pruneVersionsReturnsOnCall[len(fake.pruneVersionsArgsForCall)]
+	fake.pruneVersionsArgsForCall = append(fake.pruneVersionsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("PruneVersions", []interface{}{arg1})
+	fake.pruneVersionsMutex.Unlock()
+	if fake.PruneVersionsStub != nil {
+		return fake.PruneVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pruneVersionsReturns.result1, fake.pruneVersionsReturns.result2
+}
+
+func (fake *FakeResource) PruneVersionsCallCount() int {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	return len(fake.pruneVersionsArgsForCall)
+}
+
+func (fake *FakeResource) PruneVersionsArgsForCall(i int) int {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	return fake.pruneVersionsArgsForCall[i].arg1
+}
+
+func (fake *FakeResource) PruneVersionsReturns(result1 int, result2 error) {
+	fake.PruneVersionsStub = nil
+	fake.pruneVersionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PruneVersionsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.PruneVersionsStub = nil
+	if fake.pruneVersionsReturnsOnCall == nil {
+		fake.pruneVersionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pruneVersionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CheckDisabled() bool {
+	fake.checkDisabledMutex.Lock()
+	ret, specificReturn := fake.checkDisabledReturnsOnCall[len(fake.checkDisabledArgsForCall)]
+	fake.checkDisabledArgsForCall = append(fake.checkDisabledArgsForCall, struct{}{})
+	fake.recordInvocation("CheckDisabled", []interface{}{})
+	fake.checkDisabledMutex.Unlock()
+	if fake.CheckDisabledStub != nil {
+		return fake.CheckDisabledStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.checkDisabledReturns.result1
+}
+
+func (fake *FakeResource) CheckDisabledCallCount() int {
+	fake.checkDisabledMutex.RLock()
+	defer fake.checkDisabledMutex.RUnlock()
+	return len(fake.checkDisabledArgsForCall)
+}
+
+func (fake *FakeResource) CheckDisabledReturns(result1 bool) {
+	fake.CheckDisabledStub = nil
+	fake.checkDisabledReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) CheckDisabledReturnsOnCall(i int, result1 bool) {
+	fake.CheckDisabledStub = nil
+	if fake.checkDisabledReturnsOnCall == nil {
+		fake.checkDisabledReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checkDisabledReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) IgnoreCheckRateLimit() bool {
+	fake.ignoreCheckRateLimitMutex.Lock()
+	ret, specificReturn := fake.ignoreCheckRateLimitReturnsOnCall[len(fake.ignoreCheckRateLimitArgsForCall)]
+	fake.ignoreCheckRateLimitArgsForCall = append(fake.ignoreCheckRateLimitArgsForCall, struct{}{})
+	fake.recordInvocation("IgnoreCheckRateLimit", []interface{}{})
+	fake.ignoreCheckRateLimitMutex.Unlock()
+	if fake.IgnoreCheckRateLimitStub != nil {
+		return fake.IgnoreCheckRateLimitStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.ignoreCheckRateLimitReturns.result1
+}
+
+func (fake *FakeResource) IgnoreCheckRateLimitCallCount() int {
+	fake.ignoreCheckRateLimitMutex.RLock()
+	defer fake.ignoreCheckRateLimitMutex.RUnlock()
+	return len(fake.ignoreCheckRateLimitArgsForCall)
+}
+
+func (fake *FakeResource) IgnoreCheckRateLimitReturns(result1 bool) {
+	fake.IgnoreCheckRateLimitStub = nil
+	fake.ignoreCheckRateLimitReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) IgnoreCheckRateLimitReturnsOnCall(i int, result1 bool) {
+	fake.IgnoreCheckRateLimitStub = nil
+	if fake.ignoreCheckRateLimitReturnsOnCall == nil {
+		fake.ignoreCheckRateLimitReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.ignoreCheckRateLimitReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) Icon() string {
+	fake.iconMutex.Lock()
+	ret, specificReturn := fake.iconReturnsOnCall[len(fake.iconArgsForCall)]
+	fake.iconArgsForCall = append(fake.iconArgsForCall, struct{}{})
+	fake.recordInvocation("Icon", []interface{}{})
+	fake.iconMutex.Unlock()
+	if fake.IconStub != nil {
+		return fake.IconStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.iconReturns.result1
+}
+
+func (fake *FakeResource) IconCallCount() int {
+	fake.iconMutex.RLock()
+	defer fake.iconMutex.RUnlock()
+	return len(fake.iconArgsForCall)
+}
+
+func (fake *FakeResource) IconReturns(result1 string) {
+	fake.IconStub = nil
+	fake.iconReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) IconReturnsOnCall(i int, result1 string) {
+	fake.IconStub = nil
+	if fake.iconReturnsOnCall == nil {
+		fake.iconReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.iconReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) Description() string {
+	fake.descriptionMutex.Lock()
+	ret, specificReturn := fake.descriptionReturnsOnCall[len(fake.descriptionArgsForCall)]
+	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
+	fake.recordInvocation("Description", []interface{}{})
+	fake.descriptionMutex.Unlock()
+	if fake.DescriptionStub != nil {
+		return fake.DescriptionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.descriptionReturns.result1
+}
+
+func (fake *FakeResource) DescriptionCallCount() int {
+	fake.descriptionMutex.RLock()
+	defer fake.descriptionMutex.RUnlock()
+	return len(fake.descriptionArgsForCall)
+}
+
+func (fake *FakeResource) DescriptionReturns(result1 string) {
+	fake.DescriptionStub = nil
+	fake.descriptionReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) DescriptionReturnsOnCall(i int, result1 string) {
+	fake.DescriptionStub = nil
+	if fake.descriptionReturnsOnCall == nil {
+		fake.descriptionReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.descriptionReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) ConfigPinnedVersion() atc.Version {
+	fake.configPinnedVersionMutex.Lock()
+	ret, specificReturn := fake.configPinnedVersionReturnsOnCall[len(fake.configPinnedVersionArgsForCall)]
+	fake.configPinnedVersionArgsForCall = append(fake.configPinnedVersionArgsForCall, struct{}{})
+	fake.recordInvocation("ConfigPinnedVersion", []interface{}{})
+	fake.configPinnedVersionMutex.Unlock()
+	if fake.ConfigPinnedVersionStub != nil {
+		return fake.ConfigPinnedVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.configPinnedVersionReturns.result1
+}
+
+func (fake *FakeResource) ConfigPinnedVersionCallCount() int {
+	fake.configPinnedVersionMutex.RLock()
+	defer fake.configPinnedVersionMutex.RUnlock()
+	return len(fake.configPinnedVersionArgsForCall)
+}
+
+func (fake *FakeResource) ConfigPinnedVersionReturns(result1 atc.Version) {
+	fake.ConfigPinnedVersionStub = nil
+	fake.configPinnedVersionReturns = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) ConfigPinnedVersionReturnsOnCall(i int, result1 atc.Version) {
+	fake.ConfigPinnedVersionStub = nil
+	if fake.configPinnedVersionReturnsOnCall == nil {
+		fake.configPinnedVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+		})
+	}
+	fake.configPinnedVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) APIPinnedVersion() atc.Version {
+	fake.aPIPinnedVersionMutex.Lock()
+	ret, specificReturn := fake.aPIPinnedVersionReturnsOnCall[len(fake.aPIPinnedVersionArgsForCall)]
+	fake.aPIPinnedVersionArgsForCall = append(fake.aPIPinnedVersionArgsForCall, struct{}{})
+	fake.recordInvocation("APIPinnedVersion", []interface{}{})
+	fake.aPIPinnedVersionMutex.Unlock()
+	if fake.APIPinnedVersionStub != nil {
+		return fake.APIPinnedVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.aPIPinnedVersionReturns.result1
+}
+
+func (fake *FakeResource) APIPinnedVersionCallCount() int {
+	fake.aPIPinnedVersionMutex.RLock()
+	defer fake.aPIPinnedVersionMutex.RUnlock()
+	return len(fake.aPIPinnedVersionArgsForCall)
+}
+
+func (fake *FakeResource) APIPinnedVersionReturns(result1 atc.Version) {
+	fake.APIPinnedVersionStub = nil
+	fake.aPIPinnedVersionReturns = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) APIPinnedVersionReturnsOnCall(i int, result1 atc.Version) {
+	fake.APIPinnedVersionStub = nil
+	if fake.aPIPinnedVersionReturnsOnCall == nil {
+		fake.aPIPinnedVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+		})
+	}
+	fake.aPIPinnedVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) PinVersion(arg1 atc.Version) error {
+	fake.pinVersionMutex.Lock()
+	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
+	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
+		arg1 atc.Version
+	}{arg1})
+	fake.recordInvocation("PinVersion", []interface{}{arg1})
+	fake.pinVersionMutex.Unlock()
+	if fake.PinVersionStub != nil {
+		return fake.PinVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinVersionReturns.result1
+}
+
+func (fake *FakeResource) PinVersionCallCount() int {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return len(fake.pinVersionArgsForCall)
+}
+
+func (fake *FakeResource) PinVersionArgsForCall(i int) atc.Version {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return fake.pinVersionArgsForCall[i].arg1
+}
+
+func (fake *FakeResource) PinVersionReturns(result1 error) {
+	fake.PinVersionStub = nil
+	fake.pinVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PinVersionReturnsOnCall(i int, result1 error) {
+	fake.PinVersionStub = nil
+	if fake.pinVersionReturnsOnCall == nil {
+		fake.pinVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pinVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpinVersion() error {
+	fake.unpinVersionMutex.Lock()
+	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
+	fake.unpinVersionArgsForCall = append(fake.unpinVersionArgsForCall, struct{}{})
+	fake.recordInvocation("UnpinVersion", []interface{}{})
+	fake.unpinVersionMutex.Unlock()
+	if fake.UnpinVersionStub != nil {
+		return fake.UnpinVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unpinVersionReturns.result1
+}
+
+func (fake *FakeResource) UnpinVersionCallCount() int {
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
+	return len(fake.unpinVersionArgsForCall)
+}
+
+func (fake *FakeResource) UnpinVersionReturns(result1 error) {
+	fake.UnpinVersionStub = nil
+	fake.unpinVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
+	fake.UnpinVersionStub = nil
+	if fake.unpinVersionReturnsOnCall == nil {
+		fake.unpinVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpinVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1001,6 +1516,26 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpauseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.versionHistoryLimitMutex.RLock()
+	defer fake.versionHistoryLimitMutex.RUnlock()
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	fake.checkDisabledMutex.RLock()
+	defer fake.checkDisabledMutex.RUnlock()
+	fake.ignoreCheckRateLimitMutex.RLock()
+	defer fake.ignoreCheckRateLimitMutex.RUnlock()
+	fake.iconMutex.RLock()
+	defer fake.iconMutex.RUnlock()
+	fake.descriptionMutex.RLock()
+	defer fake.descriptionMutex.RUnlock()
+	fake.configPinnedVersionMutex.RLock()
+	defer fake.configPinnedVersionMutex.RUnlock()
+	fake.aPIPinnedVersionMutex.RLock()
+	defer fake.aPIPinnedVersionMutex.RUnlock()
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
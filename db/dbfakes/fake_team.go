@@ -342,6 +342,137 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AllowPrivilegedStub        func() bool
+	allowPrivilegedMutex       sync.RWMutex
+	allowPrivilegedArgsForCall []struct{}
+	allowPrivilegedReturns     struct {
+		result1 bool
+	}
+	allowPrivilegedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ReloadStub        func() (bool, error)
+	reloadMutex       sync.RWMutex
+	reloadArgsForCall []struct{}
+	reloadReturns     struct {
+		result1 bool
+		result2 error
+	}
+	reloadReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UpdateAllowPrivilegedStub        func(bool) error
+	updateAllowPrivilegedMutex       sync.RWMutex
+	updateAllowPrivilegedArgsForCall []struct {
+		arg1 bool
+	}
+	updateAllowPrivilegedReturns struct {
+		result1 error
+	}
+	updateAllowPrivilegedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WebhooksStub        func() ([]db.Webhook, error)
+	webhooksMutex       sync.RWMutex
+	webhooksArgsForCall []struct{}
+	webhooksReturns     struct {
+		result1 []db.Webhook
+		result2 error
+	}
+	webhooksReturnsOnCall map[int]struct {
+		result1 []db.Webhook
+		result2 error
+	}
+	CreateWebhookStub        func(url string, secret string, events []string) (db.Webhook, error)
+	createWebhookMutex       sync.RWMutex
+	createWebhookArgsForCall []struct {
+		url    string
+		secret string
+		events []string
+	}
+	createWebhookReturns struct {
+		result1 db.Webhook
+		result2 error
+	}
+	createWebhookReturnsOnCall map[int]struct {
+		result1 db.Webhook
+		result2 error
+	}
+	DeleteWebhookStub        func(id int) (bool, error)
+	deleteWebhookMutex       sync.RWMutex
+	deleteWebhookArgsForCall []struct {
+		id int
+	}
+	deleteWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UndeletePipelineStub        func(pipelineName string) (bool, error)
+	undeletePipelineMutex       sync.RWMutex
+	undeletePipelineArgsForCall []struct {
+		pipelineName string
+	}
+	undeletePipelineReturns struct {
+		result1 bool
+		result2 error
+	}
+	undeletePipelineReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ContainerRetentionStub        func() db.ContainerRetention
+	containerRetentionMutex       sync.RWMutex
+	containerRetentionArgsForCall []struct{}
+	containerRetentionReturns     struct {
+		result1 db.ContainerRetention
+	}
+	containerRetentionReturnsOnCall map[int]struct {
+		result1 db.ContainerRetention
+	}
+	UpdateContainerRetentionStub        func(retention db.ContainerRetention) error
+	updateContainerRetentionMutex       sync.RWMutex
+	updateContainerRetentionArgsForCall []struct {
+		retention db.ContainerRetention
+	}
+	updateContainerRetentionReturns struct {
+		result1 error
+	}
+	updateContainerRetentionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AbortBuildsStub        func(db.AbortBuildsFilter, string, string) ([]db.Build, error)
+	abortBuildsMutex       sync.RWMutex
+	abortBuildsArgsForCall []struct {
+		filter    db.AbortBuildsFilter
+		reason    string
+		abortedBy string
+	}
+	abortBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	abortBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	UsageStub        func(since time.Time) ([]db.TeamUsage, error)
+	usageMutex       sync.RWMutex
+	usageArgsForCall []struct {
+		since time.Time
+	}
+	usageReturns struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
+	usageReturnsOnCall map[int]struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1610,6 +1741,532 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) AllowPrivileged() bool {
+	fake.allowPrivilegedMutex.Lock()
+	ret, specificReturn := fake.allowPrivilegedReturnsOnCall[len(fake.allowPrivilegedArgsForCall)]
+	fake.allowPrivilegedArgsForCall = append(fake.allowPrivilegedArgsForCall, struct{}{})
+	fake.recordInvocation("AllowPrivileged", []interface{}{})
+	fake.allowPrivilegedMutex.Unlock()
+	if fake.AllowPrivilegedStub != nil {
+		return fake.AllowPrivilegedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.allowPrivilegedReturns.result1
+}
+
+func (fake *FakeTeam) AllowPrivilegedCallCount() int {
+	fake.allowPrivilegedMutex.RLock()
+	defer fake.allowPrivilegedMutex.RUnlock()
+	return len(fake.allowPrivilegedArgsForCall)
+}
+
+func (fake *FakeTeam) AllowPrivilegedReturns(result1 bool) {
+	fake.AllowPrivilegedStub = nil
+	fake.allowPrivilegedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeTeam) AllowPrivilegedReturnsOnCall(i int, result1 bool) {
+	fake.AllowPrivilegedStub = nil
+	if fake.allowPrivilegedReturnsOnCall == nil {
+		fake.allowPrivilegedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.allowPrivilegedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeTeam) Reload() (bool, error) {
+	fake.reloadMutex.Lock()
+	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
+	fake.reloadArgsForCall = append(fake.reloadArgsForCall, struct{}{})
+	fake.recordInvocation("Reload", []interface{}{})
+	fake.reloadMutex.Unlock()
+	if fake.ReloadStub != nil {
+		return fake.ReloadStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.reloadReturns.result1, fake.reloadReturns.result2
+}
+
+func (fake *FakeTeam) ReloadCallCount() int {
+	fake.reloadMutex.RLock()
+	defer fake.reloadMutex.RUnlock()
+	return len(fake.reloadArgsForCall)
+}
+
+func (fake *FakeTeam) ReloadReturns(result1 bool, result2 error) {
+	fake.ReloadStub = nil
+	fake.reloadReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ReloadStub = nil
+	if fake.reloadReturnsOnCall == nil {
+		fake.reloadReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reloadReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateAllowPrivileged(arg1 bool) error {
+	fake.updateAllowPrivilegedMutex.Lock()
+	ret, specificReturn := fake.updateAllowPrivilegedReturnsOnCall[len(fake.updateAllowPrivilegedArgsForCall)]
+	fake.updateAllowPrivilegedArgsForCall = append(fake.updateAllowPrivilegedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("UpdateAllowPrivileged", []interface{}{arg1})
+	fake.updateAllowPrivilegedMutex.Unlock()
+	if fake.UpdateAllowPrivilegedStub != nil {
+		return fake.UpdateAllowPrivilegedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateAllowPrivilegedReturns.result1
+}
+
+func (fake *FakeTeam) UpdateAllowPrivilegedCallCount() int {
+	fake.updateAllowPrivilegedMutex.RLock()
+	defer fake.updateAllowPrivilegedMutex.RUnlock()
+	return len(fake.updateAllowPrivilegedArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateAllowPrivilegedArgsForCall(i int) bool {
+	fake.updateAllowPrivilegedMutex.RLock()
+	defer fake.updateAllowPrivilegedMutex.RUnlock()
+	return fake.updateAllowPrivilegedArgsForCall[i].arg1
+}
+
+func (fake *FakeTeam) UpdateAllowPrivilegedReturns(result1 error) {
+	fake.UpdateAllowPrivilegedStub = nil
+	fake.updateAllowPrivilegedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateAllowPrivilegedReturnsOnCall(i int, result1 error) {
+	fake.UpdateAllowPrivilegedStub = nil
+	if fake.updateAllowPrivilegedReturnsOnCall == nil {
+		fake.updateAllowPrivilegedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAllowPrivilegedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) Webhooks() ([]db.Webhook, error) {
+	fake.webhooksMutex.Lock()
+	ret, specificReturn := fake.webhooksReturnsOnCall[len(fake.webhooksArgsForCall)]
+	fake.webhooksArgsForCall = append(fake.webhooksArgsForCall, struct{}{})
+	fake.recordInvocation("Webhooks", []interface{}{})
+	fake.webhooksMutex.Unlock()
+	if fake.WebhooksStub != nil {
+		return fake.WebhooksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.webhooksReturns.result1, fake.webhooksReturns.result2
+}
+
+func (fake *FakeTeam) WebhooksCallCount() int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	return len(fake.webhooksArgsForCall)
+}
+
+func (fake *FakeTeam) WebhooksReturns(result1 []db.Webhook, result2 error) {
+	fake.WebhooksStub = nil
+	fake.webhooksReturns = struct {
+		result1 []db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) WebhooksReturnsOnCall(i int, result1 []db.Webhook, result2 error) {
+	fake.WebhooksStub = nil
+	if fake.webhooksReturnsOnCall == nil {
+		fake.webhooksReturnsOnCall = make(map[int]struct {
+			result1 []db.Webhook
+			result2 error
+		})
+	}
+	fake.webhooksReturnsOnCall[i] = struct {
+		result1 []db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateWebhook(url string, secret string, events []string) (db.Webhook, error) {
+	var eventsCopy []string
+	if events != nil {
+		eventsCopy = make([]string, len(events))
+		copy(eventsCopy, events)
+	}
+	fake.createWebhookMutex.Lock()
+	ret, specificReturn := fake.createWebhookReturnsOnCall[len(fake.createWebhookArgsForCall)]
+	fake.createWebhookArgsForCall = append(fake.createWebhookArgsForCall, struct {
+		url    string
+		secret string
+		events []string
+	}{url, secret, eventsCopy})
+	fake.recordInvocation("CreateWebhook", []interface{}{url, secret, eventsCopy})
+	fake.createWebhookMutex.Unlock()
+	if fake.CreateWebhookStub != nil {
+		return fake.CreateWebhookStub(url, secret, events)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createWebhookReturns.result1, fake.createWebhookReturns.result2
+}
+
+func (fake *FakeTeam) CreateWebhookCallCount() int {
+	fake.createWebhookMutex.RLock()
+	defer fake.createWebhookMutex.RUnlock()
+	return len(fake.createWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) CreateWebhookArgsForCall(i int) (string, string, []string) {
+	fake.createWebhookMutex.RLock()
+	defer fake.createWebhookMutex.RUnlock()
+	return fake.createWebhookArgsForCall[i].url, fake.createWebhookArgsForCall[i].secret, fake.createWebhookArgsForCall[i].events
+}
+
+func (fake *FakeTeam) CreateWebhookReturns(result1 db.Webhook, result2 error) {
+	fake.CreateWebhookStub = nil
+	fake.createWebhookReturns = struct {
+		result1 db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateWebhookReturnsOnCall(i int, result1 db.Webhook, result2 error) {
+	fake.CreateWebhookStub = nil
+	if fake.createWebhookReturnsOnCall == nil {
+		fake.createWebhookReturnsOnCall = make(map[int]struct {
+			result1 db.Webhook
+			result2 error
+		})
+	}
+	fake.createWebhookReturnsOnCall[i] = struct {
+		result1 db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhook(id int) (bool, error) {
+	fake.deleteWebhookMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookReturnsOnCall[len(fake.deleteWebhookArgsForCall)]
+	fake.deleteWebhookArgsForCall = append(fake.deleteWebhookArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteWebhook", []interface{}{id})
+	fake.deleteWebhookMutex.Unlock()
+	if fake.DeleteWebhookStub != nil {
+		return fake.DeleteWebhookStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteWebhookReturns.result1, fake.deleteWebhookReturns.result2
+}
+
+func (fake *FakeTeam) DeleteWebhookCallCount() int {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return len(fake.deleteWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteWebhookArgsForCall(i int) int {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return fake.deleteWebhookArgsForCall[i].id
+}
+
+func (fake *FakeTeam) DeleteWebhookReturns(result1 bool, result2 error) {
+	fake.DeleteWebhookStub = nil
+	fake.deleteWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteWebhookStub = nil
+	if fake.deleteWebhookReturnsOnCall == nil {
+		fake.deleteWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UndeletePipeline(pipelineName string) (bool, error) {
+	fake.undeletePipelineMutex.Lock()
+	ret, specificReturn := fake.undeletePipelineReturnsOnCall[len(fake.undeletePipelineArgsForCall)]
+	fake.undeletePipelineArgsForCall = append(fake.undeletePipelineArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("UndeletePipeline", []interface{}{pipelineName})
+	fake.undeletePipelineMutex.Unlock()
+	if fake.UndeletePipelineStub != nil {
+		return fake.UndeletePipelineStub(pipelineName)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.undeletePipelineReturns.result1, fake.undeletePipelineReturns.result2
+}
+
+func (fake *FakeTeam) UndeletePipelineCallCount() int {
+	fake.undeletePipelineMutex.RLock()
+	defer fake.undeletePipelineMutex.RUnlock()
+	return len(fake.undeletePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) UndeletePipelineArgsForCall(i int) string {
+	fake.undeletePipelineMutex.RLock()
+	defer fake.undeletePipelineMutex.RUnlock()
+	return fake.undeletePipelineArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeam) UndeletePipelineReturns(result1 bool, result2 error) {
+	fake.UndeletePipelineStub = nil
+	fake.undeletePipelineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UndeletePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.UndeletePipelineStub = nil
+	if fake.undeletePipelineReturnsOnCall == nil {
+		fake.undeletePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.undeletePipelineReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ContainerRetention() db.ContainerRetention {
+	fake.containerRetentionMutex.Lock()
+	ret, specificReturn := fake.containerRetentionReturnsOnCall[len(fake.containerRetentionArgsForCall)]
+	fake.containerRetentionArgsForCall = append(fake.containerRetentionArgsForCall, struct{}{})
+	fake.recordInvocation("ContainerRetention", []interface{}{})
+	fake.containerRetentionMutex.Unlock()
+	if fake.ContainerRetentionStub != nil {
+		return fake.ContainerRetentionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.containerRetentionReturns.result1
+}
+
+func (fake *FakeTeam) ContainerRetentionCallCount() int {
+	fake.containerRetentionMutex.RLock()
+	defer fake.containerRetentionMutex.RUnlock()
+	return len(fake.containerRetentionArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerRetentionReturns(result1 db.ContainerRetention) {
+	fake.ContainerRetentionStub = nil
+	fake.containerRetentionReturns = struct {
+		result1 db.ContainerRetention
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerRetentionReturnsOnCall(i int, result1 db.ContainerRetention) {
+	fake.ContainerRetentionStub = nil
+	if fake.containerRetentionReturnsOnCall == nil {
+		fake.containerRetentionReturnsOnCall = make(map[int]struct {
+			result1 db.ContainerRetention
+		})
+	}
+	fake.containerRetentionReturnsOnCall[i] = struct {
+		result1 db.ContainerRetention
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerRetention(retention db.ContainerRetention) error {
+	fake.updateContainerRetentionMutex.Lock()
+	ret, specificReturn := fake.updateContainerRetentionReturnsOnCall[len(fake.updateContainerRetentionArgsForCall)]
+	fake.updateContainerRetentionArgsForCall = append(fake.updateContainerRetentionArgsForCall, struct {
+		retention db.ContainerRetention
+	}{retention})
+	fake.recordInvocation("UpdateContainerRetention", []interface{}{retention})
+	fake.updateContainerRetentionMutex.Unlock()
+	if fake.UpdateContainerRetentionStub != nil {
+		return fake.UpdateContainerRetentionStub(retention)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateContainerRetentionReturns.result1
+}
+
+func (fake *FakeTeam) UpdateContainerRetentionCallCount() int {
+	fake.updateContainerRetentionMutex.RLock()
+	defer fake.updateContainerRetentionMutex.RUnlock()
+	return len(fake.updateContainerRetentionArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateContainerRetentionArgsForCall(i int) db.ContainerRetention {
+	fake.updateContainerRetentionMutex.RLock()
+	defer fake.updateContainerRetentionMutex.RUnlock()
+	return fake.updateContainerRetentionArgsForCall[i].retention
+}
+
+func (fake *FakeTeam) UpdateContainerRetentionReturns(result1 error) {
+	fake.UpdateContainerRetentionStub = nil
+	fake.updateContainerRetentionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerRetentionReturnsOnCall(i int, result1 error) {
+	fake.UpdateContainerRetentionStub = nil
+	if fake.updateContainerRetentionReturnsOnCall == nil {
+		fake.updateContainerRetentionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateContainerRetentionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) AbortBuilds(filter db.AbortBuildsFilter, reason string, abortedBy string) ([]db.Build, error) {
+	fake.abortBuildsMutex.Lock()
+	ret, specificReturn := fake.abortBuildsReturnsOnCall[len(fake.abortBuildsArgsForCall)]
+	fake.abortBuildsArgsForCall = append(fake.abortBuildsArgsForCall, struct {
+		filter    db.AbortBuildsFilter
+		reason    string
+		abortedBy string
+	}{filter, reason, abortedBy})
+	fake.recordInvocation("AbortBuilds", []interface{}{filter, reason, abortedBy})
+	fake.abortBuildsMutex.Unlock()
+	if fake.AbortBuildsStub != nil {
+		return fake.AbortBuildsStub(filter, reason, abortedBy)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.abortBuildsReturns.result1, fake.abortBuildsReturns.result2
+}
+
+func (fake *FakeTeam) AbortBuildsCallCount() int {
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	return len(fake.abortBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) AbortBuildsArgsForCall(i int) (db.AbortBuildsFilter, string, string) {
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	return fake.abortBuildsArgsForCall[i].filter, fake.abortBuildsArgsForCall[i].reason, fake.abortBuildsArgsForCall[i].abortedBy
+}
+
+func (fake *FakeTeam) AbortBuildsReturns(result1 []db.Build, result2 error) {
+	fake.AbortBuildsStub = nil
+	fake.abortBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) AbortBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.AbortBuildsStub = nil
+	if fake.abortBuildsReturnsOnCall == nil {
+		fake.abortBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.abortBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Usage(since time.Time) ([]db.TeamUsage, error) {
+	fake.usageMutex.Lock()
+	ret, specificReturn := fake.usageReturnsOnCall[len(fake.usageArgsForCall)]
+	fake.usageArgsForCall = append(fake.usageArgsForCall, struct {
+		since time.Time
+	}{since})
+	fake.recordInvocation("Usage", []interface{}{since})
+	fake.usageMutex.Unlock()
+	if fake.UsageStub != nil {
+		return fake.UsageStub(since)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.usageReturns.result1, fake.usageReturns.result2
+}
+
+func (fake *FakeTeam) UsageCallCount() int {
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
+	return len(fake.usageArgsForCall)
+}
+
+func (fake *FakeTeam) UsageArgsForCall(i int) time.Time {
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
+	return fake.usageArgsForCall[i].since
+}
+
+func (fake *FakeTeam) UsageReturns(result1 []db.TeamUsage, result2 error) {
+	fake.UsageStub = nil
+	fake.usageReturns = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UsageReturnsOnCall(i int, result1 []db.TeamUsage, result2 error) {
+	fake.UsageStub = nil
+	if fake.usageReturnsOnCall == nil {
+		fake.usageReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamUsage
+			result2 error
+		})
+	}
+	fake.usageReturnsOnCall[i] = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1665,6 +2322,28 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createContainerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.allowPrivilegedMutex.RLock()
+	defer fake.allowPrivilegedMutex.RUnlock()
+	fake.reloadMutex.RLock()
+	defer fake.reloadMutex.RUnlock()
+	fake.updateAllowPrivilegedMutex.RLock()
+	defer fake.updateAllowPrivilegedMutex.RUnlock()
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	fake.createWebhookMutex.RLock()
+	defer fake.createWebhookMutex.RUnlock()
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	fake.undeletePipelineMutex.RLock()
+	defer fake.undeletePipelineMutex.RUnlock()
+	fake.containerRetentionMutex.RLock()
+	defer fake.containerRetentionMutex.RUnlock()
+	fake.updateContainerRetentionMutex.RLock()
+	defer fake.updateContainerRetentionMutex.RUnlock()
+	fake.abortBuildsMutex.RLock()
+	defer fake.abortBuildsMutex.RUnlock()
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
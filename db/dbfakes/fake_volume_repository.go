@@ -238,6 +238,64 @@ type FakeVolumeRepository struct {
 		result1 int
 		result2 error
 	}
+	FindResourceCacheVolumeByIDStub        func(id int) (db.CreatedVolume, bool, error)
+	findResourceCacheVolumeByIDMutex       sync.RWMutex
+	findResourceCacheVolumeByIDArgsForCall []struct {
+		id int
+	}
+	findResourceCacheVolumeByIDReturns struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	findResourceCacheVolumeByIDReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	GetResourceCacheVolumesStub        func(resourceHash string) ([]db.ResourceCacheVolume, error)
+	getResourceCacheVolumesMutex       sync.RWMutex
+	getResourceCacheVolumesArgsForCall []struct {
+		resourceHash string
+	}
+	getResourceCacheVolumesReturns struct {
+		result1 []db.ResourceCacheVolume
+		result2 error
+	}
+	getResourceCacheVolumesReturnsOnCall map[int]struct {
+		result1 []db.ResourceCacheVolume
+		result2 error
+	}
+	FindBuildInputUploadVolumeStub        func(teamID int, upload *db.BuildInputUpload) (db.CreatingVolume, db.CreatedVolume, error)
+	findBuildInputUploadVolumeMutex       sync.RWMutex
+	findBuildInputUploadVolumeArgsForCall []struct {
+		teamID int
+		upload *db.BuildInputUpload
+	}
+	findBuildInputUploadVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 db.CreatedVolume
+		result3 error
+	}
+	findBuildInputUploadVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 db.CreatedVolume
+		result3 error
+	}
+	CreateBuildInputUploadVolumeStub        func(teamID int, upload *db.BuildInputUpload) (db.CreatingVolume, error)
+	createBuildInputUploadVolumeMutex       sync.RWMutex
+	createBuildInputUploadVolumeArgsForCall []struct {
+		teamID int
+		upload *db.BuildInputUpload
+	}
+	createBuildInputUploadVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	createBuildInputUploadVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1079,6 +1137,218 @@ func (fake *FakeVolumeRepository) RemoveDestroyingVolumesReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) FindResourceCacheVolumeByID(id int) (db.CreatedVolume, bool, error) {
+	fake.findResourceCacheVolumeByIDMutex.Lock()
+	ret, specificReturn := fake.findResourceCacheVolumeByIDReturnsOnCall[len(fake.findResourceCacheVolumeByIDArgsForCall)]
+	fake.findResourceCacheVolumeByIDArgsForCall = append(fake.findResourceCacheVolumeByIDArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("FindResourceCacheVolumeByID", []interface{}{id})
+	fake.findResourceCacheVolumeByIDMutex.Unlock()
+	if fake.FindResourceCacheVolumeByIDStub != nil {
+		return fake.FindResourceCacheVolumeByIDStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findResourceCacheVolumeByIDReturns.result1, fake.findResourceCacheVolumeByIDReturns.result2, fake.findResourceCacheVolumeByIDReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheVolumeByIDCallCount() int {
+	fake.findResourceCacheVolumeByIDMutex.RLock()
+	defer fake.findResourceCacheVolumeByIDMutex.RUnlock()
+	return len(fake.findResourceCacheVolumeByIDArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheVolumeByIDArgsForCall(i int) int {
+	fake.findResourceCacheVolumeByIDMutex.RLock()
+	defer fake.findResourceCacheVolumeByIDMutex.RUnlock()
+	return fake.findResourceCacheVolumeByIDArgsForCall[i].id
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheVolumeByIDReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.FindResourceCacheVolumeByIDStub = nil
+	fake.findResourceCacheVolumeByIDReturns = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheVolumeByIDReturnsOnCall(i int, result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.FindResourceCacheVolumeByIDStub = nil
+	if fake.findResourceCacheVolumeByIDReturnsOnCall == nil {
+		fake.findResourceCacheVolumeByIDReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findResourceCacheVolumeByIDReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumes(resourceHash string) ([]db.ResourceCacheVolume, error) {
+	fake.getResourceCacheVolumesMutex.Lock()
+	ret, specificReturn := fake.getResourceCacheVolumesReturnsOnCall[len(fake.getResourceCacheVolumesArgsForCall)]
+	fake.getResourceCacheVolumesArgsForCall = append(fake.getResourceCacheVolumesArgsForCall, struct {
+		resourceHash string
+	}{resourceHash})
+	fake.recordInvocation("GetResourceCacheVolumes", []interface{}{resourceHash})
+	fake.getResourceCacheVolumesMutex.Unlock()
+	if fake.GetResourceCacheVolumesStub != nil {
+		return fake.GetResourceCacheVolumesStub(resourceHash)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getResourceCacheVolumesReturns.result1, fake.getResourceCacheVolumesReturns.result2
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesCallCount() int {
+	fake.getResourceCacheVolumesMutex.RLock()
+	defer fake.getResourceCacheVolumesMutex.RUnlock()
+	return len(fake.getResourceCacheVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesArgsForCall(i int) string {
+	fake.getResourceCacheVolumesMutex.RLock()
+	defer fake.getResourceCacheVolumesMutex.RUnlock()
+	return fake.getResourceCacheVolumesArgsForCall[i].resourceHash
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesReturns(result1 []db.ResourceCacheVolume, result2 error) {
+	fake.GetResourceCacheVolumesStub = nil
+	fake.getResourceCacheVolumesReturns = struct {
+		result1 []db.ResourceCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesReturnsOnCall(i int, result1 []db.ResourceCacheVolume, result2 error) {
+	fake.GetResourceCacheVolumesStub = nil
+	if fake.getResourceCacheVolumesReturnsOnCall == nil {
+		fake.getResourceCacheVolumesReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceCacheVolume
+			result2 error
+		})
+	}
+	fake.getResourceCacheVolumesReturnsOnCall[i] = struct {
+		result1 []db.ResourceCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) FindBuildInputUploadVolume(teamID int, upload *db.BuildInputUpload) (db.CreatingVolume, db.CreatedVolume, error) {
+	fake.findBuildInputUploadVolumeMutex.Lock()
+	ret, specificReturn := fake.findBuildInputUploadVolumeReturnsOnCall[len(fake.findBuildInputUploadVolumeArgsForCall)]
+	fake.findBuildInputUploadVolumeArgsForCall = append(fake.findBuildInputUploadVolumeArgsForCall, struct {
+		teamID int
+		upload *db.BuildInputUpload
+	}{teamID, upload})
+	fake.recordInvocation("FindBuildInputUploadVolume", []interface{}{teamID, upload})
+	fake.findBuildInputUploadVolumeMutex.Unlock()
+	if fake.FindBuildInputUploadVolumeStub != nil {
+		return fake.FindBuildInputUploadVolumeStub(teamID, upload)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findBuildInputUploadVolumeReturns.result1, fake.findBuildInputUploadVolumeReturns.result2, fake.findBuildInputUploadVolumeReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindBuildInputUploadVolumeCallCount() int {
+	fake.findBuildInputUploadVolumeMutex.RLock()
+	defer fake.findBuildInputUploadVolumeMutex.RUnlock()
+	return len(fake.findBuildInputUploadVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindBuildInputUploadVolumeArgsForCall(i int) (int, *db.BuildInputUpload) {
+	fake.findBuildInputUploadVolumeMutex.RLock()
+	defer fake.findBuildInputUploadVolumeMutex.RUnlock()
+	return fake.findBuildInputUploadVolumeArgsForCall[i].teamID, fake.findBuildInputUploadVolumeArgsForCall[i].upload
+}
+
+func (fake *FakeVolumeRepository) FindBuildInputUploadVolumeReturns(result1 db.CreatingVolume, result2 db.CreatedVolume, result3 error) {
+	fake.FindBuildInputUploadVolumeStub = nil
+	fake.findBuildInputUploadVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 db.CreatedVolume
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindBuildInputUploadVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 db.CreatedVolume, result3 error) {
+	fake.FindBuildInputUploadVolumeStub = nil
+	if fake.findBuildInputUploadVolumeReturnsOnCall == nil {
+		fake.findBuildInputUploadVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 db.CreatedVolume
+			result3 error
+		})
+	}
+	fake.findBuildInputUploadVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 db.CreatedVolume
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) CreateBuildInputUploadVolume(teamID int, upload *db.BuildInputUpload) (db.CreatingVolume, error) {
+	fake.createBuildInputUploadVolumeMutex.Lock()
+	ret, specificReturn := fake.createBuildInputUploadVolumeReturnsOnCall[len(fake.createBuildInputUploadVolumeArgsForCall)]
+	fake.createBuildInputUploadVolumeArgsForCall = append(fake.createBuildInputUploadVolumeArgsForCall, struct {
+		teamID int
+		upload *db.BuildInputUpload
+	}{teamID, upload})
+	fake.recordInvocation("CreateBuildInputUploadVolume", []interface{}{teamID, upload})
+	fake.createBuildInputUploadVolumeMutex.Unlock()
+	if fake.CreateBuildInputUploadVolumeStub != nil {
+		return fake.CreateBuildInputUploadVolumeStub(teamID, upload)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildInputUploadVolumeReturns.result1, fake.createBuildInputUploadVolumeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) CreateBuildInputUploadVolumeCallCount() int {
+	fake.createBuildInputUploadVolumeMutex.RLock()
+	defer fake.createBuildInputUploadVolumeMutex.RUnlock()
+	return len(fake.createBuildInputUploadVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) CreateBuildInputUploadVolumeArgsForCall(i int) (int, *db.BuildInputUpload) {
+	fake.createBuildInputUploadVolumeMutex.RLock()
+	defer fake.createBuildInputUploadVolumeMutex.RUnlock()
+	return fake.createBuildInputUploadVolumeArgsForCall[i].teamID, fake.createBuildInputUploadVolumeArgsForCall[i].upload
+}
+
+func (fake *FakeVolumeRepository) CreateBuildInputUploadVolumeReturns(result1 db.CreatingVolume, result2 error) {
+	fake.CreateBuildInputUploadVolumeStub = nil
+	fake.createBuildInputUploadVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CreateBuildInputUploadVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 error) {
+	fake.CreateBuildInputUploadVolumeStub = nil
+	if fake.createBuildInputUploadVolumeReturnsOnCall == nil {
+		fake.createBuildInputUploadVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createBuildInputUploadVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1114,6 +1384,14 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.findCreatedVolumeMutex.RUnlock()
 	fake.removeDestroyingVolumesMutex.RLock()
 	defer fake.removeDestroyingVolumesMutex.RUnlock()
+	fake.findResourceCacheVolumeByIDMutex.RLock()
+	defer fake.findResourceCacheVolumeByIDMutex.RUnlock()
+	fake.getResourceCacheVolumesMutex.RLock()
+	defer fake.getResourceCacheVolumesMutex.RUnlock()
+	fake.findBuildInputUploadVolumeMutex.RLock()
+	defer fake.findBuildInputUploadVolumeMutex.RUnlock()
+	fake.createBuildInputUploadVolumeMutex.RLock()
+	defer fake.createBuildInputUploadVolumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
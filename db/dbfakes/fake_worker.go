@@ -232,6 +232,33 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RuntimeStub        func() string
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct{}
+	runtimeReturns     struct {
+		result1 string
+	}
+	runtimeReturnsOnCall map[int]struct {
+		result1 string
+	}
+	LoadStub        func() *atc.WorkerLoad
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct{}
+	loadReturns     struct {
+		result1 *atc.WorkerLoad
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 *atc.WorkerLoad
+	}
+	DeniesNetworkByDefaultStub        func() bool
+	deniesNetworkByDefaultMutex       sync.RWMutex
+	deniesNetworkByDefaultArgsForCall []struct{}
+	deniesNetworkByDefaultReturns     struct {
+		result1 bool
+	}
+	deniesNetworkByDefaultReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1205,6 +1232,126 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Runtime() string {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct{}{})
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if fake.RuntimeStub != nil {
+		return fake.RuntimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.runtimeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 string) {
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 string) {
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) Load() *atc.WorkerLoad {
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct{}{})
+	fake.recordInvocation("Load", []interface{}{})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.loadReturns.result1
+}
+
+func (fake *FakeWorker) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeWorker) LoadReturns(result1 *atc.WorkerLoad) {
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 *atc.WorkerLoad
+	}{result1}
+}
+
+func (fake *FakeWorker) LoadReturnsOnCall(i int, result1 *atc.WorkerLoad) {
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerLoad
+		})
+	}
+	fake.loadReturnsOnCall[i] = struct {
+		result1 *atc.WorkerLoad
+	}{result1}
+}
+
+func (fake *FakeWorker) DeniesNetworkByDefault() bool {
+	fake.deniesNetworkByDefaultMutex.Lock()
+	ret, specificReturn := fake.deniesNetworkByDefaultReturnsOnCall[len(fake.deniesNetworkByDefaultArgsForCall)]
+	fake.deniesNetworkByDefaultArgsForCall = append(fake.deniesNetworkByDefaultArgsForCall, struct{}{})
+	fake.recordInvocation("DeniesNetworkByDefault", []interface{}{})
+	fake.deniesNetworkByDefaultMutex.Unlock()
+	if fake.DeniesNetworkByDefaultStub != nil {
+		return fake.DeniesNetworkByDefaultStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deniesNetworkByDefaultReturns.result1
+}
+
+func (fake *FakeWorker) DeniesNetworkByDefaultCallCount() int {
+	fake.deniesNetworkByDefaultMutex.RLock()
+	defer fake.deniesNetworkByDefaultMutex.RUnlock()
+	return len(fake.deniesNetworkByDefaultArgsForCall)
+}
+
+func (fake *FakeWorker) DeniesNetworkByDefaultReturns(result1 bool) {
+	fake.DeniesNetworkByDefaultStub = nil
+	fake.deniesNetworkByDefaultReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DeniesNetworkByDefaultReturnsOnCall(i int, result1 bool) {
+	fake.DeniesNetworkByDefaultStub = nil
+	if fake.deniesNetworkByDefaultReturnsOnCall == nil {
+		fake.deniesNetworkByDefaultReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.deniesNetworkByDefaultReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1256,6 +1403,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	fake.deniesNetworkByDefaultMutex.RLock()
+	defer fake.deniesNetworkByDefaultMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
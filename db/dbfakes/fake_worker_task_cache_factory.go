@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 )
@@ -42,6 +43,19 @@ type FakeWorkerTaskCacheFactory struct {
 		result1 *db.UsedWorkerTaskCache
 		result2 error
 	}
+	RemoveIdleStub        func(idleFor time.Duration) (int, error)
+	removeIdleMutex       sync.RWMutex
+	removeIdleArgsForCall []struct {
+		idleFor time.Duration
+	}
+	removeIdleReturns struct {
+		result1 int
+		result2 error
+	}
+	removeIdleReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -157,6 +171,57 @@ func (fake *FakeWorkerTaskCacheFactory) FindOrCreateReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerTaskCacheFactory) RemoveIdle(idleFor time.Duration) (int, error) {
+	fake.removeIdleMutex.Lock()
+	ret, specificReturn := fake.removeIdleReturnsOnCall[len(fake.removeIdleArgsForCall)]
+	fake.removeIdleArgsForCall = append(fake.removeIdleArgsForCall, struct {
+		idleFor time.Duration
+	}{idleFor})
+	fake.recordInvocation("RemoveIdle", []interface{}{idleFor})
+	fake.removeIdleMutex.Unlock()
+	if fake.RemoveIdleStub != nil {
+		return fake.RemoveIdleStub(idleFor)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.removeIdleReturns.result1, fake.removeIdleReturns.result2
+}
+
+func (fake *FakeWorkerTaskCacheFactory) RemoveIdleCallCount() int {
+	fake.removeIdleMutex.RLock()
+	defer fake.removeIdleMutex.RUnlock()
+	return len(fake.removeIdleArgsForCall)
+}
+
+func (fake *FakeWorkerTaskCacheFactory) RemoveIdleArgsForCall(i int) time.Duration {
+	fake.removeIdleMutex.RLock()
+	defer fake.removeIdleMutex.RUnlock()
+	return fake.removeIdleArgsForCall[i].idleFor
+}
+
+func (fake *FakeWorkerTaskCacheFactory) RemoveIdleReturns(result1 int, result2 error) {
+	fake.RemoveIdleStub = nil
+	fake.removeIdleReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) RemoveIdleReturnsOnCall(i int, result1 int, result2 error) {
+	fake.RemoveIdleStub = nil
+	if fake.removeIdleReturnsOnCall == nil {
+		fake.removeIdleReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeIdleReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerTaskCacheFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -164,6 +229,8 @@ func (fake *FakeWorkerTaskCacheFactory) Invocations() map[string][][]interface{}
 	defer fake.findMutex.RUnlock()
 	fake.findOrCreateMutex.RLock()
 	defer fake.findOrCreateMutex.RUnlock()
+	fake.removeIdleMutex.RLock()
+	defer fake.removeIdleMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"database/sql"
+	"math/rand"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// DelayQueries wraps the connection such that every query and transaction is
+// delayed by a random duration of up to maxDelay. It is only intended to be
+// used in development, to test how the rest of the ATC copes with a slow
+// database.
+func DelayQueries(conn Conn, maxDelay time.Duration) Conn {
+	return &delayingConn{
+		Conn:     conn,
+		maxDelay: maxDelay,
+	}
+}
+
+type delayingConn struct {
+	Conn
+
+	maxDelay time.Duration
+}
+
+func (c *delayingConn) Begin() (Tx, error) {
+	c.delay()
+	return c.Conn.Begin()
+}
+
+func (c *delayingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	c.delay()
+	return c.Conn.Query(query, args...)
+}
+
+func (c *delayingConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	c.delay()
+	return c.Conn.QueryRow(query, args...)
+}
+
+func (c *delayingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	c.delay()
+	return c.Conn.Exec(query, args...)
+}
+
+func (c *delayingConn) delay() {
+	if c.maxDelay > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(c.maxDelay))))
+	}
+}
+
+// DropHeartbeats wraps the worker factory such that the given fraction of
+// worker heartbeats are silently dropped, leaving the worker's TTL to run
+// out. It is only intended to be used in development.
+func DropHeartbeats(logger lager.Logger, factory WorkerFactory, rate float64) WorkerFactory {
+	return &droppingWorkerFactory{
+		WorkerFactory: factory,
+		logger:        logger,
+		rate:          rate,
+	}
+}
+
+type droppingWorkerFactory struct {
+	WorkerFactory
+
+	logger lager.Logger
+	rate   float64
+}
+
+func (f *droppingWorkerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
+	if rand.Float64() >= f.rate {
+		return f.WorkerFactory.HeartbeatWorker(atcWorker, ttl)
+	}
+
+	f.logger.Info("dropping-heartbeat", lager.Data{"worker": atcWorker.Name})
+
+	worker, found, err := f.WorkerFactory.GetWorker(atcWorker.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrWorkerNotPresent
+	}
+
+	return worker, nil
+}
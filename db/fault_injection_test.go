@@ -0,0 +1,69 @@
+package db_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DropHeartbeats", func() {
+	var (
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeWorker        *dbfakes.FakeWorker
+		rate              float64
+
+		heartbeatWorker db.Worker
+		heartbeatErr    error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeWorker = new(dbfakes.FakeWorker)
+		fakeWorkerFactory.HeartbeatWorkerReturns(fakeWorker, nil)
+		fakeWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+	})
+
+	JustBeforeEach(func() {
+		factory := db.DropHeartbeats(lagertest.NewTestLogger("test"), fakeWorkerFactory, rate)
+		heartbeatWorker, heartbeatErr = factory.HeartbeatWorker(atc.Worker{Name: "some-worker"}, 0)
+	})
+
+	Context("when the drop rate is 0", func() {
+		BeforeEach(func() {
+			rate = 0
+		})
+
+		It("heartbeats the worker", func() {
+			Expect(heartbeatErr).NotTo(HaveOccurred())
+			Expect(heartbeatWorker).To(Equal(fakeWorker))
+			Expect(fakeWorkerFactory.HeartbeatWorkerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the drop rate is 1", func() {
+		BeforeEach(func() {
+			rate = 1
+		})
+
+		It("returns the worker without heartbeating it", func() {
+			Expect(heartbeatErr).NotTo(HaveOccurred())
+			Expect(heartbeatWorker).To(Equal(fakeWorker))
+			Expect(fakeWorkerFactory.HeartbeatWorkerCallCount()).To(BeZero())
+			Expect(fakeWorkerFactory.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+		})
+
+		Context("when the worker does not exist", func() {
+			BeforeEach(func() {
+				fakeWorkerFactory.GetWorkerReturns(nil, false, nil)
+			})
+
+			It("returns ErrWorkerNotPresent", func() {
+				Expect(heartbeatErr).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+})
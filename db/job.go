@@ -31,8 +31,11 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	CreateBuildWithReason(reason string) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	AbortBuilds(filter AbortBuildsFilter, reason string, abortedBy string) ([]Build, error)
 	Build(name string) (Build, bool, error)
+	LatestSuccessfulBuild() (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists() error
@@ -292,6 +295,30 @@ func (j *job) Build(name string) (Build, bool, error) {
 	return build, true, nil
 }
 
+func (j *job) LatestSuccessfulBuild() (Build, bool, error) {
+	row := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id": j.id,
+			"b.status": BuildStatusSucceeded,
+		}).
+		OrderBy("b.id DESC").
+		Limit(1).
+		RunWith(j.conn).
+		QueryRow()
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+
+	err := scanBuild(build, row, j.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error) {
 	err := j.updateSerialGroups(serialGroups)
 	if err != nil {
@@ -545,6 +572,10 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.CreateBuildWithReason("")
+}
+
+func (j *job) CreateBuildWithReason(reason string) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -565,6 +596,7 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"trigger_reason":     sql.NullString{String: reason, Valid: reason != ""},
 	})
 	if err != nil {
 		return nil, err
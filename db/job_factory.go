@@ -8,7 +8,7 @@ import (
 //go:generate counterfeiter . JobFactory
 
 type JobFactory interface {
-	VisibleJobs([]string) (Dashboard, error)
+	VisibleJobs([]string, DashboardFilter) (Dashboard, error)
 }
 
 type jobFactory struct {
@@ -23,11 +23,12 @@ func NewJobFactory(conn Conn, lockFactory lock.LockFactory) JobFactory {
 	}
 }
 
-func (j *jobFactory) VisibleJobs(teamNames []string) (Dashboard, error) {
-	rows, err := jobsQuery.
+func (j *jobFactory) VisibleJobs(teamNames []string, filter DashboardFilter) (Dashboard, error) {
+	rows, err := filter.filterJobs(jobsQuery).
 		Where(sq.Eq{
-			"t.name":   teamNames,
-			"j.active": true,
+			"t.name":       teamNames,
+			"j.active":     true,
+			"p.deleted_at": nil,
 		}).
 		OrderBy("j.id ASC").
 		RunWith(j.conn).
@@ -41,13 +42,14 @@ func (j *jobFactory) VisibleJobs(teamNames []string) (Dashboard, error) {
 		return nil, err
 	}
 
-	rows, err = jobsQuery.
+	rows, err = filter.filterJobs(jobsQuery).
 		Where(sq.NotEq{
 			"t.name": teamNames,
 		}).
 		Where(sq.Eq{
-			"p.public": true,
-			"j.active": true,
+			"p.public":     true,
+			"j.active":     true,
+			"p.deleted_at": nil,
 		}).
 		OrderBy("j.id ASC").
 		RunWith(j.conn).
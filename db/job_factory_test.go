@@ -20,8 +20,9 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := otherTeam.SavePipeline("public-pipeline", atc.Config{
+				Labels: map[string]string{"team": "payments"},
 				Jobs: atc.JobConfigs{
-					{Name: "public-pipeline-job"},
+					{Name: "public-pipeline-job", Labels: map[string]string{"tier": "critical"}},
 				},
 			}, db.ConfigVersion(0), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
@@ -36,7 +37,7 @@ var _ = Describe("Job Factory", func() {
 		})
 
 		It("returns jobs in the provided teams and jobs in public pipelines", func() {
-			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
+			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"}, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(len(visibleJobs)).To(Equal(2))
@@ -44,6 +45,17 @@ var _ = Describe("Job Factory", func() {
 			Expect(visibleJobs[1].Job.Name()).To(Equal("public-pipeline-job"))
 		})
 
+		It("filters jobs by the labels of the job and of its pipeline", func() {
+			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"}, db.DashboardFilter{
+				Labels: map[string]string{"team": "payments", "tier": "critical"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(visibleJobs)).To(Equal(1))
+			Expect(visibleJobs[0].Job.Name()).To(Equal("public-pipeline-job"))
+			Expect(visibleJobs[0].Job.Config().Labels).To(Equal(map[string]string{"tier": "critical"}))
+		})
+
 		It("returns next build, latest completed build, and transition build for each job", func() {
 			job, found, err := defaultPipeline.Job("some-job")
 			Expect(err).ToNot(HaveOccurred())
@@ -68,7 +80,7 @@ var _ = Describe("Job Factory", func() {
 			nextBuild, err := job.CreateBuild()
 			Expect(err).ToNot(HaveOccurred())
 
-			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
+			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"}, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(visibleJobs[0].Job.Name()).To(Equal("some-job"))
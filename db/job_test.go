@@ -380,6 +380,60 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("LatestSuccessfulBuild", func() {
+		Context("when no build has succeeded", func() {
+			BeforeEach(func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not find a build", func() {
+				build, found, err := job.LatestSuccessfulBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(build).To(BeNil())
+			})
+		})
+
+		Context("when builds have succeeded", func() {
+			var latestSucceededBuild db.Build
+
+			BeforeEach(func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				latestSucceededBuild, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = latestSucceededBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				build, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("finds the most recent one", func() {
+				build, found, err := job.LatestSuccessfulBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ID()).To(Equal(latestSucceededBuild.ID()))
+				Expect(build.Status()).To(Equal(db.BuildStatusSucceeded))
+			})
+		})
+	})
+
 	Describe("GetRunningBuildsBySerialGroup", func() {
 		Describe("same job", func() {
 			var startedBuild, scheduledBuild db.Build
@@ -1144,6 +1198,19 @@ var _ = Describe("Job", func() {
 				})
 			})
 		})
+
+		Context("when a reason is given for triggering the build", func() {
+			It("saves the reason with the build", func() {
+				build, err := job.CreateBuildWithReason("some-reason")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.TriggerReason()).To(Equal("some-reason"))
+
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.TriggerReason()).To(Equal("some-reason"))
+			})
+		})
 	})
 
 	Describe("EnsurePendingBuildExists", func() {
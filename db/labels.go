@@ -0,0 +1,64 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type ErrInvalidLabelSelector struct {
+	Selector string
+}
+
+func (err ErrInvalidLabelSelector) Error() string {
+	return fmt.Sprintf("invalid label selector '%s': expected key:value", err.Selector)
+}
+
+// ParseLabelSelectors parses selectors of the form 'key:value', as given by
+// repeated 'label' query params, into the labels to filter by.
+func ParseLabelSelectors(selectors []string) (map[string]string, error) {
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+
+	labels := map[string]string{}
+	for _, selector := range selectors {
+		segs := strings.SplitN(selector, ":", 2)
+		if len(segs) != 2 || segs[0] == "" {
+			return nil, ErrInvalidLabelSelector{Selector: selector}
+		}
+
+		labels[segs[0]] = segs[1]
+	}
+
+	return labels, nil
+}
+
+func labelsJSON(labels map[string]string) ([]byte, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return json.Marshal(labels)
+}
+
+// MatchesLabels reports whether the labels include every one of the given
+// selectors, in the same way as hasLabels does in queries.
+func MatchesLabels(labels map[string]string, selectors map[string]string) bool {
+	for key, value := range selectors {
+		if actual, found := labels[key]; !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasLabels matches rows whose labels, as given by the jsonb expression,
+// include every one of the given labels.
+func hasLabels(expr string, labels map[string]string) sq.Sqlizer {
+	payload, _ := labelsJSON(labels)
+	return sq.Expr(expr+" @> ?::jsonb", string(payload))
+}
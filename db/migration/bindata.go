@@ -50,6 +50,56 @@
 // db/migration/migrations/1533136021_upsert_uniqueness.up.sql
 // db/migration/migrations/1533739478_drop_unused_volume_columns.down.sql
 // db/migration/migrations/1533739478_drop_unused_volume_columns.up.sql
+// db/migration/migrations/1534178461_add_error_to_builds.down.sql
+// db/migration/migrations/1534178461_add_error_to_builds.up.sql
+// db/migration/migrations/1534430123_add_network_to_pipelines.down.sql
+// db/migration/migrations/1534430123_add_network_to_pipelines.up.sql
+// db/migration/migrations/1534517589_add_allow_privileged_to_teams.down.sql
+// db/migration/migrations/1534517589_add_allow_privileged_to_teams.up.sql
+// db/migration/migrations/1534603201_add_max_in_flight_to_pipelines.down.sql
+// db/migration/migrations/1534603201_add_max_in_flight_to_pipelines.up.sql
+// db/migration/migrations/1534871519_create_webhooks.down.sql
+// db/migration/migrations/1534871519_create_webhooks.up.sql
+// db/migration/migrations/1534958201_add_deleted_at_to_pipelines.down.sql
+// db/migration/migrations/1534958201_add_deleted_at_to_pipelines.up.sql
+// db/migration/migrations/1535036812_add_container_retention_to_teams.down.sql
+// db/migration/migrations/1535036812_add_container_retention_to_teams.up.sql
+// db/migration/migrations/1535123076_create_cert_cache.down.sql
+// db/migration/migrations/1535123076_create_cert_cache.up.sql
+// db/migration/migrations/1535470286_add_labels_to_pipelines_and_jobs.down.sql
+// db/migration/migrations/1535470286_add_labels_to_pipelines_and_jobs.up.sql
+// db/migration/migrations/1535632051_add_idle_tracking_to_pipelines.down.sql
+// db/migration/migrations/1535632051_add_idle_tracking_to_pipelines.up.sql
+// db/migration/migrations/1535980214_add_notify_on_transition_to_jobs.down.sql
+// db/migration/migrations/1535980214_add_notify_on_transition_to_jobs.up.sql
+// db/migration/migrations/1536148921_add_start_time_indexes_to_builds.down.sql
+// db/migration/migrations/1536148921_add_start_time_indexes_to_builds.up.sql
+// db/migration/migrations/1536752341_add_trigger_reason_to_builds.down.sql
+// db/migration/migrations/1536752341_add_trigger_reason_to_builds.up.sql
+// db/migration/migrations/1537291206_add_runtime_to_workers.down.sql
+// db/migration/migrations/1537291206_add_runtime_to_workers.up.sql
+// db/migration/migrations/1537480431_add_last_used_to_worker_task_caches.down.sql
+// db/migration/migrations/1537480431_add_last_used_to_worker_task_caches.up.sql
+// db/migration/migrations/1537910553_create_team_usage.down.sql
+// db/migration/migrations/1537910553_create_team_usage.up.sql
+// db/migration/migrations/1538003462_add_load_to_workers.down.sql
+// db/migration/migrations/1538003462_add_load_to_workers.up.sql
+// db/migration/migrations/1538091547_add_api_pinned_version_to_resources.down.sql
+// db/migration/migrations/1538091547_add_api_pinned_version_to_resources.up.sql
+// db/migration/migrations/1538170832_add_abort_reason_to_builds.down.sql
+// db/migration/migrations/1538170832_add_abort_reason_to_builds.up.sql
+// db/migration/migrations/1538393317_add_denies_network_by_default_to_workers.down.sql
+// db/migration/migrations/1538393317_add_denies_network_by_default_to_workers.up.sql
+// db/migration/migrations/1538470219_create_build_input_uploads.down.sql
+// db/migration/migrations/1538470219_create_build_input_uploads.up.sql
+// db/migration/migrations/1538470220_add_containers_expire_at_to_builds.down.sql
+// db/migration/migrations/1538470220_add_containers_expire_at_to_builds.up.sql
+// db/migration/migrations/1538470221_add_meta_outputs_to_containers.down.sql
+// db/migration/migrations/1538470221_add_meta_outputs_to_containers.up.sql
+// db/migration/migrations/1538470222_add_paused_before_deletion_to_pipelines.down.sql
+// db/migration/migrations/1538470222_add_paused_before_deletion_to_pipelines.up.sql
+// db/migration/migrations/1538470223_create_build_token_accessors.down.sql
+// db/migration/migrations/1538470223_create_build_token_accessors.up.sql
 // DO NOT EDIT!
 
 package migration
@@ -1117,6 +1167,1006 @@ func _1533739478_drop_unused_volume_columnsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1534178461_add_error_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2d\x2a\xca\x2f\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xb9\x77\x34\x2a\x37\x00\x00\x00")
+
+func _1534178461_add_error_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534178461_add_error_to_buildsDownSql,
+		"1534178461_add_error_to_builds.down.sql",
+	)
+}
+
+func _1534178461_add_error_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1534178461_add_error_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534178461_add_error_to_builds.down.sql", size: 55, mode: os.FileMode(420), modTime: time.Unix(1792137401, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534178461_add_error_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2d\x2a\xca\x2f\x52\x28\x49\xad\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x13\xb8\x02\xbc\x3b\x00\x00\x00")
+
+func _1534178461_add_error_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534178461_add_error_to_buildsUpSql,
+		"1534178461_add_error_to_builds.up.sql",
+	)
+}
+
+func _1534178461_add_error_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1534178461_add_error_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534178461_add_error_to_builds.up.sql", size: 59, mode: os.FileMode(420), modTime: time.Unix(1792137401, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534430123_add_network_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4b\x2d\x29\xcf\x2f\xca\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x31\x21\x93\x0a\x3c\x00\x00\x00")
+
+func _1534430123_add_network_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534430123_add_network_to_pipelinesDownSql,
+		"1534430123_add_network_to_pipelines.down.sql",
+	)
+}
+
+func _1534430123_add_network_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1534430123_add_network_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534430123_add_network_to_pipelines.down.sql", size: 60, mode: os.FileMode(420), modTime: time.Unix(1792137996, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534430123_add_network_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4b\x2d\x29\xcf\x2f\xca\x56\x28\x49\xad\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x67\xed\x88\x34\x40\x00\x00\x00")
+
+func _1534430123_add_network_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534430123_add_network_to_pipelinesUpSql,
+		"1534430123_add_network_to_pipelines.up.sql",
+	)
+}
+
+func _1534430123_add_network_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1534430123_add_network_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534430123_add_network_to_pipelines.up.sql", size: 64, mode: os.FileMode(420), modTime: time.Unix(1792137996, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534517589_add_allow_privileged_to_teamsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x49\x4d\xcc\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xcc\xc9\xc9\x2f\x8f\x2f\x28\xca\x2c\xcb\xcc\x49\x4d\x4f\x4d\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xaa\xbf\x6d\xe7\x41\x00\x00\x00")
+
+func _1534517589_add_allow_privileged_to_teamsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534517589_add_allow_privileged_to_teamsDownSql,
+		"1534517589_add_allow_privileged_to_teams.down.sql",
+	)
+}
+
+func _1534517589_add_allow_privileged_to_teamsDownSql() (*asset, error) {
+	bytes, err := _1534517589_add_allow_privileged_to_teamsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534517589_add_allow_privileged_to_teams.down.sql", size: 65, mode: os.FileMode(420), modTime: time.Unix(1792138163, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534517589_add_allow_privileged_to_teamsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x05\xc1\x41\x0a\x83\x30\x10\x05\xd0\x7d\x4e\xf1\xef\x91\x55\x34\x51\x84\x49\x02\x32\x59\x4b\xc4\x69\x11\xa6\x4d\x31\xd2\x5e\xbf\xef\x0d\x61\x5e\x92\x35\x80\x23\x0e\x2b\xd8\x0d\x14\x70\x4b\x7d\x75\x38\xef\x31\x66\x2a\x31\xa1\xaa\xb6\xdf\xf6\xb9\xce\xef\xa9\xf2\x94\x03\x7b\x6b\x2a\xf5\x8d\x94\x19\xa9\x10\xc1\x87\xc9\x15\x62\x3c\xaa\x76\xb1\x66\xcc\x31\x2e\x6c\xcd\x1f\x85\xac\x58\xd3\x5f\x00\x00\x00")
+
+func _1534517589_add_allow_privileged_to_teamsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534517589_add_allow_privileged_to_teamsUpSql,
+		"1534517589_add_allow_privileged_to_teams.up.sql",
+	)
+}
+
+func _1534517589_add_allow_privileged_to_teamsUpSql() (*asset, error) {
+	bytes, err := _1534517589_add_allow_privileged_to_teamsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534517589_add_allow_privileged_to_teams.up.sql", size: 95, mode: os.FileMode(420), modTime: time.Unix(1792138163, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534603201_add_max_in_flight_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\xac\x88\xcf\xcc\x8b\x4f\xcb\xc9\x4c\xcf\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x08\xce\xbe\xb8\x42\x00\x00\x00")
+
+func _1534603201_add_max_in_flight_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534603201_add_max_in_flight_to_pipelinesDownSql,
+		"1534603201_add_max_in_flight_to_pipelines.down.sql",
+	)
+}
+
+func _1534603201_add_max_in_flight_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1534603201_add_max_in_flight_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534603201_add_max_in_flight_to_pipelines.down.sql", size: 66, mode: os.FileMode(420), modTime: time.Unix(1792138709, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534603201_add_max_in_flight_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x05\xc1\x41\x0a\x80\x20\x10\x05\xd0\xbd\xa7\xf8\x47\x68\xef\xca\xd4\x22\x18\x15\x62\x5c\x47\x0b\xab\x81\x12\xa9\x16\x1d\xbf\xf7\x7a\x3f\x4e\x51\x2b\xc0\x10\xfb\x19\x6c\x7a\xf2\x68\xd2\xca\x29\xb5\x3c\x30\xce\xc1\x26\xca\x21\xe2\x5a\xbf\x45\xea\xb2\x9d\xb2\x1f\x2f\xa4\xbe\x65\x2f\x37\x62\x62\xc4\x4c\x04\xe7\x07\x93\x89\xd1\x69\x65\x53\x08\x13\x6b\xf5\x03\x9e\x68\x3a\x54\x5c\x00\x00\x00")
+
+func _1534603201_add_max_in_flight_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534603201_add_max_in_flight_to_pipelinesUpSql,
+		"1534603201_add_max_in_flight_to_pipelines.up.sql",
+	)
+}
+
+func _1534603201_add_max_in_flight_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1534603201_add_max_in_flight_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534603201_add_max_in_flight_to_pipelines.up.sql", size: 92, mode: os.FileMode(420), modTime: time.Unix(1792138709, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534871519_create_webhooksDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4f\x4d\xca\xc8\xcf\xcf\x8e\x4f\x49\xcd\xc9\x2c\x4b\x2d\xca\x4c\x2d\xc6\xae\x00\x28\xec\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x48\xc1\xe0\x10\x47\x00\x00\x00")
+
+func _1534871519_create_webhooksDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534871519_create_webhooksDownSql,
+		"1534871519_create_webhooks.down.sql",
+	)
+}
+
+func _1534871519_create_webhooksDownSql() (*asset, error) {
+	bytes, err := _1534871519_create_webhooksDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534871519_create_webhooks.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1792139095, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534871519_create_webhooksUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9d\x53\xcb\x6e\x82\x40\x14\xdd\xfb\x15\x37\x6c\x84\xa4\x8b\xee\x59\x21\x8e\x86\x14\x87\x06\x31\xa9\x69\x1a\x82\x72\x5b\x27\xf2\x0a\x8c\x5a\xfb\xf5\x1d\xe4\x8d\x62\x1b\xd9\x10\xee\x3d\xf7\x75\xce\x61\x42\xe6\x06\x55\x47\x00\xba\x4d\x34\x87\x80\xa3\x4d\x4c\x02\xd2\x09\x37\xbb\x38\xde\x67\x12\xc8\x22\x97\x3f\x12\xf3\x25\xc8\x30\x65\x5e\xf0\x54\x85\x38\x7a\xa1\x9b\xc7\x59\xc4\xf1\x0b\x53\xa0\x96\x03\x74\x65\x9a\x35\xe2\x90\x06\x12\x70\xfc\xe6\xd7\xa9\x0c\xb7\x29\xf2\xa1\x6c\x14\x47\x5b\x2c\x92\x75\x0c\x8f\x18\xf1\xac\x57\x01\x53\x32\xd3\x56\xa6\x03\xe3\xf7\x8f\x71\x05\x7d\xb5\x8d\x85\x66\xaf\xe1\x85\xac\x41\xce\x37\x57\xaa\x8c\x6e\xd1\xa5\x63\x6b\x06\x75\x9a\x23\xdd\xf2\x0e\xf7\x73\x8f\x67\x09\x66\x96\x4d\x8c\x39\x2d\x8b\xab\x1b\x15\xb0\xc9\x8c\xd8\x84\xea\x64\x59\x5c\x9e\x49\x45\x6b\xb0\xa8\x58\xc2\x24\x82\x3d\x5d\x5b\xea\xda\x94\x88\x59\x8a\x3a\x6a\x48\x35\xe8\x94\xbc\x41\x7f\x5c\x5e\x57\xc5\x40\x2e\x83\x9d\xba\xae\x18\xae\x8f\x01\x3b\x0a\x05\xf0\x0f\x59\x2a\xfc\x5d\x65\x36\x07\x16\xf8\xf7\x21\x17\xbe\x5d\x7e\x4e\x70\x48\xa5\x02\x21\x76\xca\x58\x1c\x0d\x81\x12\xef\x1c\xc4\x9e\x3f\x94\xf6\x38\xc7\x30\xc9\x75\xed\x2f\x52\x4b\xfb\xdc\xd8\x42\xb4\x70\xcb\x0a\xf1\x16\x3d\x59\x88\x19\xf7\xc2\x04\x4e\x8c\xef\x2e\x9f\xf0\x13\x47\x78\xdd\x24\x8a\x4f\xb2\xf2\x90\x41\x5a\xc4\xbb\x0d\xb7\x37\xdd\xd2\xa2\xbe\x6b\x98\xfa\x87\x1a\xf2\xcc\x3f\xe7\x57\xb2\xdd\x9c\x5e\x6b\xda\x9d\x7d\x09\x3f\xe8\xd6\xf6\xec\x1e\xf9\x2d\xff\xb6\x50\x20\xf7\x60\xa2\xb7\x6e\x2d\x16\x86\xa3\x8e\x7e\x01\x6f\x22\x40\xd5\x6d\x04\x00\x00")
+
+func _1534871519_create_webhooksUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534871519_create_webhooksUpSql,
+		"1534871519_create_webhooks.up.sql",
+	)
+}
+
+func _1534871519_create_webhooksUpSql() (*asset, error) {
+	bytes, err := _1534871519_create_webhooksUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534871519_create_webhooks.up.sql", size: 1133, mode: os.FileMode(420), modTime: time.Unix(1792139095, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534958201_add_deleted_at_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x8e\xb1\x0a\xc2\x30\x14\x45\xf7\x7c\xc5\x1d\x15\xfc\x83\x4c\x69\xf3\xd4\x40\xf2\xa2\x69\x82\x6e\xa1\xd0\x0c\x85\xb6\x14\xec\xff\x23\x2d\x82\x5d\x74\x3d\xdc\x73\xb8\x15\x5d\x0c\x4b\x01\x68\xb2\x14\x09\xe7\xe0\x1d\xe6\x7e\x2e\x43\x3f\x95\x17\x1e\x57\x0a\x84\xae\x0c\x65\x29\x5d\x6e\x17\x98\x06\xec\x23\x38\x59\x2b\xc5\xaa\x05\x7f\x83\x61\x4d\xcf\xaf\x95\xa7\x76\x2c\x79\x29\xed\x98\xfb\x6e\x5b\x29\x1b\x29\x20\xaa\xca\xd2\x2e\xae\xb4\x46\xed\xb9\x89\x41\x19\x8e\x3f\x7c\x24\x36\xf7\x44\x38\xac\xf0\x84\x0f\x3d\xfe\xc9\x6e\x97\x6a\x6f\x93\xe3\xdd\x73\x29\x6a\xef\x9c\x89\x52\xbc\x01\x00\x18\x07\x5e\xf3\x00\x00\x00")
+
+func _1534958201_add_deleted_at_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534958201_add_deleted_at_to_pipelinesDownSql,
+		"1534958201_add_deleted_at_to_pipelines.down.sql",
+	)
+}
+
+func _1534958201_add_deleted_at_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1534958201_add_deleted_at_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534958201_add_deleted_at_to_pipelines.down.sql", size: 243, mode: os.FileMode(420), modTime: time.Unix(1792139443, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1534958201_add_deleted_at_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\x41\x0a\xc2\x30\x14\x44\xf7\x3d\xc5\x5f\x2a\x78\x83\xac\xd2\xe6\xa3\x81\x34\xd1\x34\x41\x77\x21\xd0\x0f\x06\x9a\x5a\x68\x40\xf0\xf4\x6a\x11\xec\xa6\xcb\xe1\x0d\x6f\xa6\xc6\xa3\xd4\xac\x02\xe0\xca\xa1\x05\xc7\x6b\x85\x30\xa5\x89\x86\x34\xd2\x0c\x5c\x08\x68\x8c\xf2\xad\x86\x9e\x06\x2a\xd4\x87\x58\xa0\xa4\x4c\x73\x89\x79\x82\x67\x2a\xf7\x25\xc2\xeb\x31\x12\xab\x36\x45\xc2\x9a\xf3\xc7\xa4\x3b\x67\xb9\xd4\xee\x4f\xc2\x18\x33\x85\x42\x31\x87\xd4\x2f\x82\xc6\x22\x77\x08\x5e\xcb\x8b\x47\x90\x5a\xe0\x6d\xa3\x0e\x46\xaf\x26\x76\x5f\x74\x80\x1f\xdb\xc3\xf5\x84\x16\xd7\xb7\x65\x07\xda\x2b\xc5\xaa\xc6\xb4\xad\x74\xac\x7a\x03\x90\x0d\xcc\xaa\xfd\x00\x00\x00")
+
+func _1534958201_add_deleted_at_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1534958201_add_deleted_at_to_pipelinesUpSql,
+		"1534958201_add_deleted_at_to_pipelines.up.sql",
+	)
+}
+
+func _1534958201_add_deleted_at_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1534958201_add_deleted_at_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1534958201_add_deleted_at_to_pipelines.up.sql", size: 253, mode: os.FileMode(420), modTime: time.Unix(1792139443, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535036812_add_container_retention_to_teamsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x49\x4d\xcc\x2d\x06\x8a\x29\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x24\xe7\xe7\x95\x24\x66\xe6\xa5\x16\xc5\x17\xa5\x96\xa4\xe6\x95\x64\xe6\xe7\xc5\x17\x97\x26\x27\xa7\x16\x17\xeb\x10\xa5\x38\x2d\x31\x33\xa7\xb4\x28\xd5\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\xb0\x0b\x50\x1c\x7d\x00\x00\x00")
+
+func _1535036812_add_container_retention_to_teamsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535036812_add_container_retention_to_teamsDownSql,
+		"1535036812_add_container_retention_to_teams.down.sql",
+	)
+}
+
+func _1535036812_add_container_retention_to_teamsDownSql() (*asset, error) {
+	bytes, err := _1535036812_add_container_retention_to_teamsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535036812_add_container_retention_to_teams.down.sql", size: 125, mode: os.FileMode(420), modTime: time.Unix(1792139606, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535036812_add_container_retention_to_teamsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\xcb\x41\x0a\x80\x20\x10\x00\xc0\xbb\xaf\xd8\x07\xf4\x03\x4f\x56\x12\x82\x16\x84\x9d\x45\x64\x0b\xa1\x36\xd0\xed\xff\x79\xe9\xde\x75\x60\x7a\x3d\x99\x59\x0a\x00\x65\xbd\x5e\xc1\xab\xde\x6a\x60\x8c\x57\x6d\xd6\x74\x1c\x61\x58\xec\xe6\x66\x48\x37\x71\xcc\x84\x25\x14\x64\x24\xce\x37\x85\xfa\xa4\x84\xb5\x42\x26\xc6\x03\x4b\xf7\xe7\xec\x31\x9f\x4f\xc1\xef\x48\x31\x2c\xce\x19\x2f\xc5\x0b\x42\x44\x77\xd2\x8b\x00\x00\x00")
+
+func _1535036812_add_container_retention_to_teamsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535036812_add_container_retention_to_teamsUpSql,
+		"1535036812_add_container_retention_to_teams.up.sql",
+	)
+}
+
+func _1535036812_add_container_retention_to_teamsUpSql() (*asset, error) {
+	bytes, err := _1535036812_add_container_retention_to_teamsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535036812_add_container_retention_to_teams.up.sql", size: 139, mode: os.FileMode(420), modTime: time.Unix(1792139606, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535123076_create_cert_cacheDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x4e\x2d\x2a\x89\x4f\x4e\x4c\xce\x48\x55\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x44\xed\x95\x69\x2a\x00\x00\x00")
+
+func _1535123076_create_cert_cacheDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535123076_create_cert_cacheDownSql,
+		"1535123076_create_cert_cache.down.sql",
+	)
+}
+
+func _1535123076_create_cert_cacheDownSql() (*asset, error) {
+	bytes, err := _1535123076_create_cert_cacheDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535123076_create_cert_cache.down.sql", size: 42, mode: os.FileMode(420), modTime: time.Unix(1792140627, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535123076_create_cert_cacheUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\xbd\x0a\xc2\x30\x14\x85\xf7\x3c\xc5\x25\x53\x0b\xbe\x41\xa6\x34\x5c\x24\x98\x26\x1a\xd3\xa1\x53\x09\x69\xc0\xa0\xa6\x50\x3b\xe8\xdb\xdb\x52\x2b\x2e\x9e\xf1\x7c\x9c\x9f\x0a\xf7\x52\x33\x02\x20\x2c\x72\x87\xe0\x78\xa5\x10\x68\x88\xe3\xd4\x05\x1f\x2e\x91\x42\x31\xd3\x45\x34\xf5\x14\x1e\x71\x4c\xfe\xb6\xdb\xac\x7e\xb8\xfb\x94\x29\x4c\xf1\x39\x81\x36\x0e\x74\xa3\xd4\x97\x2e\x2d\xff\x58\x1e\x72\x88\x2b\xdc\xbc\xa3\x95\x35\xb7\x2d\x1c\xb0\x85\x62\x59\x2b\x37\x22\x8c\x3e\x3b\xcb\xa5\x76\xbf\xd7\xba\x75\xbd\xbb\xc6\x17\x85\x46\xcb\x53\x83\x73\xee\x73\xa9\x9c\xa3\x25\x23\xc2\xd4\xb5\x74\x8c\xbc\x01\x44\xa9\xc2\x48\xe8\x00\x00\x00")
+
+func _1535123076_create_cert_cacheUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535123076_create_cert_cacheUpSql,
+		"1535123076_create_cert_cache.up.sql",
+	)
+}
+
+func _1535123076_create_cert_cacheUpSql() (*asset, error) {
+	bytes, err := _1535123076_create_cert_cacheUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535123076_create_cert_cache.up.sql", size: 232, mode: os.FileMode(420), modTime: time.Unix(1792140627, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535470286_add_labels_to_pipelines_and_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x49\x4c\x4a\xcd\x29\x46\x57\x56\x90\x59\x90\x9a\x93\x99\x97\x8a\x5d\xad\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\x1f\xc2\x42\x9d\x62\x00\x00\x00")
+
+func _1535470286_add_labels_to_pipelines_and_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535470286_add_labels_to_pipelines_and_jobsDownSql,
+		"1535470286_add_labels_to_pipelines_and_jobs.down.sql",
+	)
+}
+
+func _1535470286_add_labels_to_pipelines_and_jobsDownSql() (*asset, error) {
+	bytes, err := _1535470286_add_labels_to_pipelines_and_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535470286_add_labels_to_pipelines_and_jobs.down.sql", size: 98, mode: os.FileMode(420), modTime: time.Unix(1792141044, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535470286_add_labels_to_pipelines_and_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\xcc\x31\x0a\x80\x20\x14\x06\xe0\xbd\x53\xfc\x5b\x87\x70\xd2\xb4\x10\x9e\x0a\xf1\x3c\x40\x82\x83\x22\x25\x38\x46\x77\x6f\x6f\xeb\x3b\xc0\xa7\xcc\x66\xbd\x98\x00\x49\x6c\x76\xb0\x54\x64\xd0\x4b\xcf\xad\x9c\x79\x40\x6a\x8d\x25\x50\x74\x1e\xed\x48\xb9\x0d\xd4\x71\x9d\x09\x3e\x30\x7c\x24\x82\x36\xab\x8c\xc4\x98\xef\x67\xfe\x36\xf5\x4a\xbf\x87\x25\x38\x67\x59\x4c\x2f\xb8\x03\xd2\x38\x98\x00\x00\x00")
+
+func _1535470286_add_labels_to_pipelines_and_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535470286_add_labels_to_pipelines_and_jobsUpSql,
+		"1535470286_add_labels_to_pipelines_and_jobs.up.sql",
+	)
+}
+
+func _1535470286_add_labels_to_pipelines_and_jobsUpSql() (*asset, error) {
+	bytes, err := _1535470286_add_labels_to_pipelines_and_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535470286_add_labels_to_pipelines_and_jobs.up.sql", size: 152, mode: os.FileMode(420), modTime: time.Unix(1792141044, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535632051_add_idle_tracking_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x49\x2c\x2e\x89\x2f\x2d\x48\x49\x2c\x49\x4d\x21\x4e\x47\x41\x62\x69\x71\x6a\x4a\x7c\x52\x65\x7c\x66\x5e\x62\x72\x49\x66\x59\x66\x49\xa5\x35\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\xf8\x9e\x1f\x8d\x7b\x00\x00\x00")
+
+func _1535632051_add_idle_tracking_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535632051_add_idle_tracking_to_pipelinesDownSql,
+		"1535632051_add_idle_tracking_to_pipelines.down.sql",
+	)
+}
+
+func _1535632051_add_idle_tracking_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1535632051_add_idle_tracking_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535632051_add_idle_tracking_to_pipelines.down.sql", size: 123, mode: os.FileMode(420), modTime: time.Unix(1792141913, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535632051_add_idle_tracking_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\xcd\xb1\x0a\x83\x30\x14\x46\xe1\xdd\xa7\xf8\xc7\xf6\x19\x9c\xa2\xa6\x45\x88\x09\x94\x38\x4b\xac\xb7\x34\x10\x93\x40\xae\x15\xfb\xf4\x85\xae\x5d\x3a\x9e\xe5\x3b\x8d\xbc\xf6\xba\xae\x00\xa1\xac\xbc\xc1\x8a\x46\x49\x64\x9f\x29\xf8\x48\x05\xa2\xeb\xd0\x1a\x35\x0e\x1a\xc1\x15\x9e\xb6\xbc\x38\xa6\x05\xec\x57\x2a\xec\xd6\x8c\xdd\xf3\xf3\x9b\x78\xa7\x48\xd0\xc6\x42\x8f\x4a\xa1\x93\x17\x31\x2a\x8b\x98\xf6\xd3\xf9\xaf\x41\x76\x5b\xa1\x65\x9a\x8f\xc9\x47\x77\x67\xff\xf2\x7c\x60\x4e\x29\x90\x8b\xbf\xee\xc3\x85\x42\x75\xd5\x9a\x61\xe8\x6d\x5d\x7d\x00\x3d\xf3\x0a\xcf\xc8\x00\x00\x00")
+
+func _1535632051_add_idle_tracking_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535632051_add_idle_tracking_to_pipelinesUpSql,
+		"1535632051_add_idle_tracking_to_pipelines.up.sql",
+	)
+}
+
+func _1535632051_add_idle_tracking_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1535632051_add_idle_tracking_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535632051_add_idle_tracking_to_pipelines.up.sql", size: 200, mode: os.FileMode(420), modTime: time.Unix(1792141913, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535980214_add_notify_on_transition_to_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\xcb\x2f\xc9\x4c\xab\x8c\xcf\xcf\x8b\x2f\x29\x4a\xcc\x2b\xce\x2c\xc9\xcc\xcf\xb3\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x66\x55\x6d\x5e\x44\x00\x00\x00")
+
+func _1535980214_add_notify_on_transition_to_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535980214_add_notify_on_transition_to_jobsDownSql,
+		"1535980214_add_notify_on_transition_to_jobs.down.sql",
+	)
+}
+
+func _1535980214_add_notify_on_transition_to_jobsDownSql() (*asset, error) {
+	bytes, err := _1535980214_add_notify_on_transition_to_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535980214_add_notify_on_transition_to_jobs.down.sql", size: 68, mode: os.FileMode(420), modTime: time.Unix(1792142498, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1535980214_add_notify_on_transition_to_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x05\xc1\x41\x0a\xc4\x20\x0c\x05\xd0\xbd\xa7\xf8\xf7\x70\x65\xab\x33\x14\xa2\x42\x89\x6b\xb1\xd0\x82\x43\x49\x60\x74\xd3\xdb\xf7\xbd\x25\x7c\xb7\x64\x0d\xe0\x88\xc3\x0e\x76\x0b\x05\xfc\xf4\x18\x70\xde\x63\xcd\x54\x62\x82\xe8\xec\xd7\x53\x55\xea\xfc\x37\x19\x7d\x76\x15\x1c\xaa\xf7\xd9\x04\x29\x33\x52\x21\x82\x0f\x1f\x57\x88\x71\xb5\x7b\x9c\xd6\xac\x39\xc6\x8d\xad\x79\x01\xb9\x76\x8e\x4b\x62\x00\x00\x00")
+
+func _1535980214_add_notify_on_transition_to_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1535980214_add_notify_on_transition_to_jobsUpSql,
+		"1535980214_add_notify_on_transition_to_jobs.up.sql",
+	)
+}
+
+func _1535980214_add_notify_on_transition_to_jobsUpSql() (*asset, error) {
+	bytes, err := _1535980214_add_notify_on_transition_to_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1535980214_add_notify_on_transition_to_jobs.up.sql", size: 98, mode: os.FileMode(420), modTime: time.Unix(1792142498, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1536148921_add_start_time_indexes_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x2f\x2e\x49\x2c\x29\x05\x53\x45\x25\xf1\x25\x99\xb9\xa9\x38\x95\xc1\xe5\x9d\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x26\x8b\xc6\xf5\x56\x00\x00\x00")
+
+func _1536148921_add_start_time_indexes_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1536148921_add_start_time_indexes_to_buildsDownSql,
+		"1536148921_add_start_time_indexes_to_builds.down.sql",
+	)
+}
+
+func _1536148921_add_start_time_indexes_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1536148921_add_start_time_indexes_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1536148921_add_start_time_indexes_to_builds.down.sql", size: 86, mode: os.FileMode(420), modTime: time.Unix(1792142681, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1536148921_add_start_time_indexes_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x2f\x2e\x49\x2c\x2a\x89\x2f\xc9\xcc\x4d\x55\xf0\xf7\x83\x0a\x2a\x84\x06\x7b\xfa\xb9\x2b\x24\x95\x14\xa5\xa6\x2a\x68\x20\x54\x68\xe2\x31\xa4\xa4\x94\x48\xb3\x80\x0a\x75\x14\x50\xcc\x74\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x3b\xc2\x6e\xab\xa8\x00\x00\x00")
+
+func _1536148921_add_start_time_indexes_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1536148921_add_start_time_indexes_to_buildsUpSql,
+		"1536148921_add_start_time_indexes_to_builds.up.sql",
+	)
+}
+
+func _1536148921_add_start_time_indexes_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1536148921_add_start_time_indexes_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1536148921_add_start_time_indexes_to_builds.up.sql", size: 168, mode: os.FileMode(420), modTime: time.Unix(1792142681, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1536752341_add_trigger_reason_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x29\xca\x4c\x4f\x4f\x2d\x8a\x2f\x4a\x4d\x2c\xce\xcf\xb3\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xf9\x3b\xe5\xbd\x40\x00\x00\x00")
+
+func _1536752341_add_trigger_reason_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1536752341_add_trigger_reason_to_buildsDownSql,
+		"1536752341_add_trigger_reason_to_builds.down.sql",
+	)
+}
+
+func _1536752341_add_trigger_reason_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1536752341_add_trigger_reason_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1536752341_add_trigger_reason_to_builds.down.sql", size: 64, mode: os.FileMode(420), modTime: time.Unix(1792143397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1536752341_add_trigger_reason_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x29\xca\x4c\x4f\x4f\x2d\x8a\x2f\x4a\x4d\x2c\xce\xcf\x53\x28\x49\xad\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa8\x29\xbe\x5b\x44\x00\x00\x00")
+
+func _1536752341_add_trigger_reason_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1536752341_add_trigger_reason_to_buildsUpSql,
+		"1536752341_add_trigger_reason_to_builds.up.sql",
+	)
+}
+
+func _1536752341_add_trigger_reason_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1536752341_add_trigger_reason_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1536752341_add_trigger_reason_to_builds.up.sql", size: 68, mode: os.FileMode(420), modTime: time.Unix(1792143397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537291206_add_runtime_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x2a\xcd\x2b\xc9\xcc\x4d\xb5\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xcc\x87\xd3\xd1\x3a\x00\x00\x00")
+
+func _1537291206_add_runtime_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537291206_add_runtime_to_workersDownSql,
+		"1537291206_add_runtime_to_workers.down.sql",
+	)
+}
+
+func _1537291206_add_runtime_to_workersDownSql() (*asset, error) {
+	bytes, err := _1537291206_add_runtime_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537291206_add_runtime_to_workers.down.sql", size: 58, mode: os.FileMode(420), modTime: time.Unix(1792144233, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537291206_add_runtime_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x2a\xcd\x2b\xc9\xcc\x4d\x55\x28\x49\xad\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xce\x79\x22\xaa\x3e\x00\x00\x00")
+
+func _1537291206_add_runtime_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537291206_add_runtime_to_workersUpSql,
+		"1537291206_add_runtime_to_workers.up.sql",
+	)
+}
+
+func _1537291206_add_runtime_to_workersUpSql() (*asset, error) {
+	bytes, err := _1537291206_add_runtime_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537291206_add_runtime_to_workers.up.sql", size: 62, mode: os.FileMode(420), modTime: time.Unix(1792144233, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537480431_add_last_used_to_worker_task_cachesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x8a\x2f\x49\x2c\xce\x8e\x4f\x4e\x4c\xce\x48\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x49\x2c\x2e\x89\x2f\x2d\x4e\x4d\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x95\x67\xfb\xb2\x47\x00\x00\x00")
+
+func _1537480431_add_last_used_to_worker_task_cachesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537480431_add_last_used_to_worker_task_cachesDownSql,
+		"1537480431_add_last_used_to_worker_task_caches.down.sql",
+	)
+}
+
+func _1537480431_add_last_used_to_worker_task_cachesDownSql() (*asset, error) {
+	bytes, err := _1537480431_add_last_used_to_worker_task_cachesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537480431_add_last_used_to_worker_task_caches.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1792144585, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537480431_add_last_used_to_worker_task_cachesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x1d\xc7\x41\x0a\xc3\x20\x10\x05\xd0\xbd\xa7\xf8\xcb\xf6\x0c\xae\x4c\xb4\x25\x30\x2a\x14\x5d\x8b\xa4\x42\x42\x9a\x58\xe2\x14\xa1\xa7\x2f\xf4\xed\xde\x60\xee\x93\x93\x02\x50\x14\xcc\x03\x41\x0d\x64\xd0\xeb\xb9\x95\x33\x71\x6e\x5b\x9a\xf3\xbc\x94\x06\xa5\x35\x46\x4f\xd1\x3a\xbc\x72\xe3\xf4\x69\xe5\x09\x5e\xf7\xd2\x38\xef\x6f\xf4\x95\x97\x7f\xf1\xad\x47\x81\xf3\x01\x2e\x12\x41\x9b\x9b\x8a\x14\x70\xd4\x7e\xb9\x4a\x31\x7a\x6b\xa7\x20\xc5\x0f\x06\x55\xf4\x64\x76\x00\x00\x00")
+
+func _1537480431_add_last_used_to_worker_task_cachesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537480431_add_last_used_to_worker_task_cachesUpSql,
+		"1537480431_add_last_used_to_worker_task_caches.up.sql",
+	)
+}
+
+func _1537480431_add_last_used_to_worker_task_cachesUpSql() (*asset, error) {
+	bytes, err := _1537480431_add_last_used_to_worker_task_cachesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537480431_add_last_used_to_worker_task_caches.up.sql", size: 118, mode: os.FileMode(420), modTime: time.Unix(1792144585, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537910553_create_team_usageDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\x49\x4d\xcc\x8d\x2f\x2d\x4e\x4c\x4f\x8d\x2f\x4a\x4d\xce\x2f\x4a\x49\x4d\x51\xc2\xad\x06\x28\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\xd7\xd8\x62\x9b\x4e\x00\x00\x00")
+
+func _1537910553_create_team_usageDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537910553_create_team_usageDownSql,
+		"1537910553_create_team_usage.down.sql",
+	)
+}
+
+func _1537910553_create_team_usageDownSql() (*asset, error) {
+	bytes, err := _1537910553_create_team_usageDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537910553_create_team_usage.down.sql", size: 78, mode: os.FileMode(420), modTime: time.Unix(1792145107, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537910553_create_team_usageUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x90\x31\x6f\xc2\x30\x10\x85\x77\x7e\xc5\xc9\x53\x22\x31\x94\x39\xea\x60\xcc\x85\x46\x24\x4e\x65\xcc\xc0\x14\x99\xda\x4d\xad\x92\xa4\x4a\x8c\xa2\xf6\xd7\xd7\x90\x12\xa1\x82\xa8\xea\xed\x7c\xef\xdd\xdd\xfb\xe6\xb8\x4c\x78\x34\x01\x60\x02\xa9\x44\x90\x74\x9e\x22\x10\x67\x54\x55\x1c\x3a\x55\x1a\x02\x81\xef\x1e\xdf\xf0\x69\x35\x01\x5b\x3b\x53\x9a\x16\x78\x2e\x81\x6f\xd2\x74\x7a\x56\x68\xf5\x49\x40\x2b\x67\xae\x5b\xbb\x83\xdd\xeb\xa2\x33\x2f\x4d\xad\x3b\x02\x3b\x5b\xfa\x29\xa3\x0c\x16\x18\xd3\x4d\x2a\xe1\x61\x34\x78\xa1\x53\xb6\x36\xed\x3f\x4c\xcf\x22\xc9\xa8\xd8\xc2\x0a\xb7\x10\x8c\xf7\x4e\x87\xc3\xc2\xb3\x8a\xe5\x7c\x2d\x05\x4d\xb8\xbc\x0c\x5a\xfc\xc8\x8b\xd7\x77\xe3\x53\xc4\xb9\xc0\x64\xc9\x7f\x8d\x0a\x41\x60\x8c\x02\x39\xc3\xf5\x60\xee\x48\x40\x4e\x8d\x9c\xfb\x7b\x52\xf4\x0c\x19\x5d\x33\xba\x40\xbf\x2d\x8c\x26\x77\xd0\x16\xad\x0f\xd6\x6a\xa3\x2f\x18\xdf\xc2\x3b\xe6\x9c\x8d\x70\xce\xce\x42\x39\x02\xce\x56\xa6\x73\xaa\xfa\x80\xde\xba\xb7\x53\x09\x5f\x4d\x6d\xae\x07\xd4\x4d\x1f\x84\xb7\x61\x1d\x33\xfc\x01\x68\x5c\xda\xd9\xba\xdc\xfb\xba\xe9\x09\xb0\x27\x64\xab\xc1\x0f\x8f\x30\x0b\x87\xd4\x2c\xcf\xb2\x44\x46\x93\x6f\x2c\x0e\x2a\x5d\x5f\x02\x00\x00")
+
+func _1537910553_create_team_usageUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537910553_create_team_usageUpSql,
+		"1537910553_create_team_usage.up.sql",
+	)
+}
+
+func _1537910553_create_team_usageUpSql() (*asset, error) {
+	bytes, err := _1537910553_create_team_usageUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537910553_create_team_usage.up.sql", size: 607, mode: os.FileMode(420), modTime: time.Unix(1792145107, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538003462_add_load_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x06\x8a\x2a\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x24\x17\x94\xc6\xe7\xe4\x27\xa6\xe8\x60\xc8\xa4\x15\xa5\xa6\xc6\xe7\xa6\xe6\xe6\x17\x55\xe2\x90\x4c\xc9\x2c\xce\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x8e\xf0\xe9\x4e\x77\x00\x00\x00")
+
+func _1538003462_add_load_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538003462_add_load_to_workersDownSql,
+		"1538003462_add_load_to_workers.down.sql",
+	)
+}
+
+func _1538003462_add_load_to_workersDownSql() (*asset, error) {
+	bytes, err := _1538003462_add_load_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538003462_add_load_to_workers.down.sql", size: 119, mode: os.FileMode(420), modTime: time.Unix(1792145258, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538003462_add_load_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x06\x8a\x02\xc5\x5d\x5c\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x92\x0b\x4a\xe3\x73\xf2\x13\x53\x14\x52\xf2\x4b\x93\x72\x52\x15\x0a\x8a\x52\x93\x33\x8b\x33\xf3\xf3\x74\xd0\x55\xa6\x15\xa5\xa6\xc6\xe7\xa6\xe6\xe6\x17\x55\x2a\x24\x65\xa6\x67\xe6\x95\x60\x57\x92\x92\x59\x9c\x0d\x55\x60\xcd\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x70\xfd\x7e\xaa\x93\x00\x00\x00")
+
+func _1538003462_add_load_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538003462_add_load_to_workersUpSql,
+		"1538003462_add_load_to_workers.up.sql",
+	)
+}
+
+func _1538003462_add_load_to_workersUpSql() (*asset, error) {
+	bytes, err := _1538003462_add_load_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538003462_add_load_to_workers.up.sql", size: 147, mode: os.FileMode(420), modTime: time.Unix(1792145258, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538091547_add_api_pinned_version_to_resourcesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2c\xc8\x8c\x2f\xc8\xcc\xcb\x4b\x4d\x89\x2f\x4b\x2d\x2a\xce\xcc\xcf\xb3\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x71\x98\xd3\x11\x47\x00\x00\x00")
+
+func _1538091547_add_api_pinned_version_to_resourcesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538091547_add_api_pinned_version_to_resourcesDownSql,
+		"1538091547_add_api_pinned_version_to_resources.down.sql",
+	)
+}
+
+func _1538091547_add_api_pinned_version_to_resourcesDownSql() (*asset, error) {
+	bytes, err := _1538091547_add_api_pinned_version_to_resourcesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538091547_add_api_pinned_version_to_resources.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1792145726, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538091547_add_api_pinned_version_to_resourcesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2c\xc8\x8c\x2f\xc8\xcc\xcb\x4b\x4d\x89\x2f\x4b\x2d\x2a\xce\xcc\xcf\x53\xc8\x2a\xce\xcf\x4b\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x71\xad\x7c\xca\x4c\x00\x00\x00")
+
+func _1538091547_add_api_pinned_version_to_resourcesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538091547_add_api_pinned_version_to_resourcesUpSql,
+		"1538091547_add_api_pinned_version_to_resources.up.sql",
+	)
+}
+
+func _1538091547_add_api_pinned_version_to_resourcesUpSql() (*asset, error) {
+	bytes, err := _1538091547_add_api_pinned_version_to_resourcesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538091547_add_api_pinned_version_to_resources.up.sql", size: 76, mode: os.FileMode(420), modTime: time.Unix(1792145726, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538170832_add_abort_reason_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\xca\x2f\x2a\x89\x2f\x4a\x4d\x2c\xce\xcf\xd3\xc1\x94\x49\x4d\x89\x4f\xaa\xb4\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xf3\x06\xb5\xf4\x56\x00\x00\x00")
+
+func _1538170832_add_abort_reason_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538170832_add_abort_reason_to_buildsDownSql,
+		"1538170832_add_abort_reason_to_builds.down.sql",
+	)
+}
+
+func _1538170832_add_abort_reason_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1538170832_add_abort_reason_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538170832_add_abort_reason_to_builds.down.sql", size: 86, mode: os.FileMode(420), modTime: time.Unix(1792146098, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538170832_add_abort_reason_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\xca\x2f\x2a\x89\x2f\x4a\x4d\x2c\xce\xcf\x53\x28\x49\xad\x28\xd1\xc1\x90\x4d\x4d\x89\x4f\xaa\x04\xcb\x59\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x53\x98\x9c\x33\x5e\x00\x00\x00")
+
+func _1538170832_add_abort_reason_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538170832_add_abort_reason_to_buildsUpSql,
+		"1538170832_add_abort_reason_to_builds.up.sql",
+	)
+}
+
+func _1538170832_add_abort_reason_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1538170832_add_abort_reason_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538170832_add_abort_reason_to_builds.up.sql", size: 94, mode: os.FileMode(420), modTime: time.Unix(1792146098, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538393317_add_denies_network_by_default_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x49\xcd\xcb\x4c\x2d\x8e\xcf\x4b\x2d\x01\xc9\xc6\x27\x55\xc6\xa7\xa4\xa6\x25\x96\xe6\x94\x58\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xe5\x93\x31\x80\x4c\x00\x00\x00")
+
+func _1538393317_add_denies_network_by_default_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538393317_add_denies_network_by_default_to_workersDownSql,
+		"1538393317_add_denies_network_by_default_to_workers.down.sql",
+	)
+}
+
+func _1538393317_add_denies_network_by_default_to_workersDownSql() (*asset, error) {
+	bytes, err := _1538393317_add_denies_network_by_default_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538393317_add_denies_network_by_default_to_workers.down.sql", size: 76, mode: os.FileMode(420), modTime: time.Unix(1792147190, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538393317_add_denies_network_by_default_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x15\xc9\x41\x0a\xc3\x20\x10\x05\xd0\xbd\xa7\xf8\xf7\x70\x65\xa2\x2d\x81\x51\xa1\x8c\x6b\x31\x64\x02\xa5\xa2\x10\x53\x4a\x6f\x1f\xf2\xb6\x6f\x72\xcf\x25\x68\x05\x18\x62\xf7\x02\x9b\x89\x1c\x7e\xfd\xf8\xc8\x31\x60\xac\xc5\x1c\x29\xf9\x80\x4d\xda\x5b\x46\x6e\x72\xde\x99\xd7\x7f\xde\x64\x2f\xdf\x7a\x62\xed\xbd\x4a\x69\x08\x91\x11\x12\x11\xac\x7b\x98\x44\x8c\xbd\xd4\x21\x5a\xcd\xd1\xfb\x85\xb5\xba\x00\xca\x86\xdb\x80\x6a\x00\x00\x00")
+
+func _1538393317_add_denies_network_by_default_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538393317_add_denies_network_by_default_to_workersUpSql,
+		"1538393317_add_denies_network_by_default_to_workers.up.sql",
+	)
+}
+
+func _1538393317_add_denies_network_by_default_to_workersUpSql() (*asset, error) {
+	bytes, err := _1538393317_add_denies_network_by_default_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538393317_add_denies_network_by_default_to_workers.up.sql", size: 106, mode: os.FileMode(420), modTime: time.Unix(1792147190, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470219_create_build_input_uploadsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\x2a\xcb\xcf\x29\xcd\x4d\x2d\x56\x52\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x50\x4a\x2a\xcd\xcc\x49\x89\xcf\xcc\x2b\x28\x2d\x89\x2f\x2d\xc8\xc9\x4f\x04\x72\x52\x94\xac\xb9\x80\xba\xc1\xea\xa0\x9a\x31\x95\x15\x03\x15\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xae\x04\x25\x69\x71\x00\x00\x00")
+
+func _1538470219_create_build_input_uploadsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470219_create_build_input_uploadsDownSql,
+		"1538470219_create_build_input_uploads.down.sql",
+	)
+}
+
+func _1538470219_create_build_input_uploadsDownSql() (*asset, error) {
+	bytes, err := _1538470219_create_build_input_uploadsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470219_create_build_input_uploads.down.sql", size: 113, mode: os.FileMode(420), modTime: time.Unix(1792149155, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470219_create_build_input_uploadsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9d\x53\x4d\x73\x82\x30\x10\xbd\xf3\x2b\x76\x72\x92\x19\x0f\xbd\x73\x8a\xb0\x3a\x99\x42\x68\x43\x98\xa9\x27\x06\x35\xb5\x8c\x88\x0e\x1f\xb6\xfd\xf7\x0d\x0a\x48\x05\xdb\x4e\x39\x70\xc8\xbe\xbc\xf7\xf6\xed\x66\x86\x0b\xc6\x2d\x03\xc0\x16\x48\x25\x82\xa4\x33\x17\x81\xac\xaa\x24\xdd\x44\x49\x76\xac\xca\xa8\x3a\xa6\x87\x78\x53\x10\x98\x68\x58\xfd\x91\x64\x43\xa0\x50\x79\x12\xa7\xd3\xf6\xa8\xb9\xa0\x0b\x49\x56\xaa\xad\xca\x81\xfb\x12\x78\xe8\xba\x1d\xe4\x98\xc6\xd9\x19\x51\xaa\x8f\x72\x58\x7e\x3f\xe4\x3b\x95\x47\x59\xbc\x57\xf7\x20\xb9\x5a\xab\xe4\xa4\x34\xc5\x2a\xd9\x6a\x1d\x70\x70\x4e\x43\x57\xc2\xc3\x10\xbb\x7e\xab\xb2\x5d\x71\xb5\x73\x1f\xfa\x24\x98\x47\xc5\x12\x1e\x71\x09\x93\xba\x37\xb3\xad\xd8\x3e\x0f\xa4\xa0\x8c\xcb\xd1\x44\xa2\xb6\xe9\xe8\x75\xa7\x3e\x09\xcc\x7d\x81\x6c\xc1\x1b\xa2\x2e\x11\x13\x04\xce\x51\x20\xb7\x31\x68\x78\x0a\x72\x11\x02\x9f\x6b\x63\x2e\xea\xe0\x6d\x1a\xd8\xd4\xc1\xbf\x4a\xf7\xd2\x1a\x55\xef\xa7\xf9\xdd\xc0\xa5\x52\x3b\x68\x8a\xff\xf6\xd0\xb5\xdf\x4c\x36\x3a\xfb\x08\x39\x7b\x0e\xb1\x1f\xc0\xf4\x3a\x7b\x53\x53\x9b\x96\xa1\xff\xd4\x95\x28\xda\x75\x3b\x1d\xd2\x6a\xaf\x0a\x52\x9f\x3b\x8e\x16\x76\x43\x8f\x8f\x89\xf6\x37\x6c\xda\xa1\xaf\x36\x1b\xa2\x68\xf4\xe6\x4f\x63\xba\xd1\x18\x99\xd9\xcd\x6b\x18\x0c\x30\xc0\xcb\x5a\x9d\xbb\x6b\x5e\x13\xe3\x0e\xbe\xfc\xe2\x8a\xd4\x1c\x5d\x02\x10\x06\x8c\x2f\x60\x55\xe6\x4a\xdd\x77\x67\x19\xb6\xef\x79\x4c\x5a\xc6\x17\x4b\x78\x82\xe8\xc0\x03\x00\x00")
+
+func _1538470219_create_build_input_uploadsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470219_create_build_input_uploadsUpSql,
+		"1538470219_create_build_input_uploads.up.sql",
+	)
+}
+
+func _1538470219_create_build_input_uploadsUpSql() (*asset, error) {
+	bytes, err := _1538470219_create_build_input_uploadsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470219_create_build_input_uploads.up.sql", size: 960, mode: os.FileMode(420), modTime: time.Unix(1792149155, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470220_add_containers_expire_at_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x8e\x4f\xad\x28\xc8\x2c\x4a\x8d\x4f\x2c\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa3\x4c\x4e\xe8\x46\x00\x00\x00")
+
+func _1538470220_add_containers_expire_at_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470220_add_containers_expire_at_to_buildsDownSql,
+		"1538470220_add_containers_expire_at_to_builds.down.sql",
+	)
+}
+
+func _1538470220_add_containers_expire_at_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1538470220_add_containers_expire_at_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470220_add_containers_expire_at_to_builds.down.sql", size: 70, mode: os.FileMode(420), modTime: time.Unix(1792149804, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470220_add_containers_expire_at_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x8e\x4f\xad\x28\xc8\x2c\x4a\x8d\x4f\x2c\x51\x28\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x50\x28\xcf\x2c\xc9\x00\x73\x15\xaa\xf2\xf3\x52\xad\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\xb3\x4e\x36\x9d\x5e\x00\x00\x00")
+
+func _1538470220_add_containers_expire_at_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470220_add_containers_expire_at_to_buildsUpSql,
+		"1538470220_add_containers_expire_at_to_builds.up.sql",
+	)
+}
+
+func _1538470220_add_containers_expire_at_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1538470220_add_containers_expire_at_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470220_add_containers_expire_at_to_builds.up.sql", size: 94, mode: os.FileMode(420), modTime: time.Unix(1792149804, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470221_add_meta_outputs_to_containersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\x2d\x49\x8c\xcf\x2f\x2d\x29\x28\x2d\x29\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x94\xbe\x37\xf6\x42\x00\x00\x00")
+
+func _1538470221_add_meta_outputs_to_containersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470221_add_meta_outputs_to_containersDownSql,
+		"1538470221_add_meta_outputs_to_containers.down.sql",
+	)
+}
+
+func _1538470221_add_meta_outputs_to_containersDownSql() (*asset, error) {
+	bytes, err := _1538470221_add_meta_outputs_to_containersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470221_add_meta_outputs_to_containers.down.sql", size: 66, mode: os.FileMode(420), modTime: time.Unix(1792150478, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470221_add_meta_outputs_to_containersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\x2d\x49\x8c\xcf\x2f\x2d\x29\x28\x2d\x29\x56\xc8\x2a\xce\xcf\x4b\x52\x70\x71\x75\x73\x0c\xf5\x09\x51\x50\xaf\xae\x55\xb7\xb2\x82\x88\xf9\xf9\x87\x28\xf8\x85\xfa\xf8\x58\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xa7\x0d\x9b\x3c\x64\x00\x00\x00")
+
+func _1538470221_add_meta_outputs_to_containersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470221_add_meta_outputs_to_containersUpSql,
+		"1538470221_add_meta_outputs_to_containers.up.sql",
+	)
+}
+
+func _1538470221_add_meta_outputs_to_containersUpSql() (*asset, error) {
+	bytes, err := _1538470221_add_meta_outputs_to_containersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470221_add_meta_outputs_to_containers.up.sql", size: 100, mode: os.FileMode(420), modTime: time.Unix(1792150478, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470222_add_paused_before_deletion_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x48\x2c\x2d\x4e\x4d\x89\x4f\x4a\x4d\xcb\x2f\x4a\x8d\x4f\x49\xcd\x49\x2d\xc9\xcc\xcf\xb3\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x3f\x06\x14\x7f\x4b\x00\x00\x00")
+
+func _1538470222_add_paused_before_deletion_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470222_add_paused_before_deletion_to_pipelinesDownSql,
+		"1538470222_add_paused_before_deletion_to_pipelines.down.sql",
+	)
+}
+
+func _1538470222_add_paused_before_deletion_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1538470222_add_paused_before_deletion_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470222_add_paused_before_deletion_to_pipelines.down.sql", size: 75, mode: os.FileMode(420), modTime: time.Unix(1792150877, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470222_add_paused_before_deletion_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x05\xc1\x41\x0a\xc3\x20\x10\x05\xd0\xbd\xa7\xf8\xf7\x70\x65\xa2\x0d\x81\x51\xa1\x8c\xeb\x60\xc8\x04\x04\x51\x89\xe9\xfd\xfb\xde\xe2\xb6\x3d\x68\x05\x18\x62\xf7\x05\x9b\x85\x1c\x46\x19\x52\x4b\x93\x09\x63\x2d\xd6\x48\xc9\x07\x8c\xfc\x9b\x72\x1d\xa7\xdc\xfd\x91\xe3\x92\x2a\x6f\xe9\x0d\x67\xef\x55\x72\x83\x75\x1f\x93\x88\x71\xe7\x3a\x05\x21\x32\x42\x22\xd2\x6a\x8d\xde\xef\xac\xd5\x1f\x24\x76\x9b\xf2\x69\x00\x00\x00")
+
+func _1538470222_add_paused_before_deletion_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470222_add_paused_before_deletion_to_pipelinesUpSql,
+		"1538470222_add_paused_before_deletion_to_pipelines.up.sql",
+	)
+}
+
+func _1538470222_add_paused_before_deletion_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1538470222_add_paused_before_deletion_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470222_add_paused_before_deletion_to_pipelines.up.sql", size: 105, mode: os.FileMode(420), modTime: time.Unix(1792150877, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470223_create_build_token_accessorsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2a\xcd\xcc\x49\x89\x2f\xc9\xcf\x4e\xcd\x8b\x4f\x4c\x4e\x4e\x2d\x2e\xce\x2f\x2a\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x99\x9c\xc9\x4d\x35\x00\x00\x00")
+
+func _1538470223_create_build_token_accessorsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470223_create_build_token_accessorsDownSql,
+		"1538470223_create_build_token_accessors.down.sql",
+	)
+}
+
+func _1538470223_create_build_token_accessorsDownSql() (*asset, error) {
+	bytes, err := _1538470223_create_build_token_accessorsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470223_create_build_token_accessors.down.sql", size: 53, mode: os.FileMode(420), modTime: time.Unix(1792151069, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538470223_create_build_token_accessorsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x90\x41\x0e\x82\x30\x10\x45\xf7\x9c\x62\xd2\x15\x24\xdc\x80\x55\x29\x03\x69\x2c\xad\x29\x75\xc1\xaa\x51\xac\x86\x60\x20\x01\x4c\xf4\xf6\xa2\xa1\xd1\x68\x9c\xed\xff\xef\xcd\x64\x52\x2c\xb8\x4c\x02\x00\xa6\x91\x1a\x04\x43\x53\x81\x40\x0e\xd7\xf6\x72\xb4\xf3\xd0\xb9\xde\xee\x9b\xc6\x4d\xd3\x30\x4e\x04\xc2\xa5\xf8\x9c\x35\x6f\x8f\x04\xda\x7e\x76\x67\x37\x82\x54\x06\xe4\x4e\x88\xd8\x57\x3c\x46\x60\x76\xb7\xf9\x27\xdf\x6a\x5e\x52\x5d\xc3\x06\x6b\x08\xdf\xbe\xf8\x03\x8c\x7c\x97\x29\x59\x19\x4d\xb9\x34\x7f\x2e\xb3\x9e\xb7\xa7\xce\xdd\x09\xe4\x4a\x23\x2f\xe4\xb7\x3c\x02\x8d\x39\x6a\x94\x0c\xab\xd5\x34\x91\x90\xbc\x12\x25\x21\x43\x81\xcb\x0b\x18\xad\x18\xcd\x70\xd9\x1d\x25\x01\x53\x65\xc9\x4d\x12\x3c\x00\xeb\x00\x59\x09\x28\x01\x00\x00")
+
+func _1538470223_create_build_token_accessorsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538470223_create_build_token_accessorsUpSql,
+		"1538470223_create_build_token_accessors.up.sql",
+	)
+}
+
+func _1538470223_create_build_token_accessorsUpSql() (*asset, error) {
+	bytes, err := _1538470223_create_build_token_accessorsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538470223_create_build_token_accessors.up.sql", size: 296, mode: os.FileMode(420), modTime: time.Unix(1792151069, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1219,6 +2269,56 @@ var _bindata = map[string]func() (*asset, error){
 	"1533136021_upsert_uniqueness.up.sql": _1533136021_upsert_uniquenessUpSql,
 	"1533739478_drop_unused_volume_columns.down.sql": _1533739478_drop_unused_volume_columnsDownSql,
 	"1533739478_drop_unused_volume_columns.up.sql": _1533739478_drop_unused_volume_columnsUpSql,
+	"1534178461_add_error_to_builds.down.sql": _1534178461_add_error_to_buildsDownSql,
+	"1534178461_add_error_to_builds.up.sql": _1534178461_add_error_to_buildsUpSql,
+	"1534430123_add_network_to_pipelines.down.sql": _1534430123_add_network_to_pipelinesDownSql,
+	"1534430123_add_network_to_pipelines.up.sql": _1534430123_add_network_to_pipelinesUpSql,
+	"1534517589_add_allow_privileged_to_teams.down.sql": _1534517589_add_allow_privileged_to_teamsDownSql,
+	"1534517589_add_allow_privileged_to_teams.up.sql": _1534517589_add_allow_privileged_to_teamsUpSql,
+	"1534603201_add_max_in_flight_to_pipelines.down.sql": _1534603201_add_max_in_flight_to_pipelinesDownSql,
+	"1534603201_add_max_in_flight_to_pipelines.up.sql": _1534603201_add_max_in_flight_to_pipelinesUpSql,
+	"1534871519_create_webhooks.down.sql": _1534871519_create_webhooksDownSql,
+	"1534871519_create_webhooks.up.sql": _1534871519_create_webhooksUpSql,
+	"1534958201_add_deleted_at_to_pipelines.down.sql": _1534958201_add_deleted_at_to_pipelinesDownSql,
+	"1534958201_add_deleted_at_to_pipelines.up.sql": _1534958201_add_deleted_at_to_pipelinesUpSql,
+	"1535036812_add_container_retention_to_teams.down.sql": _1535036812_add_container_retention_to_teamsDownSql,
+	"1535036812_add_container_retention_to_teams.up.sql": _1535036812_add_container_retention_to_teamsUpSql,
+	"1535123076_create_cert_cache.down.sql": _1535123076_create_cert_cacheDownSql,
+	"1535123076_create_cert_cache.up.sql": _1535123076_create_cert_cacheUpSql,
+	"1535470286_add_labels_to_pipelines_and_jobs.down.sql": _1535470286_add_labels_to_pipelines_and_jobsDownSql,
+	"1535470286_add_labels_to_pipelines_and_jobs.up.sql": _1535470286_add_labels_to_pipelines_and_jobsUpSql,
+	"1535632051_add_idle_tracking_to_pipelines.down.sql": _1535632051_add_idle_tracking_to_pipelinesDownSql,
+	"1535632051_add_idle_tracking_to_pipelines.up.sql": _1535632051_add_idle_tracking_to_pipelinesUpSql,
+	"1535980214_add_notify_on_transition_to_jobs.down.sql": _1535980214_add_notify_on_transition_to_jobsDownSql,
+	"1535980214_add_notify_on_transition_to_jobs.up.sql": _1535980214_add_notify_on_transition_to_jobsUpSql,
+	"1536148921_add_start_time_indexes_to_builds.down.sql": _1536148921_add_start_time_indexes_to_buildsDownSql,
+	"1536148921_add_start_time_indexes_to_builds.up.sql": _1536148921_add_start_time_indexes_to_buildsUpSql,
+	"1536752341_add_trigger_reason_to_builds.down.sql": _1536752341_add_trigger_reason_to_buildsDownSql,
+	"1536752341_add_trigger_reason_to_builds.up.sql": _1536752341_add_trigger_reason_to_buildsUpSql,
+	"1537291206_add_runtime_to_workers.down.sql": _1537291206_add_runtime_to_workersDownSql,
+	"1537291206_add_runtime_to_workers.up.sql": _1537291206_add_runtime_to_workersUpSql,
+	"1537480431_add_last_used_to_worker_task_caches.down.sql": _1537480431_add_last_used_to_worker_task_cachesDownSql,
+	"1537480431_add_last_used_to_worker_task_caches.up.sql": _1537480431_add_last_used_to_worker_task_cachesUpSql,
+	"1537910553_create_team_usage.down.sql": _1537910553_create_team_usageDownSql,
+	"1537910553_create_team_usage.up.sql": _1537910553_create_team_usageUpSql,
+	"1538003462_add_load_to_workers.down.sql": _1538003462_add_load_to_workersDownSql,
+	"1538003462_add_load_to_workers.up.sql": _1538003462_add_load_to_workersUpSql,
+	"1538091547_add_api_pinned_version_to_resources.down.sql": _1538091547_add_api_pinned_version_to_resourcesDownSql,
+	"1538091547_add_api_pinned_version_to_resources.up.sql": _1538091547_add_api_pinned_version_to_resourcesUpSql,
+	"1538170832_add_abort_reason_to_builds.down.sql": _1538170832_add_abort_reason_to_buildsDownSql,
+	"1538170832_add_abort_reason_to_builds.up.sql": _1538170832_add_abort_reason_to_buildsUpSql,
+	"1538393317_add_denies_network_by_default_to_workers.down.sql": _1538393317_add_denies_network_by_default_to_workersDownSql,
+	"1538393317_add_denies_network_by_default_to_workers.up.sql": _1538393317_add_denies_network_by_default_to_workersUpSql,
+	"1538470219_create_build_input_uploads.down.sql": _1538470219_create_build_input_uploadsDownSql,
+	"1538470219_create_build_input_uploads.up.sql": _1538470219_create_build_input_uploadsUpSql,
+	"1538470220_add_containers_expire_at_to_builds.down.sql": _1538470220_add_containers_expire_at_to_buildsDownSql,
+	"1538470220_add_containers_expire_at_to_builds.up.sql": _1538470220_add_containers_expire_at_to_buildsUpSql,
+	"1538470221_add_meta_outputs_to_containers.down.sql": _1538470221_add_meta_outputs_to_containersDownSql,
+	"1538470221_add_meta_outputs_to_containers.up.sql": _1538470221_add_meta_outputs_to_containersUpSql,
+	"1538470222_add_paused_before_deletion_to_pipelines.down.sql": _1538470222_add_paused_before_deletion_to_pipelinesDownSql,
+	"1538470222_add_paused_before_deletion_to_pipelines.up.sql": _1538470222_add_paused_before_deletion_to_pipelinesUpSql,
+	"1538470223_create_build_token_accessors.down.sql": _1538470223_create_build_token_accessorsDownSql,
+	"1538470223_create_build_token_accessors.up.sql": _1538470223_create_build_token_accessorsUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -1311,6 +2411,56 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1533136021_upsert_uniqueness.up.sql": &bintree{_1533136021_upsert_uniquenessUpSql, map[string]*bintree{}},
 	"1533739478_drop_unused_volume_columns.down.sql": &bintree{_1533739478_drop_unused_volume_columnsDownSql, map[string]*bintree{}},
 	"1533739478_drop_unused_volume_columns.up.sql": &bintree{_1533739478_drop_unused_volume_columnsUpSql, map[string]*bintree{}},
+	"1534178461_add_error_to_builds.down.sql": &bintree{_1534178461_add_error_to_buildsDownSql, map[string]*bintree{}},
+	"1534178461_add_error_to_builds.up.sql": &bintree{_1534178461_add_error_to_buildsUpSql, map[string]*bintree{}},
+	"1534430123_add_network_to_pipelines.down.sql": &bintree{_1534430123_add_network_to_pipelinesDownSql, map[string]*bintree{}},
+	"1534430123_add_network_to_pipelines.up.sql": &bintree{_1534430123_add_network_to_pipelinesUpSql, map[string]*bintree{}},
+	"1534517589_add_allow_privileged_to_teams.down.sql": &bintree{_1534517589_add_allow_privileged_to_teamsDownSql, map[string]*bintree{}},
+	"1534517589_add_allow_privileged_to_teams.up.sql": &bintree{_1534517589_add_allow_privileged_to_teamsUpSql, map[string]*bintree{}},
+	"1534603201_add_max_in_flight_to_pipelines.down.sql": &bintree{_1534603201_add_max_in_flight_to_pipelinesDownSql, map[string]*bintree{}},
+	"1534603201_add_max_in_flight_to_pipelines.up.sql": &bintree{_1534603201_add_max_in_flight_to_pipelinesUpSql, map[string]*bintree{}},
+	"1534871519_create_webhooks.down.sql": &bintree{_1534871519_create_webhooksDownSql, map[string]*bintree{}},
+	"1534871519_create_webhooks.up.sql": &bintree{_1534871519_create_webhooksUpSql, map[string]*bintree{}},
+	"1534958201_add_deleted_at_to_pipelines.down.sql": &bintree{_1534958201_add_deleted_at_to_pipelinesDownSql, map[string]*bintree{}},
+	"1534958201_add_deleted_at_to_pipelines.up.sql": &bintree{_1534958201_add_deleted_at_to_pipelinesUpSql, map[string]*bintree{}},
+	"1535036812_add_container_retention_to_teams.down.sql": &bintree{_1535036812_add_container_retention_to_teamsDownSql, map[string]*bintree{}},
+	"1535036812_add_container_retention_to_teams.up.sql": &bintree{_1535036812_add_container_retention_to_teamsUpSql, map[string]*bintree{}},
+	"1535123076_create_cert_cache.down.sql": &bintree{_1535123076_create_cert_cacheDownSql, map[string]*bintree{}},
+	"1535123076_create_cert_cache.up.sql": &bintree{_1535123076_create_cert_cacheUpSql, map[string]*bintree{}},
+	"1535470286_add_labels_to_pipelines_and_jobs.down.sql": &bintree{_1535470286_add_labels_to_pipelines_and_jobsDownSql, map[string]*bintree{}},
+	"1535470286_add_labels_to_pipelines_and_jobs.up.sql": &bintree{_1535470286_add_labels_to_pipelines_and_jobsUpSql, map[string]*bintree{}},
+	"1535632051_add_idle_tracking_to_pipelines.down.sql": &bintree{_1535632051_add_idle_tracking_to_pipelinesDownSql, map[string]*bintree{}},
+	"1535632051_add_idle_tracking_to_pipelines.up.sql": &bintree{_1535632051_add_idle_tracking_to_pipelinesUpSql, map[string]*bintree{}},
+	"1535980214_add_notify_on_transition_to_jobs.down.sql": &bintree{_1535980214_add_notify_on_transition_to_jobsDownSql, map[string]*bintree{}},
+	"1535980214_add_notify_on_transition_to_jobs.up.sql": &bintree{_1535980214_add_notify_on_transition_to_jobsUpSql, map[string]*bintree{}},
+	"1536148921_add_start_time_indexes_to_builds.down.sql": &bintree{_1536148921_add_start_time_indexes_to_buildsDownSql, map[string]*bintree{}},
+	"1536148921_add_start_time_indexes_to_builds.up.sql": &bintree{_1536148921_add_start_time_indexes_to_buildsUpSql, map[string]*bintree{}},
+	"1536752341_add_trigger_reason_to_builds.down.sql": &bintree{_1536752341_add_trigger_reason_to_buildsDownSql, map[string]*bintree{}},
+	"1536752341_add_trigger_reason_to_builds.up.sql": &bintree{_1536752341_add_trigger_reason_to_buildsUpSql, map[string]*bintree{}},
+	"1537291206_add_runtime_to_workers.down.sql": &bintree{_1537291206_add_runtime_to_workersDownSql, map[string]*bintree{}},
+	"1537291206_add_runtime_to_workers.up.sql": &bintree{_1537291206_add_runtime_to_workersUpSql, map[string]*bintree{}},
+	"1537480431_add_last_used_to_worker_task_caches.down.sql": &bintree{_1537480431_add_last_used_to_worker_task_cachesDownSql, map[string]*bintree{}},
+	"1537480431_add_last_used_to_worker_task_caches.up.sql": &bintree{_1537480431_add_last_used_to_worker_task_cachesUpSql, map[string]*bintree{}},
+	"1537910553_create_team_usage.down.sql": &bintree{_1537910553_create_team_usageDownSql, map[string]*bintree{}},
+	"1537910553_create_team_usage.up.sql": &bintree{_1537910553_create_team_usageUpSql, map[string]*bintree{}},
+	"1538003462_add_load_to_workers.down.sql": &bintree{_1538003462_add_load_to_workersDownSql, map[string]*bintree{}},
+	"1538003462_add_load_to_workers.up.sql": &bintree{_1538003462_add_load_to_workersUpSql, map[string]*bintree{}},
+	"1538091547_add_api_pinned_version_to_resources.down.sql": &bintree{_1538091547_add_api_pinned_version_to_resourcesDownSql, map[string]*bintree{}},
+	"1538091547_add_api_pinned_version_to_resources.up.sql": &bintree{_1538091547_add_api_pinned_version_to_resourcesUpSql, map[string]*bintree{}},
+	"1538170832_add_abort_reason_to_builds.down.sql": &bintree{_1538170832_add_abort_reason_to_buildsDownSql, map[string]*bintree{}},
+	"1538170832_add_abort_reason_to_builds.up.sql": &bintree{_1538170832_add_abort_reason_to_buildsUpSql, map[string]*bintree{}},
+	"1538393317_add_denies_network_by_default_to_workers.down.sql": &bintree{_1538393317_add_denies_network_by_default_to_workersDownSql, map[string]*bintree{}},
+	"1538393317_add_denies_network_by_default_to_workers.up.sql": &bintree{_1538393317_add_denies_network_by_default_to_workersUpSql, map[string]*bintree{}},
+	"1538470219_create_build_input_uploads.down.sql": &bintree{_1538470219_create_build_input_uploadsDownSql, map[string]*bintree{}},
+	"1538470219_create_build_input_uploads.up.sql": &bintree{_1538470219_create_build_input_uploadsUpSql, map[string]*bintree{}},
+	"1538470220_add_containers_expire_at_to_builds.down.sql": &bintree{_1538470220_add_containers_expire_at_to_buildsDownSql, map[string]*bintree{}},
+	"1538470220_add_containers_expire_at_to_builds.up.sql": &bintree{_1538470220_add_containers_expire_at_to_buildsUpSql, map[string]*bintree{}},
+	"1538470221_add_meta_outputs_to_containers.down.sql": &bintree{_1538470221_add_meta_outputs_to_containersDownSql, map[string]*bintree{}},
+	"1538470221_add_meta_outputs_to_containers.up.sql": &bintree{_1538470221_add_meta_outputs_to_containersUpSql, map[string]*bintree{}},
+	"1538470222_add_paused_before_deletion_to_pipelines.down.sql": &bintree{_1538470222_add_paused_before_deletion_to_pipelinesDownSql, map[string]*bintree{}},
+	"1538470222_add_paused_before_deletion_to_pipelines.up.sql": &bintree{_1538470222_add_paused_before_deletion_to_pipelinesUpSql, map[string]*bintree{}},
+	"1538470223_create_build_token_accessors.down.sql": &bintree{_1538470223_create_build_token_accessorsDownSql, map[string]*bintree{}},
+	"1538470223_create_build_token_accessors.up.sql": &bintree{_1538470223_create_build_token_accessorsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
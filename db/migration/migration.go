@@ -15,12 +15,17 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// lockProgressInterval is how often to log while waiting for another ATC to
+// release the migration lock.
+const lockProgressInterval = 10 * time.Second
+
 func NewOpenHelper(driver, name string, lockFactory lock.LockFactory, strategy encryption.Strategy) *OpenHelper {
 	return &OpenHelper{
-		driver,
-		name,
-		lockFactory,
-		strategy,
+		driver:         driver,
+		dataSourceName: name,
+		lockFactory:    lockFactory,
+		strategy:       strategy,
+		logger:         lager.NewLogger("migrations"),
 	}
 }
 
@@ -29,6 +34,32 @@ type OpenHelper struct {
 	dataSourceName string
 	lockFactory    lock.LockFactory
 	strategy       encryption.Strategy
+	logger         lager.Logger
+	lockTimeout    time.Duration
+}
+
+// WithLogger sets the logger to which migration progress is logged.
+func (self *OpenHelper) WithLogger(logger lager.Logger) *OpenHelper {
+	self.logger = logger
+	return self
+}
+
+// WithLockTimeout gives up on migrating if the migration lock cannot be
+// acquired within the timeout. Zero waits forever.
+func (self *OpenHelper) WithLockTimeout(timeout time.Duration) *OpenHelper {
+	self.lockTimeout = timeout
+	return self
+}
+
+func (self *OpenHelper) migrator(db *sql.DB) Migrator {
+	return &migrator{
+		db:          db,
+		lockFactory: self.lockFactory,
+		strategy:    self.strategy,
+		logger:      self.logger,
+		bindata:     &bindataSource{},
+		lockTimeout: self.lockTimeout,
+	}
 }
 
 func (self *OpenHelper) CurrentVersion() (int, error) {
@@ -39,7 +70,7 @@ func (self *OpenHelper) CurrentVersion() (int, error) {
 
 	defer db.Close()
 
-	return NewMigrator(db, self.lockFactory, self.strategy).CurrentVersion()
+	return self.migrator(db).CurrentVersion()
 }
 
 func (self *OpenHelper) SupportedVersion() (int, error) {
@@ -50,7 +81,18 @@ func (self *OpenHelper) SupportedVersion() (int, error) {
 
 	defer db.Close()
 
-	return NewMigrator(db, self.lockFactory, self.strategy).SupportedVersion()
+	return self.migrator(db).SupportedVersion()
+}
+
+func (self *OpenHelper) Status() (Status, error) {
+	db, err := sql.Open(self.driver, self.dataSourceName)
+	if err != nil {
+		return Status{}, err
+	}
+
+	defer db.Close()
+
+	return self.migrator(db).Status()
 }
 
 func (self *OpenHelper) Open() (*sql.DB, error) {
@@ -59,7 +101,7 @@ func (self *OpenHelper) Open() (*sql.DB, error) {
 		return nil, err
 	}
 
-	if err := NewMigrator(db, self.lockFactory, self.strategy).Up(); err != nil {
+	if err := self.migrator(db).Up(); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -73,7 +115,7 @@ func (self *OpenHelper) OpenAtVersion(version int) (*sql.DB, error) {
 		return nil, err
 	}
 
-	if err := NewMigrator(db, self.lockFactory, self.strategy).Migrate(version); err != nil {
+	if err := self.migrator(db).Migrate(version); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -88,7 +130,7 @@ func (self *OpenHelper) MigrateToVersion(version int) error {
 	}
 
 	defer db.Close()
-	m := NewMigrator(db, self.lockFactory, self.strategy)
+	m := self.migrator(db)
 
 	err = self.migrateFromMigrationVersion(db)
 	if err != nil {
@@ -138,22 +180,57 @@ func (self *OpenHelper) migrateFromMigrationVersion(db *sql.DB) error {
 type Migrator interface {
 	CurrentVersion() (int, error)
 	SupportedVersion() (int, error)
+	Status() (Status, error)
 	Migrate(version int) error
 	Up() error
 	Migrations() ([]migration, error)
 }
 
+// Status describes how far the database is from the supported version, and
+// who is migrating it if anyone.
+type Status struct {
+	CurrentVersion    int         `json:"current_version"`
+	SupportedVersion  int         `json:"supported_version"`
+	PendingMigrations []string    `json:"pending_migrations"`
+	LockHolder        *LockHolder `json:"lock_holder"`
+}
+
+// LockHolder is the database session holding the migration lock.
+type LockHolder struct {
+	PID             int       `json:"pid"`
+	ApplicationName string    `json:"application_name"`
+	ClientAddr      string    `json:"client_addr"`
+	BackendStart    time.Time `json:"backend_start"`
+}
+
+func (holder *LockHolder) String() string {
+	if holder == nil {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("pid %d (%s) from %s", holder.PID, holder.ApplicationName, holder.ClientAddr)
+}
+
+type LockTimeoutError struct {
+	Timeout time.Duration
+	Holder  *LockHolder
+}
+
+func (err LockTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for the migration lock held by %s", err.Timeout, err.Holder)
+}
+
 func NewMigrator(db *sql.DB, lockFactory lock.LockFactory, strategy encryption.Strategy) Migrator {
 	return NewMigratorForMigrations(db, lockFactory, strategy, &bindataSource{})
 }
 
 func NewMigratorForMigrations(db *sql.DB, lockFactory lock.LockFactory, strategy encryption.Strategy, bindata Bindata) Migrator {
 	return &migrator{
-		db,
-		lockFactory,
-		strategy,
-		lager.NewLogger("migrations"),
-		bindata,
+		db:          db,
+		lockFactory: lockFactory,
+		strategy:    strategy,
+		logger:      lager.NewLogger("migrations"),
+		bindata:     bindata,
 	}
 }
 
@@ -163,6 +240,7 @@ type migrator struct {
 	strategy    encryption.Strategy
 	logger      lager.Logger
 	bindata     Bindata
+	lockTimeout time.Duration
 }
 
 func (m *migrator) SupportedVersion() (int, error) {
@@ -209,6 +287,75 @@ func (self *migrator) CurrentVersion() (int, error) {
 	return currentVersion, nil
 }
 
+func (self *migrator) Status() (Status, error) {
+	currentVersion := 0
+	if checkTableExist(self.db, "migrations_history") {
+		var err error
+		currentVersion, err = self.CurrentVersion()
+		if err != nil {
+			return Status{}, err
+		}
+	}
+
+	supportedVersion, err := self.SupportedVersion()
+	if err != nil {
+		return Status{}, err
+	}
+
+	migrations, err := self.Migrations()
+	if err != nil {
+		return Status{}, err
+	}
+
+	pending := []string{}
+	for _, m := range migrations {
+		if m.Direction == "up" && m.Version > currentVersion {
+			pending = append(pending, m.Name)
+		}
+	}
+
+	holder, err := self.lockHolder()
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{
+		CurrentVersion:    currentVersion,
+		SupportedVersion:  supportedVersion,
+		PendingMigrations: pending,
+		LockHolder:        holder,
+	}, nil
+}
+
+// lockHolder finds the session holding the migration lock, if any. A lock
+// taken with a single key is reported by postgres with the key in objid and
+// an objsubid of 1. Advisory locks are per database, so ones held by other
+// databases on the same server are ignored.
+func (self *migrator) lockHolder() (*LockHolder, error) {
+	var holder LockHolder
+	err := self.db.QueryRow(`
+		SELECT a.pid, COALESCE(a.application_name, ''), COALESCE(host(a.client_addr), ''), a.backend_start
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory'
+		AND l.granted
+		AND l.classid = 0
+		AND l.objid = $1
+		AND l.objsubid = 1
+		AND l.database = (SELECT oid FROM pg_database WHERE datname = current_database())
+		LIMIT 1
+	`, lock.NewDatabaseMigrationLockID()[0]).Scan(&holder.PID, &holder.ApplicationName, &holder.ClientAddr, &holder.BackendStart)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &holder, nil
+}
+
 func (self *migrator) Migrate(toVersion int) error {
 
 	lock, err := self.acquireLock()
@@ -255,6 +402,8 @@ func (self *migrator) Migrate(toVersion int) error {
 	if currentVersion <= toVersion {
 		for _, m := range migrations {
 			if currentVersion < m.Version && m.Version <= toVersion && m.Direction == "up" {
+				self.logger.Info("running-migration", lager.Data{"version": m.Version, "name": m.Name})
+
 				err = self.runMigration(m)
 				if err != nil {
 					return err
@@ -264,6 +413,8 @@ func (self *migrator) Migrate(toVersion int) error {
 	} else {
 		for i := len(migrations) - 1; i >= 0; i-- {
 			if currentVersion >= migrations[i].Version && migrations[i].Version > toVersion && migrations[i].Direction == "down" {
+				self.logger.Info("reverting-migration", lager.Data{"version": migrations[i].Version, "name": migrations[i].Name})
+
 				err = self.runMigration(migrations[i])
 				if err != nil {
 					return err
@@ -360,28 +511,56 @@ func (self *migrator) Up() error {
 }
 
 func (self *migrator) acquireLock() (lock.Lock, error) {
+	if self.lockFactory == nil {
+		return nil, nil
+	}
 
-	var err error
-	var acquired bool
-	var newLock lock.Lock
+	logger := self.logger.Session("acquire-lock")
+
+	started := time.Now()
+	var lastLogged time.Time
 
-	if self.lockFactory != nil {
-		for {
-			newLock, acquired, err = self.lockFactory.Acquire(self.logger, lock.NewDatabaseMigrationLockID())
+	for {
+		newLock, acquired, err := self.lockFactory.Acquire(logger, lock.NewDatabaseMigrationLockID())
+		if err != nil {
+			return nil, err
+		}
+
+		if acquired {
+			if !lastLogged.IsZero() {
+				logger.Info("acquired", lager.Data{"waited": time.Since(started).String()})
+			}
 
+			return newLock, nil
+		}
+
+		waited := time.Since(started)
+
+		if self.lockTimeout > 0 && waited >= self.lockTimeout {
+			holder, err := self.lockHolder()
 			if err != nil {
-				return nil, err
+				logger.Error("failed-to-find-lock-holder", err)
 			}
 
-			if acquired {
-				break
+			return nil, LockTimeoutError{Timeout: self.lockTimeout, Holder: holder}
+		}
+
+		if time.Since(lastLogged) >= lockProgressInterval {
+			holder, err := self.lockHolder()
+			if err != nil {
+				logger.Error("failed-to-find-lock-holder", err)
 			}
 
-			time.Sleep(1 * time.Second)
+			logger.Info("waiting", lager.Data{
+				"waited": waited.String(),
+				"holder": holder.String(),
+			})
+
+			lastLogged = time.Now()
 		}
-	}
 
-	return newLock, err
+		time.Sleep(1 * time.Second)
+	}
 }
 
 func checkTableExist(db *sql.DB, tableName string) bool {
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN network text;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN allow_privileged boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN max_in_flight;
+COMMIT;
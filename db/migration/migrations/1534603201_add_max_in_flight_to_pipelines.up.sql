@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN max_in_flight integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+  CREATE TABLE "webhooks" (
+      "id" serial,
+      "team_id" integer NOT NULL,
+      "url" text NOT NULL,
+      "secret" text NOT NULL,
+      "nonce" text,
+      "events" text NOT NULL DEFAULT '[]',
+      PRIMARY KEY ("id"),
+      CONSTRAINT "webhooks_team_id_fkey" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE CASCADE
+  );
+
+  CREATE INDEX webhooks_team_id ON webhooks (team_id);
+
+  CREATE TABLE "webhook_deliveries" (
+      "id" serial,
+      "webhook_id" integer NOT NULL,
+      "build_id" integer NOT NULL,
+      "event_type" text NOT NULL,
+      "event_version" text NOT NULL,
+      "payload" text NOT NULL,
+      "attempts" integer NOT NULL DEFAULT 0,
+      "next_attempt_at" timestamp with time zone NOT NULL DEFAULT now(),
+      PRIMARY KEY ("id"),
+      CONSTRAINT "webhook_deliveries_webhook_id_fkey" FOREIGN KEY ("webhook_id") REFERENCES "webhooks"("id") ON DELETE CASCADE,
+      CONSTRAINT "webhook_deliveries_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+
+  CREATE INDEX webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
+COMMIT;
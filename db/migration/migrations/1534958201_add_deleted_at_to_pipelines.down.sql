@@ -0,0 +1,9 @@
+BEGIN;
+  DELETE FROM pipelines WHERE deleted_at IS NOT NULL;
+
+  DROP INDEX pipelines_name_team_id;
+
+  ALTER TABLE pipelines ADD CONSTRAINT pipelines_name_team_id UNIQUE (name, team_id);
+
+  ALTER TABLE pipelines DROP COLUMN deleted_at;
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN deleted_at timestamp with time zone;
+
+  ALTER TABLE pipelines DROP CONSTRAINT pipelines_name_team_id;
+
+  CREATE UNIQUE INDEX pipelines_name_team_id ON pipelines (name, team_id) WHERE deleted_at IS NULL;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    DROP COLUMN container_retention_success,
+    DROP COLUMN container_retention_failure;
+COMMIT;
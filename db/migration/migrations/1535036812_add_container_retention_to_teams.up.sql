@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN container_retention_success integer,
+    ADD COLUMN container_retention_failure integer;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "cert_cache";
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE "cert_cache" (
+      "id" serial,
+      "domain" text NOT NULL,
+      "cert" text NOT NULL,
+      "nonce" text,
+      PRIMARY KEY ("id"),
+      CONSTRAINT "cert_cache_domain_key" UNIQUE ("domain")
+  );
+COMMIT;
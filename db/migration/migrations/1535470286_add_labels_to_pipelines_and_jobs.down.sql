@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE jobs DROP COLUMN labels;
+  ALTER TABLE pipelines DROP COLUMN labels;
+COMMIT;
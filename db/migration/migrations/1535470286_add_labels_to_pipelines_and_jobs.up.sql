@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+  ALTER TABLE jobs ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN last_updated;
+  ALTER TABLE pipelines DROP COLUMN paused_by_inactivity;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN last_updated timestamp with time zone NOT NULL DEFAULT now();
+  ALTER TABLE pipelines ADD COLUMN paused_by_inactivity boolean NOT NULL DEFAULT false;
+COMMIT;
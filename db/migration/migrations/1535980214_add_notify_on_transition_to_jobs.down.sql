@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs DROP COLUMN notify_on_transition;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs ADD COLUMN notify_on_transition boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP INDEX builds_status_start_time;
+  DROP INDEX builds_start_time;
+COMMIT;
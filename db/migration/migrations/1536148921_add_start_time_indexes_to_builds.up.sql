@@ -0,0 +1,4 @@
+BEGIN;
+  CREATE INDEX builds_start_time ON builds USING btree (start_time);
+  CREATE INDEX builds_status_start_time ON builds USING btree (status, start_time);
+COMMIT;
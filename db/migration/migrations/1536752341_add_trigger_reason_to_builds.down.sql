@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN trigger_reason;
+COMMIT;
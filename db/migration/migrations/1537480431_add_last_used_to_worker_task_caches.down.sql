@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE worker_task_caches DROP COLUMN last_used;
+COMMIT;
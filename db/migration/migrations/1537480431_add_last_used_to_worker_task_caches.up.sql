@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE worker_task_caches ADD COLUMN last_used timestamp with time zone NOT NULL DEFAULT now();
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE "team_usage_recorded";
+  DROP TABLE "team_usage";
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+  CREATE TABLE "team_usage" (
+      "team_id" integer NOT NULL,
+      "day" date NOT NULL,
+      "build_seconds" bigint NOT NULL DEFAULT 0,
+      "container_seconds" bigint NOT NULL DEFAULT 0,
+      PRIMARY KEY ("team_id", "day"),
+      CONSTRAINT "team_usage_team_id_fkey" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE CASCADE
+  );
+
+  CREATE TABLE "team_usage_recorded" (
+      "id" integer NOT NULL DEFAULT 1,
+      "recorded_at" timestamp with time zone NOT NULL DEFAULT now(),
+      PRIMARY KEY ("id"),
+      CONSTRAINT "team_usage_recorded_single_row" CHECK ("id" = 1)
+  );
+COMMIT;
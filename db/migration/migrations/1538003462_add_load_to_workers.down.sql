@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE workers
+    DROP COLUMN cpu_load,
+    DROP COLUMN free_memory,
+    DROP COLUMN free_disk;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE workers
+    ADD COLUMN cpu_load double precision,
+    ADD COLUMN free_memory bigint,
+    ADD COLUMN free_disk bigint;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resources DROP COLUMN api_pinned_version;
+COMMIT;
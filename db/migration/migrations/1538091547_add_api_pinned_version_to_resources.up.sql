@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resources ADD COLUMN api_pinned_version jsonb;
+COMMIT;
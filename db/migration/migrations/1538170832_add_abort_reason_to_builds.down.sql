@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN abort_reason, DROP COLUMN aborted_by;
+COMMIT;
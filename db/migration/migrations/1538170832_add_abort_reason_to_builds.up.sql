@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN abort_reason text, ADD COLUMN aborted_by text;
+COMMIT;
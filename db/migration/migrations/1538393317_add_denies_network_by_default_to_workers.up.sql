@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN denies_network_by_default boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE "volumes" DROP COLUMN "build_input_upload_id";
+
+  DROP TABLE "build_input_uploads";
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+  CREATE TABLE "build_input_uploads" (
+      "id" serial,
+      "build_id" integer NOT NULL,
+      "plan_id" text NOT NULL,
+      "worker_name" text NOT NULL,
+      "received" bigint DEFAULT 0 NOT NULL,
+      "chunks" integer DEFAULT 0 NOT NULL,
+      PRIMARY KEY ("id"),
+      CONSTRAINT "build_input_uploads_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE,
+      CONSTRAINT "build_input_uploads_worker_name_fkey" FOREIGN KEY ("worker_name") REFERENCES "workers"("name") ON DELETE CASCADE,
+      CONSTRAINT "build_input_uploads_build_id_plan_id_key" UNIQUE ("build_id", "plan_id")
+  );
+
+  ALTER TABLE "volumes"
+  ADD COLUMN "build_input_upload_id" integer,
+  ADD CONSTRAINT "volumes_build_input_upload_id_fkey" FOREIGN KEY ("build_input_upload_id") REFERENCES "build_input_uploads"("id") ON DELETE SET NULL;
+
+  CREATE INDEX "volumes_build_input_upload_id" ON "volumes" USING btree ("build_input_upload_id");
+COMMIT;
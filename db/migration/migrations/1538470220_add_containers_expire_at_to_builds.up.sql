@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN containers_expire_at timestamp with time zone;
+COMMIT;
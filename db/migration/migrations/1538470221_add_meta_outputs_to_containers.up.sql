@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE containers ADD COLUMN meta_outputs jsonb DEFAULT '{}'::jsonb NOT NULL;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN paused_before_deletion;
+COMMIT;
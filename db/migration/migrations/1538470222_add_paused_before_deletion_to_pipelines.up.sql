@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN paused_before_deletion boolean DEFAULT false NOT NULL;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "build_token_accessors";
+COMMIT;
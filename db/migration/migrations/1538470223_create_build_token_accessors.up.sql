@@ -0,0 +1,8 @@
+BEGIN;
+  CREATE TABLE "build_token_accessors" (
+      "build_id" integer NOT NULL,
+      "accessor" text NOT NULL,
+      PRIMARY KEY ("build_id", "accessor"),
+      CONSTRAINT "build_token_accessors_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+COMMIT;
@@ -2,7 +2,10 @@ package migration_test
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
@@ -97,6 +100,96 @@ var _ = Describe("OpenHelper", func() {
 		})
 
 	})
+
+	Context("when another ATC holds the migration lock", func() {
+		var (
+			otherLockDB *sql.DB
+			otherLock   lock.Lock
+		)
+
+		JustBeforeEach(func() {
+			otherLockDB, err = sql.Open("postgres", postgresRunner.DataSourceName())
+			Expect(err).NotTo(HaveOccurred())
+
+			var acquired bool
+			otherLock, acquired, err = lock.NewLockFactory(otherLockDB).Acquire(lagertest.NewTestLogger("test"), lock.NewDatabaseMigrationLockID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			_ = otherLock.Release()
+			_ = otherLockDB.Close()
+		})
+
+		It("gives up once the lock timeout has passed, reporting who holds it", func() {
+			err = openHelper.WithLockTimeout(time.Second).MigrateToVersion(initialSchemaVersion)
+			Expect(err).To(BeAssignableToTypeOf(migration.LockTimeoutError{}))
+
+			holder := err.(migration.LockTimeoutError).Holder
+			Expect(holder).NotTo(BeNil())
+
+			var otherPID int
+			err = otherLockDB.QueryRow("SELECT pid FROM pg_locks WHERE locktype = 'advisory' AND granted LIMIT 1").Scan(&otherPID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holder.PID).To(Equal(otherPID))
+		})
+
+		It("reports who holds it in the status", func() {
+			status, err := openHelper.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.LockHolder).NotTo(BeNil())
+		})
+	})
+
+	Describe("Status", func() {
+		JustBeforeEach(func() {
+			err = openHelper.MigrateToVersion(initialSchemaVersion)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the current and supported versions along with the pending migrations", func() {
+			status, err := openHelper.Status()
+			Expect(err).NotTo(HaveOccurred())
+
+			supportedVersion, err := openHelper.SupportedVersion()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(status.CurrentVersion).To(Equal(initialSchemaVersion))
+			Expect(status.SupportedVersion).To(Equal(supportedVersion))
+			Expect(status.PendingMigrations).To(ContainElement("1510670987_update_unique_constraint_for_resource_caches.up.sql"))
+			Expect(status.PendingMigrations).NotTo(ContainElement("1510262030_initial_schema.up.sql"))
+			Expect(status.LockHolder).To(BeNil())
+		})
+
+		Context("when another database on the server holds the same lock", func() {
+			var (
+				otherDB   *sql.DB
+				otherLock lock.Lock
+			)
+
+			BeforeEach(func() {
+				otherDB, err = sql.Open("postgres", fmt.Sprintf("user=postgres dbname=postgres sslmode=disable port=%d", postgresRunner.Port))
+				Expect(err).NotTo(HaveOccurred())
+
+				var acquired bool
+				otherLock, acquired, err = lock.NewLockFactory(otherDB).Acquire(lagertest.NewTestLogger("test"), lock.NewDatabaseMigrationLockID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				_ = otherLock.Release()
+				_ = otherDB.Close()
+			})
+
+			It("does not report it as the lock holder", func() {
+				status, err := openHelper.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.LockHolder).To(BeNil())
+			})
+		})
+	})
 })
 
 func SetupMigrationVersionTableToExistAtVersion(db *sql.DB, version int) {
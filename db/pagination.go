@@ -14,3 +14,53 @@ type Pagination struct {
 	Previous *Page
 	Next     *Page
 }
+
+// PageIDs pages through IDs which were all loaded at once, ordered by
+// descending ID, the same way builds are paged through. It returns the range
+// of the IDs on the page.
+func PageIDs(ids []int, page Page) (int, int, Pagination) {
+	start, end := 0, len(ids)
+
+	if page.Until != 0 {
+		for end > 0 && ids[end-1] <= page.Until {
+			end--
+		}
+
+		start = end - page.Limit
+		if start < 0 {
+			start = 0
+		}
+	} else {
+		if page.Since != 0 {
+			for start < end && ids[start] >= page.Since {
+				start++
+			}
+		}
+
+		if start+page.Limit < end {
+			end = start + page.Limit
+		}
+	}
+
+	if start == end {
+		return start, end, Pagination{}
+	}
+
+	var pagination Pagination
+
+	if ids[start] < ids[0] {
+		pagination.Previous = &Page{
+			Until: ids[start],
+			Limit: page.Limit,
+		}
+	}
+
+	if ids[end-1] > ids[len(ids)-1] {
+		pagination.Next = &Page{
+			Since: ids[end-1],
+			Limit: page.Limit,
+		}
+	}
+
+	return start, end, pagination
+}
@@ -0,0 +1,50 @@
+package db_test
+
+import (
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PageIDs", func() {
+	ids := []int{9, 7, 6, 4, 2}
+
+	pageIDs := func(page db.Page) ([]int, db.Pagination) {
+		start, end, pagination := db.PageIDs(ids, page)
+		return ids[start:end], pagination
+	}
+
+	It("returns the first page when no cursor is given", func() {
+		page, pagination := pageIDs(db.Page{Limit: 2})
+		Expect(page).To(Equal([]int{9, 7}))
+		Expect(pagination.Previous).To(BeNil())
+		Expect(pagination.Next).To(Equal(&db.Page{Since: 7, Limit: 2}))
+	})
+
+	It("returns the IDs before the since cursor", func() {
+		page, pagination := pageIDs(db.Page{Since: 7, Limit: 2})
+		Expect(page).To(Equal([]int{6, 4}))
+		Expect(pagination.Previous).To(Equal(&db.Page{Until: 6, Limit: 2}))
+		Expect(pagination.Next).To(Equal(&db.Page{Since: 4, Limit: 2}))
+	})
+
+	It("returns the IDs closest to the until cursor", func() {
+		page, pagination := pageIDs(db.Page{Until: 4, Limit: 2})
+		Expect(page).To(Equal([]int{7, 6}))
+		Expect(pagination.Previous).To(Equal(&db.Page{Until: 7, Limit: 2}))
+		Expect(pagination.Next).To(Equal(&db.Page{Since: 6, Limit: 2}))
+	})
+
+	It("does not link past the last page", func() {
+		page, pagination := pageIDs(db.Page{Since: 4, Limit: 2})
+		Expect(page).To(Equal([]int{2}))
+		Expect(pagination.Previous).To(Equal(&db.Page{Until: 2, Limit: 2}))
+		Expect(pagination.Next).To(BeNil())
+	})
+
+	It("returns nothing past the end", func() {
+		page, pagination := pageIDs(db.Page{Since: 2, Limit: 2})
+		Expect(page).To(BeEmpty())
+		Expect(pagination).To(Equal(db.Pagination{}))
+	})
+})
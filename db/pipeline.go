@@ -37,9 +37,13 @@ type Pipeline interface {
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	Network() *atc.NetworkConfig
+	Labels() map[string]string
+	MaxInFlight() int
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	PausedByInactivity() bool
 	ScopedName(string) string
 
 	CheckPaused() (bool, error)
@@ -52,6 +56,8 @@ type Pipeline interface {
 	GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error)
 
 	GetAllPendingBuilds() (map[string][]Build, error)
+	GetRunningBuilds() ([]Build, error)
+	GetNextPendingBuilds() ([]Build, error)
 
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
@@ -101,6 +107,9 @@ type Pipeline interface {
 	Pause() error
 	Unpause() error
 
+	// SoftDelete hides and pauses the pipeline, leaving it to be destroyed by
+	// garbage collection once the grace period has passed.
+	SoftDelete() error
 	Destroy() error
 	Rename(string) error
 
@@ -113,10 +122,17 @@ type pipeline struct {
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
+	network       *atc.NetworkConfig
+	labels        map[string]string
+	maxInFlight   int
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
 
+	// set when the pipeline was paused for having been idle, rather than by
+	// a user
+	pausedByInactivity bool
+
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
 
@@ -133,15 +149,24 @@ var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
 		p.groups,
+		p.network,
+		p.labels,
+		p.max_in_flight,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
+		p.paused_by_inactivity,
 		p.public
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
+// undeletedPipelinesQuery excludes pipelines which have been soft-deleted.
+// Builds still refer to their pipeline through pipelinesQuery, so that those
+// running at the time of deletion can finish.
+var undeletedPipelinesQuery = pipelinesQuery.Where(sq.Eq{"p.deleted_at": nil})
+
 const (
 	PipelinePaused   PipelinePausedState = "paused"
 	PipelineUnpaused PipelinePausedState = "unpaused"
@@ -176,9 +201,13 @@ func (p *pipeline) Name() string                 { return p.name }
 func (p *pipeline) TeamID() int                  { return p.teamID }
 func (p *pipeline) TeamName() string             { return p.teamName }
 func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
+func (p *pipeline) Network() *atc.NetworkConfig  { return p.network }
+func (p *pipeline) Labels() map[string]string    { return p.labels }
+func (p *pipeline) MaxInFlight() int             { return p.maxInFlight }
 func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 func (p *pipeline) Public() bool                 { return p.public }
 func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) PausedByInactivity() bool     { return p.pausedByInactivity }
 
 func (p *pipeline) ScopedName(n string) string {
 	return p.name + ":" + n
@@ -248,7 +277,7 @@ func (p *pipeline) CheckPaused() (bool, error) {
 	return paused, nil
 }
 func (p *pipeline) Reload() (bool, error) {
-	row := pipelinesQuery.Where(sq.Eq{"p.id": p.id}).
+	row := undeletedPipelinesQuery.Where(sq.Eq{"p.id": p.id}).
 		RunWith(p.conn).
 		QueryRow()
 
@@ -363,6 +392,76 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 	return builds, nil
 }
 
+// GetRunningBuilds returns the builds of the pipeline which are either
+// running or have been scheduled to run.
+func (p *pipeline) GetRunningBuilds() ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+		}).
+		Where(sq.Or{
+			sq.Eq{"b.status": BuildStatusStarted},
+			sq.Eq{"b.status": BuildStatusPending, "b.scheduled": true},
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build := &build{conn: p.conn, lockFactory: p.lockFactory}
+		err = scanBuild(build, rows, p.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+// GetNextPendingBuilds returns the pending builds across all of the
+// pipeline's jobs which have yet to be scheduled, oldest first.
+func (p *pipeline) GetNextPendingBuilds() ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.status":            BuildStatusPending,
+			"b.scheduled":         false,
+			"j.active":            true,
+			"j.paused":            false,
+			"j.inputs_determined": true,
+			"b.pipeline_id":       p.id,
+		}).
+		OrderBy("b.id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build := &build{conn: p.conn, lockFactory: p.lockFactory}
+		err = scanBuild(build, rows, p.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
 func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -930,6 +1029,7 @@ func (p *pipeline) Dashboard() (Dashboard, error) {
 func (p *pipeline) Pause() error {
 	_, err := psql.Update("pipelines").
 		Set("paused", true).
+		Set("paused_by_inactivity", false).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -942,6 +1042,7 @@ func (p *pipeline) Pause() error {
 func (p *pipeline) Unpause() error {
 	_, err := psql.Update("pipelines").
 		Set("paused", false).
+		Set("paused_by_inactivity", false).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -987,6 +1088,21 @@ func (p *pipeline) Rename(name string) error {
 	return err
 }
 
+func (p *pipeline) SoftDelete() error {
+	_, err := psql.Update("pipelines").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("paused_before_deletion", sq.Expr("paused")).
+		Set("paused", true).
+		Where(sq.Eq{
+			"id":         p.id,
+			"deleted_at": nil,
+		}).
+		RunWith(p.conn).
+		Exec()
+
+	return err
+}
+
 func (p *pipeline) Destroy() error {
 	_, err := psql.Delete("pipelines").
 		Where(sq.Eq{
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc/db/lock"
 )
@@ -8,8 +10,17 @@ import (
 //go:generate counterfeiter . PipelineFactory
 
 type PipelineFactory interface {
-	VisiblePipelines([]string) ([]Pipeline, error)
+	VisiblePipelines([]string, DashboardFilter) ([]Pipeline, error)
 	AllPipelines() ([]Pipeline, error)
+
+	// PurgeDeletedPipelines destroys pipelines which were soft-deleted longer
+	// than the grace period ago.
+	PurgeDeletedPipelines(gracePeriod time.Duration) error
+
+	// PauseIdlePipelines pauses pipelines whose config has not been updated,
+	// and none of whose jobs have run, for the given duration, as measured by
+	// the database's clock. The pipelines which were paused are returned.
+	PauseIdlePipelines(idleFor time.Duration) ([]Pipeline, error)
 }
 
 type pipelineFactory struct {
@@ -24,8 +35,8 @@ func NewPipelineFactory(conn Conn, lockFactory lock.LockFactory) PipelineFactory
 	}
 }
 
-func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+func (f *pipelineFactory) VisiblePipelines(teamNames []string, filter DashboardFilter) ([]Pipeline, error) {
+	rows, err := filter.filterPipelines(undeletedPipelinesQuery).
 		Where(sq.Eq{"t.name": teamNames}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
@@ -39,7 +50,7 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 		return nil, err
 	}
 
-	rows, err = pipelinesQuery.
+	rows, err = filter.filterPipelines(undeletedPipelinesQuery).
 		Where(sq.NotEq{"t.name": teamNames}).
 		Where(sq.Eq{"public": true}).
 		OrderBy("team_id ASC", "ordering ASC").
@@ -58,7 +69,7 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 }
 
 func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := undeletedPipelinesQuery.
 		OrderBy("ordering").
 		RunWith(f.conn).
 		Query()
@@ -68,3 +79,65 @@ func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 
 	return scanPipelines(f.conn, f.lockFactory, rows)
 }
+
+func (f *pipelineFactory) PurgeDeletedPipelines(gracePeriod time.Duration) error {
+	_, err := psql.Delete("pipelines").
+		Where(sq.Expr("deleted_at < now() - ? * interval '1 second'", int(gracePeriod.Seconds()))).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+func (f *pipelineFactory) PauseIdlePipelines(idleFor time.Duration) ([]Pipeline, error) {
+	idleSeconds := int(idleFor.Seconds())
+
+	rows, err := psql.Update("pipelines p").
+		Set("paused", true).
+		Set("paused_by_inactivity", true).
+		Where(sq.Eq{
+			"p.paused":     false,
+			"p.deleted_at": nil,
+		}).
+		Where(sq.Expr("p.last_updated < now() - ? * interval '1 second'", idleSeconds)).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM builds b
+			WHERE b.pipeline_id = p.id
+			AND (NOT b.completed OR b.start_time >= now() - ? * interval '1 second')
+		)`, idleSeconds)).
+		Suffix("RETURNING p.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	pipelineIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		pipelineIDs = append(pipelineIDs, id)
+	}
+
+	if len(pipelineIDs) == 0 {
+		return []Pipeline{}, nil
+	}
+
+	rows, err = pipelinesQuery.
+		Where(sq.Eq{"p.id": pipelineIDs}).
+		OrderBy("p.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPipelines(f.conn, f.lockFactory, rows)
+}
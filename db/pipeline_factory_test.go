@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -37,6 +39,7 @@ var _ = Describe("Pipeline Factory", func() {
 			Expect(pipeline1.Reload()).To(BeTrue())
 
 			pipeline2, _, err = defaultTeam.SavePipeline("fake-pipeline-two", atc.Config{
+				Labels: map[string]string{"team": "payments", "tier": "critical"},
 				Jobs: atc.JobConfigs{
 					{Name: "job-fake"},
 				},
@@ -55,7 +58,7 @@ var _ = Describe("Pipeline Factory", func() {
 		})
 
 		It("returns all pipelines visible for the given teams", func() {
-			pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team"})
+			pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team"}, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(pipelines)).To(Equal(2))
 			Expect(pipelines[0].Name()).To(Equal(pipeline1.Name()))
@@ -63,25 +66,100 @@ var _ = Describe("Pipeline Factory", func() {
 		})
 
 		It("returns all pipelines visible when empty team name provided", func() {
-			pipelines, err := pipelineFactory.VisiblePipelines([]string{""})
+			pipelines, err := pipelineFactory.VisiblePipelines([]string{""}, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(pipelines)).To(Equal(1))
 			Expect(pipelines[0].Name()).To(Equal(pipeline3.Name()))
 		})
 
 		It("returns all pipelines visible when empty teams provided", func() {
-			pipelines, err := pipelineFactory.VisiblePipelines([]string{})
+			pipelines, err := pipelineFactory.VisiblePipelines([]string{}, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(pipelines)).To(Equal(1))
 			Expect(pipelines[0].Name()).To(Equal(pipeline3.Name()))
 		})
 
 		It("returns all pipelines visible when nil teams provided", func() {
-			pipelines, err := pipelineFactory.VisiblePipelines(nil)
+			pipelines, err := pipelineFactory.VisiblePipelines(nil, db.DashboardFilter{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(pipelines)).To(Equal(1))
 			Expect(pipelines[0].Name()).To(Equal(pipeline3.Name()))
 		})
+
+		Context("with a filter", func() {
+			It("only returns pipelines of the given team", func() {
+				pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team"}, db.DashboardFilter{TeamName: "default-team"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pipelines)).To(Equal(1))
+				Expect(pipelines[0].Name()).To(Equal(pipeline3.Name()))
+			})
+
+			It("only returns pipelines whose name contains the search", func() {
+				pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Search: "PIPELINE-T"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pipelines)).To(Equal(2))
+				Expect(pipelines[0].Name()).To(Equal(pipeline2.Name()))
+				Expect(pipelines[1].Name()).To(Equal(pipeline3.Name()))
+			})
+
+			It("only returns pipelines with all of the given labels", func() {
+				pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Labels: map[string]string{"team": "payments"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pipelines)).To(Equal(1))
+				Expect(pipelines[0].Name()).To(Equal(pipeline2.Name()))
+				Expect(pipelines[0].Labels()).To(Equal(map[string]string{"team": "payments", "tier": "critical"}))
+
+				pipelines, err = pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Labels: map[string]string{"team": "payments", "tier": "low"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pipelines).To(BeEmpty())
+			})
+
+			It("treats wildcards in the search literally", func() {
+				pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Search: "fake_pipeline"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pipelines).To(BeEmpty())
+			})
+
+			Context("when a job's latest build has failed", func() {
+				BeforeEach(func() {
+					job, found, err := pipeline2.Job("job-fake")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					build, err := job.CreateBuild()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(build.Finish(db.BuildStatusFailed)).To(Succeed())
+
+					job, found, err = pipeline3.Job("job-fake-two")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					build, err = job.CreateBuild()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+				})
+
+				It("only returns failing pipelines", func() {
+					pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Status: db.DashboardStatusFailing})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(pipelines)).To(Equal(1))
+					Expect(pipelines[0].Name()).To(Equal(pipeline2.Name()))
+				})
+			})
+
+			Context("when a pipeline is paused", func() {
+				BeforeEach(func() {
+					Expect(pipeline1.Pause()).To(Succeed())
+				})
+
+				It("only returns paused pipelines", func() {
+					pipelines, err := pipelineFactory.VisiblePipelines([]string{"some-team", "default-team"}, db.DashboardFilter{Status: db.DashboardStatusPaused})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(pipelines)).To(Equal(1))
+					Expect(pipelines[0].Name()).To(Equal(pipeline1.Name()))
+				})
+			})
+		})
 	})
 
 	Describe("AllPipelines", func() {
@@ -134,4 +212,142 @@ var _ = Describe("Pipeline Factory", func() {
 			Expect(pipelines[2].Name()).To(Equal(pipeline3.Name()))
 		})
 	})
+
+	Describe("PurgeDeletedPipelines", func() {
+		var deletedPipeline db.Pipeline
+
+		BeforeEach(func() {
+			var err error
+			deletedPipeline, _, err = defaultTeam.SavePipeline("deleted-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = deletedPipeline.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the grace period has not passed", func() {
+			It("keeps the deleted pipeline", func() {
+				err := pipelineFactory.PurgeDeletedPipelines(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := defaultTeam.UndeletePipeline("deleted-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the grace period has passed", func() {
+			It("destroys the deleted pipeline", func() {
+				err := pipelineFactory.PurgeDeletedPipelines(0)
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := defaultTeam.UndeletePipeline("deleted-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("keeps pipelines which are not deleted", func() {
+				err := pipelineFactory.PurgeDeletedPipelines(0)
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := defaultPipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the grace period has passed by the database's clock", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE pipelines SET deleted_at = now() - '2 hours'::interval WHERE id = $1`, deletedPipeline.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("destroys the deleted pipeline", func() {
+				err := pipelineFactory.PurgeDeletedPipelines(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := defaultTeam.UndeletePipeline("deleted-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PauseIdlePipelines", func() {
+		var idlePipeline db.Pipeline
+
+		BeforeEach(func() {
+			var err error
+			idlePipeline, _, err = defaultTeam.SavePipeline("idle-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE pipelines SET last_updated = now() - '2 hours'::interval`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		pausedPipelineNames := func(pipelines []db.Pipeline) []string {
+			names := []string{}
+			for _, pipeline := range pipelines {
+				names = append(names, pipeline.Name())
+			}
+
+			return names
+		}
+
+		Context("when the pipeline has been idle for longer than the given duration", func() {
+			It("pauses the pipeline, marking it as paused by inactivity", func() {
+				pipelines, err := pipelineFactory.PauseIdlePipelines(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pausedPipelineNames(pipelines)).To(ContainElement("idle-pipeline"))
+
+				found, err := idlePipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(idlePipeline.Paused()).To(BeTrue())
+				Expect(idlePipeline.PausedByInactivity()).To(BeTrue())
+			})
+
+			It("no longer marks the pipeline once it is unpaused", func() {
+				_, err := pipelineFactory.PauseIdlePipelines(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = idlePipeline.Unpause()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = idlePipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(idlePipeline.Paused()).To(BeFalse())
+				Expect(idlePipeline.PausedByInactivity()).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline's config was updated within the given duration", func() {
+			It("leaves the pipeline unpaused", func() {
+				pipelines, err := pipelineFactory.PauseIdlePipelines(3 * time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pausedPipelineNames(pipelines)).ToNot(ContainElement("idle-pipeline"))
+
+				_, err = idlePipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(idlePipeline.Paused()).To(BeFalse())
+			})
+		})
+
+		Context("when a job in the pipeline has a build which has not completed", func() {
+			BeforeEach(func() {
+				_, err := defaultJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the pipeline unpaused", func() {
+				pipelines, err := pipelineFactory.PauseIdlePipelines(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pausedPipelineNames(pipelines)).ToNot(ContainElement("default-pipeline"))
+
+				_, err = defaultPipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(defaultPipeline.Paused()).To(BeFalse())
+			})
+		})
+	})
 })
@@ -1791,6 +1791,57 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SoftDelete", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pipeline.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("hides the pipeline", func() {
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = team.Pipeline(pipeline.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			pipelines, err := team.Pipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(BeEmpty())
+		})
+
+		It("pauses the pipeline", func() {
+			paused, err := pipeline.CheckPaused()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paused).To(BeTrue())
+		})
+
+		It("keeps the pipeline's builds", func() {
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			buildPipeline, found, err := build.Pipeline()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(buildPipeline.ID()).To(Equal(pipeline.ID()))
+		})
+
+		It("allows a new pipeline to be saved with the same name", func() {
+			newPipeline, created, err := team.SavePipeline(pipeline.Name(), pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(newPipeline.ID()).ToNot(Equal(pipeline.ID()))
+		})
+	})
+
 	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
 		Context("when a build is created", func() {
 			BeforeEach(func() {
@@ -1811,6 +1862,63 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("GetRunningBuilds/GetNextPendingBuilds", func() {
+		var (
+			otherJob   db.Job
+			firstBuild db.Build
+			nextBuild  db.Build
+		)
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			otherJob, found, err = pipeline.Job("a-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			firstBuild, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			nextBuild, err = otherJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(job.SaveNextInputMapping(nil)).To(Succeed())
+			Expect(otherJob.SaveNextInputMapping(nil)).To(Succeed())
+		})
+
+		It("returns the pending builds across all jobs, oldest first", func() {
+			builds, err := pipeline.GetNextPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(firstBuild.ID()))
+			Expect(builds[1].ID()).To(Equal(nextBuild.ID()))
+
+			runningBuilds, err := pipeline.GetRunningBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runningBuilds).To(BeEmpty())
+		})
+
+		Context("when the oldest build has been scheduled", func() {
+			BeforeEach(func() {
+				scheduled, err := firstBuild.Schedule()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+			})
+
+			It("counts it as running and no longer returns it as pending", func() {
+				runningBuilds, err := pipeline.GetRunningBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(runningBuilds).To(HaveLen(1))
+				Expect(runningBuilds[0].ID()).To(Equal(firstBuild.ID()))
+
+				builds, err := pipeline.GetNextPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(nextBuild.ID()))
+			})
+		})
+	})
+
 	Describe("VersionsDB caching", func() {
 		var otherPipeline db.Pipeline
 		BeforeEach(func() {
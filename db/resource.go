@@ -28,39 +28,68 @@ type Resource interface {
 	CheckError() error
 	Paused() bool
 	WebhookToken() string
+
+	// PinnedVersion is the version pinned in the pipeline config or, if
+	// there isn't one, the version pinned through the API.
 	PinnedVersion() atc.Version
+	ConfigPinnedVersion() atc.Version
+	APIPinnedVersion() atc.Version
+
+	VersionHistoryLimit() int
+	CheckDisabled() bool
+	IgnoreCheckRateLimit() bool
+	Icon() string
+	Description() string
 	FailingToCheck() bool
 
 	SetResourceConfig(int) error
 
+	// PruneVersions deletes all but the given number of most recent versions,
+	// except for those which are pinned, disabled, still referenced by builds,
+	// or yet to be built by a job using every version of the resource,
+	// returning how many were deleted.
+	PruneVersions(int) (int, error)
+
 	Pause() error
 	Unpause() error
 
+	PinVersion(atc.Version) error
+	UnpinVersion() error
+
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, r.nonce, p.name, t.name").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, r.nonce, r.api_pinned_version, p.name, t.name").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
 	Where(sq.Eq{"r.active": true})
 
 type resource struct {
-	id            int
-	name          string
-	pipelineID    int
-	pipelineName  string
-	teamName      string
-	type_         string
-	source        atc.Source
-	checkEvery    string
-	checkTimeout  string
-	lastChecked   time.Time
-	tags          atc.Tags
-	checkError    error
-	paused        bool
-	webhookToken  string
-	pinnedVersion atc.Version
+	id           int
+	name         string
+	pipelineID   int
+	pipelineName string
+	teamName     string
+	type_        string
+	source       atc.Source
+	checkEvery   string
+	checkTimeout string
+	lastChecked  time.Time
+	tags         atc.Tags
+	checkError   error
+	paused       bool
+	webhookToken string
+
+	configPinnedVersion atc.Version
+	apiPinnedVersion    atc.Version
+
+	versionHistoryLimit  int
+	checkDisabled        bool
+	ignoreCheckRateLimit bool
+
+	icon        string
+	description string
 
 	conn Conn
 }
@@ -89,6 +118,11 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 	var configs atc.ResourceConfigs
 
 	for _, r := range resources {
+		var check *bool
+		if r.CheckDisabled() {
+			check = new(bool)
+		}
+
 		configs = append(configs, atc.ResourceConfig{
 			Name:         r.Name(),
 			WebhookToken: r.WebhookToken(),
@@ -96,7 +130,14 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 			Source:       r.Source(),
 			CheckEvery:   r.CheckEvery(),
 			Tags:         r.Tags(),
-			Version:      r.PinnedVersion(),
+			Version:      r.ConfigPinnedVersion(),
+
+			VersionHistoryLimit:  r.VersionHistoryLimit(),
+			Check:                check,
+			IgnoreCheckRateLimit: r.IgnoreCheckRateLimit(),
+
+			Icon:        r.Icon(),
+			Description: r.Description(),
 		})
 	}
 
@@ -125,11 +166,26 @@ func (r *resource) Tags() atc.Tags             { return r.tags }
 func (r *resource) CheckError() error          { return r.checkError }
 func (r *resource) Paused() bool               { return r.paused }
 func (r *resource) WebhookToken() string       { return r.webhookToken }
-func (r *resource) PinnedVersion() atc.Version { return r.pinnedVersion }
+func (r *resource) VersionHistoryLimit() int   { return r.versionHistoryLimit }
+func (r *resource) CheckDisabled() bool        { return r.checkDisabled }
+func (r *resource) IgnoreCheckRateLimit() bool { return r.ignoreCheckRateLimit }
+func (r *resource) Icon() string               { return r.icon }
+func (r *resource) Description() string        { return r.description }
 func (r *resource) FailingToCheck() bool {
 	return r.checkError != nil
 }
 
+func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
+func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
+
+func (r *resource) PinnedVersion() atc.Version {
+	if r.configPinnedVersion != nil {
+		return r.configPinnedVersion
+	}
+
+	return r.apiPinnedVersion
+}
+
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
 		RunWith(r.conn).
@@ -170,6 +226,37 @@ func (r *resource) Unpause() error {
 	return err
 }
 
+// PinVersion pins the resource to the given version until it's unpinned. It
+// has no effect while a version is pinned in the pipeline config.
+func (r *resource) PinVersion(version atc.Version) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resources").
+		Set("api_pinned_version", string(versionJSON)).
+		Where(sq.Eq{
+			"id": r.id,
+		}).
+		RunWith(r.conn).
+		Exec()
+
+	return err
+}
+
+func (r *resource) UnpinVersion() error {
+	_, err := psql.Update("resources").
+		Set("api_pinned_version", nil).
+		Where(sq.Eq{
+			"id": r.id,
+		}).
+		RunWith(r.conn).
+		Exec()
+
+	return err
+}
+
 func (r *resource) SetResourceConfig(resourceConfigID int) error {
 	_, err := psql.Update("resources").
 		Set("resource_config_id", resourceConfigID).
@@ -184,14 +271,146 @@ func (r *resource) SetResourceConfig(resourceConfigID int) error {
 	return err
 }
 
+func (r *resource) PruneVersions(keep int) (int, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	var pinnedVersion *string
+	if r.PinnedVersion() != nil {
+		versionJSON, err := json.Marshal(r.PinnedVersion())
+		if err != nil {
+			return 0, err
+		}
+
+		pinnedVersion = new(string)
+		*pinnedVersion = string(versionJSON)
+	}
+
+	builtUpTo, err := r.builtByEveryJob(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	// disabled versions are kept, as they would come back enabled if they
+	// were checked again
+	result, err := tx.Exec(`
+		DELETE FROM versioned_resources v
+		WHERE v.resource_id = $1
+		AND v.id NOT IN (
+			SELECT id
+			FROM versioned_resources
+			WHERE resource_id = $1
+			ORDER BY check_order DESC
+			LIMIT $2
+		)
+		AND ($3::text IS NULL OR v.version != $3)
+		AND ($4::int IS NULL OR v.check_order <= $4)
+		AND v.enabled
+		AND NOT EXISTS (SELECT 1 FROM build_inputs WHERE versioned_resource_id = v.id)
+		AND NOT EXISTS (SELECT 1 FROM build_outputs WHERE versioned_resource_id = v.id)
+		AND NOT EXISTS (SELECT 1 FROM next_build_inputs WHERE version_id = v.id)
+		AND NOT EXISTS (SELECT 1 FROM independent_build_inputs WHERE version_id = v.id)
+	`, r.id, keep, pinnedVersion, builtUpTo)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if pruned > 0 {
+		err = bumpCacheIndex(tx, r.pipelineID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(pruned), nil
+}
+
+// builtByEveryJob returns the check order up to which each job using every
+// version of the resource has built it; newer versions are still needed by at
+// least one of them. Jobs which have not built the resource yet start from the
+// latest version, so they do not need any older ones.
+func (r *resource) builtByEveryJob(tx Tx) (sql.NullInt64, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"j.pipeline_id": r.pipelineID,
+			"j.active":      true,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	jobs, err := scanJobs(r.conn, nil, rows)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	everyJobIDs := []int{}
+	for _, job := range jobs {
+		config := job.Config()
+		if config.BuildLatestOnly {
+			// every version is resolved to the latest one
+			continue
+		}
+
+		for _, input := range config.Inputs() {
+			if input.Resource == r.name && input.Version != nil && input.Version.Every {
+				everyJobIDs = append(everyJobIDs, job.ID())
+				break
+			}
+		}
+	}
+
+	if len(everyJobIDs) == 0 {
+		return sql.NullInt64{}, nil
+	}
+
+	lastBuilt, args, err := psql.Select("MAX(v.check_order) AS check_order").
+		From("builds b").
+		Join("build_inputs bi ON bi.build_id = b.id").
+		Join("versioned_resources v ON v.id = bi.versioned_resource_id").
+		Where(sq.Eq{
+			"b.job_id":      everyJobIDs,
+			"v.resource_id": r.id,
+		}).
+		GroupBy("b.job_id").
+		ToSql()
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	var builtUpTo sql.NullInt64
+	err = tx.QueryRow(`SELECT MIN(last_built.check_order) FROM (`+lastBuilt+`) AS last_built`, args...).Scan(&builtUpTo)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return builtUpTo, nil
+}
+
 func scanResource(r *resource, row scannable) error {
 	var (
-		configBlob      []byte
-		checkErr, nonce sql.NullString
-		lastChecked     pq.NullTime
+		configBlob, apiPinnedVersion []byte
+		checkErr, nonce              sql.NullString
+		lastChecked                  pq.NullTime
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &nonce, &r.pipelineName, &r.teamName)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &nonce, &apiPinnedVersion, &r.pipelineName, &r.teamName)
 	if err != nil {
 		return err
 	}
@@ -222,11 +441,24 @@ func scanResource(r *resource, row scannable) error {
 	r.checkTimeout = config.CheckTimeout
 	r.tags = config.Tags
 	r.webhookToken = config.WebhookToken
-	r.pinnedVersion = config.Version
+	r.configPinnedVersion = config.Version
+	r.versionHistoryLimit = config.VersionHistoryLimit
+	r.checkDisabled = config.CheckDisabled()
+	r.ignoreCheckRateLimit = config.IgnoreCheckRateLimit
+	r.icon = config.Icon
+	r.description = config.Description
 
 	if checkErr.Valid {
 		r.checkError = errors.New(checkErr.String)
 	}
 
+	r.apiPinnedVersion = nil
+	if apiPinnedVersion != nil {
+		err = json.Unmarshal(apiPinnedVersion, &r.apiPinnedVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
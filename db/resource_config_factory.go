@@ -136,10 +136,12 @@ func constructResourceConfigDescriptor(
 			return ResourceConfigDescriptor{}, err
 		}
 
+		parentTypes := resourceTypes.Without(customType.Name)
+
 		customTypeResourceConfig, err := constructResourceConfigDescriptor(
 			customType.Type,
-			source,
-			resourceTypes.Without(customType.Name),
+			parentTypes.WithBaseResourceTypeDefaults(customType.Type, source),
+			parentTypes,
 		)
 		if err != nil {
 			return ResourceConfigDescriptor{}, err
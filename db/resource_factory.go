@@ -25,6 +25,7 @@ func NewResourceFactory(conn Conn, lockFactory lock.LockFactory) ResourceFactory
 
 func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, error) {
 	rows, err := resourcesQuery.
+		Where(sq.Eq{"p.deleted_at": nil}).
 		Where(
 			sq.Or{
 				sq.Eq{"t.name": teamNames},
@@ -44,6 +44,28 @@ var _ = Describe("Resource Factory", func() {
 			Expect(visibleResources[1].Name()).To(Equal("public-pipeline-resource"))
 		})
 
+		Context("when a public pipeline has been deleted", func() {
+			BeforeEach(func() {
+				otherTeam, found, err := teamFactory.FindTeam("other-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				publicPipeline, found, err := otherTeam.Pipeline("public-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(publicPipeline.SoftDelete()).To(Succeed())
+			})
+
+			It("does not return its resources", func() {
+				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(len(visibleResources)).To(Equal(1))
+				Expect(visibleResources[0].Name()).To(Equal("some-resource"))
+			})
+		})
+
 		It("returns team name and groups for each resource", func() {
 			visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"})
 			Expect(err).ToNot(HaveOccurred())
@@ -27,9 +27,11 @@ var _ = Describe("Resource", func() {
 						Version: atc.Version{"ref": "abcdef"},
 					},
 					{
-						Name:   "some-other-resource",
-						Type:   "git",
-						Source: atc.Source{"some": "other-repository"},
+						Name:        "some-other-resource",
+						Type:        "git",
+						Source:      atc.Source{"some": "other-repository"},
+						Icon:        "github",
+						Description: "some description",
 					},
 					{
 						Name:   "some-secret-resource",
@@ -77,6 +79,8 @@ var _ = Describe("Resource", func() {
 				case "some-other-resource":
 					Expect(r.Type()).To(Equal("git"))
 					Expect(r.Source()).To(Equal(atc.Source{"some": "other-repository"}))
+					Expect(r.Icon()).To(Equal("github"))
+					Expect(r.Description()).To(Equal("some description"))
 				case "some-secret-resource":
 					Expect(r.Type()).To(Equal("git"))
 					Expect(r.Source()).To(Equal(atc.Source{"some": "((secret-repository))"}))
@@ -181,4 +185,248 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PinVersion", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			resource, found, err = pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.PinnedVersion()).To(BeNil())
+
+			err = resource.PinVersion(atc.Version{"ref": "some-ref"})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("pins the resource to the version", func() {
+			Expect(resource.PinnedVersion()).To(Equal(atc.Version{"ref": "some-ref"}))
+			Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"ref": "some-ref"}))
+			Expect(resource.ConfigPinnedVersion()).To(BeNil())
+		})
+
+		It("does not add the version to the resource's config", func() {
+			Expect(db.Resources{resource}.Configs()[0].Version).To(BeNil())
+		})
+
+		Context("when the resource is unpinned", func() {
+			BeforeEach(func() {
+				err := resource.UnpinVersion()
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("is no longer pinned", func() {
+				Expect(resource.PinnedVersion()).To(BeNil())
+				Expect(resource.APIPinnedVersion()).To(BeNil())
+			})
+		})
+
+		Context("when the resource is also pinned in its config", func() {
+			BeforeEach(func() {
+				var found bool
+				var err error
+				resource, found, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = resource.PinVersion(atc.Version{"ref": "some-ref"})
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err = resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("uses the version pinned in the config", func() {
+				Expect(resource.PinnedVersion()).To(Equal(atc.Version{"ref": "abcdef"}))
+				Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"ref": "some-ref"}))
+			})
+		})
+	})
+
+	Describe("PruneVersions", func() {
+		versions := func(resourceName string) []string {
+			savedVersions, _, found, err := pipeline.GetResourceVersions(resourceName, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			refs := []string{}
+			for _, savedVersion := range savedVersions {
+				refs = append(refs, savedVersion.Version["ref"])
+			}
+
+			return refs
+		}
+
+		It("keeps the most recent versions and those used by builds", func() {
+			config := atc.ResourceConfig{Name: "some-other-resource", Type: "git"}
+			err := pipeline.SaveResourceVersions(config, []atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+				{"ref": "v3"},
+				{"ref": "v4"},
+				{"ref": "v5"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := pipeline.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveOutput(db.VersionedResource{
+				Resource: "some-other-resource",
+				Type:     "git",
+				Version:  db.ResourceVersion{"ref": "v1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found, err := pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pruned, err := resource.PruneVersions(2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(Equal(2))
+
+			Expect(versions("some-other-resource")).To(Equal([]string{"v5", "v4", "v1"}))
+		})
+
+		It("keeps the pinned version", func() {
+			config := atc.ResourceConfig{Name: "some-resource", Type: "registry-image"}
+			err := pipeline.SaveResourceVersions(config, []atc.Version{
+				{"ref": "abcdef"},
+				{"ref": "v2"},
+				{"ref": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pruned, err := resource.PruneVersions(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(Equal(1))
+
+			Expect(versions("some-resource")).To(Equal([]string{"v3", "abcdef"}))
+		})
+
+		It("keeps disabled versions", func() {
+			config := atc.ResourceConfig{Name: "some-other-resource", Type: "git"}
+			err := pipeline.SaveResourceVersions(config, []atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+				{"ref": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			savedVersions, _, found, err := pipeline.GetResourceVersions("some-other-resource", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			for _, savedVersion := range savedVersions {
+				if savedVersion.Version["ref"] == "v1" {
+					err = pipeline.DisableVersionedResource(savedVersion.ID)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}
+
+			resource, found, err := pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pruned, err := resource.PruneVersions(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(Equal(1))
+
+			Expect(versions("some-other-resource")).To(Equal([]string{"v3", "v1"}))
+		})
+
+		Context("when a job uses every version of the resource", func() {
+			var job db.Job
+
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = defaultTeam.SavePipeline(
+					"pipeline-with-every-job",
+					atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-resource", Type: "git"},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{Get: "some-resource", Version: &atc.VersionConfig{Every: true}},
+								},
+							},
+						},
+					},
+					0,
+					db.PipelineUnpaused,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				config := atc.ResourceConfig{Name: "some-resource", Type: "git"}
+				err = pipeline.SaveResourceVersions(config, []atc.Version{
+					{"ref": "v1"},
+					{"ref": "v2"},
+					{"ref": "v3"},
+					{"ref": "v4"},
+					{"ref": "v5"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("keeps the versions the job has yet to build", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveInput(db.BuildInput{
+					Name: "some-resource",
+					VersionedResource: db.VersionedResource{
+						Resource: "some-resource",
+						Type:     "git",
+						Version:  db.ResourceVersion{"ref": "v2"},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pruned, err := resource.PruneVersions(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+
+				Expect(versions("some-resource")).To(Equal([]string{"v5", "v4", "v3", "v2"}))
+			})
+
+			It("prunes as usual while the job has not built the resource", func() {
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pruned, err := resource.PruneVersions(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(4))
+
+				Expect(versions("some-resource")).To(Equal([]string{"v5"}))
+			})
+		})
+	})
 })
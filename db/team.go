@@ -19,6 +19,7 @@ import (
 )
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
+var ErrPipelineNameTaken = errors.New("a pipeline with the same name already exists")
 
 //go:generate counterfeiter . Team
 
@@ -27,8 +28,17 @@ type Team interface {
 	Name() string
 	Admin() bool
 
+	// AllowPrivileged is true if the team may run privileged tasks and
+	// privileged resource types. The admin team is always allowed.
+	AllowPrivileged() bool
+
 	Auth() map[string][]string
 
+	// ContainerRetention overrides the default retention of the containers of
+	// the team's finished builds.
+	ContainerRetention() ContainerRetention
+
+	Reload() (bool, error)
 	Delete() error
 	Rename(string) error
 
@@ -45,9 +55,14 @@ type Team interface {
 	VisiblePipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
 
+	// UndeletePipeline restores the most recently soft-deleted pipeline with
+	// the given name, paused only if it was paused before it was deleted.
+	UndeletePipeline(pipelineName string) (bool, error)
+
 	CreateOneOffBuild() (Build, error)
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	AbortBuilds(filter AbortBuildsFilter, reason string, abortedBy string) ([]Build, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -65,6 +80,15 @@ type Team interface {
 	CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 
 	UpdateProviderAuth(auth map[string][]string) error
+	UpdateAllowPrivileged(allowPrivileged bool) error
+	UpdateContainerRetention(retention ContainerRetention) error
+
+	Webhooks() ([]Webhook, error)
+	CreateWebhook(url string, secret string, events []string) (Webhook, error)
+	DeleteWebhook(id int) (bool, error)
+
+	// Usage returns the team's daily usage from the given day on.
+	Usage(since time.Time) ([]TeamUsage, error)
 }
 
 type team struct {
@@ -72,18 +96,42 @@ type team struct {
 	conn        Conn
 	lockFactory lock.LockFactory
 
-	name  string
-	admin bool
+	name            string
+	admin           bool
+	allowPrivileged bool
 
 	auth map[string][]string
+
+	containerRetention ContainerRetention
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
+func (t *team) AllowPrivileged() bool { return t.admin || t.allowPrivileged }
+
 func (t *team) Auth() map[string][]string { return t.auth }
 
+func (t *team) ContainerRetention() ContainerRetention { return t.containerRetention }
+
+func (t *team) Reload() (bool, error) {
+	err := t.queryTeam(`
+		SELECT id, name, admin, allow_privileged, auth, nonce, container_retention_success, container_retention_failure
+		FROM teams
+		WHERE id = $1
+	`, []interface{}{t.id})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -159,7 +207,7 @@ func (t *team) FindContainerOnWorker(workerName string, owner ContainerOwner) (C
 }
 
 func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
-	handle, err := uuid.NewV4()
+	handle, err := containerHandle(owner, workerName)
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +227,7 @@ func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta Con
 
 	insMap := meta.SQLMap()
 	insMap["worker_name"] = workerName
-	insMap["handle"] = handle.String()
+	insMap["handle"] = handle
 	insMap["team_id"] = t.id
 
 	ownerCols, err := owner.Create(tx, workerName)
@@ -191,12 +239,20 @@ func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta Con
 		insMap[k] = v
 	}
 
-	err = psql.Insert("containers").
-		SetMap(insMap).
-		Suffix("RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
-		RunWith(tx).
-		QueryRow().
-		Scan(cols...)
+	err = insertContainer(tx, insMap, cols)
+	if err == sql.ErrNoRows {
+		// the owner's deterministic handle is still held by an earlier
+		// container which failed or is being destroyed; give this one a random
+		// handle rather than reusing a row which may still be torn down
+		handle, err = randomContainerHandle()
+		if err != nil {
+			return nil, err
+		}
+
+		insMap["handle"] = handle
+
+		err = insertContainer(tx, insMap, cols)
+	}
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
 			return nil, ErrBuildDisappeared
@@ -212,13 +268,39 @@ func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta Con
 
 	return newCreatingContainer(
 		containerID,
-		handle.String(),
+		handle,
 		workerName,
 		*metadata,
 		t.conn,
 	), nil
 }
 
+func insertContainer(tx Tx, insMap map[string]interface{}, cols []interface{}) error {
+	return psql.Insert("containers").
+		SetMap(insMap).
+		Suffix("ON CONFLICT (handle) DO NOTHING RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
+		RunWith(tx).
+		QueryRow().
+		Scan(cols...)
+}
+
+func containerHandle(owner ContainerOwner, workerName string) (string, error) {
+	if deterministic, ok := owner.(deterministicContainerOwner); ok {
+		return deterministic.containerHandle(workerName)
+	}
+
+	return randomContainerHandle()
+}
+
+func randomContainerHandle() (string, error) {
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return handle.String(), nil
+}
+
 func (t *team) FindContainerByHandle(
 	handle string,
 ) (Container, bool, error) {
@@ -299,19 +381,21 @@ func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, res
 
 	variables := variablesFactory.NewVariables(t.name, pipeline.Name())
 
-	versionedResourceTypes := pipelineResourceTypes.Deserialize()
+	versionedResourceTypes := creds.NewVersionedResourceTypes(variables, pipelineResourceTypes.Deserialize())
 
 	source, err := creds.NewSource(variables, resource.Source()).Evaluate()
 	if err != nil {
 		return nil, err
 	}
 
+	source = versionedResourceTypes.WithBaseResourceTypeDefaults(resource.Type(), source)
+
 	resourceConfigFactory := NewResourceConfigFactory(t.conn, t.lockFactory)
 	resourceConfig, found, err := resourceConfigFactory.FindResourceConfig(
 		logger,
 		resource.Type(),
 		source,
-		creds.NewVersionedResourceTypes(variables, versionedResourceTypes),
+		versionedResourceTypes,
 	)
 	if err != nil {
 		return nil, err
@@ -384,6 +468,22 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	var networkPayload *string
+	if config.Network != nil {
+		payload, err := json.Marshal(config.Network)
+		if err != nil {
+			return nil, false, err
+		}
+
+		networkPayload = new(string)
+		*networkPayload = string(payload)
+	}
+
+	labelsPayload, err := labelsJSON(config.Labels)
+	if err != nil {
+		return nil, false, err
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -406,6 +506,7 @@ func (t *team) SavePipeline(
 		FROM pipelines
 		WHERE name = $1
 	  AND team_id = $2
+	  AND deleted_at IS NULL
 	`, pipelineName, t.id).Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
@@ -419,12 +520,15 @@ func (t *team) SavePipeline(
 
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
+				"name":          pipelineName,
+				"groups":        groupsPayload,
+				"network":       networkPayload,
+				"labels":        labelsPayload,
+				"max_in_flight": config.MaxInFlight,
+				"version":       sq.Expr("nextval('config_version_seq')"),
+				"ordering":      sq.Expr("currval('pipelines_id_seq')"),
+				"paused":        pausedState.Bool(),
+				"team_id":       t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -437,16 +541,23 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("network", networkPayload).
+			Set("labels", labelsPayload).
+			Set("max_in_flight", config.MaxInFlight).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
+			Set("last_updated", sq.Expr("now()")).
 			Where(sq.Eq{
-				"name":    pipelineName,
-				"version": from,
-				"team_id": t.id,
+				"name":       pipelineName,
+				"version":    from,
+				"team_id":    t.id,
+				"deleted_at": nil,
 			}).
 			Suffix("RETURNING id")
 
 		if pausedState != PipelineNoChange {
-			update = update.Set("paused", pausedState.Bool())
+			update = update.
+				Set("paused", pausedState.Bool()).
+				Set("paused_by_inactivity", false)
 		}
 
 		err = update.RunWith(tx).QueryRow().Scan(&pipelineID)
@@ -526,6 +637,14 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	err = releaseResourceConfigs(tx, sq.Eq{
+		"pipeline_id": pipelineID,
+		"active":      false,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
 	if err != nil {
 		return nil, false, err
@@ -557,7 +676,7 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 
 	err := scanPipeline(
 		pipeline,
-		pipelinesQuery.
+		undeletedPipelinesQuery.
 			Where(sq.Eq{
 				"p.team_id": t.id,
 				"p.name":    pipelineName,
@@ -576,7 +695,7 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 }
 
 func (t *team) Pipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := undeletedPipelinesQuery.
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
@@ -596,7 +715,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 }
 
 func (t *team) PublicPipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := undeletedPipelinesQuery.
 		Where(sq.Eq{
 			"team_id": t.id,
 			"public":  true,
@@ -617,7 +736,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 }
 
 func (t *team) VisiblePipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := undeletedPipelinesQuery.
 		Where(sq.Eq{"team_id": t.id}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(t.conn).
@@ -631,7 +750,7 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 		return nil, err
 	}
 
-	rows, err = pipelinesQuery.
+	rows, err = undeletedPipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
 		Where(sq.Eq{"public": true}).
 		OrderBy("team_id ASC", "ordering ASC").
@@ -661,8 +780,9 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 		pipelineUpdate, err := psql.Update("pipelines").
 			Set("ordering", i).
 			Where(sq.Eq{
-				"name":    name,
-				"team_id": t.id,
+				"name":       name,
+				"team_id":    t.id,
+				"deleted_at": nil,
 			}).
 			RunWith(tx).
 			Exec()
@@ -681,6 +801,36 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 	return tx.Commit()
 }
 
+func (t *team) UndeletePipeline(pipelineName string) (bool, error) {
+	result, err := t.conn.Exec(`
+		UPDATE pipelines
+		SET deleted_at = NULL, paused = paused_before_deletion
+		WHERE id = (
+			SELECT id
+			FROM pipelines
+			WHERE name = $1
+			AND team_id = $2
+			AND deleted_at IS NOT NULL
+			ORDER BY deleted_at DESC
+			LIMIT 1
+		)
+	`, pipelineName, t.id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return false, ErrPipelineNameTaken
+		}
+
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
 func (t *team) CreateOneOffBuild() (Build, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -749,18 +899,45 @@ func (t *team) UpdateProviderAuth(auth map[string][]string) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, allow_privileged, auth, nonce, container_retention_success, container_retention_failure
 	`
 	params := []interface{}{jsonEncodedProviderAuth, t.id}
 	return t.queryTeam(query, params)
 }
 
+func (t *team) UpdateAllowPrivileged(allowPrivileged bool) error {
+	query := `
+		UPDATE teams
+		SET allow_privileged = $1
+		WHERE id = $2
+		RETURNING id, name, admin, allow_privileged, auth, nonce, container_retention_success, container_retention_failure
+	`
+	params := []interface{}{allowPrivileged, t.id}
+	return t.queryTeam(query, params)
+}
+
+func (t *team) UpdateContainerRetention(retention ContainerRetention) error {
+	query := `
+		UPDATE teams
+		SET container_retention_success = $1, container_retention_failure = $2
+		WHERE id = $3
+		RETURNING id, name, admin, allow_privileged, auth, nonce, container_retention_success, container_retention_failure
+	`
+	params := []interface{}{retentionSeconds(retention.Success), retentionSeconds(retention.Failure), t.id}
+	return t.queryTeam(query, params)
+}
+
 func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) error {
 	configPayload, err := json.Marshal(job)
 	if err != nil {
 		return err
 	}
 
+	labelsPayload, err := labelsJSON(job.Labels)
+	if err != nil {
+		return err
+	}
+
 	es := t.conn.EncryptionStrategy()
 	encryptedPayload, nonce, err := es.Encrypt(configPayload)
 	if err != nil {
@@ -769,9 +946,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6
+		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6, labels = $7, notify_on_transition = $8
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", labelsPayload, job.NotifiesOnTransitionOnly())
 	if err != nil {
 		return err
 	}
@@ -781,9 +958,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags)
-		VALUES ($1, $2, $3, $4, true, $5, $6)
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags, labels, notify_on_transition)
+		VALUES ($1, $2, $3, $4, true, $5, $6, $7, $8)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", labelsPayload, job.NotifiesOnTransitionOnly())
 
 	return swallowUniqueViolation(err)
 }
@@ -816,6 +993,11 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 		return err
 	}
 
+	reconfigured, err := t.resourceReconfigured(tx, resource, pipelineID)
+	if err != nil {
+		return err
+	}
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resources
 		SET config = $3, active = true, nonce = $4
@@ -826,6 +1008,13 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	}
 
 	if updated {
+		if reconfigured {
+			return releaseResourceConfigs(tx, sq.Eq{
+				"name":        resource.Name,
+				"pipeline_id": pipelineID,
+			})
+		}
+
 		return nil
 	}
 
@@ -837,6 +1026,134 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	return swallowUniqueViolation(err)
 }
 
+// resourceReconfigured returns whether the type or source of an existing
+// resource differ from the config about to be saved. Versions found with the
+// old source are kept, but the caches fetched with it are no longer needed.
+func (t *team) resourceReconfigured(tx Tx, resource atc.ResourceConfig, pipelineID int) (bool, error) {
+	var (
+		configBlob []byte
+		nonce      sql.NullString
+	)
+
+	err := tx.QueryRow(`
+		SELECT config, nonce
+		FROM resources
+		WHERE name = $1 AND pipeline_id = $2
+	`, resource.Name, pipelineID).Scan(&configBlob, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := t.conn.EncryptionStrategy().Decrypt(string(configBlob), noncense)
+	if err != nil {
+		return false, err
+	}
+
+	var oldConfig atc.ResourceConfig
+	err = json.Unmarshal(decryptedConfig, &oldConfig)
+	if err != nil {
+		return false, err
+	}
+
+	if oldConfig.Type != resource.Type {
+		return true, nil
+	}
+
+	// compare the sources as JSON, as numbers in the old source will have
+	// been unmarshaled differently than those in the new one
+	oldSource, err := json.Marshal(oldConfig.Source)
+	if err != nil {
+		return false, err
+	}
+
+	newSource, err := json.Marshal(resource.Source)
+	if err != nil {
+		return false, err
+	}
+
+	return string(oldSource) != string(newSource), nil
+}
+
+// releaseResourceConfigs detaches the matching resources from the config they
+// were last checked with and removes their uses of caches, so that caches and
+// configs which nothing else refers to are collected on the next GC pass
+// rather than lingering until their check sessions expire.
+func releaseResourceConfigs(tx Tx, resources sq.Sqlizer) error {
+	rows, err := psql.Select("DISTINCT resource_config_id").
+		From("resources").
+		Where(resources).
+		Where(sq.NotEq{"resource_config_id": nil}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	var configIDs []int
+	for rows.Next() {
+		var configID int
+		err = rows.Scan(&configID)
+		if err != nil {
+			return err
+		}
+
+		configIDs = append(configIDs, configID)
+	}
+
+	if len(configIDs) == 0 {
+		return nil
+	}
+
+	resourceIDs, args, err := psql.Select("id").
+		From("resources").
+		Where(resources).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("resource_cache_uses").
+		Where("resource_id IN ("+resourceIDs+")", args...).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resources").
+		Set("resource_config_id", nil).
+		Where(resources).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("resource_config_check_sessions rccs").
+		Where(sq.Eq{"rccs.resource_config_id": configIDs}).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1 FROM resources r WHERE r.resource_config_id = rccs.resource_config_id
+		)`)).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1 FROM resource_types rt WHERE rt.resource_config_id = rccs.resource_config_id
+		)`)).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
 func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) error {
 	configPayload, err := json.Marshal(resourceType)
 	if err != nil {
@@ -926,8 +1243,8 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, network, labels sql.NullString
+	err := scan.Scan(&p.id, &p.name, &groups, &network, &labels, &p.maxInFlight, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.pausedByInactivity, &p.public)
 	if err != nil {
 		return err
 	}
@@ -942,6 +1259,30 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.groups = pipelineGroups
 	}
 
+	p.network = nil
+	if network.Valid {
+		var pipelineNetwork atc.NetworkConfig
+		err = json.Unmarshal([]byte(network.String), &pipelineNetwork)
+		if err != nil {
+			return err
+		}
+
+		p.network = &pipelineNetwork
+	}
+
+	p.labels = nil
+	if labels.Valid {
+		var pipelineLabels map[string]string
+		err = json.Unmarshal([]byte(labels.String), &pipelineLabels)
+		if err != nil {
+			return err
+		}
+
+		if len(pipelineLabels) > 0 {
+			p.labels = pipelineLabels
+		}
+	}
+
 	return nil
 }
 
@@ -966,6 +1307,7 @@ func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]P
 
 func (t *team) queryTeam(query string, params []interface{}) error {
 	var providerAuth, nonce sql.NullString
+	var retentionSuccess, retentionFailure sql.NullInt64
 
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -977,8 +1319,11 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&t.id,
 		&t.name,
 		&t.admin,
+		&t.allowPrivileged,
 		&providerAuth,
 		&nonce,
+		&retentionSuccess,
+		&retentionFailure,
 	)
 	if err != nil {
 		return err
@@ -995,5 +1340,7 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		}
 	}
 
+	t.containerRetention = scannedRetention(retentionSuccess, retentionFailure)
+
 	return nil
 }
@@ -50,10 +50,12 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	allowPrivileged := t.AllowPrivileged != nil && *t.AllowPrivileged
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, allow_privileged").
+		Values(t.Name, auth, admin, allowPrivileged).
+		Suffix("RETURNING id, name, admin, allow_privileged, auth, container_retention_success, container_retention_failure").
 		RunWith(tx).
 		QueryRow()
 
@@ -89,7 +91,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, allow_privileged, auth, container_retention_success, container_retention_failure").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -108,7 +110,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, allow_privileged, auth, container_retention_success, container_retention_failure").
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -167,14 +169,20 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 	var providerAuth sql.NullString
+	var retentionSuccess, retentionFailure sql.NullInt64
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
+		&t.allowPrivileged,
 		&providerAuth,
+		&retentionSuccess,
+		&retentionFailure,
 	)
 
+	t.containerRetention = scannedRetention(retentionSuccess, retentionFailure)
+
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
 		if err != nil {
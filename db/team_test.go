@@ -757,6 +757,76 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("AllowPrivileged", func() {
+		It("is not allowed by default", func() {
+			Expect(team.AllowPrivileged()).To(BeFalse())
+		})
+
+		It("is always allowed for admin teams", func() {
+			adminTeam, err := teamFactory.CreateDefaultTeamIfNotExists()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(adminTeam.AllowPrivileged()).To(BeTrue())
+		})
+
+		Describe("UpdateAllowPrivileged", func() {
+			It("updates the team", func() {
+				err := team.UpdateAllowPrivileged(true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.AllowPrivileged()).To(BeTrue())
+
+				reloadedTeam := teamFactory.GetByID(team.ID())
+				found, err := reloadedTeam.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedTeam.Name()).To(Equal(team.Name()))
+				Expect(reloadedTeam.AllowPrivileged()).To(BeTrue())
+			})
+		})
+
+		Describe("UpdateContainerRetention", func() {
+			It("updates the team", func() {
+				retention := db.ContainerRetention{
+					Success: time.Hour,
+					Failure: 2 * time.Hour,
+				}
+
+				err := team.UpdateContainerRetention(retention)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.ContainerRetention()).To(Equal(retention))
+
+				foundTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundTeam.ContainerRetention()).To(Equal(retention))
+			})
+
+			It("can reset the team to the defaults", func() {
+				err := team.UpdateContainerRetention(db.ContainerRetention{Success: time.Hour})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateContainerRetention(db.ContainerRetention{})
+				Expect(err).ToNot(HaveOccurred())
+
+				reloadedTeam := teamFactory.GetByID(team.ID())
+				found, err := reloadedTeam.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedTeam.ContainerRetention()).To(Equal(db.ContainerRetention{}))
+			})
+		})
+	})
+
+	Describe("Reload", func() {
+		It("returns false when the team no longer exists", func() {
+			err := team.Delete()
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := team.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -957,6 +1027,105 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("UndeletePipeline", func() {
+		var pipeline db.Pipeline
+
+		BeforeEach(func() {
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pipeline.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("restores the pipeline, unpaused as it was before it was deleted", func() {
+			found, err := team.UndeletePipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			restoredPipeline, found, err := team.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(restoredPipeline.ID()).To(Equal(pipeline.ID()))
+			Expect(restoredPipeline.Paused()).To(BeFalse())
+		})
+
+		Context("when the pipeline was paused before it was deleted", func() {
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline("paused-pipeline", atc.Config{}, 0, db.PipelinePaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.SoftDelete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("restores the pipeline, paused", func() {
+				found, err := team.UndeletePipeline("paused-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				restoredPipeline, found, err := team.Pipeline("paused-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(restoredPipeline.Paused()).To(BeTrue())
+			})
+		})
+
+		Context("when the pipeline was deleted more than once", func() {
+			var newerPipeline db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				newerPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = newerPipeline.SoftDelete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("restores the most recently deleted one", func() {
+				found, err := team.UndeletePipeline("some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				restoredPipeline, found, err := team.Pipeline("some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(restoredPipeline.ID()).To(Equal(newerPipeline.ID()))
+			})
+		})
+
+		Context("when another pipeline has taken its name", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns ErrPipelineNameTaken", func() {
+				_, err := team.UndeletePipeline("some-pipeline")
+				Expect(err).To(Equal(db.ErrPipelineNameTaken))
+			})
+		})
+
+		Context("when no pipeline was deleted with the name", func() {
+			It("returns false", func() {
+				found, err := team.UndeletePipeline("bogus-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline was deleted by another team", func() {
+			It("returns false", func() {
+				found, err := otherTeam.UndeletePipeline("some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("CreateOneOffBuild", func() {
 		var (
 			oneOffBuild db.Build
@@ -1613,6 +1782,141 @@ var _ = Describe("Team", func() {
 			Expect(found).To(BeFalse())
 		})
 
+		Context("when a resource has been checked", func() {
+			var (
+				pipeline                   db.Pipeline
+				resource                   db.Resource
+				resourceConfigCheckSession db.ResourceConfigCheckSession
+			)
+
+			resourceConfigID := func() sql.NullInt64 {
+				var id sql.NullInt64
+				err := psql.Select("resource_config_id").
+					From("resources").
+					Where(sq.Eq{"id": resource.ID()}).
+					RunWith(dbConn).
+					QueryRow().
+					Scan(&id)
+				Expect(err).ToNot(HaveOccurred())
+				return id
+			}
+
+			checkSessionExists := func() bool {
+				var exists bool
+				err := dbConn.QueryRow(`
+					SELECT EXISTS (SELECT 1 FROM resource_config_check_sessions WHERE id = $1)
+				`, resourceConfigCheckSession.ID()).Scan(&exists)
+				Expect(err).ToNot(HaveOccurred())
+				return exists
+			}
+
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				resource, found, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				resourceConfigCheckSession, err = resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+					logger,
+					"some-base-resource-type",
+					atc.Source{"source-config": "some-value"},
+					creds.VersionedResourceTypes{},
+					db.ContainerOwnerExpiries{
+						GraceTime: 2 * time.Minute,
+						Min:       5 * time.Minute,
+						Max:       1 * time.Hour,
+					},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps its config when the resource is unchanged", func() {
+				_, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceConfigID().Valid).To(BeTrue())
+				Expect(checkSessionExists()).To(BeTrue())
+			})
+
+			It("releases its config when the source changes", func() {
+				config.Resources[0].Source = atc.Source{"source-config": "some-other-value"}
+
+				_, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceConfigID().Valid).To(BeFalse())
+				Expect(checkSessionExists()).To(BeFalse())
+			})
+
+			It("releases its config when the resource is removed", func() {
+				config.Resources = atc.ResourceConfigs{}
+
+				_, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceConfigID().Valid).To(BeFalse())
+				Expect(checkSessionExists()).To(BeFalse())
+			})
+
+			It("keeps the check session while another resource uses the config", func() {
+				otherPipeline, _, err := team.SavePipeline("other-pipeline", config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				otherResource, found, err := otherPipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Resources = atc.ResourceConfigs{}
+
+				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceConfigID().Valid).To(BeFalse())
+				Expect(checkSessionExists()).To(BeTrue())
+			})
+		})
+
+		It("saves the pipeline's network configuration", func() {
+			config.Network = &atc.NetworkConfig{
+				Egress:       atc.NetworkEgressNone,
+				AllowedCIDRs: []string{"10.0.0.0/8"},
+			}
+
+			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.Network()).To(Equal(config.Network))
+
+			config.Network = nil
+
+			savedPipeline, _, err = team.SavePipeline(pipelineName, config, savedPipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.Network()).To(BeNil())
+		})
+
+		It("saves the pipeline's max in flight", func() {
+			config.MaxInFlight = 3
+
+			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.MaxInFlight()).To(Equal(3))
+
+			config.MaxInFlight = 0
+
+			savedPipeline, _, err = team.SavePipeline(pipelineName, config, savedPipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.MaxInFlight()).To(BeZero())
+		})
+
 		It("creates all of the serial groups from the jobs in the database", func() {
 			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
@@ -1988,6 +2292,79 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("CreateContainer handles", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("derives the handle of build step containers from the build, plan, and worker", func() {
+			owner := db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"))
+
+			container, err := defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherContainer, err := defaultTeam.CreateContainer(otherWorker.Name(), owner, db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherContainer.Handle()).ToNot(Equal(container.Handle()))
+
+			failedContainer, err := container.Failed()
+			Expect(err).ToNot(HaveOccurred())
+
+			destroyed, err := failedContainer.Destroy()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(destroyed).To(BeTrue())
+
+			recreatedContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recreatedContainer.Handle()).To(Equal(container.Handle()))
+		})
+
+		Context("when an earlier container for the same owner has not been removed yet", func() {
+			var (
+				owner     db.ContainerOwner
+				container db.CreatingContainer
+			)
+
+			BeforeEach(func() {
+				owner = db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"))
+
+				var err error
+				container, err = defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("gives a new container a random handle when the earlier one failed", func() {
+				_, err := container.Failed()
+				Expect(err).ToNot(HaveOccurred())
+
+				recreatedContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recreatedContainer.Handle()).ToNot(Equal(container.Handle()))
+
+				foundContainer, found, err := defaultTeam.FindContainerByHandle(recreatedContainer.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundContainer.Handle()).To(Equal(recreatedContainer.Handle()))
+			})
+
+			It("gives a new container a random handle when the earlier one is being destroyed", func() {
+				createdContainer, err := container.Created()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = createdContainer.Destroying()
+				Expect(err).ToNot(HaveOccurred())
+
+				recreatedContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recreatedContainer.Handle()).ToNot(Equal(container.Handle()))
+			})
+		})
+	})
+
 	Describe("FindContainerOnWorker/CreateContainer", func() {
 		var (
 			containerMetadata db.ContainerMetadata
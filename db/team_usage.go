@@ -0,0 +1,157 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TeamUsage is how much of the workers' time a team used on a day, in UTC:
+// the time spent running its builds, and the time its containers were up.
+type TeamUsage struct {
+	Day           time.Time
+	BuildTime     time.Duration
+	ContainerTime time.Duration
+}
+
+func (t *team) Usage(since time.Time) ([]TeamUsage, error) {
+	rows, err := psql.Select("day", "build_seconds", "container_seconds").
+		From("team_usage").
+		Where(sq.And{
+			sq.Eq{"team_id": t.id},
+			sq.GtOrEq{"day": since.UTC().Format("2006-01-02")},
+		}).
+		OrderBy("day ASC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	usage := []TeamUsage{}
+	for rows.Next() {
+		var (
+			day              time.Time
+			buildSeconds     int64
+			containerSeconds int64
+		)
+
+		err = rows.Scan(&day, &buildSeconds, &containerSeconds)
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, TeamUsage{
+			Day:           day,
+			BuildTime:     time.Duration(buildSeconds) * time.Second,
+			ContainerTime: time.Duration(containerSeconds) * time.Second,
+		})
+	}
+
+	return usage, nil
+}
+
+//go:generate counterfeiter . TeamUsageRecorder
+
+type TeamUsageRecorder interface {
+	// Record adds the time elapsed since usage was last recorded to each
+	// team's usage for the day, once for each of its running builds and
+	// created containers. The elapsed time is capped at maxElapsed, so that
+	// time during which no ATC was recording is not counted against teams.
+	Record(maxElapsed time.Duration) error
+}
+
+type teamUsageRecorder struct {
+	conn Conn
+}
+
+func NewTeamUsageRecorder(conn Conn) TeamUsageRecorder {
+	return &teamUsageRecorder{
+		conn: conn,
+	}
+}
+
+func (r *teamUsageRecorder) Record(maxElapsed time.Duration) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	// usage is counted from the first recording on
+	_, err = tx.Exec(`
+		INSERT INTO team_usage_recorded (id) VALUES (1)
+		ON CONFLICT (id) DO NOTHING
+	`)
+	if err != nil {
+		return err
+	}
+
+	// locking the row serializes ATCs recording at the same time, so that the
+	// same period is never counted twice
+	var elapsed float64
+	err = tx.QueryRow(`
+		SELECT EXTRACT(EPOCH FROM now() - recorded_at)
+		FROM team_usage_recorded
+		FOR UPDATE
+	`).Scan(&elapsed)
+	if err != nil {
+		return err
+	}
+
+	seconds := int64(elapsed)
+	if max := int64(maxElapsed.Seconds()); seconds > max {
+		seconds = max
+	}
+
+	if seconds <= 0 {
+		return tx.Commit()
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO team_usage (team_id, day, build_seconds)
+		SELECT team_id, (now() AT TIME ZONE 'UTC')::date, count(*) * $1
+		FROM builds
+		WHERE status = $2
+		AND team_id IS NOT NULL
+		GROUP BY team_id
+		ON CONFLICT (team_id, day) DO UPDATE SET
+			build_seconds = team_usage.build_seconds + EXCLUDED.build_seconds
+	`, seconds, string(BuildStatusStarted))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO team_usage (team_id, day, container_seconds)
+		SELECT team_id, (now() AT TIME ZONE 'UTC')::date, count(*) * $1
+		FROM containers
+		WHERE state = $2
+		AND team_id IS NOT NULL
+		GROUP BY team_id
+		ON CONFLICT (team_id, day) DO UPDATE SET
+			container_seconds = team_usage.container_seconds + EXCLUDED.container_seconds
+	`, seconds, ContainerStateCreated)
+	if err != nil {
+		return err
+	}
+
+	// only whole seconds are counted, so the remainder is left for the next
+	// recording, unless the elapsed time was capped
+	if int64(elapsed) > seconds {
+		_, err = tx.Exec(`UPDATE team_usage_recorded SET recorded_at = now()`)
+	} else {
+		_, err = tx.Exec(`
+			UPDATE team_usage_recorded
+			SET recorded_at = recorded_at + $1 * interval '1 second'
+		`, seconds)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
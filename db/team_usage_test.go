@@ -0,0 +1,144 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamUsageRecorder", func() {
+	var (
+		recorder  db.TeamUsageRecorder
+		otherTeam db.Team
+		today     time.Time
+	)
+
+	BeforeEach(func() {
+		recorder = db.NewTeamUsageRecorder(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		startedBuild, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		started, err := startedBuild.Start("some-engine", "{}", atc.Plan{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		_, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err := defaultTeam.CreateContainer(
+			defaultWorker.Name(),
+			db.NewBuildStepContainerOwner(startedBuild.ID(), atc.PlanID("some-plan")),
+			db.ContainerMetadata{Type: "task", StepName: "some-task"},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = creatingContainer.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = defaultTeam.CreateContainer(
+			defaultWorker.Name(),
+			db.NewBuildStepContainerOwner(startedBuild.ID(), atc.PlanID("some-other-plan")),
+			db.ContainerMetadata{Type: "task", StepName: "some-other-task"},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		now := time.Now().UTC()
+		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	})
+
+	recordedAgo := func(ago string) {
+		_, err := dbConn.Exec(`
+			INSERT INTO team_usage_recorded (recorded_at) VALUES (now() - $1::interval)
+			ON CONFLICT (id) DO UPDATE SET recorded_at = EXCLUDED.recorded_at
+		`, ago)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	usageOn := func(team db.Team) []db.TeamUsage {
+		usage, err := team.Usage(today)
+		Expect(err).ToNot(HaveOccurred())
+
+		for i := range usage {
+			usage[i].Day = usage[i].Day.UTC()
+		}
+
+		return usage
+	}
+
+	It("adds the time since it last recorded for each running build and created container", func() {
+		recordedAgo("90 seconds")
+
+		err := recorder.Record(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(usageOn(defaultTeam)).To(Equal([]db.TeamUsage{{
+			Day:           today,
+			BuildTime:     90 * time.Second,
+			ContainerTime: 90 * time.Second,
+		}}))
+
+		Expect(usageOn(otherTeam)).To(BeEmpty())
+	})
+
+	It("accumulates usage over recordings", func() {
+		recordedAgo("90 seconds")
+
+		err := recorder.Record(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		recordedAgo("30 seconds")
+
+		err = recorder.Record(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(usageOn(defaultTeam)).To(Equal([]db.TeamUsage{{
+			Day:           today,
+			BuildTime:     2 * time.Minute,
+			ContainerTime: 2 * time.Minute,
+		}}))
+	})
+
+	It("does not count more than the max elapsed time", func() {
+		recordedAgo("2 hours")
+
+		err := recorder.Record(time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(usageOn(defaultTeam)).To(Equal([]db.TeamUsage{{
+			Day:           today,
+			BuildTime:     time.Minute,
+			ContainerTime: time.Minute,
+		}}))
+
+		err = recorder.Record(time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(usageOn(defaultTeam)[0].BuildTime).To(Equal(time.Minute))
+	})
+
+	It("does not count anything on its first recording", func() {
+		err := recorder.Record(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(usageOn(defaultTeam)).To(BeEmpty())
+	})
+
+	It("does not show usage from before the given day", func() {
+		recordedAgo("90 seconds")
+
+		err := recorder.Record(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		usage, err := defaultTeam.Usage(today.AddDate(0, 0, 1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usage).To(BeEmpty())
+	})
+})
@@ -53,6 +53,7 @@ const (
 	VolumeTypeResourceType  VolumeType = "resource-type"
 	VolumeTypeResourceCerts VolumeType = "resource-certs"
 	VolumeTypeTaskCache     VolumeType = "task-cache"
+	VolumeTypeInputUpload   VolumeType = "input-upload"
 	VolumeTypeUknown        VolumeType = "unknown" // for migration to life
 )
 
@@ -141,6 +142,7 @@ func (volume *creatingVolume) Failed() (FailedVolume, error) {
 //go:generate counterfeiter . CreatedVolume
 
 type CreatedVolume interface {
+	ID() int
 	Handle() string
 	Path() string
 	Type() VolumeType
@@ -179,6 +181,7 @@ type VolumeResourceType struct {
 	Version                atc.Version
 }
 
+func (volume *createdVolume) ID() int                 { return volume.id }
 func (volume *createdVolume) Handle() string          { return volume.handle }
 func (volume *createdVolume) Path() string            { return volume.path }
 func (volume *createdVolume) WorkerName() string      { return volume.workerName }
@@ -2,9 +2,11 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
 	"github.com/nu7hatch/gouuid"
 )
 
@@ -20,6 +22,8 @@ type VolumeRepository interface {
 	CreateBaseResourceTypeVolume(int, *UsedWorkerBaseResourceType) (CreatingVolume, error)
 
 	FindResourceCacheVolume(string, UsedResourceCache) (CreatedVolume, bool, error)
+	FindResourceCacheVolumeByID(id int) (CreatedVolume, bool, error)
+	GetResourceCacheVolumes(resourceHash string) ([]ResourceCacheVolume, error)
 
 	FindTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, CreatedVolume, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
@@ -27,6 +31,9 @@ type VolumeRepository interface {
 	FindResourceCertsVolume(workerName string, uwrc *UsedWorkerResourceCerts) (CreatingVolume, CreatedVolume, error)
 	CreateResourceCertsVolume(workerName string, uwrc *UsedWorkerResourceCerts) (CreatingVolume, error)
 
+	FindBuildInputUploadVolume(teamID int, upload *BuildInputUpload) (CreatingVolume, CreatedVolume, error)
+	CreateBuildInputUploadVolume(teamID int, upload *BuildInputUpload) (CreatingVolume, error)
+
 	FindVolumesForContainer(CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, error)
 
@@ -251,6 +258,28 @@ func (repository *volumeRepository) CreateResourceCertsVolume(workerName string,
 	return volume, nil
 }
 
+func (repository *volumeRepository) FindBuildInputUploadVolume(teamID int, upload *BuildInputUpload) (CreatingVolume, CreatedVolume, error) {
+	return repository.findVolume(teamID, upload.WorkerName, map[string]interface{}{
+		"v.build_input_upload_id": upload.ID,
+	})
+}
+
+func (repository *volumeRepository) CreateBuildInputUploadVolume(teamID int, upload *BuildInputUpload) (CreatingVolume, error) {
+	volume, err := repository.createVolume(
+		teamID,
+		upload.WorkerName,
+		map[string]interface{}{
+			"build_input_upload_id": upload.ID,
+		},
+		VolumeTypeInputUpload,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
 func (repository *volumeRepository) FindResourceCacheVolume(workerName string, resourceCache UsedResourceCache) (CreatedVolume, bool, error) {
 	workerResourceCache, found, err := WorkerResourceCache{
 		WorkerName:    workerName,
@@ -278,6 +307,89 @@ func (repository *volumeRepository) FindResourceCacheVolume(workerName string, r
 	return createdVolume, true, nil
 }
 
+func (repository *volumeRepository) FindResourceCacheVolumeByID(id int) (CreatedVolume, bool, error) {
+	_, createdVolume, err := repository.findVolume(0, "", map[string]interface{}{
+		"v.id": id,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if createdVolume == nil || createdVolume.Type() != VolumeTypeResource {
+		return nil, false, nil
+	}
+
+	return createdVolume, true, nil
+}
+
+// ResourceCacheVolume is a created volume holding a resource cache, along
+// with the version of the resource it contains.
+type ResourceCacheVolume struct {
+	ID              int
+	Handle          string
+	WorkerName      string
+	ResourceCacheID int
+	ResourceHash    string
+	Version         atc.Version
+	ParamsHash      string
+}
+
+// GetResourceCacheVolumes returns the created volumes of all resource caches
+// whose resource config has the given source hash. If the hash is empty,
+// volumes for every resource cache are returned.
+func (repository *volumeRepository) GetResourceCacheVolumes(resourceHash string) ([]ResourceCacheVolume, error) {
+	query := psql.Select("v.id, v.handle, v.worker_name, rc.id, rcfg.source_hash, rc.version, rc.params_hash").
+		From("volumes v").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Join("resource_caches rc ON rc.id = wrc.resource_cache_id").
+		Join("resource_configs rcfg ON rcfg.id = rc.resource_config_id").
+		Where(sq.Eq{
+			"v.state": string(VolumeStateCreated),
+		}).
+		OrderBy("v.id ASC")
+
+	if resourceHash != "" {
+		query = query.Where(sq.Eq{
+			"rcfg.source_hash": resourceHash,
+		})
+	}
+
+	rows, err := query.RunWith(repository.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	volumes := []ResourceCacheVolume{}
+
+	for rows.Next() {
+		var volume ResourceCacheVolume
+		var version string
+
+		err = rows.Scan(
+			&volume.ID,
+			&volume.Handle,
+			&volume.WorkerName,
+			&volume.ResourceCacheID,
+			&volume.ResourceHash,
+			&version,
+			&volume.ParamsHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(version), &volume.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
 func (repository *volumeRepository) FindCreatedVolume(handle string) (CreatedVolume, bool, error) {
 	_, createdVolume, err := repository.findVolume(0, "", map[string]interface{}{
 		"v.handle": handle,
@@ -308,6 +420,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 					"v.container_id":                 nil,
 					"v.worker_task_cache_id":         nil,
 					"v.worker_resource_certs_id":     nil,
+					"v.build_input_upload_id":        nil,
 				},
 				sq.And{
 					sq.NotEq{
@@ -319,6 +432,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 						"v.container_id":             nil,
 						"v.worker_task_cache_id":     nil,
 						"v.worker_resource_certs_id": nil,
+						"v.build_input_upload_id":    nil,
 					},
 				},
 			},
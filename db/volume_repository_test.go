@@ -507,6 +507,48 @@ var _ = Describe("VolumeFactory", func() {
 				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
 				Expect(found).To(BeTrue())
 			})
+
+			It("is listed with its resource cache", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumes("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].Handle).To(Equal(existingVolume.Handle()))
+				Expect(volumes[0].WorkerName).To(Equal(defaultWorker.Name()))
+				Expect(volumes[0].ResourceCacheID).To(Equal(usedResourceCache.ID()))
+				Expect(volumes[0].Version).To(Equal(atc.Version{"some": "version"}))
+
+				filtered, err := volumeRepository.GetResourceCacheVolumes(volumes[0].ResourceHash)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filtered).To(Equal(volumes))
+
+				filtered, err = volumeRepository.GetResourceCacheVolumes("some-other-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filtered).To(BeEmpty())
+			})
+
+			It("can be found by the id it is listed with", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumes("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+
+				createdVolume, found, err := volumeRepository.FindResourceCacheVolumeByID(volumes[0].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
+			})
+
+			Context("when the volume is being destroyed", func() {
+				BeforeEach(func() {
+					_, err := existingVolume.Destroying()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is no longer listed", func() {
+					volumes, err := volumeRepository.GetResourceCacheVolumes("")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(volumes).To(BeEmpty())
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,298 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/event"
+)
+
+// A Webhook subscribes a URL to the build events of a team. If no Events are
+// given, every event but build output ('log') is delivered; output is only
+// delivered to webhooks which subscribe to it explicitly.
+type Webhook struct {
+	ID     int
+	TeamID int
+	URL    string
+	Secret string
+	Events []string
+}
+
+func (t *team) Webhooks() ([]Webhook, error) {
+	rows, err := psql.Select("id", "team_id", "url", "secret", "nonce", "events").
+		From("webhooks").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("id ASC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		var (
+			webhook Webhook
+			secret  string
+			nonce   sql.NullString
+			events  string
+		)
+
+		err = rows.Scan(&webhook.ID, &webhook.TeamID, &webhook.URL, &secret, &nonce, &events)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.Secret, err = decryptWebhookSecret(t.conn, secret, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(events), &webhook.Events)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (t *team) CreateWebhook(url string, secret string, events []string) (Webhook, error) {
+	if events == nil {
+		events = []string{}
+	}
+
+	eventsPayload, err := json.Marshal(events)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	encryptedSecret, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(secret))
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	var id int
+	err = psql.Insert("webhooks").
+		Columns("team_id", "url", "secret", "nonce", "events").
+		Values(t.id, url, encryptedSecret, nonce, string(eventsPayload)).
+		Suffix("RETURNING id").
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		ID:     id,
+		TeamID: t.id,
+		URL:    url,
+		Secret: secret,
+		Events: events,
+	}, nil
+}
+
+func (t *team) DeleteWebhook(id int) (bool, error) {
+	result, err := psql.Delete("webhooks").
+		Where(sq.Eq{
+			"id":      id,
+			"team_id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func decryptWebhookSecret(conn Conn, secret string, nonce sql.NullString) (string, error) {
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := conn.EncryptionStrategy().Decrypt(secret, noncense)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decrypted), nil
+}
+
+// queueWebhookDeliveries queues the event for delivery to each of the team's
+// webhooks which are subscribed to it. Build output is only queued for
+// webhooks which name it, as there is far too much of it to deliver to every
+// webhook.
+//
+// Events of builds whose job notifies on transitions only are not queued;
+// see queueTransitionWebhookDeliveries.
+func queueWebhookDeliveries(tx Tx, teamID int, buildID int, eventType string, eventVersion string, payload []byte) error {
+	_, err := tx.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, build_id, event_type, event_version, payload)
+		SELECT w.id, $1, $2, $3, $4
+		FROM webhooks w
+		WHERE w.team_id = $5
+		AND ((w.events = '[]' AND $2::text <> $6::text) OR w.events::jsonb @> to_jsonb($2::text))
+		AND NOT EXISTS (
+			SELECT 1
+			FROM builds b
+			JOIN jobs j ON j.id = b.job_id
+			WHERE b.id = $1
+			AND j.notify_on_transition
+		)
+	`, buildID, eventType, eventVersion, string(payload), teamID, string(event.EventTypeLog))
+	return err
+}
+
+// queueTransitionWebhookDeliveries queues the finished build's status event
+// for jobs which notify on transitions only, provided the build changed the
+// job's status. It must be called after the job's transition build has been
+// updated.
+func queueTransitionWebhookDeliveries(tx Tx, teamID int, buildID int, jobID int, status event.Status) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, build_id, event_type, event_version, payload)
+		SELECT w.id, $1, $2, $3, $4
+		FROM webhooks w, jobs j
+		WHERE w.team_id = $5
+		AND (w.events = '[]' OR w.events::jsonb @> to_jsonb($2::text))
+		AND j.id = $6
+		AND j.notify_on_transition
+		AND j.transition_build_id = $1
+	`, buildID, string(status.EventType()), string(status.Version()), string(payload), teamID, jobID)
+	return err
+}
+
+// A WebhookDelivery is a build event waiting to be sent to a webhook.
+type WebhookDelivery struct {
+	ID           int
+	WebhookID    int
+	URL          string
+	Secret       string
+	BuildID      int
+	EventType    string
+	EventVersion string
+	Payload      json.RawMessage
+	Attempts     int
+}
+
+//go:generate counterfeiter . WebhookDeliveryQueue
+
+type WebhookDeliveryQueue interface {
+	// DueDeliveries returns up to limit deliveries for each webhook whose
+	// oldest delivery is due, oldest first, so that a webhook with a backlog
+	// doesn't hold up the others. A webhook's deliveries are held back while
+	// its oldest one waits to be retried, so that they are made in order.
+	DueDeliveries(limit int) ([]WebhookDelivery, error)
+
+	// Retry records a failed attempt and schedules the next one.
+	Retry(id int, nextAttempt time.Time) error
+
+	// Remove removes a delivery which either succeeded or has been given up
+	// on.
+	Remove(id int) error
+}
+
+type webhookDeliveryQueue struct {
+	conn Conn
+}
+
+func NewWebhookDeliveryQueue(conn Conn) WebhookDeliveryQueue {
+	return &webhookDeliveryQueue{
+		conn: conn,
+	}
+}
+
+func (queue *webhookDeliveryQueue) DueDeliveries(limit int) ([]WebhookDelivery, error) {
+	rows, err := psql.Select("d.id", "w.id", "w.url", "w.secret", "w.nonce", "d.build_id", "d.event_type", "d.event_version", "d.payload", "d.attempts").
+		From(`(
+			SELECT *,
+				row_number() OVER (PARTITION BY webhook_id ORDER BY id ASC) AS position,
+				first_value(next_attempt_at) OVER (PARTITION BY webhook_id ORDER BY id ASC) AS oldest_next_attempt_at
+			FROM webhook_deliveries
+		) d`).
+		Join("webhooks w ON w.id = d.webhook_id").
+		Where(sq.Expr("d.position <= ?", limit)).
+		Where(sq.Expr("d.oldest_next_attempt_at <= now()")).
+		OrderBy("d.id ASC").
+		RunWith(queue.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		var (
+			delivery WebhookDelivery
+			secret   string
+			nonce    sql.NullString
+			payload  string
+		)
+
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.URL,
+			&secret,
+			&nonce,
+			&delivery.BuildID,
+			&delivery.EventType,
+			&delivery.EventVersion,
+			&payload,
+			&delivery.Attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Secret, err = decryptWebhookSecret(queue.conn, secret, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Payload = json.RawMessage(payload)
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (queue *webhookDeliveryQueue) Retry(id int, nextAttempt time.Time) error {
+	_, err := psql.Update("webhook_deliveries").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("next_attempt_at", nextAttempt).
+		Where(sq.Eq{"id": id}).
+		RunWith(queue.conn).
+		Exec()
+	return err
+}
+
+func (queue *webhookDeliveryQueue) Remove(id int) error {
+	_, err := psql.Delete("webhook_deliveries").
+		Where(sq.Eq{"id": id}).
+		RunWith(queue.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,284 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks", func() {
+	var otherTeam db.Team
+
+	BeforeEach(func() {
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("CreateWebhook", func() {
+		It("can be listed by its team", func() {
+			created, err := defaultTeam.CreateWebhook("https://example.com/hook", "some-secret", []string{"status"})
+			Expect(err).ToNot(HaveOccurred())
+
+			webhooks, err := defaultTeam.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(Equal([]db.Webhook{{
+				ID:     created.ID,
+				TeamID: defaultTeam.ID(),
+				URL:    "https://example.com/hook",
+				Secret: "some-secret",
+				Events: []string{"status"},
+			}}))
+
+			webhooks, err = otherTeam.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteWebhook", func() {
+		var webhook db.Webhook
+
+		BeforeEach(func() {
+			var err error
+			webhook, err = defaultTeam.CreateWebhook("https://example.com/hook", "some-secret", nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not delete webhooks of other teams", func() {
+			found, err := otherTeam.DeleteWebhook(webhook.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("deletes the webhook", func() {
+			found, err := defaultTeam.DeleteWebhook(webhook.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			webhooks, err := defaultTeam.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(BeEmpty())
+		})
+	})
+
+	Describe("delivery queue", func() {
+		var (
+			queue         db.WebhookDeliveryQueue
+			allWebhook    db.Webhook
+			statusWebhook db.Webhook
+			logWebhook    db.Webhook
+			build         db.Build
+		)
+
+		BeforeEach(func() {
+			queue = db.NewWebhookDeliveryQueue(dbConn)
+
+			var err error
+			allWebhook, err = defaultTeam.CreateWebhook("https://example.com/all", "all-secret", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			statusWebhook, err = defaultTeam.CreateWebhook("https://example.com/status", "status-secret", []string{"status"})
+			Expect(err).ToNot(HaveOccurred())
+
+			logWebhook, err = defaultTeam.CreateWebhook("https://example.com/log", "log-secret", []string{"log"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = otherTeam.CreateWebhook("https://example.com/other", "other-secret", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-output"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("queues build output only for the webhooks of the build's team which subscribe to it", func() {
+			deliveries, err := queue.DueDeliveries(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].WebhookID).To(Equal(logWebhook.ID))
+			Expect(deliveries[0].URL).To(Equal("https://example.com/log"))
+			Expect(deliveries[0].Secret).To(Equal("log-secret"))
+			Expect(deliveries[0].BuildID).To(Equal(build.ID()))
+			Expect(deliveries[0].EventType).To(Equal(string(event.EventTypeLog)))
+			Expect(deliveries[0].Attempts).To(BeZero())
+
+			var logEvent event.Log
+			Expect(json.Unmarshal(deliveries[0].Payload, &logEvent)).To(Succeed())
+			Expect(logEvent.Payload).To(Equal("some-output"))
+		})
+
+		Context("when the build finishes", func() {
+			BeforeEach(func() {
+				Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("queues the status event for webhooks subscribed to it", func() {
+				deliveries, err := queue.DueDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				webhookIDs := []int{}
+				for _, delivery := range deliveries {
+					if delivery.EventType == string(event.EventTypeStatus) {
+						webhookIDs = append(webhookIDs, delivery.WebhookID)
+					}
+				}
+
+				Expect(webhookIDs).To(ConsistOf(allWebhook.ID, statusWebhook.ID))
+			})
+		})
+
+		Context("when the build's job notifies on transitions only", func() {
+			var job db.Job
+
+			deliveredWebhookIDs := func(build db.Build) []int {
+				deliveries, err := queue.DueDeliveries(100)
+				Expect(err).ToNot(HaveOccurred())
+
+				webhookIDs := []int{}
+				for _, delivery := range deliveries {
+					if delivery.BuildID == build.ID() {
+						Expect(delivery.EventType).To(Equal(string(event.EventTypeStatus)))
+						webhookIDs = append(webhookIDs, delivery.WebhookID)
+					}
+				}
+
+				return webhookIDs
+			}
+
+			finishedBuild := func(status db.BuildStatus) db.Build {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "some-output"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(build.Finish(status)).To(Succeed())
+
+				return build
+			}
+
+			BeforeEach(func() {
+				pipeline, _, err := defaultTeam.SavePipeline("transition-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:     "some-job",
+							NotifyOn: atc.NotifyOnTransition,
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("does not queue events while the build is running", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "some-output"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(deliveredWebhookIDs(build)).To(BeEmpty())
+			})
+
+			It("queues the status of the job's first build", func() {
+				build := finishedBuild(db.BuildStatusSucceeded)
+				Expect(deliveredWebhookIDs(build)).To(ConsistOf(allWebhook.ID, statusWebhook.ID))
+			})
+
+			It("queues the status only when it differs from the previous build's", func() {
+				finishedBuild(db.BuildStatusFailed)
+
+				unchanged := finishedBuild(db.BuildStatusFailed)
+				Expect(deliveredWebhookIDs(unchanged)).To(BeEmpty())
+
+				fixed := finishedBuild(db.BuildStatusSucceeded)
+				Expect(deliveredWebhookIDs(fixed)).To(ConsistOf(allWebhook.ID, statusWebhook.ID))
+			})
+		})
+
+		Context("when webhooks have more deliveries due than the limit", func() {
+			BeforeEach(func() {
+				err := build.SaveEvent(event.Log{Payload: "more-output"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("returns up to the limit for each webhook, oldest first", func() {
+				deliveries, err := queue.DueDeliveries(1)
+				Expect(err).ToNot(HaveOccurred())
+
+				webhookIDs := []int{}
+				for _, delivery := range deliveries {
+					webhookIDs = append(webhookIDs, delivery.WebhookID)
+
+					if delivery.WebhookID == logWebhook.ID {
+						var logEvent event.Log
+						Expect(json.Unmarshal(delivery.Payload, &logEvent)).To(Succeed())
+						Expect(logEvent.Payload).To(Equal("some-output"))
+					}
+				}
+
+				Expect(webhookIDs).To(ConsistOf(logWebhook.ID, allWebhook.ID, statusWebhook.ID))
+			})
+		})
+
+		Context("when a delivery is retried", func() {
+			BeforeEach(func() {
+				deliveries, err := queue.DueDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+
+				err = queue.Retry(deliveries[0].ID, time.Now().Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not due until the next attempt", func() {
+				deliveries, err := queue.DueDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			Context("when newer deliveries are queued for the same webhook", func() {
+				BeforeEach(func() {
+					err := build.SaveEvent(event.Log{Payload: "more-output"})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("holds them back so that they do not overtake it", func() {
+					deliveries, err := queue.DueDeliveries(10)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deliveries).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when a delivery is removed", func() {
+			BeforeEach(func() {
+				deliveries, err := queue.DueDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+
+				Expect(queue.Remove(deliveries[0].ID)).To(Succeed())
+			})
+
+			It("is no longer due", func() {
+				deliveries, err := queue.DueDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+	})
+})
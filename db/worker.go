@@ -46,6 +46,15 @@ type Worker interface {
 	StartTime() int64
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Runtime() string
+
+	// Load is the load the worker reported on its last heartbeat, or nil if
+	// it doesn't report any.
+	Load() *atc.WorkerLoad
+
+	// DeniesNetworkByDefault is true if the worker's containers can only
+	// reach the networks they are given NetOut rules for.
+	DeniesNetworkByDefault() bool
 
 	Reload() (bool, error)
 
@@ -76,6 +85,10 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	runtime          string
+	load             *atc.WorkerLoad
+
+	deniesNetworkByDefault bool
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -95,6 +108,9 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Runtime() string                         { return worker.runtime }
+func (worker *worker) Load() *atc.WorkerLoad                   { return worker.load }
+func (worker *worker) DeniesNetworkByDefault() bool            { return worker.deniesNetworkByDefault }
 
 // TODO: normalize time values
 func (worker *worker) StartTime() int64     { return worker.startTime }
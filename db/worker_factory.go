@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -49,7 +50,12 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.runtime,
+		w.cpu_load,
+		w.free_memory,
+		w.free_disk,
+		w.denies_network_by_default
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -134,6 +140,10 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     sql.NullInt64
 		expiresAt     *time.Time
 		ephemeral     sql.NullBool
+		runtime       sql.NullString
+		cpuLoad       sql.NullFloat64
+		freeMemory    sql.NullInt64
+		freeDisk      sql.NullInt64
 	)
 
 	err := row.Scan(
@@ -155,6 +165,11 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&runtime,
+		&cpuLoad,
+		&freeMemory,
+		&freeDisk,
+		&worker.deniesNetworkByDefault,
 	)
 	if err != nil {
 		return err
@@ -214,6 +229,18 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if runtime.Valid {
+		worker.runtime = runtime.String
+	}
+
+	if cpuLoad.Valid {
+		worker.load = &atc.WorkerLoad{
+			CPULoad:    cpuLoad.Float64,
+			FreeMemory: uint64(freeMemory.Int64),
+			FreeDisk:   uint64(freeDisk.Int64),
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -267,12 +294,37 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		return nil, err
 	}
 
-	_, err = psql.Update("workers").
+	resourceTypesChanged, err := workerResourceTypesChanged(tx, atcWorker)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWorkerNotPresent
+		}
+		return nil, err
+	}
+
+	update := psql.Update("workers").
 		Set("expires", sq.Expr(expires)).
 		Set("addr", sq.Expr("("+addrSQL+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSQL+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
-		Set("state", sq.Expr("("+cSQL+")")).
+		Set("state", sq.Expr("("+cSQL+")"))
+
+	cpuLoad, freeMemory, freeDisk := workerLoadValues(atcWorker.Load)
+	update = update.
+		Set("cpu_load", cpuLoad).
+		Set("free_memory", freeMemory).
+		Set("free_disk", freeDisk)
+
+	if resourceTypesChanged {
+		resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		update = update.Set("resource_types", resourceTypes)
+	}
+
+	_, err = update.
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
 		Exec()
@@ -296,6 +348,13 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		return nil, err
 	}
 
+	if resourceTypesChanged {
+		err = syncWorkerResourceTypes(tx, worker, atcWorker.ResourceTypes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -304,6 +363,41 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 
 }
 
+// workerResourceTypesChanged determines whether the heartbeat advertises
+// different resource types than the worker registered with. Heartbeats which
+// do not advertise any resource types leave them as they are.
+func workerResourceTypesChanged(tx Tx, atcWorker atc.Worker) (bool, error) {
+	if atcWorker.ResourceTypes == nil {
+		return false, nil
+	}
+
+	var payload []byte
+	err := psql.Select("resource_types").
+		From("workers").
+		Where(sq.Eq{"name": atcWorker.Name}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		return false, err
+	}
+
+	var resourceTypes []atc.WorkerResourceType
+	if payload != nil {
+		err = json.Unmarshal(payload, &resourceTypes)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(resourceTypes) == 0 && len(atcWorker.ResourceTypes) == 0 {
+		return false, nil
+	}
+
+	return !reflect.DeepEqual(resourceTypes, atcWorker.ResourceTypes), nil
+}
+
 func (f *workerFactory) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := f.conn.Begin()
 	if err != nil {
@@ -353,6 +447,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerVersion = &atcWorker.Version
 	}
 
+	cpuLoad, freeMemory, freeDisk := workerLoadValues(atcWorker.Load)
+
 	values := []interface{}{
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
@@ -370,6 +466,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.Runtime,
+		cpuLoad,
+		freeMemory,
+		freeDisk,
+		atcWorker.DeniesNetworkByDefault,
 	}
 
 	conflictValues := values
@@ -400,6 +501,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"runtime",
+			"cpu_load",
+			"free_memory",
+			"free_disk",
+			"denies_network_by_default",
 		).
 		Values(append([]interface{}{sq.Expr(expires)}, values...)...).
 		Suffix(`
@@ -420,7 +526,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				start_time = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				runtime = ?,
+				cpu_load = ?,
+				free_memory = ?,
+				free_disk = ?,
+				denies_network_by_default = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -462,14 +573,41 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        atcWorker.StartTime,
 		ephemeral:        atcWorker.Ephemeral,
+		runtime:          atcWorker.Runtime,
+		load:             atcWorker.Load,
 		conn:             conn,
+
+		deniesNetworkByDefault: atcWorker.DeniesNetworkByDefault,
+	}
+
+	err = syncWorkerResourceTypes(tx, savedWorker, atcWorker.ResourceTypes)
+	if err != nil {
+		return nil, err
 	}
 
+	if atcWorker.CertsPath != nil {
+		_, err := WorkerResourceCerts{
+			WorkerName: atcWorker.Name,
+			CertsPath:  *atcWorker.CertsPath,
+		}.FindOrCreate(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return savedWorker, nil
+}
+
+// syncWorkerResourceTypes replaces the worker's base resource types with the
+// given ones. Any whose image or version changed are removed, which cascades
+// to the resource caches and check sessions using them, so that the image
+// caches are fetched again and the check containers are recreated.
+func syncWorkerResourceTypes(tx Tx, worker Worker, resourceTypes []atc.WorkerResourceType) error {
 	workerBaseResourceTypeIDs := []int{}
 
-	for _, resourceType := range atcWorker.ResourceTypes {
+	for _, resourceType := range resourceTypes {
 		workerResourceType := WorkerResourceType{
-			Worker:  savedWorker,
+			Worker:  worker,
 			Image:   resourceType.Image,
 			Version: resourceType.Version,
 			BaseResourceType: &BaseResourceType{
@@ -479,12 +617,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 
 		ubrt, err := workerResourceType.BaseResourceType.FindOrCreate(tx)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		_, err = psql.Delete("worker_base_resource_types").
 			Where(sq.Eq{
-				"worker_name":           atcWorker.Name,
+				"worker_name":           worker.Name(),
 				"base_resource_type_id": ubrt.ID,
 			}).
 			Where(sq.Or{
@@ -498,39 +636,35 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			RunWith(tx).
 			Exec()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		uwrt, err := workerResourceType.FindOrCreate(tx)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		workerBaseResourceTypeIDs = append(workerBaseResourceTypeIDs, uwrt.ID)
 	}
 
-	_, err = psql.Delete("worker_base_resource_types").
+	_, err := psql.Delete("worker_base_resource_types").
 		Where(sq.Eq{
-			"worker_name": atcWorker.Name,
+			"worker_name": worker.Name(),
 		}).
 		Where(sq.NotEq{
 			"id": workerBaseResourceTypeIDs,
 		}).
 		RunWith(tx).
 		Exec()
-	if err != nil {
-		return nil, err
-	}
+	return err
+}
 
-	if atcWorker.CertsPath != nil {
-		_, err := WorkerResourceCerts{
-			WorkerName: atcWorker.Name,
-			CertsPath:  *atcWorker.CertsPath,
-		}.FindOrCreate(tx)
-		if err != nil {
-			return nil, err
-		}
+// workerLoadValues returns the columns for the load a worker reported, which
+// are left NULL if it didn't report any.
+func workerLoadValues(load *atc.WorkerLoad) (interface{}, interface{}, interface{}) {
+	if load == nil {
+		return nil, nil, nil
 	}
 
-	return savedWorker, nil
+	return load.CPULoad, int64(load.FreeMemory), int64(load.FreeDisk)
 }
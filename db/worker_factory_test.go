@@ -187,6 +187,22 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*savedWorker.Version()).To(Equal("1.0.0"))
 			})
 
+			It("saves the worker's load, if it reports any", func() {
+				savedWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedWorker.Load()).To(BeNil())
+
+				atcWorker.Load = &atc.WorkerLoad{CPULoad: 0.5, FreeMemory: 1024, FreeDisk: 2048}
+
+				_, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundWorker.Load()).To(Equal(&atc.WorkerLoad{CPULoad: 0.5, FreeMemory: 1024, FreeDisk: 2048}))
+			})
+
 			It("saves worker resource types as base resource types", func() {
 				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
 				Expect(err).NotTo(HaveOccurred())
@@ -435,6 +451,86 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates the worker's load", func() {
+				atcWorker.Load = &atc.WorkerLoad{CPULoad: 1.5, FreeMemory: 1024, FreeDisk: 2048}
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.Load()).To(Equal(&atc.WorkerLoad{CPULoad: 1.5, FreeMemory: 1024, FreeDisk: 2048}))
+
+				atcWorker.Load = nil
+
+				foundWorker, err = workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.Load()).To(BeNil())
+			})
+
+			Context("when the advertised resource types changed", func() {
+				var changedResourceTypes []atc.WorkerResourceType
+
+				BeforeEach(func() {
+					changedResourceTypes = []atc.WorkerResourceType{
+						{
+							Type:       "some-resource-type",
+							Image:      "some-image",
+							Version:    "some-new-version",
+							Privileged: true,
+						},
+					}
+				})
+
+				It("updates the worker's resource types", func() {
+					atcWorker.ResourceTypes = changedResourceTypes
+
+					foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(foundWorker.ResourceTypes()).To(Equal(changedResourceTypes))
+
+					savedWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(savedWorker.ResourceTypes()).To(Equal(changedResourceTypes))
+				})
+
+				It("replaces the worker's base resource types", func() {
+					atcWorker.ResourceTypes = changedResourceTypes
+
+					_, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+					Expect(err).NotTo(HaveOccurred())
+
+					rows, err := psql.Select("b.name", "w.version").
+						From("worker_base_resource_types w").
+						Join("base_resource_types AS b ON w.base_resource_type_id = b.id").
+						Where(sq.Eq{"w.worker_name": atcWorker.Name}).
+						RunWith(dbConn).
+						Query()
+					Expect(err).NotTo(HaveOccurred())
+
+					versions := map[string]string{}
+					for rows.Next() {
+						var name, version string
+						err = rows.Scan(&name, &version)
+						Expect(err).NotTo(HaveOccurred())
+						versions[name] = version
+					}
+
+					Expect(versions).To(Equal(map[string]string{
+						"some-resource-type": "some-new-version",
+					}))
+				})
+			})
+
+			Context("when the heartbeat does not advertise resource types", func() {
+				It("keeps the worker's resource types", func() {
+					registeredResourceTypes := atcWorker.ResourceTypes
+					atcWorker.ResourceTypes = nil
+
+					foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(foundWorker.ResourceTypes()).To(Equal(registeredResourceTypes))
+				})
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
@@ -17,6 +18,12 @@ type UsedWorkerTaskCache struct {
 type WorkerTaskCacheFactory interface {
 	Find(jobID int, stepName string, path string, workerName string) (*UsedWorkerTaskCache, bool, error)
 	FindOrCreate(jobID int, stepName string, path string, workerName string) (*UsedWorkerTaskCache, error)
+
+	// RemoveIdle removes the task caches which have not been used for the
+	// given duration, as measured by the database's clock, unless their job
+	// has a build running, returning how many were removed. Their volumes are
+	// then left to be collected.
+	RemoveIdle(idleFor time.Duration) (int, error)
 }
 
 type workerTaskCacheFactory struct {
@@ -84,6 +91,24 @@ func (f *workerTaskCacheFactory) FindOrCreate(jobID int, stepName string, path s
 	return usedWorkerTaskCache, nil
 }
 
+func (f *workerTaskCacheFactory) RemoveIdle(idleFor time.Duration) (int, error) {
+	result, err := psql.Delete("worker_task_caches wtc").
+		Where(sq.Expr("wtc.last_used < now() - ? * interval '1 second'", int(idleFor.Seconds()))).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds b WHERE b.job_id = wtc.job_id AND NOT b.completed)")).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
 type WorkerTaskCache struct {
 	JobID      int
 	StepName   string
@@ -142,6 +167,15 @@ func (wtc WorkerTaskCache) FindOrCreate(
 		return nil, err
 	}
 
+	_, err = psql.Update("worker_task_caches").
+		Set("last_used", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
 	return &UsedWorkerTaskCache{
 		ID:         id,
 		WorkerName: wtc.WorkerName,
@@ -0,0 +1,68 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerTaskCacheFactory", func() {
+	Describe("RemoveIdle", func() {
+		var (
+			idleCache   *db.UsedWorkerTaskCache
+			activeCache *db.UsedWorkerTaskCache
+
+			removed   int
+			removeErr error
+		)
+
+		BeforeEach(func() {
+			var err error
+			idleCache, err = workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "idle-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			activeCache, err = workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "active-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE worker_task_caches SET last_used = now() - '2 hours'::interval WHERE id = $1`, idleCache.ID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			removed, removeErr = workerTaskCacheFactory.RemoveIdle(time.Hour)
+		})
+
+		It("removes the caches which have not been used for the idle period", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			_, found, err := workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", "idle-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			cache, found, err := workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", "active-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(cache.ID).To(Equal(activeCache.ID))
+		})
+
+		Context("when the job has a build running", func() {
+			BeforeEach(func() {
+				_, err := defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("leaves its caches alone", func() {
+				Expect(removeErr).NotTo(HaveOccurred())
+				Expect(removed).To(BeZero())
+
+				_, found, err := workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", "idle-path", defaultWorker.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
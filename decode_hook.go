@@ -68,6 +68,24 @@ var ContainerLimitsDecodeHook = func(
 	return containerLimits, nil
 }
 
+// TagsDecodeHook allows tags to be configured as a single string, so that a
+// step's tags can be given by a var which resolves to one or more tags.
+var TagsDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(Tags{}) {
+		return data, nil
+	}
+
+	if s, ok := data.(string); ok {
+		return Tags{s}, nil
+	}
+
+	return data, nil
+}
+
 var SanitizeDecodeHook = func(
 	dataKind reflect.Kind,
 	valKind reflect.Kind,
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"io"
+	"sync"
+)
+
+// logBufferSize bounds how much output of a step may be waiting to be saved
+// as log events. Once it is full, writes block until it has been drained,
+// which pushes back on the process writing the output rather than letting a
+// build that logs faster than the database can keep up grow without bound.
+const logBufferSize = 256 * 1024
+
+// bufferedEventWriter decouples writing a step's output from saving it, so
+// that the Garden stream copying the output only blocks on the database once
+// the buffer is full. Output which accumulates while a save is in flight is
+// saved as one event.
+//
+// Errors saving the output are returned by the next write, or by Flush.
+type bufferedEventWriter struct {
+	dest io.Writer
+	size int
+
+	lock     sync.Mutex
+	cond     *sync.Cond
+	pending  []byte
+	draining bool
+	err      error
+}
+
+// a flusher holds back some of the output written to it, e.g. the start of a
+// secret which may be continued by the next write, until it is flushed
+type flusher interface {
+	Flush() error
+}
+
+func newBufferedEventWriter(dest io.Writer, size int) *bufferedEventWriter {
+	writer := &bufferedEventWriter{
+		dest: dest,
+		size: size,
+	}
+
+	writer.cond = sync.NewCond(&writer.lock)
+
+	return writer
+}
+
+func (writer *bufferedEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if err := writer.takeErr(); err != nil {
+		return 0, err
+	}
+
+	// a write larger than the buffer is let through once it has drained, so
+	// that it cannot block forever
+	for len(writer.pending) > 0 && len(writer.pending)+len(data) > writer.size {
+		writer.cond.Wait()
+
+		if err := writer.takeErr(); err != nil {
+			return 0, err
+		}
+	}
+
+	writer.pending = append(writer.pending, data...)
+
+	// the drainer only runs while there is output to save, so that writers
+	// which are never flushed do not leak it
+	if !writer.draining {
+		writer.draining = true
+		go writer.drain()
+	}
+
+	return len(data), nil
+}
+
+// Flush waits for all output written so far to be saved, including any held
+// back by the destination.
+func (writer *bufferedEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	for writer.draining {
+		writer.cond.Wait()
+	}
+
+	if err := writer.takeErr(); err != nil {
+		return err
+	}
+
+	// no drainer is running while the lock is held, so the destination is
+	// not being written to
+	if dest, ok := writer.dest.(flusher); ok {
+		return dest.Flush()
+	}
+
+	return nil
+}
+
+func (writer *bufferedEventWriter) drain() {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	for len(writer.pending) > 0 {
+		chunk := writer.pending
+		writer.pending = nil
+
+		// let writers fill the buffer back up while the chunk is saved
+		writer.cond.Broadcast()
+
+		writer.lock.Unlock()
+		_, err := writer.dest.Write(chunk)
+		writer.lock.Lock()
+
+		if err != nil {
+			writer.err = err
+		}
+	}
+
+	writer.draining = false
+	writer.cond.Broadcast()
+}
+
+func (writer *bufferedEventWriter) takeErr() error {
+	err := writer.err
+	writer.err = nil
+	return err
+}
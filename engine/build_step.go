@@ -65,7 +65,14 @@ func (build *execBuild) buildOnSuccessStep(logger lager.Logger, plan atc.Plan) e
 	plan.OnSuccess.Step.Attempts = plan.Attempts
 	step := build.buildStep(logger, plan.OnSuccess.Step)
 	plan.OnSuccess.Next.Attempts = plan.Attempts
-	next := build.buildStep(logger, plan.OnSuccess.Next)
+
+	var next exec.Step
+	if plan.OnSuccess.Hook {
+		next = build.buildHookStep(logger, "on_success", plan.OnSuccess.Next)
+	} else {
+		next = build.buildStep(logger, plan.OnSuccess.Next)
+	}
+
 	return exec.OnSuccess(step, next)
 }
 
@@ -73,7 +80,7 @@ func (build *execBuild) buildOnFailureStep(logger lager.Logger, plan atc.Plan) e
 	plan.OnFailure.Step.Attempts = plan.Attempts
 	step := build.buildStep(logger, plan.OnFailure.Step)
 	plan.OnFailure.Next.Attempts = plan.Attempts
-	next := build.buildStep(logger, plan.OnFailure.Next)
+	next := build.buildHookStep(logger, "on_failure", plan.OnFailure.Next)
 	return exec.OnFailure(step, next)
 }
 
@@ -81,10 +88,19 @@ func (build *execBuild) buildEnsureStep(logger lager.Logger, plan atc.Plan) exec
 	plan.Ensure.Step.Attempts = plan.Attempts
 	step := build.buildStep(logger, plan.Ensure.Step)
 	plan.Ensure.Next.Attempts = plan.Attempts
-	next := build.buildStep(logger, plan.Ensure.Next)
+	next := build.buildHookStep(logger, "ensure", plan.Ensure.Next)
 	return exec.Ensure(step, next)
 }
 
+func (build *execBuild) buildHookStep(logger lager.Logger, hook string, plan atc.Plan) exec.Step {
+	step := build.buildStep(logger, plan)
+	if build.hookTimeout == 0 {
+		return step
+	}
+
+	return exec.HookTimeout(step, hook, build.hookTimeout, build.delegate.BuildStepDelegate(plan.ID))
+}
+
 func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("task")
 
@@ -98,6 +114,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		logger,
 		plan,
 		build.dbBuild,
+		build.stepMetadata,
 		containerMetadata,
 		build.delegate.TaskDelegate(plan.ID),
 	)
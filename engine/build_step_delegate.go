@@ -3,32 +3,98 @@ package engine
 import (
 	"io"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type BuildStepDelegate struct {
-	build  db.Build
-	planID atc.PlanID
-	clock  clock.Clock
+	build     db.Build
+	planID    atc.PlanID
+	variables *creds.TrackedVariables
+	sanitizer *LogSanitizer
+	clock     clock.Clock
+
+	stdout *bufferedEventWriter
+	stderr *bufferedEventWriter
 }
 
 func NewBuildStepDelegate(
 	build db.Build,
 	planID atc.PlanID,
+	variables *creds.TrackedVariables,
+	sanitizer *LogSanitizer,
 	clock clock.Clock,
 ) *BuildStepDelegate {
 	return &BuildStepDelegate{
-		build:  build,
-		planID: planID,
-		clock:  clock,
+		build:     build,
+		planID:    planID,
+		variables: variables,
+		sanitizer: sanitizer,
+		clock:     clock,
+
+		stdout: newBufferedEventWriter(
+			newDBEventWriter(
+				build,
+				event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     event.OriginID(planID),
+				},
+				variables,
+				sanitizer,
+				clock,
+			),
+			logBufferSize,
+		),
+
+		stderr: newBufferedEventWriter(
+			newDBEventWriter(
+				build,
+				event.Origin{
+					Source: event.OriginSourceStderr,
+					ID:     event.OriginID(planID),
+				},
+				variables,
+				sanitizer,
+				clock,
+			),
+			logBufferSize,
+		),
+	}
+}
+
+func (delegate *BuildStepDelegate) Variables() creds.Variables {
+	return delegate.variables
+}
+
+func (delegate *BuildStepDelegate) ImageCheckStarted(logger lager.Logger, resourceType string) {
+	err := delegate.build.SaveEvent(event.ImageCheck{
+		Time:   delegate.clock.Now().Unix(),
+		Origin: event.Origin{ID: event.OriginID(delegate.planID)},
+		Type:   resourceType,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-check-event", err)
+	}
+}
+
+func (delegate *BuildStepDelegate) ImageGetStarted(logger lager.Logger, resourceType string, version atc.Version) {
+	err := delegate.build.SaveEvent(event.ImageGet{
+		Time:         delegate.clock.Now().Unix(),
+		Origin:       event.Origin{ID: event.OriginID(delegate.planID)},
+		Type:         resourceType,
+		ImageVersion: version,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-get-event", err)
 	}
 }
 
@@ -37,44 +103,59 @@ func (delegate *BuildStepDelegate) ImageVersionDetermined(resourceCache db.UsedR
 }
 
 func (delegate *BuildStepDelegate) Stdout() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStdout,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	return delegate.stdout
 }
 
 func (delegate *BuildStepDelegate) Stderr() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStderr,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
-}
-
-func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string) {
-	err := delegate.build.SaveEvent(event.Error{
-		Message: message,
-		Origin: event.Origin{
-			ID: event.OriginID(delegate.planID),
-		},
+	return delegate.stderr
+}
+
+// Flush waits for the step's output to be saved, so that it precedes any
+// event saved afterwards, e.g. the step finishing.
+func (delegate *BuildStepDelegate) Flush(logger lager.Logger) {
+	if err := delegate.stdout.Flush(); err != nil {
+		logger.Error("failed-to-save-stdout", err)
+	}
+
+	if err := delegate.stderr.Flush(); err != nil {
+		logger.Error("failed-to-save-stderr", err)
+	}
+}
+
+func (delegate *BuildStepDelegate) Errored(logger lager.Logger, buildErr atc.BuildError) {
+	delegate.Flush(logger)
+
+	buildErr.Message = delegate.variables.Redact(buildErr.Message)
+
+	err := delegate.build.SaveError(buildErr, event.Origin{
+		ID: event.OriginID(delegate.planID),
 	})
 	if err != nil {
 		logger.Error("failed-to-save-error-event", err)
 	}
 }
 
-func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
+func (delegate *BuildStepDelegate) HookTimedOut(logger lager.Logger, hook string, timeout time.Duration) {
+	delegate.Flush(logger)
+
+	err := delegate.build.SaveEvent(event.HookTimeout{
+		Time:    delegate.clock.Now().Unix(),
+		Origin:  event.Origin{ID: event.OriginID(delegate.planID)},
+		Hook:    hook,
+		Timeout: timeout.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-hook-timeout-event", err)
+	}
+}
+
+func newDBEventWriter(build db.Build, origin event.Origin, variables *creds.TrackedVariables, sanitizer *LogSanitizer, clock clock.Clock) io.Writer {
 	return &dbEventWriter{
-		build:  build,
-		origin: origin,
-		clock:  clock,
+		build:     build,
+		origin:    origin,
+		variables: variables,
+		sanitizer: sanitizer,
+		clock:     clock,
 	}
 }
 
@@ -83,6 +164,18 @@ type dbEventWriter struct {
 
 	origin event.Origin
 
+	// secrets resolved for the build are redacted before the log events are
+	// saved, so that they are never persisted or streamed
+	variables *creds.TrackedVariables
+
+	// the end of the output written so far, held back until the next write
+	// or flush as it may be the start of a secret
+	undecided string
+
+	// optionally strips control sequences and invalid UTF-8; nil if log
+	// sanitization is disabled
+	sanitizer *LogSanitizer
+
 	dangling []byte
 
 	clock clock.Clock
@@ -99,11 +192,17 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 
 	writer.dangling = nil
 
-	err := writer.build.SaveEvent(event.Log{
-		Time:    writer.clock.Now().Unix(),
-		Payload: string(text),
-		Origin:  writer.origin,
-	})
+	payload := string(text)
+	if writer.sanitizer != nil {
+		payload, writer.dangling = writer.sanitizer.Sanitize(text)
+	}
+
+	payload, writer.undecided = writer.variables.RedactStream(writer.undecided + payload)
+	if payload == "" {
+		return len(data), nil
+	}
+
+	err := writer.saveLog(payload)
 	if err != nil {
 		return 0, err
 	}
@@ -111,6 +210,26 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// Flush saves the output held back in case it was the start of a secret.
+func (writer *dbEventWriter) Flush() error {
+	if writer.undecided == "" {
+		return nil
+	}
+
+	payload := writer.variables.Redact(writer.undecided)
+	writer.undecided = ""
+
+	return writer.saveLog(payload)
+}
+
+func (writer *dbEventWriter) saveLog(payload string) error {
+	return writer.build.SaveEvent(event.Log{
+		Time:    writer.clock.Now().Unix(),
+		Payload: payload,
+		Origin:  writer.origin,
+	})
+}
+
 type implicitOutput struct {
 	resourceType string
 	info         exec.VersionInfo
@@ -6,7 +6,12 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/onsi/gomega/gbytes"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/event"
@@ -16,16 +21,22 @@ import (
 
 var _ = Describe("BuildStepDelegate", func() {
 	var (
+		logger    *lagertest.TestLogger
 		fakeBuild *dbfakes.FakeBuild
 		fakeClock *fakeclock.FakeClock
+		variables *creds.TrackedVariables
 
 		delegate *engine.BuildStepDelegate
 	)
 
 	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
-		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock)
+		variables = creds.NewTrackedVariables(template.StaticVariables{
+			"some-secret": "hunter2",
+		})
+		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", variables, nil, fakeClock)
 	})
 
 	Describe("ImageVersionDetermined", func() {
@@ -46,6 +57,37 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("ImageCheckStarted", func() {
+		JustBeforeEach(func() {
+			delegate.ImageCheckStarted(lagertest.NewTestLogger("test"), "docker-image")
+		})
+
+		It("saves an image-check event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageCheck{
+				Time:   123456789,
+				Origin: event.Origin{ID: "some-plan-id"},
+				Type:   "docker-image",
+			}))
+		})
+	})
+
+	Describe("ImageGetStarted", func() {
+		JustBeforeEach(func() {
+			delegate.ImageGetStarted(lagertest.NewTestLogger("test"), "docker-image", atc.Version{"digest": "sha256:abc"})
+		})
+
+		It("saves an image-get event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageGet{
+				Time:         123456789,
+				Origin:       event.Origin{ID: "some-plan-id"},
+				Type:         "docker-image",
+				ImageVersion: atc.Version{"digest": "sha256:abc"},
+			}))
+		})
+	})
+
 	Describe("Stdout", func() {
 		var writer io.Writer
 
@@ -72,6 +114,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("saves a log event", func() {
+					delegate.Flush(logger)
+
 					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
 						Time:    123456789,
@@ -84,18 +128,159 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 			})
 
-			Context("when saving the event succeeds", func() {
+			Context("when saving the event fails", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
 					fakeBuild.SaveEventReturns(disaster)
 				})
 
-				It("returns 0 length, and the error", func() {
-					Expect(writtenBytes).To(Equal(0))
-					Expect(writeErr).To(Equal(disaster))
+				It("logs the error when flushing", func() {
+					Expect(writtenBytes).To(Equal(len("hello")))
+					Expect(writeErr).ToNot(HaveOccurred())
+
+					delegate.Flush(logger)
+
+					Expect(logger).To(gbytes.Say("failed-to-save-stdout"))
+				})
+
+				It("returns the error from a later write", func() {
+					Eventually(func() error {
+						_, err := writer.Write([]byte("hello again"))
+						return err
+					}).Should(Equal(disaster))
 				})
 			})
+
+			Context("when saving the event is slow", func() {
+				var unblock chan struct{}
+
+				BeforeEach(func() {
+					unblock = make(chan struct{})
+					fakeBuild.SaveEventStub = func(event.Event) error {
+						<-unblock
+						return nil
+					}
+				})
+
+				AfterEach(func() {
+					close(unblock)
+				})
+
+				It("does not wait for the event to be saved", func() {
+					Expect(writtenBytes).To(Equal(len("hello")))
+					Expect(writeErr).ToNot(HaveOccurred())
+				})
+
+				Context("when the output fills up the buffer", func() {
+					It("blocks further writes until it has been saved", func() {
+						Eventually(fakeBuild.SaveEventCallCount).Should(Equal(1))
+
+						_, err := writer.Write(make([]byte, 128*1024))
+						Expect(err).ToNot(HaveOccurred())
+
+						written := make(chan struct{})
+						go func() {
+							defer GinkgoRecover()
+							_, err := writer.Write(make([]byte, 256*1024))
+							Expect(err).ToNot(HaveOccurred())
+							close(written)
+						}()
+
+						Consistently(written).ShouldNot(BeClosed())
+
+						unblock <- struct{}{}
+
+						Eventually(written).Should(BeClosed())
+					})
+				})
+			})
+		})
+
+		Describe("writing a secret that was resolved for the build", func() {
+			BeforeEach(func() {
+				_, found, err := delegate.Variables().Get(template.VariableDefinition{Name: "some-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("redacts the secret before saving the log event", func() {
+				writtenBytes, err := writer.Write([]byte("the secret is hunter2"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writtenBytes).To(Equal(len("the secret is hunter2")))
+
+				delegate.Flush(logger)
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Time:    123456789,
+					Payload: "the secret is ((redacted))",
+					Origin: event.Origin{
+						Source: event.OriginSourceStdout,
+						ID:     "some-plan-id",
+					},
+				}))
+			})
+
+			It("redacts the secret when it is split across writes", func() {
+				_, err := writer.Write([]byte("the secret is hun"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(fakeBuild.SaveEventCallCount).Should(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("the secret is "))
+
+				_, err = writer.Write([]byte("ter2, ok?"))
+				Expect(err).NotTo(HaveOccurred())
+
+				delegate.Flush(logger)
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventArgsForCall(1).(event.Log).Payload).To(Equal("((redacted)), ok?"))
+			})
+
+			It("saves what looked like the start of the secret once flushed", func() {
+				_, err := writer.Write([]byte("the secret is not hun"))
+				Expect(err).NotTo(HaveOccurred())
+
+				delegate.Flush(logger)
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("the secret is not "))
+				Expect(fakeBuild.SaveEventArgsForCall(1).(event.Log).Payload).To(Equal("hun"))
+			})
+		})
+	})
+
+	Context("when log sanitization is enabled", func() {
+		var writer io.Writer
+
+		BeforeEach(func() {
+			delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", variables, engine.NewLogSanitizer([]string{"sgr"}), fakeClock)
+			writer = delegate.Stdout()
+		})
+
+		It("sanitizes the output before saving the log event", func() {
+			writtenBytes, err := writer.Write([]byte("\x1b]0;title\x07\x1b[31mred\x1b[0m"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writtenBytes).To(Equal(len("\x1b]0;title\x07\x1b[31mred\x1b[0m")))
+
+			delegate.Flush(logger)
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("\x1b[31mred\x1b[0m"))
+		})
+
+		It("buffers incomplete escape sequences until they are written", func() {
+			_, err := writer.Write([]byte("\x1b[3"))
+			Expect(err).NotTo(HaveOccurred())
+			delegate.Flush(logger)
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(0))
+
+			_, err = writer.Write([]byte("2mgreen"))
+			Expect(err).NotTo(HaveOccurred())
+			delegate.Flush(logger)
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("\x1b[32mgreen"))
 		})
 	})
 
@@ -125,6 +310,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("saves a log event", func() {
+					delegate.Flush(logger)
+
 					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
 						Time:    123456789,
@@ -137,18 +324,69 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 			})
 
-			Context("when saving the event succeeds", func() {
+			Context("when saving the event fails", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
 					fakeBuild.SaveEventReturns(disaster)
 				})
 
-				It("returns 0 length, and the error", func() {
-					Expect(writtenBytes).To(Equal(0))
-					Expect(writeErr).To(Equal(disaster))
+				It("logs the error when flushing", func() {
+					Expect(writtenBytes).To(Equal(len("hello")))
+					Expect(writeErr).ToNot(HaveOccurred())
+
+					delegate.Flush(logger)
+
+					Expect(logger).To(gbytes.Say("failed-to-save-stderr"))
+				})
+
+				It("returns the error from a later write", func() {
+					Eventually(func() error {
+						_, err := writer.Write([]byte("hello again"))
+						return err
+					}).Should(Equal(disaster))
 				})
 			})
 		})
 	})
+
+	Describe("Errored", func() {
+		var buildErr atc.BuildError
+
+		BeforeEach(func() {
+			buildErr = atc.BuildError{
+				Code:    atc.BuildErrorCodeWorkerUnavailable,
+				Message: "worker 'some-worker' is unreachable (state is 'stalled')",
+				Step:    "some-task",
+				Worker:  "some-worker",
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate.Errored(lagertest.NewTestLogger("test"), buildErr)
+		})
+
+		It("saves the error on the build", func() {
+			Expect(fakeBuild.SaveErrorCallCount()).To(Equal(1))
+			savedErr, origin := fakeBuild.SaveErrorArgsForCall(0)
+			Expect(savedErr).To(Equal(buildErr))
+			Expect(origin).To(Equal(event.Origin{ID: "some-plan-id"}))
+		})
+	})
+
+	Describe("HookTimedOut", func() {
+		JustBeforeEach(func() {
+			delegate.HookTimedOut(lagertest.NewTestLogger("test"), "ensure", 5*time.Minute)
+		})
+
+		It("saves a hook-timeout event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.HookTimeout{
+				Time:    123456789,
+				Origin:  event.Origin{ID: "some-plan-id"},
+				Hook:    "ensure",
+				Timeout: "5m0s",
+			}))
+		})
+	})
 })
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"sort"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+// BuildTokens lazily issues a single token for the build the first time a
+// task asks for one, and revokes it once the build has finished.
+//
+// The token is redacted from the build's output. Its accessor is saved on the
+// build, so that tokens issued before the build was resumed by another ATC
+// are revoked too.
+type BuildTokens struct {
+	issuer    creds.BuildTokenIssuer
+	build     db.Build
+	variables *creds.TrackedVariables
+
+	lock  sync.Mutex
+	token *creds.BuildToken
+}
+
+func NewBuildTokens(issuer creds.BuildTokenIssuer, build db.Build, variables *creds.TrackedVariables) *BuildTokens {
+	return &BuildTokens{
+		issuer:    issuer,
+		build:     build,
+		variables: variables,
+	}
+}
+
+func (tokens *BuildTokens) Env(logger lager.Logger) ([]string, error) {
+	if tokens.issuer == nil {
+		return nil, nil
+	}
+
+	tokens.lock.Lock()
+	defer tokens.lock.Unlock()
+
+	if tokens.token == nil {
+		token, err := tokens.issuer.IssueBuildToken(
+			logger.Session("issue-build-token"),
+			tokens.build.TeamName(),
+			tokens.build.PipelineName(),
+			tokens.build.ID(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens.variables.Track(token.Secret)
+
+		err = tokens.build.SaveBuildTokenAccessor(token.Accessor)
+		if err != nil {
+			// this ATC still revokes the token, unless the build is resumed
+			// elsewhere, in which case it expires once its TTL elapses
+			logger.Error("failed-to-save-build-token-accessor", err)
+		}
+
+		tokens.token = &token
+	}
+
+	env := []string{}
+	for k, v := range tokens.token.Env {
+		env = append(env, k+"="+v)
+	}
+
+	sort.Strings(env)
+
+	return env, nil
+}
+
+func (tokens *BuildTokens) Revoke(logger lager.Logger) {
+	if tokens.issuer == nil {
+		return
+	}
+
+	tokens.lock.Lock()
+	defer tokens.lock.Unlock()
+
+	accessors, err := tokens.build.BuildTokenAccessors()
+	if err != nil {
+		logger.Error("failed-to-get-build-token-accessors", err)
+		accessors = []string{}
+	}
+
+	if tokens.token != nil && !containsAccessor(accessors, tokens.token.Accessor) {
+		accessors = append(accessors, tokens.token.Accessor)
+	}
+
+	for _, accessor := range accessors {
+		err := tokens.issuer.RevokeBuildToken(logger.Session("revoke-build-token"), creds.BuildToken{
+			Accessor: accessor,
+		})
+		if err != nil {
+			// the token will still expire on its own once its TTL elapses
+			logger.Error("failed-to-revoke-build-token", err)
+			continue
+		}
+
+		err = tokens.build.DeleteBuildTokenAccessor(accessor)
+		if err != nil {
+			logger.Error("failed-to-delete-build-token-accessor", err)
+		}
+	}
+
+	tokens.token = nil
+}
+
+func containsAccessor(accessors []string, accessor string) bool {
+	for _, a := range accessors {
+		if a == accessor {
+			return true
+		}
+	}
+
+	return false
+}
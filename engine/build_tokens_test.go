@@ -0,0 +1,163 @@
+package engine_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/engine"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildTokens", func() {
+	var (
+		fakeIssuer *credsfakes.FakeBuildTokenIssuer
+		fakeBuild  *dbfakes.FakeBuild
+		variables  *creds.TrackedVariables
+		logger     *lagertest.TestLogger
+
+		tokens *BuildTokens
+	)
+
+	BeforeEach(func() {
+		fakeIssuer = new(credsfakes.FakeBuildTokenIssuer)
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+
+		variables = creds.NewTrackedVariables(template.StaticVariables{})
+		logger = lagertest.NewTestLogger("test")
+
+		fakeIssuer.IssueBuildTokenReturns(creds.BuildToken{
+			Accessor: "some-accessor",
+			Secret:   "some-token",
+			Env: map[string]string{
+				"VAULT_TOKEN": "some-token",
+				"VAULT_ADDR":  "https://vault",
+			},
+		}, nil)
+
+		tokens = NewBuildTokens(fakeIssuer, fakeBuild, variables)
+	})
+
+	Describe("Env", func() {
+		It("issues a token scoped to the build", func() {
+			env, err := tokens.Env(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env).To(Equal([]string{"VAULT_ADDR=https://vault", "VAULT_TOKEN=some-token"}))
+
+			Expect(fakeIssuer.IssueBuildTokenCallCount()).To(Equal(1))
+			_, teamName, pipelineName, buildID := fakeIssuer.IssueBuildTokenArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(buildID).To(Equal(42))
+		})
+
+		It("redacts the token from the build's output", func() {
+			_, err := tokens.Env(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(variables.Redact("VAULT_TOKEN=some-token")).To(Equal("VAULT_TOKEN=((redacted))"))
+		})
+
+		It("saves the token's accessor on the build", func() {
+			_, err := tokens.Env(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuild.SaveBuildTokenAccessorCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveBuildTokenAccessorArgsForCall(0)).To(Equal("some-accessor"))
+		})
+
+		It("only issues one token per build", func() {
+			tokens.Env(logger)
+			tokens.Env(logger)
+			Expect(fakeIssuer.IssueBuildTokenCallCount()).To(Equal(1))
+		})
+
+		Context("when issuing fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeIssuer.IssueBuildTokenReturns(creds.BuildToken{}, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := tokens.Env(logger)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		Context("when there is no issuer", func() {
+			BeforeEach(func() {
+				tokens = NewBuildTokens(nil, fakeBuild, variables)
+			})
+
+			It("returns no env", func() {
+				env, err := tokens.Env(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Revoke", func() {
+		It("does nothing if no token was issued", func() {
+			tokens.Revoke(logger)
+			Expect(fakeIssuer.RevokeBuildTokenCallCount()).To(BeZero())
+		})
+
+		It("revokes the issued token", func() {
+			tokens.Env(logger)
+			tokens.Revoke(logger)
+
+			Expect(fakeIssuer.RevokeBuildTokenCallCount()).To(Equal(1))
+			_, token := fakeIssuer.RevokeBuildTokenArgsForCall(0)
+			Expect(token.Accessor).To(Equal("some-accessor"))
+
+			Expect(fakeBuild.DeleteBuildTokenAccessorCallCount()).To(Equal(1))
+			Expect(fakeBuild.DeleteBuildTokenAccessorArgsForCall(0)).To(Equal("some-accessor"))
+		})
+
+		It("revokes the issued token once, even though its accessor was saved", func() {
+			tokens.Env(logger)
+			fakeBuild.BuildTokenAccessorsReturns([]string{"some-accessor"}, nil)
+
+			tokens.Revoke(logger)
+			Expect(fakeIssuer.RevokeBuildTokenCallCount()).To(Equal(1))
+		})
+
+		Context("when the build was resumed after tokens were issued", func() {
+			BeforeEach(func() {
+				fakeBuild.BuildTokenAccessorsReturns([]string{"earlier-accessor"}, nil)
+			})
+
+			It("revokes the tokens issued before the build was resumed", func() {
+				tokens.Revoke(logger)
+
+				Expect(fakeIssuer.RevokeBuildTokenCallCount()).To(Equal(1))
+				_, token := fakeIssuer.RevokeBuildTokenArgsForCall(0)
+				Expect(token.Accessor).To(Equal("earlier-accessor"))
+
+				Expect(fakeBuild.DeleteBuildTokenAccessorCallCount()).To(Equal(1))
+				Expect(fakeBuild.DeleteBuildTokenAccessorArgsForCall(0)).To(Equal("earlier-accessor"))
+			})
+
+			Context("when revoking fails", func() {
+				BeforeEach(func() {
+					fakeIssuer.RevokeBuildTokenReturns(errors.New("nope"))
+				})
+
+				It("keeps the accessor", func() {
+					tokens.Revoke(logger)
+					Expect(fakeBuild.DeleteBuildTokenAccessorCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+})
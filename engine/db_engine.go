@@ -15,10 +15,14 @@ import (
 
 const trackLockDuration = time.Minute
 
-func NewDBEngine(engines Engines, peerURL string) Engine {
+// NewDBEngine constructs an Engine which tracks builds through the database,
+// running them with the first of the given engines. If streamer is non-nil,
+// each build's events are streamed to it as they are saved.
+func NewDBEngine(engines Engines, peerURL string, streamer BuildEventStreamer) Engine {
 	return &dbEngine{
 		engines:   engines,
 		peerURL:   peerURL,
+		streamer:  streamer,
 		releaseCh: make(chan struct{}),
 		waitGroup: new(sync.WaitGroup),
 	}
@@ -35,6 +39,7 @@ func (err UnknownEngineError) Error() string {
 type dbEngine struct {
 	engines   Engines
 	peerURL   string
+	streamer  BuildEventStreamer
 	releaseCh chan struct{}
 	waitGroup *sync.WaitGroup
 }
@@ -46,6 +51,8 @@ func (*dbEngine) Name() string {
 func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (Build, error) {
 	buildEngine := engine.engines[0]
 
+	build = engine.streamed(build)
+
 	createdBuild, err := buildEngine.CreateBuild(logger, build, plan)
 	if err != nil {
 		return nil, err
@@ -70,6 +77,8 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 }
 
 func (engine *dbEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
+	build = engine.streamed(build)
+
 	return &dbBuild{
 		engines:   engine.engines,
 		peerURL:   engine.peerURL,
@@ -95,6 +104,17 @@ func (engine *dbEngine) ReleaseAll(logger lager.Logger) {
 	logger.Info("finished-waiting-on-builds")
 }
 
+func (engine *dbEngine) streamed(build db.Build) db.Build {
+	if engine.streamer == nil {
+		return build
+	}
+
+	return streamingBuild{
+		Build:    build,
+		streamer: engine.streamer,
+	}
+}
+
 type dbBuild struct {
 	engines   Engines
 	peerURL   string
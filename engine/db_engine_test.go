@@ -43,7 +43,7 @@ var _ = Describe("DBEngine", func() {
 		dbBuild = new(dbfakes.FakeBuild)
 		dbBuild.IDReturns(128)
 
-		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB}, "http://10.2.3.4:8080")
+		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB}, "http://10.2.3.4:8080", nil)
 	})
 
 	Describe("CreateBuild", func() {
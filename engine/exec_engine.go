@@ -7,12 +7,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
 )
 
 type execMetadata struct {
@@ -25,20 +27,25 @@ type execEngine struct {
 	factory         exec.Factory
 	delegateFactory BuildDelegateFactory
 	externalURL     string
+	hookTimeout     time.Duration
 
 	releaseCh     chan struct{}
 	trackedStates *sync.Map
 }
 
+// NewExecEngine constructs an Engine which runs builds in-process. If
+// hookTimeout is non-zero, hooks which run for longer are interrupted.
 func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	externalURL string,
+	hookTimeout time.Duration,
 ) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		externalURL:     externalURL,
+		hookTimeout:     hookTimeout,
 
 		releaseCh:     make(chan struct{}),
 		trackedStates: new(sync.Map),
@@ -59,6 +66,9 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
+
+		hookTimeout: engine.hookTimeout,
+
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -88,6 +98,9 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
+
+		hookTimeout: engine.hookTimeout,
+
 		metadata: metadata,
 
 		ctx:    ctx,
@@ -121,6 +134,8 @@ type execBuild struct {
 	factory  exec.Factory
 	delegate BuildDelegate
 
+	hookTimeout time.Duration
+
 	ctx    context.Context
 	cancel func()
 
@@ -149,12 +164,22 @@ func (build *execBuild) Resume(logger lager.Logger) {
 
 	runCtx := lagerctx.NewContext(build.ctx, logger)
 
+	span, runCtx := tracing.StartSpan(runCtx, "build", tracing.Attrs{
+		"team":     build.dbBuild.TeamName(),
+		"pipeline": build.dbBuild.PipelineName(),
+		"job":      build.dbBuild.JobName(),
+		"build":    build.dbBuild.Name(),
+		"build-id": strconv.Itoa(build.dbBuild.ID()),
+	})
+
 	state := build.runState()
 	defer build.clearRunState()
 
 	done := make(chan error, 1)
 	go func() {
-		done <- step.Run(runCtx, state)
+		err := step.Run(runCtx, state)
+		tracing.End(span, err)
+		done <- err
 	}()
 
 	for {
@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -28,43 +29,74 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	variablesFactory   creds.VariablesFactory
+	buildTokenIssuer   creds.BuildTokenIssuer
+	logSanitizer       *LogSanitizer
+	containerRetention db.ContainerRetentionPolicy
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+// NewBuildDelegateFactory constructs a BuildDelegateFactory. Once a build
+// finishes, its delegate records until when the build's containers are
+// retained, according to the container retention policy.
+func NewBuildDelegateFactory(variablesFactory creds.VariablesFactory, buildTokenIssuer creds.BuildTokenIssuer, logSanitizer *LogSanitizer, containerRetention db.ContainerRetentionPolicy) BuildDelegateFactory {
+	return buildDelegateFactory{
+		variablesFactory:   variablesFactory,
+		buildTokenIssuer:   buildTokenIssuer,
+		logSanitizer:       logSanitizer,
+		containerRetention: containerRetention,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	variables := creds.NewTrackedVariables(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
+
+	return newBuildDelegate(
+		build,
+		variables,
+		factory.logSanitizer,
+		NewBuildTokens(factory.buildTokenIssuer, build, variables),
+		factory.containerRetention,
+	)
 }
 
 type delegate struct {
-	build db.Build
+	build              db.Build
+	variables          *creds.TrackedVariables
+	sanitizer          *LogSanitizer
+	tokens             *BuildTokens
+	containerRetention db.ContainerRetentionPolicy
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, variables *creds.TrackedVariables, sanitizer *LogSanitizer, tokens *BuildTokens, containerRetention db.ContainerRetentionPolicy) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:              build,
+		variables:          variables,
+		sanitizer:          sanitizer,
+		tokens:             tokens,
+		containerRetention: containerRetention,
 	}
 }
 
 func (delegate *delegate) GetDelegate(planID atc.PlanID) exec.GetDelegate {
-	return NewGetDelegate(delegate.build, planID, clock.NewClock())
+	return NewGetDelegate(delegate.build, planID, delegate.variables, delegate.sanitizer, clock.NewClock())
 }
 
 func (delegate *delegate) PutDelegate(planID atc.PlanID) exec.PutDelegate {
-	return NewPutDelegate(delegate.build, planID, clock.NewClock())
+	return NewPutDelegate(delegate.build, planID, delegate.variables, delegate.sanitizer, clock.NewClock())
 }
 
 func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
+	return NewTaskDelegate(delegate.build, planID, delegate.variables, delegate.sanitizer, delegate.tokens, clock.NewClock())
 }
 
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
-	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+	return NewBuildStepDelegate(delegate.build, planID, delegate.variables, delegate.sanitizer, clock.NewClock())
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool) {
+	delegate.tokens.Revoke(logger)
+
 	if err == context.Canceled {
 		delegate.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
@@ -84,5 +116,11 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 	err := delegate.build.Finish(db.BuildStatus(status))
 	if err != nil {
 		logger.Error("failed-to-finish-build", err)
+		return
+	}
+
+	err = delegate.build.RetainContainers(delegate.containerRetention)
+	if err != nil {
+		logger.Error("failed-to-retain-containers", err)
 	}
 }
@@ -3,8 +3,10 @@ package engine_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
@@ -25,7 +27,10 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		factory = NewBuildDelegateFactory(new(credsfakes.FakeVariablesFactory), nil, nil, db.ContainerRetentionPolicy{
+			Default: db.ContainerRetention{Success: time.Minute},
+			Max:     db.ContainerRetention{Failure: time.Hour},
+		})
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		delegate = factory.Delegate(fakeBuild)
@@ -55,5 +60,32 @@ var _ = Describe("BuildDelegate", func() {
 				Expect(finishedStatus).To(Equal(db.BuildStatusErrored))
 			})
 		})
+
+		Context("when the build succeeded", func() {
+			BeforeEach(func() {
+				delegate.Finish(logger, nil, true)
+			})
+
+			It("records the retention of the build's containers once it has finished", func() {
+				Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+
+				Expect(fakeBuild.RetainContainersCallCount()).To(Equal(1))
+				Expect(fakeBuild.RetainContainersArgsForCall(0)).To(Equal(db.ContainerRetentionPolicy{
+					Default: db.ContainerRetention{Success: time.Minute},
+					Max:     db.ContainerRetention{Failure: time.Hour},
+				}))
+			})
+		})
+
+		Context("when finishing the build fails", func() {
+			BeforeEach(func() {
+				fakeBuild.FinishReturns(errors.New("disaster"))
+				delegate.Finish(logger, nil, true)
+			})
+
+			It("does not record the retention of its containers", func() {
+				Expect(fakeBuild.RetainContainersCallCount()).To(BeZero())
+			})
+		})
 	})
 })
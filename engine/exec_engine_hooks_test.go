@@ -1,12 +1,16 @@
 package engine_test
 
 import (
+	"context"
+	"time"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -41,6 +45,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			"http://example.com",
+			0,
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
@@ -174,7 +179,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, plan, dbBuild, containerMetadata, _ := fakeFactory.TaskArgsForCall(2)
+					logger, plan, dbBuild, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(2)
 					Expect(logger).NotTo(BeNil())
 					Expect(dbBuild).To(Equal(build))
 					Expect(plan).To(Equal(completionTaskPlan))
@@ -192,7 +197,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, plan, dbBuild, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+					logger, plan, dbBuild, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(dbBuild).To(Equal(build))
 					Expect(plan).To(Equal(failureTaskPlan))
@@ -210,7 +215,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, plan, dbBuild, containerMetadata, _ := fakeFactory.TaskArgsForCall(1)
+					logger, plan, dbBuild, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(dbBuild).To(Equal(build))
 					Expect(plan).To(Equal(successTaskPlan))
@@ -228,7 +233,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, plan, dbBuild, containerMetadata, _ := fakeFactory.TaskArgsForCall(3)
+					logger, plan, dbBuild, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(3)
 					Expect(logger).NotTo(BeNil())
 					Expect(dbBuild).To(Equal(build))
 					Expect(plan).To(Equal(nextTaskPlan))
@@ -437,5 +442,85 @@ var _ = Describe("Exec Engine With Hooks", func() {
 				Expect(outputStep.RunCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when hooks have a timeout", func() {
+			var (
+				planFactory      atc.PlanFactory
+				fakeStepDelegate *execfakes.FakeBuildStepDelegate
+			)
+
+			BeforeEach(func() {
+				planFactory = atc.NewPlanFactory(123)
+
+				execEngine = engine.NewExecEngine(
+					fakeFactory,
+					fakeDelegateFactory,
+					"http://example.com",
+					10*time.Millisecond,
+				)
+
+				fakeStepDelegate = new(execfakes.FakeBuildStepDelegate)
+				fakeDelegate.BuildStepDelegateReturns(fakeStepDelegate)
+
+				taskStep.RunStub = func(ctx context.Context, state exec.RunState) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+			})
+
+			It("interrupts a hook which runs for longer and records that it timed out", func() {
+				hookPlan := planFactory.NewPlan(atc.TaskPlan{
+					Name:   "some-hanging-hook",
+					Config: &atc.TaskConfig{},
+				})
+
+				plan := planFactory.NewPlan(atc.EnsurePlan{
+					Step: planFactory.NewPlan(atc.GetPlan{
+						Name: "some-input",
+					}),
+					Next: hookPlan,
+				})
+
+				build, err := execEngine.CreateBuild(logger, build, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Expect(taskStep.RunCallCount()).To(Equal(1))
+
+				Expect(fakeDelegate.BuildStepDelegateCallCount()).To(Equal(1))
+				Expect(fakeDelegate.BuildStepDelegateArgsForCall(0)).To(Equal(hookPlan.ID))
+
+				Expect(fakeStepDelegate.HookTimedOutCallCount()).To(Equal(1))
+				_, hook, timeout := fakeStepDelegate.HookTimedOutArgsForCall(0)
+				Expect(hook).To(Equal("ensure"))
+				Expect(timeout).To(Equal(10 * time.Millisecond))
+
+				Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+				_, _, succeeded := fakeDelegate.FinishArgsForCall(0)
+				Expect(succeeded).To(BeFalse())
+			})
+
+			It("does not limit the get following a put", func() {
+				plan := planFactory.NewPlan(atc.OnSuccessPlan{
+					Step: planFactory.NewPlan(atc.PutPlan{
+						Name: "some-put",
+					}),
+					Next: planFactory.NewPlan(atc.GetPlan{
+						Name: "some-put",
+					}),
+				})
+
+				build, err := execEngine.CreateBuild(logger, build, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Expect(inputStep.RunCallCount()).To(Equal(1))
+				runCtx, _ := inputStep.RunArgsForCall(0)
+				_, hasDeadline := runCtx.Deadline()
+				Expect(hasDeadline).To(BeFalse())
+			})
+		})
 	})
 })
@@ -36,6 +36,7 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			"http://example.com",
+			0,
 		)
 	})
 
@@ -280,9 +281,10 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
+				Expect(stepMetadata).To(Equal(expectedMetadata))
 				expectedPlan := taskPlan
 				expectedPlan.Attempts = []int{2, 1}
 				Expect(plan).To(Equal(expectedPlan))
@@ -298,7 +300,7 @@ var _ = Describe("ExecEngine", func() {
 					Attempt:      "2.1",
 				}))
 
-				logger, plan, build, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				logger, plan, build, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan = taskPlan
@@ -367,15 +369,15 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
 			})
 		})
@@ -453,7 +455,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-					logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+					logger, plan, build, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
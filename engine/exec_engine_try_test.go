@@ -42,6 +42,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			"http://example.com",
+			0,
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
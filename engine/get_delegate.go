@@ -5,21 +5,22 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type getDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.GetDelegate {
+func NewGetDelegate(build db.Build, planID atc.PlanID, variables *creds.TrackedVariables, sanitizer *LogSanitizer, clock clock.Clock) exec.GetDelegate {
 	return &getDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, variables, sanitizer, clock),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -29,6 +30,8 @@ func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.G
 }
 
 func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishGet{
 		Origin:          d.eventOrigin,
 		ExitStatus:      int(exitStatus),
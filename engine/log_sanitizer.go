@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+const (
+	LogSequenceSGR    = "sgr"
+	LogSequenceErase  = "erase"
+	LogSequenceCursor = "cursor"
+)
+
+type LogSanitizerConfig struct {
+	Enabled          bool     `long:"log-sanitization-enabled" description:"Strip control sequences and invalid UTF-8 from build logs before they are saved."`
+	AllowedSequences []string `long:"log-sanitization-allow"   default:"sgr" choice:"sgr" choice:"erase" choice:"cursor" description:"Escape sequences to keep in sanitized build logs. Can be specified multiple times."`
+}
+
+// sequences longer than this which have not yet terminated are assumed to be
+// garbage rather than waiting for the rest of them to be written
+const maxPendingSequenceLength = 256
+
+const esc = 0x1b
+
+// A LogSanitizer strips control characters and escape sequences from build
+// output, keeping only the CSI sequences in its allow-list (e.g. SGR colors),
+// and replaces invalid UTF-8 with the unicode replacement character.
+type LogSanitizer struct {
+	allowedFinals map[byte]bool
+}
+
+func NewLogSanitizer(allowedSequences []string) *LogSanitizer {
+	finals := map[byte]bool{}
+
+	for _, sequence := range allowedSequences {
+		switch sequence {
+		case LogSequenceSGR:
+			finals['m'] = true
+		case LogSequenceErase:
+			finals['J'] = true
+			finals['K'] = true
+		case LogSequenceCursor:
+			for final := byte('A'); final <= 'H'; final++ {
+				finals[final] = true
+			}
+		}
+	}
+
+	return &LogSanitizer{
+		allowedFinals: finals,
+	}
+}
+
+// Sanitize returns the sanitized text, along with any trailing escape
+// sequence which has not yet terminated. The remainder should be prepended to
+// the next chunk of output.
+func (sanitizer *LogSanitizer) Sanitize(text []byte) (string, []byte) {
+	sanitized := bytes.NewBuffer(make([]byte, 0, len(text)))
+
+	for i := 0; i < len(text); {
+		if text[i] == esc {
+			length, complete := escapeSequenceLength(text[i:])
+			if !complete {
+				if len(text)-i <= maxPendingSequenceLength {
+					return sanitized.String(), append([]byte{}, text[i:]...)
+				}
+
+				i++
+				continue
+			}
+
+			sequence := text[i : i+length]
+			if sanitizer.allowed(sequence) {
+				sanitized.Write(sequence)
+			}
+
+			i += length
+			continue
+		}
+
+		r, size := utf8.DecodeRune(text[i:])
+		if r == utf8.RuneError && size == 1 {
+			sanitized.WriteRune(utf8.RuneError)
+		} else if !isControl(r) {
+			sanitized.Write(text[i : i+size])
+		}
+
+		i += size
+	}
+
+	return sanitized.String(), nil
+}
+
+func (sanitizer *LogSanitizer) allowed(sequence []byte) bool {
+	if len(sequence) < 3 || sequence[1] != '[' {
+		return false
+	}
+
+	final := sequence[len(sequence)-1]
+	if !sanitizer.allowedFinals[final] {
+		return false
+	}
+
+	for _, b := range sequence[2 : len(sequence)-1] {
+		if !(b >= '0' && b <= '9') && b != ';' && b != ':' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isControl(r rune) bool {
+	switch r {
+	case '\n', '\r', '\t':
+		return false
+	}
+
+	return r < 0x20 || (r >= 0x7f && r <= 0x9f)
+}
+
+// escapeSequenceLength returns the length of the escape sequence at the start
+// of text, and whether the sequence is complete.
+func escapeSequenceLength(text []byte) (int, bool) {
+	if len(text) < 2 {
+		return 0, false
+	}
+
+	switch text[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes followed by a final byte
+		for i := 2; i < len(text); i++ {
+			switch {
+			case text[i] >= 0x40 && text[i] <= 0x7e:
+				return i + 1, true
+			case text[i] >= 0x20 && text[i] <= 0x3f:
+				continue
+			default:
+				// malformed; drop what we have so far
+				return i, true
+			}
+		}
+
+		return 0, false
+
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM, APC: a string terminated by BEL or ST
+		for i := 2; i < len(text); i++ {
+			if text[i] == 0x07 {
+				return i + 1, true
+			}
+
+			if text[i] == esc && i+1 < len(text) && text[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+
+		return 0, false
+
+	default:
+		// nF sequences have intermediate bytes before their final byte; all
+		// others are a single byte following ESC
+		for i := 1; i < len(text); i++ {
+			if text[i] < 0x20 || text[i] > 0x2f {
+				return i + 1, true
+			}
+		}
+
+		return 0, false
+	}
+}
@@ -0,0 +1,68 @@
+package engine_test
+
+import (
+	"github.com/concourse/atc/engine"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogSanitizer", func() {
+	var sanitizer *engine.LogSanitizer
+
+	BeforeEach(func() {
+		sanitizer = engine.NewLogSanitizer([]string{engine.LogSequenceSGR})
+	})
+
+	sanitize := func(text string) (string, string) {
+		sanitized, rest := sanitizer.Sanitize([]byte(text))
+		return sanitized, string(rest)
+	}
+
+	It("keeps plain text, newlines, and tabs", func() {
+		sanitized, rest := sanitize("hello\tworld\r\n")
+		Expect(sanitized).To(Equal("hello\tworld\r\n"))
+		Expect(rest).To(BeEmpty())
+	})
+
+	It("keeps allowed sequences", func() {
+		sanitized, _ := sanitize("\x1b[1;31mred\x1b[0m")
+		Expect(sanitized).To(Equal("\x1b[1;31mred\x1b[0m"))
+	})
+
+	It("strips sequences which are not allowed", func() {
+		sanitized, _ := sanitize("\x1b[2Jcleared\x1b[?25l\x1b]0;title\x07done\x1b(B")
+		Expect(sanitized).To(Equal("cleareddone"))
+	})
+
+	It("strips other control characters", func() {
+		sanitized, _ := sanitize("bell\x07 back\x08space\x7f\u009b")
+		Expect(sanitized).To(Equal("bell backspace"))
+	})
+
+	It("replaces invalid UTF-8", func() {
+		sanitized, _ := sanitize("bad \xff byte")
+		Expect(sanitized).To(Equal("bad � byte"))
+	})
+
+	It("returns incomplete sequences to be prepended to the next write", func() {
+		sanitized, rest := sanitize("hello \x1b[3")
+		Expect(sanitized).To(Equal("hello "))
+		Expect(rest).To(Equal("\x1b[3"))
+
+		sanitized, rest = sanitize(rest + "2mgreen")
+		Expect(sanitized).To(Equal("\x1b[32mgreen"))
+		Expect(rest).To(BeEmpty())
+	})
+
+	Context("when more sequences are allowed", func() {
+		BeforeEach(func() {
+			sanitizer = engine.NewLogSanitizer([]string{engine.LogSequenceSGR, engine.LogSequenceErase})
+		})
+
+		It("keeps them", func() {
+			sanitized, _ := sanitize("\x1b[2K\x1b[32mok")
+			Expect(sanitized).To(Equal("\x1b[2K\x1b[32mok"))
+		})
+	})
+})
@@ -5,21 +5,22 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type putDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.PutDelegate {
+func NewPutDelegate(build db.Build, planID atc.PlanID, variables *creds.TrackedVariables, sanitizer *LogSanitizer, clock clock.Clock) exec.PutDelegate {
 	return &putDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, variables, sanitizer, clock),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -29,6 +30,8 @@ func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.P
 }
 
 func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishPut{
 		Origin:          d.eventOrigin,
 		ExitStatus:      int(exitStatus),
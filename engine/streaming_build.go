@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+//go:generate counterfeiter . BuildEventStreamer
+
+// BuildEventStreamer is given the events of the builds tracked by the db engine
+// as they are saved, e.g. to tee them to a log aggregation system. It must not
+// block.
+type BuildEventStreamer interface {
+	Stream(db.Build, atc.Event)
+}
+
+// streamingBuild passes each event saved for the build on to the streamer
+// once it has been saved.
+type streamingBuild struct {
+	db.Build
+
+	streamer BuildEventStreamer
+}
+
+func (build streamingBuild) SaveEvent(ev atc.Event) error {
+	err := build.Build.SaveEvent(ev)
+	if err != nil {
+		return err
+	}
+
+	build.streamer.Stream(build.Build, ev)
+
+	return nil
+}
+
+func (build streamingBuild) SaveError(buildErr atc.BuildError, origin event.Origin) error {
+	err := build.Build.SaveError(buildErr, origin)
+	if err != nil {
+		return err
+	}
+
+	build.streamer.Stream(build.Build, event.Error{
+		Message: buildErr.Message,
+		Code:    buildErr.Code,
+		Step:    buildErr.Step,
+		Worker:  buildErr.Worker,
+		Origin:  origin,
+	})
+
+	return nil
+}
+
+func (build streamingBuild) Start(engine, metadata string, plan atc.Plan) (bool, error) {
+	started, err := build.Build.Start(engine, metadata, plan)
+	if err != nil || !started {
+		return started, err
+	}
+
+	build.streamer.Stream(build.Build, event.Status{
+		Status: atc.StatusStarted,
+		Time:   time.Now().Unix(),
+	})
+
+	return true, nil
+}
+
+func (build streamingBuild) FinishWithError(cause error) error {
+	err := build.Build.FinishWithError(cause)
+	if err != nil {
+		return err
+	}
+
+	build.streamer.Stream(build.Build, event.Error{
+		Message: cause.Error(),
+		Code:    atc.BuildErrorCodeInternal,
+	})
+
+	build.streamFinish(db.BuildStatusErrored)
+
+	return nil
+}
+
+func (build streamingBuild) Finish(status db.BuildStatus) error {
+	err := build.Build.Finish(status)
+	if err != nil {
+		return err
+	}
+
+	build.streamFinish(status)
+
+	return nil
+}
+
+func (build streamingBuild) streamFinish(status db.BuildStatus) {
+	build.streamer.Stream(build.Build, event.Status{
+		Status: atc.BuildStatus(status),
+		Time:   time.Now().Unix(),
+	})
+}
@@ -0,0 +1,160 @@
+package engine_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Streaming build events", func() {
+	var (
+		fakeEngine   *enginefakes.FakeEngine
+		fakeStreamer *enginefakes.FakeBuildEventStreamer
+		logger       *lagertest.TestLogger
+
+		dbBuild *dbfakes.FakeBuild
+
+		streamedBuild db.Build
+	)
+
+	BeforeEach(func() {
+		fakeEngine = new(enginefakes.FakeEngine)
+		fakeEngine.NameReturns("fake-engine")
+		fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+
+		fakeStreamer = new(enginefakes.FakeBuildEventStreamer)
+		logger = lagertest.NewTestLogger("test")
+
+		dbBuild = new(dbfakes.FakeBuild)
+		dbBuild.IDReturns(42)
+	})
+
+	JustBeforeEach(func() {
+		dbEngine := engine.NewDBEngine(engine.Engines{fakeEngine}, "http://10.2.3.4:8080", fakeStreamer)
+
+		_, err := dbEngine.CreateBuild(logger, dbBuild, atc.Plan{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+		_, streamedBuild, _ = fakeEngine.CreateBuildArgsForCall(0)
+	})
+
+	Describe("starting the build", func() {
+		Context("when the build starts", func() {
+			BeforeEach(func() {
+				dbBuild.StartReturns(true, nil)
+			})
+
+			It("streams the started status", func() {
+				Expect(dbBuild.StartCallCount()).To(Equal(1))
+
+				Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+				build, streamed := fakeStreamer.StreamArgsForCall(0)
+				Expect(build).To(Equal(dbBuild))
+				Expect(streamed).To(BeAssignableToTypeOf(event.Status{}))
+				Expect(streamed.(event.Status).Status).To(Equal(atc.StatusStarted))
+			})
+		})
+
+		Context("when the build does not start", func() {
+			It("does not stream anything", func() {
+				Expect(dbBuild.StartCallCount()).To(Equal(1))
+				Expect(fakeStreamer.StreamCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("saving an event", func() {
+		var ev atc.Event
+
+		BeforeEach(func() {
+			ev = event.Log{Payload: "some-output"}
+		})
+
+		It("saves the event and then streams it", func() {
+			err := streamedBuild.SaveEvent(ev)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dbBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(dbBuild.SaveEventArgsForCall(0)).To(Equal(ev))
+
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+			build, streamed := fakeStreamer.StreamArgsForCall(0)
+			Expect(build).To(Equal(dbBuild))
+			Expect(streamed).To(Equal(ev))
+		})
+
+		Context("when saving the event fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				dbBuild.SaveEventReturns(disaster)
+			})
+
+			It("returns the error without streaming the event", func() {
+				err := streamedBuild.SaveEvent(ev)
+				Expect(err).To(Equal(disaster))
+
+				Expect(fakeStreamer.StreamCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("saving an error", func() {
+		It("streams it as an error event", func() {
+			err := streamedBuild.SaveError(atc.BuildError{Message: "boom", Step: "some-step"}, event.Origin{ID: "some-origin"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dbBuild.SaveErrorCallCount()).To(Equal(1))
+
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+			_, streamed := fakeStreamer.StreamArgsForCall(0)
+			Expect(streamed).To(Equal(event.Error{
+				Message: "boom",
+				Step:    "some-step",
+				Origin:  event.Origin{ID: "some-origin"},
+			}))
+		})
+	})
+
+	Describe("finishing the build", func() {
+		It("streams the status", func() {
+			err := streamedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dbBuild.FinishCallCount()).To(Equal(1))
+
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+			_, streamed := fakeStreamer.StreamArgsForCall(0)
+			Expect(streamed).To(BeAssignableToTypeOf(event.Status{}))
+			Expect(streamed.(event.Status).Status).To(Equal(atc.StatusSucceeded))
+		})
+
+		Context("with an error", func() {
+			It("streams the error and then the errored status", func() {
+				err := streamedBuild.FinishWithError(errors.New("oh no"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStreamer.StreamCallCount()).To(Equal(2))
+
+				_, streamed := fakeStreamer.StreamArgsForCall(0)
+				Expect(streamed).To(Equal(event.Error{
+					Message: "oh no",
+					Code:    atc.BuildErrorCodeInternal,
+				}))
+
+				_, streamed = fakeStreamer.StreamArgsForCall(1)
+				Expect(streamed.(event.Status).Status).To(Equal(atc.StatusErrored))
+			})
+		})
+	})
+})
@@ -7,29 +7,36 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
+	tokens      *BuildTokens
 }
 
-func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.TaskDelegate {
+func NewTaskDelegate(build db.Build, planID atc.PlanID, variables *creds.TrackedVariables, sanitizer *LogSanitizer, tokens *BuildTokens, clock clock.Clock) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, variables, sanitizer, clock),
 
-		build: build,
+		build:  build,
+		tokens: tokens,
 		eventOrigin: event.Origin{
 			ID: event.OriginID(planID),
 		},
 	}
 }
 
+func (d *taskDelegate) BuildTokenEnv(logger lager.Logger) ([]string, error) {
+	return d.tokens.Env(logger)
+}
+
 func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConfig) {
 	err := d.build.SaveEvent(event.InitializeTask{
 		Origin:     d.eventOrigin,
@@ -59,6 +66,8 @@ func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig)
 }
 
 func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       time.Now().Unix(),
@@ -337,6 +337,14 @@ type ErrorV30 struct {
 func (ErrorV30) EventType() atc.EventType  { return "error" }
 func (ErrorV30) Version() atc.EventVersion { return "3.0" }
 
+type ErrorV40 struct {
+	Message string `json:"message"`
+	Origin  Origin `json:"origin,omitempty"`
+}
+
+func (ErrorV40) EventType() atc.EventType  { return "error" }
+func (ErrorV40) Version() atc.EventVersion { return "4.0" }
+
 type FinishTaskV30 struct {
 	Time       int64     `json:"time"`
 	ExitStatus int       `json:"exit_status"`
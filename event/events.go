@@ -3,12 +3,15 @@ package event
 import "github.com/concourse/atc"
 
 type Error struct {
-	Message string `json:"message"`
-	Origin  Origin `json:"origin,omitempty"`
+	Message string             `json:"message"`
+	Code    atc.BuildErrorCode `json:"code,omitempty"`
+	Step    string             `json:"step,omitempty"`
+	Worker  string             `json:"worker,omitempty"`
+	Origin  Origin             `json:"origin,omitempty"`
 }
 
 func (Error) EventType() atc.EventType  { return EventTypeError }
-func (Error) Version() atc.EventVersion { return "4.0" }
+func (Error) Version() atc.EventVersion { return "4.1" }
 
 type FinishTask struct {
 	Time       int64  `json:"time"`
@@ -82,6 +85,10 @@ func (StartTask) Version() atc.EventVersion { return "5.0" }
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
+
+	// given when the build was aborted through the API
+	AbortReason string `json:"abort_reason,omitempty"`
+	AbortedBy   string `json:"aborted_by,omitempty"`
 }
 
 func (Status) EventType() atc.EventType  { return EventTypeStatus }
@@ -110,6 +117,25 @@ const (
 	OriginSourceStderr OriginSource = "stderr"
 )
 
+type ImageCheck struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Type   string `json:"type"`
+}
+
+func (ImageCheck) EventType() atc.EventType  { return EventTypeImageCheck }
+func (ImageCheck) Version() atc.EventVersion { return "1.0" }
+
+type ImageGet struct {
+	Time         int64       `json:"time"`
+	Origin       Origin      `json:"origin"`
+	Type         string      `json:"type"`
+	ImageVersion atc.Version `json:"version"`
+}
+
+func (ImageGet) EventType() atc.EventType  { return EventTypeImageGet }
+func (ImageGet) Version() atc.EventVersion { return "1.0" }
+
 type FinishGet struct {
 	Origin          Origin              `json:"origin"`
 	ExitStatus      int                 `json:"exit_status"`
@@ -129,3 +155,13 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.0" }
+
+type HookTimeout struct {
+	Time    int64  `json:"time"`
+	Origin  Origin `json:"origin"`
+	Hook    string `json:"hook"`
+	Timeout string `json:"timeout"`
+}
+
+func (HookTimeout) EventType() atc.EventType  { return EventTypeHookTimeout }
+func (HookTimeout) Version() atc.EventVersion { return "1.0" }
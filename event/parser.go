@@ -38,9 +38,12 @@ func init() {
 	registerEvent(FinishTask{})
 	registerEvent(FinishGet{})
 	registerEvent(FinishPut{})
+	registerEvent(HookTimeout{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(ImageCheck{})
+	registerEvent(ImageGet{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
@@ -53,6 +56,7 @@ func init() {
 	registerEvent(ErrorV10{})
 	registerEvent(ErrorV20{})
 	registerEvent(ErrorV30{})
+	registerEvent(ErrorV40{})
 	registerEvent(FinishTaskV10{})
 	registerEvent(FinishTaskV20{})
 	registerEvent(FinishTaskV30{})
@@ -27,6 +27,15 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// checking for the latest version of a step's image
+	EventTypeImageCheck atc.EventType = "image-check"
+
+	// fetching a step's image
+	EventTypeImageGet atc.EventType = "image-get"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// an ensure, on_success or on_failure hook timed out
+	EventTypeHookTimeout atc.EventType = "hook-timeout"
 )
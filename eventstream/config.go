@@ -0,0 +1,44 @@
+package eventstream
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Config configures streaming build events to external sinks as they are
+// saved. Streaming is disabled unless a sink is given.
+type Config struct {
+	SyslogAddress   string        `long:"syslog-address"   description:"Address of a syslog server to stream build events to."`
+	SyslogTransport string        `long:"syslog-transport" default:"tcp" choice:"tcp" choice:"udp" description:"Transport to reach the syslog server over."`
+	SyslogTag       string        `long:"syslog-tag"       default:"concourse" description:"Tag to give syslog messages."`
+	HTTPURL         string        `long:"http-url"         description:"URL to POST each build event to as JSON."`
+	HTTPTimeout     time.Duration `long:"http-timeout"     default:"10s" description:"Timeout for each POST to the HTTP sink."`
+	BufferSize      int           `long:"buffer-size"      default:"1000" description:"Number of events to buffer for each sink while it catches up."`
+	DropPolicy      DropPolicy    `long:"drop-policy"      default:"drop-newest" choice:"drop-newest" choice:"drop-oldest" description:"Which events to drop once a sink's buffer is full."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.SyslogAddress != "" || config.HTTPURL != ""
+}
+
+// NewStreamer returns the streamer for the config, or nil if it is not
+// configured.
+func (config Config) NewStreamer(logger lager.Logger) *Streamer {
+	if !config.IsConfigured() {
+		return nil
+	}
+
+	sinks := map[string]Sink{}
+
+	if config.SyslogAddress != "" {
+		sinks["syslog"] = NewSyslogSink(config.SyslogTransport, config.SyslogAddress, config.SyslogTag)
+	}
+
+	if config.HTTPURL != "" {
+		sinks["http"] = NewHTTPSink(&http.Client{Timeout: config.HTTPTimeout}, config.HTTPURL)
+	}
+
+	return NewStreamer(logger, config.BufferSize, config.DropPolicy, sinks)
+}
@@ -0,0 +1,84 @@
+package eventstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"sync"
+)
+
+// NewSyslogSink returns a Sink which writes each event to a syslog server as
+// a JSON message. It does not connect until the first event is sent, so that
+// the server being unavailable does not prevent the ATC from starting.
+func NewSyslogSink(transport string, address string, tag string) Sink {
+	return &syslogSink{
+		transport: transport,
+		address:   address,
+		tag:       tag,
+	}
+}
+
+type syslogSink struct {
+	transport string
+	address   string
+	tag       string
+
+	writerL sync.Mutex
+	writer  *syslog.Writer
+}
+
+func (sink *syslogSink) Send(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	sink.writerL.Lock()
+	defer sink.writerL.Unlock()
+
+	if sink.writer == nil {
+		writer, err := syslog.Dial(sink.transport, sink.address, syslog.LOG_INFO|syslog.LOG_USER, sink.tag)
+		if err != nil {
+			return err
+		}
+
+		sink.writer = writer
+	}
+
+	return sink.writer.Info(string(payload))
+}
+
+// NewHTTPSink returns a Sink which POSTs each event to the URL as JSON.
+func NewHTTPSink(client *http.Client, url string) Sink {
+	return &httpSink{
+		client: client,
+		url:    url,
+	}
+}
+
+type httpSink struct {
+	client *http.Client
+	url    string
+}
+
+func (sink *httpSink) Send(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	response, err := sink.client.Post(sink.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", response.Status)
+	}
+
+	return nil
+}
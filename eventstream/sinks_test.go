@@ -0,0 +1,135 @@
+package eventstream_test
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+
+	"github.com/concourse/atc/eventstream"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTP sink", func() {
+	var (
+		server *ghttp.Server
+		sink   eventstream.Sink
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		sink = eventstream.NewHTTPSink(&http.Client{}, server.URL()+"/events")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the server accepts the event", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/events"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyJSON(`{
+					"build_id": 42,
+					"build_name": "7",
+					"team_name": "some-team",
+					"event": "log",
+					"version": "5.1",
+					"data": {"payload": "some-output"}
+				}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("POSTs the event as JSON", func() {
+			err := sink.Send(eventstream.Event{
+				BuildID:   42,
+				BuildName: "7",
+				TeamName:  "some-team",
+				Event:     "log",
+				Version:   "5.1",
+				Data:      []byte(`{"payload":"some-output"}`),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the server rejects the event", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			err := sink.Send(eventstream.Event{BuildID: 42, Data: []byte(`{}`)})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Syslog sink", func() {
+	var (
+		address string
+		sink    eventstream.Sink
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		address = listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		sink = eventstream.NewSyslogSink("tcp", address, "some-tag")
+	})
+
+	Context("when the server is not yet listening", func() {
+		It("returns an error from Send rather than on construction", func() {
+			err := sink.Send(eventstream.Event{BuildID: 42, Data: []byte(`{}`)})
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("once the server comes up", func() {
+			var listener net.Listener
+
+			BeforeEach(func() {
+				err := sink.Send(eventstream.Event{BuildID: 42, Data: []byte(`{}`)})
+				Expect(err).To(HaveOccurred())
+
+				listener, err = net.Listen("tcp", address)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				listener.Close()
+			})
+
+			It("connects and writes the event as JSON", func() {
+				received := make(chan string, 1)
+				go func() {
+					defer GinkgoRecover()
+
+					conn, err := listener.Accept()
+					Expect(err).NotTo(HaveOccurred())
+					defer conn.Close()
+
+					line, err := bufio.NewReader(conn).ReadString('\n')
+					Expect(err).NotTo(HaveOccurred())
+					received <- line
+				}()
+
+				err := sink.Send(eventstream.Event{BuildID: 42, Event: "log", Data: []byte(`{"payload":"some-output"}`)})
+				Expect(err).NotTo(HaveOccurred())
+
+				var line string
+				Eventually(received).Should(Receive(&line))
+				Expect(line).To(ContainSubstring("some-tag"))
+				Expect(line).To(ContainSubstring(`"build_id":42`))
+			})
+		})
+	})
+})
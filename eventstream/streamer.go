@@ -0,0 +1,177 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// Event is a build event along with the build it was saved for, as sent to
+// each sink.
+type Event struct {
+	BuildID      int              `json:"build_id"`
+	BuildName    string           `json:"build_name"`
+	JobName      string           `json:"job_name,omitempty"`
+	PipelineName string           `json:"pipeline_name,omitempty"`
+	TeamName     string           `json:"team_name"`
+	Event        atc.EventType    `json:"event"`
+	Version      atc.EventVersion `json:"version"`
+	Data         json.RawMessage  `json:"data"`
+}
+
+//go:generate counterfeiter . Sink
+
+// Sink is somewhere build events are streamed to, e.g. a log aggregation
+// system.
+type Sink interface {
+	Send(Event) error
+}
+
+type DropPolicy string
+
+const (
+	// DropNewest discards events which arrive while the buffer is full.
+	DropNewest DropPolicy = "drop-newest"
+	// DropOldest discards the oldest buffered event to make room.
+	DropOldest DropPolicy = "drop-oldest"
+)
+
+// Streamer tees build events to its sinks as they are saved. Each sink has its
+// own buffer, so a slow or unavailable sink never holds up builds or the
+// other sinks; once a sink's buffer is full, events are dropped according to
+// the drop policy.
+type Streamer struct {
+	logger lager.Logger
+	sinks  []*bufferedSink
+}
+
+type bufferedSink struct {
+	name   string
+	sink   Sink
+	policy DropPolicy
+	events chan Event
+
+	dropLock sync.Mutex
+}
+
+func NewStreamer(logger lager.Logger, bufferSize int, policy DropPolicy, sinks map[string]Sink) *Streamer {
+	streamer := &Streamer{logger: logger}
+
+	for name, sink := range sinks {
+		streamer.sinks = append(streamer.sinks, &bufferedSink{
+			name:   name,
+			sink:   sink,
+			policy: policy,
+			events: make(chan Event, bufferSize),
+		})
+	}
+
+	return streamer
+}
+
+// Stream buffers the event for each sink. It never blocks.
+func (streamer *Streamer) Stream(build db.Build, ev atc.Event) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		streamer.logger.Error("failed-to-marshal-event", err)
+		return
+	}
+
+	event := Event{
+		BuildID:      build.ID(),
+		BuildName:    build.Name(),
+		JobName:      build.JobName(),
+		PipelineName: build.PipelineName(),
+		TeamName:     build.TeamName(),
+		Event:        ev.EventType(),
+		Version:      ev.Version(),
+		Data:         payload,
+	}
+
+	for _, sink := range streamer.sinks {
+		if !sink.enqueue(event) {
+			streamer.logger.Debug("dropped-event", lager.Data{
+				"sink":  sink.name,
+				"build": event.BuildID,
+				"event": event.Event,
+			})
+		}
+	}
+}
+
+// Run sends the buffered events to each sink until signalled. Events which a
+// sink fails to accept are logged and dropped rather than retried, so that a
+// sink which is down doesn't fall ever further behind.
+func (streamer *Streamer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	done := make(chan struct{})
+
+	wg := new(sync.WaitGroup)
+	for _, sink := range streamer.sinks {
+		wg.Add(1)
+		go func(sink *bufferedSink) {
+			defer wg.Done()
+			sink.run(streamer.logger.Session("sink", lager.Data{"sink": sink.name}), done)
+		}(sink)
+	}
+
+	close(ready)
+
+	<-signals
+
+	close(done)
+	wg.Wait()
+
+	return nil
+}
+
+func (sink *bufferedSink) enqueue(event Event) bool {
+	select {
+	case sink.events <- event:
+		return true
+	default:
+	}
+
+	if sink.policy != DropOldest {
+		return false
+	}
+
+	// make room by dropping the oldest event; the lock keeps concurrent
+	// builds from dropping more events than they add
+	sink.dropLock.Lock()
+	defer sink.dropLock.Unlock()
+
+	select {
+	case <-sink.events:
+	default:
+	}
+
+	select {
+	case sink.events <- event:
+	default:
+	}
+
+	return false
+}
+
+func (sink *bufferedSink) run(logger lager.Logger, done <-chan struct{}) {
+	for {
+		select {
+		case event := <-sink.events:
+			err := sink.sink.Send(event)
+			if err != nil {
+				logger.Info("failed-to-send-event", lager.Data{
+					"error": err.Error(),
+					"build": event.BuildID,
+					"event": event.Event,
+				})
+			}
+
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package eventstream_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/eventstream"
+	"github.com/concourse/atc/eventstream/eventstreamfakes"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Streamer", func() {
+	var (
+		fakeSink   *eventstreamfakes.FakeSink
+		bufferSize int
+		policy     eventstream.DropPolicy
+
+		build *dbfakes.FakeBuild
+
+		streamer *eventstream.Streamer
+		process  ifrit.Process
+
+		sentLock sync.Mutex
+		sent     []eventstream.Event
+	)
+
+	BeforeEach(func() {
+		fakeSink = new(eventstreamfakes.FakeSink)
+		bufferSize = 10
+		policy = eventstream.DropNewest
+
+		sent = nil
+		fakeSink.SendStub = func(event eventstream.Event) error {
+			sentLock.Lock()
+			sent = append(sent, event)
+			sentLock.Unlock()
+			return nil
+		}
+
+		build = new(dbfakes.FakeBuild)
+		build.IDReturns(42)
+		build.NameReturns("7")
+		build.JobNameReturns("some-job")
+		build.PipelineNameReturns("some-pipeline")
+		build.TeamNameReturns("some-team")
+	})
+
+	JustBeforeEach(func() {
+		streamer = eventstream.NewStreamer(
+			lagertest.NewTestLogger("test"),
+			bufferSize,
+			policy,
+			map[string]eventstream.Sink{"some-sink": fakeSink},
+		)
+	})
+
+	AfterEach(func() {
+		if process != nil {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+			process = nil
+		}
+	})
+
+	sentPayloads := func() []string {
+		sentLock.Lock()
+		defer sentLock.Unlock()
+
+		payloads := []string{}
+		for _, event := range sent {
+			var log struct {
+				Payload string `json:"payload"`
+			}
+
+			Expect(json.Unmarshal(event.Data, &log)).To(Succeed())
+			payloads = append(payloads, log.Payload)
+		}
+
+		return payloads
+	}
+
+	It("sends each event to the sink along with its build", func() {
+		process = ifrit.Invoke(streamer)
+
+		streamer.Stream(build, event.Log{Payload: "some-output"})
+
+		Eventually(fakeSink.SendCallCount).Should(Equal(1))
+
+		sentEvent := fakeSink.SendArgsForCall(0)
+		Expect(sentEvent.BuildID).To(Equal(42))
+		Expect(sentEvent.BuildName).To(Equal("7"))
+		Expect(sentEvent.JobName).To(Equal("some-job"))
+		Expect(sentEvent.PipelineName).To(Equal("some-pipeline"))
+		Expect(sentEvent.TeamName).To(Equal("some-team"))
+		Expect(sentEvent.Event).To(Equal(event.EventTypeLog))
+		Expect(sentEvent.Version).To(Equal(atc.EventVersion("5.1")))
+		Expect(sentEvent.Data).To(MatchJSON(`{"origin":{},"payload":"some-output","time":0}`))
+	})
+
+	It("buffers events until it is running", func() {
+		streamer.Stream(build, event.Log{Payload: "a"})
+		streamer.Stream(build, event.Log{Payload: "b"})
+
+		Consistently(fakeSink.SendCallCount).Should(BeZero())
+
+		process = ifrit.Invoke(streamer)
+
+		Eventually(sentPayloads).Should(Equal([]string{"a", "b"}))
+	})
+
+	It("keeps sending after the sink fails", func() {
+		fakeSink.SendReturnsOnCall(0, errors.New("nope"))
+
+		process = ifrit.Invoke(streamer)
+
+		streamer.Stream(build, event.Log{Payload: "a"})
+		streamer.Stream(build, event.Log{Payload: "b"})
+
+		Eventually(fakeSink.SendCallCount).Should(Equal(2))
+	})
+
+	Context("when the buffer is full", func() {
+		BeforeEach(func() {
+			bufferSize = 2
+		})
+
+		JustBeforeEach(func() {
+			streamer.Stream(build, event.Log{Payload: "a"})
+			streamer.Stream(build, event.Log{Payload: "b"})
+			streamer.Stream(build, event.Log{Payload: "c"})
+
+			process = ifrit.Invoke(streamer)
+		})
+
+		Context("with the drop-newest policy", func() {
+			It("drops the new events", func() {
+				Eventually(sentPayloads).Should(Equal([]string{"a", "b"}))
+				Consistently(sentPayloads).Should(HaveLen(2))
+			})
+		})
+
+		Context("with the drop-oldest policy", func() {
+			BeforeEach(func() {
+				policy = eventstream.DropOldest
+			})
+
+			It("drops the oldest events", func() {
+				Eventually(sentPayloads).Should(Equal([]string{"b", "c"}))
+				Consistently(sentPayloads).Should(HaveLen(2))
+			})
+		})
+	})
+})
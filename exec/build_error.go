@@ -0,0 +1,56 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
+)
+
+// NewBuildError categorizes an error returned by a step so that it can be
+// reported as a typed build error. The message is the same as what would
+// have been shown to the user before.
+func NewBuildError(stepName string, err error) atc.BuildError {
+	buildErr := atc.BuildError{
+		Code:    atc.BuildErrorCodeUnknown,
+		Message: err.Error(),
+		Step:    stepName,
+	}
+
+	switch e := err.(type) {
+	case MissingInputsError:
+		buildErr.Code = atc.BuildErrorCodeMissingInputs
+	case MissingTaskImageSourceError,
+		TaskImageSourceParametersError,
+		UnknownArtifactSourceError,
+		UnspecifiedArtifactSourceError,
+		FileNotFoundError,
+		worker.FileNotFoundError,
+		worker.PrivilegedNotAllowedError:
+		buildErr.Code = atc.BuildErrorCodeInvalidConfig
+	case worker.NoCompatibleWorkersError:
+		buildErr.Code = atc.BuildErrorCodeNoWorkers
+	case transport.WorkerMissingError:
+		buildErr.Code = atc.BuildErrorCodeWorkerUnavailable
+		buildErr.Worker = e.WorkerName
+	case transport.WorkerUnreachableError:
+		buildErr.Code = atc.BuildErrorCodeWorkerUnavailable
+		buildErr.Worker = e.WorkerName
+	}
+
+	switch err {
+	case context.Canceled:
+		buildErr.Code = atc.BuildErrorCodeAborted
+		buildErr.Message = AbortedLogMessage
+	case context.DeadlineExceeded:
+		buildErr.Code = atc.BuildErrorCodeTimeout
+		buildErr.Message = TimeoutLogMessage
+	case worker.ErrNoWorkers:
+		buildErr.Code = atc.BuildErrorCodeNoWorkers
+	case worker.ErrMissingWorker:
+		buildErr.Code = atc.BuildErrorCodeWorkerUnavailable
+	}
+
+	return buildErr
+}
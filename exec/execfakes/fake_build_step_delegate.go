@@ -4,8 +4,11 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -40,11 +43,40 @@ type FakeBuildStepDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
-	ErroredStub        func(lager.Logger, string)
+	ErroredStub        func(lager.Logger, atc.BuildError)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.BuildError
+	}
+	VariablesStub        func() creds.Variables
+	variablesMutex       sync.RWMutex
+	variablesArgsForCall []struct{}
+	variablesReturns     struct {
+		result1 creds.Variables
+	}
+	variablesReturnsOnCall map[int]struct {
+		result1 creds.Variables
+	}
+	ImageCheckStartedStub        func(lager.Logger, string)
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	ImageGetStartedStub        func(lager.Logger, string, atc.Version)
+	imageGetStartedMutex       sync.RWMutex
+	imageGetStartedArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.Version
+	}
+	HookTimedOutStub        func(arg1 lager.Logger, arg2 string, arg3 time.Duration)
+	hookTimedOutMutex       sync.RWMutex
+	hookTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
@@ -178,11 +210,11 @@ func (fake *FakeBuildStepDelegate) StderrReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
-func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 atc.BuildError) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 string
+		arg2 atc.BuildError
 	}{arg1, arg2})
 	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
 	fake.erroredMutex.Unlock()
@@ -197,12 +229,129 @@ func (fake *FakeBuildStepDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, atc.BuildError) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
 }
 
+func (fake *FakeBuildStepDelegate) Variables() creds.Variables {
+	fake.variablesMutex.Lock()
+	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
+	fake.variablesArgsForCall = append(fake.variablesArgsForCall, struct{}{})
+	fake.recordInvocation("Variables", []interface{}{})
+	fake.variablesMutex.Unlock()
+	if fake.VariablesStub != nil {
+		return fake.VariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.variablesReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) VariablesCallCount() int {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return len(fake.variablesArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) VariablesReturns(result1 creds.Variables) {
+	fake.VariablesStub = nil
+	fake.variablesReturns = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) VariablesReturnsOnCall(i int, result1 creds.Variables) {
+	fake.VariablesStub = nil
+	if fake.variablesReturnsOnCall == nil {
+		fake.variablesReturnsOnCall = make(map[int]struct {
+			result1 creds.Variables
+		})
+	}
+	fake.variablesReturnsOnCall[i] = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStarted(arg1 lager.Logger, arg2 string) {
+	fake.imageCheckStartedMutex.Lock()
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{arg1, arg2})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		fake.ImageCheckStartedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedArgsForCall(i int) (lager.Logger, string) {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return fake.imageCheckStartedArgsForCall[i].arg1, fake.imageCheckStartedArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildStepDelegate) ImageGetStarted(arg1 lager.Logger, arg2 string, arg3 atc.Version) {
+	fake.imageGetStartedMutex.Lock()
+	fake.imageGetStartedArgsForCall = append(fake.imageGetStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageGetStarted", []interface{}{arg1, arg2, arg3})
+	fake.imageGetStartedMutex.Unlock()
+	if fake.ImageGetStartedStub != nil {
+		fake.ImageGetStartedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ImageGetStartedCallCount() int {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return len(fake.imageGetStartedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageGetStartedArgsForCall(i int) (lager.Logger, string, atc.Version) {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return fake.imageGetStartedArgsForCall[i].arg1, fake.imageGetStartedArgsForCall[i].arg2, fake.imageGetStartedArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOut(arg1 lager.Logger, arg2 string, arg3 time.Duration) {
+	fake.hookTimedOutMutex.Lock()
+	fake.hookTimedOutArgsForCall = append(fake.hookTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("HookTimedOut", []interface{}{arg1, arg2, arg3})
+	fake.hookTimedOutMutex.Unlock()
+	if fake.HookTimedOutStub != nil {
+		fake.HookTimedOutStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOutCallCount() int {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return len(fake.hookTimedOutArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOutArgsForCall(i int) (lager.Logger, string, time.Duration) {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return fake.hookTimedOutArgsForCall[i].arg1, fake.hookTimedOutArgsForCall[i].arg2, fake.hookTimedOutArgsForCall[i].arg3
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -214,6 +363,14 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -43,14 +43,15 @@ type FakeFactory struct {
 	putReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	TaskStub        func(lager.Logger, atc.Plan, db.Build, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	TaskStub        func(lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) exec.Step
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 db.ContainerMetadata
-		arg5 exec.TaskDelegate
+		arg4 exec.StepMetadata
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
 	}
 	taskReturns struct {
 		result1 exec.Step
@@ -168,20 +169,21 @@ func (fake *FakeFactory) PutReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 db.ContainerMetadata, arg5 exec.TaskDelegate) exec.Step {
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.StepMetadata, arg5 db.ContainerMetadata, arg6 exec.TaskDelegate) exec.Step {
 	fake.taskMutex.Lock()
 	ret, specificReturn := fake.taskReturnsOnCall[len(fake.taskArgsForCall)]
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 db.ContainerMetadata
-		arg5 exec.TaskDelegate
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg4 exec.StepMetadata
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -195,10 +197,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, db.ContainerMetadata, exec.TaskDelegate) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.Step) {
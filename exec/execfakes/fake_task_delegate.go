@@ -4,9 +4,11 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -41,11 +43,11 @@ type FakeTaskDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
-	ErroredStub        func(lager.Logger, string)
+	ErroredStub        func(lager.Logger, atc.BuildError)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 string
+		arg2 atc.BuildError
 	}
 	InitializingStub        func(lager.Logger, atc.TaskConfig)
 	initializingMutex       sync.RWMutex
@@ -65,6 +67,48 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 exec.ExitStatus
 	}
+	VariablesStub        func() creds.Variables
+	variablesMutex       sync.RWMutex
+	variablesArgsForCall []struct{}
+	variablesReturns     struct {
+		result1 creds.Variables
+	}
+	variablesReturnsOnCall map[int]struct {
+		result1 creds.Variables
+	}
+	BuildTokenEnvStub        func(lager.Logger) ([]string, error)
+	buildTokenEnvMutex       sync.RWMutex
+	buildTokenEnvArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	buildTokenEnvReturns struct {
+		result1 []string
+		result2 error
+	}
+	buildTokenEnvReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ImageCheckStartedStub        func(lager.Logger, string)
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	ImageGetStartedStub        func(lager.Logger, string, atc.Version)
+	imageGetStartedMutex       sync.RWMutex
+	imageGetStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}
+	HookTimedOutStub        func(arg1 lager.Logger, arg2 string, arg3 time.Duration)
+	hookTimedOutMutex       sync.RWMutex
+	hookTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -197,11 +241,11 @@ func (fake *FakeTaskDelegate) StderrReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
-func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 atc.BuildError) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 string
+		arg2 atc.BuildError
 	}{arg1, arg2})
 	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
 	fake.erroredMutex.Unlock()
@@ -216,7 +260,7 @@ func (fake *FakeTaskDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, atc.BuildError) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
@@ -297,6 +341,174 @@ func (fake *FakeTaskDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exi
 	return fake.finishedArgsForCall[i].arg1, fake.finishedArgsForCall[i].arg2
 }
 
+func (fake *FakeTaskDelegate) Variables() creds.Variables {
+	fake.variablesMutex.Lock()
+	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
+	fake.variablesArgsForCall = append(fake.variablesArgsForCall, struct{}{})
+	fake.recordInvocation("Variables", []interface{}{})
+	fake.variablesMutex.Unlock()
+	if fake.VariablesStub != nil {
+		return fake.VariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.variablesReturns.result1
+}
+
+func (fake *FakeTaskDelegate) VariablesCallCount() int {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return len(fake.variablesArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) VariablesReturns(result1 creds.Variables) {
+	fake.VariablesStub = nil
+	fake.variablesReturns = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) VariablesReturnsOnCall(i int, result1 creds.Variables) {
+	fake.VariablesStub = nil
+	if fake.variablesReturnsOnCall == nil {
+		fake.variablesReturnsOnCall = make(map[int]struct {
+			result1 creds.Variables
+		})
+	}
+	fake.variablesReturnsOnCall[i] = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) BuildTokenEnv(arg1 lager.Logger) ([]string, error) {
+	fake.buildTokenEnvMutex.Lock()
+	ret, specificReturn := fake.buildTokenEnvReturnsOnCall[len(fake.buildTokenEnvArgsForCall)]
+	fake.buildTokenEnvArgsForCall = append(fake.buildTokenEnvArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("BuildTokenEnv", []interface{}{arg1})
+	fake.buildTokenEnvMutex.Unlock()
+	if fake.BuildTokenEnvStub != nil {
+		return fake.BuildTokenEnvStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.buildTokenEnvReturns.result1, fake.buildTokenEnvReturns.result2
+}
+
+func (fake *FakeTaskDelegate) BuildTokenEnvCallCount() int {
+	fake.buildTokenEnvMutex.RLock()
+	defer fake.buildTokenEnvMutex.RUnlock()
+	return len(fake.buildTokenEnvArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) BuildTokenEnvArgsForCall(i int) lager.Logger {
+	fake.buildTokenEnvMutex.RLock()
+	defer fake.buildTokenEnvMutex.RUnlock()
+	return fake.buildTokenEnvArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) BuildTokenEnvReturns(result1 []string, result2 error) {
+	fake.BuildTokenEnvStub = nil
+	fake.buildTokenEnvReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDelegate) BuildTokenEnvReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.BuildTokenEnvStub = nil
+	if fake.buildTokenEnvReturnsOnCall == nil {
+		fake.buildTokenEnvReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.buildTokenEnvReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStarted(arg1 lager.Logger, arg2 string) {
+	fake.imageCheckStartedMutex.Lock()
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{arg1, arg2})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		fake.ImageCheckStartedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedArgsForCall(i int) (lager.Logger, string) {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return fake.imageCheckStartedArgsForCall[i].arg1, fake.imageCheckStartedArgsForCall[i].arg2
+}
+
+func (fake *FakeTaskDelegate) ImageGetStarted(arg1 lager.Logger, arg2 string, arg3 atc.Version) {
+	fake.imageGetStartedMutex.Lock()
+	fake.imageGetStartedArgsForCall = append(fake.imageGetStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageGetStarted", []interface{}{arg1, arg2, arg3})
+	fake.imageGetStartedMutex.Unlock()
+	if fake.ImageGetStartedStub != nil {
+		fake.ImageGetStartedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageGetStartedCallCount() int {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return len(fake.imageGetStartedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageGetStartedArgsForCall(i int) (lager.Logger, string, atc.Version) {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return fake.imageGetStartedArgsForCall[i].arg1, fake.imageGetStartedArgsForCall[i].arg2, fake.imageGetStartedArgsForCall[i].arg3
+}
+
+func (fake *FakeTaskDelegate) HookTimedOut(arg1 lager.Logger, arg2 string, arg3 time.Duration) {
+	fake.hookTimedOutMutex.Lock()
+	fake.hookTimedOutArgsForCall = append(fake.hookTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("HookTimedOut", []interface{}{arg1, arg2, arg3})
+	fake.hookTimedOutMutex.Unlock()
+	if fake.HookTimedOutStub != nil {
+		fake.HookTimedOutStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) HookTimedOutCallCount() int {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return len(fake.hookTimedOutArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) HookTimedOutArgsForCall(i int) (lager.Logger, string, time.Duration) {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return fake.hookTimedOutArgsForCall[i].arg1, fake.hookTimedOutArgsForCall[i].arg2, fake.hookTimedOutArgsForCall[i].arg3
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -314,6 +526,16 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.startingMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	fake.buildTokenEnvMutex.RLock()
+	defer fake.buildTokenEnvMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
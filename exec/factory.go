@@ -2,9 +2,11 @@ package exec
 
 import (
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -37,6 +39,7 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		StepMetadata,
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
@@ -51,12 +54,18 @@ type StepMetadata interface {
 //go:generate counterfeiter . BuildStepDelegate
 
 type BuildStepDelegate interface {
+	ImageCheckStarted(lager.Logger, string)
+	ImageGetStarted(lager.Logger, string, atc.Version)
 	ImageVersionDetermined(db.UsedResourceCache) error
 
+	Variables() creds.Variables
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 
-	Errored(lager.Logger, string)
+	Errored(lager.Logger, atc.BuildError)
+
+	HookTimedOut(lager.Logger, string, time.Duration)
 }
 
 // Privileged is used to indicate whether the given step should run with
@@ -11,7 +11,9 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/taskcache"
 )
 
 type gardenFactory struct {
@@ -19,8 +21,8 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
-	variablesFactory       creds.VariablesFactory
 	defaultLimits          atc.ContainerLimits
+	taskCacheStore         taskcache.Store
 }
 
 func NewGardenFactory(
@@ -28,16 +30,16 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
-	variablesFactory creds.VariablesFactory,
 	defaultLimits atc.ContainerLimits,
+	taskCacheStore taskcache.Store,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
-		variablesFactory:       variablesFactory,
 		defaultLimits:          defaultLimits,
+		taskCacheStore:         taskCacheStore,
 	}
 }
 
@@ -51,18 +53,21 @@ func (factory *gardenFactory) Get(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	getStep := NewGetStep(
 		build,
 
 		plan.Get.Name,
+		plan.Get.Path,
 		plan.Get.Type,
 		plan.Get.Resource,
 		creds.NewSource(variables, plan.Get.Source),
 		creds.NewParams(variables, plan.Get.Params),
 		NewVersionSourceFromPlan(plan.Get),
 		plan.Get.Tags,
+		plan.Get.Network,
+		buildIsolationLabels(logger, build),
 
 		delegate,
 		factory.resourceFetcher,
@@ -76,7 +81,12 @@ func (factory *gardenFactory) Get(
 		creds.NewVersionedResourceTypes(variables, plan.Get.VersionedResourceTypes),
 	)
 
-	return LogError(getStep, delegate)
+	tracedStep := Traced(getStep, "get", tracing.Attrs{
+		"name":     plan.Get.Name,
+		"resource": plan.Get.Resource,
+	})
+
+	return LogError(tracedStep, plan.Get.Name, delegate)
 }
 
 func (factory *gardenFactory) Put(
@@ -89,7 +99,7 @@ func (factory *gardenFactory) Put(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	putStep := NewPutStep(
 		build,
@@ -100,6 +110,8 @@ func (factory *gardenFactory) Put(
 		creds.NewSource(variables, plan.Put.Source),
 		creds.NewParams(variables, plan.Put.Params),
 		plan.Put.Tags,
+		plan.Put.Network,
+		buildIsolationLabels(logger, build),
 
 		delegate,
 		factory.resourceFactory,
@@ -110,13 +122,19 @@ func (factory *gardenFactory) Put(
 		creds.NewVersionedResourceTypes(variables, plan.Put.VersionedResourceTypes),
 	)
 
-	return LogError(putStep, delegate)
+	tracedStep := Traced(putStep, "put", tracing.Attrs{
+		"name":     plan.Put.Name,
+		"resource": plan.Put.Resource,
+	})
+
+	return LogError(tracedStep, plan.Put.Name, delegate)
 }
 
 func (factory *gardenFactory) Task(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	stepMetadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	delegate TaskDelegate,
 ) Step {
@@ -137,14 +155,16 @@ func (factory *gardenFactory) Task(
 
 	taskConfigSource = ValidatingConfigSource{ConfigSource: taskConfigSource}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	taskStep := NewTaskStep(
 		Privileged(plan.Task.Privileged),
 		taskConfigSource,
 		plan.Task.Tags,
+		plan.Task.Network,
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
+		buildIsolationLabels(logger, build),
 
 		workingDirectory,
 		plan.Task.ImageArtifactName,
@@ -157,14 +177,62 @@ func (factory *gardenFactory) Task(
 		build.JobID(),
 		plan.Task.Name,
 		plan.ID,
+		stepMetadata,
 		containerMetadata,
 
 		creds.NewVersionedResourceTypes(variables, plan.Task.VersionedResourceTypes),
 		variables,
 		factory.defaultLimits,
+		factory.taskCacheStore,
 	)
 
-	return LogError(taskStep, delegate)
+	tracedStep := Traced(taskStep, "task", tracing.Attrs{
+		"name": plan.Task.Name,
+	})
+
+	return LogError(tracedStep, plan.Task.Name, delegate)
+}
+
+// buildIsolationLabels labels the containers of the build with its team and
+// pipeline, and the container properties of its job. Unless the pipeline is
+// known to be public they are labelled private.
+func buildIsolationLabels(logger lager.Logger, build db.Build) worker.IsolationLabels {
+	labels := worker.IsolationLabels{
+		Team:     build.TeamName(),
+		Pipeline: build.PipelineName(),
+	}
+
+	if build.PipelineID() == 0 {
+		return labels
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return labels
+	}
+
+	if !found {
+		return labels
+	}
+
+	labels.Public = pipeline.Public()
+
+	if build.JobName() == "" {
+		return labels
+	}
+
+	job, found, err := pipeline.Job(build.JobName())
+	if err != nil {
+		logger.Error("failed-to-find-job", err)
+		return labels
+	}
+
+	if found {
+		labels.JobProperties = job.Config().ContainerProperties
+	}
+
+	return labels
 }
 
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
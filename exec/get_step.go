@@ -29,12 +29,16 @@ type GetStep struct {
 	build db.Build
 
 	name          string
+	path          string
 	resourceType  string
 	resource      string
 	source        creds.Source
 	params        creds.Params
 	versionSource VersionSource
 	tags          atc.Tags
+	network       *atc.NetworkConfig
+
+	isolationLabels worker.IsolationLabels
 
 	delegate GetDelegate
 
@@ -55,12 +59,15 @@ func NewGetStep(
 	build db.Build,
 
 	name string,
+	path string,
 	resourceType string,
 	resource string,
 	source creds.Source,
 	params creds.Params,
 	versionSource VersionSource,
 	tags atc.Tags,
+	network *atc.NetworkConfig,
+	isolationLabels worker.IsolationLabels,
 
 	delegate GetDelegate,
 
@@ -78,12 +85,16 @@ func NewGetStep(
 		build: build,
 
 		name:          name,
+		path:          path,
 		resourceType:  resourceType,
 		resource:      resource,
 		source:        source,
 		params:        params,
 		versionSource: versionSource,
 		tags:          tags,
+		network:       network,
+
+		isolationLabels: isolationLabels,
 
 		delegate: delegate,
 
@@ -135,6 +146,8 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	source = step.resourceTypes.WithBaseResourceTypeDefaults(step.resourceType, source)
+
 	params, err := step.params.Evaluate()
 	if err != nil {
 		return err
@@ -168,7 +181,9 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		ctx,
 		logger,
 		resource.Session{
-			Metadata: step.containerMetadata,
+			Metadata:        step.containerMetadata,
+			Network:         step.network,
+			IsolationLabels: step.isolationLabels,
 		},
 		step.tags,
 		step.teamID,
@@ -194,6 +209,10 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		versionedSource:  versionedSource,
 	})
 
+	if step.path != "" {
+		state.Artifacts().RegisterPath(worker.ArtifactName(step.name), step.path)
+	}
+
 	if step.resource != "" {
 		err := step.build.SaveInput(db.BuildInput{
 			Name: step.name,
@@ -14,7 +14,6 @@ import (
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
-	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/exec"
@@ -36,7 +35,6 @@ var _ = Describe("GetStep", func() {
 		fakeWorkerClient           *workerfakes.FakeClient
 		fakeResourceFetcher        *resourcefakes.FakeFetcher
 		fakeDBResourceCacheFactory *dbfakes.FakeResourceCacheFactory
-		fakeVariablesFactory       *credsfakes.FakeVariablesFactory
 		variables                  creds.Variables
 		fakeBuild                  *dbfakes.FakeBuild
 		fakeDelegate               *execfakes.FakeGetDelegate
@@ -72,11 +70,9 @@ var _ = Describe("GetStep", func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
 		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 
-		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
 		variables = template.StaticVariables{
 			"source-param": "super-secret-source",
 		}
-		fakeVariablesFactory.NewVariablesReturns(variables)
 
 		artifactRepository = worker.NewArtifactRepository()
 		state = new(execfakes.FakeRunState)
@@ -112,9 +108,10 @@ var _ = Describe("GetStep", func() {
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory, atc.ContainerLimits{})
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, atc.ContainerLimits{}, nil)
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
+		fakeDelegate.VariablesReturns(variables)
 	})
 
 	AfterEach(func() {
@@ -231,6 +228,23 @@ var _ = Describe("GetStep", func() {
 			})
 		})
 
+		It("does not register a path with the repository", func() {
+			_, found := artifactRepository.PathFor("some-name")
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the plan has a path", func() {
+			BeforeEach(func() {
+				getPlan.Path = "some/path"
+			})
+
+			It("registers the path with the repository", func() {
+				path, found := artifactRepository.PathFor("some-name")
+				Expect(found).To(BeTrue())
+				Expect(path).To(Equal("some/path"))
+			})
+		})
+
 		Describe("the source registered with the repository", func() {
 			var artifactSource worker.ArtifactSource
 
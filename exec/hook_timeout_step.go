@@ -0,0 +1,56 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+)
+
+// HookTimeoutStep limits how long a hook, i.e. an ensure, on_success or
+// on_failure step, may run, so that a hanging hook can't keep its build from
+// finishing. It applies regardless of any timeout configured on the hook
+// itself.
+type HookTimeoutStep struct {
+	step     Step
+	hook     string
+	duration time.Duration
+	delegate BuildStepDelegate
+	timedOut bool
+}
+
+// HookTimeout constructs a HookTimeoutStep for the given kind of hook.
+func HookTimeout(step Step, hook string, duration time.Duration, delegate BuildStepDelegate) *HookTimeoutStep {
+	return &HookTimeoutStep{
+		step:     step,
+		hook:     hook,
+		duration: duration,
+		delegate: delegate,
+	}
+}
+
+// Run invokes the nested step with the duration as its timeout.
+//
+// If the nested step takes longer than the duration, the delegate is told
+// that the hook timed out, and the HookTimeoutStep returns nil once the nested
+// step exits. This is decided by the deadline rather than the nested step's
+// error, as steps such as try swallow it.
+func (ts *HookTimeoutStep) Run(ctx context.Context, state RunState) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, ts.duration)
+	defer cancel()
+
+	err := ts.step.Run(timeoutCtx, state)
+	if timeoutCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		ts.timedOut = true
+		ts.delegate.HookTimedOut(lagerctx.FromContext(ctx), ts.hook, ts.duration)
+		return nil
+	}
+
+	return err
+}
+
+// Succeeded is true if the nested step completed successfully
+// and did not time out.
+func (ts *HookTimeoutStep) Succeeded() bool {
+	return !ts.timedOut && ts.step.Succeeded()
+}
@@ -0,0 +1,176 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hook Timeout Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep     *execfakes.FakeStep
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+
+		hook Step
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		step Step
+
+		timeout time.Duration
+
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+
+		hook = fakeStep
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		timeout = time.Hour
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = HookTimeout(hook, "ensure", timeout, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("runs the step with a deadline", func() {
+		runCtx, _ := fakeStep.RunArgsForCall(0)
+		deadline, ok := runCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), 10*time.Second))
+	})
+
+	Context("when the step returns an error", func() {
+		var someError error
+
+		BeforeEach(func() {
+			someError = errors.New("some error")
+			fakeStep.RunReturns(someError)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(someError))
+		})
+
+		It("does not tell the delegate the hook timed out", func() {
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the step exceeds the timeout", func() {
+		BeforeEach(func() {
+			timeout = time.Millisecond
+
+			fakeStep.SucceededReturns(true)
+			fakeStep.RunStub = func(ctx context.Context, state RunState) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		})
+
+		It("returns no error", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("tells the delegate the hook timed out", func() {
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(Equal(1))
+			_, hook, duration := fakeDelegate.HookTimedOutArgsForCall(0)
+			Expect(hook).To(Equal("ensure"))
+			Expect(duration).To(Equal(time.Millisecond))
+		})
+	})
+
+	Context("when the step is wrapped in a try and exceeds the timeout", func() {
+		BeforeEach(func() {
+			timeout = time.Millisecond
+
+			fakeStep.RunStub = func(ctx context.Context, state RunState) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			hook = Try(fakeStep)
+		})
+
+		It("returns no error", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("tells the delegate the hook timed out", func() {
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(Equal(1))
+			_, hook, duration := fakeDelegate.HookTimedOutArgsForCall(0)
+			Expect(hook).To(Equal("ensure"))
+			Expect(duration).To(Equal(time.Millisecond))
+		})
+	})
+
+	Context("when the build is aborted while the step runs", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(runCtx context.Context, state RunState) error {
+				cancel()
+				<-runCtx.Done()
+				return runCtx.Err()
+			}
+		})
+
+		It("returns the cancellation", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+
+		It("does not tell the delegate the hook timed out", func() {
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the step is successful", func() {
+		BeforeEach(func() {
+			fakeStep.SucceededReturns(true)
+		})
+
+		It("is successful", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the step fails", func() {
+		BeforeEach(func() {
+			fakeStep.SucceededReturns(false)
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
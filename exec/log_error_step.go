@@ -13,13 +13,15 @@ const TimeoutLogMessage = "timeout exceeded"
 type LogErrorStep struct {
 	Step
 
+	name     string
 	delegate BuildStepDelegate
 }
 
-func LogError(step Step, delegate BuildStepDelegate) Step {
+func LogError(step Step, name string, delegate BuildStepDelegate) Step {
 	return LogErrorStep{
 		Step: step,
 
+		name:     name,
 		delegate: delegate,
 	}
 }
@@ -28,22 +30,13 @@ func (step LogErrorStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 
 	runErr := step.Step.Run(ctx, state)
-
-	var message string
-	switch runErr {
-	case nil:
+	if runErr == nil {
 		return nil
-	case context.Canceled:
-		message = AbortedLogMessage
-	case context.DeadlineExceeded:
-		message = TimeoutLogMessage
-	default:
-		message = runErr.Error()
 	}
 
 	logger.Info("errored", lager.Data{"error": runErr.Error()})
 
-	step.delegate.Errored(logger, message)
+	step.delegate.Errored(logger, NewBuildError(step.name, runErr))
 
 	return runErr
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
 
 	"github.com/concourse/atc/exec/execfakes"
 	. "github.com/onsi/ginkgo"
@@ -36,7 +38,7 @@ var _ = Describe("LogErrorStep", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(repo)
 
-		step = LogError(fakeStep, fakeDelegate)
+		step = LogError(fakeStep, "some-step", fakeDelegate)
 	})
 
 	AfterEach(func() {
@@ -75,8 +77,12 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'interrupted'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
-				Expect(message).To(Equal("interrupted"))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr).To(Equal(atc.BuildError{
+					Code:    atc.BuildErrorCodeAborted,
+					Message: "interrupted",
+					Step:    "some-step",
+				}))
 			})
 		})
 
@@ -91,8 +97,12 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'timeout exceeded'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
-				Expect(message).To(Equal("timeout exceeded"))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr).To(Equal(atc.BuildError{
+					Code:    atc.BuildErrorCodeTimeout,
+					Message: "timeout exceeded",
+					Step:    "some-step",
+				}))
 			})
 		})
 
@@ -109,8 +119,54 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs the error", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
-				Expect(message).To(Equal("disaster"))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr).To(Equal(atc.BuildError{
+					Code:    atc.BuildErrorCodeUnknown,
+					Message: "disaster",
+					Step:    "some-step",
+				}))
+			})
+		})
+
+		Context("when the inner step fails due to missing inputs", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(MissingInputsError{Inputs: []string{"some-input"}})
+			})
+
+			It("logs a missing inputs error", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr.Code).To(Equal(atc.BuildErrorCodeMissingInputs))
+				Expect(buildErr.Message).To(Equal("missing inputs: some-input"))
+			})
+		})
+
+		Context("when the inner step fails because a worker is unreachable", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(transport.WorkerUnreachableError{
+					WorkerName:  "some-worker",
+					WorkerState: "stalled",
+				})
+			})
+
+			It("logs an infrastructure error with the worker name", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr.Code).To(Equal(atc.BuildErrorCodeWorkerUnavailable))
+				Expect(buildErr.Code.Infrastructure()).To(BeTrue())
+				Expect(buildErr.Worker).To(Equal("some-worker"))
+			})
+		})
+
+		Context("when there are no workers", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(worker.ErrNoWorkers)
+			})
+
+			It("logs a no workers error", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, buildErr := fakeDelegate.ErroredArgsForCall(0)
+				Expect(buildErr.Code).To(Equal(atc.BuildErrorCodeNoWorkers))
 			})
 		})
 	})
@@ -30,6 +30,9 @@ type PutStep struct {
 	source       creds.Source
 	params       creds.Params
 	tags         atc.Tags
+	network      *atc.NetworkConfig
+
+	isolationLabels worker.IsolationLabels
 
 	resource string
 
@@ -53,6 +56,8 @@ func NewPutStep(
 	source creds.Source,
 	params creds.Params,
 	tags atc.Tags,
+	network *atc.NetworkConfig,
+	isolationLabels worker.IsolationLabels,
 	delegate PutDelegate,
 	resourceFactory resource.ResourceFactory,
 	planID atc.PlanID,
@@ -69,6 +74,8 @@ func NewPutStep(
 		source:            source,
 		params:            params,
 		tags:              tags,
+		network:           network,
+		isolationLabels:   isolationLabels,
 		delegate:          delegate,
 		resourceFactory:   resourceFactory,
 		planID:            planID,
@@ -93,8 +100,11 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		ImageSpec: worker.ImageSpec{
 			ResourceType: step.resourceType,
 		},
-		Tags:   step.tags,
-		TeamID: step.build.TeamID(),
+		Tags:    step.tags,
+		TeamID:  step.build.TeamID(),
+		Network: step.network,
+
+		IsolationLabels: step.isolationLabels,
 
 		Dir: resource.ResourcesDir("put"),
 
@@ -126,6 +136,8 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	source = step.resourceTypes.WithBaseResourceTypeDefaults(step.resourceType, source)
+
 	params, err := step.params.Evaluate()
 	if err != nil {
 		return err
@@ -107,6 +107,8 @@ var _ = Describe("PutStep", func() {
 			creds.NewSource(variables, atc.Source{"some": "((source-param))"}),
 			creds.NewParams(variables, atc.Params{"some-param": "some-value"}),
 			[]string{"some", "tags"},
+			&atc.NetworkConfig{Egress: atc.NetworkEgressNone},
+			worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 			fakeDelegate,
 			fakeResourceFactory,
 			planID,
@@ -163,6 +165,8 @@ var _ = Describe("PutStep", func() {
 				}))
 				Expect(containerSpec.Tags).To(Equal([]string{"some", "tags"}))
 				Expect(containerSpec.TeamID).To(Equal(123))
+				Expect(containerSpec.Network).To(Equal(&atc.NetworkConfig{Egress: atc.NetworkEgressNone}))
+				Expect(containerSpec.IsolationLabels).To(Equal(worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"}))
 				Expect(containerSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 				Expect(containerSpec.Dir).To(Equal("/tmp/build/put"))
 				Expect(containerSpec.Inputs).To(HaveLen(3))
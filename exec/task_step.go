@@ -19,6 +19,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/taskcache"
 )
 
 const taskProcessID = "task"
@@ -59,6 +60,10 @@ func (err TaskImageSourceParametersError) Error() string {
 type TaskDelegate interface {
 	BuildStepDelegate
 
+	// BuildTokenEnv returns environment variables exposing a token scoped to
+	// the build, if the credential manager is configured to issue them.
+	BuildTokenEnv(lager.Logger) ([]string, error)
+
 	Initializing(lager.Logger, atc.TaskConfig)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
@@ -70,9 +75,12 @@ type TaskStep struct {
 	privileged    Privileged
 	configSource  TaskConfigSource
 	tags          atc.Tags
+	network       *atc.NetworkConfig
 	inputMapping  map[string]string
 	outputMapping map[string]string
 
+	isolationLabels worker.IsolationLabels
+
 	artifactsRoot     string
 	imageArtifactName string
 
@@ -84,6 +92,7 @@ type TaskStep struct {
 	jobID             int
 	stepName          string
 	planID            atc.PlanID
+	stepMetadata      StepMetadata
 	containerMetadata db.ContainerMetadata
 
 	resourceTypes creds.VersionedResourceTypes
@@ -91,6 +100,8 @@ type TaskStep struct {
 	variables     creds.Variables
 	defaultLimits atc.ContainerLimits
 
+	taskCacheStore taskcache.Store
+
 	succeeded bool
 }
 
@@ -98,8 +109,10 @@ func NewTaskStep(
 	privileged Privileged,
 	configSource TaskConfigSource,
 	tags atc.Tags,
+	network *atc.NetworkConfig,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
+	isolationLabels worker.IsolationLabels,
 	artifactsRoot string,
 	imageArtifactName string,
 	delegate TaskDelegate,
@@ -109,17 +122,21 @@ func NewTaskStep(
 	jobID int,
 	stepName string,
 	planID atc.PlanID,
+	stepMetadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	resourceTypes creds.VersionedResourceTypes,
 	variables creds.Variables,
 	defaultLimits atc.ContainerLimits,
+	taskCacheStore taskcache.Store,
 ) Step {
 	return &TaskStep{
 		privileged:        privileged,
 		configSource:      configSource,
 		tags:              tags,
+		network:           network,
 		inputMapping:      inputMapping,
 		outputMapping:     outputMapping,
+		isolationLabels:   isolationLabels,
 		artifactsRoot:     artifactsRoot,
 		imageArtifactName: imageArtifactName,
 		delegate:          delegate,
@@ -129,10 +146,12 @@ func NewTaskStep(
 		jobID:             jobID,
 		stepName:          stepName,
 		planID:            planID,
+		stepMetadata:      stepMetadata,
 		containerMetadata: containerMetadata,
 		resourceTypes:     resourceTypes,
 		variables:         variables,
 		defaultLimits:     defaultLimits,
+		taskCacheStore:    taskCacheStore,
 	}
 }
 
@@ -179,12 +198,22 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	// the outputs are recorded so that they can be found by the name they
+	// are registered as, e.g. to download them
+	containerMetadata := action.containerMetadata
+	if len(config.Outputs) > 0 {
+		containerMetadata.Outputs = db.ContainerOutputs{}
+		for _, output := range config.Outputs {
+			containerMetadata.Outputs[action.outputName(output)] = artifactsPath(output, action.artifactsRoot)
+		}
+	}
+
 	container, err := action.workerPool.FindOrCreateContainer(
 		ctx,
 		logger,
 		action.delegate,
 		db.NewBuildStepContainerOwner(action.buildID, action.planID),
-		action.containerMetadata,
+		containerMetadata,
 		containerSpec,
 		action.resourceTypes,
 	)
@@ -244,6 +273,17 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 			// which creates ANSI control sequences that do not work with other window sizes
 			TTY: &garden.TTYSpec{WindowSize: &garden.WindowSize{Columns: 500, Rows: 500}},
 		}, processIO)
+		if err != nil {
+			// the process may have been spawned by an earlier attempt which lost
+			// track of it (e.g. the ATC restarted mid-spawn), so try attaching once
+			// more before giving up
+			var attachErr error
+			process, attachErr = container.Attach(taskProcessID, processIO)
+			if attachErr == nil {
+				logger.Info("reattached-after-failed-spawn", lager.Data{"spawn-error": err.Error()})
+				err = nil
+			}
+		}
 	}
 	if err != nil {
 		return err
@@ -295,6 +335,10 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 		action.succeeded = processStatus == 0
 
+		if action.succeeded {
+			action.snapshotCaches(ctx, logger, config, container)
+		}
+
 		return nil
 	}
 }
@@ -333,6 +377,11 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 		return worker.ContainerSpec{}, err
 	}
 
+	tokenEnv, err := action.delegate.BuildTokenEnv(logger)
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
 	containerSpec := worker.ContainerSpec{
 		Platform:  config.Platform,
 		Tags:      action.tags,
@@ -341,7 +390,10 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 		Limits:    worker.ContainerLimits(config.Limits),
 		User:      config.Run.User,
 		Dir:       action.artifactsRoot,
-		Env:       action.envForParams(params),
+		Env:       append(action.env(params), tokenEnv...),
+		Network:   action.network,
+
+		IsolationLabels: action.isolationLabels,
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
@@ -362,6 +414,12 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 			continue
 		}
 
+		if input.Path == "" {
+			if path, found := repository.PathFor(worker.ArtifactName(inputName)); found {
+				input.Path = path
+			}
+		}
+
 		containerSpec.Inputs = append(containerSpec.Inputs, &taskInputSource{
 			config:        input,
 			source:        source,
@@ -375,6 +433,10 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 
 	for _, cacheConfig := range config.Caches {
 		source := newTaskCacheSource(logger, action.teamID, action.jobID, action.stepName, cacheConfig.Path)
+		if action.snapshotsEnabled(cacheConfig) {
+			source.store = action.taskCacheStore
+		}
+
 		containerSpec.Inputs = append(containerSpec.Inputs, &taskCacheInputSource{
 			source:        source,
 			artifactsRoot: action.artifactsRoot,
@@ -390,17 +452,22 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 	return containerSpec, nil
 }
 
+// outputName is the name of the artifact the output is registered as.
+func (action *TaskStep) outputName(output atc.TaskOutputConfig) string {
+	if destinationName, ok := action.outputMapping[output.Name]; ok {
+		return destinationName
+	}
+
+	return output.Name
+}
+
 func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, container worker.Container) error {
 	volumeMounts := container.VolumeMounts()
 
 	logger.Debug("registering-outputs", lager.Data{"outputs": config.Outputs})
 
 	for _, output := range config.Outputs {
-		outputName := output.Name
-		if destinationName, ok := action.outputMapping[output.Name]; ok {
-			outputName = destinationName
-		}
-
+		outputName := action.outputName(output)
 		outputPath := artifactsPath(output, action.artifactsRoot)
 
 		for _, mount := range volumeMounts {
@@ -434,6 +501,82 @@ func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.
 	return nil
 }
 
+// snapshotsEnabled returns whether the cache is warmed up from, and
+// snapshotted to, the task cache store. One-off builds have no caches to
+// share.
+func (action *TaskStep) snapshotsEnabled(cacheConfig atc.CacheConfig) bool {
+	return action.taskCacheStore != nil && action.jobID != 0 && cacheConfig.Snapshot
+}
+
+// snapshotCaches uploads the caches which opted in to snapshots once their
+// latest snapshot is due to be replaced. Failing to do so does not fail the
+// build, as the cache itself is intact on the worker. Uploads are aborted
+// if the build is.
+func (action *TaskStep) snapshotCaches(ctx context.Context, logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	for _, cacheConfig := range config.Caches {
+		if !action.snapshotsEnabled(cacheConfig) {
+			continue
+		}
+
+		key := taskcache.Key{
+			JobID:    action.jobID,
+			StepName: action.stepName,
+			Path:     cacheConfig.Path,
+		}
+
+		for _, volumeMount := range container.VolumeMounts() {
+			if volumeMount.MountPath != filepath.Join(action.artifactsRoot, cacheConfig.Path) {
+				continue
+			}
+
+			err := action.snapshotCache(ctx, logger.Session("snapshot-cache", lager.Data{"path": cacheConfig.Path}), key, volumeMount.Volume)
+			if err != nil {
+				logger.Error("failed-to-snapshot-cache", err, lager.Data{"path": cacheConfig.Path})
+			}
+		}
+	}
+}
+
+func (action *TaskStep) snapshotCache(ctx context.Context, logger lager.Logger, key taskcache.Key, volume worker.Volume) error {
+	due, err := action.taskCacheStore.SnapshotDue(ctx, logger, key)
+	if err != nil {
+		return err
+	}
+
+	if !due {
+		return nil
+	}
+
+	out, err := volume.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	logger.Info("saving")
+
+	return action.taskCacheStore.Save(ctx, logger, key, out)
+}
+
+// env is the task's params along with the build's metadata, e.g. BUILD_ID,
+// as given to resource containers. Params take precedence over metadata of
+// the same name, so that tasks which already set them keep working.
+func (action *TaskStep) env(params map[string]string) []string {
+	env := action.envForParams(params)
+
+	for _, variable := range action.stepMetadata.Env() {
+		name := strings.SplitN(variable, "=", 2)[0]
+		if _, overridden := params[name]; overridden {
+			continue
+		}
+
+		env = append(env, variable)
+	}
+
+	return env
+}
+
 func (TaskStep) envForParams(params map[string]string) []string {
 	env := make([]string, 0, len(params))
 
@@ -542,6 +685,10 @@ type taskCacheSource struct {
 	jobID    int
 	stepName string
 	path     string
+
+	// store, if set, holds snapshots to warm the cache up from when it is
+	// not on the worker
+	store taskcache.Store
 }
 
 func newTaskCacheSource(
@@ -561,7 +708,39 @@ func newTaskCacheSource(
 }
 
 func (src *taskCacheSource) StreamTo(destination worker.ArtifactDestination) error {
-	// cache will be initialized every time on a new worker
+	// cache will be initialized every time on a new worker, starting out
+	// empty unless there is a snapshot of it
+	if src.store == nil {
+		return nil
+	}
+
+	logger := src.logger.Session("warm-up-cache", lager.Data{"path": src.path})
+
+	snapshot, found, err := src.store.Fetch(logger, taskcache.Key{
+		JobID:    src.jobID,
+		StepName: src.stepName,
+		Path:     src.path,
+	})
+	if err != nil {
+		// the cache works just as well cold
+		logger.Error("failed-to-fetch-snapshot", err)
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	defer snapshot.Close()
+
+	err = destination.StreamIn(".", snapshot)
+	if err != nil {
+		// the cache may be left with part of the snapshot, which is no worse
+		// than a stale cache
+		logger.Error("failed-to-stream-in-snapshot", err)
+		return nil
+	}
+
 	return nil
 }
 
@@ -19,6 +19,8 @@ import (
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/taskcache"
+	"github.com/concourse/atc/worker/taskcache/taskcachefakes"
 	"github.com/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -42,6 +44,8 @@ var _ = Describe("TaskStep", func() {
 
 		privileged    exec.Privileged
 		tags          []string
+		network       *atc.NetworkConfig
+		labels        worker.IsolationLabels
 		teamID        int
 		buildID       int
 		planID        atc.PlanID
@@ -52,6 +56,10 @@ var _ = Describe("TaskStep", func() {
 		outputMapping map[string]string
 		variables     creds.Variables
 
+		taskCacheStore taskcache.Store
+
+		stepMetadata testMetadata
+
 		repo  *worker.ArtifactRepository
 		state *execfakes.FakeRunState
 
@@ -74,11 +82,15 @@ var _ = Describe("TaskStep", func() {
 
 		privileged = false
 		tags = []string{"step", "tags"}
+		network = nil
+		labels = worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline", Public: true}
 		teamID = 123
 		planID = atc.PlanID(42)
 		buildID = 1234
 		jobID = 12345
 		configSource = new(execfakes.FakeTaskConfigSource)
+		taskCacheStore = nil
+		stepMetadata = nil
 
 		repo = worker.NewArtifactRepository()
 		state = new(execfakes.FakeRunState)
@@ -118,8 +130,10 @@ var _ = Describe("TaskStep", func() {
 			privileged,
 			configSource,
 			tags,
+			network,
 			inputMapping,
 			outputMapping,
+			labels,
 			"some-artifact-root",
 			imageArtifactName,
 			fakeDelegate,
@@ -129,10 +143,12 @@ var _ = Describe("TaskStep", func() {
 			jobID,
 			"some-task",
 			planID,
+			stepMetadata,
 			containerMetadata,
 			resourceTypes,
 			variables,
 			atc.ContainerLimits{},
+			taskCacheStore,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -276,6 +292,75 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			Context("when the task's network is restricted", func() {
+				BeforeEach(func() {
+					network = &atc.NetworkConfig{
+						Egress:       atc.NetworkEgressNone,
+						AllowedCIDRs: []string{"10.0.0.0/8"},
+					}
+				})
+
+				It("creates the container with the network configuration", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+					_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+					Expect(spec.Network).To(Equal(network))
+				})
+			})
+
+			It("creates the container with the build's isolation labels", func() {
+				Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+				_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+				Expect(spec.IsolationLabels).To(Equal(labels))
+			})
+
+			Context("when the build has metadata", func() {
+				BeforeEach(func() {
+					stepMetadata = testMetadata{"BUILD_ID=1", "BUILD_NAME=42", "SECURE=not-a-param"}
+				})
+
+				It("exposes the metadata to the container via env", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+					_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+					Expect(spec.Env).To(Equal([]string{
+						"SECURE=super-secret-param",
+						"BUILD_ID=1",
+						"BUILD_NAME=42",
+					}))
+				})
+			})
+
+			Context("when the build has a build token", func() {
+				BeforeEach(func() {
+					fakeDelegate.BuildTokenEnvReturns([]string{"VAULT_ADDR=https://vault", "VAULT_TOKEN=some-token"}, nil)
+				})
+
+				It("exposes the token to the container via env", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+					_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+					Expect(spec.Env).To(Equal([]string{
+						"SECURE=super-secret-param",
+						"VAULT_ADDR=https://vault",
+						"VAULT_TOKEN=some-token",
+					}))
+				})
+			})
+
+			Context("when issuing the build token fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeDelegate.BuildTokenEnvReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(disaster))
+				})
+
+				It("does not create a container", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when an exit status is already saved off", func() {
 				BeforeEach(func() {
 					fakeContainer.PropertyStub = func(name string) (string, error) {
@@ -556,6 +641,30 @@ var _ = Describe("TaskStep", func() {
 						})
 					})
 
+					Context("when the inputs were fetched with a path", func() {
+						BeforeEach(func() {
+							repo.RegisterSource("some-input", inputSource)
+							repo.RegisterPath("some-input", "some-input-fetched-path")
+							repo.RegisterSource("some-other-input", otherInputSource)
+							repo.RegisterPath("some-other-input", "some/other-input-fetched-path")
+						})
+
+						It("mounts inputs without a configured path at the fetched path", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Inputs).To(HaveLen(2))
+							for _, input := range spec.Inputs {
+								switch input.DestinationPath() {
+								case "some-artifact-root/some-input-configured-path":
+									Expect(input.Source()).To(Equal(inputSource))
+								case "some-artifact-root/some/other-input-fetched-path":
+									Expect(input.Source()).To(Equal(otherInputSource))
+								default:
+									panic("unknown input: " + input.DestinationPath())
+								}
+							}
+						})
+					})
+
 					Context("when any of the inputs are missing", func() {
 						BeforeEach(func() {
 							repo.RegisterSource("some-input", inputSource)
@@ -730,6 +839,153 @@ var _ = Describe("TaskStep", func() {
 							Expect(fakeVolume2.InitializeTaskCacheCallCount()).To(Equal(0))
 						})
 					})
+
+					Context("when a cache opts in to snapshots and a store is configured", func() {
+						var fakeTaskCacheStore *taskcachefakes.FakeStore
+
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Platform:  "some-platform",
+								RootfsURI: "some-image",
+								Run:       atc.TaskRunConfig{},
+								Caches: []atc.CacheConfig{
+									{Path: "some-path-1", Snapshot: true},
+									{Path: "some-path-2"},
+								},
+							}, nil)
+
+							fakeTaskCacheStore = new(taskcachefakes.FakeStore)
+							taskCacheStore = fakeTaskCacheStore
+						})
+
+						cacheSource := func(path string) worker.ArtifactSource {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							for _, input := range spec.Inputs {
+								if input.DestinationPath() == "some-artifact-root/"+path {
+									return input.Source()
+								}
+							}
+
+							Fail("no input for cache " + path)
+							return nil
+						}
+
+						Context("when the cache misses on the worker", func() {
+							var fakeDestination *workerfakes.FakeArtifactDestination
+
+							BeforeEach(func() {
+								fakeDestination = new(workerfakes.FakeArtifactDestination)
+							})
+
+							Context("when there is a snapshot", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.FetchReturns(ioutil.NopCloser(strings.NewReader("some-snapshot")), true, nil)
+								})
+
+								It("warms the cache up from the snapshot", func() {
+									err := cacheSource("some-path-1").StreamTo(fakeDestination)
+									Expect(err).ToNot(HaveOccurred())
+
+									_, key := fakeTaskCacheStore.FetchArgsForCall(0)
+									Expect(key).To(Equal(taskcache.Key{
+										JobID:    jobID,
+										StepName: "some-task",
+										Path:     "some-path-1",
+									}))
+
+									Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+									dest, src := fakeDestination.StreamInArgsForCall(0)
+									Expect(dest).To(Equal("."))
+									Expect(ioutil.ReadAll(src)).To(Equal([]byte("some-snapshot")))
+								})
+
+								It("leaves caches which did not opt in cold", func() {
+									err := cacheSource("some-path-2").StreamTo(fakeDestination)
+									Expect(err).ToNot(HaveOccurred())
+
+									Expect(fakeTaskCacheStore.FetchCallCount()).To(BeZero())
+									Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when streaming in the snapshot fails", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.FetchReturns(ioutil.NopCloser(strings.NewReader("some-snapshot")), true, nil)
+									fakeDestination.StreamInReturns(errors.New("nope"))
+								})
+
+								It("leaves the cache as it is rather than failing", func() {
+									err := cacheSource("some-path-1").StreamTo(fakeDestination)
+									Expect(err).ToNot(HaveOccurred())
+									Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when fetching the snapshot fails", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.FetchReturns(nil, false, errors.New("nope"))
+								})
+
+								It("leaves the cache cold", func() {
+									err := cacheSource("some-path-1").StreamTo(fakeDestination)
+									Expect(err).ToNot(HaveOccurred())
+									Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when a snapshot is due", func() {
+							BeforeEach(func() {
+								fakeTaskCacheStore.SnapshotDueReturns(true, nil)
+								fakeVolume1.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-cache")), nil)
+							})
+
+							It("saves a snapshot of the cache", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+
+								Expect(fakeTaskCacheStore.SaveCallCount()).To(Equal(1))
+								saveCtx, _, key, snapshot := fakeTaskCacheStore.SaveArgsForCall(0)
+								Expect(saveCtx).To(Equal(ctx))
+								Expect(key.Path).To(Equal("some-path-1"))
+								Expect(ioutil.ReadAll(snapshot)).To(Equal([]byte("some-cache")))
+
+								Expect(fakeVolume2.StreamOutCallCount()).To(BeZero())
+							})
+
+							Context("when the task fails", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(1, nil)
+								})
+
+								It("does not save a snapshot", func() {
+									Expect(fakeTaskCacheStore.SaveCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when saving the snapshot fails", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.SaveReturns(errors.New("nope"))
+								})
+
+								It("does not fail the build", func() {
+									Expect(stepErr).ToNot(HaveOccurred())
+									Expect(taskStep.Succeeded()).To(BeTrue())
+								})
+							})
+						})
+
+						Context("when a snapshot is not yet due", func() {
+							BeforeEach(func() {
+								fakeTaskCacheStore.SnapshotDueReturns(false, nil)
+							})
+
+							It("does not save a snapshot", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(fakeVolume1.StreamOutCallCount()).To(BeZero())
+								Expect(fakeTaskCacheStore.SaveCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 
 				Context("when the configuration specifies paths for outputs", func() {
@@ -1146,6 +1402,13 @@ var _ = Describe("TaskStep", func() {
 						sourceMap := repo.AsMap()
 						Expect(sourceMap).To(ConsistOf(artifactSource))
 					})
+
+					It("records the output's mount path by its specific name on the container", func() {
+						_, _, _, _, createdMetadata, _, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+						Expect(createdMetadata.Outputs).To(Equal(db.ContainerOutputs{
+							"specific-remapped-output": fakeMountPath,
+						}))
+					})
 				})
 
 				Context("when an image artifact name is specified", func() {
@@ -1518,6 +1781,30 @@ var _ = Describe("TaskStep", func() {
 					It("is not successful", func() {
 						Expect(taskStep.Succeeded()).To(BeFalse())
 					})
+
+					Context("when the process was spawned after all", func() {
+						var spawnedProcess *gardenfakes.FakeProcess
+
+						BeforeEach(func() {
+							spawnedProcess = new(gardenfakes.FakeProcess)
+							spawnedProcess.WaitReturns(0, nil)
+
+							fakeContainer.AttachReturnsOnCall(1, spawnedProcess, nil)
+						})
+
+						It("attaches to it", func() {
+							Expect(fakeContainer.AttachCallCount()).To(Equal(2))
+
+							pid, _ := fakeContainer.AttachArgsForCall(1)
+							Expect(pid).To(Equal("task"))
+						})
+
+						It("waits for it to exit", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(spawnedProcess.WaitCallCount()).To(Equal(1))
+							Expect(taskStep.Succeeded()).To(BeTrue())
+						})
+					})
 				})
 			})
 		})
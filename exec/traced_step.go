@@ -0,0 +1,34 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/atc/tracing"
+)
+
+type TracedStep struct {
+	Step
+
+	operationName string
+	attrs         tracing.Attrs
+}
+
+// Traced runs the step in a span, as a child of the build's span.
+func Traced(step Step, operationName string, attrs tracing.Attrs) Step {
+	return TracedStep{
+		Step: step,
+
+		operationName: operationName,
+		attrs:         attrs,
+	}
+}
+
+func (step TracedStep) Run(ctx context.Context, state RunState) error {
+	span, ctx := tracing.StartSpan(ctx, step.operationName, step.attrs)
+
+	err := step.Step.Run(ctx, state)
+
+	tracing.End(span, err)
+
+	return err
+}
@@ -0,0 +1,82 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/tracing"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracedStep", func() {
+	var (
+		ctx context.Context
+
+		tracer   *mocktracer.MockTracer
+		fakeStep *execfakes.FakeStep
+		state    *execfakes.FakeRunState
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		tracer = mocktracer.New()
+		opentracing.SetGlobalTracer(tracer)
+
+		fakeStep = new(execfakes.FakeStep)
+		state = new(execfakes.FakeRunState)
+
+		step = Traced(fakeStep, "task", tracing.Attrs{"name": "some-task"})
+	})
+
+	AfterEach(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	Describe("Run", func() {
+		var runErr error
+
+		JustBeforeEach(func() {
+			runErr = step.Run(ctx, state)
+		})
+
+		It("runs the inner step in a span", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+
+			stepCtx, stepState := fakeStep.RunArgsForCall(0)
+			Expect(opentracing.SpanFromContext(stepCtx)).NotTo(BeNil())
+			Expect(stepState).To(Equal(state))
+
+			spans := tracer.FinishedSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].OperationName).To(Equal("task"))
+			Expect(spans[0].Tag("name")).To(Equal("some-task"))
+		})
+
+		Context("when the inner step errors", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+
+			It("marks the span as failed", func() {
+				spans := tracer.FinishedSpans()
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Tag("error")).To(Equal(true))
+			})
+		})
+	})
+})
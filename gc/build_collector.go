@@ -4,19 +4,22 @@ import (
 	"context"
 
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
 )
 
 type buildCollector struct {
-	buildFactory buildFactory
+	buildFactory       buildFactory
+	containerRetention db.ContainerRetentionPolicy
 }
 
 type buildFactory interface {
-	MarkNonInterceptibleBuilds() error
+	MarkNonInterceptibleBuilds(db.ContainerRetentionPolicy) error
 }
 
-func NewBuildCollector(buildFactory buildFactory) *buildCollector {
+func NewBuildCollector(buildFactory buildFactory, containerRetention db.ContainerRetentionPolicy) *buildCollector {
 	return &buildCollector{
-		buildFactory: buildFactory,
+		buildFactory:       buildFactory,
+		containerRetention: containerRetention,
 	}
 }
 
@@ -26,5 +29,5 @@ func (b *buildCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	return b.buildFactory.MarkNonInterceptibleBuilds()
+	return b.buildFactory.MarkNonInterceptibleBuilds(b.containerRetention)
 }
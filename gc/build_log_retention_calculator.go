@@ -4,6 +4,8 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+//go:generate counterfeiter . BuildLogRetentionCalculator
+
 type BuildLogRetentionCalculator interface {
 	BuildLogsToRetain(db.Job) int
 }
@@ -14,6 +14,7 @@ type Collector interface {
 
 type aggregateCollector struct {
 	buildCollector                      Collector
+	pipelineCollector                   Collector
 	workerCollector                     Collector
 	resourceCacheUseCollector           Collector
 	resourceConfigCollector             Collector
@@ -25,6 +26,7 @@ type aggregateCollector struct {
 
 func NewCollector(
 	buildCollector Collector,
+	pipelines Collector,
 	workers Collector,
 	resourceCacheUses Collector,
 	resourceConfigs Collector,
@@ -35,6 +37,7 @@ func NewCollector(
 ) Collector {
 	return &aggregateCollector{
 		buildCollector:                      buildCollector,
+		pipelineCollector:                   pipelines,
 		workerCollector:                     workers,
 		resourceCacheUseCollector:           resourceCacheUses,
 		resourceConfigCollector:             resourceConfigs,
@@ -55,6 +58,11 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-run-build-collector", err)
 	}
 
+	err = c.pipelineCollector.Run(ctx)
+	if err != nil {
+		logger.Error("failed-to-run-pipeline-collector", err)
+	}
+
 	err = c.workerCollector.Run(ctx)
 	if err != nil {
 		logger.Error("failed-to-run-worker-collector", err)
@@ -16,6 +16,7 @@ var _ = Describe("Aggregate Collector", func() {
 		subject Collector
 
 		fakeBuildCollector                      *gcfakes.FakeCollector
+		fakePipelineCollector                   *gcfakes.FakeCollector
 		fakeWorkerCollector                     *gcfakes.FakeCollector
 		fakeResourceCacheUseCollector           *gcfakes.FakeCollector
 		fakeResourceConfigCollector             *gcfakes.FakeCollector
@@ -30,6 +31,7 @@ var _ = Describe("Aggregate Collector", func() {
 
 	BeforeEach(func() {
 		fakeBuildCollector = new(gcfakes.FakeCollector)
+		fakePipelineCollector = new(gcfakes.FakeCollector)
 		fakeWorkerCollector = new(gcfakes.FakeCollector)
 		fakeResourceCacheUseCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCollector = new(gcfakes.FakeCollector)
@@ -40,6 +42,7 @@ var _ = Describe("Aggregate Collector", func() {
 
 		subject = NewCollector(
 			fakeBuildCollector,
+			fakePipelineCollector,
 			fakeWorkerCollector,
 			fakeResourceCacheUseCollector,
 			fakeResourceConfigCollector,
@@ -61,6 +64,30 @@ var _ = Describe("Aggregate Collector", func() {
 			Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
 		})
 
+		It("runs the pipeline collector", func() {
+			Expect(fakePipelineCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the pipeline collector errors", func() {
+			BeforeEach(func() {
+				fakePipelineCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeWorkerCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeResourceCacheUseCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeResourceConfigCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeResourceCacheCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the build collector errors", func() {
 			BeforeEach(func() {
 				fakeBuildCollector.RunReturns(disaster)
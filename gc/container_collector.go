@@ -158,7 +158,7 @@ func destroyCreatedContainers(logger lager.Logger, containers []db.CreatedContai
 					"worker":    workerClient.Name(),
 				})
 
-				_, err = markHijackedContainerAsDestroying(cLog, container, workerClient.GardenClient())
+				_, err = markHijackedContainerAsDestroying(cLog, container, workerClient.Runtime())
 				if err != nil {
 					cLog.Error("failed-to-transition", err)
 					return
@@ -182,10 +182,10 @@ func destroyCreatedContainers(logger lager.Logger, containers []db.CreatedContai
 func markHijackedContainerAsDestroying(
 	logger lager.Logger,
 	hijackedContainer db.CreatedContainer,
-	gardenClient garden.Client,
+	runtime worker.Runtime,
 ) (db.DestroyingContainer, error) {
 
-	gardenContainer, found, err := findContainer(gardenClient, hijackedContainer.Handle())
+	gardenContainer, found, err := findContainer(runtime, hijackedContainer.Handle())
 	if err != nil {
 		logger.Error("failed-to-lookup-container-in-garden", err)
 		return nil, err
@@ -218,8 +218,8 @@ func markHijackedContainerAsDestroying(
 	return nil, nil
 }
 
-func findContainer(gardenClient garden.Client, handle string) (garden.Container, bool, error) {
-	gardenContainer, err := gardenClient.Lookup(handle)
+func findContainer(runtime worker.Runtime, handle string) (garden.Container, bool, error) {
+	gardenContainer, err := runtime.Lookup(handle)
 	if err != nil {
 		if _, ok := err.(garden.ContainerNotFoundError); ok {
 			return nil, false, nil
@@ -37,7 +37,7 @@ var _ = Describe("ContainerCollector", func() {
 
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeGardenClient = new(gardenfakes.FakeClient)
-		fakeWorker.GardenClientReturns(fakeGardenClient)
+		fakeWorker.RuntimeReturns(fakeGardenClient)
 		fakeJobRunner = new(gcfakes.FakeWorkerJobRunner)
 		fakeJobRunner.TryStub = func(logger lager.Logger, workerName string, job gc.Job) {
 			job.Run(fakeWorker)
@@ -0,0 +1,117 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+type containerReaper struct {
+	containerRepository db.ContainerRepository
+	jobRunner           WorkerJobRunner
+	batchSize           int
+	destroyAttempts     int
+}
+
+// NewContainerReaper destroys containers which have been marked as destroying
+// directly on their workers, rather than waiting for each worker to destroy
+// them and report back, so that containers whose destruction failed do not
+// linger.
+//
+// At most batchSize containers are destroyed per worker on each run, and the
+// job runner limits how many run against a worker at once.
+func NewContainerReaper(
+	containerRepository db.ContainerRepository,
+	jobRunner WorkerJobRunner,
+	batchSize int,
+	destroyAttempts int,
+) Collector {
+	if destroyAttempts < 1 {
+		destroyAttempts = 1
+	}
+
+	return &containerReaper{
+		containerRepository: containerRepository,
+		jobRunner:           jobRunner,
+		batchSize:           batchSize,
+		destroyAttempts:     destroyAttempts,
+	}
+}
+
+func (r *containerReaper) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("container-reaper")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	destroyingContainers, err := r.containerRepository.FindAllDestroyingContainers()
+	if err != nil {
+		logger.Error("failed-to-find-destroying-containers", err)
+		return err
+	}
+
+	workerContainers := map[string][]db.DestroyingContainer{}
+	for _, container := range destroyingContainers {
+		batch := workerContainers[container.WorkerName()]
+		if len(batch) < r.batchSize {
+			workerContainers[container.WorkerName()] = append(batch, container)
+		}
+	}
+
+	for workerName, containers := range workerContainers {
+		r.jobRunner.Try(logger,
+			workerName,
+			&job{
+				JobName: fmt.Sprintf("reap-containers-%s", workerName),
+				RunFunc: r.reapContainers(logger, containers),
+			},
+		)
+	}
+
+	return nil
+}
+
+func (r *containerReaper) reapContainers(logger lager.Logger, containers []db.DestroyingContainer) func(worker.Worker) {
+	return func(workerClient worker.Worker) {
+		runtime := workerClient.Runtime()
+
+		for _, container := range containers {
+			cLog := logger.Session("reap", lager.Data{
+				"container": container.Handle(),
+				"worker":    workerClient.Name(),
+			})
+
+			err := r.destroy(runtime, container.Handle())
+			if err != nil {
+				cLog.Error("failed-to-destroy-container", err)
+				continue
+			}
+
+			_, err = container.Destroy()
+			if err != nil {
+				cLog.Error("failed-to-remove-container", err)
+			}
+		}
+	}
+}
+
+func (r *containerReaper) destroy(runtime worker.Runtime, handle string) error {
+	var err error
+	for attempt := 0; attempt < r.destroyAttempts; attempt++ {
+		err = runtime.Destroy(handle)
+		if err == nil {
+			return nil
+		}
+
+		if _, ok := err.(garden.ContainerNotFoundError); ok {
+			return nil
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,132 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+	"github.com/concourse/atc/gc/gcfakes"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerReaper", func() {
+	var (
+		fakeContainerRepository *dbfakes.FakeContainerRepository
+		fakeJobRunner           *gcfakes.FakeWorkerJobRunner
+
+		fakeWorker       *workerfakes.FakeWorker
+		fakeGardenClient *gardenfakes.FakeClient
+
+		container1 *dbfakes.FakeDestroyingContainer
+		container2 *dbfakes.FakeDestroyingContainer
+
+		reaper gc.Collector
+		err    error
+	)
+
+	BeforeEach(func() {
+		fakeContainerRepository = new(dbfakes.FakeContainerRepository)
+
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeGardenClient = new(gardenfakes.FakeClient)
+		fakeWorker.RuntimeReturns(fakeGardenClient)
+
+		fakeJobRunner = new(gcfakes.FakeWorkerJobRunner)
+		fakeJobRunner.TryStub = func(logger lager.Logger, workerName string, job gc.Job) {
+			job.Run(fakeWorker)
+		}
+
+		container1 = new(dbfakes.FakeDestroyingContainer)
+		container1.HandleReturns("some-handle-1")
+		container1.WorkerNameReturns("some-worker")
+
+		container2 = new(dbfakes.FakeDestroyingContainer)
+		container2.HandleReturns("some-handle-2")
+		container2.WorkerNameReturns("some-worker")
+
+		fakeContainerRepository.FindAllDestroyingContainersReturns([]db.DestroyingContainer{container1, container2}, nil)
+
+		reaper = gc.NewContainerReaper(fakeContainerRepository, fakeJobRunner, 10, 3)
+	})
+
+	JustBeforeEach(func() {
+		err = reaper.Run(context.TODO())
+	})
+
+	It("destroys the containers on their worker and removes them", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeJobRunner.TryCallCount()).To(Equal(1))
+		_, workerName, _ := fakeJobRunner.TryArgsForCall(0)
+		Expect(workerName).To(Equal("some-worker"))
+
+		Expect(fakeGardenClient.DestroyCallCount()).To(Equal(2))
+		Expect(fakeGardenClient.DestroyArgsForCall(0)).To(Equal("some-handle-1"))
+		Expect(fakeGardenClient.DestroyArgsForCall(1)).To(Equal("some-handle-2"))
+
+		Expect(container1.DestroyCallCount()).To(Equal(1))
+		Expect(container2.DestroyCallCount()).To(Equal(1))
+	})
+
+	Context("when there are more containers than the batch size", func() {
+		BeforeEach(func() {
+			reaper = gc.NewContainerReaper(fakeContainerRepository, fakeJobRunner, 1, 3)
+		})
+
+		It("only destroys a batch of them", func() {
+			Expect(fakeGardenClient.DestroyCallCount()).To(Equal(1))
+			Expect(container2.DestroyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the container is already gone", func() {
+		BeforeEach(func() {
+			fakeGardenClient.DestroyReturns(garden.ContainerNotFoundError{Handle: "some-handle-1"})
+		})
+
+		It("removes it", func() {
+			Expect(container1.DestroyCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when destroying a container fails", func() {
+		BeforeEach(func() {
+			fakeGardenClient.DestroyStub = func(handle string) error {
+				if handle == "some-handle-1" {
+					return errors.New("nope")
+				}
+
+				return nil
+			}
+		})
+
+		It("retries it", func() {
+			Expect(fakeGardenClient.DestroyCallCount()).To(Equal(4))
+		})
+
+		It("leaves it to be reaped again", func() {
+			Expect(container1.DestroyCallCount()).To(BeZero())
+			Expect(container2.DestroyCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when finding the containers fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeContainerRepository.FindAllDestroyingContainersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
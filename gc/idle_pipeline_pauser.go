@@ -0,0 +1,54 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+)
+
+type idlePipelinePauser struct {
+	pipelineFactory db.PipelineFactory
+	idleAfter       time.Duration
+}
+
+// NewIdlePipelinePauser pauses pipelines which have not been configured, and
+// none of whose jobs have run, for the given duration, so that abandoned
+// pipelines stop checking their resources. A metric is emitted for each
+// pipeline which is paused.
+func NewIdlePipelinePauser(pipelineFactory db.PipelineFactory, idleAfter time.Duration) Collector {
+	return &idlePipelinePauser{
+		pipelineFactory: pipelineFactory,
+		idleAfter:       idleAfter,
+	}
+}
+
+func (ipp *idlePipelinePauser) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("idle-pipeline-pauser")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	pipelines, err := ipp.pipelineFactory.PauseIdlePipelines(ipp.idleAfter)
+	if err != nil {
+		logger.Error("failed-to-pause-idle-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		logger.Info("paused-idle-pipeline", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		metric.PipelinePausedByInactivity{
+			PipelineName: pipeline.Name(),
+			TeamName:     pipeline.TeamName(),
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdlePipelinePauser", func() {
+	var (
+		collector           Collector
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		collector = NewIdlePipelinePauser(fakePipelineFactory, time.Hour)
+
+		fakePipelineFactory.PauseIdlePipelinesReturns([]db.Pipeline{new(dbfakes.FakePipeline)}, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run(context.TODO())
+	})
+
+	It("pauses pipelines which have been idle for the idle period", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakePipelineFactory.PauseIdlePipelinesCallCount()).To(Equal(1))
+		Expect(fakePipelineFactory.PauseIdlePipelinesArgsForCall(0)).To(Equal(time.Hour))
+	})
+
+	Context("when pausing fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipelineFactory.PauseIdlePipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
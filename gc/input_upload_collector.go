@@ -0,0 +1,41 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type inputUploadCollector struct {
+	uploadFactory db.BuildInputUploadFactory
+}
+
+// NewInputUploadCollector removes the input uploads of builds which have
+// finished, whether or not the upload completed, so that their volumes are
+// collected.
+func NewInputUploadCollector(uploadFactory db.BuildInputUploadFactory) Collector {
+	return &inputUploadCollector{
+		uploadFactory: uploadFactory,
+	}
+}
+
+func (iuc *inputUploadCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("input-upload-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := iuc.uploadFactory.RemoveFinished()
+	if err != nil {
+		logger.Error("failed-to-remove-finished-input-uploads", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Info("removed-finished-input-uploads", lager.Data{"removed": removed})
+	}
+
+	return nil
+}
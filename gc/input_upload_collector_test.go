@@ -0,0 +1,49 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InputUploadCollector", func() {
+	var (
+		collector              Collector
+		fakeInputUploadFactory *dbfakes.FakeBuildInputUploadFactory
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeInputUploadFactory = new(dbfakes.FakeBuildInputUploadFactory)
+		collector = NewInputUploadCollector(fakeInputUploadFactory)
+
+		fakeInputUploadFactory.RemoveFinishedReturns(2, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run(context.TODO())
+	})
+
+	It("removes the uploads of finished builds", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeInputUploadFactory.RemoveFinishedCallCount()).To(Equal(1))
+	})
+
+	Context("when removing fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeInputUploadFactory.RemoveFinishedReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
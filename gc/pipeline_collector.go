@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type pipelineCollector struct {
+	pipelineFactory db.PipelineFactory
+	gracePeriod     time.Duration
+}
+
+func NewPipelineCollector(pipelineFactory db.PipelineFactory, gracePeriod time.Duration) Collector {
+	return &pipelineCollector{
+		pipelineFactory: pipelineFactory,
+		gracePeriod:     gracePeriod,
+	}
+}
+
+func (pc *pipelineCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipeline-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := pc.pipelineFactory.PurgeDeletedPipelines(pc.gracePeriod)
+	if err != nil {
+		logger.Error("failed-to-purge-deleted-pipelines", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineCollector", func() {
+	var (
+		collector           Collector
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		collector = NewPipelineCollector(fakePipelineFactory, time.Hour)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run(context.TODO())
+	})
+
+	It("purges pipelines deleted longer than the grace period ago", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakePipelineFactory.PurgeDeletedPipelinesCallCount()).To(Equal(1))
+		Expect(fakePipelineFactory.PurgeDeletedPipelinesArgsForCall(0)).To(Equal(time.Hour))
+	})
+
+	Context("when purging fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipelineFactory.PurgeDeletedPipelinesReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -20,7 +20,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 
 	BeforeEach(func() {
 		collector = gc.NewResourceCacheCollector(resourceCacheLifecycle)
-		buildCollector = gc.NewBuildCollector(buildFactory)
+		buildCollector = gc.NewBuildCollector(buildFactory, db.ContainerRetentionPolicy{})
 	})
 
 	Describe("Run", func() {
@@ -19,7 +19,7 @@ var _ = Describe("ResourceCacheUseCollector", func() {
 
 	BeforeEach(func() {
 		collector = gc.NewResourceCacheUseCollector(resourceCacheLifecycle)
-		buildCollector = gc.NewBuildCollector(buildFactory)
+		buildCollector = gc.NewBuildCollector(buildFactory, db.ContainerRetentionPolicy{})
 	})
 
 	Describe("Run", func() {
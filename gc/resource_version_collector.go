@@ -0,0 +1,75 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type resourceVersionCollector struct {
+	pipelineFactory     db.PipelineFactory
+	defaultHistoryLimit int
+}
+
+// NewResourceVersionCollector prunes the version history of each resource
+// down to its version_history_limit, or the given default if it has none. A
+// limit of 0 keeps every version.
+func NewResourceVersionCollector(pipelineFactory db.PipelineFactory, defaultHistoryLimit int) Collector {
+	return &resourceVersionCollector{
+		pipelineFactory:     pipelineFactory,
+		defaultHistoryLimit: defaultHistoryLimit,
+	}
+}
+
+func (rvc *resourceVersionCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("resource-version-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	pipelines, err := rvc.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			return err
+		}
+
+		for _, resource := range resources {
+			limit := resource.VersionHistoryLimit()
+			if limit == 0 {
+				limit = rvc.defaultHistoryLimit
+			}
+
+			if limit == 0 {
+				continue
+			}
+
+			pruned, err := resource.PruneVersions(limit)
+			if err != nil {
+				logger.Error("failed-to-prune-versions", err, lager.Data{
+					"pipeline": pipeline.Name(),
+					"resource": resource.Name(),
+				})
+				return err
+			}
+
+			if pruned > 0 {
+				logger.Debug("pruned-versions", lager.Data{
+					"pipeline": pipeline.Name(),
+					"resource": resource.Name(),
+					"pruned":   pruned,
+				})
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceVersionCollector", func() {
+	var (
+		collector           Collector
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+		fakePipeline        *dbfakes.FakePipeline
+
+		limitedResource   *dbfakes.FakeResource
+		unlimitedResource *dbfakes.FakeResource
+
+		defaultLimit int
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+		limitedResource = new(dbfakes.FakeResource)
+		limitedResource.VersionHistoryLimitReturns(10)
+
+		unlimitedResource = new(dbfakes.FakeResource)
+
+		fakePipeline.ResourcesReturns(db.Resources{limitedResource, unlimitedResource}, nil)
+
+		defaultLimit = 0
+	})
+
+	JustBeforeEach(func() {
+		collector = NewResourceVersionCollector(fakePipelineFactory, defaultLimit)
+		err = collector.Run(context.TODO())
+	})
+
+	It("prunes resources down to their own limit", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limitedResource.PruneVersionsCallCount()).To(Equal(1))
+		Expect(limitedResource.PruneVersionsArgsForCall(0)).To(Equal(10))
+	})
+
+	It("does not prune resources without a limit", func() {
+		Expect(unlimitedResource.PruneVersionsCallCount()).To(BeZero())
+	})
+
+	Context("when a default limit is configured", func() {
+		BeforeEach(func() {
+			defaultLimit = 100
+		})
+
+		It("prunes resources without a limit down to the default", func() {
+			Expect(unlimitedResource.PruneVersionsCallCount()).To(Equal(1))
+			Expect(unlimitedResource.PruneVersionsArgsForCall(0)).To(Equal(100))
+		})
+
+		It("still prefers the resource's own limit", func() {
+			Expect(limitedResource.PruneVersionsArgsForCall(0)).To(Equal(10))
+		})
+	})
+
+	Context("when pruning fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			limitedResource.PruneVersionsReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when getting the resources fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipeline.ResourcesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
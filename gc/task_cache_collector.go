@@ -0,0 +1,44 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type taskCacheCollector struct {
+	taskCacheFactory db.WorkerTaskCacheFactory
+	idleAfter        time.Duration
+}
+
+// NewTaskCacheCollector removes task caches which have not been used by a
+// build for the given duration, so that the volumes of caches for steps
+// which no longer run are collected.
+func NewTaskCacheCollector(taskCacheFactory db.WorkerTaskCacheFactory, idleAfter time.Duration) Collector {
+	return &taskCacheCollector{
+		taskCacheFactory: taskCacheFactory,
+		idleAfter:        idleAfter,
+	}
+}
+
+func (tcc *taskCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("task-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := tcc.taskCacheFactory.RemoveIdle(tcc.idleAfter)
+	if err != nil {
+		logger.Error("failed-to-remove-idle-task-caches", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Info("removed-idle-task-caches", lager.Data{"removed": removed})
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheCollector", func() {
+	var (
+		collector            Collector
+		fakeTaskCacheFactory *dbfakes.FakeWorkerTaskCacheFactory
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeTaskCacheFactory = new(dbfakes.FakeWorkerTaskCacheFactory)
+		collector = NewTaskCacheCollector(fakeTaskCacheFactory, 24*time.Hour)
+
+		fakeTaskCacheFactory.RemoveIdleReturns(2, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run(context.TODO())
+	})
+
+	It("removes task caches which have not been used for the idle period", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeTaskCacheFactory.RemoveIdleCallCount()).To(Equal(1))
+		Expect(fakeTaskCacheFactory.RemoveIdleArgsForCall(0)).To(Equal(24 * time.Hour))
+	})
+
+	Context("when removing fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeTaskCacheFactory.RemoveIdleReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
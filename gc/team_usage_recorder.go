@@ -0,0 +1,39 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type teamUsageRecorder struct {
+	recorder db.TeamUsageRecorder
+	interval time.Duration
+}
+
+// NewTeamUsageRecorder records the time used by each team's running builds
+// and containers on every run. Gaps of more than twice the interval, e.g.
+// while every ATC was down, are not counted.
+func NewTeamUsageRecorder(recorder db.TeamUsageRecorder, interval time.Duration) Collector {
+	return &teamUsageRecorder{
+		recorder: recorder,
+		interval: interval,
+	}
+}
+
+func (tur *teamUsageRecorder) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("team-usage-recorder")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := tur.recorder.Record(2 * tur.interval)
+	if err != nil {
+		logger.Error("failed-to-record-team-usage", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamUsageRecorder", func() {
+	var (
+		recorder              Collector
+		fakeTeamUsageRecorder *dbfakes.FakeTeamUsageRecorder
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeTeamUsageRecorder = new(dbfakes.FakeTeamUsageRecorder)
+		recorder = NewTeamUsageRecorder(fakeTeamUsageRecorder, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		err = recorder.Run(context.TODO())
+	})
+
+	It("records usage, counting at most twice the interval", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeTeamUsageRecorder.RecordCallCount()).To(Equal(1))
+		Expect(fakeTeamUsageRecorder.RecordArgsForCall(0)).To(Equal(2 * time.Minute))
+	})
+
+	Context("when recording fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeTeamUsageRecorder.RecordReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ErrCheckTimedOut is reported for checks which do not return within the
+// timeout, e.g. while the database is unreachable and queries are retried.
+var ErrCheckTimedOut = errors.New("check timed out")
+
+// Check returns an error if the subsystem it checks is not ready to serve
+// requests.
+type Check func() error
+
+// Checks are the readiness checks registered by each subsystem.
+type Checks struct {
+	timeout time.Duration
+
+	checksL sync.RWMutex
+	checks  map[string]Check
+}
+
+type CheckResult struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+
+	// Error is only logged, as the readiness endpoint is not authenticated
+	// and errors may reveal details of the deployment.
+	Error string `json:"-"`
+}
+
+type Readiness struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func NewChecks(timeout time.Duration) *Checks {
+	return &Checks{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds a check, replacing any previously registered with the same
+// name.
+func (checks *Checks) Register(name string, check Check) {
+	checks.checksL.Lock()
+	checks.checks[name] = check
+	checks.checksL.Unlock()
+}
+
+// Run runs every check concurrently and reports ready only if all of them
+// pass.
+func (checks *Checks) Run() Readiness {
+	checks.checksL.RLock()
+	names := []string{}
+	for name := range checks.checks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	results := make([]CheckResult, len(names))
+
+	wg := new(sync.WaitGroup)
+	for i, name := range names {
+		wg.Add(1)
+
+		go func(i int, name string, check Check) {
+			defer wg.Done()
+
+			results[i] = CheckResult{Name: name, Ready: true}
+
+			err := checks.run(check)
+			if err != nil {
+				results[i].Ready = false
+				results[i].Error = err.Error()
+			}
+		}(i, name, checks.checks[name])
+	}
+	checks.checksL.RUnlock()
+
+	wg.Wait()
+
+	readiness := Readiness{Ready: true, Checks: results}
+	for _, result := range results {
+		if !result.Ready {
+			readiness.Ready = false
+		}
+	}
+
+	return readiness
+}
+
+func (checks *Checks) run(check Check) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- check()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(checks.timeout):
+		return ErrCheckTimedOut
+	}
+}
+
+// NewLivenessHandler responds OK for as long as the process is able to serve
+// requests at all.
+func NewLivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+}
+
+// NewReadinessHandler runs the checks, responding with 503 if any of them
+// fail so that the instance is taken out of rotation until they pass. Only
+// whether each check passed is returned; why one failed is logged.
+func NewReadinessHandler(logger lager.Logger, checks *Checks) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readiness := checks.Run()
+
+		status := http.StatusOK
+		if !readiness.Ready {
+			status = http.StatusServiceUnavailable
+
+			for _, result := range readiness.Checks {
+				if !result.Ready {
+					logger.Info("not-ready", lager.Data{"check": result.Name, "error": result.Error})
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		err := json.NewEncoder(w).Encode(readiness)
+		if err != nil {
+			logger.Error("failed-to-encode-readiness", err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/health"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Checks", func() {
+	var (
+		checks *health.Checks
+		logger *lagertest.TestLogger
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		checks = health.NewChecks(100 * time.Millisecond)
+		logger = lagertest.NewTestLogger("test")
+
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", health.NewLivenessHandler())
+		mux.Handle("/ready", health.NewReadinessHandler(logger, checks))
+
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	getReadiness := func() (int, health.Readiness) {
+		response, err := http.Get(server.URL + "/ready")
+		Expect(err).NotTo(HaveOccurred())
+
+		defer response.Body.Close()
+
+		Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+		var readiness health.Readiness
+		err = json.NewDecoder(response.Body).Decode(&readiness)
+		Expect(err).NotTo(HaveOccurred())
+
+		return response.StatusCode, readiness
+	}
+
+	Describe("GET /healthz", func() {
+		It("returns 200 even if checks fail", func() {
+			checks.Register("some-check", func() error { return errors.New("nope") })
+
+			response, err := http.Get(server.URL + "/healthz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("GET /ready", func() {
+		Context("when every check passes", func() {
+			BeforeEach(func() {
+				checks.Register("some-check", func() error { return nil })
+				checks.Register("another-check", func() error { return nil })
+			})
+
+			It("returns 200 with each check, sorted by name", func() {
+				status, readiness := getReadiness()
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(readiness).To(Equal(health.Readiness{
+					Ready: true,
+					Checks: []health.CheckResult{
+						{Name: "another-check", Ready: true},
+						{Name: "some-check", Ready: true},
+					},
+				}))
+			})
+		})
+
+		Context("when a check fails", func() {
+			BeforeEach(func() {
+				checks.Register("some-check", func() error { return nil })
+				checks.Register("another-check", func() error { return errors.New("disaster") })
+			})
+
+			It("returns 503 with which check failed", func() {
+				status, readiness := getReadiness()
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+				Expect(readiness).To(Equal(health.Readiness{
+					Ready: false,
+					Checks: []health.CheckResult{
+						{Name: "another-check", Ready: false},
+						{Name: "some-check", Ready: true},
+					},
+				}))
+			})
+
+			It("logs the error instead of returning it", func() {
+				response, err := http.Get(server.URL + "/ready")
+				Expect(err).NotTo(HaveOccurred())
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Body.Close()).To(Succeed())
+
+				Expect(string(body)).NotTo(ContainSubstring("disaster"))
+				Expect(logger).To(gbytes.Say("disaster"))
+			})
+		})
+
+		Context("when a check does not return in time", func() {
+			var release chan struct{}
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+
+				checks.Register("some-check", func() error {
+					<-release
+					return nil
+				})
+			})
+
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("returns 503 without waiting for it", func() {
+				status, readiness := getReadiness()
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+				Expect(readiness.Checks).To(Equal([]health.CheckResult{
+					{Name: "some-check", Ready: false},
+				}))
+				Expect(logger).To(gbytes.Say(health.ErrCheckTimedOut.Error()))
+			})
+		})
+
+		Context("when no checks are registered", func() {
+			It("returns 200", func() {
+				status, readiness := getReadiness()
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(readiness.Ready).To(BeTrue())
+			})
+		})
+	})
+})
@@ -4,3 +4,20 @@ type Info struct {
 	Version       string `json:"version"`
 	WorkerVersion string `json:"worker_version"`
 }
+
+// A JSONWebKeySet lists the public keys which tokens may be signed with, as
+// described by RFC 7517.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+
+	// Modulus and Exponent are base64url-encoded big-endian integers.
+	Modulus  string `json:"n"`
+	Exponent string `json:"e"`
+}
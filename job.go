@@ -3,30 +3,38 @@ package atc
 type Job struct {
 	ID int `json:"id"`
 
-	Name                 string `json:"name"`
-	PipelineName         string `json:"pipeline_name"`
-	TeamName             string `json:"team_name"`
-	Paused               bool   `json:"paused,omitempty"`
-	FirstLoggedBuildID   int    `json:"first_logged_build_id,omitempty"`
-	DisableManualTrigger bool   `json:"disable_manual_trigger,omitempty"`
-	NextBuild            *Build `json:"next_build"`
-	FinishedBuild        *Build `json:"finished_build"`
-	TransitionBuild      *Build `json:"transition_build,omitempty"`
+	Name                       string `json:"name"`
+	PipelineName               string `json:"pipeline_name"`
+	TeamName                   string `json:"team_name"`
+	Paused                     bool   `json:"paused,omitempty"`
+	FirstLoggedBuildID         int    `json:"first_logged_build_id,omitempty"`
+	DisableManualTrigger       bool   `json:"disable_manual_trigger,omitempty"`
+	RequireManualTriggerReason bool   `json:"require_manual_trigger_reason,omitempty"`
+	BuildLogsToRetain          int    `json:"build_logs_to_retain,omitempty"`
+	NextBuild                  *Build `json:"next_build"`
+	FinishedBuild              *Build `json:"finished_build"`
+	TransitionBuild            *Build `json:"transition_build,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
 
 	Groups []string `json:"groups"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type JobInput struct {
-	Name     string         `json:"name"`
-	Resource string         `json:"resource"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
+	Name                 string         `json:"name"`
+	Resource             string         `json:"resource"`
+	Passed               []string       `json:"passed,omitempty"`
+	Trigger              bool           `json:"trigger"`
+	RerunOnUpstreamRerun bool           `json:"rerun_on_upstream_rerun,omitempty"`
+	Version              *VersionConfig `json:"version,omitempty"`
+	Params               Params         `json:"params,omitempty"`
+	Tags                 Tags           `json:"tags,omitempty"`
 }
 
 type JobOutput struct {
@@ -43,3 +51,20 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+// JobInputsExplanation describes how the inputs for a job's next build would
+// be resolved.
+type JobInputsExplanation struct {
+	Satisfiable bool               `json:"satisfiable"`
+	Inputs      []InputExplanation `json:"inputs"`
+}
+
+// InputExplanation either gives the version an input would use, or what is
+// blocking it: "versions", "disabled", "pinned", "passed" or "ambiguous".
+type InputExplanation struct {
+	Name         string   `json:"name"`
+	Resource     string   `json:"resource"`
+	Version      Version  `json:"version,omitempty"`
+	BlockedBy    string   `json:"blocked_by,omitempty"`
+	BlockingJobs []string `json:"blocking_jobs,omitempty"`
+}
@@ -1,5 +1,10 @@
 package atc
 
+const (
+	NotifyOnAll        = "all"
+	NotifyOnTransition = "transition"
+)
+
 type JobConfig struct {
 	Name   string `yaml:"name" json:"name" mapstructure:"name"`
 	Public bool   `yaml:"public,omitempty" json:"public,omitempty" mapstructure:"public"`
@@ -11,6 +16,33 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	// builds triggered manually must say why, e.g. for jobs which deploy to
+	// production outside of the usual flow of versions
+	RequireManualTriggerReason bool `yaml:"require_manual_trigger_reason,omitempty" json:"require_manual_trigger_reason,omitempty" mapstructure:"require_manual_trigger_reason"`
+
+	// collapse versions which accumulate while the job is busy into a single
+	// build of the newest ones, rather than building each of them in turn, and
+	// skip pending builds which are superseded by a newer one
+	BuildLatestOnly bool `yaml:"build_latest_only,omitempty" json:"build_latest_only,omitempty" mapstructure:"build_latest_only"`
+
+	Network *NetworkConfig `yaml:"network,omitempty" json:"network,omitempty" mapstructure:"network"`
+
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty" mapstructure:"labels"`
+
+	// shown alongside the job's name by the UI
+	Icon        string `yaml:"icon,omitempty" json:"icon,omitempty" mapstructure:"icon"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
+
+	// set as properties of the containers the job's builds run, so that agents
+	// on the workers can tell what the containers are running for
+	ContainerProperties map[string]string `yaml:"container_properties,omitempty" json:"container_properties,omitempty" mapstructure:"container_properties"`
+
+	ScheduleWindow *ScheduleWindow `yaml:"schedule_window,omitempty" json:"schedule_window,omitempty" mapstructure:"schedule_window"`
+
+	// which builds to send webhook deliveries for: every build by default, or
+	// only those which change the job's status
+	NotifyOn string `yaml:"notify_on,omitempty" json:"notify_on,omitempty" mapstructure:"notify_on"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
@@ -23,6 +55,10 @@ func (config JobConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
 
+func (config JobConfig) NotifiesOnTransitionOnly() bool {
+	return config.NotifyOn == NotifyOnTransition
+}
+
 func (config JobConfig) MaxInFlight() int {
 	if config.Serial || len(config.SerialGroups) > 0 {
 		return 1
@@ -134,13 +170,14 @@ func (config JobConfig) Inputs() []JobInput {
 			}
 
 			inputs = append(inputs, JobInput{
-				Name:     get,
-				Resource: resource,
-				Passed:   plan.Passed,
-				Version:  plan.Version,
-				Trigger:  plan.Trigger,
-				Params:   plan.Params,
-				Tags:     plan.Tags,
+				Name:                 get,
+				Resource:             resource,
+				Passed:               plan.Passed,
+				Version:              plan.Version,
+				Trigger:              plan.Trigger,
+				RerunOnUpstreamRerun: plan.RerunOnUpstreamRerun,
+				Params:               plan.Params,
+				Tags:                 plan.Tags,
 			})
 		}
 	}
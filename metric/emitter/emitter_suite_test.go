@@ -0,0 +1,13 @@
+package emitter_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestEmitter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Emitter Suite")
+}
@@ -0,0 +1,332 @@
+package emitter
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+// OTLPEmitter sends events to an OpenTelemetry collector using OTLP over HTTP
+// with JSON encoding. Every event is sent as a gauge, and finished builds are
+// also sent as spans.
+//
+// Events are sent in batches, each in the background, so that a slow
+// collector does not hold up the emission of other metrics.
+type OTLPEmitter struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+
+	batchSize     int
+	batchDuration time.Duration
+
+	batchLock     sync.Mutex
+	batch         []metric.Event
+	lastBatchTime time.Time
+}
+
+type OTLPConfig struct {
+	Endpoint string            `long:"otlp-endpoint" description:"OpenTelemetry collector OTLP/HTTP endpoint to send metrics and traces to, e.g. http://localhost:4318."`
+	Headers  map[string]string `long:"otlp-headers" description:"Header to send with each OTLP request, as key:value. Can be specified multiple times."`
+
+	BatchSize     int           `long:"otlp-batch-size" default:"50" description:"Number of events to send to the collector in each request."`
+	BatchDuration time.Duration `long:"otlp-batch-duration" default:"10s" description:"Maximum time to wait before sending a partial batch of events."`
+}
+
+func init() {
+	metric.RegisterEmitter(&OTLPConfig{})
+}
+
+func (config *OTLPConfig) Description() string { return "OpenTelemetry" }
+func (config *OTLPConfig) IsConfigured() bool  { return config.Endpoint != "" }
+
+func (config *OTLPConfig) NewEmitter() (metric.Emitter, error) {
+	return &OTLPEmitter{
+		client: &http.Client{
+			Transport: &http.Transport{},
+			Timeout:   time.Minute,
+		},
+		endpoint: strings.TrimRight(config.Endpoint, "/"),
+		headers:  config.Headers,
+
+		batchSize:     config.BatchSize,
+		batchDuration: config.BatchDuration,
+
+		lastBatchTime: time.Now(),
+	}, nil
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name  string    `json:"name"`
+	Gauge otlpGauge `json:"gauge"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpDataPoint struct {
+	Attributes   []otlpKeyValue `json:"attributes"`
+	TimeUnixNano string         `json:"timeUnixNano"`
+	AsDouble     float64        `json:"asDouble"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code int `json:"code"`
+}
+
+const (
+	otlpScopeName = "github.com/concourse/atc/metric"
+
+	otlpSpanKindInternal = 1
+
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+func (emitter *OTLPEmitter) Emit(logger lager.Logger, event metric.Event) {
+	if _, ok := otlpValue(event.Value); !ok {
+		logger.Debug("skipping-non-numeric-event", lager.Data{"event": event.Name})
+		return
+	}
+
+	emitter.batchLock.Lock()
+	defer emitter.batchLock.Unlock()
+
+	emitter.batch = append(emitter.batch, event)
+
+	if len(emitter.batch) >= emitter.batchSize || time.Since(emitter.lastBatchTime) >= emitter.batchDuration {
+		go emitter.submitBatch(logger, emitter.batch)
+
+		emitter.batch = nil
+		emitter.lastBatchTime = time.Now()
+	}
+}
+
+// submitBatch sends the gauges for a batch of events in one request, and the
+// spans for any finished builds among them in another.
+func (emitter *OTLPEmitter) submitBatch(logger lager.Logger, batch []metric.Event) {
+	logger.Debug("submitting-batch", lager.Data{"size": len(batch)})
+
+	resourceMetrics := []otlpResourceMetrics{}
+	resourceSpans := []otlpResourceSpans{}
+
+	for _, event := range batch {
+		value, _ := otlpValue(event.Value)
+
+		resource := otlpResource{
+			Attributes: []otlpKeyValue{
+				{Key: "service.name", Value: otlpAnyValue{StringValue: "concourse"}},
+				{Key: "host.name", Value: otlpAnyValue{StringValue: event.Host}},
+			},
+		}
+
+		attributes := otlpAttributes(event.Attributes)
+		attributes = append(attributes, otlpKeyValue{Key: "state", Value: otlpAnyValue{StringValue: string(event.State)}})
+
+		resourceMetrics = append(resourceMetrics, otlpResourceMetrics{
+			Resource: resource,
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope: otlpScope{Name: otlpScopeName},
+				Metrics: []otlpMetric{{
+					Name: "concourse." + strings.Replace(event.Name, " ", "_", -1),
+					Gauge: otlpGauge{
+						DataPoints: []otlpDataPoint{{
+							Attributes:   attributes,
+							TimeUnixNano: otlpTime(event.Time),
+							AsDouble:     value,
+						}},
+					},
+				}},
+			}},
+		})
+
+		if event.Name == "build finished" {
+			span, err := otlpBuildSpan(event, value)
+			if err != nil {
+				logger.Error("failed-to-generate-span", err)
+				continue
+			}
+
+			resourceSpans = append(resourceSpans, otlpResourceSpans{
+				Resource: resource,
+				ScopeSpans: []otlpScopeSpans{{
+					Scope: otlpScope{Name: otlpScopeName},
+					Spans: []otlpSpan{span},
+				}},
+			})
+		}
+	}
+
+	emitter.send(logger, "/v1/metrics", otlpMetricsRequest{ResourceMetrics: resourceMetrics})
+
+	if len(resourceSpans) > 0 {
+		emitter.send(logger, "/v1/traces", otlpTracesRequest{ResourceSpans: resourceSpans})
+	}
+}
+
+// otlpBuildSpan returns a span covering the build, whose duration in
+// milliseconds is the value of the event.
+func otlpBuildSpan(event metric.Event, durationMS float64) (otlpSpan, error) {
+	traceID, err := otlpID(16)
+	if err != nil {
+		return otlpSpan{}, err
+	}
+
+	spanID, err := otlpID(8)
+	if err != nil {
+		return otlpSpan{}, err
+	}
+
+	status := otlpStatusCodeOK
+	if event.Attributes["build_status"] != "succeeded" {
+		status = otlpStatusCodeError
+	}
+
+	start := event.Time.Add(-time.Duration(durationMS * float64(time.Millisecond)))
+
+	return otlpSpan{
+		TraceID:           traceID,
+		SpanID:            spanID,
+		Name:              "build",
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: otlpTime(start),
+		EndTimeUnixNano:   otlpTime(event.Time),
+		Attributes:        otlpAttributes(event.Attributes),
+		Status:            otlpStatus{Code: status},
+	}, nil
+}
+
+func (emitter *OTLPEmitter) send(logger lager.Logger, path string, payload interface{}) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed-to-serialize-payload", err)
+		return
+	}
+
+	req, err := http.NewRequest("POST", emitter.endpoint+path, bytes.NewBuffer(payloadJSON))
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range emitter.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := emitter.client.Do(req)
+	if err != nil {
+		logger.Error("failed-to-send-request", err)
+		return
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		logger.Error("failed-to-export", fmt.Errorf("unexpected response code %d", resp.StatusCode), lager.Data{"path": path})
+	}
+}
+
+func otlpAttributes(attributes map[string]string) []otlpKeyValue {
+	keyValues := []otlpKeyValue{}
+	for k, v := range attributes {
+		keyValues = append(keyValues, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+
+	return keyValues
+}
+
+func otlpValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpID(size int) (string, error) {
+	id := make([]byte, size)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,177 @@
+package emitter_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/emitter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("OTLPEmitter", func() {
+	var (
+		logger *lagertest.TestLogger
+		server *ghttp.Server
+		config *emitter.OTLPConfig
+
+		otlpEmitter metric.Emitter
+
+		requests chan *http.Request
+		bodies   chan map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		server = ghttp.NewServer()
+
+		requests = make(chan *http.Request, 10)
+		bodies = make(chan map[string]interface{}, 10)
+
+		record := func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			payload, err := ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(payload, &body)).To(Succeed())
+
+			requests <- r
+			bodies <- body
+		}
+
+		server.RouteToHandler("POST", "/v1/metrics", record)
+		server.RouteToHandler("POST", "/v1/traces", record)
+
+		config = &emitter.OTLPConfig{
+			Endpoint:      server.URL() + "/",
+			Headers:       map[string]string{"Authorization": "some-token"},
+			BatchSize:     2,
+			BatchDuration: time.Hour,
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		otlpEmitter, err = config.NewEmitter()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	event := func(name string, value interface{}) metric.Event {
+		return metric.Event{
+			Name:       name,
+			Value:      value,
+			State:      metric.EventStateOK,
+			Host:       "some-host",
+			Time:       time.Now(),
+			Attributes: map[string]string{"some": "attribute"},
+		}
+	}
+
+	metricNames := func(body map[string]interface{}) []string {
+		names := []string{}
+		for _, resourceMetrics := range body["resourceMetrics"].([]interface{}) {
+			for _, scopeMetrics := range resourceMetrics.(map[string]interface{})["scopeMetrics"].([]interface{}) {
+				for _, m := range scopeMetrics.(map[string]interface{})["metrics"].([]interface{}) {
+					names = append(names, m.(map[string]interface{})["name"].(string))
+				}
+			}
+		}
+
+		return names
+	}
+
+	It("waits for a full batch before sending the events", func() {
+		otlpEmitter.Emit(logger, event("some event", 1))
+		Consistently(requests).ShouldNot(Receive())
+
+		otlpEmitter.Emit(logger, event("some other event", 2.5))
+
+		var request *http.Request
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.URL.Path).To(Equal("/v1/metrics"))
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get("Authorization")).To(Equal("some-token"))
+
+		var body map[string]interface{}
+		Eventually(bodies).Should(Receive(&body))
+		Expect(metricNames(body)).To(Equal([]string{
+			"concourse.some_event",
+			"concourse.some_other_event",
+		}))
+	})
+
+	It("skips events without a numeric value", func() {
+		otlpEmitter.Emit(logger, event("some event", "not-a-number"))
+		otlpEmitter.Emit(logger, event("some event", 1))
+		Consistently(requests).ShouldNot(Receive())
+	})
+
+	It("sends the spans of finished builds", func() {
+		build := event("build finished", 1000)
+		build.Attributes["build_status"] = "succeeded"
+
+		otlpEmitter.Emit(logger, build)
+		otlpEmitter.Emit(logger, event("some event", 1))
+
+		paths := []string{}
+		for i := 0; i < 2; i++ {
+			var request *http.Request
+			Eventually(requests).Should(Receive(&request))
+			paths = append(paths, request.URL.Path)
+		}
+
+		Expect(paths).To(ConsistOf("/v1/metrics", "/v1/traces"))
+	})
+
+	Context("when the batch duration has elapsed", func() {
+		BeforeEach(func() {
+			config.BatchSize = 100
+			config.BatchDuration = time.Nanosecond
+		})
+
+		It("sends a partial batch", func() {
+			otlpEmitter.Emit(logger, event("some event", 1))
+
+			var body map[string]interface{}
+			Eventually(bodies).Should(Receive(&body))
+			Expect(metricNames(body)).To(Equal([]string{"concourse.some_event"}))
+		})
+	})
+
+	Context("when the collector is slow", func() {
+		var unblock chan struct{}
+
+		BeforeEach(func() {
+			config.BatchSize = 1
+			unblock = make(chan struct{})
+
+			server.RouteToHandler("POST", "/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+				<-unblock
+			})
+		})
+
+		AfterEach(func() {
+			close(unblock)
+		})
+
+		It("does not hold up emitting", func() {
+			emitted := make(chan struct{})
+			go func() {
+				otlpEmitter.Emit(logger, event("some event", 1))
+				otlpEmitter.Emit(logger, event("some event", 2))
+				close(emitted)
+			}()
+
+			Eventually(emitted).Should(BeClosed())
+		})
+	})
+})
@@ -38,6 +38,10 @@ type PrometheusEmitter struct {
 	dbConnections  *prometheus.GaugeVec
 
 	resourceChecksVec *prometheus.CounterVec
+	checkLeasesHeld   prometheus.Gauge
+
+	volumesStreamedBytes   *prometheus.CounterVec
+	volumesStreamDurations *prometheus.HistogramVec
 
 	workerLastSeen map[string]time.Time
 	mu             sync.Mutex
@@ -231,6 +235,40 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	checkLeasesHeld := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "check_leases_held",
+			Help:      "Current number of resource checks being run by this ATC",
+		},
+	)
+	prometheus.MustRegister(checkLeasesHeld)
+
+	// volume streaming metrics
+	volumesStreamedBytes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streamed_bytes_total",
+			Help:      "Total bytes of volumes streamed from one worker to another",
+		},
+		[]string{"source_worker", "destination_worker"},
+	)
+	prometheus.MustRegister(volumesStreamedBytes)
+
+	volumesStreamDurations := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "stream_duration_seconds",
+			Help:      "Time taken to stream a volume from one worker to another",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"source_worker", "destination_worker"},
+	)
+	prometheus.MustRegister(volumesStreamDurations)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -261,6 +299,10 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		dbConnections:  dbConnections,
 
 		resourceChecksVec: resourceChecksVec,
+		checkLeasesHeld:   checkLeasesHeld,
+
+		volumesStreamedBytes:   volumesStreamedBytes,
+		volumesStreamDurations: volumesStreamDurations,
 
 		workerLastSeen: map[string]time.Time{},
 	}
@@ -301,6 +343,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "check leases held":
+		emitter.checkLeasesMetric(logger, event)
+	case "volume streamed bytes":
+		emitter.volumeStreamMetrics(logger, event)
+	case "volume stream duration (ms)":
+		emitter.volumeStreamMetrics(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -483,6 +531,50 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	emitter.resourceChecksVec.WithLabelValues(pipeline, team).Inc()
 }
 
+func (emitter *PrometheusEmitter) checkLeasesMetric(logger lager.Logger, event metric.Event) {
+	held, ok := event.Value.(int)
+	if !ok {
+		logger.Error("check-leases-held-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+		return
+	}
+
+	emitter.checkLeasesHeld.Set(float64(held))
+}
+
+func (emitter *PrometheusEmitter) volumeStreamMetrics(logger lager.Logger, event metric.Event) {
+	source, exists := event.Attributes["source_worker"]
+	if !exists {
+		logger.Error("failed-to-find-source-worker-in-event", fmt.Errorf("expected source_worker to exist in event.Attributes"))
+		return
+	}
+
+	destination, exists := event.Attributes["destination_worker"]
+	if !exists {
+		logger.Error("failed-to-find-destination-worker-in-event", fmt.Errorf("expected destination_worker to exist in event.Attributes"))
+		return
+	}
+
+	switch event.Name {
+	case "volume streamed bytes":
+		bytes, ok := event.Value.(int64)
+		if !ok {
+			logger.Error("volume-streamed-bytes-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int64"))
+			return
+		}
+
+		emitter.volumesStreamedBytes.WithLabelValues(source, destination).Add(float64(bytes))
+	case "volume stream duration (ms)":
+		duration, ok := event.Value.(float64)
+		if !ok {
+			logger.Error("volume-stream-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+			return
+		}
+
+		// seconds are the standard prometheus base unit for time
+		emitter.volumesStreamDurations.WithLabelValues(source, destination).Observe(duration / 1000)
+	}
+}
+
 // updateLastSeen tracks for each worker when it last received a metric event.
 func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	emitter.mu.Lock()
@@ -297,6 +297,24 @@ func (event GarbageCollectionContainerCollectorJobDropped) Emit(logger lager.Log
 	)
 }
 
+type GarbageCollectionContainerReaperJobDropped struct {
+	WorkerName string
+}
+
+func (event GarbageCollectionContainerReaperJobDropped) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("gc-container-reaper-dropped"),
+		Event{
+			Name:  "GC container reaper job dropped",
+			Value: 1,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
@@ -415,3 +433,78 @@ func (event ResourceCheck) Emit(logger lager.Logger) {
 		},
 	)
 }
+
+type PipelinePausedByInactivity struct {
+	PipelineName string
+	TeamName     string
+}
+
+func (event PipelinePausedByInactivity) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("pipeline-paused-by-inactivity"),
+		Event{
+			Name:  "pipeline paused by inactivity",
+			Value: 1,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"team":     event.TeamName,
+			},
+		},
+	)
+}
+
+type CheckLeasesHeld struct {
+	Count int
+}
+
+func (event CheckLeasesHeld) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("check-leases-held"),
+		Event{
+			Name:  "check leases held",
+			Value: event.Count,
+			State: EventStateOK,
+		},
+	)
+}
+
+type VolumeStreamed struct {
+	SourceWorker      string
+	DestinationWorker string
+	Bytes             int64
+	Duration          time.Duration
+	Slow              bool
+}
+
+func (event VolumeStreamed) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Slow {
+		state = EventStateWarning
+	}
+
+	attributes := map[string]string{
+		"source_worker":      event.SourceWorker,
+		"destination_worker": event.DestinationWorker,
+	}
+
+	emit(
+		logger.Session("volume-streamed"),
+		Event{
+			Name:       "volume streamed bytes",
+			Value:      event.Bytes,
+			State:      state,
+			Attributes: attributes,
+		},
+	)
+
+	emit(
+		logger.Session("volume-stream-duration"),
+		Event{
+			Name:       "volume stream duration (ms)",
+			Value:      ms(event.Duration),
+			State:      state,
+			Attributes: attributes,
+		},
+	)
+}
@@ -0,0 +1,51 @@
+package atc
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	NetworkEgressAll  = "all"
+	NetworkEgressNone = "none"
+)
+
+// NetworkConfig restricts the network access of the containers created for a
+// job's get, put, and task steps.
+//
+// Containers with an egress of "none" are given NetOut rules for each of the
+// AllowedCIDRs and only run on workers which report that they deny network
+// access by default, so that they can reach nothing else. The containers which
+// check and fetch a step's image or custom resource type are not restricted,
+// as they are shared with the rest of the pipeline.
+type NetworkConfig struct {
+	Egress       string   `yaml:"egress,omitempty" json:"egress,omitempty" mapstructure:"egress"`
+	AllowedCIDRs []string `yaml:"allowed_cidrs,omitempty" json:"allowed_cidrs,omitempty" mapstructure:"allowed_cidrs"`
+}
+
+func (config NetworkConfig) Restricted() bool {
+	return config.Egress == NetworkEgressNone
+}
+
+func (config NetworkConfig) Validate() []string {
+	errorMessages := []string{}
+
+	switch config.Egress {
+	case "", NetworkEgressAll:
+		if len(config.AllowedCIDRs) > 0 {
+			errorMessages = append(errorMessages, "allowed_cidrs without an egress of 'none'")
+		}
+	case NetworkEgressNone:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("unknown egress '%s' (must be '%s' or '%s')", config.Egress, NetworkEgressAll, NetworkEgressNone))
+	}
+
+	for _, cidr := range config.AllowedCIDRs {
+		_, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid allowed CIDR '%s'", cidr))
+		}
+	}
+
+	return errorMessages
+}
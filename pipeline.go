@@ -7,6 +7,12 @@ type Pipeline struct {
 	Public   bool         `json:"public"`
 	Groups   GroupConfigs `json:"groups,omitempty"`
 	TeamName string       `json:"team_name"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// PausedByInactivity is set when the pipeline was paused automatically
+	// for having been idle. Unpausing the pipeline clears it.
+	PausedByInactivity bool `json:"paused_by_inactivity,omitempty"`
 }
 
 type RenameRequest struct {
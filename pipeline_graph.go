@@ -0,0 +1,161 @@
+package atc
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	PipelineGraphNodeJob      = "job"
+	PipelineGraphNodeResource = "resource"
+
+	// a resource is fetched by a job
+	PipelineGraphEdgeInput = "input"
+	// a resource is put to by a job
+	PipelineGraphEdgeOutput = "output"
+	// a job only runs with versions of a resource which passed through another
+	PipelineGraphEdgePassed = "passed"
+)
+
+// PipelineGraph is the dependency graph between a pipeline's jobs and
+// resources, as drawn by the web UI.
+type PipelineGraph struct {
+	Nodes []PipelineGraphNode `json:"nodes"`
+	Edges []PipelineGraphEdge `json:"edges"`
+}
+
+type PipelineGraphNode struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type PipelineGraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+
+	// the resource the edge is for, set for passed edges
+	Resource string `json:"resource,omitempty"`
+
+	// whether new versions trigger the target job, set for input and passed
+	// edges
+	Trigger bool `json:"trigger,omitempty"`
+}
+
+// NewPipelineGraph determines the graph of the given jobs and resources. There
+// is a node for each job and resource, in config order, and edges for each
+// get, put, and passed constraint.
+func NewPipelineGraph(jobs JobConfigs, resources ResourceConfigs) PipelineGraph {
+	graph := PipelineGraph{
+		Nodes: []PipelineGraphNode{},
+		Edges: []PipelineGraphEdge{},
+	}
+
+	for _, resource := range resources {
+		graph.Nodes = append(graph.Nodes, PipelineGraphNode{
+			ID:   resourceNodeID(resource.Name),
+			Type: PipelineGraphNodeResource,
+			Name: resource.Name,
+		})
+	}
+
+	for _, job := range jobs {
+		graph.Nodes = append(graph.Nodes, PipelineGraphNode{
+			ID:   jobNodeID(job.Name),
+			Type: PipelineGraphNodeJob,
+			Name: job.Name,
+		})
+	}
+
+	seen := map[PipelineGraphEdge]bool{}
+	addEdge := func(edge PipelineGraphEdge) {
+		if !seen[edge] {
+			seen[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	for _, job := range jobs {
+		for _, input := range job.Inputs() {
+			addEdge(PipelineGraphEdge{
+				Source:  resourceNodeID(input.Resource),
+				Target:  jobNodeID(job.Name),
+				Type:    PipelineGraphEdgeInput,
+				Trigger: input.Trigger,
+			})
+
+			for _, passed := range input.Passed {
+				addEdge(PipelineGraphEdge{
+					Source:   jobNodeID(passed),
+					Target:   jobNodeID(job.Name),
+					Type:     PipelineGraphEdgePassed,
+					Resource: input.Resource,
+					Trigger:  input.Trigger,
+				})
+			}
+		}
+
+		for _, output := range job.Outputs() {
+			addEdge(PipelineGraphEdge{
+				Source: jobNodeID(job.Name),
+				Target: resourceNodeID(output.Resource),
+				Type:   PipelineGraphEdgeOutput,
+			})
+		}
+	}
+
+	return graph
+}
+
+// DOT renders the graph in Graphviz's DOT language. Resources are drawn as
+// boxes, and edges which don't trigger jobs are dashed.
+func (graph PipelineGraph) DOT() string {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintln(buf, "digraph pipeline {")
+	fmt.Fprintln(buf, "  rankdir=LR;")
+
+	for _, node := range graph.Nodes {
+		shape := "ellipse"
+		if node.Type == PipelineGraphNodeResource {
+			shape = "box"
+		}
+
+		fmt.Fprintf(buf, "  %s [label=%s, shape=%s];\n", strconv.Quote(node.ID), strconv.Quote(node.Name), shape)
+	}
+
+	for _, edge := range graph.Edges {
+		var attrs []string
+
+		if edge.Resource != "" {
+			attrs = append(attrs, "label="+strconv.Quote(edge.Resource))
+		}
+
+		if edge.Type != PipelineGraphEdgeOutput && !edge.Trigger {
+			attrs = append(attrs, "style=dashed")
+		}
+
+		fmt.Fprintf(buf, "  %s -> %s", strconv.Quote(edge.Source), strconv.Quote(edge.Target))
+
+		if len(attrs) > 0 {
+			fmt.Fprintf(buf, " [%s]", strings.Join(attrs, ", "))
+		}
+
+		fmt.Fprintln(buf, ";")
+	}
+
+	fmt.Fprintln(buf, "}")
+
+	return buf.String()
+}
+
+func jobNodeID(name string) string {
+	return "job:" + name
+}
+
+func resourceNodeID(name string) string {
+	return "resource:" + name
+}
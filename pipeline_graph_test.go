@@ -0,0 +1,80 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineGraph", func() {
+	var graph PipelineGraph
+
+	BeforeEach(func() {
+		graph = NewPipelineGraph(
+			JobConfigs{
+				{
+					Name: "unit",
+					Plan: PlanSequence{
+						{Get: "source", Trigger: true},
+						{Put: "image"},
+						{Put: "image", Params: Params{"again": true}},
+					},
+				},
+				{
+					Name: "deploy",
+					Plan: PlanSequence{
+						{Get: "code", Resource: "source", Passed: []string{"unit"}},
+						{Get: "image", Passed: []string{"unit"}, Trigger: true},
+					},
+				},
+			},
+			ResourceConfigs{
+				{Name: "source", Type: "git"},
+				{Name: "image", Type: "docker-image"},
+				{Name: "unused", Type: "time"},
+			},
+		)
+	})
+
+	It("has a node for each resource and job", func() {
+		Expect(graph.Nodes).To(Equal([]PipelineGraphNode{
+			{ID: "resource:source", Type: "resource", Name: "source"},
+			{ID: "resource:image", Type: "resource", Name: "image"},
+			{ID: "resource:unused", Type: "resource", Name: "unused"},
+			{ID: "job:unit", Type: "job", Name: "unit"},
+			{ID: "job:deploy", Type: "job", Name: "deploy"},
+		}))
+	})
+
+	It("has an edge for each get, put, and passed constraint", func() {
+		Expect(graph.Edges).To(Equal([]PipelineGraphEdge{
+			{Source: "resource:source", Target: "job:unit", Type: "input", Trigger: true},
+			{Source: "job:unit", Target: "resource:image", Type: "output"},
+			{Source: "resource:source", Target: "job:deploy", Type: "input"},
+			{Source: "job:unit", Target: "job:deploy", Type: "passed", Resource: "source"},
+			{Source: "resource:image", Target: "job:deploy", Type: "input", Trigger: true},
+			{Source: "job:unit", Target: "job:deploy", Type: "passed", Resource: "image", Trigger: true},
+		}))
+	})
+
+	Describe("DOT", func() {
+		It("renders the graph", func() {
+			Expect(graph.DOT()).To(Equal(`digraph pipeline {
+  rankdir=LR;
+  "resource:source" [label="source", shape=box];
+  "resource:image" [label="image", shape=box];
+  "resource:unused" [label="unused", shape=box];
+  "job:unit" [label="unit", shape=ellipse];
+  "job:deploy" [label="deploy", shape=ellipse];
+  "resource:source" -> "job:unit";
+  "job:unit" -> "resource:image";
+  "resource:source" -> "job:deploy" [style=dashed];
+  "job:unit" -> "job:deploy" [label="source", style=dashed];
+  "resource:image" -> "job:deploy";
+  "job:unit" -> "job:deploy" [label="image"];
+}
+`))
+		})
+	})
+})
@@ -30,6 +30,9 @@ type radarSchedulerFactory struct {
 	resourceTypeCheckingInterval      time.Duration
 	resourceCheckingInterval          time.Duration
 	engine                            engine.Engine
+	workerFactory                     db.WorkerFactory
+	checkLeases                       *radar.CheckLeases
+	checkRateLimiter                  *radar.CheckRateLimiter
 }
 
 func NewRadarSchedulerFactory(
@@ -38,18 +41,23 @@ func NewRadarSchedulerFactory(
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
 	engine engine.Engine,
+	workerFactory db.WorkerFactory,
+	checkRateLimiter *radar.CheckRateLimiter,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
 		resourceTypeCheckingInterval:      resourceTypeCheckingInterval,
 		resourceCheckingInterval:          resourceCheckingInterval,
-		engine: engine,
+		engine:                            engine,
+		workerFactory:                     workerFactory,
+		checkLeases:                       radar.NewCheckLeases(),
+		checkRateLimiter:                  checkRateLimiter,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline, externalURL string, variables creds.Variables) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.resourceConfigCheckSessionFactory, rsf.resourceTypeCheckingInterval, rsf.resourceCheckingInterval, dbPipeline, clock.NewClock(), externalURL, variables)
+	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.resourceConfigCheckSessionFactory, rsf.resourceTypeCheckingInterval, rsf.resourceCheckingInterval, dbPipeline, clock.NewClock(), rsf.checkLeases, rsf.checkRateLimiter, externalURL, variables)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalURL string, variables creds.Variables) scheduler.BuildScheduler {
@@ -73,6 +81,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 		externalURL,
 		variables,
 		resourceTypeScanner,
+		rsf.checkRateLimiter,
 	)
 
 	inputMapper := inputmapper.NewInputMapper(
@@ -87,12 +96,16 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 			maxinflight.NewUpdater(pipeline),
 			factory.NewBuildFactory(
 				pipeline.ID(),
+				pipeline.TeamName(),
 				atc.NewPlanFactory(time.Now().Unix()),
+				variables,
+				rsf.workerFactory,
 			),
 			scanner,
 			inputMapper,
 			rsf.engine,
 		),
 		Scanner: scanner,
+		Clock:   clock.NewClock(),
 	}
 }
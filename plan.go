@@ -53,6 +53,10 @@ type EnsurePlan struct {
 type OnSuccessPlan struct {
 	Step Plan `json:"step"`
 	Next Plan `json:"on_success"`
+
+	// whether Next is an on_success hook, as opposed to e.g. the get
+	// following a put
+	Hook bool `json:"hook,omitempty"`
 }
 
 type TimeoutPlan struct {
@@ -77,6 +81,9 @@ type GetPlan struct {
 	Version     *Version `json:"version,omitempty"`
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 	Tags        Tags     `json:"tags,omitempty"`
+	Path        string   `json:"path,omitempty"`
+
+	Network *NetworkConfig `json:"network,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
@@ -89,6 +96,8 @@ type PutPlan struct {
 	Params   Params `json:"params,omitempty"`
 	Tags     Tags   `json:"tags,omitempty"`
 
+	Network *NetworkConfig `json:"network,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -106,6 +115,8 @@ type TaskPlan struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	Network *NetworkConfig `json:"network,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -0,0 +1,79 @@
+package radar
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// after losing a check lease to another ATC, every check lease this ATC holds
+// delays its next attempt at acquiring that lease by this long, leaving it to
+// ATCs with fewer checks running
+const heldLeaseBackoff = time.Second
+
+// CheckLeases accounts for the check leases this ATC holds, so that checking
+// spreads across ATCs rather than being won by the same one every time.
+//
+// It is shared by all of an ATC's interval runners.
+type CheckLeases struct {
+	held int64
+}
+
+func NewCheckLeases() *CheckLeases {
+	return &CheckLeases{}
+}
+
+type checkLeasesKey struct{}
+
+// Context returns a context in which scanners account for the check leases
+// they acquire.
+func (leases *CheckLeases) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, checkLeasesKey{}, leases)
+}
+
+// Held returns the number of check leases this ATC currently holds.
+func (leases *CheckLeases) Held() int {
+	return int(atomic.LoadInt64(&leases.held))
+}
+
+// holdCheckLease accounts for a check lease acquired by a scanner until the
+// returned func is called. Leases acquired for checks which were not started
+// by an interval runner, e.g. those for builds, are not accounted for.
+func holdCheckLease(ctx context.Context) func() {
+	leases, ok := ctx.Value(checkLeasesKey{}).(*CheckLeases)
+	if !ok {
+		return func() {}
+	}
+
+	atomic.AddInt64(&leases.held, 1)
+
+	return func() {
+		atomic.AddInt64(&leases.held, -1)
+	}
+}
+
+// acquisitionDelay returns how long to wait after the interval has elapsed
+// before trying to acquire the lease. Random jitter keeps ATCs which start
+// their runners together from racing for the same leases. Once the lease has
+// been lost to another ATC, the backoff for leases already held lets less busy
+// ATCs get there first, so an ATC which is the only one checking never backs
+// off. The delay never exceeds half the interval, so that a busy ATC still
+// checks if the others do not.
+func (leases *CheckLeases) acquisitionDelay(interval time.Duration, lostLease bool) time.Duration {
+	spread := interval / 10
+	if spread <= 0 {
+		return 0
+	}
+
+	delay := time.Duration(rand.Int63n(int64(spread)))
+	if lostLease {
+		delay += time.Duration(leases.Held()) * heldLeaseBackoff
+	}
+
+	if delay > interval/2 {
+		delay = interval / 2
+	}
+
+	return delay
+}
@@ -0,0 +1,83 @@
+package radar
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// CheckRateLimiter is a token bucket limiting how many checks an ATC starts
+// per second, so that setting many pipelines at once does not flood the
+// services their resources point at.
+//
+// It is shared by all of an ATC's resource scanners, both the API's and the
+// backend's. A nil limiter, or one with a rate of 0, allows every check.
+type CheckRateLimiter struct {
+	clock clock.Clock
+	rate  float64
+	burst float64
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewCheckRateLimiter returns a limiter allowing up to checksPerSecond checks
+// each second on average, and up to a second's worth of checks at once.
+func NewCheckRateLimiter(clock clock.Clock, checksPerSecond float64) *CheckRateLimiter {
+	burst := math.Max(checksPerSecond, 1)
+
+	return &CheckRateLimiter{
+		clock:  clock,
+		rate:   checksPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   clock.Now(),
+	}
+}
+
+// Reserve takes a check from the budget, returning how long to wait before
+// starting it. The budget may be overdrawn, so that checks waiting for it
+// start in the order they reserved it.
+func (limiter *CheckRateLimiter) Reserve() time.Duration {
+	if limiter == nil || limiter.rate <= 0 {
+		return 0
+	}
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	limiter.refill()
+
+	limiter.tokens--
+
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+}
+
+// Cancel gives back a check reserved from the budget which did not go ahead,
+// e.g. because another ATC ran it.
+func (limiter *CheckRateLimiter) Cancel() {
+	if limiter == nil || limiter.rate <= 0 {
+		return
+	}
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	limiter.refill()
+
+	limiter.tokens = math.Min(limiter.burst, limiter.tokens+1)
+}
+
+func (limiter *CheckRateLimiter) refill() {
+	now := limiter.clock.Now()
+
+	limiter.tokens = math.Min(limiter.burst, limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.rate)
+	limiter.last = now
+}
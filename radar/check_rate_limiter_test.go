@@ -0,0 +1,119 @@
+package radar_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+
+	. "github.com/concourse/atc/radar"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRateLimiter", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		limiter   *CheckRateLimiter
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+	})
+
+	Context("with a rate of 0", func() {
+		BeforeEach(func() {
+			limiter = NewCheckRateLimiter(fakeClock, 0)
+		})
+
+		It("allows every check", func() {
+			for i := 0; i < 100; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+		})
+	})
+
+	Context("when nil", func() {
+		It("allows every check", func() {
+			limiter = nil
+
+			Expect(limiter.Reserve()).To(BeZero())
+			limiter.Cancel()
+		})
+	})
+
+	Context("with a rate", func() {
+		BeforeEach(func() {
+			limiter = NewCheckRateLimiter(fakeClock, 4)
+		})
+
+		It("allows a second's worth of checks at once", func() {
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			Expect(limiter.Reserve()).To(Equal(250 * time.Millisecond))
+		})
+
+		It("makes each check waiting for budget wait its turn", func() {
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			Expect(limiter.Reserve()).To(Equal(250 * time.Millisecond))
+			Expect(limiter.Reserve()).To(Equal(500 * time.Millisecond))
+		})
+
+		It("allows more checks as time passes", func() {
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			fakeClock.Increment(250 * time.Millisecond)
+
+			Expect(limiter.Reserve()).To(BeZero())
+			Expect(limiter.Reserve()).NotTo(BeZero())
+		})
+
+		It("does not save up more than a second's worth of checks", func() {
+			fakeClock.Increment(time.Minute)
+
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			Expect(limiter.Reserve()).NotTo(BeZero())
+		})
+
+		It("gives back cancelled checks", func() {
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			limiter.Cancel()
+
+			Expect(limiter.Reserve()).To(BeZero())
+			Expect(limiter.Reserve()).NotTo(BeZero())
+		})
+
+		It("does not save up more than a second's worth of cancelled checks", func() {
+			limiter.Cancel()
+
+			for i := 0; i < 4; i++ {
+				Expect(limiter.Reserve()).To(BeZero())
+			}
+
+			Expect(limiter.Reserve()).NotTo(BeZero())
+		})
+	})
+
+	Context("with a rate below one check per second", func() {
+		BeforeEach(func() {
+			limiter = NewCheckRateLimiter(fakeClock, 0.5)
+		})
+
+		It("still allows a check at once", func() {
+			Expect(limiter.Reserve()).To(BeZero())
+			Expect(limiter.Reserve()).To(Equal(2 * time.Second))
+		})
+	})
+})
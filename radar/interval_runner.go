@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . IntervalRunner
@@ -18,6 +19,7 @@ type intervalRunner struct {
 	clock   clock.Clock
 	name    string
 	scanner Scanner
+	leases  *CheckLeases
 }
 
 func NewIntervalRunner(
@@ -25,12 +27,14 @@ func NewIntervalRunner(
 	clock clock.Clock,
 	name string,
 	scanner Scanner,
+	leases *CheckLeases,
 ) IntervalRunner {
 	return &intervalRunner{
 		logger:  logger,
 		clock:   clock,
 		name:    name,
 		scanner: scanner,
+		leases:  leases,
 	}
 }
 
@@ -38,8 +42,13 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 	// do an immediate initial check
 	var interval time.Duration = 0
 
+	// whether the last attempt lost the lease to another ATC
+	var lostLease bool
+
+	scanCtx := r.leases.Context(ctx)
+
 	for {
-		timer := r.clock.NewTimer(interval)
+		timer := r.clock.NewTimer(interval + r.leases.acquisitionDelay(interval, lostLease))
 
 		select {
 		case <-ctx.Done():
@@ -47,13 +56,27 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 			return nil
 		case <-timer.C():
 			var err error
-			interval, err = r.scanner.Run(r.logger, r.name)
+
+			interval, err = r.scanner.Run(scanCtx, r.logger, r.name)
+
+			lostLease = err == ErrFailedToAcquireLock
+
 			if err != nil {
 				if err == ErrFailedToAcquireLock {
 					break
 				}
+
+				// stopped while the scanner was waiting
+				if ctx.Err() != nil {
+					return nil
+				}
+
 				return err
 			}
+
+			metric.CheckLeasesHeld{
+				Count: r.leases.Held(),
+			}.Emit(r.logger)
 		}
 	}
 }
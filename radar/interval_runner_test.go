@@ -25,6 +25,7 @@ var _ = Describe("IntervalRunner", func() {
 
 		intervalRunner IntervalRunner
 		fakeScanner    *radarfakes.FakeScanner
+		checkLeases    *CheckLeases
 
 		ctx    context.Context
 		cancel context.CancelFunc
@@ -37,14 +38,16 @@ var _ = Describe("IntervalRunner", func() {
 		fakeScanner = &radarfakes.FakeScanner{}
 		times = make(chan time.Time, 100)
 		interval = 1 * time.Minute
-		fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+		fakeScanner.RunStub = func(context.Context, lager.Logger, string) (time.Duration, error) {
 			times <- fakeClock.Now()
 			return interval, nil
 		}
 		ctx, cancel = context.WithCancel(context.Background())
 
+		checkLeases = NewCheckLeases()
+
 		logger := lagertest.NewTestLogger("test")
-		intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner)
+		intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner, checkLeases)
 	})
 
 	Describe("RunFunc", func() {
@@ -69,16 +72,35 @@ var _ = Describe("IntervalRunner", func() {
 				Expect(<-times).To(Equal(epoch))
 			})
 
-			It("runs a scan on returned interval", func() {
+			It("runs a scan once the returned interval and some jitter have elapsed", func() {
 				Expect(<-times).To(Equal(epoch))
 
-				fakeClock.WaitForWatcherAndIncrement(interval)
-				Expect(<-times).To(Equal(epoch.Add(interval)))
+				fakeClock.WaitForWatcherAndIncrement(interval - time.Second)
+				Consistently(times).ShouldNot(Receive())
+
+				fakeClock.Increment(interval/10 + time.Second)
+				Expect(<-times).To(Equal(epoch.Add(interval + interval/10)))
+			})
+
+			Context("while the scan runs", func() {
+				var held chan int
+
+				BeforeEach(func() {
+					held = make(chan int, 1)
+					fakeScanner.RunStub = func(context.Context, lager.Logger, string) (time.Duration, error) {
+						held <- checkLeases.Held()
+						return interval, nil
+					}
+				})
+
+				It("leaves counting the check lease to the scanner once it acquires it", func() {
+					Expect(<-held).To(BeZero())
+				})
 			})
 
 			Context("when Run takes a while", func() {
 				BeforeEach(func() {
-					fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+					fakeScanner.RunStub = func(context.Context, lager.Logger, string) (time.Duration, error) {
 						times <- fakeClock.Now()
 						fakeClock.Increment(interval / 2)
 						return interval, nil
@@ -89,8 +111,10 @@ var _ = Describe("IntervalRunner", func() {
 					Expect(<-times).To(Equal(epoch))
 
 					fakeClock.WaitForWatcherAndIncrement(interval / 2)
-					fakeClock.Increment(interval / 2)
-					Expect(<-times).To(Equal(epoch.Add(interval + (interval / 2))))
+					Consistently(times).ShouldNot(Receive())
+
+					fakeClock.Increment(interval)
+					Expect(<-times).To(Equal(epoch.Add(2 * interval)))
 				})
 			})
 		})
@@ -98,7 +122,7 @@ var _ = Describe("IntervalRunner", func() {
 		Context("when scanner.Run() returns an error", func() {
 			var disaster = errors.New("failed")
 			BeforeEach(func() {
-				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				fakeScanner.RunStub = func(context.Context, lager.Logger, string) (time.Duration, error) {
 					times <- fakeClock.Now()
 					return interval, disaster
 				}
@@ -111,7 +135,7 @@ var _ = Describe("IntervalRunner", func() {
 
 		Context("when scanner.Run() returns ErrFailedToAcquireLock error", func() {
 			BeforeEach(func() {
-				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				fakeScanner.RunStub = func(context.Context, lager.Logger, string) (time.Duration, error) {
 					times <- fakeClock.Now()
 					return interval, ErrFailedToAcquireLock
 				}
@@ -120,8 +144,11 @@ var _ = Describe("IntervalRunner", func() {
 			It("waits for the interval and tries again", func() {
 				<-times
 
-				fakeClock.WaitForWatcherAndIncrement(interval)
-				Expect(<-times).To(Equal(epoch.Add(interval)))
+				fakeClock.WaitForWatcherAndIncrement(interval - time.Second)
+				Consistently(times).ShouldNot(Receive())
+
+				fakeClock.Increment(interval/2 + time.Second)
+				Expect(<-times).To(Equal(epoch.Add(interval + interval/2)))
 			})
 		})
 
@@ -2,6 +2,7 @@
 package radarfakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -11,11 +12,12 @@ import (
 )
 
 type FakeScanner struct {
-	RunStub        func(lager.Logger, string) (time.Duration, error)
+	RunStub        func(context.Context, lager.Logger, string) (time.Duration, error)
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
 	}
 	runReturns struct {
 		result1 time.Duration
@@ -54,17 +56,18 @@ type FakeScanner struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeScanner) Run(arg1 lager.Logger, arg2 string) (time.Duration, error) {
+func (fake *FakeScanner) Run(arg1 context.Context, arg2 lager.Logger, arg3 string) (time.Duration, error) {
 	fake.runMutex.Lock()
 	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
 	fake.runArgsForCall = append(fake.runArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Run", []interface{}{arg1, arg2})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Run", []interface{}{arg1, arg2, arg3})
 	fake.runMutex.Unlock()
 	if fake.RunStub != nil {
-		return fake.RunStub(arg1, arg2)
+		return fake.RunStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -78,10 +81,10 @@ func (fake *FakeScanner) RunCallCount() int {
 	return len(fake.runArgsForCall)
 }
 
-func (fake *FakeScanner) RunArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeScanner) RunArgsForCall(i int) (context.Context, lager.Logger, string) {
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
-	return fake.runArgsForCall[i].arg1, fake.runArgsForCall[i].arg2
+	return fake.runArgsForCall[i].arg1, fake.runArgsForCall[i].arg2, fake.runArgsForCall[i].arg3
 }
 
 func (fake *FakeScanner) RunReturns(result1 time.Duration, result2 error) {
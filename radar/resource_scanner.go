@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -28,6 +29,7 @@ type resourceScanner struct {
 	externalURL                       string
 	variables                         creds.Variables
 	typeScanner                       Scanner
+	checkRateLimiter                  *CheckRateLimiter
 }
 
 func NewResourceScanner(
@@ -39,6 +41,7 @@ func NewResourceScanner(
 	externalURL string,
 	variables creds.Variables,
 	typeScanner Scanner,
+	checkRateLimiter *CheckRateLimiter,
 ) Scanner {
 	return &resourceScanner{
 		clock:                             clock,
@@ -49,13 +52,14 @@ func NewResourceScanner(
 		externalURL:                       externalURL,
 		variables:                         variables,
 		typeScanner:                       typeScanner,
+		checkRateLimiter:                  checkRateLimiter,
 	}
 }
 
 var ErrFailedToAcquireLock = errors.New("failed-to-acquire-lock")
 
-func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, false)
+func (scanner *resourceScanner) Run(ctx context.Context, logger lager.Logger, resourceName string) (time.Duration, error) {
+	interval, err := scanner.scan(ctx, logger.Session("tick"), resourceName, nil, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -63,20 +67,20 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceName, fromVersion, true)
+	_, err := scanner.scan(context.Background(), logger, resourceName, fromVersion, true)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
-	_, err := scanner.scan(logger, resourceName, nil, true)
+	_, err := scanner.scan(context.Background(), logger, resourceName, nil, true)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
+func (scanner *resourceScanner) scan(ctx context.Context, logger lager.Logger, resourceName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -98,6 +102,11 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		return 0, err
 	}
 
+	if savedResource.CheckDisabled() {
+		logger.Debug("checks-disabled")
+		return interval, nil
+	}
+
 	resourceTypes, err := scanner.dbPipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
@@ -138,6 +147,8 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		return 0, err
 	}
 
+	source = versionedResourceTypes.WithBaseResourceTypeDefaults(savedResource.Type(), source)
+
 	resourceConfigCheckSession, err := scanner.resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
 		logger,
 		savedResource.Type(),
@@ -158,6 +169,26 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		return 0, err
 	}
 
+	// only periodic checks count against the budget; checks which must
+	// complete were asked for by a user or a build. the budget is reserved
+	// before taking the lease so that the lease is not held while waiting for
+	// it, and is given back if the check does not go ahead, e.g. because
+	// another ATC holds the lease.
+	rateLimited := !mustComplete && !savedResource.IgnoreCheckRateLimit()
+	if rateLimited {
+		wait := scanner.checkRateLimiter.Reserve()
+		if wait > 0 {
+			logger.Debug("check-rate-limited", lager.Data{"wait": wait.String()})
+
+			select {
+			case <-scanner.clock.After(wait):
+			case <-ctx.Done():
+				scanner.checkRateLimiter.Cancel()
+				return interval, ctx.Err()
+			}
+		}
+	}
+
 	for breaker := true; breaker == true; breaker = mustComplete {
 		lock, acquired, err := scanner.dbPipeline.AcquireResourceCheckingLockWithIntervalCheck(
 			logger,
@@ -170,6 +201,11 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 			lockLogger.Error("failed-to-get-lock", err, lager.Data{
 				"resource": resourceName,
 			})
+
+			if rateLimited {
+				scanner.checkRateLimiter.Cancel()
+			}
+
 			return interval, ErrFailedToAcquireLock
 		}
 
@@ -179,11 +215,16 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 				scanner.clock.Sleep(time.Second)
 				continue
 			} else {
+				if rateLimited {
+					scanner.checkRateLimiter.Cancel()
+				}
+
 				return interval, ErrFailedToAcquireLock
 			}
 		}
 
 		defer lock.Release()
+		defer holdCheckLease(ctx)()
 
 		break
 	}
@@ -197,7 +238,14 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		fromVersion = atc.Version(vr.Version)
 	}
 
-	return interval, scanner.check(
+	span, ctx := tracing.StartSpan(ctx, "check", tracing.Attrs{
+		"team":     scanner.dbPipeline.TeamName(),
+		"pipeline": scanner.dbPipeline.Name(),
+		"resource": resourceName,
+	})
+
+	err = scanner.check(
+		ctx,
 		logger,
 		savedResource,
 		resourceConfigCheckSession,
@@ -205,9 +253,13 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		versionedResourceTypes,
 		source,
 	)
+	tracing.End(span, err)
+
+	return interval, err
 }
 
 func (scanner *resourceScanner) check(
+	ctx context.Context,
 	logger lager.Logger,
 	savedResource db.Resource,
 	resourceConfigCheckSession db.ResourceConfigCheckSession,
@@ -254,10 +306,16 @@ func (scanner *resourceScanner) check(
 		Tags:   savedResource.Tags(),
 		TeamID: scanner.dbPipeline.TeamID(),
 		Env:    metadata.Env(),
+
+		IsolationLabels: worker.IsolationLabels{
+			Team:     scanner.dbPipeline.TeamName(),
+			Pipeline: scanner.dbPipeline.Name(),
+			Public:   scanner.dbPipeline.Public(),
+		},
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
-		context.Background(),
+		ctx,
 		logger,
 		db.NewResourceConfigCheckSessionContainerOwner(resourceConfigCheckSession, scanner.dbPipeline.TeamID()),
 		db.ContainerMetadata{
@@ -284,10 +342,10 @@ func (scanner *resourceScanner) check(
 		logger.Error("failed-to-read-check-timeout", err)
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	newVersions, err := res.Check(ctx, source, fromVersion)
+	newVersions, err := res.Check(checkCtx, source, fromVersion)
 	if err == context.DeadlineExceeded {
 		err = fmt.Errorf("Timed out after %v while checking for new versions - perhaps increase your resource check timeout?", timeout)
 	}
@@ -23,6 +23,8 @@ import (
 	rfakes "github.com/concourse/atc/resource/resourcefakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
 )
 
 var _ = Describe("ResourceScanner", func() {
@@ -47,6 +49,8 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBResource     *dbfakes.FakeResource
 		fakeResourceConfig *dbfakes.FakeResourceConfig
 
+		checkRateLimiter *CheckRateLimiter
+
 		fakeLock *lockfakes.FakeLock
 		teamID   = 123
 	)
@@ -91,6 +95,7 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.NameReturns("some-pipeline")
+		fakeDBPipeline.TeamNameReturns("some-team")
 		fakeDBPipeline.TeamIDReturns(teamID)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 
@@ -116,6 +121,8 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeResourceTypeScanner = new(radarfakes.FakeScanner)
 
+		checkRateLimiter = NewCheckRateLimiter(fakeClock, 1)
+
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeResourceFactory,
@@ -125,12 +132,14 @@ var _ = Describe("ResourceScanner", func() {
 			"https://www.example.com",
 			variables,
 			fakeResourceTypeScanner,
+			checkRateLimiter,
 		)
 	})
 
 	Describe("Run", func() {
 		var (
 			fakeResource   *rfakes.FakeResource
+			runCtx         context.Context
 			actualInterval time.Duration
 			runErr         error
 		)
@@ -138,10 +147,12 @@ var _ = Describe("ResourceScanner", func() {
 		BeforeEach(func() {
 			fakeResource = new(rfakes.FakeResource)
 			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+
+			runCtx = context.Background()
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), "some-resource")
+			actualInterval, runErr = scanner.Run(runCtx, lagertest.NewTestLogger("test"), "some-resource")
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -157,6 +168,36 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(runErr).To(Equal(ErrFailedToAcquireLock))
 				Expect(actualInterval).To(Equal(interval))
 			})
+
+			It("does not spend any of the budget of checks", func() {
+				Expect(checkRateLimiter.Reserve()).To(BeZero())
+			})
+
+			Context("when run with check leases", func() {
+				var checkLeases *CheckLeases
+
+				BeforeEach(func() {
+					checkLeases = NewCheckLeases()
+					runCtx = checkLeases.Context(runCtx)
+				})
+
+				It("does not account for the lease", func() {
+					Expect(checkLeases.Held()).To(BeZero())
+				})
+			})
+
+			Context("after waiting for budget", func() {
+				BeforeEach(func() {
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+
+					// allow the wait for budget to continue
+					go fakeClock.WaitForWatcherAndIncrement(time.Second)
+				})
+
+				It("gives the budget back", func() {
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when the lock can be acquired", func() {
@@ -168,6 +209,32 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(fakeResource.CheckCallCount()).To(Equal(1))
 			})
 
+			Context("when the scan is traced", func() {
+				var (
+					tracer *mocktracer.MockTracer
+					parent opentracing.Span
+				)
+
+				BeforeEach(func() {
+					tracer = mocktracer.New()
+					opentracing.SetGlobalTracer(tracer)
+
+					parent = tracer.StartSpan("some-parent")
+					runCtx = opentracing.ContextWithSpan(runCtx, parent)
+				})
+
+				AfterEach(func() {
+					opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+				})
+
+				It("traces the check as part of the scan", func() {
+					spans := tracer.FinishedSpans()
+					Expect(spans).To(HaveLen(1))
+					Expect(spans[0].OperationName).To(Equal("check"))
+					Expect(spans[0].ParentID).To(Equal(parent.Context().(mocktracer.MockSpanContext).SpanID))
+				})
+			})
+
 			It("constructs the resource of the correct type", func() {
 				Expect(fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionCallCount()).To(Equal(1))
 				_, resourceType, resourceSource, resourceTypes, _ := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
@@ -190,8 +257,9 @@ var _ = Describe("ResourceScanner", func() {
 					ImageSpec: worker.ImageSpec{
 						ResourceType: "git",
 					},
-					Tags:   atc.Tags{"some-tag"},
-					TeamID: 123,
+					Tags:            atc.Tags{"some-tag"},
+					TeamID:          123,
+					IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 					Env: []string{
 						"ATC_EXTERNAL_URL=https://www.example.com",
 						"RESOURCE_PIPELINE_NAME=some-pipeline",
@@ -245,6 +313,28 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when run with check leases", func() {
+				var (
+					checkLeases     *CheckLeases
+					heldDuringCheck int
+				)
+
+				BeforeEach(func() {
+					checkLeases = NewCheckLeases()
+					runCtx = checkLeases.Context(runCtx)
+
+					fakeResource.CheckStub = func(context.Context, atc.Source, atc.Version) ([]atc.Version, error) {
+						heldDuringCheck = checkLeases.Held()
+						return nil, nil
+					}
+				})
+
+				It("accounts for the lease while checking", func() {
+					Expect(heldDuringCheck).To(Equal(1))
+					Expect(checkLeases.Held()).To(BeZero())
+				})
+			})
+
 			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
 
@@ -409,6 +499,100 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the budget of checks has been spent", func() {
+				var waitedBeforeLocking time.Duration
+
+				BeforeEach(func() {
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+
+					fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckStub = func(lager.Logger, string, db.ResourceConfig, time.Duration, bool) (lock.Lock, bool, error) {
+						waitedBeforeLocking = fakeClock.Since(epoch)
+						return fakeLock, true, nil
+					}
+
+					// allow the wait for budget to continue
+					go fakeClock.WaitForWatcherAndIncrement(time.Second)
+				})
+
+				It("waits for budget before acquiring the checking lock", func() {
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
+					Expect(waitedBeforeLocking).To(Equal(time.Second))
+				})
+
+				It("checks once there is budget for it", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when stopped while waiting for budget", func() {
+				BeforeEach(func() {
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					runCtx = ctx
+				})
+
+				It("gives up without acquiring the checking lock", func() {
+					Expect(runErr).To(Equal(context.Canceled))
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("gives the budget back", func() {
+					fakeClock.Increment(time.Second)
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+				})
+			})
+
+			Context("when the resource ignores the check rate limit and the budget has been spent", func() {
+				BeforeEach(func() {
+					fakeDBResource.IgnoreCheckRateLimitReturns(true)
+
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+				})
+
+				It("checks without waiting", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+					Expect(fakeClock.Since(epoch)).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+			})
+
+			Context("when checks are disabled for the resource", func() {
+				BeforeEach(func() {
+					fakeDBResource.CheckDisabledReturns(true)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("does not acquire a checking lock", func() {
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("when the pipeline is paused", func() {
 				BeforeEach(func() {
 					fakeDBPipeline.CheckPausedReturns(true, nil)
@@ -509,6 +693,16 @@ var _ = Describe("ResourceScanner", func() {
 				fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
 			})
 
+			Context("when the budget of checks has been spent", func() {
+				BeforeEach(func() {
+					Expect(checkRateLimiter.Reserve()).To(BeZero())
+				})
+
+				It("checks anyway", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("Parent resource has no version and attempt to Scan fails", func() {
 				BeforeEach(func() {
 					var fakeGitResourceType *dbfakes.FakeResourceType
@@ -570,8 +764,9 @@ var _ = Describe("ResourceScanner", func() {
 					ImageSpec: worker.ImageSpec{
 						ResourceType: "git",
 					},
-					Tags:   atc.Tags{"some-tag"},
-					TeamID: 123,
+					Tags:            atc.Tags{"some-tag"},
+					TeamID:          123,
+					IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 					Env: []string{
 						"ATC_EXTERNAL_URL=https://www.example.com",
 						"RESOURCE_PIPELINE_NAME=some-pipeline",
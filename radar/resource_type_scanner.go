@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -44,8 +45,8 @@ func NewResourceTypeScanner(
 	}
 }
 
-func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName string) (time.Duration, error) {
-	return scanner.scan(logger.Session("tick"), resourceTypeName, nil, false)
+func (scanner *resourceTypeScanner) Run(ctx context.Context, logger lager.Logger, resourceTypeName string) (time.Duration, error) {
+	return scanner.scan(ctx, logger.Session("tick"), resourceTypeName, nil, false)
 }
 
 func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourceTypeName string, fromVersion atc.Version) error {
@@ -53,12 +54,12 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
-	_, err := scanner.scan(logger, resourceTypeName, nil, true)
+	_, err := scanner.scan(context.Background(), logger, resourceTypeName, nil, true)
 
 	return err
 }
 
-func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
+func (scanner *resourceTypeScanner) scan(ctx context.Context, logger lager.Logger, resourceTypeName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource-type": resourceTypeName,
 	})
@@ -118,6 +119,8 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		return 0, err
 	}
 
+	source = versionedResourceTypes.Without(savedResourceType.Name()).WithBaseResourceTypeDefaults(savedResourceType.Type(), source)
+
 	resourceConfigCheckSession, err := scanner.resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
 		logger,
 		savedResourceType.Type(),
@@ -162,6 +165,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		}
 
 		defer lock.Release()
+		defer holdCheckLease(ctx)()
 
 		break
 	}
@@ -170,7 +174,14 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		fromVersion = atc.Version(savedResourceType.Version())
 	}
 
-	return interval, scanner.check(
+	span, ctx := tracing.StartSpan(ctx, "check", tracing.Attrs{
+		"team":          scanner.dbPipeline.TeamName(),
+		"pipeline":      scanner.dbPipeline.Name(),
+		"resource-type": resourceTypeName,
+	})
+
+	err = scanner.check(
+		ctx,
 		logger,
 		savedResourceType,
 		resourceConfigCheckSession,
@@ -178,9 +189,13 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		versionedResourceTypes,
 		source,
 	)
+	tracing.End(span, err)
+
+	return interval, err
 }
 
 func (scanner *resourceTypeScanner) check(
+	ctx context.Context,
 	logger lager.Logger,
 	savedResourceType db.ResourceType,
 	resourceConfigCheckSession db.ResourceConfigCheckSession,
@@ -205,10 +220,16 @@ func (scanner *resourceTypeScanner) check(
 		},
 		Tags:   savedResourceType.Tags(),
 		TeamID: scanner.dbPipeline.TeamID(),
+
+		IsolationLabels: worker.IsolationLabels{
+			Team:     scanner.dbPipeline.TeamName(),
+			Pipeline: scanner.dbPipeline.Name(),
+			Public:   scanner.dbPipeline.Public(),
+		},
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
-		context.Background(),
+		ctx,
 		logger,
 		db.NewResourceConfigCheckSessionContainerOwner(resourceConfigCheckSession, scanner.dbPipeline.TeamID()),
 		db.ContainerMetadata{
@@ -223,7 +244,7 @@ func (scanner *resourceTypeScanner) check(
 		return err
 	}
 
-	newVersions, err := res.Check(context.TODO(), source, fromVersion)
+	newVersions, err := res.Check(ctx, source, fromVersion)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
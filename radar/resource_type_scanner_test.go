@@ -85,6 +85,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.NameReturns("some-pipeline")
+		fakeDBPipeline.TeamNameReturns("some-team")
 		fakeDBPipeline.TeamIDReturns(teamID)
 		fakeDBPipeline.ReloadReturns(true, nil)
 		fakeDBPipeline.ResourceTypesReturns([]db.ResourceType{fakeResourceType}, nil)
@@ -114,7 +115,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), fakeResourceType.Name())
+			actualInterval, runErr = scanner.Run(context.TODO(), lagertest.NewTestLogger("test"), fakeResourceType.Name())
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -162,8 +163,9 @@ var _ = Describe("ResourceTypeScanner", func() {
 					ImageSpec: worker.ImageSpec{
 						ResourceType: "registry-image",
 					},
-					Tags:   []string{"some-tag"},
-					TeamID: 123,
+					Tags:            []string{"some-tag"},
+					TeamID:          123,
+					IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 				}))
 				Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
 			})
@@ -210,7 +212,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 						ImageSpec: worker.ImageSpec{
 							ResourceType: "registry-image",
 						},
-						TeamID: 123,
+						TeamID:          123,
+						IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 					}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
@@ -405,8 +408,9 @@ var _ = Describe("ResourceTypeScanner", func() {
 					ImageSpec: worker.ImageSpec{
 						ResourceType: "registry-image",
 					},
-					Tags:   []string{"some-tag"},
-					TeamID: 123,
+					Tags:            []string{"some-tag"},
+					TeamID:          123,
+					IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 				}))
 				Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
 			})
@@ -518,7 +522,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 						ImageSpec: worker.ImageSpec{
 							ResourceType: "registry-image",
 						},
-						TeamID: 123,
+						TeamID:          123,
+						IsolationLabels: worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 					}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"context"
 	"time"
 
 	"github.com/concourse/atc"
@@ -15,7 +16,7 @@ import (
 //go:generate counterfeiter . Scanner
 
 type Scanner interface {
-	Run(lager.Logger, string) (time.Duration, error)
+	Run(context.Context, lager.Logger, string) (time.Duration, error)
 	Scan(lager.Logger, string) error
 	ScanFromVersion(lager.Logger, string, atc.Version) error
 }
@@ -29,6 +30,7 @@ type ScanRunnerFactory interface {
 
 type scanRunnerFactory struct {
 	clock               clock.Clock
+	checkLeases         *CheckLeases
 	resourceScanner     Scanner
 	resourceTypeScanner Scanner
 }
@@ -40,6 +42,8 @@ func NewScanRunnerFactory(
 	resourceCheckingInterval time.Duration,
 	dbPipeline db.Pipeline,
 	clock clock.Clock,
+	checkLeases *CheckLeases,
+	checkRateLimiter *CheckRateLimiter,
 	externalURL string,
 	variables creds.Variables,
 ) ScanRunnerFactory {
@@ -62,18 +66,20 @@ func NewScanRunnerFactory(
 		externalURL,
 		variables,
 		resourceTypeScanner,
+		checkRateLimiter,
 	)
 	return &scanRunnerFactory{
 		clock:               clock,
+		checkLeases:         checkLeases,
 		resourceScanner:     resourceScanner,
 		resourceTypeScanner: resourceTypeScanner,
 	}
 }
 
 func (sf *scanRunnerFactory) ScanResourceRunner(logger lager.Logger, name string) IntervalRunner {
-	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceScanner)
+	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceScanner, sf.checkLeases)
 }
 
 func (sf *scanRunnerFactory) ScanResourceTypeRunner(logger lager.Logger, name string) IntervalRunner {
-	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceTypeScanner)
+	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceTypeScanner, sf.checkLeases)
 }
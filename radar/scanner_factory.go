@@ -73,6 +73,7 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		f.externalURL,
 		variables,
 		resourceTypeScanner,
+		nil,
 	)
 }
 
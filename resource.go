@@ -7,8 +7,14 @@ type Resource struct {
 	Type         string `json:"type"`
 	LastChecked  int64  `json:"last_checked,omitempty"`
 
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+
 	Paused bool `json:"paused,omitempty"`
 
+	PinnedVersion  Version `json:"pinned_version,omitempty"`
+	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
+
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
@@ -96,6 +96,8 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		ResourceType: string(f.resourceInstance.ResourceType()),
 		Tags:         f.tags,
 		TeamID:       f.teamID,
+
+		RestrictedNetwork: f.session.Network != nil && f.session.Network.Restricted(),
 	}
 
 	chosenWorker, err := f.workerClient.Satisfying(f.logger.Session("fetch-source-provider"), resourceSpec, f.resourceTypes)
@@ -0,0 +1,79 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+// MaxVersionSize and MaxMetadataSize limit how large, in bytes, each version
+// a resource emits and the metadata accompanying it may be once encoded as
+// JSON. They are kept in the database and returned by the API for as long as
+// the version is, so a resource type emitting huge payloads is failed rather
+// than trusted. A limit of 0 means no limit.
+var MaxVersionSize int
+var MaxMetadataSize int
+
+type ErrVersionTooLarge struct {
+	Size  int
+	Limit int
+}
+
+func (err ErrVersionTooLarge) Error() string {
+	return fmt.Sprintf("resource emitted a version of %d bytes, which is over the limit of %d bytes", err.Size, err.Limit)
+}
+
+type ErrMetadataTooLarge struct {
+	Size  int
+	Limit int
+}
+
+func (err ErrMetadataTooLarge) Error() string {
+	return fmt.Sprintf("resource emitted %d bytes of metadata, which is over the limit of %d bytes", err.Size, err.Limit)
+}
+
+// ValidateVersion returns ErrVersionTooLarge if the version is over
+// MaxVersionSize. Versions saved through the API are held to the same limit.
+func ValidateVersion(version atc.Version) error {
+	if MaxVersionSize == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > MaxVersionSize {
+		return ErrVersionTooLarge{Size: len(payload), Limit: MaxVersionSize}
+	}
+
+	return nil
+}
+
+func validateMetadata(metadata []atc.MetadataField) error {
+	if MaxMetadataSize == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > MaxMetadataSize {
+		return ErrMetadataTooLarge{Size: len(payload), Limit: MaxMetadataSize}
+	}
+
+	return nil
+}
+
+func (vr versionResult) validate() error {
+	err := ValidateVersion(vr.Version)
+	if err != nil {
+		return err
+	}
+
+	return validateMetadata(vr.Metadata)
+}
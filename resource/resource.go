@@ -22,7 +22,9 @@ type Resource interface {
 type ResourceType string
 
 type Session struct {
-	Metadata db.ContainerMetadata
+	Metadata        db.ContainerMetadata
+	Network         *atc.NetworkConfig
+	IsolationLabels worker.IsolationLabels
 }
 
 type Metadata interface {
@@ -27,5 +27,12 @@ func (resource *resource) Check(ctx context.Context, source atc.Source, fromVers
 		return nil, err
 	}
 
+	for _, version := range versions {
+		err := ValidateVersion(version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return versions, nil
 }
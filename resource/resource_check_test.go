@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/resource"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -93,6 +94,21 @@ var _ = Describe("Resource Check", func() {
 			}))
 
 		})
+
+		Context("when a version is over the size limit", func() {
+			BeforeEach(func() {
+				resource.MaxVersionSize = 15
+				checkScriptStdout = `[{"ver":"abc"}, {"ver":"a-much-longer-version"}]`
+			})
+
+			AfterEach(func() {
+				resource.MaxVersionSize = 0
+			})
+
+			It("returns an error saying so", func() {
+				Expect(checkErr).To(Equal(resource.ErrVersionTooLarge{Size: 31, Limit: 15}))
+			})
+		})
 	})
 
 	Context("when running /opt/resource/check fails", func() {
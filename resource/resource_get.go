@@ -36,5 +36,10 @@ func (resource *resource) Get(
 		return nil, err
 	}
 
+	err = vr.validate()
+	if err != nil {
+		return nil, err
+	}
+
 	return NewGetVersionedSource(volume, vr.Version, vr.Metadata), nil
 }
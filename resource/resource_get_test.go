@@ -363,6 +363,35 @@ var _ = Describe("Resource Get", func() {
 					Expect(name).To(Equal("concourse:resource-result"))
 					Expect(value).To(Equal(inScriptStdout))
 				})
+
+				Context("when the version is over the size limit", func() {
+					BeforeEach(func() {
+						resource.MaxVersionSize = 10
+					})
+
+					AfterEach(func() {
+						resource.MaxVersionSize = 0
+					})
+
+					It("returns an error saying so", func() {
+						Expect(getErr).To(Equal(resource.ErrVersionTooLarge{Size: 22, Limit: 10}))
+					})
+				})
+
+				Context("when the metadata is over the size limit", func() {
+					BeforeEach(func() {
+						resource.MaxMetadataSize = 10
+					})
+
+					AfterEach(func() {
+						resource.MaxMetadataSize = 0
+					})
+
+					It("returns an error saying so", func() {
+						Expect(getErr).To(BeAssignableToTypeOf(resource.ErrMetadataTooLarge{}))
+						Expect(getErr.Error()).To(ContainSubstring("over the limit of 10 bytes"))
+					})
+				})
 			})
 
 			Context("when /in outputs to stderr", func() {
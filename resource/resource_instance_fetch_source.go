@@ -101,9 +101,12 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (VersionedSour
 		ImageSpec: worker.ImageSpec{
 			ResourceType: string(s.resourceInstance.ResourceType()),
 		},
-		Tags:   s.tags,
-		TeamID: s.teamID,
-		Env:    s.metadata.Env(),
+		Tags:    s.tags,
+		TeamID:  s.teamID,
+		Env:     s.metadata.Env(),
+		Network: s.session.Network,
+
+		IsolationLabels: s.session.IsolationLabels,
 
 		Outputs: map[string]string{
 			"resource": mountPath,
@@ -40,5 +40,10 @@ func (resource *resource) Put(
 		return nil, err
 	}
 
+	err = vs.versionResult.validate()
+	if err != nil {
+		return nil, err
+	}
+
 	return vs, nil
 }
@@ -391,6 +391,35 @@ var _ = Describe("Resource Put", func() {
 					Expect(name).To(Equal("concourse:resource-result"))
 					Expect(value).To(Equal(outScriptStdout))
 				})
+
+				Context("when the version is over the size limit", func() {
+					BeforeEach(func() {
+						MaxVersionSize = 10
+					})
+
+					AfterEach(func() {
+						MaxVersionSize = 0
+					})
+
+					It("returns an error saying so", func() {
+						Expect(putErr).To(Equal(ErrVersionTooLarge{Size: 22, Limit: 10}))
+					})
+				})
+
+				Context("when the metadata is over the size limit", func() {
+					BeforeEach(func() {
+						MaxMetadataSize = 10
+					})
+
+					AfterEach(func() {
+						MaxMetadataSize = 0
+					})
+
+					It("returns an error saying so", func() {
+						Expect(putErr).To(BeAssignableToTypeOf(ErrMetadataTooLarge{}))
+						Expect(putErr.Error()).To(ContainSubstring("over the limit of 10 bytes"))
+					})
+				})
 			})
 
 			Context("when /out outputs to stderr", func() {
@@ -3,30 +3,39 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig        = "SaveConfig"
+	GetConfig         = "GetConfig"
+	GetConfigChecksum = "GetConfigChecksum"
+	DiffConfig        = "DiffConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
+	BuildEventsWS       = "BuildEventsWS"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	AbortJobBuilds      = "AbortJobBuilds"
+	AbortTeamBuilds     = "AbortTeamBuilds"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildBundle      = "GetBuildBundle"
+	GetBuildArtifact    = "GetBuildArtifact"
 
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetJob           = "GetJob"
+	CreateJobBuild   = "CreateJobBuild"
+	ListAllJobs      = "ListAllJobs"
+	ListJobs         = "ListJobs"
+	ListJobBuilds    = "ListJobBuilds"
+	ListJobInputs    = "ListJobInputs"
+	LatestJobOutputs = "LatestJobOutputs"
+	ExplainJobInputs = "ExplainJobInputs"
+	GetJobBuild      = "GetJobBuild"
+	PauseJob         = "PauseJob"
+	UnpauseJob       = "UnpauseJob"
+	GetVersionsDB    = "GetVersionsDB"
+	JobBadge         = "JobBadge"
+	MainJobBadge     = "MainJobBadge"
 
 	ClearTaskCache = "ClearTaskCache"
 
@@ -36,6 +45,8 @@ const (
 	GetResource          = "GetResource"
 	PauseResource        = "PauseResource"
 	UnpauseResource      = "UnpauseResource"
+	PinResource          = "PinResource"
+	UnpinResource        = "UnpinResource"
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
 	CheckResourceType    = "CheckResourceType"
@@ -44,6 +55,7 @@ const (
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	SaveResourceVersions          = "SaveResourceVersions"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
@@ -52,6 +64,7 @@ const (
 	ListPipelines       = "ListPipelines"
 	GetPipeline         = "GetPipeline"
 	DeletePipeline      = "DeletePipeline"
+	UndeletePipeline    = "UndeletePipeline"
 	OrderPipelines      = "OrderPipelines"
 	PausePipeline       = "PausePipeline"
 	UnpausePipeline     = "UnpausePipeline"
@@ -61,6 +74,7 @@ const (
 	ListPipelineBuilds  = "ListPipelineBuilds"
 	CreatePipelineBuild = "CreatePipelineBuild"
 	PipelineBadge       = "PipelineBadge"
+	PipelineGraph       = "PipelineGraph"
 
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
@@ -76,6 +90,9 @@ const (
 	DownloadCLI  = "DownloadCLI"
 	GetInfo      = "Info"
 	GetInfoCreds = "InfoCreds"
+	GetInfoKeys  = "InfoKeys"
+
+	GetPipelineConfigSchema = "GetPipelineConfigSchema"
 
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
@@ -87,14 +104,28 @@ const (
 	ListDestroyingVolumes = "ListDestroyingVolumes"
 	ReportWorkerVolumes   = "ReportWorkerVolumes"
 
+	ListCaches   = "ListCaches"
+	DestroyCache = "DestroyCache"
+
 	ListTeams      = "ListTeams"
 	SetTeam        = "SetTeam"
 	RenameTeam     = "RenameTeam"
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
-	SendInputToBuildPlan    = "SendInputToBuildPlan"
-	ReadOutputFromBuildPlan = "ReadOutputFromBuildPlan"
+	GetTeamSettings = "GetTeamSettings"
+	SetTeamSettings = "SetTeamSettings"
+	GetTeamUsage    = "GetTeamUsage"
+	ExportTeam      = "ExportTeam"
+	ImportTeam      = "ImportTeam"
+
+	ListWebhooks  = "ListWebhooks"
+	CreateWebhook = "CreateWebhook"
+	DeleteWebhook = "DeleteWebhook"
+
+	SendInputToBuildPlan      = "SendInputToBuildPlan"
+	GetBuildPlanInputProgress = "GetBuildPlanInputProgress"
+	ReadOutputFromBuildPlan   = "ReadOutputFromBuildPlan"
 )
 
 const (
@@ -105,6 +136,8 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/checksum", Method: "GET", Name: GetConfigChecksum},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", Method: "PUT", Name: DiffConfig},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -112,18 +145,26 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
 	{Path: "/api/v1/builds/:build_id/plan/:plan_id/input", Method: "PUT", Name: SendInputToBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/:plan_id/input", Method: "HEAD", Name: GetBuildPlanInputProgress},
 	{Path: "/api/v1/builds/:build_id/plan/:plan_id/output", Method: "GET", Name: ReadOutputFromBuildPlan},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
+	{Path: "/api/v1/builds/:build_id/events/ws", Method: "GET", Name: BuildEventsWS},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/teams/:team_name/builds/abort", Method: "POST", Name: AbortTeamBuilds},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/bundle", Method: "GET", Name: GetBuildBundle},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_name", Method: "GET", Name: GetBuildArtifact},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/abort", Method: "POST", Name: AbortJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs/explain", Method: "GET", Name: ExplainJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/last-successful-outputs", Method: "GET", Name: LatestJobOutputs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -136,6 +177,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "GET", Name: GetPipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "DELETE", Name: DeletePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/undelete", Method: "PUT", Name: UndeletePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
@@ -146,6 +188,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/graph", Method: "GET", Name: PipelineGraph},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -153,11 +196,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin", Method: "PUT", Name: PinResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_name/check", Method: "POST", Name: CheckResourceType},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "PUT", Name: SaveResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
@@ -179,6 +225,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
+	{Path: "/api/v1/info/keys", Method: "GET", Name: GetInfoKeys},
+
+	{Path: "/api/v1/schemas/pipeline", Method: "GET", Name: GetPipelineConfigSchema},
 
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
@@ -190,9 +239,21 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/volumes/destroying", Method: "GET", Name: ListDestroyingVolumes},
 	{Path: "/api/v1/volumes/report", Method: "PUT", Name: ReportWorkerVolumes},
 
+	{Path: "/api/v1/caches", Method: "GET", Name: ListCaches},
+	{Path: "/api/v1/caches/:id", Method: "DELETE", Name: DestroyCache},
+
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/settings", Method: "GET", Name: GetTeamSettings},
+	{Path: "/api/v1/teams/:team_name/settings", Method: "PUT", Name: SetTeamSettings},
+	{Path: "/api/v1/teams/:team_name/usage", Method: "GET", Name: GetTeamUsage},
+	{Path: "/api/v1/teams/:team_name/export", Method: "GET", Name: ExportTeam},
+	{Path: "/api/v1/teams/:team_name/import", Method: "PUT", Name: ImportTeam},
+
+	{Path: "/api/v1/teams/:team_name/webhooks", Method: "GET", Name: ListWebhooks},
+	{Path: "/api/v1/teams/:team_name/webhooks", Method: "POST", Name: CreateWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_id", Method: "DELETE", Name: DeleteWebhook},
 })
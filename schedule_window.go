@@ -0,0 +1,122 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleWindow limits when builds of a job may be started automatically.
+// Builds which are triggered manually are always started.
+//
+// Start and Stop are times of day (HH:MM) in the given Location, defaulting
+// to UTC. If Stop is before Start the window spans midnight, in which case
+// Days refers to the day on which the window opens. If no Days are given the
+// window applies to every day.
+type ScheduleWindow struct {
+	Days     []string `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	Start    string   `yaml:"start" json:"start" mapstructure:"start"`
+	Stop     string   `yaml:"stop" json:"stop" mapstructure:"stop"`
+	Location string   `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+}
+
+func (window ScheduleWindow) Validate() []string {
+	errorMessages := []string{}
+
+	start, startErr := parseTimeOfDay(window.Start)
+	if startErr != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid start '%s' (must be HH:MM)", window.Start))
+	}
+
+	stop, stopErr := parseTimeOfDay(window.Stop)
+	if stopErr != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid stop '%s' (must be HH:MM)", window.Stop))
+	}
+
+	if startErr == nil && stopErr == nil && start == stop {
+		errorMessages = append(errorMessages, fmt.Sprintf("start and stop are both '%s' (the window would never be open)", window.Start))
+	}
+
+	if _, err := window.location(); err != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("unknown location '%s'", window.Location))
+	}
+
+	for _, day := range window.Days {
+		if _, found := parseWeekday(day); !found {
+			errorMessages = append(errorMessages, fmt.Sprintf("unknown day '%s'", day))
+		}
+	}
+
+	return errorMessages
+}
+
+// Contains returns whether the window is open at the given time.
+func (window ScheduleWindow) Contains(t time.Time) (bool, error) {
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return false, err
+	}
+
+	stop, err := parseTimeOfDay(window.Stop)
+	if err != nil {
+		return false, err
+	}
+
+	loc, err := window.location()
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(loc)
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	today := t.Weekday()
+	yesterday := (today + 6) % 7
+
+	if start <= stop {
+		return window.onDay(today) && now >= start && now < stop, nil
+	}
+
+	return (window.onDay(today) && now >= start) || (window.onDay(yesterday) && now < stop), nil
+}
+
+func (window ScheduleWindow) onDay(weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, day := range window.Days {
+		if parsed, found := parseWeekday(day); found && parsed == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (window ScheduleWindow) location() (*time.Location, error) {
+	if window.Location == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(window.Location)
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if strings.ToLower(day) == name || strings.ToLower(day) == name[:3] {
+			return weekday, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,76 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScheduleWindow", func() {
+	// 2018-08-20 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2018, time.August, 20, hour, minute, 0, 0, time.UTC)
+	}
+
+	Describe("Contains", func() {
+		It("contains times between start and stop", func() {
+			window := atc.ScheduleWindow{Start: "09:00", Stop: "17:00"}
+
+			Expect(window.Contains(monday(9, 0))).To(BeTrue())
+			Expect(window.Contains(monday(16, 59))).To(BeTrue())
+			Expect(window.Contains(monday(17, 0))).To(BeFalse())
+			Expect(window.Contains(monday(8, 59))).To(BeFalse())
+		})
+
+		It("only contains times on the given days", func() {
+			window := atc.ScheduleWindow{Days: []string{"Tuesday", "wed"}, Start: "09:00", Stop: "17:00"}
+
+			Expect(window.Contains(monday(12, 0))).To(BeFalse())
+			Expect(window.Contains(monday(12, 0).AddDate(0, 0, 1))).To(BeTrue())
+			Expect(window.Contains(monday(12, 0).AddDate(0, 0, 2))).To(BeTrue())
+		})
+
+		It("spans midnight when stop is before start", func() {
+			window := atc.ScheduleWindow{Days: []string{"Monday"}, Start: "22:00", Stop: "06:00"}
+
+			Expect(window.Contains(monday(23, 0))).To(BeTrue())
+			Expect(window.Contains(monday(23, 0).Add(4 * time.Hour))).To(BeTrue())
+			Expect(window.Contains(monday(5, 0))).To(BeFalse())
+			Expect(window.Contains(monday(12, 0))).To(BeFalse())
+		})
+
+		It("uses the location", func() {
+			window := atc.ScheduleWindow{Start: "09:00", Stop: "17:00", Location: "America/New_York"}
+
+			Expect(window.Contains(monday(12, 0))).To(BeFalse())
+			Expect(window.Contains(monday(14, 0))).To(BeTrue())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("returns no errors for a valid window", func() {
+			window := atc.ScheduleWindow{Days: []string{"Mon"}, Start: "09:00", Stop: "17:00", Location: "Europe/London"}
+			Expect(window.Validate()).To(BeEmpty())
+		})
+
+		It("returns errors for invalid fields", func() {
+			window := atc.ScheduleWindow{Days: []string{"Someday"}, Start: "9am", Stop: "25:00", Location: "Nowhere/Bogus"}
+			Expect(window.Validate()).To(ConsistOf(
+				"invalid start '9am' (must be HH:MM)",
+				"invalid stop '25:00' (must be HH:MM)",
+				"unknown location 'Nowhere/Bogus'",
+				"unknown day 'Someday'",
+			))
+		})
+
+		It("returns an error if the window opens and closes at the same time", func() {
+			window := atc.ScheduleWindow{Start: "09:00", Stop: "09:00"}
+			Expect(window.Validate()).To(ConsistOf(
+				"start and stop are both '09:00' (the window would never be open)",
+			))
+		})
+	})
+})
@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
 )
@@ -18,6 +21,7 @@ type BuildStarter interface {
 		resources db.Resources,
 		resourceTypes atc.VersionedResourceTypes,
 		nextPendingBuilds []db.Build,
+		schedule maxinflight.JobSchedule,
 	) error
 }
 
@@ -60,9 +64,25 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
 	nextPendingBuildsForJob []db.Build,
+	schedule maxinflight.JobSchedule,
 ) error {
+	if job.Config().BuildLatestOnly {
+		var err error
+		nextPendingBuildsForJob, err = s.skipIntermediateBuilds(logger, nextPendingBuildsForJob)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
-		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
+		if schedule.WaitsForWindow(nextPendingBuild) {
+			// automatically triggered builds wait until the window opens, but
+			// should not hold up any manually triggered builds behind them
+			logger.Debug("outside-schedule-window", lager.Data{"build-id": nextPendingBuild.ID()})
+			continue
+		}
+
+		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes, schedule)
 		if err != nil {
 			return err
 		}
@@ -75,19 +95,59 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	return nil
 }
 
+// skipIntermediateBuilds aborts the pending builds which are superseded by a
+// newer one, as they would all be built with the same latest versions. Builds
+// which are already being started are left alone. It returns the builds which
+// are left to start.
+func (s *buildStarter) skipIntermediateBuilds(logger lager.Logger, pendingBuilds []db.Build) ([]db.Build, error) {
+	if len(pendingBuilds) < 2 {
+		return pendingBuilds, nil
+	}
+
+	latestBuild := pendingBuilds[len(pendingBuilds)-1]
+
+	remaining := []db.Build{}
+	for _, pendingBuild := range pendingBuilds[:len(pendingBuilds)-1] {
+		if pendingBuild.IsScheduled() {
+			remaining = append(remaining, pendingBuild)
+			continue
+		}
+
+		logger.Info("skipping-intermediate-build", lager.Data{
+			"build-id":        pendingBuild.ID(),
+			"latest-build-id": latestBuild.ID(),
+		})
+
+		err := pendingBuild.SaveAbortReason(fmt.Sprintf("superseded by build #%s", latestBuild.Name()), "")
+		if err != nil {
+			logger.Error("failed-to-save-abort-reason", err)
+			return nil, err
+		}
+
+		err = pendingBuild.Finish(db.BuildStatusAborted)
+		if err != nil {
+			logger.Error("failed-to-mark-build-as-aborted", err)
+			return nil, err
+		}
+	}
+
+	return append(remaining, latestBuild), nil
+}
+
 func (s *buildStarter) tryStartNextPendingBuild(
 	logger lager.Logger,
 	nextPendingBuild db.Build,
 	job db.Job,
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
+	schedule maxinflight.JobSchedule,
 ) (bool, error) {
 	logger = logger.Session("try-start-next-pending-build", lager.Data{
 		"build-id":   nextPendingBuild.ID(),
 		"build-name": nextPendingBuild.Name(),
 	})
 
-	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
+	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID(), schedule)
 	if err != nil {
 		return false, err
 	}
@@ -175,9 +235,26 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		})
 	}
 
-	plan, err := s.factory.Create(job.Config(), resourceConfigs, resourceTypes, buildInputs)
+	jobConfig := job.Config()
+	if jobConfig.Network == nil {
+		jobConfig.Network = s.pipeline.Network()
+	}
+
+	plan, err := s.factory.Create(jobConfig, resourceConfigs, resourceTypes, buildInputs)
 	if err != nil {
-		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
+		logger.Error("failed-to-create-build-plan", err)
+
+		// the build will never start, so the error is saved on it directly for
+		// the user to see why, e.g. a var in its tags which could not be
+		// resolved
+		saveErr := nextPendingBuild.SaveError(atc.BuildError{
+			Code:    atc.BuildErrorCodeInvalidConfig,
+			Message: fmt.Sprintf("failed to create build plan: %s", err),
+		}, event.Origin{})
+		if saveErr != nil {
+			logger.Error("failed-to-save-build-error", saveErr)
+		}
+
 		err := nextPendingBuild.Finish(db.BuildStatusErrored)
 		if err != nil {
 			logger.Error("failed-to-mark-build-as-errored", err)
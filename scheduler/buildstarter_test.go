@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -13,6 +14,7 @@ import (
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
+	"github.com/concourse/atc/scheduler/maxinflight"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 
@@ -29,6 +31,7 @@ var _ = Describe("BuildStarter", func() {
 		pendingBuilds    []db.Build
 		fakeScanner      *schedulerfakes.FakeScanner
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
+		schedule         maxinflight.JobSchedule
 
 		buildStarter scheduler.BuildStarter
 
@@ -42,6 +45,7 @@ var _ = Describe("BuildStarter", func() {
 		fakeEngine = new(enginefakes.FakeEngine)
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
+		schedule = maxinflight.JobSchedule{}
 
 		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
 
@@ -87,12 +91,13 @@ var _ = Describe("BuildStarter", func() {
 					db.Resources{resource},
 					versionedResourceTypes,
 					pendingBuilds,
+					schedule,
 				)
 			})
 
 			It("updates max in flight for the job", func() {
 				Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
-				_, actualJob, actualBuildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+				_, actualJob, actualBuildID, _ := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
 				Expect(actualJob.Name()).To(Equal(job.Name()))
 				Expect(actualBuildID).To(Equal(66))
 			})
@@ -304,6 +309,7 @@ var _ = Describe("BuildStarter", func() {
 						},
 					},
 					pendingBuilds,
+					schedule,
 				)
 			})
 
@@ -330,11 +336,11 @@ var _ = Describe("BuildStarter", func() {
 			itUpdatedMaxInFlightForAllBuilds := func() {
 				It("updated max in flight for the right jobs", func() {
 					Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(3))
-					_, actualJob, actualBuildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+					_, actualJob, actualBuildID, _ := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
 					Expect(actualJob).To(Equal(job))
 					Expect(actualBuildID).To(Equal(99))
 
-					_, actualJob, actualBuildID = fakeUpdater.UpdateMaxInFlightReachedArgsForCall(1)
+					_, actualJob, actualBuildID, _ = fakeUpdater.UpdateMaxInFlightReachedArgsForCall(1)
 					Expect(actualJob.Name()).To(Equal(job.Name()))
 					Expect(actualBuildID).To(Equal(999))
 				})
@@ -343,7 +349,7 @@ var _ = Describe("BuildStarter", func() {
 			itUpdatedMaxInFlightForTheFirstBuild := func() {
 				It("updated max in flight for the first jobs", func() {
 					Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
-					_, actualJob, actualBuildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+					_, actualJob, actualBuildID, _ := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
 					Expect(actualJob.Name()).To(Equal(job.Name()))
 					Expect(actualBuildID).To(Equal(99))
 				})
@@ -443,6 +449,16 @@ var _ = Describe("BuildStarter", func() {
 									Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 								})
 
+								It("saves the error on the build", func() {
+									Expect(pendingBuild1.SaveErrorCallCount()).To(Equal(1))
+									buildErr, origin := pendingBuild1.SaveErrorArgsForCall(0)
+									Expect(buildErr).To(Equal(atc.BuildError{
+										Code:    atc.BuildErrorCodeInvalidConfig,
+										Message: "failed to create build plan: bad thing",
+									}))
+									Expect(origin).To(BeZero())
+								})
+
 								Context("when marking the build as errored fails", func() {
 									BeforeEach(func() {
 										pendingBuild1.FinishReturns(disaster)
@@ -497,6 +513,20 @@ var _ = Describe("BuildStarter", func() {
 									Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 								})
 
+								Context("when the pipeline has a network configuration", func() {
+									BeforeEach(func() {
+										fakePipeline.NetworkReturns(&atc.NetworkConfig{Egress: atc.NetworkEgressNone})
+									})
+
+									It("applies it to jobs without their own", func() {
+										actualJobConfig, _, _, _ := fakeFactory.CreateArgsForCall(0)
+										Expect(actualJobConfig).To(Equal(atc.JobConfig{
+											Name:    "some-job",
+											Network: &atc.NetworkConfig{Egress: atc.NetworkEgressNone},
+										}))
+									})
+								})
+
 								Context("when creating the engine build fails", func() {
 									BeforeEach(func() {
 										fakeEngine.CreateBuildReturns(nil, disaster)
@@ -624,6 +654,111 @@ var _ = Describe("BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when the job has a schedule window", func() {
+						var window *atc.ScheduleWindow
+
+						BeforeEach(func() {
+							window = &atc.ScheduleWindow{Start: "22:00", Stop: "06:00"}
+							job.ConfigReturns(atc.JobConfig{Name: "some-job", ScheduleWindow: window})
+
+							pendingBuild1.JobNameReturns("some-job")
+							pendingBuild2.JobNameReturns("some-job")
+							pendingBuild3.JobNameReturns("some-job")
+
+							fakeFactory.CreateReturns(atc.Plan{}, disaster)
+							pendingBuild1.FinishReturns(nil)
+							pendingBuild2.FinishReturns(nil)
+						})
+
+						takeSchedule := func() {
+							var err error
+							schedule, err = maxinflight.NewJobSchedule([]db.Job{job}, time.Date(2018, time.August, 20, 12, 0, 0, 0, time.UTC))
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						Context("when the window is closed", func() {
+							BeforeEach(func() {
+								pendingBuild2.IsManuallyTriggeredReturns(true)
+								takeSchedule()
+							})
+
+							It("doesn't schedule automatically triggered builds", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+								Expect(pendingBuild3.ScheduleCallCount()).To(BeZero())
+							})
+
+							It("still schedules manually triggered builds", func() {
+								Expect(pendingBuild2.ScheduleCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when the window is open", func() {
+							BeforeEach(func() {
+								window.Start = "09:00"
+								window.Stop = "17:00"
+								takeSchedule()
+							})
+
+							It("schedules the build", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when the job only builds the latest versions", func() {
+						BeforeEach(func() {
+							job.ConfigReturns(atc.JobConfig{Name: "some-job", BuildLatestOnly: true})
+
+							pendingBuild3.NameReturns("3")
+							fakeFactory.CreateReturns(atc.Plan{}, disaster)
+						})
+
+						It("aborts the intermediate builds, recording which build superseded them", func() {
+							Expect(pendingBuild1.SaveAbortReasonCallCount()).To(Equal(1))
+							reason, abortedBy := pendingBuild1.SaveAbortReasonArgsForCall(0)
+							Expect(reason).To(Equal("superseded by build #3"))
+							Expect(abortedBy).To(BeEmpty())
+
+							Expect(pendingBuild1.FinishCallCount()).To(Equal(1))
+							Expect(pendingBuild1.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+
+							Expect(pendingBuild2.FinishCallCount()).To(Equal(1))
+							Expect(pendingBuild2.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+						})
+
+						It("only schedules the latest build", func() {
+							Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+							Expect(pendingBuild2.ScheduleCallCount()).To(BeZero())
+							Expect(pendingBuild3.ScheduleCallCount()).To(Equal(1))
+
+							Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
+							_, _, buildID, _ := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+							Expect(buildID).To(Equal(555))
+						})
+
+						Context("when an intermediate build is already being started", func() {
+							BeforeEach(func() {
+								pendingBuild1.IsScheduledReturns(true)
+							})
+
+							It("leaves it alone", func() {
+								Expect(pendingBuild1.FinishCallCount()).To(BeZero())
+								Expect(pendingBuild2.FinishCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when aborting an intermediate build fails", func() {
+							BeforeEach(func() {
+								pendingBuild1.FinishReturns(disaster)
+							})
+
+							It("returns the error without scheduling any build", func() {
+								Expect(tryStartErr).To(Equal(disaster))
+								Expect(pendingBuild3.ScheduleCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 			})
 		})
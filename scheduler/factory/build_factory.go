@@ -2,13 +2,26 @@ package factory
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
 var ErrResourceNotFound = errors.New("resource not found")
 
+// NoMatchingWorkersError is returned when a step's tags, once their vars are
+// resolved, do not match any of the team's workers.
+type NoMatchingWorkersError struct {
+	Tags atc.Tags
+}
+
+func (err NoMatchingWorkersError) Error() string {
+	return fmt.Sprintf("no workers satisfying tags: %s", strings.Join(err.Tags, ", "))
+}
+
 //go:generate counterfeiter . BuildFactory
 
 type BuildFactory interface {
@@ -16,14 +29,26 @@ type BuildFactory interface {
 }
 
 type buildFactory struct {
-	PipelineID  int
-	planFactory atc.PlanFactory
+	PipelineID    int
+	TeamName      string
+	planFactory   atc.PlanFactory
+	variables     creds.Variables
+	workerFactory db.WorkerFactory
 }
 
-func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory) BuildFactory {
+func NewBuildFactory(
+	pipelineID int,
+	teamName string,
+	planFactory atc.PlanFactory,
+	variables creds.Variables,
+	workerFactory db.WorkerFactory,
+) BuildFactory {
 	return &buildFactory{
-		PipelineID:  pipelineID,
-		planFactory: planFactory,
+		PipelineID:    pipelineID,
+		TeamName:      teamName,
+		planFactory:   planFactory,
+		variables:     variables,
+		workerFactory: workerFactory,
 	}
 }
 
@@ -38,13 +63,175 @@ func (factory *buildFactory) Create(
 		return atc.Plan{}, err
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         job.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if job.Network != nil {
+		applyNetwork(&plan, job.Network)
+	}
+
+	err = factory.interpolateTags(&plan, &tagsInterpolation{})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return plan, nil
+}
+
+// applyNetwork configures every step in the plan which runs a container with
+// the job's network configuration.
+func applyNetwork(plan *atc.Plan, network *atc.NetworkConfig) {
+	switch {
+	case plan.Get != nil:
+		plan.Get.Network = network
+	case plan.Put != nil:
+		plan.Put.Network = network
+	case plan.Task != nil:
+		plan.Task.Network = network
+	case plan.Aggregate != nil:
+		for i := range *plan.Aggregate {
+			applyNetwork(&(*plan.Aggregate)[i], network)
+		}
+	case plan.Do != nil:
+		for i := range *plan.Do {
+			applyNetwork(&(*plan.Do)[i], network)
+		}
+	case plan.Retry != nil:
+		for i := range *plan.Retry {
+			applyNetwork(&(*plan.Retry)[i], network)
+		}
+	case plan.OnAbort != nil:
+		applyNetwork(&plan.OnAbort.Step, network)
+		applyNetwork(&plan.OnAbort.Next, network)
+	case plan.OnFailure != nil:
+		applyNetwork(&plan.OnFailure.Step, network)
+		applyNetwork(&plan.OnFailure.Next, network)
+	case plan.OnSuccess != nil:
+		applyNetwork(&plan.OnSuccess.Step, network)
+		applyNetwork(&plan.OnSuccess.Next, network)
+	case plan.Ensure != nil:
+		applyNetwork(&plan.Ensure.Step, network)
+		applyNetwork(&plan.Ensure.Next, network)
+	case plan.Try != nil:
+		applyNetwork(&plan.Try.Step, network)
+	case plan.Timeout != nil:
+		applyNetwork(&plan.Timeout.Step, network)
+	}
+}
+
+type tagsInterpolation struct {
+	workers []db.Worker
+	fetched bool
+}
+
+// interpolateTags resolves any vars in the tags of every step in the plan,
+// making sure that each step can still be placed on one of the team's workers.
+func (factory *buildFactory) interpolateTags(plan *atc.Plan, interpolation *tagsInterpolation) error {
+	var err error
+
+	switch {
+	case plan.Get != nil:
+		plan.Get.Tags, err = factory.resolveTags(plan.Get.Tags, interpolation)
+	case plan.Put != nil:
+		plan.Put.Tags, err = factory.resolveTags(plan.Put.Tags, interpolation)
+	case plan.Task != nil:
+		plan.Task.Tags, err = factory.resolveTags(plan.Task.Tags, interpolation)
+	case plan.Aggregate != nil:
+		for i := range *plan.Aggregate {
+			err = factory.interpolateTags(&(*plan.Aggregate)[i], interpolation)
+			if err != nil {
+				return err
+			}
+		}
+	case plan.Do != nil:
+		for i := range *plan.Do {
+			err = factory.interpolateTags(&(*plan.Do)[i], interpolation)
+			if err != nil {
+				return err
+			}
+		}
+	case plan.Retry != nil:
+		for i := range *plan.Retry {
+			err = factory.interpolateTags(&(*plan.Retry)[i], interpolation)
+			if err != nil {
+				return err
+			}
+		}
+	case plan.OnAbort != nil:
+		err = factory.interpolateTagsOfHook(&plan.OnAbort.Step, &plan.OnAbort.Next, interpolation)
+	case plan.OnFailure != nil:
+		err = factory.interpolateTagsOfHook(&plan.OnFailure.Step, &plan.OnFailure.Next, interpolation)
+	case plan.OnSuccess != nil:
+		err = factory.interpolateTagsOfHook(&plan.OnSuccess.Step, &plan.OnSuccess.Next, interpolation)
+	case plan.Ensure != nil:
+		err = factory.interpolateTagsOfHook(&plan.Ensure.Step, &plan.Ensure.Next, interpolation)
+	case plan.Try != nil:
+		err = factory.interpolateTags(&plan.Try.Step, interpolation)
+	case plan.Timeout != nil:
+		err = factory.interpolateTags(&plan.Timeout.Step, interpolation)
+	}
+
+	return err
+}
+
+func (factory *buildFactory) interpolateTagsOfHook(step *atc.Plan, next *atc.Plan, interpolation *tagsInterpolation) error {
+	err := factory.interpolateTags(step, interpolation)
+	if err != nil {
+		return err
+	}
+
+	return factory.interpolateTags(next, interpolation)
+}
+
+func (factory *buildFactory) resolveTags(tags atc.Tags, interpolation *tagsInterpolation) (atc.Tags, error) {
+	if !strings.Contains(strings.Join(tags, ""), "((") {
+		return tags, nil
+	}
+
+	resolvedTags, err := creds.NewTags(factory.variables, tags).Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	if !interpolation.fetched {
+		interpolation.workers, err = factory.workerFactory.VisibleWorkers([]string{factory.TeamName})
+		if err != nil {
+			return nil, err
+		}
+
+		interpolation.fetched = true
+	}
+
+	for _, worker := range interpolation.workers {
+		if workerHasTags(worker, resolvedTags) {
+			return resolvedTags, nil
+		}
+	}
+
+	return nil, NoMatchingWorkersError{Tags: resolvedTags}
+}
+
+func workerHasTags(worker db.Worker, tags atc.Tags) bool {
+	workerTags := map[string]bool{}
+	for _, tag := range worker.Tags() {
+		workerTags[tag] = true
+	}
+
+	for _, tag := range tags {
+		if !workerTags[tag] {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (factory *buildFactory) constructPlanFromJob(
@@ -185,6 +372,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params: planConfig.GetParams,
 			Tags:   planConfig.Tags,
 			Source: resource.Source,
+			Path:   planConfig.Path,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -222,6 +410,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params:   planConfig.Params,
 			Version:  &version,
 			Tags:     planConfig.Tags,
+			Path:     planConfig.Path,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -335,6 +524,7 @@ func (factory *buildFactory) successIfPresent(cp constructionParams) (constructi
 		cp.plan = factory.planFactory.NewPlan(atc.OnSuccessPlan{
 			Step: cp.plan,
 			Next: nextPlan,
+			Hook: true,
 		})
 	}
 	return cp, nil
@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,7 +23,7 @@ var _ = Describe("Factory Aggregate", func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
 
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 		resources = atc.ResourceConfigs{
 			{
@@ -148,6 +150,7 @@ var _ = Describe("Factory Aggregate", func() {
 						Name: "some success hook",
 						VersionedResourceTypes: resourceTypes,
 					}),
+					Hook: true,
 				}),
 			})
 			Expect(actual).To(Equal(expected))
@@ -183,6 +186,7 @@ var _ = Describe("Factory Aggregate", func() {
 					Name: "some success hook",
 					VersionedResourceTypes: resourceTypes,
 				}),
+				Hook: true,
 			})
 			Expect(actual).To(Equal(expected))
 		})
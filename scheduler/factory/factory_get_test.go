@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
@@ -23,7 +25,7 @@ var _ = Describe("Factory Get", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 		resources = atc.ResourceConfigs{
 			{
@@ -75,6 +77,38 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a get with a path", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-get",
+						Resource: "some-resource",
+						Path:     "src/some-get",
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:     "git",
+				Name:     "some-get",
+				Resource: "some-resource",
+				Source: atc.Source{
+					"uri": "git://some-resource",
+				},
+				Version:                &version,
+				Path:                   "src/some-get",
+				VersionedResourceTypes: resourceTypes,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 
@@ -23,7 +25,7 @@ var _ = Describe("Factory Hooks", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 		resources = atc.ResourceConfigs{
 			{
@@ -250,6 +252,7 @@ var _ = Describe("Factory Hooks", func() {
 						}),
 					}),
 				}),
+				Hook: true,
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -291,6 +294,7 @@ var _ = Describe("Factory Hooks", func() {
 						VersionedResourceTypes: resourceTypes,
 					}),
 				}),
+				Hook: true,
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -358,8 +362,10 @@ var _ = Describe("Factory Hooks", func() {
 								Name: "do-task-4",
 								VersionedResourceTypes: resourceTypes,
 							}),
+							Hook: true,
 						}),
 					}),
+					Hook: true,
 				}),
 			})
 
@@ -412,11 +418,13 @@ var _ = Describe("Factory Hooks", func() {
 							Name: "agg-task-1-success",
 							VersionedResourceTypes: resourceTypes,
 						}),
+						Hook: true,
 					}),
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
 						Name: "agg-task-2",
 						VersionedResourceTypes: resourceTypes,
 					}),
+					Hook: true,
 				}),
 			})
 
@@ -641,6 +649,7 @@ var _ = Describe("Factory Hooks", func() {
 						Name: "those who successfully resisted our will",
 						VersionedResourceTypes: resourceTypes,
 					}),
+					Hook: true,
 				}),
 				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
 					Name: "those who always resist our will",
@@ -703,6 +712,7 @@ var _ = Describe("Factory Hooks", func() {
 					Version:                &version,
 					VersionedResourceTypes: resourceTypes,
 				}),
+				Hook: true,
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -747,6 +757,7 @@ var _ = Describe("Factory Hooks", func() {
 							Name: "some other success task",
 							VersionedResourceTypes: resourceTypes,
 						}),
+						Hook: true,
 					}),
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
 						Name: "those who still resist our will",
@@ -807,6 +818,7 @@ var _ = Describe("Factory Hooks", func() {
 								Name: "some other success task",
 								VersionedResourceTypes: resourceTypes,
 							}),
+							Hook: true,
 						}),
 						expectedPlanFactory.NewPlan(atc.TaskPlan{
 							Name: "those who used to resist our will",
@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
@@ -23,7 +25,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 			resources = atc.ResourceConfigs{
 				{
@@ -121,7 +123,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 			resources = atc.ResourceConfigs{
 				{
@@ -232,6 +234,7 @@ var _ = Describe("Factory Put", func() {
 							VersionedResourceTypes: resourceTypes,
 						}),
 					}),
+					Hook: true,
 				})
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
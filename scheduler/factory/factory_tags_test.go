@@ -0,0 +1,128 @@
+package factory_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Tags", func() {
+	var (
+		buildFactory      factory.BuildFactory
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeWorker        *dbfakes.FakeWorker
+
+		resources atc.ResourceConfigs
+		input     atc.JobConfig
+	)
+
+	BeforeEach(func() {
+		fakeWorker = new(dbfakes.FakeWorker)
+		fakeWorker.TagsReturns([]string{"us-east", "linux"})
+
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeWorkerFactory.VisibleWorkersReturns([]db.Worker{fakeWorker}, nil)
+
+		buildFactory = factory.NewBuildFactory(
+			42,
+			"some-team",
+			atc.NewPlanFactory(123),
+			template.StaticVariables{"deploy_zone": "us-east"},
+			fakeWorkerFactory,
+		)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		input = atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Get:  "some-resource",
+					Tags: atc.Tags{"((deploy_zone))"},
+				},
+				{
+					Task: "some-task",
+					Tags: atc.Tags{"((deploy_zone))", "linux"},
+				},
+			},
+		}
+	})
+
+	It("resolves the vars in each step's tags", func() {
+		actual, err := buildFactory.Create(input, resources, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect((*actual.Do)[0].Get.Tags).To(Equal(atc.Tags{"us-east"}))
+		Expect((*actual.Do)[1].Task.Tags).To(Equal(atc.Tags{"us-east", "linux"}))
+	})
+
+	It("looks up the workers visible to the team once", func() {
+		_, err := buildFactory.Create(input, resources, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeWorkerFactory.VisibleWorkersCallCount()).To(Equal(1))
+		Expect(fakeWorkerFactory.VisibleWorkersArgsForCall(0)).To(Equal([]string{"some-team"}))
+	})
+
+	Context("when the steps' tags do not use vars", func() {
+		BeforeEach(func() {
+			input.Plan[0].Tags = atc.Tags{"us-west"}
+			input.Plan[1].Tags = nil
+		})
+
+		It("does not validate them against the workers", func() {
+			actual, err := buildFactory.Create(input, resources, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect((*actual.Do)[0].Get.Tags).To(Equal(atc.Tags{"us-west"}))
+			Expect(fakeWorkerFactory.VisibleWorkersCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the resolved tags do not match any worker", func() {
+		BeforeEach(func() {
+			fakeWorker.TagsReturns([]string{"us-east"})
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, nil, nil)
+			Expect(err).To(Equal(factory.NoMatchingWorkersError{Tags: atc.Tags{"us-east", "linux"}}))
+		})
+	})
+
+	Context("when a var cannot be resolved", func() {
+		BeforeEach(func() {
+			input.Plan[0].Tags = atc.Tags{"((missing))"}
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, nil, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when looking up the workers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerFactory.VisibleWorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			_, err := buildFactory.Create(input, resources, nil, nil)
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
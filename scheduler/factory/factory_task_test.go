@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
@@ -24,7 +26,7 @@ var _ = Describe("Factory Task", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 			resources = atc.ResourceConfigs{
 				{
@@ -76,6 +78,48 @@ var _ = Describe("Factory Task", func() {
 			})
 		})
 
+		Context("when the job has a network configuration", func() {
+			var network *atc.NetworkConfig
+
+			BeforeEach(func() {
+				network = &atc.NetworkConfig{
+					Egress:       atc.NetworkEgressNone,
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				}
+
+				input = atc.JobConfig{
+					Network: network,
+					Plan: atc.PlanSequence{
+						{
+							Task: "some-task",
+						},
+					},
+					Failure: &atc.PlanConfig{
+						Task: "some-failure-task",
+					},
+				}
+			})
+
+			It("applies it to every task", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some-task",
+						Network:                network,
+						VersionedResourceTypes: resourceTypes,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some-failure-task",
+						Network:                network,
+						VersionedResourceTypes: resourceTypes,
+					}),
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
 		Context("when input mapping is specified", func() {
 			BeforeEach(func() {
 				input = atc.JobConfig{
@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 
 	. "github.com/onsi/ginkgo"
@@ -20,7 +22,7 @@ var _ = Describe("Factory Timeout Step", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(321)
 		expectedPlanFactory = atc.NewPlanFactory(321)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 		resourceTypes = atc.VersionedResourceTypes{
 			{
@@ -1,7 +1,9 @@
 package factory_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 
@@ -21,7 +23,7 @@ var _ = Describe("Factory Try Step", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, "some-team", actualPlanFactory, template.StaticVariables{}, new(dbfakes.FakeWorkerFactory))
 
 		resourceTypes = atc.VersionedResourceTypes{
 			{
@@ -93,6 +95,7 @@ var _ = Describe("Factory Try Step", func() {
 				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
 					Name: "second task",
 				}),
+				Hook: true,
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -48,12 +48,13 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 		}
 
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
-			Name:            input.Name,
-			UseEveryVersion: input.Version.Every,
-			PinnedVersionID: pinnedVersionID,
-			ResourceID:      db.ResourceIDs[input.Resource],
-			Passed:          jobs,
-			JobID:           db.JobIDs[jobName],
+			Name:                 input.Name,
+			UseEveryVersion:      input.Version.Every,
+			PinnedVersionID:      pinnedVersionID,
+			ResourceID:           db.ResourceIDs[input.Resource],
+			Passed:               jobs,
+			JobID:                db.JobIDs[jobName],
+			RerunOnUpstreamRerun: input.RerunOnUpstreamRerun,
 		})
 	}
 
@@ -86,6 +86,30 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input reruns on upstream reruns", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:                 "job-input-1",
+						Resource:             "r1",
+						Version:              &atc.VersionConfig{Latest: true},
+						Passed:               []string{"j1"},
+						RerunOnUpstreamRerun: true,
+					}}
+				})
+
+				It("sets RerunOnUpstreamRerun", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:                 "job-input-1",
+						UseEveryVersion:      false,
+						PinnedVersionID:      0,
+						ResourceID:           11,
+						Passed:               algorithm.JobSet{1: struct{}{}},
+						JobID:                1,
+						RerunOnUpstreamRerun: true,
+					}))
+				})
+			})
+
 			Context("when an input has version: every", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{{
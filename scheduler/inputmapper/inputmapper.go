@@ -17,6 +17,25 @@ type InputMapper interface {
 		job db.Job,
 		resources db.Resources,
 	) (algorithm.InputMapping, error)
+
+	ExplainNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		resources db.Resources,
+	) ([]InputExplanation, error)
+}
+
+// InputExplanation describes which version an input would use for the job's
+// next build, or what is keeping it from being satisfied.
+type InputExplanation struct {
+	Name     string
+	Resource string
+
+	VersionID int
+
+	BlockedBy    algorithm.Blocker
+	BlockingJobs []string
 }
 
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
@@ -36,20 +55,7 @@ func (i *inputMapper) SaveNextInputMapping(
 ) (algorithm.InputMapping, error) {
 	logger = logger.Session("save-next-input-mapping")
 
-	inputConfigs := job.Config().Inputs()
-
-	for i, inputConfig := range inputConfigs {
-		resource, found := resources.Lookup(inputConfig.Resource)
-
-		if !found {
-			logger.Debug("failed-to-find-resource")
-			continue
-		}
-
-		if len(resource.PinnedVersion()) != 0 {
-			inputConfigs[i].Version = &atc.VersionConfig{Pinned: resource.PinnedVersion()}
-		}
-	}
+	inputConfigs := i.inputConfigs(logger, job, resources)
 
 	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
 	if err != nil {
@@ -99,3 +105,97 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+// ExplainNextInputMapping resolves the inputs for the job's next build like
+// SaveNextInputMapping, without saving anything, explaining the outcome for
+// each input.
+func (i *inputMapper) ExplainNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+) ([]InputExplanation, error) {
+	logger = logger.Session("explain-next-input-mapping")
+
+	inputConfigs := i.inputConfigs(logger, job, resources)
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	jobNames := map[int]string{}
+	for name, id := range versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	algorithmExplanations := map[string]algorithm.InputExplanation{}
+	for _, explanation := range algorithmInputConfigs.Explain(versions) {
+		algorithmExplanations[explanation.Name] = explanation
+	}
+
+	explanations := []InputExplanation{}
+	for _, inputConfig := range inputConfigs {
+		explanation := InputExplanation{
+			Name:     inputConfig.Name,
+			Resource: inputConfig.Resource,
+		}
+
+		algorithmExplanation, found := algorithmExplanations[inputConfig.Name]
+		if !found {
+			// the transformer leaves out inputs whose pinned version could not
+			// be found
+			explanation.BlockedBy = algorithm.BlockerPinned
+			explanations = append(explanations, explanation)
+			continue
+		}
+
+		explanation.VersionID = algorithmExplanation.VersionID
+		explanation.BlockedBy = algorithmExplanation.BlockedBy
+
+		for _, jobID := range algorithmExplanation.BlockingJobIDs {
+			explanation.BlockingJobs = append(explanation.BlockingJobs, jobNames[jobID])
+		}
+
+		if explanation.BlockedBy == algorithm.BlockerVersions {
+			// the versions db only knows of enabled versions
+			savedVersions, _, _, err := i.pipeline.GetResourceVersions(inputConfig.Resource, db.Page{Limit: 1})
+			if err != nil {
+				logger.Error("failed-to-get-resource-versions", err)
+				return nil, err
+			}
+
+			if len(savedVersions) > 0 {
+				explanation.BlockedBy = algorithm.BlockerDisabled
+			}
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations, nil
+}
+
+func (i *inputMapper) inputConfigs(logger lager.Logger, job db.Job, resources db.Resources) []atc.JobInput {
+	inputConfigs := job.Config().Inputs()
+
+	for i, inputConfig := range inputConfigs {
+		if job.Config().BuildLatestOnly && inputConfig.Version != nil && inputConfig.Version.Every {
+			inputConfigs[i].Version = &atc.VersionConfig{Latest: true}
+		}
+
+		resource, found := resources.Lookup(inputConfig.Resource)
+
+		if !found {
+			logger.Debug("failed-to-find-resource")
+			continue
+		}
+
+		if len(resource.PinnedVersion()) != 0 {
+			inputConfigs[i].Version = &atc.VersionConfig{Pinned: resource.PinnedVersion()}
+		}
+	}
+
+	return inputConfigs
+}
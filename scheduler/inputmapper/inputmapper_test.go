@@ -432,5 +432,152 @@ var _ = Describe("Inputmapper", func() {
 				})
 			})
 		})
+
+		Context("when the job builds the latest versions only", func() {
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					BuildLatestOnly: true,
+					Plan: atc.PlanSequence{
+						{Get: "a", Resource: "a", Version: &atc.VersionConfig{Every: true}},
+						{Get: "b", Resource: "b"},
+					},
+				})
+			})
+
+			It("uses the latest version of inputs configured with every version", func() {
+				Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+				_, _, actualJobInputs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+				Expect(actualJobInputs).To(ConsistOf(
+					atc.JobInput{
+						Name:     "a",
+						Resource: "a",
+						Version:  &atc.VersionConfig{Latest: true},
+					},
+					atc.JobInput{
+						Name:     "b",
+						Resource: "b",
+					},
+				))
+			})
+		})
+	})
+
+	Describe("ExplainNextInputMapping", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			fakeJob      *dbfakes.FakeJob
+			explanations []inputmapper.InputExplanation
+			explainErr   error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12, "c": 13},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a", Resource: "a"},
+					{Get: "b", Resource: "b", Passed: []string{"upstream"}},
+					{Get: "c", Resource: "c", Version: &atc.VersionConfig{Pinned: atc.Version{"ref": "abc"}}},
+				},
+			})
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+				{Name: "b", ResourceID: 12, JobID: 1, Passed: algorithm.JobSet{2: struct{}{}}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			explanations, explainErr = inputMapper.ExplainNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				db.Resources{},
+			)
+		})
+
+		It("explains each input without saving anything", func() {
+			Expect(explainErr).NotTo(HaveOccurred())
+			Expect(explanations).To(Equal([]inputmapper.InputExplanation{
+				{Name: "a", Resource: "a"},
+				{Name: "b", Resource: "b", BlockedBy: algorithm.BlockerPassed, BlockingJobs: []string{"upstream"}},
+				{Name: "c", Resource: "c", BlockedBy: algorithm.BlockerPinned},
+			}))
+
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when a resource has no enabled versions", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{Name: "b", ResourceID: 12, JobID: 1},
+				}, nil)
+
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "b", Resource: "b"},
+					},
+				})
+			})
+
+			Context("when it has disabled versions", func() {
+				BeforeEach(func() {
+					fakePipeline.GetResourceVersionsReturns([]db.SavedVersionedResource{{ID: 2, Enabled: false}}, db.Pagination{}, true, nil)
+				})
+
+				It("is blocked by disabled", func() {
+					Expect(explanations).To(Equal([]inputmapper.InputExplanation{
+						{Name: "b", Resource: "b", BlockedBy: algorithm.BlockerDisabled},
+					}))
+
+					resourceName, _ := fakePipeline.GetResourceVersionsArgsForCall(0)
+					Expect(resourceName).To(Equal("b"))
+				})
+			})
+
+			Context("when it has no versions at all", func() {
+				BeforeEach(func() {
+					fakePipeline.GetResourceVersionsReturns(nil, db.Pagination{}, false, nil)
+				})
+
+				It("is blocked by versions", func() {
+					Expect(explanations).To(Equal([]inputmapper.InputExplanation{
+						{Name: "b", Resource: "b", BlockedBy: algorithm.BlockerVersions},
+					}))
+				})
+			})
+
+			Context("when getting its versions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.GetResourceVersionsReturns(nil, db.Pagination{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(explainErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
 	})
 })
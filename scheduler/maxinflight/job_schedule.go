@@ -0,0 +1,51 @@
+package maxinflight
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+)
+
+// JobSchedule is a snapshot of the pipeline's jobs and of which of them are
+// outside of their schedule window. It is taken once per scheduling tick,
+// rather than looking up and checking each pending build's job.
+type JobSchedule struct {
+	jobs   map[string]db.Job
+	closed map[string]bool
+}
+
+func NewJobSchedule(jobs []db.Job, now time.Time) (JobSchedule, error) {
+	schedule := JobSchedule{
+		jobs:   map[string]db.Job{},
+		closed: map[string]bool{},
+	}
+
+	for _, job := range jobs {
+		schedule.jobs[job.Name()] = job
+
+		window := job.Config().ScheduleWindow
+		if window == nil {
+			continue
+		}
+
+		inWindow, err := window.Contains(now)
+		if err != nil {
+			return JobSchedule{}, err
+		}
+
+		schedule.closed[job.Name()] = !inWindow
+	}
+
+	return schedule, nil
+}
+
+func (schedule JobSchedule) Job(name string) (db.Job, bool) {
+	job, found := schedule.jobs[name]
+	return job, found
+}
+
+// WaitsForWindow is true if the build can't start until its job's schedule
+// window opens. Manually triggered builds never wait.
+func (schedule JobSchedule) WaitsForWindow(build db.Build) bool {
+	return schedule.closed[build.JobName()] && !build.IsManuallyTriggered()
+}
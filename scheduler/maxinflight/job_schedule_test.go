@@ -0,0 +1,96 @@
+package maxinflight_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/maxinflight"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobSchedule", func() {
+	var (
+		fakeJob   *dbfakes.FakeJob
+		fakeBuild *dbfakes.FakeBuild
+		window    *atc.ScheduleWindow
+
+		schedule    maxinflight.JobSchedule
+		scheduleErr error
+	)
+
+	BeforeEach(func() {
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("some-job")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.JobNameReturns("some-job")
+
+		window = &atc.ScheduleWindow{Start: "20:00", Stop: "22:00"}
+	})
+
+	JustBeforeEach(func() {
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", ScheduleWindow: window})
+
+		schedule, scheduleErr = maxinflight.NewJobSchedule(
+			[]db.Job{fakeJob},
+			time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+		)
+	})
+
+	It("looks up the jobs by name", func() {
+		Expect(scheduleErr).NotTo(HaveOccurred())
+
+		job, found := schedule.Job("some-job")
+		Expect(found).To(BeTrue())
+		Expect(job).To(Equal(fakeJob))
+
+		_, found = schedule.Job("other-job")
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the job's window is closed", func() {
+		It("holds back automatically triggered builds", func() {
+			Expect(schedule.WaitsForWindow(fakeBuild)).To(BeTrue())
+		})
+
+		It("doesn't hold back manually triggered builds", func() {
+			fakeBuild.IsManuallyTriggeredReturns(true)
+			Expect(schedule.WaitsForWindow(fakeBuild)).To(BeFalse())
+		})
+	})
+
+	Context("when the job's window is open", func() {
+		BeforeEach(func() {
+			window.Start = "09:00"
+			window.Stop = "17:00"
+		})
+
+		It("doesn't hold back the build", func() {
+			Expect(schedule.WaitsForWindow(fakeBuild)).To(BeFalse())
+		})
+	})
+
+	Context("when the job has no window", func() {
+		BeforeEach(func() {
+			window = nil
+		})
+
+		It("doesn't hold back the build", func() {
+			Expect(schedule.WaitsForWindow(fakeBuild)).To(BeFalse())
+		})
+	})
+
+	Context("when the window is invalid", func() {
+		BeforeEach(func() {
+			window.Location = "Nowhere/Bogus"
+		})
+
+		It("returns an error", func() {
+			Expect(scheduleErr).To(HaveOccurred())
+		})
+	})
+})
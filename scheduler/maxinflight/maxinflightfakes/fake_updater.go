@@ -10,12 +10,13 @@ import (
 )
 
 type FakeUpdater struct {
-	UpdateMaxInFlightReachedStub        func(logger lager.Logger, job db.Job, buildID int) (bool, error)
+	UpdateMaxInFlightReachedStub        func(logger lager.Logger, job db.Job, buildID int, schedule maxinflight.JobSchedule) (bool, error)
 	updateMaxInFlightReachedMutex       sync.RWMutex
 	updateMaxInFlightReachedArgsForCall []struct {
-		logger  lager.Logger
-		job     db.Job
-		buildID int
+		logger   lager.Logger
+		job      db.Job
+		buildID  int
+		schedule maxinflight.JobSchedule
 	}
 	updateMaxInFlightReachedReturns struct {
 		result1 bool
@@ -29,18 +30,19 @@ type FakeUpdater struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeUpdater) UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int) (bool, error) {
+func (fake *FakeUpdater) UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int, schedule maxinflight.JobSchedule) (bool, error) {
 	fake.updateMaxInFlightReachedMutex.Lock()
 	ret, specificReturn := fake.updateMaxInFlightReachedReturnsOnCall[len(fake.updateMaxInFlightReachedArgsForCall)]
 	fake.updateMaxInFlightReachedArgsForCall = append(fake.updateMaxInFlightReachedArgsForCall, struct {
-		logger  lager.Logger
-		job     db.Job
-		buildID int
-	}{logger, job, buildID})
-	fake.recordInvocation("UpdateMaxInFlightReached", []interface{}{logger, job, buildID})
+		logger   lager.Logger
+		job      db.Job
+		buildID  int
+		schedule maxinflight.JobSchedule
+	}{logger, job, buildID, schedule})
+	fake.recordInvocation("UpdateMaxInFlightReached", []interface{}{logger, job, buildID, schedule})
 	fake.updateMaxInFlightReachedMutex.Unlock()
 	if fake.UpdateMaxInFlightReachedStub != nil {
-		return fake.UpdateMaxInFlightReachedStub(logger, job, buildID)
+		return fake.UpdateMaxInFlightReachedStub(logger, job, buildID, schedule)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -54,10 +56,10 @@ func (fake *FakeUpdater) UpdateMaxInFlightReachedCallCount() int {
 	return len(fake.updateMaxInFlightReachedArgsForCall)
 }
 
-func (fake *FakeUpdater) UpdateMaxInFlightReachedArgsForCall(i int) (lager.Logger, db.Job, int) {
+func (fake *FakeUpdater) UpdateMaxInFlightReachedArgsForCall(i int) (lager.Logger, db.Job, int, maxinflight.JobSchedule) {
 	fake.updateMaxInFlightReachedMutex.RLock()
 	defer fake.updateMaxInFlightReachedMutex.RUnlock()
-	return fake.updateMaxInFlightReachedArgsForCall[i].logger, fake.updateMaxInFlightReachedArgsForCall[i].job, fake.updateMaxInFlightReachedArgsForCall[i].buildID
+	return fake.updateMaxInFlightReachedArgsForCall[i].logger, fake.updateMaxInFlightReachedArgsForCall[i].job, fake.updateMaxInFlightReachedArgsForCall[i].buildID, fake.updateMaxInFlightReachedArgsForCall[i].schedule
 }
 
 func (fake *FakeUpdater) UpdateMaxInFlightReachedReturns(result1 bool, result2 error) {
@@ -8,21 +8,23 @@ import (
 //go:generate counterfeiter . Updater
 
 type Updater interface {
-	UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int) (bool, error)
+	UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int, schedule JobSchedule) (bool, error)
 }
 
 func NewUpdater(pipeline db.Pipeline) Updater {
-	return &updater{pipeline: pipeline}
+	return &updater{
+		pipeline: pipeline,
+	}
 }
 
 type updater struct {
 	pipeline db.Pipeline
 }
 
-func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int) (bool, error) {
+func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buildID int, schedule JobSchedule) (bool, error) {
 	logger = logger.Session("is-max-in-flight-reached", lager.Data{"job-name": job.Name()})
 
-	reached, err := u.isMaxInFlightReached(logger, job, buildID)
+	reached, err := u.isMaxInFlightReached(logger, job, buildID, schedule)
 	if err != nil {
 		return false, err
 	}
@@ -36,7 +38,77 @@ func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, job db.Job, buil
 	return reached, nil
 }
 
-func (u *updater) isMaxInFlightReached(logger lager.Logger, job db.Job, buildID int) (bool, error) {
+func (u *updater) isMaxInFlightReached(logger lager.Logger, job db.Job, buildID int, schedule JobSchedule) (bool, error) {
+	reached, err := u.isJobMaxInFlightReached(logger, job, buildID)
+	if err != nil || reached {
+		return reached, err
+	}
+
+	return u.isPipelineMaxInFlightReached(logger, buildID, schedule)
+}
+
+// isPipelineMaxInFlightReached enforces the pipeline's max in flight across
+// all of its jobs. Pending builds are started in the order they were created,
+// regardless of which job they belong to, passing over those which are held
+// back by their own job, so that one serial job can't stall the pipeline.
+func (u *updater) isPipelineMaxInFlightReached(logger lager.Logger, buildID int, schedule JobSchedule) (bool, error) {
+	maxInFlight := u.pipeline.MaxInFlight()
+
+	if maxInFlight == 0 {
+		return false, nil
+	}
+
+	builds, err := u.pipeline.GetRunningBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-running-builds-for-pipeline", err)
+		return false, err
+	}
+
+	if len(builds) >= maxInFlight {
+		return true, nil
+	}
+
+	pendingBuilds, err := u.pipeline.GetNextPendingBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-builds-for-pipeline", err)
+		return false, err
+	}
+
+	for _, pendingBuild := range pendingBuilds {
+		if pendingBuild.ID() == buildID {
+			return false, nil
+		}
+
+		held, err := u.isHeldBackByJob(logger, schedule, pendingBuild)
+		if err != nil {
+			return false, err
+		}
+
+		if !held {
+			return true, nil
+		}
+	}
+
+	logger.Info("pending-build-disappeared-from-pipeline")
+	return true, nil
+}
+
+// isHeldBackByJob is true if the pending build can't start because of its
+// job's max in flight or schedule window.
+func (u *updater) isHeldBackByJob(logger lager.Logger, schedule JobSchedule, build db.Build) (bool, error) {
+	job, found := schedule.Job(build.JobName())
+	if !found {
+		return true, nil
+	}
+
+	if schedule.WaitsForWindow(build) {
+		return true, nil
+	}
+
+	return u.isJobMaxInFlightReached(logger, job, build.ID())
+}
+
+func (u *updater) isJobMaxInFlightReached(logger lager.Logger, job db.Job, buildID int) (bool, error) {
 	maxInFlight := job.Config().MaxInFlight()
 
 	if maxInFlight == 0 {
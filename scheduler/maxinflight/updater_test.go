@@ -2,6 +2,7 @@ package maxinflight_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -17,6 +18,8 @@ var _ = Describe("Updater", func() {
 	var (
 		fakePipeline *dbfakes.FakePipeline
 		fakeJob      *dbfakes.FakeJob
+		jobs         []db.Job
+		now          time.Time
 		updater      maxinflight.Updater
 		disaster     error
 	)
@@ -24,6 +27,8 @@ var _ = Describe("Updater", func() {
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeJob = new(dbfakes.FakeJob)
+		jobs = []db.Job{fakeJob}
+		now = time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
 		updater = maxinflight.NewUpdater(fakePipeline)
 		disaster = errors.New("bad thing")
 	})
@@ -42,10 +47,14 @@ var _ = Describe("Updater", func() {
 				RawMaxInFlight: rawMaxInFlight,
 			})
 
+			schedule, err := maxinflight.NewJobSchedule(jobs, now)
+			Expect(err).NotTo(HaveOccurred())
+
 			reached, updateErr = updater.UpdateMaxInFlightReached(
 				lagertest.NewTestLogger("test"),
 				fakeJob,
 				57,
+				schedule,
 			)
 		})
 
@@ -243,5 +252,147 @@ var _ = Describe("Updater", func() {
 				itReturnsFalseIfOurBuildIsNext()
 			})
 		})
+
+		Context("when the pipeline config specifies max in flight = 2", func() {
+			BeforeEach(func() {
+				rawMaxInFlight = 0
+				serialGroups = []string{}
+				fakePipeline.MaxInFlightReturns(2)
+			})
+
+			Context("when looking up the running builds fails", func() {
+				BeforeEach(func() {
+					fakePipeline.GetRunningBuildsReturns(nil, disaster)
+				})
+
+				itReturnsTheError()
+			})
+
+			Context("when there are 2 builds of the pipeline running", func() {
+				BeforeEach(func() {
+					fakePipeline.GetRunningBuildsReturns([]db.Build{new(dbfakes.FakeBuild), new(dbfakes.FakeBuild)}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+
+				It("doesn't look up the next pending builds", func() {
+					Expect(fakePipeline.GetNextPendingBuildsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when there is 1 build of the pipeline running", func() {
+				BeforeEach(func() {
+					fakePipeline.GetRunningBuildsReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+				})
+
+				Context("when looking up the next pending builds returns an error", func() {
+					BeforeEach(func() {
+						fakePipeline.GetNextPendingBuildsReturns(nil, disaster)
+					})
+
+					itReturnsTheError()
+				})
+
+				Context("when a build of another job is ahead of us in line", func() {
+					var (
+						otherJob    *dbfakes.FakeJob
+						otherConfig atc.JobConfig
+						otherBuild  *dbfakes.FakeBuild
+					)
+
+					BeforeEach(func() {
+						otherJob = new(dbfakes.FakeJob)
+						otherJob.NameReturns("other-job")
+						otherConfig = atc.JobConfig{Name: "other-job"}
+
+						otherBuild = new(dbfakes.FakeBuild)
+						otherBuild.IDReturns(42)
+						otherBuild.JobNameReturns("other-job")
+
+						ourBuild := new(dbfakes.FakeBuild)
+						ourBuild.IDReturns(57)
+						ourBuild.JobNameReturns("some-job")
+
+						fakePipeline.GetNextPendingBuildsReturns([]db.Build{otherBuild, ourBuild}, nil)
+						otherJob.ConfigReturns(otherConfig)
+						jobs = append(jobs, otherJob)
+					})
+
+					Context("when nothing holds it back", func() {
+						itReturnsTrueAndNoError()
+
+						It("doesn't look up its job", func() {
+							Expect(fakePipeline.JobCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when its job is serial and already running a build", func() {
+						BeforeEach(func() {
+							otherConfig.Serial = true
+							otherJob.ConfigReturns(otherConfig)
+							otherJob.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+						})
+
+						itReturnsFalseAndNoError()
+					})
+
+					Context("when it is outside its job's schedule window", func() {
+						BeforeEach(func() {
+							otherConfig.ScheduleWindow = &atc.ScheduleWindow{Start: "20:00", Stop: "22:00"}
+							otherJob.ConfigReturns(otherConfig)
+						})
+
+						itReturnsFalseAndNoError()
+
+						Context("when it was triggered manually", func() {
+							BeforeEach(func() {
+								otherBuild.IsManuallyTriggeredReturns(true)
+							})
+
+							itReturnsTrueAndNoError()
+						})
+					})
+
+					Context("when its job is no longer in the pipeline", func() {
+						BeforeEach(func() {
+							jobs = []db.Job{fakeJob}
+						})
+
+						itReturnsFalseAndNoError()
+					})
+				})
+
+				Context("when the build we are trying to run is first in line", func() {
+					BeforeEach(func() {
+						fakeBuild := new(dbfakes.FakeBuild)
+						fakeBuild.IDReturns(57)
+						fakePipeline.GetNextPendingBuildsReturns([]db.Build{fakeBuild}, nil)
+					})
+
+					itReturnsFalseAndNoError()
+				})
+
+				Context("when the build we are trying to run is no longer pending", func() {
+					BeforeEach(func() {
+						fakePipeline.GetNextPendingBuildsReturns([]db.Build{}, nil)
+					})
+
+					itReturnsTrueAndNoError()
+				})
+			})
+
+			Context("when the job's own max in flight is reached", func() {
+				BeforeEach(func() {
+					rawMaxInFlight = 1
+					fakeJob.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+
+				It("doesn't check the pipeline", func() {
+					Expect(fakePipeline.GetRunningBuildsCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
 //go:generate counterfeiter . BuildScheduler
@@ -28,9 +29,12 @@ type BuildScheduler interface {
 		job db.Job,
 		resources db.Resources,
 		resourceTypes atc.VersionedResourceTypes,
+		reason string,
 	) (db.Build, Waiter, error)
 
 	SaveNextInputMapping(logger lager.Logger, job db.Job, resource db.Resources) error
+
+	ExplainNextInputMapping(logger lager.Logger, job db.Job, resources db.Resources) ([]inputmapper.InputExplanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
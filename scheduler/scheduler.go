@@ -4,11 +4,13 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/scheduler/maxinflight"
 )
 
 type Scheduler struct {
@@ -16,6 +18,7 @@ type Scheduler struct {
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
 	Scanner      Scanner
+	Clock        clock.Clock
 }
 
 //go:generate counterfeiter . Scanner
@@ -49,6 +52,12 @@ func (s *Scheduler) Schedule(
 		return jobSchedulingTime, err
 	}
 
+	schedule, err := maxinflight.NewJobSchedule(jobs, s.Clock.Now())
+	if err != nil {
+		logger.Error("failed-to-check-schedule-windows", err)
+		return jobSchedulingTime, err
+	}
+
 	for _, job := range jobs {
 		jStart := time.Now()
 		nextPendingBuildsForJob, ok := nextPendingBuilds[job.Name()]
@@ -56,7 +65,7 @@ func (s *Scheduler) Schedule(
 			continue
 		}
 
-		err := s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuildsForJob)
+		err := s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuildsForJob, schedule)
 		jobSchedulingTime[job.Name()] = jobSchedulingTime[job.Name()] + time.Since(jStart)
 
 		if err != nil {
@@ -105,10 +114,11 @@ func (s *Scheduler) TriggerImmediately(
 	job db.Job,
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
+	reason string,
 ) (db.Build, Waiter, error) {
 	logger = logger.Session("trigger-immediately", lager.Data{"job_name": job.Name()})
 
-	build, err := job.CreateBuild()
+	build, err := job.CreateBuildWithReason(reason)
 	if err != nil {
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
@@ -125,7 +135,19 @@ func (s *Scheduler) TriggerImmediately(
 			return
 		}
 
-		err = s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuilds)
+		jobs, err := s.Pipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			return
+		}
+
+		schedule, err := maxinflight.NewJobSchedule(jobs, s.Clock.Now())
+		if err != nil {
+			logger.Error("failed-to-check-schedule-windows", err)
+			return
+		}
+
+		err = s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuilds, schedule)
 		if err != nil {
 			logger.Error("failed-to-start-next-pending-build-for-job", err, lager.Data{"job-name": job.Name()})
 			return
@@ -145,3 +167,13 @@ func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job db.Job, resour
 	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job, resources)
 	return err
 }
+
+func (s *Scheduler) ExplainNextInputMapping(logger lager.Logger, job db.Job, resources db.Resources) ([]inputmapper.InputExplanation, error) {
+	versions, err := s.Pipeline.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return nil, err
+	}
+
+	return s.InputMapper.ExplainNextInputMapping(logger, versions, job, resources)
+}
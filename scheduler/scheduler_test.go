@@ -2,7 +2,9 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -38,6 +40,7 @@ var _ = Describe("Scheduler", func() {
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			Clock:        fakeclock.NewFakeClock(time.Date(2018, time.August, 20, 12, 0, 0, 0, time.UTC)),
 		}
 
 		disaster = errors.New("bad thing")
@@ -142,7 +145,7 @@ var _ = Describe("Scheduler", func() {
 
 					It("started all pending builds for the right job", func() {
 						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-						_, actualJob, actualResources, actualResourceTypes, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						_, actualJob, actualResources, actualResourceTypes, actualPendingBuilds, _ := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 						Expect(actualResources).To(Equal(db.Resources{fakeResource}))
 						Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
@@ -164,6 +167,30 @@ var _ = Describe("Scheduler", func() {
 						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
 						Expect(fakeJob2.EnsurePendingBuildExistsCallCount()).To(BeZero())
 					})
+
+					It("shares one snapshot of the jobs' schedule windows across all jobs", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+						_, _, _, _, _, schedule1 := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						_, _, _, _, _, schedule2 := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(1)
+						Expect(schedule1).To(Equal(schedule2))
+
+						job, found := schedule1.Job("some-job-2")
+						Expect(found).To(BeTrue())
+						Expect(job).To(Equal(fakeJob2))
+					})
+				})
+
+				Context("when a job's schedule window is invalid", func() {
+					BeforeEach(func() {
+						fakeJob2.ConfigReturns(atc.JobConfig{
+							ScheduleWindow: &atc.ScheduleWindow{Start: "09:00", Stop: "17:00", Location: "Nowhere/Bogus"},
+						})
+					})
+
+					It("returns an error without starting any builds", func() {
+						Expect(scheduleErr).To(HaveOccurred())
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+					})
 				})
 			})
 		})
@@ -282,6 +309,7 @@ var _ = Describe("Scheduler", func() {
 						Version:      atc.Version{"some": "version"},
 					},
 				},
+				"some-reason",
 			)
 			if waiter != nil {
 				waiter.Wait()
@@ -290,7 +318,7 @@ var _ = Describe("Scheduler", func() {
 
 		Context("when creating the build fails", func() {
 			BeforeEach(func() {
-				fakeJob.CreateBuildReturns(nil, disaster)
+				fakeJob.CreateBuildWithReasonReturns(nil, disaster)
 			})
 
 			It("returns the error", func() {
@@ -304,11 +332,15 @@ var _ = Describe("Scheduler", func() {
 			BeforeEach(func() {
 				createdBuild = new(dbfakes.FakeBuild)
 				createdBuild.IsManuallyTriggeredReturns(true)
-				fakeJob.CreateBuildReturns(createdBuild, nil)
+				fakeJob.CreateBuildWithReasonReturns(createdBuild, nil)
 			})
 
 			It("tried to create a build for the right job", func() {
-				Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+				Expect(fakeJob.CreateBuildWithReasonCallCount()).To(Equal(1))
+			})
+
+			It("creates the build with the reason it was triggered", func() {
+				Expect(fakeJob.CreateBuildWithReasonArgsForCall(0)).To(Equal("some-reason"))
 			})
 
 			Context("when get pending builds for job fails", func() {
@@ -325,6 +357,7 @@ var _ = Describe("Scheduler", func() {
 				BeforeEach(func() {
 					nextPendingBuilds = []db.Build{new(dbfakes.FakeBuild)}
 					fakeJob.GetPendingBuildsReturns(nextPendingBuilds, nil)
+					fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
 				})
 
 				It("tried to get pending builds for the right job", func() {
@@ -338,9 +371,26 @@ var _ = Describe("Scheduler", func() {
 
 					It("tries to start builds for the right job", func() {
 						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-						_, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						_, _, _, _, b, _ := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 						Expect(b).To(Equal(nextPendingBuilds))
 					})
+
+					It("takes a snapshot of the pipeline's jobs", func() {
+						_, _, _, _, _, schedule := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						job, found := schedule.Job("some-job")
+						Expect(found).To(BeTrue())
+						Expect(job).To(Equal(fakeJob))
+					})
+				})
+
+				Context("when getting the pipeline's jobs fails", func() {
+					BeforeEach(func() {
+						fakePipeline.JobsReturns(nil, disaster)
+					})
+
+					It("does not try to start pending builds for job", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(0))
+					})
 				})
 			})
 		})
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
 type FakeBuildScheduler struct {
@@ -30,13 +31,14 @@ type FakeBuildScheduler struct {
 		result1 map[string]time.Duration
 		result2 error
 	}
-	TriggerImmediatelyStub        func(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	TriggerImmediatelyStub        func(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, reason string) (db.Build, scheduler.Waiter, error)
 	triggerImmediatelyMutex       sync.RWMutex
 	triggerImmediatelyArgsForCall []struct {
 		logger        lager.Logger
 		job           db.Job
 		resources     db.Resources
 		resourceTypes atc.VersionedResourceTypes
+		reason        string
 	}
 	triggerImmediatelyReturns struct {
 		result1 db.Build
@@ -61,6 +63,21 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExplainNextInputMappingStub        func(logger lager.Logger, job db.Job, resources db.Resources) ([]inputmapper.InputExplanation, error)
+	explainNextInputMappingMutex       sync.RWMutex
+	explainNextInputMappingArgsForCall []struct {
+		logger    lager.Logger
+		job       db.Job
+		resources db.Resources
+	}
+	explainNextInputMappingReturns struct {
+		result1 []inputmapper.InputExplanation
+		result2 error
+	}
+	explainNextInputMappingReturnsOnCall map[int]struct {
+		result1 []inputmapper.InputExplanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -125,7 +142,7 @@ func (fake *FakeBuildScheduler) ScheduleReturnsOnCall(i int, result1 map[string]
 	}{result1, result2}
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, reason string) (db.Build, scheduler.Waiter, error) {
 	fake.triggerImmediatelyMutex.Lock()
 	ret, specificReturn := fake.triggerImmediatelyReturnsOnCall[len(fake.triggerImmediatelyArgsForCall)]
 	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
@@ -133,11 +150,12 @@ func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job db.J
 		job           db.Job
 		resources     db.Resources
 		resourceTypes atc.VersionedResourceTypes
-	}{logger, job, resources, resourceTypes})
-	fake.recordInvocation("TriggerImmediately", []interface{}{logger, job, resources, resourceTypes})
+		reason        string
+	}{logger, job, resources, resourceTypes, reason})
+	fake.recordInvocation("TriggerImmediately", []interface{}{logger, job, resources, resourceTypes, reason})
 	fake.triggerImmediatelyMutex.Unlock()
 	if fake.TriggerImmediatelyStub != nil {
-		return fake.TriggerImmediatelyStub(logger, job, resources, resourceTypes)
+		return fake.TriggerImmediatelyStub(logger, job, resources, resourceTypes, reason)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -151,10 +169,10 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
 	return len(fake.triggerImmediatelyArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, db.Job, db.Resources, atc.VersionedResourceTypes) {
+func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, db.Job, db.Resources, atc.VersionedResourceTypes, string) {
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
-	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].job, fake.triggerImmediatelyArgsForCall[i].resources, fake.triggerImmediatelyArgsForCall[i].resourceTypes
+	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].job, fake.triggerImmediatelyArgsForCall[i].resources, fake.triggerImmediatelyArgsForCall[i].resourceTypes, fake.triggerImmediatelyArgsForCall[i].reason
 }
 
 func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
@@ -232,6 +250,59 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) ExplainNextInputMapping(logger lager.Logger, job db.Job, resources db.Resources) ([]inputmapper.InputExplanation, error) {
+	fake.explainNextInputMappingMutex.Lock()
+	ret, specificReturn := fake.explainNextInputMappingReturnsOnCall[len(fake.explainNextInputMappingArgsForCall)]
+	fake.explainNextInputMappingArgsForCall = append(fake.explainNextInputMappingArgsForCall, struct {
+		logger    lager.Logger
+		job       db.Job
+		resources db.Resources
+	}{logger, job, resources})
+	fake.recordInvocation("ExplainNextInputMapping", []interface{}{logger, job, resources})
+	fake.explainNextInputMappingMutex.Unlock()
+	if fake.ExplainNextInputMappingStub != nil {
+		return fake.ExplainNextInputMappingStub(logger, job, resources)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.explainNextInputMappingReturns.result1, fake.explainNextInputMappingReturns.result2
+}
+
+func (fake *FakeBuildScheduler) ExplainNextInputMappingCallCount() int {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return len(fake.explainNextInputMappingArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainNextInputMappingArgsForCall(i int) (lager.Logger, db.Job, db.Resources) {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return fake.explainNextInputMappingArgsForCall[i].logger, fake.explainNextInputMappingArgsForCall[i].job, fake.explainNextInputMappingArgsForCall[i].resources
+}
+
+func (fake *FakeBuildScheduler) ExplainNextInputMappingReturns(result1 []inputmapper.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	fake.explainNextInputMappingReturns = struct {
+		result1 []inputmapper.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildScheduler) ExplainNextInputMappingReturnsOnCall(i int, result1 []inputmapper.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	if fake.explainNextInputMappingReturnsOnCall == nil {
+		fake.explainNextInputMappingReturnsOnCall = make(map[int]struct {
+			result1 []inputmapper.InputExplanation
+			result2 error
+		})
+	}
+	fake.explainNextInputMappingReturnsOnCall[i] = struct {
+		result1 []inputmapper.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -241,6 +312,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -8,10 +8,11 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/maxinflight"
 )
 
 type FakeBuildStarter struct {
-	TryStartPendingBuildsForJobStub        func(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, nextPendingBuilds []db.Build) error
+	TryStartPendingBuildsForJobStub        func(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, nextPendingBuilds []db.Build, schedule maxinflight.JobSchedule) error
 	tryStartPendingBuildsForJobMutex       sync.RWMutex
 	tryStartPendingBuildsForJobArgsForCall []struct {
 		logger            lager.Logger
@@ -19,6 +20,7 @@ type FakeBuildStarter struct {
 		resources         db.Resources
 		resourceTypes     atc.VersionedResourceTypes
 		nextPendingBuilds []db.Build
+		schedule          maxinflight.JobSchedule
 	}
 	tryStartPendingBuildsForJobReturns struct {
 		result1 error
@@ -30,7 +32,7 @@ type FakeBuildStarter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildStarter) TryStartPendingBuildsForJob(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, nextPendingBuilds []db.Build) error {
+func (fake *FakeBuildStarter) TryStartPendingBuildsForJob(logger lager.Logger, job db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes, nextPendingBuilds []db.Build, schedule maxinflight.JobSchedule) error {
 	var nextPendingBuildsCopy []db.Build
 	if nextPendingBuilds != nil {
 		nextPendingBuildsCopy = make([]db.Build, len(nextPendingBuilds))
@@ -44,11 +46,12 @@ func (fake *FakeBuildStarter) TryStartPendingBuildsForJob(logger lager.Logger, j
 		resources         db.Resources
 		resourceTypes     atc.VersionedResourceTypes
 		nextPendingBuilds []db.Build
-	}{logger, job, resources, resourceTypes, nextPendingBuildsCopy})
-	fake.recordInvocation("TryStartPendingBuildsForJob", []interface{}{logger, job, resources, resourceTypes, nextPendingBuildsCopy})
+		schedule          maxinflight.JobSchedule
+	}{logger, job, resources, resourceTypes, nextPendingBuildsCopy, schedule})
+	fake.recordInvocation("TryStartPendingBuildsForJob", []interface{}{logger, job, resources, resourceTypes, nextPendingBuildsCopy, schedule})
 	fake.tryStartPendingBuildsForJobMutex.Unlock()
 	if fake.TryStartPendingBuildsForJobStub != nil {
-		return fake.TryStartPendingBuildsForJobStub(logger, job, resources, resourceTypes, nextPendingBuilds)
+		return fake.TryStartPendingBuildsForJobStub(logger, job, resources, resourceTypes, nextPendingBuilds, schedule)
 	}
 	if specificReturn {
 		return ret.result1
@@ -62,10 +65,10 @@ func (fake *FakeBuildStarter) TryStartPendingBuildsForJobCallCount() int {
 	return len(fake.tryStartPendingBuildsForJobArgsForCall)
 }
 
-func (fake *FakeBuildStarter) TryStartPendingBuildsForJobArgsForCall(i int) (lager.Logger, db.Job, db.Resources, atc.VersionedResourceTypes, []db.Build) {
+func (fake *FakeBuildStarter) TryStartPendingBuildsForJobArgsForCall(i int) (lager.Logger, db.Job, db.Resources, atc.VersionedResourceTypes, []db.Build, maxinflight.JobSchedule) {
 	fake.tryStartPendingBuildsForJobMutex.RLock()
 	defer fake.tryStartPendingBuildsForJobMutex.RUnlock()
-	return fake.tryStartPendingBuildsForJobArgsForCall[i].logger, fake.tryStartPendingBuildsForJobArgsForCall[i].job, fake.tryStartPendingBuildsForJobArgsForCall[i].resources, fake.tryStartPendingBuildsForJobArgsForCall[i].resourceTypes, fake.tryStartPendingBuildsForJobArgsForCall[i].nextPendingBuilds
+	return fake.tryStartPendingBuildsForJobArgsForCall[i].logger, fake.tryStartPendingBuildsForJobArgsForCall[i].job, fake.tryStartPendingBuildsForJobArgsForCall[i].resources, fake.tryStartPendingBuildsForJobArgsForCall[i].resourceTypes, fake.tryStartPendingBuildsForJobArgsForCall[i].nextPendingBuilds, fake.tryStartPendingBuildsForJobArgsForCall[i].schedule
 }
 
 func (fake *FakeBuildStarter) TryStartPendingBuildsForJobReturns(result1 error) {
@@ -0,0 +1,13 @@
+package sentry_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSentry(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sentry Suite")
+}
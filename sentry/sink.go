@@ -0,0 +1,211 @@
+package sentry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	sentryVersion = 7
+	sentryClient  = "concourse-atc/1.0"
+
+	// errors logged while the queue is full are dropped rather than blocking
+	// the logger
+	queueSize = 100
+)
+
+// Event is the subset of the Sentry event payload which is reported.
+type Event struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger"`
+	Platform    string                 `json:"platform"`
+	Message     string                 `json:"message"`
+	Environment string                 `json:"environment,omitempty"`
+	Fingerprint []string               `json:"fingerprint"`
+	Tags        map[string]string      `json:"tags"`
+	Extra       map[string]interface{} `json:"extra"`
+}
+
+type sink struct {
+	storeURL    string
+	auth        string
+	environment string
+
+	client *http.Client
+	events chan Event
+}
+
+// NewSink returns a lager sink which reports errors to the Sentry project
+// identified by the DSN.
+//
+// Events are grouped by the lager session which logged them and the class of
+// the error, rather than by message, as the same failure is often logged with
+// errors that differ only in their details.
+func NewSink(dsn string, environment string) (lager.Sink, error) {
+	storeURL, auth, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &sink{
+		storeURL:    storeURL,
+		auth:        auth,
+		environment: environment,
+
+		client: &http.Client{Timeout: 10 * time.Second},
+		events: make(chan Event, queueSize),
+	}
+
+	go s.sendLoop()
+
+	return s, nil
+}
+
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", err
+	}
+
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("sentry dsn has no public key: %s", dsn)
+	}
+
+	projectID := path.Base(u.Path)
+	if projectID == "" || projectID == "/" || projectID == "." {
+		return "", "", fmt.Errorf("sentry dsn has no project id: %s", dsn)
+	}
+
+	auth := fmt.Sprintf(
+		"Sentry sentry_version=%d, sentry_client=%s, sentry_key=%s",
+		sentryVersion,
+		sentryClient,
+		u.User.Username(),
+	)
+
+	if secret, ok := u.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+
+	storeURL := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   path.Join(path.Dir(u.Path), "api", projectID, "store") + "/",
+	}
+
+	return storeURL.String(), auth, nil
+}
+
+func (s *sink) Log(log lager.LogFormat) {
+	if log.LogLevel < lager.ERROR {
+		return
+	}
+
+	event, err := s.event(log)
+	if err != nil {
+		return
+	}
+
+	select {
+	case s.events <- event:
+	default:
+	}
+}
+
+func (s *sink) event(log lager.LogFormat) (Event, error) {
+	eventID := make([]byte, 16)
+	_, err := rand.Read(eventID)
+	if err != nil {
+		return Event{}, err
+	}
+
+	level := "error"
+	if log.LogLevel == lager.FATAL {
+		level = "fatal"
+	}
+
+	message := log.Message
+	if errMsg, ok := log.Data["error"].(string); ok {
+		message += ": " + errMsg
+	}
+
+	errorClass := "unknown"
+	if log.Error != nil {
+		errorClass = fmt.Sprintf("%T", log.Error)
+	}
+
+	tags := map[string]string{
+		"source":      log.Source,
+		"error_class": errorClass,
+	}
+
+	if session, ok := log.Data["session"].(string); ok {
+		tags["session"] = session
+	}
+
+	return Event{
+		EventID:     hex.EncodeToString(eventID),
+		Timestamp:   time.Now().UTC().Format("2006-01-02T15:04:05"),
+		Level:       level,
+		Logger:      log.Source,
+		Platform:    "go",
+		Message:     message,
+		Environment: s.environment,
+		Fingerprint: []string{sessionName(log.Message), errorClass},
+		Tags:        tags,
+		Extra:       log.Data,
+	}, nil
+}
+
+// sessionName strips the action from a lager message, e.g.
+// 'atc.scanner.failed-to-check' is logged by the 'atc.scanner' session.
+func sessionName(message string) string {
+	i := strings.LastIndex(message, ".")
+	if i == -1 {
+		return message
+	}
+
+	return message[:i]
+}
+
+func (s *sink) sendLoop() {
+	for event := range s.events {
+		s.send(event)
+	}
+}
+
+// send makes a single attempt at reporting the event; failures cannot be
+// logged, as they would be reported in turn.
+func (s *sink) send(event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST", s.storeURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	resp.Body.Close()
+}
@@ -0,0 +1,113 @@
+package sentry_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/sentry"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type someError struct{}
+
+func (someError) Error() string { return "some error" }
+
+var _ = Describe("Sink", func() {
+	var (
+		server   *httptest.Server
+		requests chan *http.Request
+		events   chan sentry.Event
+
+		sink lager.Sink
+	)
+
+	BeforeEach(func() {
+		requests = make(chan *http.Request, 10)
+		events = make(chan sentry.Event, 10)
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event sentry.Event
+			err := json.NewDecoder(r.Body).Decode(&event)
+			Expect(err).NotTo(HaveOccurred())
+
+			requests <- r
+			events <- event
+		}))
+
+		dsn := strings.Replace(server.URL, "http://", "http://some-key:some-secret@", 1) + "/42"
+
+		var err error
+		sink, err = sentry.NewSink(dsn, "some-environment")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reports errors to the project's store endpoint", func() {
+		sink.Log(lager.LogFormat{
+			Source:   "atc",
+			Message:  "atc.scanner.failed-to-check",
+			LogLevel: lager.ERROR,
+			Data:     lager.Data{"session": "12.3", "error": "some error"},
+			Error:    someError{},
+		})
+
+		var request *http.Request
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.URL.Path).To(Equal("/api/42/store/"))
+		Expect(request.Header.Get("X-Sentry-Auth")).To(ContainSubstring("sentry_key=some-key"))
+		Expect(request.Header.Get("X-Sentry-Auth")).To(ContainSubstring("sentry_secret=some-secret"))
+
+		var event sentry.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Level).To(Equal("error"))
+		Expect(event.Message).To(Equal("atc.scanner.failed-to-check: some error"))
+		Expect(event.Environment).To(Equal("some-environment"))
+		Expect(event.Fingerprint).To(Equal([]string{"atc.scanner", "sentry_test.someError"}))
+		Expect(event.Tags).To(HaveKeyWithValue("session", "12.3"))
+		Expect(event.EventID).To(HaveLen(32))
+	})
+
+	It("fingerprints errors without a class as unknown", func() {
+		sink.Log(lager.LogFormat{
+			Message:  "atc.failed",
+			LogLevel: lager.FATAL,
+			Data:     lager.Data{},
+		})
+
+		var event sentry.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Level).To(Equal("fatal"))
+		Expect(event.Fingerprint).To(Equal([]string{"atc", "unknown"}))
+	})
+
+	It("does not report anything below the error level", func() {
+		sink.Log(lager.LogFormat{
+			Message:  "atc.scanner.checking",
+			LogLevel: lager.INFO,
+			Error:    errors.New("not an error"),
+		})
+
+		Consistently(requests).ShouldNot(Receive())
+	})
+
+	Describe("NewSink", func() {
+		It("rejects DSNs without a public key", func() {
+			_, err := sentry.NewSink("https://sentry.example.com/42", "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects DSNs without a project", func() {
+			_, err := sentry.NewSink("https://some-key@sentry.example.com", "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -389,4 +389,8 @@ type MetadataField struct {
 
 type CacheConfig struct {
 	Path string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path"`
+
+	// Snapshot opts the cache in to being warmed up from a snapshot in the
+	// object store when it misses on a worker, if one is configured.
+	Snapshot bool `json:"snapshot,omitempty" yaml:"snapshot,omitempty" mapstructure:"snapshot"`
 }
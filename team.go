@@ -4,4 +4,57 @@ type Team struct {
 	ID   int                 `json:"id,omitempty"`
 	Name string              `json:"name,omitempty"`
 	Auth map[string][]string `json:"auth,omitempty"`
+
+	// AllowPrivileged is nil when not given, in which case set-team leaves
+	// the team's setting as it is.
+	AllowPrivileged *bool `json:"allow_privileged,omitempty"`
+}
+
+// TeamExport is everything needed to recreate a team on another installation:
+// its auth settings and its pipelines, in order.
+type TeamExport struct {
+	Team      Team             `json:"team"`
+	Pipelines []PipelineExport `json:"pipelines"`
+}
+
+type PipelineExport struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+	Public bool   `json:"public"`
+	Config Config `json:"config"`
+}
+
+// TeamSettings are the settings which a team may change for itself.
+type TeamSettings struct {
+	ContainerRetention ContainerRetention `json:"container_retention"`
+}
+
+// ContainerRetention overrides how long the containers of a team's finished
+// builds are kept around for hijacking, as durations such as "1h". Empty
+// durations fall back to the defaults.
+type ContainerRetention struct {
+	Success string `json:"success,omitempty"`
+	Failure string `json:"failure,omitempty"`
+}
+
+// TeamUsage is how much of the workers' time a team has used each day, in
+// UTC, along with the operator's daily quota. Quotas are not enforced;
+// exceeding them only adds warnings.
+type TeamUsage struct {
+	Days     []TeamDailyUsage `json:"days"`
+	Quota    TeamUsageQuota   `json:"quota"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+type TeamDailyUsage struct {
+	Date           string  `json:"date"`
+	BuildMinutes   float64 `json:"build_minutes"`
+	ContainerHours float64 `json:"container_hours"`
+}
+
+// TeamUsageQuota is the daily usage allowed for each team. Zero means no
+// quota.
+type TeamUsageQuota struct {
+	BuildMinutes   float64 `json:"build_minutes,omitempty"`
+	ContainerHours float64 `json:"container_hours,omitempty"`
 }
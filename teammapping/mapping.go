@@ -0,0 +1,47 @@
+package teammapping
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitHubTeamMapping grants the members of a GitHub organization, or of one of
+// its teams, membership of a Concourse team when they log in with GitHub.
+//
+// As a flag it is given as ORG=TEAM or ORG:GITHUB-TEAM=TEAM.
+type GitHubTeamMapping struct {
+	Org        string
+	GitHubTeam string
+	Team       string
+}
+
+func (mapping *GitHubTeamMapping) UnmarshalFlag(value string) error {
+	segs := strings.SplitN(value, "=", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return fmt.Errorf("malformed github team mapping '%s' (must be ORG=TEAM or ORG:GITHUB-TEAM=TEAM)", value)
+	}
+
+	orgAndTeam := strings.SplitN(segs[0], ":", 2)
+	if orgAndTeam[0] == "" || (len(orgAndTeam) == 2 && orgAndTeam[1] == "") {
+		return fmt.Errorf("malformed github team mapping '%s' (must be ORG=TEAM or ORG:GITHUB-TEAM=TEAM)", value)
+	}
+
+	mapping.Org = orgAndTeam[0]
+	if len(orgAndTeam) == 2 {
+		mapping.GitHubTeam = orgAndTeam[1]
+	}
+
+	mapping.Team = segs[1]
+
+	return nil
+}
+
+// Group returns the group which GitHub users are placed in on login for the
+// mapped organization or team.
+func (mapping GitHubTeamMapping) Group() string {
+	if mapping.GitHubTeam == "" {
+		return "github:" + mapping.Org
+	}
+
+	return "github:" + mapping.Org + ":" + mapping.GitHubTeam
+}
@@ -0,0 +1,41 @@
+package teammapping_test
+
+import (
+	. "github.com/concourse/atc/teammapping"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitHubTeamMapping", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			mapping GitHubTeamMapping
+			err     error
+		)
+
+		BeforeEach(func() {
+			mapping = GitHubTeamMapping{}
+		})
+
+		It("parses an organization", func() {
+			err = mapping.UnmarshalFlag("some-org=some-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mapping).To(Equal(GitHubTeamMapping{Org: "some-org", Team: "some-team"}))
+			Expect(mapping.Group()).To(Equal("github:some-org"))
+		})
+
+		It("parses a team of an organization", func() {
+			err = mapping.UnmarshalFlag("some-org:some-github-team=some-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mapping).To(Equal(GitHubTeamMapping{Org: "some-org", GitHubTeam: "some-github-team", Team: "some-team"}))
+			Expect(mapping.Group()).To(Equal("github:some-org:some-github-team"))
+		})
+
+		It("rejects malformed mappings", func() {
+			for _, value := range []string{"", "some-org", "=some-team", "some-org=", ":some-github-team=some-team", "some-org:=some-team"} {
+				Expect(mapping.UnmarshalFlag(value)).To(HaveOccurred(), value)
+			}
+		})
+	})
+})
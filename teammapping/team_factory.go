@@ -0,0 +1,96 @@
+package teammapping
+
+import (
+	"github.com/concourse/atc/db"
+)
+
+type teamFactory struct {
+	db.TeamFactory
+
+	groups map[string][]string
+}
+
+// NewTeamFactory returns a team factory whose teams' auth also allows the
+// groups mapped to them, for use when logging in. The mapped groups are never
+// saved to the teams, so setting a team's auth does not remove them.
+func NewTeamFactory(factory db.TeamFactory, mappings []GitHubTeamMapping) db.TeamFactory {
+	groups := map[string][]string{}
+	for _, mapping := range mappings {
+		groups[mapping.Team] = append(groups[mapping.Team], mapping.Group())
+	}
+
+	return &teamFactory{
+		TeamFactory: factory,
+
+		groups: groups,
+	}
+}
+
+func (factory *teamFactory) FindTeam(teamName string) (db.Team, bool, error) {
+	team, found, err := factory.TeamFactory.FindTeam(teamName)
+	if err != nil || !found {
+		return team, found, err
+	}
+
+	return factory.mapped(team), true, nil
+}
+
+func (factory *teamFactory) GetTeams() ([]db.Team, error) {
+	teams, err := factory.TeamFactory.GetTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	mappedTeams := []db.Team{}
+	for _, team := range teams {
+		mappedTeams = append(mappedTeams, factory.mapped(team))
+	}
+
+	return mappedTeams, nil
+}
+
+func (factory *teamFactory) mapped(team db.Team) db.Team {
+	groups, found := factory.groups[team.Name()]
+	if !found {
+		return team
+	}
+
+	return &mappedTeam{
+		Team:   team,
+		groups: groups,
+	}
+}
+
+type mappedTeam struct {
+	db.Team
+
+	groups []string
+}
+
+func (team *mappedTeam) Auth() map[string][]string {
+	auth := map[string][]string{}
+	for key, values := range team.Team.Auth() {
+		auth[key] = values
+	}
+
+	groups := append([]string{}, auth["groups"]...)
+	for _, group := range team.groups {
+		if !contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+
+	auth["groups"] = groups
+
+	return auth
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,158 @@
+package teammapping_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/teammapping"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamFactory", func() {
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeOtherTeam   *dbfakes.FakeTeam
+
+		teamFactory db.TeamFactory
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeam.AuthReturns(map[string][]string{
+			"users":  []string{"local:some-user"},
+			"groups": []string{"github:some-org:some-github-team"},
+		})
+
+		fakeOtherTeam = new(dbfakes.FakeTeam)
+		fakeOtherTeam.NameReturns("some-other-team")
+		fakeOtherTeam.AuthReturns(map[string][]string{
+			"users": []string{"local:some-other-user"},
+		})
+
+		teamFactory = NewTeamFactory(fakeTeamFactory, []GitHubTeamMapping{
+			{Org: "some-org", GitHubTeam: "some-github-team", Team: "some-team"},
+			{Org: "some-org", GitHubTeam: "some-other-github-team", Team: "some-team"},
+			{Org: "some-other-org", Team: "some-team"},
+		})
+	})
+
+	Describe("FindTeam", func() {
+		var (
+			team  db.Team
+			found bool
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			team, found, err = teamFactory.FindTeam("some-team")
+		})
+
+		Context("when the team has mappings", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("adds the mapped groups to the team's auth", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(team.Name()).To(Equal("some-team"))
+				Expect(team.Auth()).To(Equal(map[string][]string{
+					"users": []string{"local:some-user"},
+					"groups": []string{
+						"github:some-org:some-github-team",
+						"github:some-org:some-other-github-team",
+						"github:some-other-org",
+					},
+				}))
+			})
+
+			It("does not change the team's own auth", func() {
+				team.Auth()
+				Expect(fakeTeam.Auth()).To(Equal(map[string][]string{
+					"users":  []string{"local:some-user"},
+					"groups": []string{"github:some-org:some-github-team"},
+				}))
+			})
+		})
+
+		Context("when the team has no mappings", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(fakeOtherTeam, true, nil)
+			})
+
+			It("returns the team as it is", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(team).To(Equal(fakeOtherTeam))
+			})
+		})
+
+		Context("when the team is not found", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when finding the team fails", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("GetTeams", func() {
+		var (
+			teams []db.Team
+			err   error
+		)
+
+		BeforeEach(func() {
+			fakeTeamFactory.GetTeamsReturns([]db.Team{fakeTeam, fakeOtherTeam}, nil)
+		})
+
+		JustBeforeEach(func() {
+			teams, err = teamFactory.GetTeams()
+		})
+
+		It("adds the mapped groups to the auth of the teams with mappings", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teams).To(HaveLen(2))
+			Expect(teams[0].Auth()["groups"]).To(ConsistOf(
+				"github:some-org:some-github-team",
+				"github:some-org:some-other-github-team",
+				"github:some-other-org",
+			))
+			Expect(teams[1]).To(Equal(fakeOtherTeam))
+		})
+
+		Context("when getting the teams fails", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeTeamFactory.GetTeamsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package teammapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTeamMapping(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Team Mapping Suite")
+}
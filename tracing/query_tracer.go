@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+// TraceQueries wraps the connection such that every query, including those
+// run in transactions, is recorded as a span. The db package does not take
+// contexts, so these spans are not children of the build or check that ran
+// the query; they are tagged with the statement instead.
+func TraceQueries(conn db.Conn) db.Conn {
+	return &tracingConn{
+		Conn: conn,
+	}
+}
+
+type tracingConn struct {
+	db.Conn
+}
+
+func (c *tracingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	span := startQuerySpan(query)
+
+	rows, err := c.Conn.Query(query, args...)
+	End(span, err)
+
+	return rows, err
+}
+
+func (c *tracingConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	return &tracingRow{
+		span:       startQuerySpan(query),
+		RowScanner: c.Conn.QueryRow(query, args...),
+	}
+}
+
+func (c *tracingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	span := startQuerySpan(query)
+
+	result, err := c.Conn.Exec(query, args...)
+	End(span, err)
+
+	return result, err
+}
+
+func (c *tracingConn) Begin() (db.Tx, error) {
+	tx, err := c.Conn.Begin()
+	if err != nil {
+		return tx, err
+	}
+
+	return &tracingTx{Tx: tx}, nil
+}
+
+type tracingTx struct {
+	db.Tx
+}
+
+func (t *tracingTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	span := startQuerySpan(query)
+
+	rows, err := t.Tx.Query(query, args...)
+	End(span, err)
+
+	return rows, err
+}
+
+func (t *tracingTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	return &tracingRow{
+		span:       startQuerySpan(query),
+		RowScanner: t.Tx.QueryRow(query, args...),
+	}
+}
+
+func (t *tracingTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	span := startQuerySpan(query)
+
+	result, err := t.Tx.Exec(query, args...)
+	End(span, err)
+
+	return result, err
+}
+
+// tracingRow ends the query's span once the row is scanned, as that is when
+// the query is actually run. Finding no row is not an error.
+type tracingRow struct {
+	squirrel.RowScanner
+
+	span opentracing.Span
+}
+
+func (r *tracingRow) Scan(dest ...interface{}) error {
+	err := r.RowScanner.Scan(dest...)
+	if err == sql.ErrNoRows {
+		End(r.span, nil)
+	} else {
+		End(r.span, err)
+	}
+
+	return err
+}
+
+func startQuerySpan(query string) opentracing.Span {
+	span, _ := StartSpan(context.Background(), "db-query", Attrs{
+		"db.type":      "sql",
+		"db.statement": strings.Join(strings.Fields(query), " "),
+	})
+
+	return span
+}
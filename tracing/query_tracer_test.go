@@ -0,0 +1,116 @@
+package tracing_test
+
+import (
+	"database/sql"
+	"errors"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeRow struct {
+	err error
+}
+
+func (row fakeRow) Scan(...interface{}) error {
+	return row.err
+}
+
+var _ = Describe("Tracing Database Queries", func() {
+	var (
+		tracer         *mocktracer.MockTracer
+		underlyingConn *dbfakes.FakeConn
+		tracingConn    db.Conn
+	)
+
+	BeforeEach(func() {
+		tracer = mocktracer.New()
+		opentracing.SetGlobalTracer(tracer)
+
+		underlyingConn = new(dbfakes.FakeConn)
+		tracingConn = tracing.TraceQueries(underlyingConn)
+	})
+
+	AfterEach(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	It("passes through calls to the underlying connection", func() {
+		underlyingConn.PingReturns(errors.New("disaster"))
+
+		err := tracingConn.Ping()
+		Expect(err).To(MatchError("disaster"))
+		Expect(underlyingConn.PingCallCount()).To(Equal(1))
+	})
+
+	It("records a span for each query, tagged with the statement", func() {
+		_, err := tracingConn.Query("SELECT\n\t$1::int", 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		query, args := underlyingConn.QueryArgsForCall(0)
+		Expect(query).To(Equal("SELECT\n\t$1::int"))
+		Expect(args).To(Equal([]interface{}{1}))
+
+		spans := tracer.FinishedSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].OperationName).To(Equal("db-query"))
+		Expect(spans[0].Tag("db.statement")).To(Equal("SELECT $1::int"))
+	})
+
+	It("marks the spans of failed queries as failed", func() {
+		underlyingConn.ExecReturns(nil, errors.New("disaster"))
+
+		_, err := tracingConn.Exec("DELETE FROM builds")
+		Expect(err).To(MatchError("disaster"))
+
+		spans := tracer.FinishedSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Tag("error")).To(Equal(true))
+	})
+
+	Describe("QueryRow", func() {
+		It("ends the span once the row is scanned", func() {
+			underlyingConn.QueryRowReturns(fakeRow{})
+
+			row := tracingConn.QueryRow("SELECT 1")
+			Expect(tracer.FinishedSpans()).To(BeEmpty())
+
+			Expect(row.Scan()).To(Succeed())
+			Expect(tracer.FinishedSpans()).To(HaveLen(1))
+		})
+
+		It("does not mark finding no rows as a failure", func() {
+			underlyingConn.QueryRowReturns(fakeRow{err: sql.ErrNoRows})
+
+			err := tracingConn.QueryRow("SELECT 1").Scan()
+			Expect(err).To(Equal(sql.ErrNoRows))
+
+			spans := tracer.FinishedSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Tag("error")).To(BeNil())
+		})
+	})
+
+	It("records spans for queries run in transactions", func() {
+		underlyingTx := new(dbfakes.FakeTx)
+		underlyingConn.BeginReturns(underlyingTx, nil)
+
+		tx, err := tracingConn.Begin()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tx.Exec("UPDATE builds SET status = 'aborted'")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(underlyingTx.ExecCallCount()).To(Equal(1))
+
+		spans := tracer.FinishedSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Tag("db.statement")).To(Equal("UPDATE builds SET status = 'aborted'"))
+	})
+})
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	jaeger "github.com/uber/jaeger-client-go"
+	jaegercfg "github.com/uber/jaeger-client-go/config"
+)
+
+// Attrs are set as tags of a span.
+type Attrs map[string]string
+
+// ConfigureJaeger reports every span to the given Jaeger collector endpoint,
+// e.g. http://jaeger:14268/api/traces, in the background. Until it is called
+// spans are not recorded at all.
+//
+// Spans are buffered before being sent, so the returned closer must be closed
+// on shutdown to send the rest.
+func ConfigureJaeger(serviceName string, endpoint string) (io.Closer, error) {
+	if endpoint == "" {
+		return noopCloser{}, nil
+	}
+
+	tracer, closer, err := jaegercfg.Configuration{
+		ServiceName: serviceName,
+		Sampler: &jaegercfg.SamplerConfig{
+			Type:  jaeger.SamplerTypeConst,
+			Param: 1,
+		},
+		Reporter: &jaegercfg.ReporterConfig{
+			CollectorEndpoint: endpoint,
+		},
+	}.NewTracer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure jaeger: %s", err)
+	}
+
+	opentracing.SetGlobalTracer(tracer)
+
+	return closer, nil
+}
+
+type noopCloser struct{}
+
+func (noopCloser) Close() error { return nil }
+
+// StartSpan starts a span as a child of any span in the context, returning a
+// context carrying the new span for its own children.
+func StartSpan(ctx context.Context, operationName string, attrs Attrs) (opentracing.Span, context.Context) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, operationName)
+
+	for key, value := range attrs {
+		span.SetTag(key, value)
+	}
+
+	return span, ctx
+}
+
+// End finishes the span, marking it as failed if there was an error.
+func End(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+
+	span.Finish()
+}
@@ -0,0 +1,100 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Tracing", func() {
+	var tracer *mocktracer.MockTracer
+
+	BeforeEach(func() {
+		tracer = mocktracer.New()
+		opentracing.SetGlobalTracer(tracer)
+	})
+
+	AfterEach(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	Describe("ConfigureJaeger", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			server.RouteToHandler("POST", "/api/traces", ghttp.RespondWith(http.StatusAccepted, nil))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("sends buffered spans when closed", func() {
+			closer, err := tracing.ConfigureJaeger("some-service", server.URL()+"/api/traces")
+			Expect(err).NotTo(HaveOccurred())
+
+			span, _ := tracing.StartSpan(context.Background(), "some-operation", nil)
+			tracing.End(span, nil)
+
+			Expect(closer.Close()).To(Succeed())
+			Expect(server.ReceivedRequests()).NotTo(BeEmpty())
+		})
+
+		Context("without an endpoint", func() {
+			It("returns a closer which does nothing", func() {
+				closer, err := tracing.ConfigureJaeger("some-service", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(closer.Close()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("StartSpan", func() {
+		It("tags the span with the attrs", func() {
+			span, _ := tracing.StartSpan(context.Background(), "some-operation", tracing.Attrs{
+				"some-attr": "some-value",
+			})
+			tracing.End(span, nil)
+
+			spans := tracer.FinishedSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].OperationName).To(Equal("some-operation"))
+			Expect(spans[0].Tags()).To(Equal(map[string]interface{}{
+				"some-attr": "some-value",
+			}))
+		})
+
+		It("starts spans as children of the span in the context", func() {
+			parent, ctx := tracing.StartSpan(context.Background(), "parent", nil)
+			child, _ := tracing.StartSpan(ctx, "child", nil)
+			tracing.End(child, nil)
+			tracing.End(parent, nil)
+
+			spans := tracer.FinishedSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].ParentID).To(Equal(spans[1].SpanContext.SpanID))
+		})
+	})
+
+	Describe("End", func() {
+		It("marks spans which ended in an error as failed", func() {
+			span, _ := tracing.StartSpan(context.Background(), "some-operation", nil)
+			tracing.End(span, errors.New("disaster"))
+
+			spans := tracer.FinishedSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Tag("error")).To(Equal(true))
+			Expect(spans[0].Logs()).To(HaveLen(1))
+		})
+	})
+})
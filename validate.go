@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -43,6 +44,17 @@ func (c Config) Validate() ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
+	if c.Network != nil {
+		networkErr := compositeErr(c.Network.Validate())
+		if networkErr != nil {
+			errorMessages = append(errorMessages, formatErr("network", networkErr))
+		}
+	}
+
+	if c.MaxInFlight < 0 {
+		errorMessages = append(errorMessages, formatErr("max_in_flight", fmt.Errorf("negative max_in_flight: %d", c.MaxInFlight)))
+	}
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -120,6 +132,10 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.VersionHistoryLimit < 0 {
+			errorMessages = append(errorMessages, identifier+" has a negative version_history_limit")
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -224,6 +240,33 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.Network != nil {
+			for _, message := range job.Network.Validate() {
+				errorMessages = append(errorMessages, identifier+".network has "+message)
+			}
+		}
+
+		for name := range job.ContainerProperties {
+			if name == "" {
+				errorMessages = append(errorMessages, identifier+".container_properties has a property with no name")
+			}
+		}
+
+		if job.ScheduleWindow != nil {
+			for _, message := range job.ScheduleWindow.Validate() {
+				errorMessages = append(errorMessages, identifier+".schedule_window has "+message)
+			}
+		}
+
+		switch job.NotifyOn {
+		case "", NotifyOnAll, NotifyOnTransition:
+		default:
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has unknown notify_on '%s' (must be '%s' or '%s')", job.NotifyOn, NotifyOnAll, NotifyOnTransition),
+			)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -363,6 +406,8 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			plan, identifier)...,
 		)
 
+		errorMessages = append(errorMessages, validateArtifactPath(plan.Path, identifier)...)
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -388,6 +433,16 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
+		if plan.RerunOnUpstreamRerun && len(plan.Passed) == 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s has rerun_on_upstream_rerun set without any passed constraints",
+					identifier,
+				),
+			)
+		}
+
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
@@ -434,10 +489,12 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "rerun_on_upstream_rerun", "privileged", "config", "file"},
 			plan, identifier)...,
 		)
 
+		errorMessages = append(errorMessages, validateArtifactPath(plan.Path, identifier)...)
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -491,7 +548,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "path"},
 			plan, identifier)...,
 		)
 
@@ -564,6 +621,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "rerun_on_upstream_rerun":
+			if plan.RerunOnUpstreamRerun {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "privileged":
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -576,6 +637,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "path":
+			if plan.Path != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -593,6 +658,25 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 	return errorMessages
 }
 
+func validateArtifactPath(artifactPath string, identifier string) []string {
+	if artifactPath == "" {
+		return nil
+	}
+
+	cleanPath := path.Clean(artifactPath)
+
+	switch {
+	case path.IsAbs(cleanPath):
+		return []string{fmt.Sprintf("%s.path must be relative ('%s')", identifier, artifactPath)}
+	case cleanPath == ".":
+		return []string{fmt.Sprintf("%s.path must not be the task's working directory ('%s')", identifier, artifactPath)}
+	case cleanPath == ".." || strings.HasPrefix(cleanPath, "../"):
+		return []string{fmt.Sprintf("%s.path must not be outside of the task's working directory ('%s')", identifier, artifactPath)}
+	}
+
+	return nil
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -93,6 +93,32 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid max_in_flight", func() {
+		BeforeEach(func() {
+			config.MaxInFlight = -1
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("invalid max_in_flight:"))
+			Expect(errorMessages[0]).To(ContainSubstring("negative max_in_flight: -1"))
+		})
+	})
+
+	Describe("invalid network", func() {
+		Context("when the pipeline's egress is unknown", func() {
+			BeforeEach(func() {
+				config.Network = &NetworkConfig{Egress: "some-egress"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid network:"))
+				Expect(errorMessages[0]).To(ContainSubstring("unknown egress 'some-egress'"))
+			})
+		})
+	})
+
 	Describe("invalid groups", func() {
 		Context("when the groups reference a bogus resource", func() {
 			BeforeEach(func() {
@@ -174,6 +200,18 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has a negative version history limit", func() {
+			BeforeEach(func() {
+				config.Resources[0].VersionHistoryLimit = -1
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a negative version_history_limit"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, ResourceConfig{
@@ -459,6 +497,101 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has an invalid network configuration", func() {
+			BeforeEach(func() {
+				job.Network = &NetworkConfig{
+					Egress:       "some-egress",
+					AllowedCIDRs: []string{"bogus"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.network has unknown egress 'some-egress'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.network has invalid allowed CIDR 'bogus'"))
+			})
+		})
+
+		Context("when a job allows CIDRs without restricting egress", func() {
+			BeforeEach(func() {
+				job.Network = &NetworkConfig{
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.network has allowed_cidrs without an egress of 'none'"))
+			})
+		})
+
+		Context("when a job has a container property with no name", func() {
+			BeforeEach(func() {
+				job.ContainerProperties = map[string]string{"": "some-value"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.container_properties has a property with no name"))
+			})
+		})
+
+		Context("when a job has an invalid schedule window", func() {
+			BeforeEach(func() {
+				job.ScheduleWindow = &ScheduleWindow{
+					Start: "09:00",
+					Stop:  "5pm",
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule_window has invalid stop '5pm' (must be HH:MM)"))
+			})
+		})
+
+		Context("when a job has an unknown notify_on", func() {
+			BeforeEach(func() {
+				job.NotifyOn = "sometimes"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has unknown notify_on 'sometimes' (must be 'all' or 'transition')"))
+			})
+		})
+
+		Context("when a job notifies on transitions only", func() {
+			BeforeEach(func() {
+				job.NotifyOn = NotifyOnTransition
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("is valid", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a job has a restricted network", func() {
+			BeforeEach(func() {
+				job.Network = &NetworkConfig{
+					Egress:       NetworkEgressNone,
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -616,6 +749,69 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan has a relative path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:  "some-resource",
+						Path: "src/github.com/concourse/atc",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a get plan has an absolute path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:  "some-resource",
+						Path: "/tmp/some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.path must be relative ('/tmp/some-resource')"))
+				})
+			})
+
+			Context("when a put plan has a path outside of the working directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:  "some-resource",
+						Path: "src/../../some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.path must not be outside of the task's working directory ('src/../../some-resource')"))
+				})
+			})
+
+			Context("when a get plan's path is the working directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:  "some-resource",
+						Path: "./",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.path must not be the task's working directory ('./')"))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1214,6 +1410,38 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
 				})
 			})
+
+			Context("when a job's input sets rerun_on_upstream_rerun with passed constraints", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:                  "some-resource",
+						Passed:               []string{"some-job"},
+						RerunOnUpstreamRerun: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input sets rerun_on_upstream_rerun without passed constraints", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:                  "some-resource",
+						RerunOnUpstreamRerun: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has rerun_on_upstream_rerun set without any passed constraints"))
+				})
+			})
 		})
 
 		Context("when two jobs have the same name", func() {
@@ -24,3 +24,13 @@ type Volume struct {
 	JobName          string                  `json:"job_name"`
 	StepName         string                  `json:"step_name"`
 }
+
+type Cache struct {
+	ID              int     `json:"id"`
+	Handle          string  `json:"handle"`
+	WorkerName      string  `json:"worker_name"`
+	ResourceCacheID int     `json:"resource_cache_id"`
+	ResourceHash    string  `json:"resource_hash"`
+	Version         Version `json:"version"`
+	ParamsHash      string  `json:"params_hash"`
+}
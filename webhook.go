@@ -0,0 +1,12 @@
+package atc
+
+// Webhook is an outbound subscription to a team's build events. Each
+// delivery is signed with the Secret, which is never returned by the API.
+// If no Events are given, every build event but build output ('log') is
+// delivered.
+type Webhook struct {
+	ID     int      `json:"id,omitempty"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
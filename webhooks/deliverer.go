@@ -0,0 +1,206 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	SignatureHeader = "X-Concourse-Signature"
+	EventHeader     = "X-Concourse-Event"
+	DeliveryHeader  = "X-Concourse-Delivery"
+)
+
+const (
+	// deliveryBatchSize is how many deliveries are made to each webhook per
+	// run
+	deliveryBatchSize = 100
+	initialBackoff    = 10 * time.Second
+	maxBackoff        = time.Hour
+)
+
+// Payload is the body of each webhook delivery.
+type Payload struct {
+	BuildID int              `json:"build_id"`
+	Event   atc.EventType    `json:"event"`
+	Version atc.EventVersion `json:"version"`
+	Data    json.RawMessage  `json:"data"`
+}
+
+// Deliverer sends queued build events to their webhooks. Each webhook is
+// delivered to concurrently, in order, so that a slow or unavailable endpoint
+// only holds up its own deliveries. Failed deliveries are retried with
+// exponential backoff until maxAttempts is reached.
+type Deliverer struct {
+	queue       db.WebhookDeliveryQueue
+	client      *http.Client
+	clock       clock.Clock
+	maxAttempts int
+}
+
+func NewDeliverer(
+	queue db.WebhookDeliveryQueue,
+	client *http.Client,
+	clock clock.Clock,
+	maxAttempts int,
+) *Deliverer {
+	return &Deliverer{
+		queue:       queue,
+		client:      client,
+		clock:       clock,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (deliverer *Deliverer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("webhook-deliverer")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	deliveries, err := deliverer.queue.DueDeliveries(deliveryBatchSize)
+	if err != nil {
+		logger.Error("failed-to-get-due-deliveries", err)
+		return err
+	}
+
+	webhookIDs := []int{}
+	byWebhook := map[int][]db.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		if _, found := byWebhook[delivery.WebhookID]; !found {
+			webhookIDs = append(webhookIDs, delivery.WebhookID)
+		}
+
+		byWebhook[delivery.WebhookID] = append(byWebhook[delivery.WebhookID], delivery)
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, webhookID := range webhookIDs {
+		wg.Add(1)
+		go func(deliveries []db.WebhookDelivery) {
+			defer wg.Done()
+			deliverer.deliverAll(logger, deliveries)
+		}(byWebhook[webhookID])
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// deliverAll makes one webhook's deliveries in order. Once one fails, the
+// rest are left for the next run rather than each waiting out the same
+// failure.
+func (deliverer *Deliverer) deliverAll(logger lager.Logger, deliveries []db.WebhookDelivery) {
+	for _, delivery := range deliveries {
+		dLog := logger.Session("deliver", lager.Data{
+			"delivery-id": delivery.ID,
+			"webhook-id":  delivery.WebhookID,
+			"build-id":    delivery.BuildID,
+		})
+
+		err := deliverer.deliver(delivery)
+		if err == nil {
+			err = deliverer.queue.Remove(delivery.ID)
+			if err != nil {
+				dLog.Error("failed-to-remove-delivery", err)
+			}
+
+			continue
+		}
+
+		attempts := delivery.Attempts + 1
+		if attempts >= deliverer.maxAttempts {
+			dLog.Error("giving-up", err, lager.Data{"attempts": attempts})
+
+			err = deliverer.queue.Remove(delivery.ID)
+			if err != nil {
+				dLog.Error("failed-to-remove-delivery", err)
+			}
+
+			return
+		}
+
+		dLog.Info("failed-to-deliver", lager.Data{"error": err.Error(), "attempts": attempts})
+
+		err = deliverer.queue.Retry(delivery.ID, deliverer.clock.Now().Add(Backoff(attempts)))
+		if err != nil {
+			dLog.Error("failed-to-schedule-retry", err)
+		}
+
+		return
+	}
+}
+
+func (deliverer *Deliverer) deliver(delivery db.WebhookDelivery) error {
+	body, err := json.Marshal(Payload{
+		BuildID: delivery.BuildID,
+		Event:   atc.EventType(delivery.EventType),
+		Version: atc.EventVersion(delivery.EventVersion),
+		Data:    delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, body))
+
+	response, err := deliverer.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", response.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the signature of a delivery's body, as sent in the
+// X-Concourse-Signature header: the hex-encoded HMAC-SHA256 of the body,
+// keyed by the webhook's secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait before retrying a delivery which has
+// failed the given number of times.
+func Backoff(attempts int) time.Duration {
+	backoff := initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return backoff
+}
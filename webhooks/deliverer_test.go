@@ -0,0 +1,182 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeQueue *dbfakes.FakeWebhookDeliveryQueue
+		fakeClock *fakeclock.FakeClock
+		server    *ghttp.Server
+		delivery  db.WebhookDelivery
+
+		deliverer *webhooks.Deliverer
+		runErr    error
+	)
+
+	BeforeEach(func() {
+		fakeQueue = new(dbfakes.FakeWebhookDeliveryQueue)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		server = ghttp.NewServer()
+
+		delivery = db.WebhookDelivery{
+			ID:           42,
+			WebhookID:    1,
+			URL:          server.URL() + "/hook",
+			Secret:       "some-secret",
+			BuildID:      7,
+			EventType:    "status",
+			EventVersion: "1.0",
+			Payload:      json.RawMessage(`{"status":"succeeded","time":1}`),
+			Attempts:     2,
+		}
+
+		fakeQueue.DueDeliveriesReturns([]db.WebhookDelivery{delivery}, nil)
+
+		deliverer = webhooks.NewDeliverer(fakeQueue, http.DefaultClient, fakeClock, 5)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = deliverer.Run(context.TODO())
+	})
+
+	Context("when the webhook accepts the delivery", func() {
+		var expectedBody []byte
+
+		BeforeEach(func() {
+			expectedBody = []byte(`{"build_id":7,"event":"status","version":"1.0","data":{"status":"succeeded","time":1}}`)
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV(webhooks.EventHeader, "status"),
+				ghttp.VerifyHeaderKV(webhooks.DeliveryHeader, "42"),
+				ghttp.VerifyHeaderKV(webhooks.SignatureHeader, webhooks.Sign("some-secret", expectedBody)),
+				ghttp.VerifyJSON(string(expectedBody)),
+				ghttp.RespondWith(http.StatusOK, ""),
+			))
+		})
+
+		It("delivers the signed event", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("removes the delivery from the queue", func() {
+			Expect(fakeQueue.RemoveCallCount()).To(Equal(1))
+			Expect(fakeQueue.RemoveArgsForCall(0)).To(Equal(42))
+			Expect(fakeQueue.RetryCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the webhook rejects the delivery", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+		})
+
+		It("schedules a retry with backoff", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeQueue.RemoveCallCount()).To(BeZero())
+			Expect(fakeQueue.RetryCallCount()).To(Equal(1))
+
+			id, nextAttempt := fakeQueue.RetryArgsForCall(0)
+			Expect(id).To(Equal(42))
+			Expect(nextAttempt).To(Equal(fakeClock.Now().Add(webhooks.Backoff(3))))
+		})
+
+		Context("when the delivery has run out of attempts", func() {
+			BeforeEach(func() {
+				delivery.Attempts = 4
+				fakeQueue.DueDeliveriesReturns([]db.WebhookDelivery{delivery}, nil)
+			})
+
+			It("gives up on the delivery", func() {
+				Expect(fakeQueue.RetryCallCount()).To(BeZero())
+				Expect(fakeQueue.RemoveCallCount()).To(Equal(1))
+				Expect(fakeQueue.RemoveArgsForCall(0)).To(Equal(42))
+			})
+		})
+	})
+
+	Context("when there are deliveries for several webhooks", func() {
+		var failingRequests int32
+
+		BeforeEach(func() {
+			failing := delivery
+			failing.URL = server.URL() + "/failing"
+
+			nextFailing := failing
+			nextFailing.ID = 43
+
+			other := delivery
+			other.ID = 44
+			other.WebhookID = 2
+			other.URL = server.URL() + "/other"
+
+			fakeQueue.DueDeliveriesReturns([]db.WebhookDelivery{failing, nextFailing, other}, nil)
+
+			atomic.StoreInt32(&failingRequests, 0)
+			server.RouteToHandler("POST", "/failing", func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&failingRequests, 1)
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			server.RouteToHandler("POST", "/other", ghttp.RespondWith(http.StatusOK, ""))
+		})
+
+		It("still delivers to the other webhooks", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeQueue.RemoveCallCount()).To(Equal(1))
+			Expect(fakeQueue.RemoveArgsForCall(0)).To(Equal(44))
+		})
+
+		It("leaves the failing webhook's later deliveries for the next run", func() {
+			Expect(atomic.LoadInt32(&failingRequests)).To(Equal(int32(1)))
+			Expect(fakeQueue.RetryCallCount()).To(Equal(1))
+
+			id, _ := fakeQueue.RetryArgsForCall(0)
+			Expect(id).To(Equal(42))
+		})
+	})
+
+	Context("when getting the due deliveries fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeQueue.DueDeliveriesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
+
+var _ = Describe("Backoff", func() {
+	It("doubles with each attempt", func() {
+		Expect(webhooks.Backoff(1)).To(Equal(10 * time.Second))
+		Expect(webhooks.Backoff(2)).To(Equal(20 * time.Second))
+		Expect(webhooks.Backoff(3)).To(Equal(40 * time.Second))
+	})
+
+	It("is capped at an hour", func() {
+		Expect(webhooks.Backoff(20)).To(Equal(time.Hour))
+	})
+})
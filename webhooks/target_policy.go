@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// forbiddenNetworks are the networks webhooks may not be delivered to by
+// default: they would let team members reach the ATC itself, cloud metadata
+// services, or anything else on the ATC's private network.
+var forbiddenNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// ForbiddenTargetError is returned when a webhook would be delivered to an
+// address which the TargetPolicy forbids.
+type ForbiddenTargetError struct {
+	IP net.IP
+}
+
+func (err ForbiddenTargetError) Error() string {
+	return fmt.Sprintf("webhooks may not be delivered to %s", err.IP)
+}
+
+// TargetPolicy decides which addresses webhooks may be delivered to.
+// Loopback, link-local, private and unspecified addresses are forbidden unless
+// they are within one of the AllowedNetworks configured by the operator.
+type TargetPolicy struct {
+	AllowedNetworks []*net.IPNet
+
+	// LookupIP resolves host names when checking a URL. It defaults to
+	// net.LookupIP.
+	LookupIP func(host string) ([]net.IP, error)
+}
+
+// CheckIP returns a ForbiddenTargetError if webhooks may not be delivered to
+// the IP.
+func (policy TargetPolicy) CheckIP(ip net.IP) error {
+	for _, network := range policy.AllowedNetworks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return ForbiddenTargetError{IP: ip}
+	}
+
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return ForbiddenTargetError{IP: ip}
+		}
+	}
+
+	return nil
+}
+
+// CheckURL returns a ForbiddenTargetError if the URL's host is, or resolves
+// to, an address which webhooks may not be delivered to. A host which cannot
+// be resolved yet is allowed; it is checked again whenever a delivery dials
+// it.
+func (policy TargetPolicy) CheckURL(webhookURL *url.URL) error {
+	host := webhookURL.Hostname()
+
+	if ip := net.ParseIP(host); ip != nil {
+		return policy.CheckIP(ip)
+	}
+
+	lookupIP := policy.LookupIP
+	if lookupIP == nil {
+		lookupIP = net.LookupIP
+	}
+
+	ips, err := lookupIP(host)
+	if err != nil {
+		return nil
+	}
+
+	for _, ip := range ips {
+		err := policy.CheckIP(ip)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Client returns an HTTP client for delivering webhooks which refuses to
+// connect to forbidden addresses. The check is made on the address actually
+// dialed, so that it also covers redirects and host names which resolve
+// differently by the time of the delivery.
+func (policy TargetPolicy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("dialed non-IP address %s", address)
+			}
+
+			return policy.CheckIP(ip)
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
@@ -0,0 +1,131 @@
+package webhooks_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("TargetPolicy", func() {
+	var policy webhooks.TargetPolicy
+
+	BeforeEach(func() {
+		policy = webhooks.TargetPolicy{}
+	})
+
+	Describe("CheckIP", func() {
+		It("allows public addresses", func() {
+			Expect(policy.CheckIP(net.ParseIP("203.0.113.10"))).To(Succeed())
+			Expect(policy.CheckIP(net.ParseIP("2001:db8::1"))).To(Succeed())
+		})
+
+		It("forbids loopback, link-local, private and unspecified addresses", func() {
+			for _, ip := range []string{
+				"127.0.0.1",
+				"169.254.169.254",
+				"10.1.2.3",
+				"172.16.0.1",
+				"192.168.1.1",
+				"100.64.0.1",
+				"0.0.0.0",
+				"::1",
+				"fe80::1",
+				"fd00::1",
+			} {
+				Expect(policy.CheckIP(net.ParseIP(ip))).To(Equal(webhooks.ForbiddenTargetError{IP: net.ParseIP(ip)}), ip)
+			}
+		})
+
+		Context("when the operator allows a private network", func() {
+			BeforeEach(func() {
+				_, network, err := net.ParseCIDR("10.1.0.0/16")
+				Expect(err).NotTo(HaveOccurred())
+
+				policy.AllowedNetworks = []*net.IPNet{network}
+			})
+
+			It("allows addresses within it", func() {
+				Expect(policy.CheckIP(net.ParseIP("10.1.2.3"))).To(Succeed())
+				Expect(policy.CheckIP(net.ParseIP("10.2.0.1"))).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("CheckURL", func() {
+		BeforeEach(func() {
+			policy.LookupIP = func(host string) ([]net.IP, error) {
+				switch host {
+				case "internal.example.com":
+					return []net.IP{net.ParseIP("203.0.113.10"), net.ParseIP("10.1.2.3")}, nil
+				case "unknown.example.com":
+					return nil, errors.New("no such host")
+				default:
+					return []net.IP{net.ParseIP("203.0.113.10")}, nil
+				}
+			}
+		})
+
+		check := func(rawURL string) error {
+			webhookURL, err := url.Parse(rawURL)
+			Expect(err).NotTo(HaveOccurred())
+
+			return policy.CheckURL(webhookURL)
+		}
+
+		It("checks IP hosts directly", func() {
+			Expect(check("http://169.254.169.254/latest/meta-data")).NotTo(Succeed())
+			Expect(check("http://[::1]:8080/hook")).NotTo(Succeed())
+		})
+
+		It("forbids host names resolving to any forbidden address", func() {
+			Expect(check("https://internal.example.com/hook")).NotTo(Succeed())
+			Expect(check("https://example.com/hook")).To(Succeed())
+		})
+
+		It("allows host names which cannot be resolved yet", func() {
+			Expect(check("https://unknown.example.com/hook")).To(Succeed())
+		})
+	})
+
+	Describe("Client", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			server.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusOK, ""))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("refuses to connect to forbidden addresses", func() {
+			_, err := policy.Client(time.Second).Post(server.URL()+"/hook", "application/json", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("webhooks may not be delivered to 127.0.0.1"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		Context("when the operator allows the address", func() {
+			BeforeEach(func() {
+				_, network, err := net.ParseCIDR("127.0.0.0/8")
+				Expect(err).NotTo(HaveOccurred())
+
+				policy.AllowedNetworks = []*net.IPNet{network}
+			})
+
+			It("connects", func() {
+				response, err := policy.Client(time.Second).Post(server.URL()+"/hook", "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+})
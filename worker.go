@@ -29,6 +29,29 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// the container runtime the worker serves, e.g. 'containerd'; workers
+	// which leave it empty run Garden
+	Runtime string `json:"runtime,omitempty"`
+
+	// the machine's load as of the worker's last heartbeat, if it reports it
+	Load *WorkerLoad `json:"load,omitempty"`
+
+	// whether the worker's containers are denied network access other than
+	// by their NetOut rules, e.g. with Garden's --deny-network 0.0.0.0/0;
+	// only such workers run containers with an egress of 'none'
+	DeniesNetworkByDefault bool `json:"denies_network_by_default,omitempty"`
+}
+
+// WorkerLoad is how busy a worker's machine is.
+type WorkerLoad struct {
+	// CPULoad is the load average over the last minute divided by the number
+	// of CPUs, so that 1 means every CPU is busy.
+	CPULoad float64 `json:"cpu_load"`
+
+	// FreeMemory and FreeDisk are in bytes.
+	FreeMemory uint64 `json:"free_memory"`
+	FreeDisk   uint64 `json:"free_disk"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
@@ -24,22 +24,27 @@ type ArtifactName string
 // subdirectories corresponding to their ArtifactName.
 type ArtifactRepository struct {
 	repo  map[ArtifactName]ArtifactSource
+	paths map[ArtifactName]string
 	repoL sync.RWMutex
 }
 
 // NewArtifactRepository constructs a new repository.
 func NewArtifactRepository() *ArtifactRepository {
 	return &ArtifactRepository{
-		repo: make(map[ArtifactName]ArtifactSource),
+		repo:  make(map[ArtifactName]ArtifactSource),
+		paths: make(map[ArtifactName]string),
 	}
 }
 
 // RegisterSource inserts an ArtifactSource into the map under the given
 // ArtifactName. Producers of artifacts, e.g. the Get step and the Task step,
 // will call this after they've successfully produced their artifact(s).
+//
+// Any path registered for a previous artifact of the same name is cleared.
 func (repo *ArtifactRepository) RegisterSource(name ArtifactName, source ArtifactSource) {
 	repo.repoL.Lock()
 	repo.repo[name] = source
+	delete(repo.paths, name)
 	repo.repoL.Unlock()
 }
 
@@ -52,6 +57,23 @@ func (repo *ArtifactRepository) SourceFor(name ArtifactName) (ArtifactSource, bo
 	return source, found
 }
 
+// RegisterPath sets the path, relative to a task's working directory, at which
+// the named artifact is mounted when a task's input config doesn't specify one.
+// This is configured by the Get step's 'path'.
+func (repo *ArtifactRepository) RegisterPath(name ArtifactName, path string) {
+	repo.repoL.Lock()
+	repo.paths[name] = path
+	repo.repoL.Unlock()
+}
+
+// PathFor looks up the path registered for the given ArtifactName.
+func (repo *ArtifactRepository) PathFor(name ArtifactName) (string, bool) {
+	repo.repoL.RLock()
+	path, found := repo.paths[name]
+	repo.repoL.RUnlock()
+	return path, found
+}
+
 // StreamTo will stream all currently registered artifacts to the destination.
 // This is used by the Put step, which currently does not have an explicit set
 // of dependencies, and instead just pulls in everything.
@@ -50,6 +50,36 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 
+		Describe("PathFor", func() {
+			It("yields nothing when no path is registered", func() {
+				_, found := repo.PathFor("first-source")
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when a path is registered", func() {
+				BeforeEach(func() {
+					repo.RegisterPath("first-source", "some/path")
+				})
+
+				It("yields the path by the given name", func() {
+					path, found := repo.PathFor("first-source")
+					Expect(path).To(Equal("some/path"))
+					Expect(found).To(BeTrue())
+				})
+
+				Context("when the source is registered again", func() {
+					BeforeEach(func() {
+						repo.RegisterSource("first-source", new(workerfakes.FakeArtifactSource))
+					})
+
+					It("forgets the path", func() {
+						_, found := repo.PathFor("first-source")
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+		})
+
 		Context("when a second source is registered", func() {
 			var secondSource *workerfakes.FakeArtifactSource
 
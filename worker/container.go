@@ -16,7 +16,7 @@ type gardenWorkerContainer struct {
 	dbContainer db.CreatedContainer
 	dbVolumes   []db.CreatedVolume
 
-	gardenClient garden.Client
+	runtime Runtime
 
 	volumeMounts []VolumeMount
 
@@ -32,7 +32,7 @@ func newGardenWorkerContainer(
 	container garden.Container,
 	dbContainer db.CreatedContainer,
 	dbContainerVolumes []db.CreatedVolume,
-	gardenClient garden.Client,
+	runtime Runtime,
 	volumeClient VolumeClient,
 	workerName string,
 ) (Container, error) {
@@ -43,7 +43,7 @@ func newGardenWorkerContainer(
 		dbContainer: dbContainer,
 		dbVolumes:   dbContainerVolumes,
 
-		gardenClient: gardenClient,
+		runtime: runtime,
 
 		workerName: workerName,
 	}
@@ -68,7 +68,7 @@ func newGardenWorkerContainer(
 }
 
 func (container *gardenWorkerContainer) Destroy() error {
-	return container.gardenClient.Destroy(container.Handle())
+	return container.runtime.Destroy(container.Handle())
 }
 
 func (container *gardenWorkerContainer) WorkerName() string {
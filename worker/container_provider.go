@@ -12,13 +12,24 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/baggageclaim"
 )
 
 const creatingContainerRetryDelay = 1 * time.Second
 
+// PrivilegedNotAllowedError is returned when creating a privileged container
+// for a team which is not allowed to run them.
+type PrivilegedNotAllowedError struct {
+	TeamName string
+}
+
+func (err PrivilegedNotAllowedError) Error() string {
+	return fmt.Sprintf("team '%s' is not allowed to run privileged containers", err.TeamName)
+}
+
 func NewContainerProvider(
-	gardenClient garden.Client,
+	runtime Runtime,
 	baggageclaimClient baggageclaim.Client,
 	volumeClient VolumeClient,
 	dbWorker db.Worker,
@@ -28,10 +39,11 @@ func NewContainerProvider(
 	dbVolumeRepository db.VolumeRepository,
 	dbTeamFactory db.TeamFactory,
 	lockFactory lock.LockFactory,
+	minStreamThroughput uint64,
 ) ContainerProvider {
 
 	return &containerProvider{
-		gardenClient:       gardenClient,
+		runtime:            runtime,
 		baggageclaimClient: baggageclaimClient,
 		volumeClient:       volumeClient,
 		imageFactory:       imageFactory,
@@ -43,6 +55,8 @@ func NewContainerProvider(
 		noProxy:            dbWorker.NoProxy(),
 		clock:              clock,
 		worker:             dbWorker,
+
+		minStreamThroughput: minStreamThroughput,
 	}
 }
 
@@ -67,7 +81,7 @@ type ContainerProvider interface {
 }
 
 type containerProvider struct {
-	gardenClient       garden.Client
+	runtime            Runtime
 	baggageclaimClient baggageclaim.Client
 	volumeClient       VolumeClient
 	imageFactory       ImageFactory
@@ -82,6 +96,8 @@ type containerProvider struct {
 	noProxy       string
 
 	clock clock.Clock
+
+	minStreamThroughput uint64
 }
 
 func (p *containerProvider) FindOrCreateContainer(
@@ -110,7 +126,7 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("found-created-container-in-db")
 
-			gardenContainer, err = p.gardenClient.Lookup(createdContainer.Handle())
+			gardenContainer, err = p.runtime.Lookup(createdContainer.Handle())
 			if err != nil {
 				logger.Error("failed-to-lookup-created-container-in-garden", err)
 				return nil, err
@@ -128,7 +144,7 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("found-creating-container-in-db")
 
-			gardenContainer, err = p.gardenClient.Lookup(creatingContainer.Handle())
+			gardenContainer, err = p.runtime.Lookup(creatingContainer.Handle())
 			if err != nil {
 				if _, ok := err.(garden.ContainerNotFoundError); !ok {
 					logger.Error("failed-to-lookup-creating-container-in-garden", err)
@@ -140,9 +156,13 @@ func (p *containerProvider) FindOrCreateContainer(
 		if gardenContainer != nil {
 			logger.Debug("found-created-container-in-garden")
 		} else {
+			err = p.checkPrivilegedAllowed(logger, spec, resourceTypes)
+			if err != nil {
+				return nil, err
+			}
 
 			worker := NewGardenWorker(
-				p.gardenClient,
+				p.runtime,
 				p.baggageclaimClient,
 				p,
 				p.volumeClient,
@@ -158,6 +178,7 @@ func (p *containerProvider) FindOrCreateContainer(
 				spec.TeamID,
 				delegate,
 				resourceTypes,
+				spec.IsolationLabels,
 			)
 			if err != nil {
 				return nil, err
@@ -196,7 +217,12 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("fetching-image")
 
-			fetchedImage, err := image.FetchForContainer(ctx, logger, creatingContainer)
+			span, imageCtx := tracing.StartSpan(ctx, "fetch-image", tracing.Attrs{
+				"worker": p.worker.Name(),
+			})
+
+			fetchedImage, err := image.FetchForContainer(imageCtx, logger, creatingContainer)
+			tracing.End(span, err)
 			if err != nil {
 				creatingContainer.Failed()
 				logger.Error("failed-to-fetch-image-for-container", err)
@@ -205,12 +231,19 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("creating-container-in-garden")
 
+			span, createCtx := tracing.StartSpan(ctx, "create-container", tracing.Attrs{
+				"worker":    p.worker.Name(),
+				"container": creatingContainer.Handle(),
+			})
+
 			gardenContainer, err = p.createGardenContainer(
+				createCtx,
 				logger,
 				creatingContainer,
 				spec,
 				fetchedImage,
 			)
+			tracing.End(span, err)
 			if err != nil {
 				_, failedErr := creatingContainer.Failed()
 				if failedErr != nil {
@@ -231,7 +264,7 @@ func (p *containerProvider) FindOrCreateContainer(
 		if err != nil {
 			logger.Error("failed-to-mark-container-as-created", err)
 
-			_ = p.gardenClient.Destroy(creatingContainer.Handle())
+			_ = p.runtime.Destroy(creatingContainer.Handle())
 
 			return nil, err
 		}
@@ -246,12 +279,44 @@ func (p *containerProvider) FindOrCreateContainer(
 	}
 }
 
+// checkPrivilegedAllowed re-checks the team's setting at container creation,
+// as it may have changed since the pipeline was configured.
+func (p *containerProvider) checkPrivilegedAllowed(
+	logger lager.Logger,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
+) error {
+	privileged := spec.ImageSpec.Privileged
+	if resourceType, found := resourceTypes.Lookup(spec.ImageSpec.ResourceType); found && resourceType.Privileged {
+		privileged = true
+	}
+
+	if !privileged {
+		return nil
+	}
+
+	team := p.dbTeamFactory.GetByID(spec.TeamID)
+
+	found, err := team.Reload()
+	if err != nil {
+		logger.Error("failed-to-reload-team", err)
+		return err
+	}
+
+	if !found || !team.AllowPrivileged() {
+		logger.Info("privileged-not-allowed", lager.Data{"team": team.Name()})
+		return PrivilegedNotAllowedError{TeamName: team.Name()}
+	}
+
+	return nil
+}
+
 func (p *containerProvider) FindCreatedContainerByHandle(
 	logger lager.Logger,
 	handle string,
 	teamID int,
 ) (Container, bool, error) {
-	gardenContainer, err := p.gardenClient.Lookup(handle)
+	gardenContainer, err := p.runtime.Lookup(handle)
 	if err != nil {
 		if _, ok := err.(garden.ContainerNotFoundError); ok {
 			logger.Info("container-not-found")
@@ -282,7 +347,7 @@ func (p *containerProvider) FindCreatedContainerByHandle(
 		gardenContainer,
 		createdContainer,
 		createdVolumes,
-		p.gardenClient,
+		p.runtime,
 		p.volumeClient,
 		p.worker.Name(),
 	)
@@ -311,13 +376,14 @@ func (p *containerProvider) constructGardenWorkerContainer(
 		gardenContainer,
 		createdContainer,
 		createdVolumes,
-		p.gardenClient,
+		p.runtime,
 		p.volumeClient,
 		p.worker.Name(),
 	)
 }
 
 func (p *containerProvider) createGardenContainer(
+	ctx context.Context,
 	logger lager.Logger,
 	creatingContainer db.CreatingContainer,
 	spec ContainerSpec,
@@ -366,7 +432,7 @@ func (p *containerProvider) createGardenContainer(
 	}
 
 	worker := NewGardenWorker(
-		p.gardenClient,
+		p.runtime,
 		p.baggageclaimClient,
 		p,
 		p.volumeClient,
@@ -412,7 +478,19 @@ func (p *containerProvider) createGardenContainer(
 				return nil, err
 			}
 
-			err = inputSource.Source().StreamTo(inputVolume)
+			span, _ := tracing.StartSpan(ctx, "stream-input", tracing.Attrs{
+				"worker": p.worker.Name(),
+				"path":   inputSource.DestinationPath(),
+			})
+
+			err = inputSource.Source().StreamTo(meteredDestination{
+				logger:        logger.Session("stream-input", lager.Data{"path": inputSource.DestinationPath()}),
+				clock:         p.clock,
+				destination:   inputVolume,
+				workerName:    p.worker.Name(),
+				minThroughput: p.minStreamThroughput,
+			})
+			tracing.End(span, err)
 			if err != nil {
 				return nil, err
 			}
@@ -467,7 +545,7 @@ func (p *containerProvider) createGardenContainer(
 		volumeHandleMounts[mount.Volume.Handle()] = mount.MountPath
 	}
 
-	gardenProperties := garden.Properties{}
+	gardenProperties := spec.IsolationLabels.Properties()
 
 	if spec.User != "" {
 		gardenProperties[userPropertyName] = spec.User
@@ -489,7 +567,12 @@ func (p *containerProvider) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", p.noProxy))
 	}
 
-	return p.gardenClient.Create(garden.ContainerSpec{
+	netOut, err := spec.NetOutRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.runtime.Create(garden.ContainerSpec{
 		Handle:     creatingContainer.Handle(),
 		RootFSPath: fetchedImage.URL,
 		Privileged: fetchedImage.Privileged,
@@ -497,6 +580,7 @@ func (p *containerProvider) createGardenContainer(
 		Limits:     spec.Limits.ToGardenLimits(),
 		Env:        env,
 		Properties: gardenProperties,
+		NetOut:     netOut,
 	})
 }
 
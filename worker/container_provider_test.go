@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -25,6 +27,7 @@ import (
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("ContainerProvider", func() {
@@ -44,6 +47,7 @@ var _ = Describe("ContainerProvider", func() {
 		fakeDBTeam             *dbfakes.FakeTeam
 		fakeDBVolumeRepository *dbfakes.FakeVolumeRepository
 		fakeLockFactory        *lockfakes.FakeLockFactory
+		fakeClock              *fakeclock.FakeClock
 
 		containerProvider ContainerProvider
 
@@ -100,7 +104,7 @@ var _ = Describe("ContainerProvider", func() {
 		fakeDBTeam = new(dbfakes.FakeTeam)
 		fakeDBTeamFactory.GetByIDReturns(fakeDBTeam)
 		fakeDBVolumeRepository = new(dbfakes.FakeVolumeRepository)
-		fakeClock := fakeclock.NewFakeClock(time.Unix(0, 123))
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 		fakeGardenContainer = new(gardenfakes.FakeContainer)
 		fakeGardenClient.CreateReturns(fakeGardenContainer, nil)
 
@@ -119,6 +123,7 @@ var _ = Describe("ContainerProvider", func() {
 			fakeDBVolumeRepository,
 			fakeDBTeamFactory,
 			fakeLockFactory,
+			1024,
 		)
 
 		fakeLocalInput = new(workerfakes.FakeInputSource)
@@ -384,9 +389,9 @@ var _ = Describe("ContainerProvider", func() {
 
 		It("gets image", func() {
 			Expect(fakeImageFactory.GetImageCallCount()).To(Equal(1))
-			_, actualWorker, actualVolumeClient, actualImageSpec, actualTeamID, actualDelegate, actualResourceTypes := fakeImageFactory.GetImageArgsForCall(0)
+			_, actualWorker, actualVolumeClient, actualImageSpec, actualTeamID, actualDelegate, actualResourceTypes, _ := fakeImageFactory.GetImageArgsForCall(0)
 
-			Expect(actualWorker.GardenClient()).To(Equal(fakeGardenClient))
+			Expect(actualWorker.Runtime()).To(Equal(fakeGardenClient))
 
 			Expect(actualVolumeClient).To(Equal(fakeVolumeClient))
 			Expect(actualImageSpec).To(Equal(containerSpec.ImageSpec))
@@ -410,6 +415,57 @@ var _ = Describe("ContainerProvider", func() {
 			Expect(fakeLockFactory.AcquireCallCount()).To(Equal(1))
 		})
 
+		Context("when the spec has isolation labels", func() {
+			BeforeEach(func() {
+				containerSpec.IsolationLabels = IsolationLabels{
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Public:   true,
+				}
+			})
+
+			It("gets the image with them, so that the containers fetching it are labelled too", func() {
+				Expect(fakeImageFactory.GetImageCallCount()).To(Equal(1))
+				_, _, _, _, _, _, _, actualIsolationLabels := fakeImageFactory.GetImageArgsForCall(0)
+				Expect(actualIsolationLabels).To(Equal(containerSpec.IsolationLabels))
+			})
+
+			It("sets them as properties of the garden container", func() {
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+				actualSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualSpec.Properties).To(Equal(garden.Properties{
+					"user":                 "some-user",
+					"concourse:team":       "some-team",
+					"concourse:pipeline":   "some-pipeline",
+					"concourse:visibility": "public",
+				}))
+			})
+
+			Context("when the spec has job properties", func() {
+				BeforeEach(func() {
+					containerSpec.IsolationLabels.JobProperties = map[string]string{
+						"service": "some-service",
+						"user":    "some-other-user",
+					}
+				})
+
+				It("sets them as prefixed properties of the garden container", func() {
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+					actualSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(actualSpec.Properties).To(Equal(garden.Properties{
+						"user":                  "some-user",
+						"concourse:team":        "some-team",
+						"concourse:pipeline":    "some-pipeline",
+						"concourse:visibility":  "public",
+						"concourse:job:service": "some-service",
+						"concourse:job:user":    "some-other-user",
+					}))
+				})
+			})
+		})
+
 		It("creates the container in garden", func() {
 			Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
 
@@ -488,6 +544,44 @@ var _ = Describe("ContainerProvider", func() {
 			Expect(ioutil.ReadAll(from)).To(Equal([]byte("some-stream")))
 		})
 
+		Context("when streaming a remote input runs slower than the minimum throughput", func() {
+			BeforeEach(func() {
+				fakeRemoteInputContainerVolume.StreamInStub = func(path string, tarStream io.Reader) error {
+					_, err := ioutil.ReadAll(tarStream)
+					fakeClock.Increment(10 * time.Second)
+					return err
+				}
+			})
+
+			It("logs a slow stream", func() {
+				ad := fakeRemoteInputAS.StreamToArgsForCall(0)
+
+				err := ad.StreamIn(".", bytes.NewBufferString("some-stream"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(logger).To(gbytes.Say("slow-stream"))
+			})
+		})
+
+		Context("when streaming a remote input keeps up with the minimum throughput", func() {
+			BeforeEach(func() {
+				fakeRemoteInputContainerVolume.StreamInStub = func(path string, tarStream io.Reader) error {
+					_, err := ioutil.ReadAll(tarStream)
+					fakeClock.Increment(2 * time.Second)
+					return err
+				}
+			})
+
+			It("does not log a slow stream", func() {
+				ad := fakeRemoteInputAS.StreamToArgsForCall(0)
+
+				err := ad.StreamIn(".", bytes.NewReader(make([]byte, 4096)))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(logger).ToNot(gbytes.Say("slow-stream"))
+			})
+		})
+
 		It("marks container as created", func() {
 			Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 		})
@@ -522,6 +616,41 @@ var _ = Describe("ContainerProvider", func() {
 
 		})
 
+		Context("when the container's network is restricted", func() {
+			BeforeEach(func() {
+				containerSpec.Network = &atc.NetworkConfig{
+					Egress:       atc.NetworkEgressNone,
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				}
+			})
+
+			It("creates the container with net out rules for the allowed CIDRs", func() {
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+				_, allowed, err := net.ParseCIDR("10.0.0.0/8")
+				Expect(err).ToNot(HaveOccurred())
+
+				actualSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualSpec.NetOut).To(Equal([]garden.NetOutRule{
+					{
+						Protocol: garden.ProtocolAll,
+						Networks: []garden.IPRange{garden.IPRangeFromIPNet(allowed)},
+					},
+				}))
+			})
+
+			Context("when no CIDRs are allowed", func() {
+				BeforeEach(func() {
+					containerSpec.Network.AllowedCIDRs = nil
+				})
+
+				It("creates the container without any net out rules", func() {
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					Expect(fakeGardenClient.CreateArgsForCall(0).NetOut).To(BeEmpty())
+				})
+			})
+		})
+
 		Context("when an input has the path set to the workdir itself", func() {
 			BeforeEach(func() {
 				fakeLocalInput.DestinationPathReturns("/some/work-dir")
@@ -563,6 +692,73 @@ var _ = Describe("ContainerProvider", func() {
 			})
 		})
 
+		Context("when a privileged container is requested", func() {
+			BeforeEach(func() {
+				containerSpec.ImageSpec.Privileged = true
+				fakeDBTeam.NameReturns("some-team")
+				fakeDBTeam.ReloadReturns(true, nil)
+			})
+
+			Context("when the team is allowed to run privileged containers", func() {
+				BeforeEach(func() {
+					fakeDBTeam.AllowPrivilegedReturns(true)
+				})
+
+				It("creates the container", func() {
+					Expect(findOrCreateErr).ToNot(HaveOccurred())
+					Expect(fakeDBTeam.ReloadCallCount()).To(Equal(1))
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the team is not allowed to run privileged containers", func() {
+				BeforeEach(func() {
+					fakeDBTeam.AllowPrivilegedReturns(false)
+				})
+
+				It("returns an error", func() {
+					Expect(findOrCreateErr).To(Equal(PrivilegedNotAllowedError{TeamName: "some-team"}))
+				})
+
+				It("does not create the container", func() {
+					Expect(fakeImageFactory.GetImageCallCount()).To(BeZero())
+					Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when reloading the team fails", func() {
+				BeforeEach(func() {
+					fakeDBTeam.ReloadReturns(false, disasterErr)
+				})
+
+				It("returns an error", func() {
+					Expect(findOrCreateErr).To(Equal(disasterErr))
+				})
+			})
+		})
+
+		Context("when the resource type is privileged", func() {
+			BeforeEach(func() {
+				containerSpec.ImageSpec = ImageSpec{ResourceType: "some-type"}
+				resourceTypes = creds.NewVersionedResourceTypes(template.StaticVariables{}, atc.VersionedResourceTypes{
+					{
+						ResourceType: atc.ResourceType{
+							Name:       "some-type",
+							Type:       "some-base-type",
+							Privileged: true,
+						},
+					},
+				})
+
+				fakeDBTeam.ReloadReturns(true, nil)
+				fakeDBTeam.AllowPrivilegedReturns(false)
+			})
+
+			It("checks that the team is allowed to run privileged containers", func() {
+				Expect(findOrCreateErr).To(BeAssignableToTypeOf(PrivilegedNotAllowedError{}))
+			})
+		})
+
 		Context("when getting image fails", func() {
 			BeforeEach(func() {
 				fakeImageFactory.GetImageReturns(nil, disasterErr)
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
@@ -14,6 +15,10 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// RestrictedNetwork requires a worker which denies network access by
+	// default.
+	RestrictedNetwork bool
 }
 
 type ContainerSpec struct {
@@ -42,6 +47,56 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional network restrictions, translated into NetOut rules when
+	// creating in garden.
+	Network *atc.NetworkConfig
+
+	// Optional labels identifying who the container belongs to, set as
+	// properties of the garden container.
+	IsolationLabels IsolationLabels
+}
+
+// IsolationLabels identify the team and pipeline a container belongs to, and
+// whether the pipeline is public, so that tooling on the worker such as
+// network policy agents can isolate containers by label.
+//
+// JobProperties are the container_properties of the job the container runs
+// for, if any, for agents which need more context than that.
+type IsolationLabels struct {
+	Team     string
+	Pipeline string
+	Public   bool
+
+	JobProperties map[string]string
+}
+
+// Properties returns the labels as garden container properties. Containers
+// which do not belong to a team are not labelled.
+func (labels IsolationLabels) Properties() garden.Properties {
+	properties := garden.Properties{}
+
+	for name, value := range labels.JobProperties {
+		properties[jobPropertyPrefix+name] = value
+	}
+
+	if labels.Team == "" {
+		return properties
+	}
+
+	properties[teamPropertyName] = labels.Team
+
+	if labels.Pipeline != "" {
+		properties[pipelinePropertyName] = labels.Pipeline
+	}
+
+	if labels.Public {
+		properties[visibilityPropertyName] = "public"
+	} else {
+		properties[visibilityPropertyName] = "private"
+	}
+
+	return properties
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
@@ -85,12 +140,38 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 	return gardenLimits
 }
 
+// NetOutRules returns the rules permitting the container to reach each of
+// its network's allowed CIDRs, and nothing else. An unrestricted network has
+// no rules.
+func (spec ContainerSpec) NetOutRules() ([]garden.NetOutRule, error) {
+	if spec.Network == nil || !spec.Network.Restricted() {
+		return nil, nil
+	}
+
+	rules := []garden.NetOutRule{}
+	for _, cidr := range spec.Network.AllowedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, garden.NetOutRule{
+			Protocol: garden.ProtocolAll,
+			Networks: []garden.IPRange{garden.IPRangeFromIPNet(ipNet)},
+		})
+	}
+
+	return rules, nil
+}
+
 func (spec ContainerSpec) WorkerSpec() WorkerSpec {
 	return WorkerSpec{
 		ResourceType: spec.ImageSpec.ResourceType,
 		Platform:     spec.Platform,
 		Tags:         spec.Tags,
 		TeamID:       spec.TeamID,
+
+		RestrictedNetwork: spec.Network != nil && spec.Network.Restricted(),
 	}
 }
 
@@ -109,5 +190,9 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.RestrictedNetwork {
+		attrs = append(attrs, "network denied by default")
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
-	gclient "code.cloudfoundry.org/garden/client"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/worker/transport"
 	bclient "github.com/concourse/baggageclaim/client"
@@ -32,6 +32,8 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     *version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	resourceTypeVersions              map[string]string
+	minStreamThroughput               uint64
 }
 
 func NewDBWorkerProvider(
@@ -47,6 +49,8 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion *version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	resourceTypeVersions map[string]string,
+	minStreamThroughput uint64,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -61,6 +65,8 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		resourceTypeVersions:              resourceTypeVersions,
+		minStreamThroughput:               minStreamThroughput,
 	}
 }
 
@@ -80,6 +86,10 @@ func (provider *dbWorkerProvider) RunningWorkers(logger lager.Logger) ([]Worker,
 		}
 
 		workerLog := logger.Session("running-worker")
+		if !provider.isRuntimeSupported(workerLog, savedWorker) {
+			continue
+		}
+
 		worker := provider.NewGardenWorker(workerLog, tikTok, savedWorker)
 		if !worker.IsVersionCompatible(workerLog, provider.workerVersion) {
 			continue
@@ -108,6 +118,10 @@ func (provider *dbWorkerProvider) FindWorkerForContainerByOwner(
 		return nil, false, nil
 	}
 
+	if !provider.isRuntimeSupported(logger, dbWorker) {
+		return nil, false, nil
+	}
+
 	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker)
 	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
@@ -132,6 +146,10 @@ func (provider *dbWorkerProvider) FindWorkerForContainer(
 		return nil, false, nil
 	}
 
+	if !provider.isRuntimeSupported(logger, dbWorker) {
+		return nil, false, nil
+	}
+
 	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker)
 	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
@@ -140,16 +158,25 @@ func (provider *dbWorkerProvider) FindWorkerForContainer(
 }
 
 func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok clock.Clock, savedWorker db.Worker) Worker {
-	gcf := NewGardenConnectionFactory(
+	if len(provider.resourceTypeVersions) > 0 {
+		savedWorker = pinnedResourceTypesWorker{
+			Worker:   savedWorker,
+			versions: provider.resourceTypeVersions,
+		}
+	}
+
+	runtimeFactory, found := runtimeFactories[runtimeName(savedWorker)]
+	if !found {
+		runtimeFactory = runtimeFactories[GardenRuntime]
+	}
+
+	runtime := runtimeFactory.NewRuntime(
+		logger,
 		provider.dbWorkerFactory,
-		logger.Session("garden-connection"),
-		savedWorker.Name(),
-		savedWorker.GardenAddr(),
+		savedWorker,
 		provider.retryBackOffFactory,
 	)
 
-	gClient := gclient.New(NewRetryableConnection(gcf.BuildConnection()))
-
 	bClient := bclient.New("", transport.NewBaggageclaimRoundTripper(
 		savedWorker.Name(),
 		savedWorker.BaggageclaimURL(),
@@ -171,7 +198,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 	)
 
 	containerProvider := NewContainerProvider(
-		gClient,
+		runtime,
 		bClient,
 		// rClient,
 		volumeClient,
@@ -181,10 +208,11 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeRepository,
 		provider.dbTeamFactory,
 		provider.lockFactory,
+		provider.minStreamThroughput,
 	)
 
 	return NewGardenWorker(
-		gClient,
+		runtime,
 		bClient,
 		// rClient,
 		containerProvider,
@@ -193,3 +221,41 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		tikTok,
 	)
 }
+
+// isRuntimeSupported returns whether a runtime has been registered for the
+// worker's. Workers registered through another ATC may run a runtime this one
+// does not know how to talk to.
+func (provider *dbWorkerProvider) isRuntimeSupported(logger lager.Logger, savedWorker db.Worker) bool {
+	_, found := runtimeFactories[runtimeName(savedWorker)]
+	if !found {
+		logger.Info("unsupported-runtime", lager.Data{
+			"worker":  savedWorker.Name(),
+			"runtime": savedWorker.Runtime(),
+		})
+	}
+
+	return found
+}
+
+// pinnedResourceTypesWorker hides the base resource types of a worker whose
+// version does not match the one pinned by the operator, so that steps using
+// them are only scheduled on workers providing the pinned version.
+type pinnedResourceTypesWorker struct {
+	db.Worker
+
+	versions map[string]string
+}
+
+func (worker pinnedResourceTypesWorker) ResourceTypes() []atc.WorkerResourceType {
+	resourceTypes := []atc.WorkerResourceType{}
+	for _, resourceType := range worker.Worker.ResourceTypes() {
+		version, pinned := worker.versions[resourceType.Type]
+		if pinned && resourceType.Version != version {
+			continue
+		}
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes
+}
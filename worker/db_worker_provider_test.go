@@ -43,7 +43,9 @@ var _ = Describe("DBProvider", func() {
 		gardenServer                      *server.GardenServer
 		provider                          WorkerProvider
 		baggageclaimResponseHeaderTimeout time.Duration
+		resourceTypeVersions              map[string]string
 
+		fakeBackOffFactory                  *retryhttpfakes.FakeBackOffFactory
 		fakeImageFactory                    *workerfakes.FakeImageFactory
 		fakeImageFetchingDelegate           *workerfakes.FakeImageFetchingDelegate
 		fakeDBVolumeRepository              *dbfakes.FakeVolumeRepository
@@ -142,7 +144,7 @@ var _ = Describe("DBProvider", func() {
 		fakeDBTeamFactory.GetByIDReturns(fakeDBTeam)
 		fakeDBVolumeRepository = new(dbfakes.FakeVolumeRepository)
 
-		fakeBackOffFactory := new(retryhttpfakes.FakeBackOffFactory)
+		fakeBackOffFactory = new(retryhttpfakes.FakeBackOffFactory)
 		fakeBackOff := new(retryhttpfakes.FakeBackOff)
 		fakeBackOffFactory.NewBackOffReturns(fakeBackOff)
 		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
@@ -159,6 +161,12 @@ var _ = Describe("DBProvider", func() {
 		wantWorkerVersion, err = version.NewVersionFromString("1.1.0")
 		Expect(err).ToNot(HaveOccurred())
 
+		resourceTypeVersions = nil
+
+		baggageclaimURL = baggageclaimServer.URL()
+	})
+
+	JustBeforeEach(func() {
 		provider = NewDBWorkerProvider(
 			fakeLockFactory,
 			fakeBackOffFactory,
@@ -172,8 +180,9 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			&wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			resourceTypeVersions,
+			0,
 		)
-		baggageclaimURL = baggageclaimServer.URL()
 	})
 
 	AfterEach(func() {
@@ -209,6 +218,23 @@ var _ = Describe("DBProvider", func() {
 				Expect(workers).To(HaveLen(2))
 			})
 
+			Context("when resource type versions are pinned", func() {
+				BeforeEach(func() {
+					fakeWorker1.ResourceTypesReturns([]atc.WorkerResourceType{
+						{Type: "some-resource-a", Image: "some-image-a", Version: "1.0.0"},
+						{Type: "some-resource-b", Image: "some-image-b", Version: "2.0.0"},
+					})
+
+					resourceTypeVersions = map[string]string{"some-resource-b": "3.0.0"}
+				})
+
+				It("hides the base resource types which do not match the pinned version", func() {
+					Expect(workers[0].ResourceTypes()).To(Equal([]atc.WorkerResourceType{
+						{Type: "some-resource-a", Image: "some-image-a", Version: "1.0.0"},
+					}))
+				})
+			})
+
 			Context("when some of the workers returned are stalled or landing", func() {
 				BeforeEach(func() {
 					landingWorker := new(dbfakes.FakeWorker)
@@ -243,6 +269,50 @@ var _ = Describe("DBProvider", func() {
 				})
 			})
 
+			Context("when a worker's runtime has been registered", func() {
+				var fakeRuntimeFactory *workerfakes.FakeRuntimeFactory
+				var fakeRuntime *workerfakes.FakeRuntime
+
+				BeforeEach(func() {
+					fakeRuntime = new(workerfakes.FakeRuntime)
+					fakeRuntimeFactory = new(workerfakes.FakeRuntimeFactory)
+					fakeRuntimeFactory.NewRuntimeReturns(fakeRuntime)
+
+					RegisterRuntime("some-runtime", fakeRuntimeFactory)
+
+					fakeWorker1.RuntimeReturns("some-runtime")
+				})
+
+				AfterEach(func() {
+					delete(RuntimeFactories(), "some-runtime")
+				})
+
+				It("returns the worker", func() {
+					Expect(workersErr).NotTo(HaveOccurred())
+					Expect(workers).To(HaveLen(2))
+				})
+
+				It("connects to the worker using the registered runtime", func() {
+					Expect(fakeRuntimeFactory.NewRuntimeCallCount()).To(Equal(1))
+					_, _, dbWorker, _ := fakeRuntimeFactory.NewRuntimeArgsForCall(0)
+					Expect(dbWorker).To(Equal(fakeWorker1))
+
+					Expect(workers[0].Runtime()).To(Equal(fakeRuntime))
+				})
+			})
+
+			Context("when a worker's runtime has not been registered", func() {
+				BeforeEach(func() {
+					fakeWorker1.RuntimeReturns("some-unknown-runtime")
+				})
+
+				It("does not return the worker", func() {
+					Expect(workersErr).NotTo(HaveOccurred())
+					Expect(workers).To(HaveLen(1))
+					Expect(workers[0].Name()).To(Equal(fakeWorker2.Name()))
+				})
+			})
+
 			Context("when a worker's major version is higher or lower than the atc worker version", func() {
 				BeforeEach(func() {
 					worker1 := new(dbfakes.FakeWorker)
@@ -557,6 +627,18 @@ var _ = Describe("DBProvider", func() {
 					Expect(found).To(BeFalse())
 				})
 			})
+
+			Context("when the worker's runtime has not been registered", func() {
+				BeforeEach(func() {
+					fakeExistingWorker.RuntimeReturns("some-unknown-runtime")
+				})
+
+				It("does not return the worker", func() {
+					Expect(findErr).ToNot(HaveOccurred())
+					Expect(foundWorker).To(BeNil())
+					Expect(found).To(BeFalse())
+				})
+			})
 		})
 
 		Context("when the worker is not found", func() {
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+var ErrInjectedContainerFailure = errors.New("injected fault: failed to create container")
+
+// FailContainerCreations wraps the client such that the given fraction of
+// container creations fail with ErrInjectedContainerFailure. It is only
+// intended to be used in development, to test how the rest of the ATC copes
+// with unreliable workers.
+func FailContainerCreations(client Client, rate float64) Client {
+	return &failingClient{
+		Client: client,
+		rate:   rate,
+	}
+}
+
+type failingClient struct {
+	Client
+
+	rate float64
+}
+
+func (client *failingClient) FindOrCreateContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate ImageFetchingDelegate,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
+) (Container, error) {
+	if rand.Float64() < client.rate {
+		logger.Info("injecting-container-failure")
+		return nil, ErrInjectedContainerFailure
+	}
+
+	return client.Client.FindOrCreateContainer(ctx, logger, delegate, owner, metadata, spec, resourceTypes)
+}
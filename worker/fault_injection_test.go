@@ -0,0 +1,69 @@
+package worker_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FailContainerCreations", func() {
+	var (
+		fakeClient    *workerfakes.FakeClient
+		fakeContainer *workerfakes.FakeContainer
+		client        Client
+		rate          float64
+
+		container Container
+		createErr error
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(workerfakes.FakeClient)
+		fakeContainer = new(workerfakes.FakeContainer)
+		fakeClient.FindOrCreateContainerReturns(fakeContainer, nil)
+	})
+
+	JustBeforeEach(func() {
+		client = FailContainerCreations(fakeClient, rate)
+		container, createErr = client.FindOrCreateContainer(
+			context.Background(),
+			lagertest.NewTestLogger("test"),
+			new(workerfakes.FakeImageFetchingDelegate),
+			new(dbfakes.FakeContainerOwner),
+			db.ContainerMetadata{},
+			ContainerSpec{},
+			creds.VersionedResourceTypes{},
+		)
+	})
+
+	Context("when the failure rate is 0", func() {
+		BeforeEach(func() {
+			rate = 0
+		})
+
+		It("creates the container", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(container).To(Equal(fakeContainer))
+			Expect(fakeClient.FindOrCreateContainerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the failure rate is 1", func() {
+		BeforeEach(func() {
+			rate = 1
+		})
+
+		It("fails without creating the container", func() {
+			Expect(createErr).To(Equal(ErrInjectedContainerFailure))
+			Expect(fakeClient.FindOrCreateContainerCallCount()).To(BeZero())
+		})
+	})
+})
@@ -32,6 +32,7 @@ func (f *imageFactory) GetImage(
 	teamID int,
 	delegate worker.ImageFetchingDelegate,
 	resourceTypes creds.VersionedResourceTypes,
+	isolationLabels worker.IsolationLabels,
 ) (worker.Image, error) {
 	if imageSpec.ImageArtifactSource != nil {
 		artifactVolume, existsOnWorker, err := imageSpec.ImageArtifactSource.VolumeOn(workerClient)
@@ -71,6 +72,7 @@ func (f *imageFactory) GetImage(
 			teamID,
 			resourceTypes.Without(imageSpec.ResourceType),
 			delegate,
+			isolationLabels,
 		)
 
 		return &imageFromResource{
@@ -96,6 +98,7 @@ func (f *imageFactory) GetImage(
 			teamID,
 			resourceTypes,
 			delegate,
+			isolationLabels,
 		)
 
 		return &imageFromResource{
@@ -36,6 +36,7 @@ type ImageResourceFetcherFactory interface {
 		int,
 		creds.VersionedResourceTypes,
 		worker.ImageFetchingDelegate,
+		worker.IsolationLabels,
 	) ImageResourceFetcher
 }
 
@@ -79,6 +80,7 @@ func (f *imageResourceFetcherFactory) NewImageResourceFetcher(
 	teamID int,
 	customTypes creds.VersionedResourceTypes,
 	imageFetchingDelegate worker.ImageFetchingDelegate,
+	isolationLabels worker.IsolationLabels,
 ) ImageResourceFetcher {
 	return &imageResourceFetcher{
 		resourceFetcher:         f.resourceFetcherFactory.FetcherFor(worker),
@@ -93,6 +95,7 @@ func (f *imageResourceFetcherFactory) NewImageResourceFetcher(
 		teamID:                teamID,
 		customTypes:           customTypes,
 		imageFetchingDelegate: imageFetchingDelegate,
+		isolationLabels:       isolationLabels,
 	}
 }
 
@@ -109,6 +112,7 @@ type imageResourceFetcher struct {
 	teamID                int
 	customTypes           creds.VersionedResourceTypes
 	imageFetchingDelegate worker.ImageFetchingDelegate
+	isolationLabels       worker.IsolationLabels
 	variables             creds.Variables
 }
 
@@ -133,6 +137,8 @@ func (i *imageResourceFetcher) Fetch(
 		return nil, nil, nil, err
 	}
 
+	source = i.customTypes.WithBaseResourceTypeDefaults(i.imageResource.Type, source)
+
 	var params atc.Params
 	if i.imageResource.Params != nil {
 		params = *i.imageResource.Params
@@ -167,10 +173,13 @@ func (i *imageResourceFetcher) Fetch(
 		return nil, nil, nil, err
 	}
 
+	i.imageFetchingDelegate.ImageGetStarted(logger, i.imageResource.Type, version)
+
 	getSess := resource.Session{
 		Metadata: db.ContainerMetadata{
 			Type: db.ContainerTypeGet,
 		},
+		IsolationLabels: i.isolationLabels,
 	}
 
 	versionedSource, err := i.resourceFetcher.Fetch(
@@ -237,8 +246,9 @@ func (i *imageResourceFetcher) ensureVersionOfType(
 			ImageSpec: worker.ImageSpec{
 				ResourceType: resourceType.Name,
 			},
-			Tags:   i.worker.Tags(),
-			TeamID: i.teamID,
+			Tags:            i.worker.Tags(),
+			TeamID:          i.teamID,
+			IsolationLabels: i.isolationLabels,
 		}, i.customTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
@@ -251,6 +261,8 @@ func (i *imageResourceFetcher) ensureVersionOfType(
 		return err
 	}
 
+	source = i.customTypes.Without(resourceType.Name).WithBaseResourceTypeDefaults(resourceType.Type, source)
+
 	versions, err := checkResourceType.Check(context.TODO(), source, nil)
 	if err != nil {
 		return err
@@ -285,8 +297,9 @@ func (i *imageResourceFetcher) getLatestVersion(
 		ImageSpec: worker.ImageSpec{
 			ResourceType: i.imageResource.Type,
 		},
-		Tags:   i.worker.Tags(),
-		TeamID: i.teamID,
+		Tags:            i.worker.Tags(),
+		TeamID:          i.teamID,
+		IsolationLabels: i.isolationLabels,
 	}
 
 	source, err := i.imageResource.Source.Evaluate()
@@ -294,6 +307,8 @@ func (i *imageResourceFetcher) getLatestVersion(
 		return nil, err
 	}
 
+	source = i.customTypes.WithBaseResourceTypeDefaults(i.imageResource.Type, source)
+
 	checkingResource, err := i.resourceFactory.NewResource(
 		ctx,
 		logger,
@@ -309,6 +324,8 @@ func (i *imageResourceFetcher) getLatestVersion(
 		return nil, err
 	}
 
+	i.imageFetchingDelegate.ImageCheckStarted(logger, i.imageResource.Type)
+
 	versions, err := checkingResource.Check(context.TODO(), source, nil)
 	if err != nil {
 		return nil, err
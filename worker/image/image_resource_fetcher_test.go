@@ -57,6 +57,7 @@ var _ = Describe("Image", func() {
 	var fetchedVersion atc.Version
 	var fetchErr error
 	var teamID int
+	var isolationLabels worker.IsolationLabels
 	var variables template.StaticVariables
 
 	BeforeEach(func() {
@@ -88,6 +89,7 @@ var _ = Describe("Image", func() {
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeWorker.TagsReturns(atc.Tags{"worker", "tags"})
 		teamID = 123
+		isolationLabels = worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"}
 
 		customTypes = creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 			{
@@ -125,6 +127,7 @@ var _ = Describe("Image", func() {
 			teamID,
 			customTypes,
 			fakeImageFetchingDelegate,
+			isolationLabels,
 		)
 
 		fetchedVolume, fetchedMetadataReader, fetchedVersion, fetchErr = imageResourceFetcher.Fetch(
@@ -194,6 +197,7 @@ var _ = Describe("Image", func() {
 						By("using the resource factory to find or create a resource container")
 						_, _, _, _, containerSpec, _, _ := fakeResourceFactory.NewResourceArgsForCall(0)
 						Expect(containerSpec.ImageSpec.ResourceType).To(Equal("custom-type-a"))
+						Expect(containerSpec.IsolationLabels).To(Equal(isolationLabels))
 
 						By("calling the resource type's check script")
 						Expect(fakeCheckResourceType.CheckCallCount()).To(Equal(1))
@@ -288,8 +292,9 @@ var _ = Describe("Image", func() {
 										ImageSpec: worker.ImageSpec{
 											ResourceType: "docker",
 										},
-										Tags:   []string{"worker", "tags"},
-										TeamID: 123,
+										Tags:            []string{"worker", "tags"},
+										TeamID:          123,
+										IsolationLabels: isolationLabels,
 									}))
 									Expect(actualCustomTypes).To(Equal(customTypes))
 									Expect(delegate).To(Equal(fakeImageFetchingDelegate))
@@ -329,8 +334,9 @@ var _ = Describe("Image", func() {
 									ImageSpec: worker.ImageSpec{
 										ResourceType: "docker",
 									},
-									Tags:   []string{"worker", "tags"},
-									TeamID: 123,
+									Tags:            []string{"worker", "tags"},
+									TeamID:          123,
+									IsolationLabels: isolationLabels,
 								}))
 								Expect(actualCustomTypes).To(Equal(customTypes))
 								Expect(delegate).To(Equal(fakeImageFetchingDelegate))
@@ -343,6 +349,19 @@ var _ = Describe("Image", func() {
 								Expect(checkSource).To(Equal(atc.Source{"some": "super-secret-sauce"}))
 							})
 
+							It("emits an image-check event before checking", func() {
+								Expect(fakeImageFetchingDelegate.ImageCheckStartedCallCount()).To(Equal(1))
+								_, resourceType := fakeImageFetchingDelegate.ImageCheckStartedArgsForCall(0)
+								Expect(resourceType).To(Equal("docker"))
+							})
+
+							It("emits an image-get event with the version before fetching", func() {
+								Expect(fakeImageFetchingDelegate.ImageGetStartedCallCount()).To(Equal(1))
+								_, resourceType, version := fakeImageFetchingDelegate.ImageGetStartedArgsForCall(0)
+								Expect(resourceType).To(Equal("docker"))
+								Expect(version).To(Equal(atc.Version{"v": "1"}))
+							})
+
 							It("saved the image resource version in the database", func() {
 								Expect(fakeImageFetchingDelegate.ImageVersionDeterminedCallCount()).To(Equal(1))
 								Expect(fakeImageFetchingDelegate.ImageVersionDeterminedArgsForCall(0)).To(Equal(fakeUsedResourceCache))
@@ -356,6 +375,7 @@ var _ = Describe("Image", func() {
 									Metadata: db.ContainerMetadata{
 										Type: db.ContainerTypeGet,
 									},
+									IsolationLabels: isolationLabels,
 								}))
 								Expect(tags).To(Equal(atc.Tags{"worker", "tags"}))
 								Expect(actualTeamID).To(Equal(teamID))
@@ -559,6 +579,16 @@ var _ = Describe("Image", func() {
 						Expect(fetchedVersion).To(Equal(atc.Version{"some": "version"}))
 					})
 
+					It("does not emit an image-check event", func() {
+						Expect(fakeImageFetchingDelegate.ImageCheckStartedCallCount()).To(Equal(0))
+					})
+
+					It("emits an image-get event with the version", func() {
+						Expect(fakeImageFetchingDelegate.ImageGetStartedCallCount()).To(Equal(1))
+						_, _, version := fakeImageFetchingDelegate.ImageGetStartedArgsForCall(0)
+						Expect(version).To(Equal(atc.Version{"some": "version"}))
+					})
+
 					It("saved the image resource version in the database", func() {
 						Expect(fakeImageFetchingDelegate.ImageVersionDeterminedCallCount()).To(Equal(1))
 						Expect(fakeImageFetchingDelegate.ImageVersionDeterminedArgsForCall(0)).To(Equal(fakeUsedResourceCache))
@@ -572,6 +602,7 @@ var _ = Describe("Image", func() {
 							Metadata: db.ContainerMetadata{
 								Type: db.ContainerTypeGet,
 							},
+							IsolationLabels: isolationLabels,
 						}))
 						Expect(tags).To(Equal(atc.Tags{"worker", "tags"}))
 						Expect(actualTeamID).To(Equal(teamID))
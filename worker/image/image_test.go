@@ -90,6 +90,7 @@ var _ = Describe("Image", func() {
 				42,
 				fakeImageFetchingDelegate,
 				creds.VersionedResourceTypes{},
+				worker.IsolationLabels{},
 			)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -157,6 +158,7 @@ var _ = Describe("Image", func() {
 				42,
 				fakeImageFetchingDelegate,
 				creds.VersionedResourceTypes{},
+				worker.IsolationLabels{},
 			)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -246,12 +248,13 @@ var _ = Describe("Image", func() {
 					42,
 					fakeImageFetchingDelegate,
 					creds.VersionedResourceTypes{},
+					worker.IsolationLabels{Team: "some-team", Pipeline: "some-pipeline"},
 				)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("fetches image without custom resource type", func() {
-				worker, _, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, _, imageResource, version, teamID, resourceTypes, delegate, isolationLabels := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-image-resource-type"))
 				Expect(imageResource.Source).To(Equal(creds.NewSource(variables, atc.Source{"some": "source"})))
@@ -259,6 +262,8 @@ var _ = Describe("Image", func() {
 				Expect(teamID).To(Equal(42))
 				Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
 				Expect(delegate).To(Equal(fakeImageFetchingDelegate))
+				Expect(isolationLabels.Team).To(Equal("some-team"))
+				Expect(isolationLabels.Pipeline).To(Equal("some-pipeline"))
 			})
 
 			It("finds or creates cow volume", func() {
@@ -327,12 +332,13 @@ var _ = Describe("Image", func() {
 							Version: atc.Version{"some": "custom-image-resource-type-version"},
 						},
 					}),
+					worker.IsolationLabels{},
 				)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("fetches unprivileged image without custom resource type", func() {
-				worker, _, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, _, imageResource, version, teamID, resourceTypes, delegate, _ := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-base-resource-type"))
 				Expect(imageResource.Source).To(Equal(creds.NewSource(variables, atc.Source{
@@ -422,12 +428,13 @@ var _ = Describe("Image", func() {
 							Version: atc.Version{"some": "custom-image-resource-type-version"},
 						},
 					}),
+					worker.IsolationLabels{},
 				)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("fetches image without custom resource type", func() {
-				worker, _, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, _, imageResource, version, teamID, resourceTypes, delegate, _ := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-base-image-resource-type"))
 				Expect(imageResource.Source).To(Equal(creds.NewSource(variables, atc.Source{
@@ -522,6 +529,7 @@ var _ = Describe("Image", func() {
 				42,
 				fakeImageFetchingDelegate,
 				creds.VersionedResourceTypes{},
+				worker.IsolationLabels{},
 			)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -631,6 +639,7 @@ var _ = Describe("Image", func() {
 				42,
 				fakeImageFetchingDelegate,
 				creds.VersionedResourceTypes{},
+				worker.IsolationLabels{},
 			)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -12,7 +12,7 @@ import (
 )
 
 type FakeImageResourceFetcherFactory struct {
-	NewImageResourceFetcherStub        func(worker.Worker, resource.ResourceFactory, worker.ImageResource, atc.Version, int, creds.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher
+	NewImageResourceFetcherStub        func(worker.Worker, resource.ResourceFactory, worker.ImageResource, atc.Version, int, creds.VersionedResourceTypes, worker.ImageFetchingDelegate, worker.IsolationLabels) image.ImageResourceFetcher
 	newImageResourceFetcherMutex       sync.RWMutex
 	newImageResourceFetcherArgsForCall []struct {
 		arg1 worker.Worker
@@ -22,6 +22,7 @@ type FakeImageResourceFetcherFactory struct {
 		arg5 int
 		arg6 creds.VersionedResourceTypes
 		arg7 worker.ImageFetchingDelegate
+		arg8 worker.IsolationLabels
 	}
 	newImageResourceFetcherReturns struct {
 		result1 image.ImageResourceFetcher
@@ -33,7 +34,7 @@ type FakeImageResourceFetcherFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker.Worker, arg2 resource.ResourceFactory, arg3 worker.ImageResource, arg4 atc.Version, arg5 int, arg6 creds.VersionedResourceTypes, arg7 worker.ImageFetchingDelegate) image.ImageResourceFetcher {
+func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker.Worker, arg2 resource.ResourceFactory, arg3 worker.ImageResource, arg4 atc.Version, arg5 int, arg6 creds.VersionedResourceTypes, arg7 worker.ImageFetchingDelegate, arg8 worker.IsolationLabels) image.ImageResourceFetcher {
 	fake.newImageResourceFetcherMutex.Lock()
 	ret, specificReturn := fake.newImageResourceFetcherReturnsOnCall[len(fake.newImageResourceFetcherArgsForCall)]
 	fake.newImageResourceFetcherArgsForCall = append(fake.newImageResourceFetcherArgsForCall, struct {
@@ -44,11 +45,12 @@ func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker
 		arg5 int
 		arg6 creds.VersionedResourceTypes
 		arg7 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
-	fake.recordInvocation("NewImageResourceFetcher", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg8 worker.IsolationLabels
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.recordInvocation("NewImageResourceFetcher", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.newImageResourceFetcherMutex.Unlock()
 	if fake.NewImageResourceFetcherStub != nil {
-		return fake.NewImageResourceFetcherStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return fake.NewImageResourceFetcherStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	}
 	if specificReturn {
 		return ret.result1
@@ -62,10 +64,10 @@ func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherCallCount()
 	return len(fake.newImageResourceFetcherArgsForCall)
 }
 
-func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherArgsForCall(i int) (worker.Worker, resource.ResourceFactory, worker.ImageResource, atc.Version, int, creds.VersionedResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherArgsForCall(i int) (worker.Worker, resource.ResourceFactory, worker.ImageResource, atc.Version, int, creds.VersionedResourceTypes, worker.ImageFetchingDelegate, worker.IsolationLabels) {
 	fake.newImageResourceFetcherMutex.RLock()
 	defer fake.newImageResourceFetcherMutex.RUnlock()
-	return fake.newImageResourceFetcherArgsForCall[i].arg1, fake.newImageResourceFetcherArgsForCall[i].arg2, fake.newImageResourceFetcherArgsForCall[i].arg3, fake.newImageResourceFetcherArgsForCall[i].arg4, fake.newImageResourceFetcherArgsForCall[i].arg5, fake.newImageResourceFetcherArgsForCall[i].arg6, fake.newImageResourceFetcherArgsForCall[i].arg7
+	return fake.newImageResourceFetcherArgsForCall[i].arg1, fake.newImageResourceFetcherArgsForCall[i].arg2, fake.newImageResourceFetcherArgsForCall[i].arg3, fake.newImageResourceFetcherArgsForCall[i].arg4, fake.newImageResourceFetcherArgsForCall[i].arg5, fake.newImageResourceFetcherArgsForCall[i].arg6, fake.newImageResourceFetcherArgsForCall[i].arg7, fake.newImageResourceFetcherArgsForCall[i].arg8
 }
 
 func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherReturns(result1 image.ImageResourceFetcher) {
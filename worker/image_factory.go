@@ -22,6 +22,7 @@ type ImageFactory interface {
 		teamID int,
 		delegate ImageFetchingDelegate,
 		resourceTypes creds.VersionedResourceTypes,
+		isolationLabels IsolationLabels,
 	) (Image, error)
 }
 
@@ -47,6 +48,8 @@ type Image interface {
 type ImageFetchingDelegate interface {
 	Stdout() io.Writer
 	Stderr() io.Writer
+	ImageCheckStarted(lager.Logger, string)
+	ImageGetStarted(lager.Logger, string, atc.Version)
 	ImageVersionDetermined(db.UsedResourceCache) error
 }
 
@@ -59,4 +62,6 @@ type NoopImageFetchingDelegate struct{}
 
 func (NoopImageFetchingDelegate) Stdout() io.Writer                                 { return ioutil.Discard }
 func (NoopImageFetchingDelegate) Stderr() io.Writer                                 { return ioutil.Discard }
+func (NoopImageFetchingDelegate) ImageCheckStarted(lager.Logger, string)            {}
+func (NoopImageFetchingDelegate) ImageGetStarted(lager.Logger, string, atc.Version) {}
 func (NoopImageFetchingDelegate) ImageVersionDetermined(db.UsedResourceCache) error { return nil }
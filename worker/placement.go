@@ -61,3 +61,82 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+// workers with less free memory or disk than these are only chosen if every
+// candidate is short on it
+const minFreeMemory = 256 * 1024 * 1024
+const minFreeDisk = 1024 * 1024 * 1024
+
+type LeastLoadedPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewLeastLoadedPlacementStrategy() ContainerPlacementStrategy {
+	return &LeastLoadedPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Choose picks a worker at random, weighted heavily towards those with the
+// least CPU load. It doesn't always pick the least loaded one, as every ATC
+// would then pile containers onto it until its next heartbeat.
+//
+// Workers which don't report their load are weighted as if they had the
+// average load of those which do.
+func (strategy *LeastLoadedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates := []Worker{}
+	for _, w := range workers {
+		load := w.Load()
+		if load != nil && (load.FreeMemory < minFreeMemory || load.FreeDisk < minFreeDisk) {
+			continue
+		}
+
+		candidates = append(candidates, w)
+	}
+
+	if len(candidates) == 0 {
+		candidates = workers
+	}
+
+	var reported int
+	var totalLoad float64
+	for _, w := range candidates {
+		if load := w.Load(); load != nil {
+			totalLoad += load.CPULoad
+			reported++
+		}
+	}
+
+	var averageLoad float64
+	if reported > 0 {
+		averageLoad = totalLoad / float64(reported)
+	}
+
+	var totalWeight float64
+	weights := make([]float64, len(candidates))
+	for i, w := range candidates {
+		cpuLoad := averageLoad
+		if load := w.Load(); load != nil {
+			cpuLoad = load.CPULoad
+		}
+
+		if cpuLoad < 0 {
+			cpuLoad = 0
+		}
+
+		// squared, so that saturated workers are rarely chosen
+		weights[i] = 1 / ((1 + cpuLoad) * (1 + cpuLoad))
+		totalWeight += weights[i]
+	}
+
+	choice := strategy.rand.Float64() * totalWeight
+	for i, weight := range weights {
+		if choice < weight {
+			return candidates[i], nil
+		}
+
+		choice -= weight
+	}
+
+	return candidates[len(candidates)-1], nil
+}
@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
@@ -208,3 +209,102 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("LeastLoadedPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			idleWorker       *workerfakes.FakeWorker
+			saturatedWorker  *workerfakes.FakeWorker
+			unreportedWorker *workerfakes.FakeWorker
+		)
+
+		plenty := atc.WorkerLoad{
+			FreeMemory: 8 * 1024 * 1024 * 1024,
+			FreeDisk:   100 * 1024 * 1024 * 1024,
+		}
+
+		withCPULoad := func(load atc.WorkerLoad, cpuLoad float64) *atc.WorkerLoad {
+			load.CPULoad = cpuLoad
+			return &load
+		}
+
+		choiceCounts := func() map[Worker]int {
+			workerChoiceCounts := map[Worker]int{}
+
+			for i := 0; i < 1000; i++ {
+				worker, err := strategy.Choose(
+					workers,
+					spec,
+				)
+				Expect(err).ToNot(HaveOccurred())
+				workerChoiceCounts[worker]++
+			}
+
+			return workerChoiceCounts
+		}
+
+		BeforeEach(func() {
+			strategy = NewLeastLoadedPlacementStrategy()
+
+			idleWorker = new(workerfakes.FakeWorker)
+			idleWorker.LoadReturns(withCPULoad(plenty, 0.1))
+
+			saturatedWorker = new(workerfakes.FakeWorker)
+			saturatedWorker.LoadReturns(withCPULoad(plenty, 4))
+
+			unreportedWorker = new(workerfakes.FakeWorker)
+		})
+
+		Context("with workers under different loads", func() {
+			BeforeEach(func() {
+				workers = []Worker{idleWorker, saturatedWorker}
+			})
+
+			It("mostly chooses the least loaded one", func() {
+				counts := choiceCounts()
+				Expect(counts[idleWorker]).To(BeNumerically(">", 800))
+				Expect(counts[saturatedWorker]).To(BeNumerically(">", 0))
+			})
+		})
+
+		Context("with a worker short on memory", func() {
+			var shortWorker *workerfakes.FakeWorker
+
+			BeforeEach(func() {
+				load := withCPULoad(plenty, 0)
+				load.FreeMemory = 10 * 1024 * 1024
+
+				shortWorker = new(workerfakes.FakeWorker)
+				shortWorker.LoadReturns(load)
+
+				workers = []Worker{shortWorker, saturatedWorker}
+			})
+
+			It("avoids it", func() {
+				Expect(choiceCounts()[shortWorker]).To(BeZero())
+			})
+
+			Context("when every worker is short", func() {
+				BeforeEach(func() {
+					workers = []Worker{shortWorker}
+				})
+
+				It("chooses it anyway", func() {
+					Expect(choiceCounts()[shortWorker]).To(Equal(1000))
+				})
+			})
+		})
+
+		Context("with a worker which doesn't report its load", func() {
+			BeforeEach(func() {
+				workers = []Worker{unreportedWorker, saturatedWorker}
+			})
+
+			It("treats it as having the average load", func() {
+				counts := choiceCounts()
+				Expect(counts[unreportedWorker]).To(BeNumerically(">", 300))
+				Expect(counts[saturatedWorker]).To(BeNumerically(">", 300))
+			})
+		})
+	})
+})
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
-	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
@@ -194,8 +193,8 @@ func (*pool) LookupVolume(lager.Logger, string) (Volume, bool, error) {
 	return nil, false, errors.New("LookupVolume not implemented for pool")
 }
 
-func (*pool) GardenClient() garden.Client {
-	panic("GardenClient not implemented for pool")
+func (*pool) Runtime() Runtime {
+	panic("Runtime not implemented for pool")
 }
 
 func resourcesDir(suffix string) string {
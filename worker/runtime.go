@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"code.cloudfoundry.org/garden"
+	gclient "code.cloudfoundry.org/garden/client"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp"
+)
+
+// GardenRuntime is the runtime of workers which register without naming one.
+const GardenRuntime = "garden"
+
+//go:generate counterfeiter . Runtime
+
+// Runtime is the part of a worker's container runtime used by the ATC:
+// creating, looking up, and destroying containers. Processes are run and
+// attached to, and properties are read and written, through the containers
+// it returns.
+//
+// Garden's client is a Runtime. Workers running anything else, such as
+// containerd, are reached through the RuntimeFactory registered for the
+// runtime they register with.
+type Runtime interface {
+	Create(garden.ContainerSpec) (garden.Container, error)
+	Lookup(handle string) (garden.Container, error)
+	Destroy(handle string) error
+}
+
+//go:generate counterfeiter . RuntimeFactory
+
+// RuntimeFactory connects to the runtime of a registered worker. Connections
+// should go through the worker's registered address, which for workers
+// forwarded through the TSA changes as they re-register.
+type RuntimeFactory interface {
+	NewRuntime(
+		logger lager.Logger,
+		db transport.TransportDB,
+		worker db.Worker,
+		retryBackOffFactory retryhttp.BackOffFactory,
+	) Runtime
+}
+
+var runtimeFactories = map[string]RuntimeFactory{
+	GardenRuntime: gardenRuntimeFactory{},
+}
+
+// RegisterRuntime makes workers registering with the named runtime usable.
+func RegisterRuntime(name string, runtimeFactory RuntimeFactory) {
+	runtimeFactories[name] = runtimeFactory
+}
+
+func RuntimeFactories() map[string]RuntimeFactory {
+	return runtimeFactories
+}
+
+func runtimeName(worker db.Worker) string {
+	if worker.Runtime() == "" {
+		return GardenRuntime
+	}
+
+	return worker.Runtime()
+}
+
+type gardenRuntimeFactory struct{}
+
+func (gardenRuntimeFactory) NewRuntime(
+	logger lager.Logger,
+	db transport.TransportDB,
+	worker db.Worker,
+	retryBackOffFactory retryhttp.BackOffFactory,
+) Runtime {
+	gcf := NewGardenConnectionFactory(
+		db,
+		logger.Session("garden-connection"),
+		worker.Name(),
+		worker.GardenAddr(),
+		retryBackOffFactory,
+	)
+
+	return gclient.New(NewRetryableConnection(gcf.BuildConnection()))
+}
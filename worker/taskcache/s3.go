@@ -0,0 +1,146 @@
+package taskcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Config configures snapshotting task caches to an S3-compatible object
+// store. Snapshots are disabled unless a bucket is given.
+type S3Config struct {
+	Bucket          string        `long:"bucket"            description:"Bucket to store snapshots of task caches in. Caches opt in with 'snapshot: true'."`
+	Prefix          string        `long:"prefix"            description:"Prefix for the names of snapshot objects, e.g. 'concourse/'."`
+	Endpoint        string        `long:"endpoint"          description:"Endpoint of an S3-compatible object store. Defaults to AWS S3."`
+	Region          string        `long:"region"            default:"us-east-1" description:"Region of the bucket."`
+	AccessKeyID     string        `long:"access-key-id"     description:"Access key ID. Defaults to the AWS credential chain."`
+	SecretAccessKey string        `long:"secret-access-key" description:"Secret access key."`
+	PathStyle       bool          `long:"path-style"        description:"Address the bucket as part of the path rather than the host name, as most S3-compatible stores expect."`
+	Interval        time.Duration `long:"interval"          default:"1h" description:"Minimum time between uploading snapshots of each cache."`
+}
+
+func (config S3Config) IsConfigured() bool {
+	return config.Bucket != ""
+}
+
+// NewStore returns the store for the config, or nil if it is not
+// configured.
+func (config S3Config) NewStore(logger lager.Logger) (Store, error) {
+	if !config.IsConfigured() {
+		return nil, nil
+	}
+
+	if config.AccessKeyID != "" && config.SecretAccessKey == "" {
+		return nil, errors.New("task cache snapshots: secret access key must be given along with the access key ID")
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(config.Region),
+		S3ForcePathStyle: aws.Bool(config.PathStyle),
+	}
+
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+
+	if config.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("failed-to-create-aws-session", err)
+		return nil, err
+	}
+
+	return &s3Store{
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+		bucket:   config.Bucket,
+		prefix:   config.Prefix,
+		interval: config.Interval,
+		clock:    clock.NewClock(),
+	}, nil
+}
+
+type s3Store struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+	interval time.Duration
+	clock    clock.Clock
+}
+
+func (store *s3Store) Fetch(logger lager.Logger, key Key) (io.ReadCloser, bool, error) {
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.objectKey(key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	logger.Debug("fetching-snapshot", lager.Data{
+		"key":           store.objectKey(key),
+		"last-modified": aws.TimeValue(output.LastModified),
+	})
+
+	return output.Body, true, nil
+}
+
+func (store *s3Store) SnapshotDue(ctx context.Context, logger lager.Logger, key Key) (bool, error) {
+	output, err := store.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.objectKey(key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return store.clock.Since(aws.TimeValue(output.LastModified)) >= store.interval, nil
+}
+
+func (store *s3Store) Save(ctx context.Context, logger lager.Logger, key Key, snapshot io.Reader) error {
+	_, err := store.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.objectKey(key)),
+		Body:   snapshot,
+	})
+	return err
+}
+
+func (store *s3Store) objectKey(key Key) string {
+	return store.prefix + key.String()
+}
+
+func isNotFound(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == http.StatusNotFound
+	}
+
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey
+	}
+
+	return false
+}
@@ -0,0 +1,177 @@
+package taskcache_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/worker/taskcache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("S3 store", func() {
+	const objectPath = "/some-bucket/some-prefix/jobs/42/steps/some-task/caches/some/path.tgz"
+
+	var (
+		logger *lagertest.TestLogger
+		server *ghttp.Server
+		key    taskcache.Key
+
+		store taskcache.Store
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		server = ghttp.NewServer()
+
+		key = taskcache.Key{
+			JobID:    42,
+			StepName: "some-task",
+			Path:     "./some/path/",
+		}
+
+		var err error
+		store, err = taskcache.S3Config{
+			Bucket:          "some-bucket",
+			Prefix:          "some-prefix/",
+			Endpoint:        server.URL(),
+			Region:          "us-east-1",
+			AccessKeyID:     "some-access-key-id",
+			SecretAccessKey: "some-secret-access-key",
+			PathStyle:       true,
+			Interval:        time.Hour,
+		}.NewStore(logger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	lastModified := func(age time.Duration) http.Header {
+		return http.Header{
+			"Last-Modified": {time.Now().Add(-age).UTC().Format(http.TimeFormat)},
+		}
+	}
+
+	Describe("Fetch", func() {
+		Context("when there is a snapshot", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", objectPath),
+					ghttp.RespondWith(http.StatusOK, "some-snapshot", lastModified(time.Minute)),
+				))
+			})
+
+			It("returns it", func() {
+				snapshot, found, err := store.Fetch(logger, key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				defer snapshot.Close()
+				Expect(ioutil.ReadAll(snapshot)).To(Equal([]byte("some-snapshot")))
+			})
+		})
+
+		Context("when there is no snapshot", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", objectPath),
+					ghttp.RespondWith(http.StatusNotFound, `<Error><Code>NoSuchKey</Code></Error>`),
+				))
+			})
+
+			It("returns not found", func() {
+				_, found, err := store.Fetch(logger, key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the bucket is not accessible", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", objectPath),
+					ghttp.RespondWith(http.StatusForbidden, `<Error><Code>AccessDenied</Code></Error>`),
+				))
+			})
+
+			It("returns an error", func() {
+				_, _, err := store.Fetch(logger, key)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("SnapshotDue", func() {
+		Context("when there is no snapshot", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", objectPath),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				))
+			})
+
+			It("is due", func() {
+				Expect(store.SnapshotDue(context.Background(), logger, key)).To(BeTrue())
+			})
+		})
+
+		Context("when the snapshot is older than the interval", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", objectPath),
+					ghttp.RespondWith(http.StatusOK, nil, lastModified(2*time.Hour)),
+				))
+			})
+
+			It("is due", func() {
+				Expect(store.SnapshotDue(context.Background(), logger, key)).To(BeTrue())
+			})
+		})
+
+		Context("when the snapshot is newer than the interval", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", objectPath),
+					ghttp.RespondWith(http.StatusOK, nil, lastModified(time.Minute)),
+				))
+			})
+
+			It("is not due", func() {
+				Expect(store.SnapshotDue(context.Background(), logger, key)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Save", func() {
+		It("uploads the snapshot", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", objectPath),
+				ghttp.VerifyBody([]byte("some-snapshot")),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			err := store.Save(context.Background(), logger, key, strings.NewReader("some-snapshot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the context is cancelled", func() {
+			It("does not upload the snapshot", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				err := store.Save(ctx, logger, key, strings.NewReader("some-snapshot"))
+				Expect(err).To(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+})
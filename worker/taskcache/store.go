@@ -0,0 +1,50 @@
+package taskcache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Key identifies a task cache independently of the worker it lives on, in
+// the same way as worker_task_caches do.
+type Key struct {
+	JobID    int
+	StepName string
+	Path     string
+}
+
+// String is the object name of the cache's snapshot, relative to the
+// store's prefix.
+func (key Key) String() string {
+	return fmt.Sprintf(
+		"jobs/%d/steps/%s/caches/%s.tgz",
+		key.JobID,
+		key.StepName,
+		strings.Trim(path.Clean("/"+key.Path), "/"),
+	)
+}
+
+//go:generate counterfeiter . Store
+
+// Store holds snapshots of task caches, so that a cache which misses on a
+// worker, e.g. one which has just been deployed, can be warmed up from the
+// last snapshot rather than starting out empty.
+//
+// Snapshots are the tgz streams that volumes are streamed in and out as.
+type Store interface {
+	// Fetch returns the latest snapshot of the cache, if there is one.
+	Fetch(lager.Logger, Key) (io.ReadCloser, bool, error)
+
+	// SnapshotDue returns whether the cache has no snapshot or its latest
+	// snapshot is old enough to be replaced.
+	SnapshotDue(context.Context, lager.Logger, Key) (bool, error)
+
+	// Save uploads a snapshot of the cache, replacing the previous one. The
+	// upload is aborted if the context is cancelled.
+	Save(context.Context, lager.Logger, Key, io.Reader) error
+}
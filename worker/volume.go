@@ -73,7 +73,15 @@ func (v *volume) StreamIn(path string, tarStream io.Reader) error {
 }
 
 func (v *volume) StreamOut(path string) (io.ReadCloser, error) {
-	return v.bcVolume.StreamOut(path)
+	out, err := v.bcVolume.StreamOut(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volumeStream{
+		ReadCloser: out,
+		workerName: v.dbVolume.WorkerName(),
+	}, nil
 }
 
 func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
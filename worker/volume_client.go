@@ -61,6 +61,12 @@ type VolumeClient interface {
 	FindOrCreateVolumeForResourceCerts(
 		logger lager.Logger,
 	) (volume Volume, found bool, err error)
+	FindOrCreateVolumeForBuildInputUpload(
+		logger lager.Logger,
+		volumeSpec VolumeSpec,
+		teamID int,
+		upload *db.BuildInputUpload,
+	) (Volume, error)
 
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 }
@@ -268,6 +274,24 @@ func (c *volumeClient) FindOrCreateVolumeForResourceCerts(logger lager.Logger) (
 	return volume, true, err
 }
 
+func (c *volumeClient) FindOrCreateVolumeForBuildInputUpload(
+	logger lager.Logger,
+	volumeSpec VolumeSpec,
+	teamID int,
+	upload *db.BuildInputUpload,
+) (Volume, error) {
+	return c.findOrCreateVolume(
+		logger.Session("find-or-create-volume-for-build-input-upload"),
+		volumeSpec,
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return c.dbVolumeRepository.FindBuildInputUploadVolume(teamID, upload)
+		},
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeRepository.CreateBuildInputUploadVolume(teamID, upload)
+		},
+	)
+}
+
 func (c *volumeClient) FindVolumeForTaskCache(
 	logger lager.Logger,
 	teamID int,
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+// streams shorter than this are dominated by latency rather than bandwidth,
+// so their throughput says little about the link between the workers
+const minStreamDurationForThroughput = time.Second
+
+// volumeStream is a stream out of a volume which remembers the worker the
+// volume lives on, so that streaming it into another worker can be attributed
+// to the pair of workers.
+type volumeStream struct {
+	io.ReadCloser
+
+	workerName string
+}
+
+// meteredDestination streams into a volume, emitting how many bytes were
+// streamed and how long it took, and warning when the stream ran slower than
+// the minimum throughput.
+type meteredDestination struct {
+	logger        lager.Logger
+	clock         clock.Clock
+	destination   Volume
+	workerName    string
+	minThroughput uint64
+}
+
+func (dest meteredDestination) StreamIn(path string, tarStream io.Reader) error {
+	sourceWorker := "unknown"
+	if stream, ok := tarStream.(*volumeStream); ok {
+		sourceWorker = stream.workerName
+	}
+
+	counter := &countingReader{Reader: tarStream}
+
+	start := dest.clock.Now()
+
+	err := dest.destination.StreamIn(path, counter)
+	if err != nil {
+		return err
+	}
+
+	duration := dest.clock.Since(start)
+	slow := dest.slow(counter.bytes, duration)
+
+	if slow {
+		dest.logger.Info("slow-stream", lager.Data{
+			"source-worker":      sourceWorker,
+			"destination-worker": dest.workerName,
+			"bytes":              counter.bytes,
+			"duration":           duration.String(),
+			"bytes-per-second":   throughput(counter.bytes, duration),
+		})
+	}
+
+	metric.VolumeStreamed{
+		SourceWorker:      sourceWorker,
+		DestinationWorker: dest.workerName,
+		Bytes:             counter.bytes,
+		Duration:          duration,
+		Slow:              slow,
+	}.Emit(dest.logger)
+
+	return nil
+}
+
+func (dest meteredDestination) slow(bytes int64, duration time.Duration) bool {
+	if dest.minThroughput == 0 || duration < minStreamDurationForThroughput {
+		return false
+	}
+
+	return throughput(bytes, duration) < dest.minThroughput
+}
+
+func throughput(bytes int64, duration time.Duration) uint64 {
+	return uint64(float64(bytes) / duration.Seconds())
+}
+
+type countingReader struct {
+	io.Reader
+
+	bytes int64
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+	reader.bytes += int64(n)
+	return n, err
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
-	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
@@ -21,6 +20,7 @@ import (
 var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
+var ErrNetworkNotDenied = errors.New("worker does not deny network access by default")
 var ErrNoVolumeManager = errors.New("worker does not support volume management")
 var ErrTeamMismatch = errors.New("mismatched team")
 var ErrNotImplemented = errors.New("Not implemented")
@@ -39,6 +39,13 @@ const ephemeralPropertyName = "concourse:ephemeral"
 const volumePropertyName = "concourse:volumes"
 const volumeMountsPropertyName = "concourse:volume-mounts"
 const userPropertyName = "user"
+const teamPropertyName = "concourse:team"
+const pipelinePropertyName = "concourse:pipeline"
+const visibilityPropertyName = "concourse:visibility"
+
+// jobs' own container properties are prefixed so they can't be confused with
+// or overwrite the ones set by the ATC
+const jobPropertyPrefix = "concourse:job:"
 const RawRootFSScheme = "raw"
 const ImageMetadataFile = "metadata.json"
 
@@ -49,6 +56,10 @@ type Worker interface {
 
 	ActiveContainers() int
 
+	// Load is the load the worker last reported, or nil if it doesn't report
+	// any.
+	Load() *atc.WorkerLoad
+
 	Description() string
 	Name() string
 	ResourceTypes() []atc.WorkerResourceType
@@ -60,14 +71,15 @@ type Worker interface {
 
 	FindVolumeForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error)
 	FindVolumeForTaskCache(lager.Logger, int, int, string, string) (Volume, bool, error)
+	FindOrCreateVolumeForBuildInputUpload(lager.Logger, VolumeSpec, int, *db.BuildInputUpload) (Volume, error)
 
 	CertsVolume(lager.Logger) (volume Volume, found bool, err error)
 
-	GardenClient() garden.Client
+	Runtime() Runtime
 }
 
 type gardenWorker struct {
-	gardenClient       garden.Client
+	runtime            Runtime
 	baggageclaimClient baggageclaim.Client
 
 	volumeClient      VolumeClient
@@ -76,6 +88,7 @@ type gardenWorker struct {
 	clock clock.Clock
 
 	activeContainers int
+	load             *atc.WorkerLoad
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
@@ -84,10 +97,12 @@ type gardenWorker struct {
 	startTime        int64
 	ephemeral        bool
 	version          *string
+
+	deniesNetworkByDefault bool
 }
 
 func NewGardenWorker(
-	gardenClient garden.Client,
+	runtime Runtime,
 	baggageclaimClient baggageclaim.Client,
 	containerProvider ContainerProvider,
 	volumeClient VolumeClient,
@@ -96,13 +111,14 @@ func NewGardenWorker(
 ) Worker {
 
 	return &gardenWorker{
-		gardenClient:       gardenClient,
+		runtime:            runtime,
 		baggageclaimClient: baggageclaimClient,
 		volumeClient:       volumeClient,
 		containerProvider:  containerProvider,
 
 		clock:            clock,
 		activeContainers: dbWorker.ActiveContainers(),
+		load:             dbWorker.Load(),
 		resourceTypes:    dbWorker.ResourceTypes(),
 		platform:         dbWorker.Platform(),
 		tags:             dbWorker.Tags(),
@@ -111,11 +127,13 @@ func NewGardenWorker(
 		startTime:        dbWorker.StartTime(),
 		version:          dbWorker.Version(),
 		ephemeral:        dbWorker.Ephemeral(),
+
+		deniesNetworkByDefault: dbWorker.DeniesNetworkByDefault(),
 	}
 }
 
-func (worker *gardenWorker) GardenClient() garden.Client {
-	return worker.gardenClient
+func (worker *gardenWorker) Runtime() Runtime {
+	return worker.runtime
 }
 
 func (worker *gardenWorker) IsVersionCompatible(logger lager.Logger, comparedVersion *version.Version) bool {
@@ -171,6 +189,10 @@ func (worker *gardenWorker) FindVolumeForTaskCache(logger lager.Logger, teamID i
 	return worker.volumeClient.FindVolumeForTaskCache(logger, teamID, jobID, stepName, path)
 }
 
+func (worker *gardenWorker) FindOrCreateVolumeForBuildInputUpload(logger lager.Logger, volumeSpec VolumeSpec, teamID int, upload *db.BuildInputUpload) (Volume, error) {
+	return worker.volumeClient.FindOrCreateVolumeForBuildInputUpload(logger, volumeSpec, teamID, upload)
+}
+
 func (worker *gardenWorker) CertsVolume(logger lager.Logger) (Volume, bool, error) {
 	return worker.volumeClient.FindOrCreateVolumeForResourceCerts(logger.Session("find-or-create"))
 }
@@ -208,6 +230,10 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) Load() *atc.WorkerLoad {
+	return worker.load
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes creds.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -239,6 +265,12 @@ func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, res
 		return nil, ErrMismatchedTags
 	}
 
+	// Garden can only add NetOut rules allowing access, so a restricted
+	// network is only enforced where everything else is denied
+	if spec.RestrictedNetwork && !worker.deniesNetworkByDefault {
+		return nil, ErrNetworkNotDenied
+	}
+
 	return worker, nil
 }
 
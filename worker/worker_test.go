@@ -32,6 +32,7 @@ var _ = Describe("Worker", func() {
 		tags                       atc.Tags
 		teamID                     int
 		ephemeral                  bool
+		deniesNetworkByDefault     bool
 		workerName                 string
 		workerStartTime            int64
 		workerUptime               uint64
@@ -57,6 +58,7 @@ var _ = Describe("Worker", func() {
 		tags = atc.Tags{"some", "tags"}
 		teamID = 17
 		ephemeral = true
+		deniesNetworkByDefault = false
 		workerName = "some-worker"
 		workerStartTime = fakeClock.Now().Unix()
 		workerUptime = 0
@@ -74,6 +76,7 @@ var _ = Describe("Worker", func() {
 		dbWorker.PlatformReturns(platform)
 		dbWorker.TagsReturns(tags)
 		dbWorker.EphemeralReturns(ephemeral)
+		dbWorker.DeniesNetworkByDefaultReturns(deniesNetworkByDefault)
 		dbWorker.TeamIDReturns(teamID)
 		dbWorker.NameReturns(workerName)
 		dbWorker.StartTimeReturns(workerStartTime)
@@ -547,5 +550,28 @@ var _ = Describe("Worker", func() {
 				})
 			})
 		})
+
+		Context("when the spec requires a restricted network", func() {
+			BeforeEach(func() {
+				spec.RestrictedNetwork = true
+			})
+
+			Context("when the worker denies network access by default", func() {
+				BeforeEach(func() {
+					deniesNetworkByDefault = true
+				})
+
+				It("returns the worker", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+			})
+
+			Context("when the worker allows network access by default", func() {
+				It("returns ErrNetworkNotDenied", func() {
+					Expect(satisfyingErr).To(Equal(ErrNetworkNotDenied))
+				})
+			})
+		})
 	})
 })
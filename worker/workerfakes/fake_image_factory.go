@@ -10,16 +10,17 @@ import (
 )
 
 type FakeImageFactory struct {
-	GetImageStub        func(logger lager.Logger, workerClient worker.Worker, volumeClient worker.VolumeClient, imageSpec worker.ImageSpec, teamID int, delegate worker.ImageFetchingDelegate, resourceTypes creds.VersionedResourceTypes) (worker.Image, error)
+	GetImageStub        func(logger lager.Logger, workerClient worker.Worker, volumeClient worker.VolumeClient, imageSpec worker.ImageSpec, teamID int, delegate worker.ImageFetchingDelegate, resourceTypes creds.VersionedResourceTypes, isolationLabels worker.IsolationLabels) (worker.Image, error)
 	getImageMutex       sync.RWMutex
 	getImageArgsForCall []struct {
-		logger        lager.Logger
-		workerClient  worker.Worker
-		volumeClient  worker.VolumeClient
-		imageSpec     worker.ImageSpec
-		teamID        int
-		delegate      worker.ImageFetchingDelegate
-		resourceTypes creds.VersionedResourceTypes
+		logger          lager.Logger
+		workerClient    worker.Worker
+		volumeClient    worker.VolumeClient
+		imageSpec       worker.ImageSpec
+		teamID          int
+		delegate        worker.ImageFetchingDelegate
+		resourceTypes   creds.VersionedResourceTypes
+		isolationLabels worker.IsolationLabels
 	}
 	getImageReturns struct {
 		result1 worker.Image
@@ -33,22 +34,23 @@ type FakeImageFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeImageFactory) GetImage(logger lager.Logger, workerClient worker.Worker, volumeClient worker.VolumeClient, imageSpec worker.ImageSpec, teamID int, delegate worker.ImageFetchingDelegate, resourceTypes creds.VersionedResourceTypes) (worker.Image, error) {
+func (fake *FakeImageFactory) GetImage(logger lager.Logger, workerClient worker.Worker, volumeClient worker.VolumeClient, imageSpec worker.ImageSpec, teamID int, delegate worker.ImageFetchingDelegate, resourceTypes creds.VersionedResourceTypes, isolationLabels worker.IsolationLabels) (worker.Image, error) {
 	fake.getImageMutex.Lock()
 	ret, specificReturn := fake.getImageReturnsOnCall[len(fake.getImageArgsForCall)]
 	fake.getImageArgsForCall = append(fake.getImageArgsForCall, struct {
-		logger        lager.Logger
-		workerClient  worker.Worker
-		volumeClient  worker.VolumeClient
-		imageSpec     worker.ImageSpec
-		teamID        int
-		delegate      worker.ImageFetchingDelegate
-		resourceTypes creds.VersionedResourceTypes
-	}{logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes})
-	fake.recordInvocation("GetImage", []interface{}{logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes})
+		logger          lager.Logger
+		workerClient    worker.Worker
+		volumeClient    worker.VolumeClient
+		imageSpec       worker.ImageSpec
+		teamID          int
+		delegate        worker.ImageFetchingDelegate
+		resourceTypes   creds.VersionedResourceTypes
+		isolationLabels worker.IsolationLabels
+	}{logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes, isolationLabels})
+	fake.recordInvocation("GetImage", []interface{}{logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes, isolationLabels})
 	fake.getImageMutex.Unlock()
 	if fake.GetImageStub != nil {
-		return fake.GetImageStub(logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes)
+		return fake.GetImageStub(logger, workerClient, volumeClient, imageSpec, teamID, delegate, resourceTypes, isolationLabels)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -62,10 +64,10 @@ func (fake *FakeImageFactory) GetImageCallCount() int {
 	return len(fake.getImageArgsForCall)
 }
 
-func (fake *FakeImageFactory) GetImageArgsForCall(i int) (lager.Logger, worker.Worker, worker.VolumeClient, worker.ImageSpec, int, worker.ImageFetchingDelegate, creds.VersionedResourceTypes) {
+func (fake *FakeImageFactory) GetImageArgsForCall(i int) (lager.Logger, worker.Worker, worker.VolumeClient, worker.ImageSpec, int, worker.ImageFetchingDelegate, creds.VersionedResourceTypes, worker.IsolationLabels) {
 	fake.getImageMutex.RLock()
 	defer fake.getImageMutex.RUnlock()
-	return fake.getImageArgsForCall[i].logger, fake.getImageArgsForCall[i].workerClient, fake.getImageArgsForCall[i].volumeClient, fake.getImageArgsForCall[i].imageSpec, fake.getImageArgsForCall[i].teamID, fake.getImageArgsForCall[i].delegate, fake.getImageArgsForCall[i].resourceTypes
+	return fake.getImageArgsForCall[i].logger, fake.getImageArgsForCall[i].workerClient, fake.getImageArgsForCall[i].volumeClient, fake.getImageArgsForCall[i].imageSpec, fake.getImageArgsForCall[i].teamID, fake.getImageArgsForCall[i].delegate, fake.getImageArgsForCall[i].resourceTypes, fake.getImageArgsForCall[i].isolationLabels
 }
 
 func (fake *FakeImageFactory) GetImageReturns(result1 worker.Image, result2 error) {
@@ -5,6 +5,8 @@ import (
 	"io"
 	"sync"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
@@ -39,6 +41,19 @@ type FakeImageFetchingDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ImageCheckStartedStub        func(lager.Logger, string)
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	ImageGetStartedStub        func(lager.Logger, string, atc.Version)
+	imageGetStartedMutex       sync.RWMutex
+	imageGetStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -171,6 +186,57 @@ func (fake *FakeImageFetchingDelegate) ImageVersionDeterminedReturnsOnCall(i int
 	}{result1}
 }
 
+func (fake *FakeImageFetchingDelegate) ImageCheckStarted(arg1 lager.Logger, arg2 string) {
+	fake.imageCheckStartedMutex.Lock()
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{arg1, arg2})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		fake.ImageCheckStartedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageCheckStartedArgsForCall(i int) (lager.Logger, string) {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return fake.imageCheckStartedArgsForCall[i].arg1, fake.imageCheckStartedArgsForCall[i].arg2
+}
+
+func (fake *FakeImageFetchingDelegate) ImageGetStarted(arg1 lager.Logger, arg2 string, arg3 atc.Version) {
+	fake.imageGetStartedMutex.Lock()
+	fake.imageGetStartedArgsForCall = append(fake.imageGetStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageGetStarted", []interface{}{arg1, arg2, arg3})
+	fake.imageGetStartedMutex.Unlock()
+	if fake.ImageGetStartedStub != nil {
+		fake.ImageGetStartedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageGetStartedCallCount() int {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return len(fake.imageGetStartedArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageGetStartedArgsForCall(i int) (lager.Logger, string, atc.Version) {
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
+	return fake.imageGetStartedArgsForCall[i].arg1, fake.imageGetStartedArgsForCall[i].arg2, fake.imageGetStartedArgsForCall[i].arg3
+}
+
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -180,6 +246,10 @@ func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{}
 	defer fake.stderrMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageGetStartedMutex.RLock()
+	defer fake.imageGetStartedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -145,6 +145,22 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	FindOrCreateVolumeForBuildInputUploadStub        func(logger lager.Logger, volumeSpec worker.VolumeSpec, teamID int, upload *db.BuildInputUpload) (worker.Volume, error)
+	findOrCreateVolumeForBuildInputUploadMutex       sync.RWMutex
+	findOrCreateVolumeForBuildInputUploadArgsForCall []struct {
+		logger     lager.Logger
+		volumeSpec worker.VolumeSpec
+		teamID     int
+		upload     *db.BuildInputUpload
+	}
+	findOrCreateVolumeForBuildInputUploadReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	findOrCreateVolumeForBuildInputUploadReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -592,6 +608,60 @@ func (fake *FakeVolumeClient) LookupVolumeReturnsOnCall(i int, result1 worker.Vo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBuildInputUpload(logger lager.Logger, volumeSpec worker.VolumeSpec, teamID int, upload *db.BuildInputUpload) (worker.Volume, error) {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.Lock()
+	ret, specificReturn := fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall[len(fake.findOrCreateVolumeForBuildInputUploadArgsForCall)]
+	fake.findOrCreateVolumeForBuildInputUploadArgsForCall = append(fake.findOrCreateVolumeForBuildInputUploadArgsForCall, struct {
+		logger     lager.Logger
+		volumeSpec worker.VolumeSpec
+		teamID     int
+		upload     *db.BuildInputUpload
+	}{logger, volumeSpec, teamID, upload})
+	fake.recordInvocation("FindOrCreateVolumeForBuildInputUpload", []interface{}{logger, volumeSpec, teamID, upload})
+	fake.findOrCreateVolumeForBuildInputUploadMutex.Unlock()
+	if fake.FindOrCreateVolumeForBuildInputUploadStub != nil {
+		return fake.FindOrCreateVolumeForBuildInputUploadStub(logger, volumeSpec, teamID, upload)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findOrCreateVolumeForBuildInputUploadReturns.result1, fake.findOrCreateVolumeForBuildInputUploadReturns.result2
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBuildInputUploadCallCount() int {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
+	return len(fake.findOrCreateVolumeForBuildInputUploadArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBuildInputUploadArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, *db.BuildInputUpload) {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
+	return fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].logger, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].volumeSpec, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].teamID, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].upload
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBuildInputUploadReturns(result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForBuildInputUploadStub = nil
+	fake.findOrCreateVolumeForBuildInputUploadReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBuildInputUploadReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForBuildInputUploadStub = nil
+	if fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall == nil {
+		fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -611,6 +681,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -6,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
@@ -258,14 +257,39 @@ type FakeWorker struct {
 		result2 bool
 		result3 error
 	}
-	GardenClientStub        func() garden.Client
-	gardenClientMutex       sync.RWMutex
-	gardenClientArgsForCall []struct{}
-	gardenClientReturns     struct {
-		result1 garden.Client
+	RuntimeStub        func() worker.Runtime
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct{}
+	runtimeReturns     struct {
+		result1 worker.Runtime
 	}
-	gardenClientReturnsOnCall map[int]struct {
-		result1 garden.Client
+	runtimeReturnsOnCall map[int]struct {
+		result1 worker.Runtime
+	}
+	LoadStub        func() *atc.WorkerLoad
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct{}
+	loadReturns     struct {
+		result1 *atc.WorkerLoad
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 *atc.WorkerLoad
+	}
+	FindOrCreateVolumeForBuildInputUploadStub        func(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 *db.BuildInputUpload) (worker.Volume, error)
+	findOrCreateVolumeForBuildInputUploadMutex       sync.RWMutex
+	findOrCreateVolumeForBuildInputUploadArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 *db.BuildInputUpload
+	}
+	findOrCreateVolumeForBuildInputUploadReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	findOrCreateVolumeForBuildInputUploadReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
@@ -1183,46 +1207,140 @@ func (fake *FakeWorker) CertsVolumeReturnsOnCall(i int, result1 worker.Volume, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeWorker) GardenClient() garden.Client {
-	fake.gardenClientMutex.Lock()
-	ret, specificReturn := fake.gardenClientReturnsOnCall[len(fake.gardenClientArgsForCall)]
-	fake.gardenClientArgsForCall = append(fake.gardenClientArgsForCall, struct{}{})
-	fake.recordInvocation("GardenClient", []interface{}{})
-	fake.gardenClientMutex.Unlock()
-	if fake.GardenClientStub != nil {
-		return fake.GardenClientStub()
+func (fake *FakeWorker) Runtime() worker.Runtime {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct{}{})
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if fake.RuntimeStub != nil {
+		return fake.RuntimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.runtimeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 worker.Runtime) {
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 worker.Runtime
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 worker.Runtime) {
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 worker.Runtime
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 worker.Runtime
+	}{result1}
+}
+
+func (fake *FakeWorker) Load() *atc.WorkerLoad {
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct{}{})
+	fake.recordInvocation("Load", []interface{}{})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.gardenClientReturns.result1
+	return fake.loadReturns.result1
 }
 
-func (fake *FakeWorker) GardenClientCallCount() int {
-	fake.gardenClientMutex.RLock()
-	defer fake.gardenClientMutex.RUnlock()
-	return len(fake.gardenClientArgsForCall)
+func (fake *FakeWorker) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
 }
 
-func (fake *FakeWorker) GardenClientReturns(result1 garden.Client) {
-	fake.GardenClientStub = nil
-	fake.gardenClientReturns = struct {
-		result1 garden.Client
+func (fake *FakeWorker) LoadReturns(result1 *atc.WorkerLoad) {
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 *atc.WorkerLoad
 	}{result1}
 }
 
-func (fake *FakeWorker) GardenClientReturnsOnCall(i int, result1 garden.Client) {
-	fake.GardenClientStub = nil
-	if fake.gardenClientReturnsOnCall == nil {
-		fake.gardenClientReturnsOnCall = make(map[int]struct {
-			result1 garden.Client
+func (fake *FakeWorker) LoadReturnsOnCall(i int, result1 *atc.WorkerLoad) {
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerLoad
 		})
 	}
-	fake.gardenClientReturnsOnCall[i] = struct {
-		result1 garden.Client
+	fake.loadReturnsOnCall[i] = struct {
+		result1 *atc.WorkerLoad
 	}{result1}
 }
 
+func (fake *FakeWorker) FindOrCreateVolumeForBuildInputUpload(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 *db.BuildInputUpload) (worker.Volume, error) {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.Lock()
+	ret, specificReturn := fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall[len(fake.findOrCreateVolumeForBuildInputUploadArgsForCall)]
+	fake.findOrCreateVolumeForBuildInputUploadArgsForCall = append(fake.findOrCreateVolumeForBuildInputUploadArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 *db.BuildInputUpload
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("FindOrCreateVolumeForBuildInputUpload", []interface{}{arg1, arg2, arg3, arg4})
+	fake.findOrCreateVolumeForBuildInputUploadMutex.Unlock()
+	if fake.FindOrCreateVolumeForBuildInputUploadStub != nil {
+		return fake.FindOrCreateVolumeForBuildInputUploadStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findOrCreateVolumeForBuildInputUploadReturns.result1, fake.findOrCreateVolumeForBuildInputUploadReturns.result2
+}
+
+func (fake *FakeWorker) FindOrCreateVolumeForBuildInputUploadCallCount() int {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
+	return len(fake.findOrCreateVolumeForBuildInputUploadArgsForCall)
+}
+
+func (fake *FakeWorker) FindOrCreateVolumeForBuildInputUploadArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, *db.BuildInputUpload) {
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
+	return fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].arg1, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].arg2, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].arg3, fake.findOrCreateVolumeForBuildInputUploadArgsForCall[i].arg4
+}
+
+func (fake *FakeWorker) FindOrCreateVolumeForBuildInputUploadReturns(result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForBuildInputUploadStub = nil
+	fake.findOrCreateVolumeForBuildInputUploadReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) FindOrCreateVolumeForBuildInputUploadReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForBuildInputUploadStub = nil
+	if fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall == nil {
+		fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.findOrCreateVolumeForBuildInputUploadReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1264,8 +1382,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
 	fake.certsVolumeMutex.RLock()
 	defer fake.certsVolumeMutex.RUnlock()
-	fake.gardenClientMutex.RLock()
-	defer fake.gardenClientMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	fake.findOrCreateVolumeForBuildInputUploadMutex.RLock()
+	defer fake.findOrCreateVolumeForBuildInputUploadMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -40,6 +40,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.DownloadCLI,
 			atc.CheckResourceWebHook,
 			atc.GetInfo,
+			atc.GetInfoKeys,
+			atc.GetPipelineConfigSchema,
 			atc.ListTeams,
 			atc.ListAllPipelines,
 			atc.ListPipelines,
@@ -56,12 +58,16 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
-			atc.BuildEvents:
+			atc.BuildEvents,
+			atc.BuildEventsWS,
+			atc.GetBuildBundle:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
 		case atc.AbortBuild,
+			atc.GetBuildArtifact,
 			atc.SendInputToBuildPlan,
+			atc.GetBuildPlanInputProgress,
 			atc.ReadOutputFromBuildPlan:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
@@ -79,10 +85,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.PipelineGraph,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.LatestJobOutputs,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
@@ -107,25 +115,34 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListTeamBuilds,
 			atc.RenameTeam,
 			atc.DestroyTeam,
+			atc.ImportTeam,
 			atc.ListVolumes:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
 			atc.SetLogLevel,
-			atc.GetInfoCreds:
+			atc.GetInfoCreds,
+			atc.ListCaches,
+			atc.DestroyCache:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
-		case atc.CheckResource,
+		case atc.AbortJobBuilds,
+			atc.AbortTeamBuilds,
+			atc.CheckResource,
 			atc.CheckResourceType,
 			atc.CreateJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
+			atc.UndeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.SaveResourceVersions,
 			atc.GetConfig,
+			atc.GetConfigChecksum,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ExplainJobInputs,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
@@ -134,10 +151,20 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.PinResource,
+			atc.UnpinResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
-			atc.ClearTaskCache:
+			atc.DiffConfig,
+			atc.ClearTaskCache,
+			atc.GetTeamSettings,
+			atc.SetTeamSettings,
+			atc.GetTeamUsage,
+			atc.ExportTeam,
+			atc.ListWebhooks,
+			atc.CreateWebhook,
+			atc.DeleteWebhook:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -141,16 +141,18 @@ var _ = Describe("APIAuthWrappa", func() {
 
 			expectedHandlers = rata.Handlers{
 				//unauthenticated / delegating to handler
-				atc.GetInfo:              unauthenticated(inputHandlers[atc.GetInfo]),
-				atc.DownloadCLI:          unauthenticated(inputHandlers[atc.DownloadCLI]),
-				atc.CheckResourceWebHook: unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
-				atc.ListAllPipelines:     unauthenticated(inputHandlers[atc.ListAllPipelines]),
-				atc.ListBuilds:           unauthenticated(inputHandlers[atc.ListBuilds]),
-				atc.ListPipelines:        unauthenticated(inputHandlers[atc.ListPipelines]),
-				atc.ListAllJobs:          unauthenticated(inputHandlers[atc.ListAllJobs]),
-				atc.ListAllResources:     unauthenticated(inputHandlers[atc.ListAllResources]),
-				atc.ListTeams:            unauthenticated(inputHandlers[atc.ListTeams]),
-				atc.MainJobBadge:         unauthenticated(inputHandlers[atc.MainJobBadge]),
+				atc.GetInfo:                 unauthenticated(inputHandlers[atc.GetInfo]),
+				atc.GetInfoKeys:             unauthenticated(inputHandlers[atc.GetInfoKeys]),
+				atc.GetPipelineConfigSchema: unauthenticated(inputHandlers[atc.GetPipelineConfigSchema]),
+				atc.DownloadCLI:             unauthenticated(inputHandlers[atc.DownloadCLI]),
+				atc.CheckResourceWebHook:    unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
+				atc.ListAllPipelines:        unauthenticated(inputHandlers[atc.ListAllPipelines]),
+				atc.ListBuilds:              unauthenticated(inputHandlers[atc.ListBuilds]),
+				atc.ListPipelines:           unauthenticated(inputHandlers[atc.ListPipelines]),
+				atc.ListAllJobs:             unauthenticated(inputHandlers[atc.ListAllJobs]),
+				atc.ListAllResources:        unauthenticated(inputHandlers[atc.ListAllResources]),
+				atc.ListTeams:               unauthenticated(inputHandlers[atc.ListTeams]),
+				atc.MainJobBadge:            unauthenticated(inputHandlers[atc.MainJobBadge]),
 
 				// authorized or public pipeline
 				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
@@ -159,12 +161,16 @@ var _ = Describe("APIAuthWrappa", func() {
 
 				// authorized or public pipeline and public job
 				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.BuildEventsWS:       checksIfPrivateJob(inputHandlers[atc.BuildEventsWS]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildBundle:      checksIfPrivateJob(inputHandlers[atc.GetBuildBundle]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild:              checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
-				atc.SendInputToBuildPlan:    checkWritePermissionForBuild(inputHandlers[atc.SendInputToBuildPlan]),
-				atc.ReadOutputFromBuildPlan: checkWritePermissionForBuild(inputHandlers[atc.ReadOutputFromBuildPlan]),
+				atc.AbortBuild:                checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.SendInputToBuildPlan:      checkWritePermissionForBuild(inputHandlers[atc.SendInputToBuildPlan]),
+				atc.GetBuildPlanInputProgress: checkWritePermissionForBuild(inputHandlers[atc.GetBuildPlanInputProgress]),
+				atc.ReadOutputFromBuildPlan:   checkWritePermissionForBuild(inputHandlers[atc.ReadOutputFromBuildPlan]),
+				atc.GetBuildArtifact:          checkWritePermissionForBuild(inputHandlers[atc.GetBuildArtifact]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -179,10 +185,12 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
 				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
 				atc.PipelineBadge:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineBadge]),
+				atc.PipelineGraph:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineGraph]),
 				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.LatestJobOutputs:              openForPublicPipelineOrAuthorized(inputHandlers[atc.LatestJobOutputs]),
 				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListPipelineBuilds]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
@@ -207,35 +215,54 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
 				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
 				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.ImportTeam:      authenticated(inputHandlers[atc.ImportTeam]),
 
 				// authenticated and is admin
 				atc.GetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 				atc.GetInfoCreds: authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.ListCaches:   authenticatedAndAdmin(inputHandlers[atc.ListCaches]),
+				atc.DestroyCache: authenticatedAndAdmin(inputHandlers[atc.DestroyCache]),
 
 				// authorized (requested team matches resource team)
+				atc.AbortJobBuilds:         authorized(inputHandlers[atc.AbortJobBuilds]),
+				atc.AbortTeamBuilds:        authorized(inputHandlers[atc.AbortTeamBuilds]),
 				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
 				atc.CheckResourceType:      authorized(inputHandlers[atc.CheckResourceType]),
 				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
 				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
+				atc.UndeletePipeline:       authorized(inputHandlers[atc.UndeletePipeline]),
 				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
 				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.SaveResourceVersions:   authorized(inputHandlers[atc.SaveResourceVersions]),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.GetConfigChecksum:      authorized(inputHandlers[atc.GetConfigChecksum]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJobInputs:       authorized(inputHandlers[atc.ExplainJobInputs]),
 				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
 				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
 				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
 				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
 				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
+				atc.DiffConfig:             authorized(inputHandlers[atc.DiffConfig]),
 				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
 				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
 				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
+				atc.PinResource:            authorized(inputHandlers[atc.PinResource]),
+				atc.UnpinResource:          authorized(inputHandlers[atc.UnpinResource]),
 				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
 				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
 				atc.CreatePipelineBuild:    authorized(inputHandlers[atc.CreatePipelineBuild]),
 				atc.ClearTaskCache:         authorized(inputHandlers[atc.ClearTaskCache]),
+				atc.GetTeamSettings:        authorized(inputHandlers[atc.GetTeamSettings]),
+				atc.SetTeamSettings:        authorized(inputHandlers[atc.SetTeamSettings]),
+				atc.GetTeamUsage:           authorized(inputHandlers[atc.GetTeamUsage]),
+				atc.ExportTeam:             authorized(inputHandlers[atc.ExportTeam]),
+				atc.ListWebhooks:           authorized(inputHandlers[atc.ListWebhooks]),
+				atc.CreateWebhook:          authorized(inputHandlers[atc.CreateWebhook]),
+				atc.DeleteWebhook:          authorized(inputHandlers[atc.DeleteWebhook]),
 			}
 		})
 
@@ -0,0 +1,70 @@
+package wrappa
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth"
+)
+
+const (
+	corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE"
+	corsAllowedHeaders = "Authorization, Content-Type, " + auth.CSRFHeaderName + ", " + atc.ConfigVersionHeader
+	corsExposedHeaders = "Link, " + concourseVersionHeader + ", " + atc.ConfigVersionHeader + ", " + atc.ConfigChecksumHeader
+)
+
+// CORSHandler allows browsers on the given origins to make requests to the
+// wrapped handler, answering preflight requests itself. An origin of "*"
+// allows any origin, but without credentials.
+type CORSHandler struct {
+	AllowedOrigins []string
+	Handler        http.Handler
+}
+
+func (handler CORSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+
+	allowed, anyOrigin := handler.allows(origin)
+	if !allowed {
+		handler.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	if anyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Add("Vary", "Origin")
+	}
+
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+		w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+	handler.Handler.ServeHTTP(w, r)
+}
+
+func (handler CORSHandler) allows(origin string) (bool, bool) {
+	if origin == "" {
+		return false, false
+	}
+
+	allowed := false
+	for _, allowedOrigin := range handler.AllowedOrigins {
+		if allowedOrigin == "*" {
+			return true, true
+		}
+
+		if strings.EqualFold(allowedOrigin, origin) {
+			allowed = true
+		}
+	}
+
+	return allowed, false
+}
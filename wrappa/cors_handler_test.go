@@ -0,0 +1,121 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/concourse/atc/wrappa"
+
+	"github.com/concourse/atc/wrappa/wrappafakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CORSHandler", func() {
+	var (
+		request *http.Request
+		rw      *httptest.ResponseRecorder
+
+		fakeHandler *wrappafakes.FakeHandler
+
+		corsHandler wrappa.CORSHandler
+	)
+
+	BeforeEach(func() {
+		rw = httptest.NewRecorder()
+		request = httptest.NewRequest("GET", "/api/v1/pipelines", nil)
+		request.Header.Set("Origin", "https://dashboard.example.com")
+
+		fakeHandler = new(wrappafakes.FakeHandler)
+
+		corsHandler = wrappa.CORSHandler{
+			AllowedOrigins: []string{"https://dashboard.example.com"},
+			Handler:        fakeHandler,
+		}
+	})
+
+	JustBeforeEach(func() {
+		corsHandler.ServeHTTP(rw, request)
+	})
+
+	Context("when the origin is allowed", func() {
+		It("allows the origin with credentials", func() {
+			Expect(rw.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://dashboard.example.com"))
+			Expect(rw.Header().Get("Access-Control-Allow-Credentials")).To(Equal("true"))
+			Expect(rw.Header().Get("Vary")).To(Equal("Origin"))
+		})
+
+		It("exposes the pagination and version headers", func() {
+			Expect(rw.Header().Get("Access-Control-Expose-Headers")).To(Equal("Link, X-Concourse-Version, X-Concourse-Config-Version, X-Concourse-Config-Checksum"))
+		})
+
+		It("serves the request", func() {
+			Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(1))
+		})
+
+		Context("when the request is a preflight", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest("OPTIONS", "/api/v1/pipelines", nil)
+				request.Header.Set("Origin", "https://dashboard.example.com")
+				request.Header.Set("Access-Control-Request-Method", "PUT")
+			})
+
+			It("responds with the allowed methods and headers", func() {
+				Expect(rw.Code).To(Equal(http.StatusOK))
+				Expect(rw.Header().Get("Access-Control-Allow-Methods")).To(Equal("GET, POST, PUT, PATCH, DELETE"))
+				Expect(rw.Header().Get("Access-Control-Allow-Headers")).To(Equal("Authorization, Content-Type, X-Csrf-Token, X-Concourse-Config-Version"))
+			})
+
+			It("does not serve the request", func() {
+				Expect(fakeHandler.ServeHTTPCallCount()).To(BeZero())
+			})
+
+			Context("when a PUT with a CSRF token and config version is requested", func() {
+				BeforeEach(func() {
+					request.Header.Set("Access-Control-Request-Headers", "content-type, x-csrf-token, x-concourse-config-version")
+				})
+
+				It("allows each of the requested headers", func() {
+					allowed := map[string]bool{}
+					for _, header := range strings.Split(rw.Header().Get("Access-Control-Allow-Headers"), ",") {
+						allowed[http.CanonicalHeaderKey(strings.TrimSpace(header))] = true
+					}
+
+					for _, header := range strings.Split(request.Header.Get("Access-Control-Request-Headers"), ",") {
+						Expect(allowed).To(HaveKey(http.CanonicalHeaderKey(strings.TrimSpace(header))))
+					}
+				})
+
+				It("allows the PUT method", func() {
+					Expect(strings.Split(rw.Header().Get("Access-Control-Allow-Methods"), ", ")).To(ContainElement(request.Header.Get("Access-Control-Request-Method")))
+				})
+			})
+		})
+	})
+
+	Context("when any origin is allowed", func() {
+		BeforeEach(func() {
+			corsHandler.AllowedOrigins = []string{"*"}
+		})
+
+		It("allows any origin without credentials", func() {
+			Expect(rw.Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+			Expect(rw.HeaderMap).NotTo(HaveKey("Access-Control-Allow-Credentials"))
+		})
+	})
+
+	Context("when the origin is not allowed", func() {
+		BeforeEach(func() {
+			request.Header.Set("Origin", "https://evil.example.com")
+		})
+
+		It("does not set any CORS headers", func() {
+			Expect(rw.HeaderMap).NotTo(HaveKey("Access-Control-Allow-Origin"))
+		})
+
+		It("serves the request", func() {
+			Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,18 +1,58 @@
 package wrappa
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 type SecurityHandler struct {
-	XFrameOptions string
-	Handler       http.Handler
+	XFrameOptions           string
+	StrictTransportSecurity string
+	ContentSecurityPolicy   string
+
+	// ExemptPaths are path prefixes which are served without X-Frame-Options
+	// or Content-Security-Policy, e.g. so that the dashboard can be embedded
+	ExemptPaths []string
+
+	// HTTPS is whether the ATC's external URL is HTTPS. TLS may be terminated
+	// in front of the ATC, so this is what decides whether to send
+	// Strict-Transport-Security, rather than how the request arrived.
+	HTTPS bool
+
+	Handler http.Handler
 }
 
 func (handler SecurityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if handler.XFrameOptions != "" {
-		w.Header().Set("X-Frame-Options", handler.XFrameOptions)
+	if !handler.isExempt(r.URL.Path) {
+		if handler.XFrameOptions != "" {
+			w.Header().Set("X-Frame-Options", handler.XFrameOptions)
+		}
+		if handler.ContentSecurityPolicy != "" {
+			w.Header().Set("Content-Security-Policy", handler.ContentSecurityPolicy)
+		}
 	}
+
+	// browsers ignore the header unless it is received over HTTPS
+	if handler.StrictTransportSecurity != "" && handler.HTTPS {
+		w.Header().Set("Strict-Transport-Security", handler.StrictTransportSecurity)
+	}
+
 	w.Header().Set("X-XSS-Protection", "1; mode=block")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.Header().Set("X-Download-Options", "noopen")
 	handler.Handler.ServeHTTP(w, r)
 }
+
+// isExempt matches whole path segments, so that exempting '/dashboard' does
+// not also exempt '/dashboards'.
+func (handler SecurityHandler) isExempt(path string) bool {
+	for _, prefix := range handler.ExemptPaths {
+		prefix = strings.TrimSuffix(prefix, "/")
+
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
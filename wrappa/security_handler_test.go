@@ -1,6 +1,7 @@
 package wrappa_test
 
 import (
+	"crypto/tls"
 	"net/http"
 	"net/http/httptest"
 
@@ -59,4 +60,85 @@ var _ = Describe("SecurityHandler", func() {
 			Expect(rw.Header().Get("X-Frame-Options")).To(Equal("some-x-frame-options"))
 		})
 	})
+
+	Context("when the Content-Security-Policy is non-empty", func() {
+		BeforeEach(func() {
+			securityHandler.ContentSecurityPolicy = "frame-ancestors 'none'"
+		})
+
+		It("sets the Content-Security-Policy", func() {
+			Expect(rw.Header().Get("Content-Security-Policy")).To(Equal("frame-ancestors 'none'"))
+		})
+	})
+
+	Context("when the path is exempt", func() {
+		BeforeEach(func() {
+			securityHandler.XFrameOptions = "deny"
+			securityHandler.ContentSecurityPolicy = "frame-ancestors 'none'"
+			securityHandler.ExemptPaths = []string{"/dashboard", "/some"}
+		})
+
+		It("sets neither the X-Frame-Options nor the Content-Security-Policy", func() {
+			Expect(rw.HeaderMap).NotTo(HaveKey("X-Frame-Options"))
+			Expect(rw.HeaderMap).NotTo(HaveKey("Content-Security-Policy"))
+		})
+
+		It("still sets the other security headers", func() {
+			Expect(rw.Header().Get("X-Content-Type-Options")).To(Equal("nosniff"))
+		})
+
+		Context("when the path only shares a prefix with an exempt path", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest("GET", "/dashboards", nil)
+			})
+
+			It("sets the X-Frame-Options and the Content-Security-Policy", func() {
+				Expect(rw.Header().Get("X-Frame-Options")).To(Equal("deny"))
+				Expect(rw.Header().Get("Content-Security-Policy")).To(Equal("frame-ancestors 'none'"))
+			})
+		})
+
+		Context("when the exempt path is given with a trailing slash", func() {
+			BeforeEach(func() {
+				securityHandler.ExemptPaths = []string{"/dashboard/"}
+				request = httptest.NewRequest("GET", "/dashboard", nil)
+			})
+
+			It("still exempts the path itself", func() {
+				Expect(rw.HeaderMap).NotTo(HaveKey("X-Frame-Options"))
+			})
+		})
+	})
+
+	Context("when the Strict-Transport-Security is non-empty", func() {
+		BeforeEach(func() {
+			securityHandler.StrictTransportSecurity = "max-age=31536000"
+		})
+
+		Context("when the external URL is HTTPS", func() {
+			BeforeEach(func() {
+				securityHandler.HTTPS = true
+			})
+
+			It("sets the Strict-Transport-Security", func() {
+				Expect(rw.Header().Get("Strict-Transport-Security")).To(Equal("max-age=31536000"))
+			})
+		})
+
+		Context("when the external URL is not HTTPS", func() {
+			Context("even if the request is over TLS", func() {
+				BeforeEach(func() {
+					request.TLS = &tls.ConnectionState{}
+				})
+
+				It("does not set the Strict-Transport-Security", func() {
+					Expect(rw.HeaderMap).NotTo(HaveKey("Strict-Transport-Security"))
+				})
+			})
+
+			It("does not set the Strict-Transport-Security", func() {
+				Expect(rw.HeaderMap).NotTo(HaveKey("Strict-Transport-Security"))
+			})
+		})
+	})
 })